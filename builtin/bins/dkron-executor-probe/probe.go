@@ -0,0 +1,478 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dkplugin "github.com/distribworks/dkron/v3/plugin"
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	// defaultTimeout of the probes.
+	defaultTimeout = 10 * time.Second
+	// defaultCount is the number of ICMP echo requests sent.
+	defaultCount = 3
+	// maxBodySize limits how much of the responses is read.
+	maxBodySize = 1 << 20
+)
+
+// Probe checks that a service answers in time and as expected, reporting
+// its latency and results as metrics of the execution.
+type Probe struct {
+}
+
+// result is the outcome of a probe.
+type result struct {
+	output  bytes.Buffer
+	metrics map[string]float64
+}
+
+func (r *result) printf(format string, a ...interface{}) {
+	fmt.Fprintf(&r.output, format+"\n", a...)
+}
+
+func (r *result) set(name string, v float64) {
+	r.metrics[name] = v
+}
+
+func (r *result) setLatency(d time.Duration) {
+	r.set("latency_ms", float64(d)/float64(time.Millisecond))
+}
+
+// Execute Process method of the plugin
+// "executor": "probe",
+// "executor_config": {
+//     "type": "http",                   // Probe type: http, tcp, icmp or dns
+//     "target": "https://example.com",  // URL, host:port, host or name to probe
+//     "timeout": "10s",                 // Timeout of the probe
+//     "maxLatency": "500ms",            // Fail when slower
+//     "expectStatus": "200,204",        // http: expected status codes, 2xx and 3xx by default
+//     "expectBody": "ok",               // http: regexp the body must match
+//     "expectHeader": "Content-Type: json" // http: header and regexp its value must match
+// }
+func (p *Probe) Execute(args *types.ExecuteRequest, cb dkplugin.StatusHelper) (*types.ExecuteResponse, error) {
+	r, err := p.ExecuteImpl(args)
+	resp := &types.ExecuteResponse{Output: r.output.Bytes(), Metrics: r.metrics}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp, nil
+}
+
+// ExecuteImpl runs the probe, the metrics are set even when it fails.
+func (p *Probe) ExecuteImpl(args *types.ExecuteRequest) (*result, error) {
+	r := &result{metrics: map[string]float64{"up": 0}}
+	config := args.Config
+
+	if config["target"] == "" {
+		return r, errors.New("target is empty")
+	}
+	timeout, err := durationOrDefault(config["timeout"], defaultTimeout)
+	if err != nil {
+		return r, fmt.Errorf("invalid timeout: %s", err)
+	}
+	maxLatency, err := durationOrDefault(config["maxLatency"], 0)
+	if err != nil {
+		return r, fmt.Errorf("invalid maxLatency: %s", err)
+	}
+
+	var latency time.Duration
+	switch config["type"] {
+	case "http":
+		latency, err = probeHTTP(config, timeout, r)
+	case "tcp":
+		latency, err = probeTCP(config, timeout, r)
+	case "icmp":
+		latency, err = probeICMP(config, timeout, r)
+	case "dns":
+		latency, err = probeDNS(config, timeout, r)
+	default:
+		return r, fmt.Errorf("unknown probe type %q, use http, tcp, icmp or dns", config["type"])
+	}
+	if err != nil {
+		r.printf("FAIL: %s", err)
+		return r, err
+	}
+
+	if maxLatency > 0 && latency > maxLatency {
+		err := fmt.Errorf("latency %s over the max latency %s", latency, maxLatency)
+		r.printf("FAIL: %s", err)
+		return r, err
+	}
+
+	r.set("up", 1)
+	r.printf("OK")
+	return r, nil
+}
+
+// probeHTTP sends the request and checks the status, body and headers of
+// the response.
+func probeHTTP(config map[string]string, timeout time.Duration, r *result) (time.Duration, error) {
+	method := config["method"]
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, config["target"], strings.NewReader(config["body"]))
+	if err != nil {
+		return 0, err
+	}
+	if config["headers"] != "" {
+		var headers []string
+		if err := json.Unmarshal([]byte(config["headers"]), &headers); err != nil {
+			return 0, fmt.Errorf("invalid headers: %s", err)
+		}
+		for _, h := range headers {
+			kv := strings.SplitN(h, ":", 2)
+			if len(kv) != 2 {
+				return 0, fmt.Errorf("invalid header %q", h)
+			}
+			req.Header.Set(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+		}
+	}
+
+	noVerify, _ := strconv.ParseBool(config["tlsNoVerifyPeer"])
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: noVerify},
+			DisableKeepAlives: true,
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	latency := time.Since(start)
+	if err != nil {
+		return latency, err
+	}
+
+	r.setLatency(latency)
+	r.set("status_code", float64(resp.StatusCode))
+	r.printf("%s %s: %s in %s", method, config["target"], resp.Status, latency)
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		left := time.Until(resp.TLS.PeerCertificates[0].NotAfter)
+		days := float64(left) / float64(24*time.Hour)
+		r.set("cert_expiry_days", days)
+		if config["minCertDays"] != "" {
+			min, err := strconv.ParseFloat(config["minCertDays"], 64)
+			if err != nil {
+				return latency, fmt.Errorf("invalid minCertDays: %s", err)
+			}
+			if days < min {
+				return latency, fmt.Errorf("certificate expires in %.1f days, less than %s", days, config["minCertDays"])
+			}
+		}
+	}
+
+	if !expectedStatus(config["expectStatus"], resp.StatusCode) {
+		return latency, fmt.Errorf("status %d not expected", resp.StatusCode)
+	}
+	if config["expectBody"] != "" {
+		re, err := regexp.Compile(config["expectBody"])
+		if err != nil {
+			return latency, fmt.Errorf("invalid expectBody: %s", err)
+		}
+		if !re.Match(body) {
+			return latency, errors.New("body does not match expectBody")
+		}
+	}
+	if config["expectHeader"] != "" {
+		kv := strings.SplitN(config["expectHeader"], ":", 2)
+		if len(kv) != 2 {
+			return latency, fmt.Errorf("invalid expectHeader %q, use Name: regexp", config["expectHeader"])
+		}
+		re, err := regexp.Compile(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return latency, fmt.Errorf("invalid expectHeader: %s", err)
+		}
+		name := strings.TrimSpace(kv[0])
+		if !re.MatchString(resp.Header.Get(name)) {
+			return latency, fmt.Errorf("header %s does not match expectHeader", name)
+		}
+	}
+	return latency, nil
+}
+
+// expectedStatus returns whether the status is in the comma separated
+// list of codes, any 2xx or 3xx status when empty.
+func expectedStatus(expect string, status int) bool {
+	if expect == "" {
+		return status >= 200 && status < 400
+	}
+	for _, code := range strings.Split(expect, ",") {
+		if strings.TrimSpace(code) == strconv.Itoa(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// probeTCP connects to the target, sends the data if any and checks the
+// answer. The latency is the time to connect.
+func probeTCP(config map[string]string, timeout time.Duration, r *result) (time.Duration, error) {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", config["target"], timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	latency := time.Since(start)
+	r.setLatency(latency)
+	r.printf("connected to %s in %s", config["target"], latency)
+
+	conn.SetDeadline(start.Add(timeout))
+	if config["send"] != "" {
+		if _, err := conn.Write([]byte(config["send"])); err != nil {
+			return latency, err
+		}
+	}
+	if config["expect"] != "" {
+		re, err := regexp.Compile(config["expect"])
+		if err != nil {
+			return latency, fmt.Errorf("invalid expect: %s", err)
+		}
+		buf := make([]byte, 4096)
+		n, err := conn.Read(buf)
+		if err != nil && n == 0 {
+			return latency, fmt.Errorf("reading the answer: %s", err)
+		}
+		if !re.Match(buf[:n]) {
+			return latency, errors.New("answer does not match expect")
+		}
+	}
+	return latency, nil
+}
+
+// probeICMP pings the target, without privileges when the system allows
+// unprivileged ICMP sockets. The latency is the average round trip time.
+func probeICMP(config map[string]string, timeout time.Duration, r *result) (time.Duration, error) {
+	count, err := intOrDefault(config["count"], defaultCount)
+	if err != nil || count < 1 {
+		return 0, fmt.Errorf("invalid count %q", config["count"])
+	}
+	maxLoss, err := floatOrDefault(config["maxLoss"], 0)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxLoss: %s", err)
+	}
+
+	dst, err := net.ResolveIPAddr("ip", config["target"])
+	if err != nil {
+		return 0, err
+	}
+	network, proto, echoType, replyType := "udp4", 1, icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply)
+	if dst.IP.To4() == nil {
+		network, proto, echoType, replyType = "udp6", 58, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+
+	privileged := false
+	conn, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		raw := "ip4:icmp"
+		if proto == 58 {
+			raw = "ip6:ipv6-icmp"
+		}
+		var rawErr error
+		if conn, rawErr = icmp.ListenPacket(raw, ""); rawErr != nil {
+			return 0, fmt.Errorf("opening ICMP socket: %s", err)
+		}
+		privileged = true
+	}
+	defer conn.Close()
+
+	var addr net.Addr = &net.UDPAddr{IP: dst.IP, Zone: dst.Zone}
+	if privileged {
+		addr = dst
+	}
+
+	id := os.Getpid() & 0xffff
+	var rtts []time.Duration
+	for seq := 1; seq <= count; seq++ {
+		msg := icmp.Message{Type: echoType, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("dkron-probe")}}
+		b, err := msg.Marshal(nil)
+		if err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		if _, err := conn.WriteTo(b, addr); err != nil {
+			return 0, err
+		}
+		if rtt, ok := readEchoReply(conn, proto, replyType, seq, start.Add(timeout)); ok {
+			rtts = append(rtts, rtt)
+		}
+	}
+
+	loss := float64(count-len(rtts)) / float64(count) * 100
+	r.set("packet_loss", loss)
+	r.printf("%d packets sent to %s, %d received, %.0f%% loss", count, dst, len(rtts), loss)
+	if len(rtts) == 0 {
+		return 0, fmt.Errorf("no echo reply from %s", dst)
+	}
+
+	var sum time.Duration
+	sort.Slice(rtts, func(i, j int) bool { return rtts[i] < rtts[j] })
+	for _, rtt := range rtts {
+		sum += rtt
+	}
+	latency := sum / time.Duration(len(rtts))
+	r.setLatency(latency)
+	r.set("rtt_min_ms", float64(rtts[0])/float64(time.Millisecond))
+	r.set("rtt_max_ms", float64(rtts[len(rtts)-1])/float64(time.Millisecond))
+	r.printf("rtt min/avg/max %s/%s/%s", rtts[0], latency, rtts[len(rtts)-1])
+
+	if loss > maxLoss {
+		return latency, fmt.Errorf("packet loss %.0f%% over the max loss %s%%", loss, strconv.FormatFloat(maxLoss, 'f', -1, 64))
+	}
+	return latency, nil
+}
+
+// readEchoReply waits for the reply to the echo request until the
+// deadline. The ID of unprivileged sockets is set by the system, replies
+// are matched by sequence only.
+func readEchoReply(conn *icmp.PacketConn, proto int, replyType icmp.Type, seq int, deadline time.Time) (time.Duration, bool) {
+	start := time.Now()
+	buf := make([]byte, 1500)
+	for {
+		conn.SetReadDeadline(deadline)
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, false
+		}
+		msg, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil || msg.Type != replyType {
+			continue
+		}
+		if echo, ok := msg.Body.(*icmp.Echo); ok && echo.Seq == seq {
+			return time.Since(start), true
+		}
+	}
+}
+
+// probeDNS resolves the record of the target and checks the answers.
+func probeDNS(config map[string]string, timeout time.Duration, r *result) (time.Duration, error) {
+	resolver := net.DefaultResolver
+	if server := config["server"]; server != "" {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	recordType := strings.ToUpper(config["recordType"])
+	if recordType == "" {
+		recordType = "A"
+	}
+	name := config["target"]
+
+	start := time.Now()
+	var answers []string
+	var err error
+	switch recordType {
+	case "A", "AAAA":
+		var addrs []net.IPAddr
+		addrs, err = resolver.LookupIPAddr(ctx, name)
+		for _, a := range addrs {
+			if (a.IP.To4() != nil) == (recordType == "A") {
+				answers = append(answers, a.IP.String())
+			}
+		}
+	case "CNAME":
+		var cname string
+		cname, err = resolver.LookupCNAME(ctx, name)
+		answers = append(answers, cname)
+	case "MX":
+		var mxs []*net.MX
+		mxs, err = resolver.LookupMX(ctx, name)
+		for _, mx := range mxs {
+			answers = append(answers, fmt.Sprintf("%d %s", mx.Pref, mx.Host))
+		}
+	case "NS":
+		var nss []*net.NS
+		nss, err = resolver.LookupNS(ctx, name)
+		for _, ns := range nss {
+			answers = append(answers, ns.Host)
+		}
+	case "TXT":
+		answers, err = resolver.LookupTXT(ctx, name)
+	default:
+		return 0, fmt.Errorf("unknown recordType %q, use A, AAAA, CNAME, MX, NS or TXT", recordType)
+	}
+	latency := time.Since(start)
+	if err != nil {
+		return latency, err
+	}
+
+	r.setLatency(latency)
+	r.set("answers", float64(len(answers)))
+	r.printf("%s %s: %s in %s", recordType, name, strings.Join(answers, ", "), latency)
+	if len(answers) == 0 {
+		return latency, fmt.Errorf("no %s record for %s", recordType, name)
+	}
+
+	if config["expectAnswer"] != "" {
+		re, err := regexp.Compile(config["expectAnswer"])
+		if err != nil {
+			return latency, fmt.Errorf("invalid expectAnswer: %s", err)
+		}
+		for _, a := range answers {
+			if re.MatchString(a) {
+				return latency, nil
+			}
+		}
+		return latency, errors.New("no answer matches expectAnswer")
+	}
+	return latency, nil
+}
+
+func durationOrDefault(s string, d time.Duration) (time.Duration, error) {
+	if s == "" {
+		return d, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func intOrDefault(s string, d int) (int, error) {
+	if s == "" {
+		return d, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func floatOrDefault(s string, d float64) (float64, error) {
+	if s == "" {
+		return d, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, config map[string]string) *types.ExecuteResponse {
+	p := &Probe{}
+	resp, err := p.Execute(&types.ExecuteRequest{JobName: "testJob", Config: config}, nil)
+	require.NoError(t, err)
+	fmt.Println(string(resp.Output))
+	return resp
+}
+
+func TestProbeHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer ts.Close()
+
+	resp := probe(t, map[string]string{
+		"type":         "http",
+		"target":       ts.URL,
+		"expectStatus": "200,204",
+		"expectBody":   `"status":"ok"`,
+		"expectHeader": "Content-Type: json",
+	})
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, float64(1), resp.Metrics["up"])
+	assert.Equal(t, float64(200), resp.Metrics["status_code"])
+	assert.Contains(t, resp.Metrics, "latency_ms")
+
+	resp = probe(t, map[string]string{
+		"type":       "http",
+		"target":     ts.URL,
+		"expectBody": "down",
+	})
+	assert.Equal(t, "body does not match expectBody", resp.Error)
+	assert.Equal(t, float64(0), resp.Metrics["up"])
+	assert.Equal(t, float64(200), resp.Metrics["status_code"])
+
+	resp = probe(t, map[string]string{
+		"type":         "http",
+		"target":       ts.URL,
+		"expectStatus": "204",
+	})
+	assert.Equal(t, "status 200 not expected", resp.Error)
+
+	resp = probe(t, map[string]string{
+		"type":       "http",
+		"target":     ts.URL + "/slow",
+		"maxLatency": "10ms",
+	})
+	assert.Contains(t, resp.Error, "over the max latency 10ms")
+	assert.Equal(t, float64(0), resp.Metrics["up"])
+	assert.True(t, resp.Metrics["latency_ms"] >= 50)
+}
+
+func TestProbeTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 16)
+			n, _ := conn.Read(buf)
+			conn.Write(append([]byte("+"), buf[:n]...))
+			conn.Close()
+		}
+	}()
+
+	resp := probe(t, map[string]string{
+		"type":   "tcp",
+		"target": l.Addr().String(),
+		"send":   "PING\r\n",
+		"expect": `^\+PING`,
+	})
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, float64(1), resp.Metrics["up"])
+
+	resp = probe(t, map[string]string{
+		"type":   "tcp",
+		"target": l.Addr().String(),
+		"send":   "PING\r\n",
+		"expect": "PONG",
+	})
+	assert.Equal(t, "answer does not match expect", resp.Error)
+
+	addr := l.Addr().String()
+	l.Close()
+	resp = probe(t, map[string]string{
+		"type":   "tcp",
+		"target": addr,
+	})
+	assert.NotEmpty(t, resp.Error)
+	assert.Equal(t, float64(0), resp.Metrics["up"])
+}
+
+func TestProbeDNS(t *testing.T) {
+	resp := probe(t, map[string]string{
+		"type":         "dns",
+		"target":       "localhost",
+		"expectAnswer": `^127\.`,
+	})
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, float64(1), resp.Metrics["up"])
+	assert.True(t, resp.Metrics["answers"] >= 1)
+
+	resp = probe(t, map[string]string{
+		"type":       "dns",
+		"target":     "localhost",
+		"recordType": "SRV",
+	})
+	assert.Contains(t, resp.Error, "unknown recordType")
+}
+
+func TestProbeICMP(t *testing.T) {
+	resp := probe(t, map[string]string{
+		"type":    "icmp",
+		"target":  "127.0.0.1",
+		"count":   "2",
+		"timeout": "1s",
+	})
+	if resp.Metrics["up"] == 0 {
+		t.Skipf("ICMP not allowed: %s", resp.Error)
+	}
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, float64(0), resp.Metrics["packet_loss"])
+}
+
+func TestProbeConfig(t *testing.T) {
+	resp := probe(t, map[string]string{"type": "http"})
+	assert.Equal(t, "target is empty", resp.Error)
+
+	resp = probe(t, map[string]string{"type": "smtp", "target": "localhost:25"})
+	assert.Contains(t, resp.Error, "unknown probe type")
+
+	resp = probe(t, map[string]string{"type": "tcp", "target": "localhost:25", "timeout": "soon"})
+	assert.Contains(t, resp.Error, "invalid timeout")
+}
@@ -0,0 +1,308 @@
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// defaultCgroupParent is the cgroup v2 directory under which a cgroup is
+	// created for every execution that sets cpu or memory limits.
+	defaultCgroupParent = "/sys/fs/cgroup/dkron"
+
+	// cpuPeriod is the cgroup cpu.max period in microseconds.
+	cpuPeriod = 100000
+
+	ioprioClassShift = 13
+	ioprioWhoProcess = 1
+)
+
+var rlimitResources = map[string]int{
+	"core":    unix.RLIMIT_CORE,
+	"cpu":     unix.RLIMIT_CPU,
+	"data":    unix.RLIMIT_DATA,
+	"fsize":   unix.RLIMIT_FSIZE,
+	"nofile":  unix.RLIMIT_NOFILE,
+	"stack":   unix.RLIMIT_STACK,
+	"as":      unix.RLIMIT_AS,
+	"nproc":   unix.RLIMIT_NPROC,
+	"memlock": unix.RLIMIT_MEMLOCK,
+}
+
+var ioprioClasses = map[string]int{
+	"realtime":    1,
+	"best-effort": 2,
+	"idle":        3,
+}
+
+// limitSpec are the limits the re-executed plugin applies to itself before
+// running the command, along with the user to run it as.
+type limitSpec struct {
+	Nice       *int                `json:"nice,omitempty"`
+	Ionice     int                 `json:"ionice,omitempty"`
+	Ulimits    map[int]uint64      `json:"ulimits,omitempty"`
+	Cgroup     string              `json:"cgroup,omitempty"`
+	Credential *syscall.Credential `json:"credential,omitempty"`
+}
+
+// wrapLimits makes the command run through the plugin itself, which sets the
+// priority, rlimits and cgroup limits defined in the executor config on its
+// own process and then executes the command, so the command is limited from
+// its first instruction. It returns a function that must be called once the
+// process has exited to release resources.
+func wrapLimits(cmd *exec.Cmd, jobName string, config map[string]string) (func(), error) {
+	release := func() {}
+
+	var spec limitSpec
+	limited := false
+
+	if v := config["nice"]; v != "" {
+		nice, err := strconv.Atoi(v)
+		if err != nil || nice < -20 || nice > 19 {
+			return release, fmt.Errorf("shell: invalid nice value %q, must be between -20 and 19", v)
+		}
+		spec.Nice = &nice
+		limited = true
+	}
+
+	if v := config["ionice"]; v != "" {
+		prio, err := parseIonice(v)
+		if err != nil {
+			return release, err
+		}
+		spec.Ionice = prio
+		limited = true
+	}
+
+	if v := config["ulimit"]; v != "" {
+		limits, err := parseUlimits(v)
+		if err != nil {
+			return release, err
+		}
+		spec.Ulimits = limits
+		limited = true
+	}
+
+	if config["cpu_limit"] != "" || config["memory_limit"] != "" {
+		dir, err := setupCgroup(jobName, config)
+		if err != nil {
+			return release, err
+		}
+		release = func() {
+			os.Remove(dir)
+		}
+		spec.Cgroup = dir
+		limited = true
+	}
+
+	if !limited {
+		return release, nil
+	}
+
+	// The plugin applies the limits with its own privileges, then runs the
+	// command as the user
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Credential != nil {
+		spec.Credential = cmd.SysProcAttr.Credential
+		cmd.SysProcAttr.Credential = nil
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		release()
+		return func() {}, fmt.Errorf("shell: error finding the plugin executable: %s", err)
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		release()
+		return func() {}, err
+	}
+	cmd.Args = append([]string{self, limitsArg, string(b), cmd.Path}, cmd.Args...)
+	cmd.Path = self
+
+	return release, nil
+}
+
+// runLimited applies the limits of the spec to the process and executes the
+// command, the arguments are the spec, the path of the command and its
+// arguments. It only returns on errors, with the exit code.
+func runLimited(args []string) int {
+	// The priorities are set on the thread, the one executing the command
+	runtime.LockOSThread()
+
+	if err := execLimited(args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return 126
+}
+
+func execLimited(args []string) error {
+	if len(args) < 3 {
+		return errors.New("shell: missing command to limit")
+	}
+	var spec limitSpec
+	if err := json.Unmarshal([]byte(args[0]), &spec); err != nil {
+		return fmt.Errorf("shell: invalid limits: %s", err)
+	}
+
+	if spec.Nice != nil {
+		if err := unix.Setpriority(unix.PRIO_PROCESS, 0, *spec.Nice); err != nil {
+			return fmt.Errorf("shell: error setting nice: %s", err)
+		}
+	}
+
+	if spec.Ionice != 0 {
+		if _, _, errno := unix.Syscall(unix.SYS_IOPRIO_SET, ioprioWhoProcess, 0, uintptr(spec.Ionice)); errno != 0 {
+			return fmt.Errorf("shell: error setting ionice: %s", errno)
+		}
+	}
+
+	for res, lim := range spec.Ulimits {
+		if err := unix.Setrlimit(res, &unix.Rlimit{Cur: lim, Max: lim}); err != nil {
+			return fmt.Errorf("shell: error setting ulimit: %s", err)
+		}
+	}
+
+	if spec.Cgroup != "" {
+		if err := ioutil.WriteFile(filepath.Join(spec.Cgroup, "cgroup.procs"), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+			return fmt.Errorf("shell: error moving process to cgroup: %s", err)
+		}
+	}
+
+	if c := spec.Credential; c != nil {
+		if err := syscall.Setgroups(nil); err != nil {
+			return fmt.Errorf("shell: error setting groups: %s", err)
+		}
+		if err := syscall.Setgid(int(c.Gid)); err != nil {
+			return fmt.Errorf("shell: error setting gid: %s", err)
+		}
+		if err := syscall.Setuid(int(c.Uid)); err != nil {
+			return fmt.Errorf("shell: error setting uid: %s", err)
+		}
+	}
+
+	return syscall.Exec(args[1], args[2:], os.Environ())
+}
+
+// parseIonice parses an ionice spec in the form "class[:level]" where class
+// is realtime, best-effort or idle and level goes from 0 (highest) to 7.
+func parseIonice(spec string) (int, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	class, ok := ioprioClasses[parts[0]]
+	if !ok {
+		return 0, fmt.Errorf("shell: invalid ionice class %q, use realtime, best-effort or idle", parts[0])
+	}
+	level := 0
+	if len(parts) == 2 {
+		l, err := strconv.Atoi(parts[1])
+		if err != nil || l < 0 || l > 7 {
+			return 0, fmt.Errorf("shell: invalid ionice level %q, must be between 0 and 7", parts[1])
+		}
+		level = l
+	}
+	return class<<ioprioClassShift | level, nil
+}
+
+// parseUlimits parses a comma separated list of resource=value pairs,
+// e.g. "nofile=1024,nproc=64".
+func parseUlimits(spec string) (map[int]uint64, error) {
+	limits := make(map[int]uint64)
+	for _, l := range strings.Split(spec, ",") {
+		kv := strings.SplitN(strings.TrimSpace(l), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("shell: invalid ulimit %q, use resource=value", l)
+		}
+		res, ok := rlimitResources[kv[0]]
+		if !ok {
+			return nil, fmt.Errorf("shell: unknown ulimit resource %q", kv[0])
+		}
+		v, err := parseSize(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("shell: invalid ulimit value %q: %s", kv[1], err)
+		}
+		limits[res] = v
+	}
+	return limits, nil
+}
+
+// parseSize parses a number with an optional K, M or G suffix.
+func parseSize(s string) (uint64, error) {
+	mult := uint64(1)
+	switch {
+	case strings.HasSuffix(s, "K"):
+		mult = 1 << 10
+	case strings.HasSuffix(s, "M"):
+		mult = 1 << 20
+	case strings.HasSuffix(s, "G"):
+		mult = 1 << 30
+	}
+	if mult > 1 {
+		s = s[:len(s)-1]
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v * mult, nil
+}
+
+// setupCgroup creates a cgroup v2 for the execution and sets the configured
+// cpu and memory limits, the process moves itself into it.
+func setupCgroup(jobName string, config map[string]string) (string, error) {
+	parent := config["cgroup_parent"]
+	if parent == "" {
+		parent = defaultCgroupParent
+	}
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", fmt.Errorf("shell: error creating cgroup parent: %s", err)
+	}
+	// Enabling controllers might fail if they are already enabled or
+	// delegated by the system, setting the limits below will tell.
+	ioutil.WriteFile(filepath.Join(parent, "cgroup.subtree_control"), []byte("+cpu +memory"), 0644)
+
+	dir := filepath.Join(parent, fmt.Sprintf("%s-%d", jobName, time.Now().UnixNano()))
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return "", fmt.Errorf("shell: error creating cgroup: %s", err)
+	}
+
+	if v := config["cpu_limit"]; v != "" {
+		cpus, err := strconv.ParseFloat(v, 64)
+		if err != nil || cpus <= 0 {
+			os.Remove(dir)
+			return "", fmt.Errorf("shell: invalid cpu_limit %q, must be a positive number of cpus", v)
+		}
+		max := fmt.Sprintf("%d %d", int(cpus*cpuPeriod), cpuPeriod)
+		if err := ioutil.WriteFile(filepath.Join(dir, "cpu.max"), []byte(max), 0644); err != nil {
+			os.Remove(dir)
+			return "", fmt.Errorf("shell: error setting cpu_limit: %s", err)
+		}
+	}
+
+	if v := config["memory_limit"]; v != "" {
+		mem, err := parseSize(v)
+		if err != nil {
+			os.Remove(dir)
+			return "", fmt.Errorf("shell: invalid memory_limit %q: %s", v, err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "memory.max"), []byte(strconv.FormatUint(mem, 10)), 0644); err != nil {
+			os.Remove(dir)
+			return "", fmt.Errorf("shell: error setting memory_limit: %s", err)
+		}
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,69 @@
+// +build linux
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	dktypes "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// TestMain runs the limited commands like the plugin, the test binary is
+// the executable wrapping them.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == limitsArg {
+		os.Exit(runLimited(os.Args[2:]))
+	}
+	os.Exit(m.Run())
+}
+
+func Test_parseUlimits(t *testing.T) {
+	limits, err := parseUlimits("nofile=1024, as=1G")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1024), limits[unix.RLIMIT_NOFILE])
+	assert.Equal(t, uint64(1<<30), limits[unix.RLIMIT_AS])
+
+	_, err = parseUlimits("nofile")
+	assert.Error(t, err)
+
+	_, err = parseUlimits("foo=1")
+	assert.Error(t, err)
+}
+
+func Test_parseIonice(t *testing.T) {
+	prio, err := parseIonice("best-effort:7")
+	assert.NoError(t, err)
+	assert.Equal(t, 2<<ioprioClassShift|7, prio)
+
+	prio, err = parseIonice("idle")
+	assert.NoError(t, err)
+	assert.Equal(t, 3<<ioprioClassShift, prio)
+
+	_, err = parseIonice("best-effort:8")
+	assert.Error(t, err)
+}
+
+func Test_wrapLimitsInvalidNice(t *testing.T) {
+	_, err := wrapLimits(exec.Command("true"), "test", map[string]string{"nice": "42"})
+	assert.Error(t, err)
+}
+
+func TestExecuteImpl_limits(t *testing.T) {
+	// The limits are set before the command starts
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "limited",
+		Config: map[string]string{
+			"command": "ulimit -n; nice",
+			"shell":   "true",
+			"ulimit":  "nofile=64",
+			"nice":    "5",
+		},
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "64\n5\n", string(out))
+}
@@ -0,0 +1,26 @@
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+)
+
+var limitKeys = []string{"nice", "ionice", "ulimit", "cpu_limit", "memory_limit"}
+
+// wrapLimits is only supported on linux, fail if any limit is requested
+// so jobs don't silently run unrestricted.
+func wrapLimits(cmd *exec.Cmd, jobName string, config map[string]string) (func(), error) {
+	for _, k := range limitKeys {
+		if config[k] != "" {
+			return func() {}, errors.New("shell: resource limits are only supported on linux")
+		}
+	}
+	return func() {}, nil
+}
+
+// runLimited is never called as commands aren't wrapped.
+func runLimited(args []string) int {
+	return 126
+}
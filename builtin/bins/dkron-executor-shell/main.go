@@ -1,11 +1,18 @@
 package main
 
 import (
+	"os"
+
 	dkplugin "github.com/distribworks/dkron/v3/plugin"
 	"github.com/hashicorp/go-plugin"
 )
 
 func main() {
+	// Commands with limits run through the plugin, see wrapLimits
+	if len(os.Args) > 1 && os.Args[1] == limitsArg {
+		os.Exit(runLimited(os.Args[2:]))
+	}
+
 	plugin.Serve(&plugin.ServeConfig{
 		HandshakeConfig: dkplugin.Handshake,
 		Plugins: map[string]plugin.Plugin{
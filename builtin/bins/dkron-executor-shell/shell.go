@@ -1,18 +1,26 @@
 package main
 
 import (
+	"bytes"
 	"encoding/base64"
+	"fmt"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/armon/circbuf"
 	dkplugin "github.com/distribworks/dkron/v3/plugin"
 	dktypes "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/mattn/go-shellwords"
 )
 
@@ -23,8 +31,16 @@ const (
 	// This is to prevent Serf's memory from growing to an enormous
 	// amount due to a faulty handler.
 	maxBufSize = 256000
+
+	// limitsArg is the first argument of the plugin executed to run a
+	// command with resource limits.
+	limitsArg = "dkron-shell-limits"
 )
 
+// paramEnvChars are the characters of a param name replaced in its
+// environment variable.
+var paramEnvChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
 // reportingWriter This is a Writer implementation that writes back to the host
 type reportingWriter struct {
 	buffer  *circbuf.Buffer
@@ -47,6 +63,13 @@ func (s *Shell) Execute(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelper)
 	if err != nil {
 		resp.Error = err.Error()
 	}
+	if args.ArtifactsDir != "" {
+		artifacts, aerr := listArtifacts(args.ArtifactsDir)
+		if aerr != nil {
+			log.Printf("shell: Error listing artifacts: %s", aerr)
+		}
+		resp.Artifacts = artifacts
+	}
 	return resp, nil
 }
 
@@ -61,6 +84,31 @@ func (s *Shell) ExecuteImpl(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelp
 	command := args.Config["command"]
 	env := strings.Split(args.Config["env"], ",")
 	cwd := args.Config["cwd"]
+	// Every execution has a workspace, the default cwd and temporary
+	// directory, shared by the steps of composite jobs
+	if args.WorkspaceDir != "" {
+		env = append([]string{"TMPDIR=" + args.WorkspaceDir}, env...)
+		env = append(env, "DKRON_WORKSPACE="+args.WorkspaceDir)
+		if cwd == "" {
+			cwd = args.WorkspaceDir
+		}
+	}
+	if args.ArtifactsDir != "" {
+		env = append(env, "DKRON_ARTIFACTS_DIR="+args.ArtifactsDir)
+	}
+	env = append(env, scheduleEnv(args)...)
+	if args.Namespace != "" {
+		env = append(env, "DKRON_NAMESPACE="+args.Namespace)
+	}
+	// The token of the namespace in the KV store
+	if args.KvToken != "" {
+		env = append(env, "DKRON_KV_TOKEN="+args.KvToken)
+	}
+	env = append(env, paramsEnv(args)...)
+	// Secrets read from Vault, named by the job
+	for k, v := range args.Secrets {
+		env = append(env, k+"="+v)
+	}
 
 	cmd, err := buildCmd(command, shell, env, cwd)
 	if err != nil {
@@ -70,6 +118,11 @@ func (s *Shell) ExecuteImpl(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelp
 	if err != nil {
 		return nil, err
 	}
+	release, err := wrapLimits(cmd, args.JobName, args.Config)
+	defer release()
+	if err != nil {
+		return nil, err
+	}
 	// use same buffer for both channels, for the full return at the end
 	cmd.Stderr = reportingWriter{buffer: output, cb: cb, isError: true}
 	cmd.Stdout = reportingWriter{buffer: output, cb: cb}
@@ -80,23 +133,14 @@ func (s *Shell) ExecuteImpl(args *dktypes.ExecuteRequest, cb dkplugin.StatusHelp
 	})
 	defer slowTimer.Stop()
 
-	stdin, err := cmd.StdinPipe()
-	if err != nil {
-		return nil, err
-	}
-
-	defer stdin.Close()
-
-	payload, err := base64.StdEncoding.DecodeString(args.Config["payload"])
+	payload, err := buildPayload(args)
 	if err != nil {
 		return nil, err
 	}
-
-	stdin.Write(payload)
-	stdin.Close()
+	cmd.Stdin = bytes.NewReader(payload)
 
 	log.Printf("shell: going to run %s", command)
-	err = cmd.Start()
+	err = startCmd(cmd, args.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -140,3 +184,87 @@ func buildCmd(command string, useShell bool, env []string, cwd string) (cmd *exe
 	cmd.Dir = cwd
 	return
 }
+
+// listArtifacts returns the files the command left in the artifacts directory.
+func listArtifacts(dir string) ([]string, error) {
+	var artifacts []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			artifacts = append(artifacts, path)
+		}
+		return nil
+	})
+	return artifacts, err
+}
+
+// scheduleEnv returns the environment variables with the time the job was
+// scheduled at and the previous time it was, in RFC3339 and UTC.
+func scheduleEnv(args *dktypes.ExecuteRequest) []string {
+	var env []string
+	if t := timestampTime(args.ScheduledTime); !t.IsZero() {
+		env = append(env, "DKRON_SCHEDULED_TIME="+t.Format(time.RFC3339))
+	}
+	if t := timestampTime(args.PreviousScheduledTime); !t.IsZero() {
+		env = append(env, "DKRON_PREVIOUS_SCHEDULED_TIME="+t.Format(time.RFC3339))
+	}
+	return env
+}
+
+// paramsEnv returns the environment variables with the params of the
+// execution, like the member of a member trigger, named after the param
+// uppercased with the DKRON_PARAM_ prefix.
+func paramsEnv(args *dktypes.ExecuteRequest) []string {
+	var env []string
+	for k, v := range args.Params {
+		name := paramEnvChars.ReplaceAllString(strings.ToUpper(k), "_")
+		env = append(env, "DKRON_PARAM_"+name+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// timestampTime returns the time of the timestamp in UTC, or the zero time if unset.
+func timestampTime(ts *timestamp.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// payloadData is the data available to the stdin template.
+type payloadData struct {
+	JobName               string
+	Config                map[string]string
+	ScheduledTime         time.Time
+	PreviousScheduledTime time.Time
+}
+
+// buildPayload returns the data to feed to the command stdin, either the
+// rendered "stdin" template or the base64 encoded "payload".
+func buildPayload(args *dktypes.ExecuteRequest) ([]byte, error) {
+	if tmpl := args.Config["stdin"]; tmpl != "" {
+		t, err := template.New("stdin").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("shell: error parsing stdin template: %s", err)
+		}
+		var out bytes.Buffer
+		if err := t.Execute(&out, payloadData{
+			JobName:               args.JobName,
+			Config:                args.Config,
+			ScheduledTime:         timestampTime(args.ScheduledTime),
+			PreviousScheduledTime: timestampTime(args.PreviousScheduledTime),
+		}); err != nil {
+			return nil, fmt.Errorf("shell: error executing stdin template: %s", err)
+		}
+		return out.Bytes(), nil
+	}
+
+	return base64.StdEncoding.DecodeString(args.Config["payload"])
+}
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	dktypes "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,3 +40,163 @@ func Test_buildCmdWithCustomEnvironmentVariables(t *testing.T) {
 	assert.Equal(t, "Toto\nHo\n", string(out))
 
 }
+
+type statusHelperMock struct{}
+
+func (statusHelperMock) Update([]byte, bool) (int64, error) { return 0, nil }
+
+func TestExecuteImpl_stdinTemplate(t *testing.T) {
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "myjob",
+		Config: map[string]string{
+			"command": "cat",
+			"stdin":   "job={{.JobName}} table={{index .Config \"table\"}}",
+			"table":   "users",
+		},
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "job=myjob table=users", string(out))
+}
+
+func TestExecuteImpl_scheduledTime(t *testing.T) {
+	scheduled, _ := ptypes.TimestampProto(time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC))
+	previous, _ := ptypes.TimestampProto(time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC))
+
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "etl",
+		Config: map[string]string{
+			"command": "echo $DKRON_PREVIOUS_SCHEDULED_TIME $DKRON_SCHEDULED_TIME",
+			"shell":   "true",
+		},
+		ScheduledTime:         scheduled,
+		PreviousScheduledTime: previous,
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2020-05-14T00:00:00Z 2020-05-15T00:00:00Z\n", string(out))
+
+	out, err = s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "etl",
+		Config: map[string]string{
+			"command": "cat",
+			"stdin":   "{{.ScheduledTime.Format \"2006-01-02\"}}",
+		},
+		ScheduledTime: scheduled,
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2020-05-15", string(out))
+}
+
+func TestExecuteImpl_cwdAndUmask(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-shell")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "myjob",
+		Config: map[string]string{
+			"shell":   "true",
+			"command": "umask && pwd",
+			"cwd":     dir,
+			"umask":   "027",
+		},
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "0027\n"+dir+"\n", string(out))
+
+	_, err = s.ExecuteImpl(&dktypes.ExecuteRequest{
+		Config: map[string]string{"command": "true", "umask": "999"},
+	}, statusHelperMock{})
+	assert.Error(t, err)
+}
+
+func TestExecute_artifacts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-artifacts")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &Shell{}
+	resp, err := s.Execute(&dktypes.ExecuteRequest{
+		JobName: "myjob",
+		Config: map[string]string{
+			"shell":   "true",
+			"command": "echo done > $DKRON_ARTIFACTS_DIR/report.txt && mkdir $DKRON_ARTIFACTS_DIR/logs && touch $DKRON_ARTIFACTS_DIR/logs/run.log",
+		},
+		ArtifactsDir: dir,
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Error)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "logs", "run.log"),
+		filepath.Join(dir, "report.txt"),
+	}, resp.Artifacts)
+}
+
+func TestExecuteImpl_workspace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-workspace-")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "pipeline",
+		Config: map[string]string{
+			"command": "echo step > out.txt && cat $DKRON_WORKSPACE/out.txt",
+			"shell":   "true",
+		},
+		WorkspaceDir: dir,
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "step\n", string(out))
+}
+
+func TestExecuteImpl_kvToken(t *testing.T) {
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "sync",
+		Config: map[string]string{
+			"command": "echo $DKRON_NAMESPACE $DKRON_KV_TOKEN",
+			"shell":   "true",
+		},
+		Namespace: "billing",
+		KvToken:   "secret",
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "billing secret\n", string(out))
+}
+
+func TestExecuteImpl_params(t *testing.T) {
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "provision",
+		Config: map[string]string{
+			"command": "echo $DKRON_PARAM_MEMBER_NAME $DKRON_PARAM_MEMBER_TAG_ROLE $DKRON_PARAM_MEMBER_TAG_DC_ZONE",
+			"shell":   "true",
+		},
+		Params: map[string]string{
+			"member_name":        "web3",
+			"member_tag_role":    "web",
+			"member_tag_dc-zone": "eu1",
+		},
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "web3 web eu1\n", string(out))
+}
+
+func TestExecuteImpl_secrets(t *testing.T) {
+	s := &Shell{}
+	out, err := s.ExecuteImpl(&dktypes.ExecuteRequest{
+		JobName: "report",
+		Config: map[string]string{
+			"command": "echo $PGUSER",
+			"shell":   "true",
+		},
+		Secrets: map[string]string{
+			"PGUSER": "v-approle-reporting",
+		},
+	}, statusHelperMock{})
+	assert.NoError(t, err)
+	assert.Equal(t, "v-approle-reporting\n", string(out))
+}
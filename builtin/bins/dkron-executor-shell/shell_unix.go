@@ -3,13 +3,19 @@
 package main
 
 import (
+	"fmt"
 	"os/exec"
 	"os/user"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 )
 
+// umaskLock serializes command starts as the umask is process wide and
+// inherited by the child on fork.
+var umaskLock sync.Mutex
+
 func setCmdAttr(cmd *exec.Cmd, config map[string]string) error {
 	su, _ := config["su"]
 	if su != "" {
@@ -37,3 +43,20 @@ func setCmdAttr(cmd *exec.Cmd, config map[string]string) error {
 	}
 	return nil
 }
+
+// startCmd starts the command applying the configured umask, if any.
+func startCmd(cmd *exec.Cmd, config map[string]string) error {
+	umaskLock.Lock()
+	defer umaskLock.Unlock()
+
+	if v := config["umask"]; v != "" {
+		mask, err := strconv.ParseUint(v, 8, 32)
+		if err != nil || mask > 0777 {
+			return fmt.Errorf("shell: invalid umask %q, use an octal value like 022", v)
+		}
+		old := syscall.Umask(int(mask))
+		defer syscall.Umask(old)
+	}
+
+	return cmd.Start()
+}
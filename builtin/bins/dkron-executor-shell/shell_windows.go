@@ -3,9 +3,17 @@
 package main
 
 import (
+	"errors"
 	"os/exec"
 )
 
 func setCmdAttr(cmd *exec.Cmd, config map[string]string) error {
 	return nil
 }
+
+func startCmd(cmd *exec.Cmd, config map[string]string) error {
+	if config["umask"] != "" {
+		return errors.New("shell: umask is not supported on windows")
+	}
+	return cmd.Start()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -48,9 +49,27 @@ func init() {
 
 func agentRun(args ...string) error {
 	// Make sure we clean up any managed plugins at the end of this
+	checksums, err := UnmarshalPluginChecksums(config.PluginChecksums)
+	if err != nil {
+		return err
+	}
+	dirs := config.PluginDirs
+	if len(config.PluginImages) > 0 {
+		auth, err := UnmarshalRegistryAuth(config.PluginRegistryAuth)
+		if err != nil {
+			return err
+		}
+		imageDirs, err := PullPluginImages(config.PluginImages, filepath.Join(config.DataDir, "plugin-images"), auth)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, imageDirs...)
+	}
 	p := &Plugins{
-		LogLevel: config.LogLevel,
-		NodeName: config.NodeName,
+		LogLevel:  config.LogLevel,
+		NodeName:  config.NodeName,
+		Dirs:      dirs,
+		Checksums: checksums,
 	}
 	if err := p.DiscoverPlugins(); err != nil {
 		log.Fatal(err)
@@ -58,6 +77,8 @@ func agentRun(args ...string) error {
 	plugins := dkron.Plugins{
 		Processors: p.Processors,
 		Executors:  p.Executors,
+		Stops:      p.Stops,
+		Loader:     p.Load,
 	}
 
 	agent = dkron.NewAgent(config, dkron.WithPlugins(plugins))
@@ -67,3 +67,61 @@ tags:
 	assert.Equal(t, "v1", config.Tags["t1"])
 	assert.Equal(t, "v2", config.Tags["t2"])
 }
+
+func TestReadConfigNamespaceDefaults(t *testing.T) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	var yamlConfig = []byte(`
+namespace-defaults:
+  payments:
+    processors:
+      elasticsearch:
+        index: payments
+    owner-escalation-channel: "#payments-oncall"
+    notify-on: state-change
+    webhook-url: https://hooks.slack.com/services/T000/B000/XXXX
+`)
+	if err := viper.ReadConfig(bytes.NewBuffer(yamlConfig)); err != nil {
+		t.Fatal(err)
+	}
+	config := dkron.DefaultConfig()
+	viper.Unmarshal(config)
+
+	d := config.NamespaceDefaults["payments"]
+	if assert.NotNil(t, d) {
+		assert.Equal(t, "payments", d.Processors["elasticsearch"]["index"])
+		assert.Equal(t, "#payments-oncall", d.OwnerEscalationChannel)
+		assert.Equal(t, "state-change", d.NotifyOn)
+		assert.Equal(t, "https://hooks.slack.com/services/T000/B000/XXXX", d.WebhookURL)
+	}
+}
+
+func TestReadConfigJobPolicies(t *testing.T) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+	var yamlConfig = []byte(`
+job-policies:
+  - name: no-remote-scripts
+    match: executor:shell
+    deny-commands:
+      - 'curl .*\|\s*(ba)?sh'
+    require-owner:
+      - owner_team
+    deny-tags:
+      - role=db
+`)
+	if err := viper.ReadConfig(bytes.NewBuffer(yamlConfig)); err != nil {
+		t.Fatal(err)
+	}
+	config := dkron.DefaultConfig()
+	viper.Unmarshal(config)
+
+	if assert.Len(t, config.JobPolicies, 1) {
+		p := config.JobPolicies[0]
+		assert.Equal(t, "no-remote-scripts", p.Name)
+		assert.Equal(t, "executor:shell", p.Match)
+		assert.Equal(t, []string{`curl .*\|\s*(ba)?sh`}, p.DenyCommands)
+		assert.Equal(t, []string{"owner_team"}, p.RequireOwner)
+		assert.Equal(t, []string{"role=db"}, p.DenyTags)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/distribworks/dkron/v3/dkron"
+	"github.com/spf13/cobra"
+)
+
+var backupTargetDir string
+var backupNodeName string
+var backupRPCAddr string
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup [command]",
+	Short: "Command to perform backup operations",
+	Long:  ``,
+}
+
+var backupVerifyCmd = &cobra.Command{
+	Use:   "verify <file>",
+	Short: "Verify a store snapshot",
+	Long: `Structurally validates a store snapshot, like the state.bin file of a raft
+snapshot: decodes every job and execution, checks the references between
+parent and dependent jobs and reports statistics.
+
+With --target-dir the snapshot is also written as a raft snapshot into
+the given data dir, a server agent started with that data dir, node name
+and RPC address restores it, allowing restore drills in staging.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+
+		s, err := dkron.NewStore()
+		if err != nil {
+			return err
+		}
+		defer s.Shutdown()
+		if err := s.Restore(in); err != nil {
+			return fmt.Errorf("error reading %s: %s", args[0], err)
+		}
+
+		report, err := s.Check()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Schema version: %d\n", report.SchemaVersion)
+		fmt.Printf("Jobs: %d (%d disabled)\n", report.Jobs, report.DisabledJobs)
+		fmt.Printf("Executions: %d\n", report.Executions)
+		for _, p := range report.Problems {
+			fmt.Printf("Problem: %s\n", p)
+		}
+		if len(report.Problems) > 0 {
+			return fmt.Errorf("snapshot has %d problems", len(report.Problems))
+		}
+		fmt.Println("Snapshot is valid")
+
+		if backupTargetDir != "" {
+			id, err := dkron.WriteRaftSnapshot(s, backupTargetDir, backupNodeName, backupRPCAddr)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Restored as raft snapshot %s in %s, start a server with --data-dir %s --node-name %s\n",
+				id, backupTargetDir, backupTargetDir, backupNodeName)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	hostname, _ := os.Hostname()
+
+	backupVerifyCmd.Flags().StringVar(&backupTargetDir, "target-dir", "", "Data dir where to restore the snapshot for a restore drill")
+	backupVerifyCmd.Flags().StringVar(&backupNodeName, "node-name", hostname, "Node name of the server that will restore the snapshot")
+	backupVerifyCmd.Flags().StringVar(&backupRPCAddr, "rpc-addr", "127.0.0.1:6868", "Advertised RPC address of the server that will restore the snapshot")
+
+	backupCmd.AddCommand(backupVerifyCmd)
+	dkronCmd.AddCommand(backupCmd)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/distribworks/dkron/v3/dkron"
+	"github.com/spf13/cobra"
+)
+
+var benchOptions dkron.BenchOptions
+var benchTags []string
+var benchJSON bool
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load test a cluster with synthetic jobs",
+	Long: `Creates synthetic jobs using the HTTP API of an agent, runs them at the
+given rate and waits for their executions, then reports the latency
+percentiles of the job writes, the run requests and the dispatch of the
+executions to the agents, along with the throughput of the store.
+The jobs are deleted once done unless kept.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, err := UnmarshalTags(benchTags)
+		if err != nil {
+			return err
+		}
+		benchOptions.Tags = tags
+
+		// Interrupting stops running jobs and reports what was measured
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		signalCh := make(chan os.Signal, 1)
+		signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signalCh)
+		go func() {
+			select {
+			case <-signalCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		report, err := dkron.RunBench(ctx, benchOptions)
+		if err != nil {
+			return err
+		}
+
+		if benchJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		printBenchReport(report)
+		return nil
+	},
+}
+
+func printBenchReport(r *dkron.BenchReport) {
+	fmt.Printf("%-12s %8s %7s %10s %10s %10s %10s %10s\n", "", "count", "errors", "per sec", "p50", "p90", "p99", "max")
+	for _, s := range []struct {
+		name  string
+		stats dkron.BenchStats
+	}{
+		{"job writes", r.JobWrites},
+		{"run requests", r.RunRequests},
+		{"dispatch", r.Dispatch},
+		{"completion", r.Completion},
+	} {
+		throughput := "-"
+		if s.stats.Throughput > 0 {
+			throughput = fmt.Sprintf("%.1f", s.stats.Throughput)
+		}
+		fmt.Printf("%-12s %8d %7d %10s %10s %10s %10s %10s\n", s.name, s.stats.Count, s.stats.Errors, throughput,
+			benchDuration(s.stats.P50), benchDuration(s.stats.P90), benchDuration(s.stats.P99), benchDuration(s.stats.Max))
+	}
+	fmt.Printf("\n%d executions, %d failed, %d runs without finished executions\n", r.Executions, r.Failed, r.Missing)
+}
+
+func benchDuration(d time.Duration) string {
+	return d.Round(100 * time.Microsecond).String()
+}
+
+func init() {
+	benchCmd.Flags().StringVar(&benchOptions.Address, "address", "http://localhost:8080", "Address of the HTTP API of the agent")
+	benchCmd.Flags().IntVar(&benchOptions.Jobs, "jobs", 10, "Number of synthetic jobs, the runs are spread over them")
+	benchCmd.Flags().Float64Var(&benchOptions.Rate, "rate", 10, "Runs per second, over all the jobs")
+	benchCmd.Flags().DurationVar(&benchOptions.Duration, "duration", 30*time.Second, "How long to run jobs for")
+	benchCmd.Flags().IntVar(&benchOptions.Concurrency, "concurrency", 10, "Requests to the API in flight at the same time")
+	benchCmd.Flags().IntVar(&benchOptions.OutputSize, "output-size", 0, "Bytes output by every execution")
+	benchCmd.Flags().StringSliceVar(&benchTags, "tag", []string{}, "Tag of the target nodes of the jobs, specified as key=value. Can be specified multiple times")
+	benchCmd.Flags().StringVar(&benchOptions.Prefix, "prefix", "bench-", "Prefix of the names of the jobs and the request IDs of the runs")
+	benchCmd.Flags().DurationVar(&benchOptions.Wait, "wait", time.Minute, "How long to wait for the executions after the last run")
+	benchCmd.Flags().BoolVar(&benchOptions.Keep, "keep", false, "Keep the jobs instead of deleting them once done")
+	benchCmd.Flags().BoolVar(&benchJSON, "json", false, "Print the report as JSON")
+
+	dkronCmd.AddCommand(benchCmd)
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var debugAddress string
+var debugAdminToken string
+var debugOutput string
+var debugLogFiles []string
+var debugProfileSeconds int
+
+// debugCmd represents the debug command
+var debugCmd = &cobra.Command{
+	Use:   "debug [command]",
+	Short: "Diagnostics tools",
+	Long:  ``,
+}
+
+var debugBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Collect diagnostics of a server into a tarball",
+	Long: `Collects the runtime and raft state, metrics, store stats, cluster
+members, goroutine dump and profiles of a server, along with the given
+log files, into a gzipped tarball to attach to support requests.
+The debug endpoints require the admin token of the server.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if debugOutput == "" {
+			debugOutput = fmt.Sprintf("dkron-debug-%s.tar.gz", time.Now().Format("20060102-150405"))
+		}
+
+		f, err := os.Create(debugOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		gz := gzip.NewWriter(f)
+		tw := tar.NewWriter(gz)
+
+		files := []struct {
+			name string
+			path string
+		}{
+			{"state.json", "/debug/state"},
+			{"vars.json", "/debug/vars"},
+			{"members.json", "/v1/members"},
+			{"leader.json", "/v1/leader"},
+			{"busy.json", "/v1/busy"},
+			{"store.json", "/v1/fsck"},
+			{"metrics.txt", "/metrics"},
+			{"goroutines.txt", "/debug/pprof/goroutine?debug=2"},
+			{"heap.pprof", "/debug/pprof/heap"},
+		}
+		if debugProfileSeconds > 0 {
+			files = append(files, struct {
+				name string
+				path string
+			}{"cpu.pprof", fmt.Sprintf("/debug/pprof/profile?seconds=%d", debugProfileSeconds)})
+		}
+
+		// Failures are recorded in the bundle instead of aborting it
+		var failures []string
+		for _, file := range files {
+			data, err := debugGet(file.path)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", file.name, err))
+				continue
+			}
+			if err := addBundleFile(tw, file.name, data); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range debugLogFiles {
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s", name, err))
+				continue
+			}
+			if err := addBundleFile(tw, filepath.Join("logs", filepath.Base(name)), data); err != nil {
+				return err
+			}
+		}
+
+		if len(failures) > 0 {
+			if err := addBundleFile(tw, "errors.txt", []byte(strings.Join(failures, "\n")+"\n")); err != nil {
+				return err
+			}
+			for _, f := range failures {
+				fmt.Fprintf(os.Stderr, "Skipped %s\n", f)
+			}
+		}
+
+		if err := tw.Close(); err != nil {
+			return err
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Debug bundle written to %s\n", debugOutput)
+		return nil
+	},
+}
+
+// debugGet returns the response of the server to a GET request.
+func debugGet(path string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, debugAddress+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if debugAdminToken != "" {
+		req.Header.Set("X-Dkron-Admin-Token", debugAdminToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+func addBundleFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func init() {
+	debugBundleCmd.Flags().StringVar(&debugAddress, "address", "http://localhost:8080", "Address of the HTTP API of the server")
+	debugBundleCmd.Flags().StringVar(&debugAdminToken, "admin-token", os.Getenv("DKRON_ADMIN_TOKEN"), "Admin token of the server, defaults to the DKRON_ADMIN_TOKEN environment variable")
+	debugBundleCmd.Flags().StringVarP(&debugOutput, "output", "o", "", "Path of the tarball, defaults to dkron-debug-<time>.tar.gz")
+	debugBundleCmd.Flags().StringSliceVar(&debugLogFiles, "log-file", []string{}, "Log file to include in the bundle. Can be specified multiple times")
+	debugBundleCmd.Flags().IntVar(&debugProfileSeconds, "cpu-profile", 0, "Seconds of CPU profile to collect, 0 disables it")
+
+	debugCmd.AddCommand(debugBundleCmd)
+	dkronCmd.AddCommand(debugCmd)
+}
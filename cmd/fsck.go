@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/distribworks/dkron/v3/dkron"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fsckRepair     bool
+	fsckAdminToken string
+)
+
+// fsckCmd represents the fsck command
+var fsckCmd = &cobra.Command{
+	Use:   "fsck",
+	Short: "Check the consistency of the store",
+	Long: `Scans the store of a server for undecodable records, dangling and duplicated
+dependent jobs, executions of deleted jobs and executions not stored with
+the configured compression, reporting a summary.
+With --repair the problems that can be fixed safely are repaired, this
+must be run against the leader and takes the admin token while the
+cluster is read-only.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		ipa, err := dkron.ParseSingleIPTemplate(rpcAddr)
+		if err != nil {
+			return err
+		}
+		ip = ipa
+
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var gc dkron.DkronGRPCClient
+		gc = dkron.NewGRPCClient(nil, nil)
+
+		report, err := gc.CheckStore(ip, fsckRepair, fsckAdminToken)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Schema version: %d\n", report.SchemaVersion)
+		fmt.Printf("Jobs: %d (%d disabled)\n", report.Jobs, report.DisabledJobs)
+		fmt.Printf("Executions: %d\n", report.Executions)
+		if fsckRepair {
+			fmt.Printf("Repaired: %d\n", report.Repaired)
+		}
+		for _, p := range report.Problems {
+			fmt.Printf("Problem: %s\n", p)
+		}
+		fmt.Printf("%d problems found\n", len(report.Problems))
+
+		return nil
+	},
+}
+
+func init() {
+	fsckCmd.Flags().StringVar(&rpcAddr, "rpc-addr", "{{ GetPrivateIP }}:6868", "gRPC address of the agent")
+	fsckCmd.Flags().BoolVar(&fsckRepair, "repair", false, "Repair the problems found")
+	fsckCmd.Flags().StringVar(&fsckAdminToken, "admin-token", os.Getenv("DKRON_ADMIN_TOKEN"), "Admin token of the cluster, required to repair a read-only cluster, defaults to the DKRON_ADMIN_TOKEN environment variable")
+	dkronCmd.AddCommand(fsckCmd)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/distribworks/dkron/v3/dkron"
+	"github.com/spf13/cobra"
+)
+
+var importAddress string
+var importTags []string
+var importPrefix string
+var importSystem bool
+var importDryRun bool
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [command]",
+	Short: "Import jobs from other schedulers",
+	Long:  ``,
+}
+
+var importCrontabCmd = &cobra.Command{
+	Use:   "crontab <file>",
+	Short: "Import the entries of a crontab as jobs",
+	Long: `Converts every entry of a crontab into a shell job, created using the
+HTTP API of an agent. Jobs with the same name as existing ones and
+entries that can't be converted are skipped and reported.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		query := importQuery()
+		if importSystem {
+			query.Set("system", "true")
+		}
+
+		return postImport("crontab", query, "text/plain", bytes.NewReader(data))
+	},
+}
+
+var importSystemdCmd = &cobra.Command{
+	Use:   "systemd <unit file>...",
+	Short: "Import systemd timers as jobs",
+	Long: `Converts systemd timers and the services they activate into shell jobs,
+created using the HTTP API of an agent. Pass the timer and service unit
+files, timers without their service are skipped and reported.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		for _, name := range args {
+			data, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			part, err := w.CreateFormFile("file", filepath.Base(name))
+			if err != nil {
+				return err
+			}
+			if _, err := part.Write(data); err != nil {
+				return err
+			}
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+
+		return postImport("systemd", importQuery(), w.FormDataContentType(), &body)
+	},
+}
+
+func importQuery() url.Values {
+	query := url.Values{}
+	if importPrefix != "" {
+		query.Set("prefix", importPrefix)
+	}
+	for _, tag := range importTags {
+		query.Add("tag", tag)
+	}
+	if importDryRun {
+		query.Set("dry_run", "true")
+	}
+	return query
+}
+
+func postImport(format string, query url.Values, contentType string, body io.Reader) error {
+	u := fmt.Sprintf("%s/v1/import/%s?%s", importAddress, format, query.Encode())
+	resp, err := http.Post(u, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("import failed: %s: %s", resp.Status, data)
+	}
+
+	var result dkron.ImportResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	verb := "Created"
+	if importDryRun {
+		verb = "Would create"
+	}
+	for _, job := range result.Jobs {
+		fmt.Printf("%s job %s: %s %s\n", verb, job.Name, job.Schedule, job.ExecutorConfig["command"])
+	}
+	for _, s := range result.Skipped {
+		fmt.Fprintf(os.Stderr, "Skipped %s: %s\n", s.Source, s.Reason)
+	}
+	fmt.Printf("%d jobs imported, %d skipped\n", len(result.Jobs), len(result.Skipped))
+
+	return nil
+}
+
+func init() {
+	importCmd.PersistentFlags().StringVar(&importAddress, "address", "http://localhost:8080", "Address of the HTTP API of the agent")
+	importCmd.PersistentFlags().StringSliceVar(&importTags, "tag", []string{}, "Tag of the target nodes of the imported jobs, specified as key=value. Can be specified multiple times")
+	importCmd.PersistentFlags().StringVar(&importPrefix, "prefix", "", "Prefix of the names of the imported jobs")
+	importCmd.PersistentFlags().BoolVar(&importDryRun, "dry-run", false, "Show the jobs that would be imported without creating them")
+	importCrontabCmd.Flags().BoolVar(&importSystem, "system", false, "The crontab has a user field, like /etc/crontab")
+
+	importCmd.AddCommand(importCrontabCmd)
+	importCmd.AddCommand(importSystemdCmd)
+	dkronCmd.AddCommand(importCmd)
+}
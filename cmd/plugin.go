@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/ryanuber/columnize"
+	"github.com/spf13/cobra"
+)
+
+// pluginCmd represents the plugin command
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Command to manage the plugins",
+	Long:  ``,
+}
+
+// pluginListCmd represents the plugin list command
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the plugins the agent loads",
+	Long: `Discovers and loads the plugins like the agent does, from the default
+directories and the plugin-dir ones of the config file, listing their
+name, type, protocol version, path and SHA256 checksum.
+
+Plugins pinned with plugin-checksum are marked, the command fails like the
+agent if a pinned plugin doesn't match its checksum.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checksums, err := UnmarshalPluginChecksums(config.PluginChecksums)
+		if err != nil {
+			return err
+		}
+		p := &Plugins{
+			LogLevel:  config.LogLevel,
+			NodeName:  config.NodeName,
+			Dirs:      config.PluginDirs,
+			Checksums: checksums,
+		}
+		defer plugin.CleanupClients()
+		if err := p.DiscoverPlugins(); err != nil {
+			return err
+		}
+
+		// Format it as a nice table.
+		result := []string{"Name|Type|Protocol|Pinned|SHA256|Path"}
+		for _, info := range p.Loaded {
+			result = append(result, fmt.Sprintf("%s|%s|%d|%v|%s|%s",
+				info.Name, info.Type, info.Protocol, info.Pinned, info.SHA256, info.Path))
+		}
+
+		fmt.Println(columnize.SimpleFormat(result))
+
+		return nil
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	dkronCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	mediaTypeOCIManifest    = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex       = "application/vnd.oci.image.index.v1+json"
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// defaultRegistry is the registry of the images without one.
+	defaultRegistry = "registry-1.docker.io"
+)
+
+var (
+	// ErrDigestMismatch is returned when a pulled manifest or layer
+	// doesn't match its digest.
+	ErrDigestMismatch = errors.New("plugin image: digest mismatch")
+	// ErrNoPlatform is returned when a multi-platform image has no image
+	// for the platform of the agent.
+	ErrNoPlatform = errors.New("plugin image: no image for the platform")
+	// ErrUnpinnedImage is returned for image references without the
+	// digest of their manifest.
+	ErrUnpinnedImage = errors.New("plugin image: reference not pinned to a digest")
+
+	validDigest = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// imageRef is a parsed image reference.
+type imageRef struct {
+	Registry   string
+	Repository string
+	// Reference is the digest of the image.
+	Reference string
+}
+
+// parseImageRef parses references pinned to the digest of their manifest,
+// like registry.example.com/dkron/shell@sha256:<digest> or
+// dkron/shell:1.0@sha256:<digest>, which is in Docker Hub. The tag is only
+// informative, tags can be moved to other images.
+func parseImageRef(ref string) (*imageRef, error) {
+	r := &imageRef{}
+
+	name := ref
+	i := strings.Index(name, "@")
+	if i < 0 {
+		return nil, fmt.Errorf("%s: %s", ErrUnpinnedImage, ref)
+	}
+	r.Reference = name[i+1:]
+	name = name[:i]
+	if !validDigest.MatchString(r.Reference) {
+		return nil, fmt.Errorf("plugin image %s: invalid digest %s", ref, r.Reference)
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		r.Registry, r.Repository = parts[0], parts[1]
+	} else {
+		r.Registry, r.Repository = defaultRegistry, name
+		if !strings.Contains(name, "/") {
+			r.Repository = "library/" + name
+		}
+	}
+	if r.Repository == "" {
+		return nil, fmt.Errorf("plugin image %s: invalid reference", ref)
+	}
+	return r, nil
+}
+
+// dir is the directory the plugins of the image are extracted in.
+func (r *imageRef) dir() string {
+	return strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(r.Registry + "/" + r.Repository + "@" + r.Reference)
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform,omitempty"`
+}
+
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+// imagePuller pulls the plugins of images from registries implementing
+// the OCI distribution API.
+type imagePuller struct {
+	client *http.Client
+	// auth are the user:password credentials by registry host.
+	auth map[string]string
+	// scheme of the registry URLs, https unless testing.
+	scheme string
+}
+
+func newImagePuller(auth map[string]string) *imagePuller {
+	return &imagePuller{
+		client: http.DefaultClient,
+		auth:   auth,
+		scheme: "https",
+	}
+}
+
+// PullPluginImages pulls the plugins of the images into dir, returning the
+// directories to discover them in. Images that can't be pulled use the
+// plugins of their last pull, if any.
+func PullPluginImages(images []string, dir string, auth map[string]string) ([]string, error) {
+	p := newImagePuller(auth)
+
+	var dirs []string
+	for _, image := range images {
+		ref, err := parseImageRef(image)
+		if err != nil {
+			return nil, err
+		}
+		imageDir := filepath.Join(dir, ref.dir())
+
+		plugins, err := p.pull(ref, imageDir)
+		if err != nil {
+			if _, serr := os.Stat(imageDir); serr != nil {
+				return nil, fmt.Errorf("plugin image %s: %s", image, err)
+			}
+			logrus.WithError(err).WithField("image", image).Warn("agent: Error pulling plugin image, using the last pulled plugins")
+		} else {
+			logrus.WithField("image", image).WithField("plugins", plugins).Info("agent: Pulled plugin image")
+		}
+		dirs = append(dirs, imageDir)
+	}
+	return dirs, nil
+}
+
+// pull verifies and extracts the plugin binaries of the image in dir,
+// replacing the ones of previous pulls.
+func (p *imagePuller) pull(ref *imageRef, dir string) ([]string, error) {
+	m, err := p.manifest(ref, ref.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	// Multi-platform images point to the image of every platform
+	if m.MediaType == mediaTypeOCIIndex || m.MediaType == mediaTypeDockerList {
+		digest := ""
+		for _, d := range m.Manifests {
+			if d.Platform != nil && d.Platform.OS == runtime.GOOS && d.Platform.Architecture == runtime.GOARCH {
+				digest = d.Digest
+				break
+			}
+		}
+		if digest == "" {
+			return nil, fmt.Errorf("%s: %s/%s", ErrNoPlatform, runtime.GOOS, runtime.GOARCH)
+		}
+		if m, err = p.manifest(ref, digest); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempDir(filepath.Dir(dir), ".pull-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	var plugins []string
+	for _, layer := range m.Layers {
+		extracted, err := p.extractLayer(ref, layer, tmp)
+		if err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, extracted...)
+	}
+	if len(plugins) == 0 {
+		return nil, fmt.Errorf("plugin image: no dkron-executor-* or dkron-processor-* binaries in %s/%s", ref.Registry, ref.Repository)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return nil, err
+	}
+	return plugins, nil
+}
+
+// manifest gets the manifest of the image by digest, verifying it.
+func (p *imagePuller) manifest(ref *imageRef, digest string) (*manifest, error) {
+	if !validDigest.MatchString(digest) {
+		return nil, fmt.Errorf("plugin image: invalid manifest digest %s", digest)
+	}
+
+	u := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", p.scheme, ref.Registry, ref.Repository, digest)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{mediaTypeOCIManifest, mediaTypeOCIIndex, mediaTypeDockerManifest, mediaTypeDockerList}, ", "))
+
+	resp, err := p.do(ref, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(body); "sha256:"+hex.EncodeToString(sum[:]) != digest {
+		return nil, fmt.Errorf("%s: manifest %s", ErrDigestMismatch, digest)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, err
+	}
+	if m.MediaType == "" {
+		m.MediaType = resp.Header.Get("Content-Type")
+	}
+	return &m, nil
+}
+
+// extractLayer downloads the layer verifying its digest and extracts the
+// plugin binaries in it to dir.
+func (p *imagePuller) extractLayer(ref *imageRef, layer descriptor, dir string) ([]string, error) {
+	if !validDigest.MatchString(layer.Digest) {
+		return nil, fmt.Errorf("plugin image: invalid layer digest %s", layer.Digest)
+	}
+
+	u := fmt.Sprintf("%s://%s/v2/%s/blobs/%s", p.scheme, ref.Registry, ref.Repository, layer.Digest)
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.do(ref, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Layers are verified before extracting anything
+	blob, err := ioutil.TempFile(dir, ".layer-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(blob.Name())
+	defer blob.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(blob, h), resp.Body); err != nil {
+		return nil, err
+	}
+	if "sha256:"+hex.EncodeToString(h.Sum(nil)) != layer.Digest {
+		return nil, fmt.Errorf("%s: layer %s", ErrDigestMismatch, layer.Digest)
+	}
+	if _, err := blob.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = bufio.NewReader(blob)
+	if magic, _ := r.(*bufio.Reader).Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var plugins []string
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg ||
+			!(strings.HasPrefix(name, "dkron-executor-") || strings.HasPrefix(name, "dkron-processor-")) {
+			continue
+		}
+
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(f, tr); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, name)
+	}
+	return plugins, nil
+}
+
+// do sends the request, authenticating with the registry when it asks to.
+func (p *imagePuller) do(ref *imageRef, req *http.Request) (*http.Response, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		auth, err := p.authorize(ref, challenge)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", auth)
+		if resp, err = p.client.Do(req); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("plugin image: %s %s: %s", req.Method, req.URL, resp.Status)
+	}
+	return resp, nil
+}
+
+// authorize returns the Authorization header answering the challenge of
+// the registry, getting a token for bearer challenges.
+func (p *imagePuller) authorize(ref *imageRef, challenge string) (string, error) {
+	creds := p.auth[ref.Registry]
+	user, password := "", ""
+	if i := strings.Index(creds, ":"); i >= 0 {
+		user, password = creds[:i], creds[i+1:]
+	}
+
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth(user, password)
+		return req.Header.Get("Authorization"), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("plugin image: unsupported authentication %q", challenge)
+	}
+
+	u, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("plugin image: invalid token realm %q", params["realm"])
+	}
+	q := u.Query()
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", ref.Repository)
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if creds != "" {
+		req.SetBasicAuth(user, password)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("plugin image: getting token: %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses WWW-Authenticate headers like
+// Bearer realm="https://auth.example.com/token",service="registry".
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+	for _, kv := range regexp.MustCompile(`(\w+)="([^"]*)"`).FindAllStringSubmatch(parts[1], -1) {
+		params[strings.ToLower(kv[1])] = kv[2]
+	}
+	return parts[0], params
+}
+
+// UnmarshalRegistryAuth parses the registry credentials in the
+// registry=user:password format.
+func UnmarshalRegistryAuth(auth []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, a := range auth {
+		parts := strings.SplitN(a, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || !strings.Contains(parts[1], ":") {
+			return nil, fmt.Errorf("Invalid plugin registry auth for '%s', not in the registry=user:password format", parts[0])
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result, nil
+}
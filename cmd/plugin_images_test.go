@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImageRef(t *testing.T) {
+	digest := "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		ref      string
+		expected imageRef
+	}{
+		{"registry.example.com/dkron/plugins@" + digest, imageRef{"registry.example.com", "dkron/plugins", digest}},
+		{"localhost:5000/plugins:1.0@" + digest, imageRef{"localhost:5000", "plugins", digest}},
+		{"dkron/plugins:1.0@" + digest, imageRef{defaultRegistry, "dkron/plugins", digest}},
+		{"plugins@" + digest, imageRef{defaultRegistry, "library/plugins", digest}},
+	}
+	for _, tt := range tests {
+		ref, err := parseImageRef(tt.ref)
+		require.NoError(t, err, tt.ref)
+		assert.Equal(t, tt.expected, *ref, tt.ref)
+	}
+
+	_, err := parseImageRef("registry.example.com/plugins@sha256:abc")
+	assert.Error(t, err)
+
+	// Tags can be moved to other images
+	_, err = parseImageRef("registry.example.com/dkron/plugins:1.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrUnpinnedImage.Error())
+}
+
+func TestPullPluginImages(t *testing.T) {
+	// A layer with a plugin and a file that isn't one
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{
+		"usr/bin/dkron-executor-test": "plugin",
+		"etc/motd":                    "not a plugin",
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	layer := buf.Bytes()
+
+	layerSum := sha256.Sum256(layer)
+	layerDigest := "sha256:" + hex.EncodeToString(layerSum[:])
+	m, _ := json.Marshal(manifest{
+		MediaType: mediaTypeOCIManifest,
+		Layers:    []descriptor{{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: layerDigest}},
+	})
+	manifestSum := sha256.Sum256(m)
+	manifestDigest := "sha256:" + hex.EncodeToString(manifestSum[:])
+
+	// Registry requiring a token
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if user, password, _ := r.BasicAuth(); user != "dkron" || password != "s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			assert.Equal(t, "repository:dkron/plugins:pull", r.URL.Query().Get("scope"))
+			w.Write([]byte(`{"token": "t0ken"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, ts.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/dkron/plugins/manifests/"):
+			w.Header().Set("Content-Type", mediaTypeOCIManifest)
+			w.Write(m)
+		case r.URL.Path == "/v2/dkron/plugins/blobs/"+layerDigest:
+			w.Write(layer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	dir, err := ioutil.TempDir("", "dkron-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := newImagePuller(map[string]string{host: "dkron:s3cret"})
+	p.scheme = "http"
+
+	for _, image := range []string{host + "/dkron/plugins:1.0@" + manifestDigest, host + "/dkron/plugins@" + manifestDigest} {
+		ref, err := parseImageRef(image)
+		require.NoError(t, err)
+		imageDir := filepath.Join(dir, ref.dir())
+
+		plugins, err := p.pull(ref, imageDir)
+		require.NoError(t, err, image)
+		assert.Equal(t, []string{"dkron-executor-test"}, plugins)
+
+		content, err := ioutil.ReadFile(filepath.Join(imageDir, "dkron-executor-test"))
+		require.NoError(t, err)
+		assert.Equal(t, "plugin", string(content))
+		_, err = os.Stat(filepath.Join(imageDir, "motd"))
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	// Images pinned to another digest aren't extracted
+	ref, err := parseImageRef(host + "/dkron/plugins@sha256:" + strings.Repeat("0", 64))
+	require.NoError(t, err)
+	_, err = p.pull(ref, filepath.Join(dir, ref.dir()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrDigestMismatch.Error())
+
+	// Without credentials there's no token
+	p.auth = nil
+	ref, err = parseImageRef(host + "/dkron/plugins@" + manifestDigest)
+	require.NoError(t, err)
+	_, err = p.pull(ref, filepath.Join(dir, "noauth"))
+	assert.Error(t, err)
+}
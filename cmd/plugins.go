@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/distribworks/dkron/v3/dkron"
@@ -19,6 +24,26 @@ type Plugins struct {
 	Executors  map[string]dkplugin.Executor
 	LogLevel   string
 	NodeName   string
+
+	// Dirs are the additional directories plugins are discovered in.
+	Dirs []string
+	// Checksums are the SHA256 checksums the plugin binaries are pinned
+	// to by file name, pinned plugins not matching aren't loaded.
+	Checksums map[string]string
+	// Loaded are the plugins loaded by DiscoverPlugins.
+	Loaded []PluginInfo
+	// Stops are the functions stopping every loaded plugin.
+	Stops map[interface{}]func()
+}
+
+// PluginInfo describes a loaded plugin.
+type PluginInfo struct {
+	Name     string
+	Type     string
+	Path     string
+	SHA256   string
+	Protocol int
+	Pinned   bool
 }
 
 // Discover plugins located on disk
@@ -27,23 +52,16 @@ type Plugins struct {
 //
 // 1. Dkron configuration path
 // 2. Path where Dkron is installed
+// 3. The configured plugin directories, in order
 //
 // Whichever file is discoverd LAST wins.
 func (p *Plugins) DiscoverPlugins() error {
 	p.Processors = make(map[string]dkplugin.Processor)
 	p.Executors = make(map[string]dkplugin.Executor)
+	p.Stops = make(map[interface{}]func())
 
 	// Look in /etc/dkron/plugins
-	processors, err := plugin.Discover("dkron-processor-*", filepath.Join("/etc", "dkron", "plugins"))
-	if err != nil {
-		return err
-	}
-
-	// Look in /etc/dkron/plugins
-	executors, err := plugin.Discover("dkron-executor-*", filepath.Join("/etc", "dkron", "plugins"))
-	if err != nil {
-		return err
-	}
+	dirs := []string{filepath.Join("/etc", "dkron", "plugins")}
 
 	// Next, look in the same directory as the Dkron executable, usually
 	// /usr/local/bin. If found, this replaces what we found in the config path.
@@ -51,18 +69,25 @@ func (p *Plugins) DiscoverPlugins() error {
 	if err != nil {
 		logrus.WithError(err).Error("Error loading exe directory")
 	} else {
-		p, err := plugin.Discover("dkron-processor-*", filepath.Dir(exePath))
+		dirs = append(dirs, filepath.Dir(exePath))
+	}
+	dirs = append(dirs, p.Dirs...)
+
+	var processors, executors []string
+	for _, dir := range dirs {
+		pr, err := plugin.Discover("dkron-processor-*", dir)
 		if err != nil {
 			return err
 		}
-		processors = append(processors, p...)
-		e, err := plugin.Discover("dkron-executor-*", filepath.Dir(exePath))
+		processors = append(processors, pr...)
+		e, err := plugin.Discover("dkron-executor-*", dir)
 		if err != nil {
 			return err
 		}
 		executors = append(executors, e...)
 	}
 
+	loaded := map[string]PluginInfo{}
 	for _, file := range processors {
 
 		pluginName, ok := getPluginName(file)
@@ -70,11 +95,13 @@ func (p *Plugins) DiscoverPlugins() error {
 			continue
 		}
 
-		raw, err := p.pluginFactory(file, dkplugin.ProcessorPluginName)
+		raw, info, stop, err := p.pluginFactory(file, dkplugin.ProcessorPluginName)
 		if err != nil {
 			return err
 		}
 		p.Processors[pluginName] = raw.(dkplugin.Processor)
+		p.Stops[raw] = stop
+		loaded[dkplugin.ProcessorPluginName+":"+pluginName] = info
 	}
 
 	for _, file := range executors {
@@ -84,13 +111,26 @@ func (p *Plugins) DiscoverPlugins() error {
 			continue
 		}
 
-		raw, err := p.pluginFactory(file, dkplugin.ExecutorPluginName)
+		raw, info, stop, err := p.pluginFactory(file, dkplugin.ExecutorPluginName)
 		if err != nil {
 			return err
 		}
 		p.Executors[pluginName] = raw.(dkplugin.Executor)
+		p.Stops[raw] = stop
+		loaded[dkplugin.ExecutorPluginName+":"+pluginName] = info
 	}
 
+	p.Loaded = make([]PluginInfo, 0, len(loaded))
+	for _, info := range loaded {
+		p.Loaded = append(p.Loaded, info)
+	}
+	sort.Slice(p.Loaded, func(i, j int) bool {
+		if p.Loaded[i].Type != p.Loaded[j].Type {
+			return p.Loaded[i].Type < p.Loaded[j].Type
+		}
+		return p.Loaded[i].Name < p.Loaded[j].Name
+	})
+
 	return nil
 }
 
@@ -107,7 +147,45 @@ func getPluginName(file string) (string, bool) {
 	return name, true
 }
 
-func (p *Plugins) pluginFactory(path string, pluginType string) (interface{}, error) {
+// Load starts the plugin binary in path, its type and name are taken from
+// the file name like in the discovery. Used to reload plugins at runtime.
+func (p *Plugins) Load(path string) (*dkron.LoadedPlugin, error) {
+	name, ok := getPluginName(path)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s: not a plugin file name", path)
+	}
+	pluginType := strings.SplitN(filepath.Base(path), "-", 3)[1]
+	if pluginType != dkplugin.ExecutorPluginName && pluginType != dkplugin.ProcessorPluginName {
+		return nil, fmt.Errorf("plugin %s: unknown plugin type %s", path, pluginType)
+	}
+
+	raw, info, stop, err := p.pluginFactory(path, pluginType)
+	if err != nil {
+		return nil, err
+	}
+	return &dkron.LoadedPlugin{
+		Name:   name,
+		Type:   pluginType,
+		SHA256: info.SHA256,
+		Plugin: raw,
+		Stop:   stop,
+	}, nil
+}
+
+func (p *Plugins) pluginFactory(path string, pluginType string) (interface{}, PluginInfo, func(), error) {
+	name, _ := getPluginName(path)
+	info := PluginInfo{
+		Name: name,
+		Type: pluginType,
+		Path: path,
+	}
+
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return nil, info, nil, err
+	}
+	info.SHA256 = sum
+
 	// Build the plugin client configuration and init the plugin
 	var config plugin.ClientConfig
 	config.Cmd = exec.Command(path)
@@ -118,6 +196,19 @@ func (p *Plugins) pluginFactory(path string, pluginType string) (interface{}, er
 	config.SyncStderr = os.Stderr
 	config.Logger = &dkron.HCLogAdapter{Logger: dkron.InitLogger(p.LogLevel, p.NodeName), LoggerName: "plugins"}
 
+	// Pinned plugins are checked again right before starting them
+	if pinned, ok := p.Checksums[filepath.Base(path)]; ok {
+		if !strings.EqualFold(pinned, sum) {
+			return nil, info, nil, fmt.Errorf("plugin %s: checksum %s doesn't match the pinned %s", path, sum, pinned)
+		}
+		checksum, _ := hex.DecodeString(pinned)
+		config.SecureConfig = &plugin.SecureConfig{
+			Checksum: checksum,
+			Hash:     sha256.New(),
+		}
+		info.Pinned = true
+	}
+
 	switch pluginType {
 	case dkplugin.ProcessorPluginName:
 		config.AllowedProtocols = []plugin.Protocol{plugin.ProtocolNetRPC}
@@ -131,13 +222,48 @@ func (p *Plugins) pluginFactory(path string, pluginType string) (interface{}, er
 	// so we can build the actual RPC-implemented provider.
 	rpcClient, err := client.Client()
 	if err != nil {
-		return nil, err
+		client.Kill()
+		return nil, info, nil, err
 	}
+	info.Protocol = client.NegotiatedVersion()
 
 	raw, err := rpcClient.Dispense(pluginType)
 	if err != nil {
-		return nil, err
+		client.Kill()
+		return nil, info, nil, err
 	}
 
-	return raw, nil
+	return raw, info, client.Kill, nil
+}
+
+// fileSHA256 returns the hex encoded SHA256 checksum of the file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// UnmarshalPluginChecksums parses the plugin checksums in the
+// file=sha256 format.
+func UnmarshalPluginChecksums(checksums []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, c := range checksums {
+		parts := strings.SplitN(c, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("Invalid plugin checksum: '%s'", c)
+		}
+		if b, err := hex.DecodeString(parts[1]); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("Invalid plugin checksum: '%s', not a SHA256 checksum", c)
+		}
+		result[parts[0]] = strings.ToLower(parts[1])
+	}
+	return result, nil
 }
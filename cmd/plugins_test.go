@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalPluginChecksums(t *testing.T) {
+	sum := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	checksums, err := UnmarshalPluginChecksums([]string{"dkron-executor-shell=" + sum})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"dkron-executor-shell": sum}, checksums)
+
+	_, err = UnmarshalPluginChecksums([]string{"dkron-executor-shell"})
+	assert.Error(t, err)
+	_, err = UnmarshalPluginChecksums([]string{"dkron-executor-shell=abc"})
+	assert.Error(t, err)
+}
+
+func TestPluginChecksumMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "dkron-executor-test")
+	require.NoError(t, ioutil.WriteFile(file, []byte("test"), 0755))
+
+	sum, err := fileSHA256(file)
+	require.NoError(t, err)
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", sum)
+
+	// Tampered plugins aren't started
+	p := &Plugins{
+		LogLevel: logLevel,
+		Checksums: map[string]string{
+			"dkron-executor-test": "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+	_, info, _, err := p.pluginFactory(file, "executor")
+	assert.Error(t, err)
+	assert.Equal(t, "test", info.Name)
+	assert.Equal(t, sum, info.SHA256)
+
+	_, err = p.Load(file)
+	assert.Error(t, err)
+	_, err = p.Load(filepath.Join(dir, "dkron-test"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/distribworks/dkron/v3/dkron"
+	"github.com/spf13/cobra"
+)
+
+var signKey string
+
+// signCmd represents the sign command
+var signCmd = &cobra.Command{
+	Use:   "sign [command]",
+	Short: "Sign job specs",
+	Long: `Generates job signing keys and signs job specs with them, servers
+started with --job-signing-key verify the signatures of the jobs set
+through the API.`,
+}
+
+var signKeygenCmd = &cobra.Command{
+	Use:   "keygen <name>",
+	Short: "Generates a new job signing key",
+	Long: `Generates an ed25519 key pair, writing the private key to <name>.key
+and the public key to <name>.pub. Servers load the public key with
+--job-signing-key and report <name> as the signer of the jobs it verifies.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return fmt.Errorf("Error generating key: %s", err)
+		}
+
+		name := args[0]
+		if err := ioutil.WriteFile(name+".key", []byte(base64.StdEncoding.EncodeToString(priv)+"\n"), 0600); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(name+".pub", []byte(base64.StdEncoding.EncodeToString(pub)+"\n"), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Private key written to %s.key, public key to %s.pub\n", name, name)
+		return nil
+	},
+}
+
+var signJobCmd = &cobra.Command{
+	Use:   "job <file>",
+	Short: "Signs a job spec",
+	Long: `Signs the job spec in the JSON file with the private key, printing the
+job with its signature. The signature covers the name, executor, executor
+config, steps and tags of the job, sign the job again after changing them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := ioutil.ReadFile(signKey)
+		if err != nil {
+			return err
+		}
+		key, err := dkron.DecodeSigningKey(data, ed25519.PrivateKeySize)
+		if err != nil {
+			return err
+		}
+
+		data, err = ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		var job map[string]json.RawMessage
+		if err := json.Unmarshal(data, &job); err != nil {
+			return err
+		}
+		var spec dkron.Job
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return err
+		}
+
+		// Keep the fields of the file as they are, only adding the signature
+		dkron.SignJob(&spec, ed25519.PrivateKey(key))
+		job["signature"], _ = json.Marshal(spec.Signature)
+		delete(job, "signed_by")
+
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(job)
+	},
+}
+
+func init() {
+	signJobCmd.Flags().StringVar(&signKey, "key", "", "File of the private key signing the job, as written by keygen")
+	signJobCmd.MarkFlagRequired("key")
+
+	signCmd.AddCommand(signKeygenCmd)
+	signCmd.AddCommand(signJobCmd)
+	dkronCmd.AddCommand(signCmd)
+}
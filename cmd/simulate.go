@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/distribworks/dkron/v3/dkron"
+	"github.com/spf13/cobra"
+)
+
+var simulateNodes []string
+var simulateStart string
+var simulateDuration time.Duration
+var simulateExecutionTime time.Duration
+var simulateFailJobs []string
+var simulateSeed int64
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate <jobs file>",
+	Short: "Simulate the runs of jobs over a period",
+	Long: `Runs the jobs of a JSON file, an array of jobs as accepted by the API,
+against simulated nodes and a mock clock, and prints the timeline of
+their executions. Schedules, timezones, target nodes, concurrency,
+retries, circuit breakers and dependent jobs follow the same rules as a
+cluster, but nothing is executed and days run in an instant.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		var jobs []*dkron.Job
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return err
+		}
+
+		start := time.Now().Truncate(time.Minute)
+		if simulateStart != "" {
+			if start, err = time.Parse(time.RFC3339, simulateStart); err != nil {
+				return err
+			}
+		}
+
+		nodes, err := parseSimNodes(simulateNodes)
+		if err != nil {
+			return err
+		}
+
+		failing := map[string]bool{}
+		for _, name := range simulateFailJobs {
+			failing[name] = true
+		}
+
+		sim, err := dkron.NewSimulation(start, nodes, func(job *dkron.Job, node string, attempt uint) dkron.SimResult {
+			return dkron.SimResult{Success: !failing[job.Name], Duration: simulateExecutionTime}
+		})
+		if err != nil {
+			return err
+		}
+		defer sim.Shutdown()
+		sim.Seed(simulateSeed)
+
+		// Parents are saved before their dependent jobs
+		if err := addSimJobs(sim, jobs); err != nil {
+			return err
+		}
+
+		if err := sim.RunFor(simulateDuration); err != nil {
+			return err
+		}
+
+		for _, e := range sim.Events {
+			fmt.Println(e)
+		}
+		return nil
+	},
+}
+
+// parseSimNodes parses nodes given as name or name:key=value,key=value.
+func parseSimNodes(values []string) ([]dkron.SimNode, error) {
+	nodes := []dkron.SimNode{}
+	for _, v := range values {
+		parts := strings.SplitN(v, ":", 2)
+		node := dkron.SimNode{Name: parts[0], Tags: map[string]string{}}
+		if len(parts) == 2 {
+			for _, tag := range strings.Split(parts[1], ",") {
+				kv := strings.SplitN(tag, "=", 2)
+				if len(kv) != 2 {
+					return nil, fmt.Errorf("invalid tag %q of node %s", tag, node.Name)
+				}
+				node.Tags[kv[0]] = kv[1]
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// addSimJobs adds the jobs to the simulation, parents first.
+func addSimJobs(sim *dkron.Simulation, jobs []*dkron.Job) error {
+	added := map[string]bool{}
+	for len(jobs) > 0 {
+		pending := []*dkron.Job{}
+		for _, job := range jobs {
+			if job.ParentJob != "" && !added[job.ParentJob] {
+				pending = append(pending, job)
+				continue
+			}
+			if err := sim.AddJob(job); err != nil {
+				return fmt.Errorf("%s: %s", job.Name, err)
+			}
+			added[job.Name] = true
+		}
+		if len(pending) == len(jobs) {
+			return fmt.Errorf("%s: parent job %s not found", pending[0].Name, pending[0].ParentJob)
+		}
+		jobs = pending
+	}
+	return nil
+}
+
+func init() {
+	simulateCmd.Flags().StringSliceVar(&simulateNodes, "node", []string{"node1"}, "Simulated node as name or name:key=value,key=value with its tags. Can be specified multiple times")
+	simulateCmd.Flags().StringVar(&simulateStart, "start", "", "Start time of the simulation in RFC3339, defaults to the current minute")
+	simulateCmd.Flags().DurationVar(&simulateDuration, "duration", 24*time.Hour, "Period to simulate")
+	simulateCmd.Flags().DurationVar(&simulateExecutionTime, "execution-time", time.Second, "Duration of every execution")
+	simulateCmd.Flags().StringSliceVar(&simulateFailJobs, "fail", []string{}, "Job whose executions fail. Can be specified multiple times")
+	simulateCmd.Flags().Int64Var(&simulateSeed, "seed", 1, "Seed used to pick target nodes")
+
+	dkronCmd.AddCommand(simulateCmd)
+}
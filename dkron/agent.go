@@ -1,6 +1,7 @@
 package dkron
 
 import (
+	"crypto/ed25519"
 	"crypto/tls"
 	"errors"
 	"expvar"
@@ -10,12 +11,14 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
+	"github.com/distribworks/dkron/v3/extcron"
 	"github.com/distribworks/dkron/v3/plugin"
 	proto "github.com/distribworks/dkron/v3/plugin/types"
 	"github.com/hashicorp/memberlist"
@@ -57,6 +60,12 @@ type Agent struct {
 	//ExecutorPlugins maps executor plugins
 	ExecutorPlugins map[string]plugin.Executor
 
+	// pluginsLock guards the plugin maps, that change when plugins are
+	// reloaded.
+	pluginsLock  sync.RWMutex
+	pluginCalls  *pluginCalls
+	pluginLoader PluginLoader
+
 	// HTTPTransport is a swappable interface for the HTTP server interface
 	HTTPTransport Transport
 
@@ -72,6 +81,9 @@ type Agent struct {
 	// TLSConfig allows setting a TLS config for transport
 	TLSConfig *tls.Config
 
+	// ArtifactStore interface to set where execution artifacts are uploaded
+	ArtifactStore ArtifactStore
+
 	// Pro features
 	GlobalLock         bool
 	MemberEventHandler func(serf.Event)
@@ -89,6 +101,7 @@ type Agent struct {
 	// region to protect operations that require strong consistency
 	leaderCh <-chan bool
 	raft     *raft.Raft
+	fsm      *dkronFSM
 	// raftLayer provides network layering of the raft RPC along with
 	// the Dkron gRPC transport layer.
 	raftLayer     *RaftLayer
@@ -109,6 +122,53 @@ type Agent struct {
 
 	activeExecutions sync.Map
 
+	// resultSpool holds the execution results that couldn't be delivered.
+	resultSpool *resultSpool
+
+	// workspaces are the directories the executions run in.
+	workspaces *workspaces
+
+	// clockSkews holds the last clock skew of every member measured by
+	// the leader.
+	clockSkews sync.Map
+
+	// backfillRuns holds the backfills being dispatched by the leader.
+	backfillRuns sync.Map
+
+	// overload tracks the load of the leader.
+	overload overload
+
+	// rampUp releases the runs missed while the scheduler was paused or
+	// without leader.
+	rampUp rampUp
+
+	// reserved are the resources reserved by the executions this agent
+	// is running, gossiped in its tags.
+	reserved     nodeResources
+	reservedLock sync.Mutex
+
+	tagsLock sync.Mutex
+
+	// signingKeys are the public keys verifying the signatures of jobs.
+	signingKeys map[string]ed25519.PublicKey
+
+	// policies are the compiled job policies.
+	policies []*jobPolicy
+
+	// redactions are the compiled output redaction patterns.
+	redactions *outputRedactions
+
+	// changeSink delivers the changes of the store, nil when change data
+	// capture is off.
+	changeSink changeSink
+
+	// vault reads the secrets of the jobs, nil when Vault is not configured.
+	vault *vaultClient
+
+	// executionLogs mirrors the output of the executions to local files,
+	// nil when disabled.
+	executionLogs *executionLogs
+
 	listener net.Listener
 }
 
@@ -120,6 +180,12 @@ type ProcessorFactory func() (plugin.Processor, error)
 type Plugins struct {
 	Processors map[string]plugin.Processor
 	Executors  map[string]plugin.Executor
+
+	// Stops are the functions stopping each plugin, used when they're
+	// replaced.
+	Stops map[interface{}]func()
+	// Loader starts the plugins reloaded at runtime.
+	Loader PluginLoader
 }
 
 // AgentOption type that defines agent options
@@ -131,6 +197,7 @@ func NewAgent(config *Config, options ...AgentOption) *Agent {
 	agent := &Agent{
 		config:      config,
 		retryJoinCh: make(chan error),
+		pluginCalls: newPluginCalls(),
 	}
 
 	for _, option := range options {
@@ -148,6 +215,56 @@ func (a *Agent) Start() error {
 	// Normalize configured addresses
 	a.config.normalizeAddrs()
 
+	if slimBuild {
+		a.config.Slim = true
+	}
+	if a.config.Slim && a.config.Server {
+		return errors.New("agent: Servers can't run slim")
+	}
+
+	if a.config.Server {
+		if err := extcron.ValidateMacros(scheduleMacros(a.config.ScheduleMacros)); err != nil {
+			return fmt.Errorf("agent: Invalid schedule macros, %s", err)
+		}
+		if err := validCompression(a.config.ExecutionCompression); err != nil {
+			return fmt.Errorf("agent: Invalid execution compression, %s", err)
+		}
+		if _, err := getStoreProfile(a.config.StoreProfile); err != nil {
+			return fmt.Errorf("agent: Invalid store profile, %s", err)
+		}
+		keys, err := loadJobSigningKeys(a.config.JobSigningKeys)
+		if err != nil {
+			return fmt.Errorf("agent: Invalid job signing keys, %s", err)
+		}
+		if a.config.RequireSignedJobs && len(keys) == 0 {
+			return errors.New("agent: Signed jobs are required but no job signing key is configured")
+		}
+		a.signingKeys = keys
+		policies, err := compileJobPolicies(a.config.JobPolicies)
+		if err != nil {
+			return fmt.Errorf("agent: Invalid job policies, %s", err)
+		}
+		a.policies = policies
+		redactions, err := compileOutputRedactions(a.config)
+		if err != nil {
+			return fmt.Errorf("agent: Invalid redact patterns, %s", err)
+		}
+		a.redactions = redactions
+		sink, err := newChangeSink(a.config)
+		if err != nil {
+			return fmt.Errorf("agent: Invalid change data capture sink, %s", err)
+		}
+		a.changeSink = sink
+		if err := validCostRates(a.config.CostRates); err != nil {
+			return fmt.Errorf("agent: Invalid cost rates, %s", err)
+		}
+		if a.config.CredentialsKey != "" {
+			if _, err := credentialsCipher(a.config); err != nil {
+				return fmt.Errorf("agent: Invalid credentials key, %s", err)
+			}
+		}
+	}
+
 	s, err := a.setupSerf()
 	if err != nil {
 		return fmt.Errorf("agent: Can not setup serf, %s", err)
@@ -168,6 +285,46 @@ func (a *Agent) Start() error {
 	// Expose the node name
 	expNode.Set(a.config.NodeName)
 
+	// Secrets are read by the agents running the jobs
+	a.vault = newVaultClient(a.config)
+
+	el, err := newExecutionLogs(a.config)
+	if err != nil {
+		return fmt.Errorf("agent: Can not setup execution log files, %s", err)
+	}
+	a.executionLogs = el
+
+	if a.ArtifactStore == nil && a.config.ArtifactStore != "" {
+		as, err := NewArtifactStore(a.config.ArtifactStore)
+		if err != nil {
+			return fmt.Errorf("agent: Can not setup artifact store, %s", err)
+		}
+		a.ArtifactStore = as
+	}
+
+	// Slim agents write nothing to the data dir
+	if a.config.ResultSpoolMax > 0 && !a.config.Slim {
+		rs, err := newResultSpool(filepath.Join(a.config.DataDir, "results"), a.config.ResultSpoolMax)
+		if err != nil {
+			return fmt.Errorf("agent: Can not setup result spool, %s", err)
+		}
+		a.resultSpool = rs
+		go a.deliverSpooledResults()
+	}
+
+	if !a.config.Slim {
+		workspaceDir := a.config.WorkspaceDir
+		if workspaceDir == "" {
+			workspaceDir = filepath.Join(a.config.DataDir, "workspaces")
+		}
+		ws, err := newWorkspaces(workspaceDir, a.config.WorkspaceRetention, a.config.WorkspaceMaxSize)
+		if err != nil {
+			return fmt.Errorf("agent: Can not setup workspaces, %s", err)
+		}
+		a.workspaces = ws
+		go a.collectWorkspaces()
+	}
+
 	//Use the value of "RPCPort" if AdvertiseRPCPort has not been set
 	if a.config.AdvertiseRPCPort <= 0 {
 		a.config.AdvertiseRPCPort = a.config.RPCPort
@@ -203,9 +360,12 @@ func (a *Agent) Start() error {
 	tags := a.serf.LocalMember().Tags
 	tags["rpc_addr"] = a.advertiseRPCAddr() // Address that clients will use to RPC to servers
 	tags["port"] = strconv.Itoa(a.config.AdvertiseRPCPort)
+	tags[capacityTag] = nodeResources{CPU: a.config.ResourceCPU, Memory: a.config.ResourceMemory}.String()
+	tags[reservedTag] = nodeResources{}.String()
 	a.serf.SetTags(tags)
 
 	go a.eventLoop()
+	go a.monitorPressure()
 	a.ready = true
 
 	return nil
@@ -261,6 +421,11 @@ func (a *Agent) setupRaft() error {
 		}
 	}
 
+	profile, err := getStoreProfile(a.config.StoreProfile)
+	if err != nil {
+		return err
+	}
+
 	logger := ioutil.Discard
 	if log.Logger.Level == logrus.DebugLevel {
 		logger = log.Logger.Writer()
@@ -298,7 +463,7 @@ func (a *Agent) setupRaft() error {
 		var err error
 		// Create the snapshot store. This allows the Raft to truncate the log to
 		// mitigate the issue of having an unbounded replicated log.
-		snapshots, err = raft.NewFileSnapshotStore(filepath.Join(a.config.DataDir, "raft"), 3, logger)
+		snapshots, err = raft.NewFileSnapshotStore(filepath.Join(a.config.DataDir, "raft"), profile.raftSnapshots, logger)
 		if err != nil {
 			return fmt.Errorf("file snapshot store: %s", err)
 		}
@@ -312,7 +477,7 @@ func (a *Agent) setupRaft() error {
 		stableStore = s
 
 		// Wrap the store in a LogCache to improve performance
-		cacheStore, err := raft.NewLogCache(raftLogCacheSize, s)
+		cacheStore, err := raft.NewLogCache(profile.raftLogCache, s)
 		if err != nil {
 			s.Close()
 			return err
@@ -364,6 +529,7 @@ func (a *Agent) setupRaft() error {
 
 	// Instantiate the Raft systems. The second parameter is a finite state machine
 	// which stores the actual kv pairs and is operated upon through Apply().
+	a.Store.RecordChanges(a.config.cdcMaxPending())
 	fsm := newFSM(a.Store, a.ProAppliers)
 	rft, err := raft.NewRaft(config, fsm, logStore, stableStore, snapshots, transport)
 	if err != nil {
@@ -371,6 +537,7 @@ func (a *Agent) setupRaft() error {
 	}
 	a.leaderCh = rft.LeaderCh()
 	a.raft = rft
+	a.fsm = fsm
 
 	return nil
 }
@@ -491,8 +658,14 @@ func (a *Agent) StartServer() {
 		}
 		a.Store = s
 	}
+	profile, _ := getStoreProfile(a.config.StoreProfile)
+	if s, ok := a.Store.(*Store); ok {
+		s.compression = a.config.ExecutionCompression
+		s.applyProfile(profile)
+	}
 
 	a.sched = NewScheduler()
+	a.sched.Offset = a.config.ScheduleOffset
 
 	if a.HTTPTransport == nil {
 		a.HTTPTransport = NewTransport(a)
@@ -560,6 +733,7 @@ func (a *Agent) StartServer() {
 		}
 	}()
 	go a.monitorLeadership()
+	go a.monitorQuorum()
 }
 
 // Utility method to get leader nodename
@@ -648,9 +822,13 @@ func (a *Agent) eventLoop() {
 				case serf.EventMemberJoin:
 					a.nodeJoin(me)
 					a.localMemberEvent(me)
+					a.serverMemberEvent(me)
+					a.triggerMemberJobs(me)
 				case serf.EventMemberLeave, serf.EventMemberFailed:
 					a.nodeFailed(me)
 					a.localMemberEvent(me)
+					a.serverMemberEvent(me)
+					a.triggerMemberJobs(me)
 				case serf.EventMemberReap:
 					a.localMemberEvent(me)
 				case serf.EventMemberUpdate, serf.EventUser, serf.EventQuery: // Ignore
@@ -659,6 +837,13 @@ func (a *Agent) eventLoop() {
 				}
 			}
 
+			if q, ok := e.(*serf.Query); ok && q.Name == clockQuery {
+				answerClockQuery(q)
+			}
+			if ue, ok := e.(serf.UserEvent); ok && ue.Name == pressureEvent {
+				a.handlePressureEvent(ue)
+			}
+
 		case <-serfShutdownCh:
 			log.Warn("agent: Serf shutdown detected, quitting")
 			return
@@ -680,21 +865,62 @@ func (a *Agent) join(addrs []string, replay bool) (n int, err error) {
 }
 
 func (a *Agent) processFilteredNodes(job *Job) (map[string]string, map[string]string, error) {
+	members, _ := a.maintenanceMembers(time.Now())
+	members, _ = cordonedMembers(members)
+	shuffle := func(n int, swap func(i, j int)) {
+		rand.Seed(time.Now().UnixNano())
+		rand.Shuffle(n, swap)
+	}
+
+	// Only the nodes of the healthy instances of the Consul service
+	if job.ConsulService != nil {
+		var err error
+		if members, err = a.consulMembers(members, job.ConsulService); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Avoid the nodes saturated by other executions, unless all are
+	nodes, tags, err := filterNodes(unsaturatedMembers(members, job.Resources), job.Tags, a.config.Region, shuffle)
+	if err == nil && len(nodes) == 0 && job.Resources != nil {
+		nodes, tags, err = filterNodes(members, job.Tags, a.config.Region, shuffle)
+		if len(nodes) > 0 {
+			log.WithField("job", job.Name).Warning("agent: All target nodes are saturated, running anyway")
+		}
+	}
+	if err == nil && job.ConsulService != nil {
+		nodes = limitNodes(nodes, job.ConsulService.Count, shuffle)
+	}
+	return nodes, tags, err
+}
+
+// filterNodes returns the alive members matching the job tags and the
+// region, along with their RPC address, and the tags used to filter them.
+// shuffle randomizes the candidates of tags with cardinality.
+func filterNodes(members []serf.Member, jobTags map[string]string, region string, shuffle func(n int, swap func(i, j int))) (map[string]string, map[string]string, error) {
 	// candidates will contain a set of candidates by tags
 	// the final set of nodes will be the intesection of all groups
 	tags := make(map[string]string)
 
 	// Actually copy the map
-	for key, val := range job.Tags {
+	for key, val := range jobTags {
 		tags[key] = val
 	}
 
 	// Always filter by region tag as we currently only target nodes
 	// on the same region.
-	tags["region"] = a.config.Region
+	tags["region"] = region
+
+	// Walk the tags in order so the selection only depends on shuffle
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 
 	candidates := [][]string{}
-	for jtk, jtv := range tags {
+	for _, jtk := range keys {
+		jtv := tags[jtk]
 		cans := []string{}
 		tc := strings.Split(jtv, ":")
 
@@ -703,7 +929,7 @@ func (a *Agent) processFilteredNodes(job *Job) (map[string]string, map[string]st
 		// Set original tag to clean tag
 		tags[jtk] = tv
 
-		for _, member := range a.serf.Members() {
+		for _, member := range members {
 			if member.Status == serf.StatusAlive {
 				for mtk, mtv := range member.Tags {
 					if mtk == jtk && mtv == tv {
@@ -717,8 +943,7 @@ func (a *Agent) processFilteredNodes(job *Job) (map[string]string, map[string]st
 		// or else just add all nodes to the result.
 		if len(tc) == 2 {
 			f := []string{}
-			rand.Seed(time.Now().UnixNano())
-			rand.Shuffle(len(cans), func(i, j int) {
+			shuffle(len(cans), func(i, j int) {
 				cans[i], cans[j] = cans[j], cans[i]
 			})
 
@@ -754,7 +979,7 @@ func (a *Agent) processFilteredNodes(job *Job) (map[string]string, map[string]st
 	}
 
 	for _, n := range r {
-		for _, m := range a.serf.Members() {
+		for _, m := range members {
 			if n == m.Name {
 				// If the server is missing the rpc_addr tag, default to the serf advertise addr
 				if addr, ok := m.Tags["rpc_addr"]; ok {
@@ -790,7 +1015,7 @@ func (a *Agent) applySetJob(job *proto.Job) error {
 	if err != nil {
 		return err
 	}
-	af := a.raft.Apply(cmd, raftTimeout)
+	af := a.RaftApply(cmd)
 	if err := af.Error(); err != nil {
 		return err
 	}
@@ -805,11 +1030,25 @@ func (a *Agent) applySetJob(job *proto.Job) error {
 	return nil
 }
 
-// RaftApply applies a command to the Raft log
+// RaftApply applies a command to the Raft log. Injected store write
+// faults fail the command here, before it's in the log, so every server
+// applies the same commands.
 func (a *Agent) RaftApply(cmd []byte) raft.ApplyFuture {
+	if err := injectStoreWriteFault(); err != nil {
+		return errorFuture{err}
+	}
 	return a.raft.Apply(cmd, raftTimeout)
 }
 
+// errorFuture is the future of a command failed before applying it.
+type errorFuture struct {
+	err error
+}
+
+func (f errorFuture) Error() error          { return f.err }
+func (f errorFuture) Index() uint64         { return 0 }
+func (f errorFuture) Response() interface{} { return nil }
+
 // GetRunningJobs returns amount of active jobs of the local agent
 func (a *Agent) GetRunningJobs() int {
 	job := 0
@@ -836,17 +1075,17 @@ func (a *Agent) GetActiveExecutions() ([]*proto.Execution, error) {
 	return executions, nil
 }
 
-func (a *Agent) recursiveSetJob(jobs []*Job) []string {
+func (a *Agent) recursiveSetJob(jobs []*Job, adminToken string) []string {
 	result := make([]string, 0)
 	for _, job := range jobs {
-		err := a.GRPCClient.SetJob(job)
+		err := a.GRPCClient.SetJob(job, adminToken)
 		if err != nil {
 			result = append(result, "fail create "+job.Name)
 			continue
 		} else {
 			result = append(result, "success create "+job.Name)
 			if len(job.ChildJobs) > 0 {
-				recursiveResult := a.recursiveSetJob(job.ChildJobs)
+				recursiveResult := a.recursiveSetJob(job.ChildJobs, adminToken)
 				result = append(result, recursiveResult...)
 			}
 		}
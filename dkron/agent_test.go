@@ -3,6 +3,7 @@ package dkron
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -101,6 +102,87 @@ func TestAgentCommand_runForElection(t *testing.T) {
 	a3.Stop()
 }
 
+func TestAgentBootstrapExpect(t *testing.T) {
+	newAgent := func(name string, join []string) (*Agent, func()) {
+		dir, err := ioutil.TempDir("", "dkron-test")
+		require.NoError(t, err)
+		ip, returnFn := testutil.TakeIP()
+
+		c := DefaultConfig()
+		c.BindAddr = ip.String()
+		c.StartJoin = join
+		c.NodeName = name
+		c.Server = true
+		c.LogLevel = logLevel
+		c.BootstrapExpect = 2
+		c.DataDir = dir
+		c.ReconcileInterval = time.Second
+
+		a := NewAgent(c)
+		require.NoError(t, a.Start())
+		return a, func() {
+			a.Stop()
+			returnFn()
+			os.RemoveAll(dir)
+		}
+	}
+
+	a1, stop1 := newAgent("test1", nil)
+	defer stop1()
+	a2, stop2 := newAgent("test2", []string{a1.config.BindAddr})
+	defer stop2()
+
+	// Both servers bootstrap the cluster once they found each other
+	for a1.raft.Leader() == "" || a2.raft.Leader() == "" {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A new server expecting the same number of servers finds the existing
+	// cluster and joins it
+	a3, stop3 := newAgent("test3", []string{a1.config.BindAddr})
+	defer stop3()
+
+	for a3.raft.Leader() == "" {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, a1.raft.Leader(), a3.raft.Leader())
+
+	future := a3.raft.GetConfiguration()
+	require.NoError(t, future.Error())
+	assert.Len(t, future.Configuration().Servers, 3)
+}
+
+func TestAgentSlim(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ip, returnFn := testutil.TakeIP()
+	defer returnFn()
+
+	c := DefaultConfig()
+	c.BindAddr = ip.String()
+	c.NodeName = "test-slim"
+	c.Server = true
+	c.Slim = true
+	c.LogLevel = logLevel
+	c.DataDir = filepath.Join(dir, "data")
+
+	// Servers need their store
+	a := NewAgent(c)
+	assert.Error(t, a.Start())
+
+	c.Server = false
+	a = NewAgent(c)
+	require.NoError(t, a.Start())
+	defer a.Stop()
+
+	assert.Nil(t, a.workspaces)
+	assert.Nil(t, a.resultSpool)
+	_, err = os.Stat(c.DataDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
 func Test_processFilteredNodes(t *testing.T) {
 	dir, err := ioutil.TempDir("", "dkron-test")
 	require.NoError(t, err)
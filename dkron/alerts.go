@@ -0,0 +1,163 @@
+package dkron
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+)
+
+// alertmanagerPayload is the body of the webhooks of Prometheus
+// Alertmanager, version 4.
+type alertmanagerPayload struct {
+	Version  string   `json:"version"`
+	Status   string   `json:"status"`
+	Receiver string   `json:"receiver"`
+	Alerts   []*alert `json:"alerts"`
+}
+
+// alert is an alert of an Alertmanager webhook.
+type alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// AlertRun is a run of a job triggered by an alert, with the error if it
+// couldn't run.
+type AlertRun struct {
+	Job   string `json:"job"`
+	Alert string `json:"alert"`
+	Error string `json:"error,omitempty"`
+}
+
+// alertEventTrigger returns the trigger event of the status of the alert,
+// if any.
+func alertEventTrigger(status string) string {
+	switch status {
+	case "firing":
+		return TriggerAlertFiring
+	case "resolved":
+		return TriggerAlertResolved
+	}
+	return ""
+}
+
+// matchesAlert returns whether the event of the alert fires the trigger.
+func (t *MemberTrigger) matchesAlert(event string, a *alert) bool {
+	if t.Event != event || len(t.Labels) == 0 {
+		return false
+	}
+	for k, v := range t.Labels {
+		if a.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// triggeredByAlert returns whether any trigger of the job fires on the
+// event of the alert.
+func (j *Job) triggeredByAlert(event string, a *alert) bool {
+	for _, t := range j.Triggers {
+		if t.matchesAlert(event, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// alertParams returns the params of the executions triggered by the alert.
+func alertParams(a *alert) map[string]string {
+	params := map[string]string{
+		"alert_name":          a.Labels["alertname"],
+		"alert_status":        a.Status,
+		"alert_fingerprint":   a.Fingerprint,
+		"alert_starts_at":     a.StartsAt.Format(time.RFC3339),
+		"alert_generator_url": a.GeneratorURL,
+	}
+	for k, v := range a.Labels {
+		params["alert_label_"+k] = v
+	}
+	for k, v := range a.Annotations {
+		params["alert_annotation_"+k] = v
+	}
+	return params
+}
+
+// alertsHandler receives the webhooks of Alertmanager, running the jobs
+// triggered by their alerts.
+func (h *HTTPTransport) alertsHandler(c *gin.Context) {
+	var payload alertmanagerPayload
+	if err := c.BindJSON(&payload); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	if len(h.agent.config.APITokens) > 0 && !h.isAdmin(c) && h.apiToken(c) == nil {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		c.Writer.WriteString(ErrAPIToken.Error())
+		return
+	}
+
+	jobs, err := h.agent.Store.GetJobs(nil)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	runs := []*AlertRun{}
+	for _, a := range payload.Alerts {
+		event := alertEventTrigger(a.Status)
+		if event == "" {
+			continue
+		}
+		for _, job := range jobs {
+			if job.Disabled || !job.triggeredByAlert(event, a) {
+				continue
+			}
+			run := &AlertRun{Job: job.Name, Alert: a.Fingerprint}
+			runs = append(runs, run)
+
+			if err := h.executorsAllowed(c, job); err != nil {
+				run.Error = err.Error()
+				continue
+			}
+			if err := h.agent.admitJob(job); err != nil {
+				run.Error = err.Error()
+				continue
+			}
+
+			annotation := fmt.Sprintf("%s %s", event, a.Labels["alertname"])
+			if _, err := h.agent.GRPCClient.TriggerJob(job.Name, alertParams(a), []string{annotation}, c.GetString(requestIDKey)); err != nil {
+				run.Error = status.Convert(err).Message()
+				continue
+			}
+			metrics.IncrCounterWithLabels([]string{"alert", "triggered"}, 1, []metrics.Label{{Name: "job", Value: job.Name}})
+		}
+	}
+
+	for _, run := range runs {
+		entry := log.WithFields(logrus.Fields{
+			"job":      run.Job,
+			"alert":    run.Alert,
+			"receiver": payload.Receiver,
+		})
+		if run.Error != "" {
+			entry.WithField("error", run.Error).Warn("api: Skipping job triggered by alert")
+		} else {
+			entry.Info("api: Running job triggered by alert")
+		}
+	}
+
+	renderJSON(c, http.StatusOK, runs)
+}
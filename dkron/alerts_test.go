@@ -0,0 +1,101 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertTriggers(t *testing.T) {
+	job := scaffoldJob()
+	job.Triggers = []*MemberTrigger{
+		{Event: TriggerAlertFiring, Labels: map[string]string{"alertname": "ServiceDown", "service": "api"}},
+	}
+	assert.NoError(t, job.Validate())
+
+	down := &alert{
+		Status:      "firing",
+		Labels:      map[string]string{"alertname": "ServiceDown", "service": "api", "instance": "web3:9100"},
+		Annotations: map[string]string{"summary": "api is down"},
+		StartsAt:    time.Date(2020, 5, 15, 10, 0, 0, 0, time.UTC),
+		Fingerprint: "a1b2c3",
+	}
+	other := &alert{Status: "firing", Labels: map[string]string{"alertname": "ServiceDown", "service": "db"}}
+
+	assert.True(t, job.triggeredByAlert(alertEventTrigger(down.Status), down))
+	assert.False(t, job.triggeredByAlert(alertEventTrigger(other.Status), other))
+	assert.False(t, job.triggeredByAlert(TriggerAlertResolved, down))
+
+	params := alertParams(down)
+	assert.Equal(t, "ServiceDown", params["alert_name"])
+	assert.Equal(t, "firing", params["alert_status"])
+	assert.Equal(t, "a1b2c3", params["alert_fingerprint"])
+	assert.Equal(t, "2020-05-15T10:00:00Z", params["alert_starts_at"])
+	assert.Equal(t, "web3:9100", params["alert_label_instance"])
+	assert.Equal(t, "api is down", params["alert_annotation_summary"])
+
+	assert.Equal(t, job.Triggers, NewJobFromProto(job.ToProto()).Triggers)
+
+	// Alert triggers match labels, not tags
+	job.Triggers = []*MemberTrigger{{Event: TriggerAlertFiring}}
+	assert.Error(t, job.Validate())
+	job.Triggers = []*MemberTrigger{{Event: TriggerAlertFiring, Labels: map[string]string{"service": "api"}, Tags: map[string]string{"role": "web"}}}
+	assert.Error(t, job.Validate())
+	job.Triggers = []*MemberTrigger{{Event: TriggerMemberJoin, Labels: map[string]string{"service": "api"}}}
+	assert.Error(t, job.Validate())
+}
+
+func TestAPIAlerts(t *testing.T) {
+	dir, a := setupAPITest(t, "8144")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:     "restart-api",
+		Schedule: "@manually",
+		Executor: "shell",
+		ExecutorConfig: map[string]string{
+			"command": "echo restarting $DKRON_PARAM_ALERT_LABEL_SERVICE",
+		},
+		Triggers: []*MemberTrigger{
+			{Event: TriggerAlertFiring, Labels: map[string]string{"alertname": "ServiceDown"}},
+		},
+	}
+	require.NoError(t, a.GRPCClient.SetJob(job, ""))
+
+	payload := `{
+		"version": "4",
+		"status": "firing",
+		"receiver": "dkron",
+		"alerts": [
+			{"status": "firing", "labels": {"alertname": "ServiceDown", "service": "api"}, "fingerprint": "a1b2c3"},
+			{"status": "firing", "labels": {"alertname": "HighLatency", "service": "api"}, "fingerprint": "d4e5f6"}
+		]
+	}`
+	resp, err := http.Post("http://localhost:8144/v1/alerts", "application/json", bytes.NewBufferString(payload))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var runs []*AlertRun
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&runs))
+	assert.Equal(t, []*AlertRun{{Job: "restart-api", Alert: "a1b2c3"}}, runs)
+
+	var executions []*Execution
+	for i := 0; i < 100; i++ {
+		executions, _ = a.Store.GetExecutions("restart-api")
+		if len(executions) > 0 && !executions[0].FinishedAt.IsZero() {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.Len(t, executions, 1)
+	assert.Equal(t, "api", executions[0].Params["alert_label_service"])
+	assert.Equal(t, []string{"alert-firing ServiceDown"}, executions[0].Annotations)
+}
@@ -1,13 +1,18 @@
 package dkron
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/gin-contrib/expvar"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
@@ -17,8 +22,23 @@ import (
 
 const (
 	pretty = "pretty"
+
+	// adminTokenHeader is the header carrying the admin token.
+	adminTokenHeader = "X-Dkron-Admin-Token"
+
+	// userHeader is the header naming the user making the request, recorded
+	// in the jobs it changes.
+	userHeader = "X-Dkron-User"
+
+	// requestIDHeader is the header carrying the request ID.
+	requestIDHeader = "X-Request-ID"
+	// requestIDKey is the context key of the request ID.
+	requestIDKey = "request_id"
 )
 
+// validRequestID matches the request IDs accepted from clients.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
 // Transport is the interface that wraps the ServeHTTP method.
 type Transport interface {
 	ServeHTTP()
@@ -57,7 +77,7 @@ func (h *HTTPTransport) ServeHTTP() {
 
 // APIRoutes registers the api routes on the gin RouterGroup.
 func (h *HTTPTransport) APIRoutes(r *gin.RouterGroup, middleware ...gin.HandlerFunc) {
-	r.GET("/debug/vars", expvar.Handler())
+	h.debugRoutes(r)
 
 	h.Engine.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -72,29 +92,136 @@ func (h *HTTPTransport) APIRoutes(r *gin.RouterGroup, middleware ...gin.HandlerF
 
 	r.GET("/v1", h.indexHandler)
 	v1 := r.Group("/v1")
+	v1.Use(h.RequestIDMiddleware())
 	v1.Use(middleware...)
 	v1.GET("/", h.indexHandler)
-	v1.GET("/members", h.membersHandler)
+	v1.GET("/members", h.readMiddleware(), h.membersHandler)
+	v1.POST("/members/match", h.membersMatchHandler)
 	v1.GET("/leader", h.leaderHandler)
 	v1.GET("/isleader", h.isLeaderHandler)
 	v1.POST("/leave", h.leaveHandler)
 	v1.POST("/restore", h.restoreHandler)
 
-	v1.GET("/busy", h.busyHandler)
+	v1.GET("/busy", gzipMiddleware(), h.busyHandler)
+	v1.GET("/overload", h.overloadHandler)
+
+	v1.GET("/readonly", h.readOnlyHandler)
+	v1.PUT("/readonly", h.adminMiddleware(), h.readOnlySetHandler)
+
+	v1.GET("/scheduler", h.schedulerHandler)
+	v1.GET("/scheduler/events", h.schedulerEventsHandler)
+	v1.POST("/scheduler/pause", h.adminMiddleware(), h.schedulerActionHandler(SchedulerPause))
+	v1.POST("/scheduler/resume", h.adminMiddleware(), h.schedulerActionHandler(SchedulerResume))
+
+	v1.POST("/import/crontab", h.importCrontabHandler)
+	v1.POST("/import/systemd", h.importSystemdHandler)
+
+	v1.GET("/trash", h.trashHandler)
+	v1.POST("/trash/:job/restore", h.trashRestoreHandler)
+
+	v1.GET("/maintenance", h.maintenanceHandler)
+	v1.POST("/maintenance", h.maintenanceSetHandler)
+	v1.DELETE("/maintenance/:window", h.maintenanceDeleteHandler)
+	v1.GET("/silences", h.silencesHandler)
+	v1.POST("/silences", h.silenceCreateHandler)
+	v1.DELETE("/silences/:silence", h.silenceDeleteHandler)
+
+	v1.GET("/credentials", h.credentialsHandler)
+	v1.POST("/credentials", h.adminMiddleware(), h.credentialSetHandler)
+	v1.DELETE("/credentials/:credential", h.adminMiddleware(), h.credentialDeleteHandler)
+
+	v1.GET("/holds", h.legalHoldsHandler)
+	v1.GET("/compliance/events", h.complianceEventsHandler)
+	v1.GET("/cdc", h.cdcHandler)
+
+	v1.GET("/fsck", h.fsckHandler)
+	v1.POST("/fsck", h.adminMiddleware(), h.fsckRepairHandler)
+
+	v1.GET("/digest", h.digestHandler)
+	v1.GET("/costs", h.costsHandler)
+
+	v1.GET("/executions/:id", h.readMiddleware(), h.etagMiddleware(), h.executionGetHandler)
+
+	v1.GET("/schedule-macros", h.scheduleMacrosHandler)
+
+	v1.POST("/plugins/reload", h.adminMiddleware(), h.pluginReloadHandler)
+
+	v1.GET("/workflows/:root", h.workflowHandler)
+	v1.GET("/timeline", h.timelineHandler)
+	v1.POST("/conflicts", h.conflictsHandler)
+	v1.POST("/alerts", h.alertsHandler)
+
+	v1.GET("/locks", h.locksHandler)
+	v1.GET("/locks/:name", h.lockGetHandler)
+	v1.POST("/locks/:name/acquire", h.lockAcquireHandler)
+	v1.POST("/locks/:name/renew", h.lockRenewHandler)
+	v1.POST("/locks/:name/release", h.lockReleaseHandler)
+
+	v1.GET("/kv/:namespace/*key", h.kvGetHandler)
+	v1.PUT("/kv/:namespace/*key", h.kvPutHandler)
+	v1.DELETE("/kv/:namespace/*key", h.kvDeleteHandler)
+
+	h.chaosRoutes(v1)
 
 	v1.POST("/jobs", h.jobCreateOrUpdateHandler)
 	v1.PATCH("/jobs", h.jobCreateOrUpdateHandler)
 	// Place fallback routes last
-	v1.GET("/jobs", h.jobsHandler)
+	v1.GET("/jobs", gzipMiddleware(), h.readMiddleware(), h.etagMiddleware(), h.jobsHandler)
 
 	jobs := v1.Group("/jobs")
 	jobs.DELETE("/:job", h.jobDeleteHandler)
 	jobs.POST("/:job", h.jobRunHandler)
 	jobs.POST("/:job/toggle", h.jobToggleHandler)
+	jobs.POST("/:job/reset", h.jobResetHandler)
+	jobs.POST("/:job/clone", h.jobCloneHandler)
+	jobs.POST("/:job/backfill", h.jobBackfillHandler)
+	jobs.POST("/:job/shadow", h.jobShadowRunHandler)
+	jobs.POST("/:job/hold", h.complianceHandler(ComplianceHold))
+	jobs.POST("/:job/hold/release", h.adminMiddleware(), h.complianceHandler(ComplianceRelease))
+	jobs.POST("/:job/purge", h.adminMiddleware(), h.complianceHandler(CompliancePurge))
+	jobs.POST("/:job/canary/promote", h.jobCanaryEndHandler(true))
+	jobs.DELETE("/:job/canary", h.jobCanaryEndHandler(false))
+	jobs.DELETE("/:job/backfills/:backfill", h.backfillCancelHandler)
 
 	// Place fallback routes last
-	jobs.GET("/:job", h.jobGetHandler)
-	jobs.GET("/:job/executions", h.executionsHandler)
+	jobs.GET("/:job", gzipMiddleware(), h.readMiddleware(), h.etagMiddleware(), h.jobGetHandler)
+	jobs.GET("/:job/executions", gzipMiddleware(), h.readMiddleware(), h.etagMiddleware(), h.executionsHandler)
+	jobs.GET("/:job/executions/:execution", h.readMiddleware(), h.etagMiddleware(), h.executionViewHandler)
+	jobs.GET("/:job/export", h.jobExportHandler)
+	jobs.GET("/:job/explain", h.jobExplainHandler)
+	jobs.GET("/:job/impact", h.jobImpactHandler)
+	jobs.GET("/:job/hold", h.legalHoldHandler)
+	jobs.GET("/:job/backfills", h.backfillsHandler)
+	jobs.GET("/:job/shadow", h.jobShadowExecutionsHandler)
+	jobs.GET("/:job/canary", h.jobCanaryHandler)
+	jobs.GET("/:job/matrix", h.jobMatrixHandler)
+	jobs.GET("/:job/backfills/:backfill", h.backfillGetHandler)
+	jobs.POST("/:job/executions/:execution/annotations", h.executionAnnotateHandler)
+	jobs.GET("/:job/executions/:execution/artifacts", h.executionArtifactsHandler)
+}
+
+// RequestIDMiddleware takes the request ID from the X-Request-ID header or
+// generates one, it's returned in the response and stored in the executions
+// triggered by the request.
+func (h *HTTPTransport) RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+// newRequestID returns a random request ID.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(b)
 }
 
 // MetaMiddleware adds middleware to the gin Context.
@@ -125,6 +252,18 @@ func (h *HTTPTransport) indexHandler(c *gin.Context) {
 		"tags": local.Tags,
 	}
 
+	// The pause of the scheduler is shown as a banner by clients
+	scheduler := map[string]string{"paused": "false"}
+	if pause, err := h.agent.Store.SchedulerPause(); err == nil && pause != nil {
+		scheduler = map[string]string{
+			"paused":    "true",
+			"paused_by": pause.User,
+			"paused_at": pause.At.Format(time.RFC3339),
+			"reason":    pause.Reason,
+		}
+	}
+	stats["scheduler"] = scheduler
+
 	renderJSON(c, http.StatusOK, stats)
 }
 
@@ -140,15 +279,44 @@ func (h *HTTPTransport) jobsHandler(c *gin.Context) {
 		log.WithError(err).Error("api: Unable to get jobs, store not reachable.")
 		return
 	}
-	// Ask all server peers for connections
-	// Range through jobs and assing running based on peers connections
+	h.setStatuses(jobs...)
 
-	renderJSON(c, http.StatusOK, jobs)
+	renderRead(c, http.StatusOK, jobs)
+}
+
+// setStatuses sets the status of the jobs from their last execution group,
+// running ones included, keeping the status of tripped jobs.
+func (h *HTTPTransport) setStatuses(jobs ...*Job) {
+	statuses, err := h.agent.Store.GetStatuses()
+	if err != nil {
+		log.WithError(err).Error("api: Unable to compute job statuses")
+		return
+	}
+	for _, job := range jobs {
+		if status, ok := statuses[job.Name]; ok && job.Status != StatusTripped {
+			job.Status = status
+		}
+	}
 }
 
 func (h *HTTPTransport) jobGetHandler(c *gin.Context) {
 	jobName := c.Param("job")
 
+	// The router doesn't allow a static route next to the job param,
+	// search is served here, its name is reserved for jobs.
+	if jobName == "search" {
+		h.jobSearchHandler(c)
+		return
+	}
+
+	// Past versions of the job are served from the version history
+	_, asOf := c.GetQuery("as_of")
+	_, version := c.GetQuery("version")
+	if asOf || version {
+		h.jobVersionHandler(c)
+		return
+	}
+
 	job, err := h.agent.Store.GetJob(jobName, nil)
 	if err != nil {
 		log.Error(err)
@@ -157,7 +325,22 @@ func (h *HTTPTransport) jobGetHandler(c *gin.Context) {
 		c.AbortWithStatus(http.StatusNotFound)
 		return
 	}
-	renderJSON(c, http.StatusOK, job)
+	h.setStatuses(job)
+	renderRead(c, http.StatusOK, job)
+}
+
+func (h *HTTPTransport) jobSearchHandler(c *gin.Context) {
+	_, regex := c.GetQuery("regex")
+
+	jobs, err := h.agent.Store.SearchJobs(c.Query("q"), regex)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Invalid query: %s.", err))
+		return
+	}
+	h.setStatuses(jobs...)
+
+	renderRead(c, http.StatusOK, jobs)
 }
 
 func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
@@ -180,8 +363,46 @@ func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
 		return
 	}
 
+	// Reject unowned jobs
+	if err := job.ValidateOwner(h.agent.config.RequiredOwnerFields); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Job contains invalid value: %s.", err))
+		return
+	}
+
+	// Reject untrusted specs
+	if err := h.agent.verifyJobSignature(&job); err != nil {
+		c.AbortWithStatus(http.StatusForbidden)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	// Reject jobs denied by the job policies
+	if err := h.agent.admitJob(&job); err != nil {
+		c.AbortWithStatus(http.StatusForbidden)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	if !h.checkWritable(c, job.Name) || !h.checkExecutors(c, &job) {
+		return
+	}
+
+	// Keep the current spec running while the canary rolls out the new one
+	if job.Canary != nil {
+		current, _ := h.agent.Store.GetJob(job.Name, nil)
+		canary, err := job.Canary.update(&job, current, time.Now())
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			c.Writer.WriteString(fmt.Sprintf("Job contains invalid value: %s.", err))
+			return
+		}
+		job.Canary = canary
+	}
+	h.stampJob(c, &job)
+
 	// Call gRPC SetJob
-	if err := h.agent.GRPCClient.SetJob(&job); err != nil {
+	if err := h.agent.GRPCClient.SetJob(&job, c.GetHeader(adminTokenHeader)); err != nil {
 		s := status.Convert(err)
 		if s.Message() == ErrParentJobNotFound.Error() {
 			c.AbortWithStatus(http.StatusNotFound)
@@ -194,7 +415,9 @@ func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
 
 	// Immediately run the job if so requested
 	if _, exists := c.GetQuery("runoncreate"); exists {
-		h.agent.GRPCClient.RunJob(job.Name)
+		log.WithField("job", job.Name).WithField("request_id", c.GetString(requestIDKey)).
+			Info("api: Running job on create")
+		h.agent.GRPCClient.RunJob(job.Name, nil, c.GetString(requestIDKey))
 	}
 
 	c.Header("Location", fmt.Sprintf("%s/%s", c.Request.RequestURI, job.Name))
@@ -204,9 +427,23 @@ func (h *HTTPTransport) jobCreateOrUpdateHandler(c *gin.Context) {
 func (h *HTTPTransport) jobDeleteHandler(c *gin.Context) {
 	jobName := c.Param("job")
 
+	cascade := c.Query("cascade") == "true"
+
+	// Every job of the tree must be writable when cascading
+	names := h.agent.deletedJobNames(jobName, cascade)
+	if !h.checkWritable(c, names...) {
+		return
+	}
+
 	// Call gRPC DeleteJob
-	job, err := h.agent.GRPCClient.DeleteJob(jobName)
+	job, err := h.agent.GRPCClient.DeleteJob(jobName, cascade, c.GetHeader(adminTokenHeader))
 	if err != nil {
+		s := status.Convert(err)
+		if strings.HasPrefix(s.Message(), ErrDependentJobs.Error()) {
+			c.AbortWithStatus(http.StatusConflict)
+			c.Writer.WriteString(s.Message())
+			return
+		}
 		c.AbortWithError(http.StatusNotFound, err)
 		return
 	}
@@ -215,9 +452,38 @@ func (h *HTTPTransport) jobDeleteHandler(c *gin.Context) {
 
 func (h *HTTPTransport) jobRunHandler(c *gin.Context) {
 	jobName := c.Param("job")
+	annotations := c.QueryArray("annotation")
+
+	if pause, err := h.agent.Store.SchedulerPause(); err != nil || pause != nil {
+		c.AbortWithStatus(http.StatusConflict)
+		c.Writer.WriteString(ErrSchedulerPaused.Error())
+		return
+	}
+
+	// Jobs set before the job policies are checked when run
+	if job, err := h.agent.Store.GetJob(jobName, nil); err == nil {
+		if !h.checkExecutors(c, job) {
+			return
+		}
+		if err := h.agent.admitJob(job); err != nil {
+			c.AbortWithStatus(http.StatusForbidden)
+			c.Writer.WriteString(err.Error())
+			return
+		}
+		if err := h.agent.checkMinInterval(job, time.Now()); err != nil {
+			c.AbortWithStatus(http.StatusTooManyRequests)
+			c.Writer.WriteString(err.Error())
+			return
+		}
+		if err := checkBudget(job, time.Now()); err != nil {
+			c.AbortWithStatus(http.StatusTooManyRequests)
+			c.Writer.WriteString(err.Error())
+			return
+		}
+	}
 
 	// Call gRPC RunJob
-	job, err := h.agent.GRPCClient.RunJob(jobName)
+	job, err := h.agent.GRPCClient.RunJob(jobName, annotations, c.GetString(requestIDKey))
 	if err != nil {
 		c.AbortWithError(http.StatusNotFound, err)
 		return
@@ -250,12 +516,30 @@ func (h *HTTPTransport) restoreHandler(c *gin.Context) {
 		return
 	}
 
+	names := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		if err := h.agent.verifyJobSignature(job); err != nil {
+			c.AbortWithStatus(http.StatusForbidden)
+			c.Writer.WriteString(fmt.Sprintf("%s: %s", job.Name, err))
+			return
+		}
+		if err := h.agent.admitJob(job); err != nil {
+			c.AbortWithStatus(http.StatusForbidden)
+			c.Writer.WriteString(fmt.Sprintf("%s: %s", job.Name, err))
+			return
+		}
+		names = append(names, job.Name)
+	}
+	if !h.checkWritable(c, names...) || !h.checkExecutors(c, jobs...) {
+		return
+	}
+
 	jobTree, err := generateJobTree(jobs)
 	if err != nil {
 		c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
-	result := h.agent.recursiveSetJob(jobTree)
+	result := h.agent.recursiveSetJob(jobTree, c.GetHeader(adminTokenHeader))
 	resp, err := json.Marshal(result)
 	if err != nil {
 		c.AbortWithError(http.StatusBadRequest, err)
@@ -283,7 +567,123 @@ func (h *HTTPTransport) executionsHandler(c *gin.Context) {
 		return
 
 	}
-	renderJSON(c, http.StatusOK, executions)
+
+	if annotation := c.Query("annotation"); annotation != "" {
+		filtered := []*Execution{}
+		for _, ex := range executions {
+			if ex.HasAnnotation(annotation) {
+				filtered = append(filtered, ex)
+			}
+		}
+		executions = filtered
+	}
+
+	renderRead(c, http.StatusOK, executions)
+}
+
+// executionViewHandler serves the views over the executions of the job.
+// The router doesn't allow static routes next to the execution param, the
+// views are served here and executions aren't found.
+func (h *HTTPTransport) executionViewHandler(c *gin.Context) {
+	switch c.Param("execution") {
+	case "summary":
+		h.executionSummaryHandler(c)
+	case "diff":
+		h.executionDiffHandler(c)
+	default:
+		c.AbortWithStatus(http.StatusNotFound)
+	}
+}
+
+// executionAnnotateHandler appends the annotations in the request body,
+// a JSON array of strings, to the given execution.
+func (h *HTTPTransport) executionAnnotateHandler(c *gin.Context) {
+	jobName := c.Param("job")
+	key := c.Param("execution")
+
+	var annotations []string
+	if err := c.BindJSON(&annotations); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	execution, err := h.getExecution(jobName, key)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	// The leader appends them to the stored execution, keeping the ones
+	// made meanwhile
+	execution, err = h.agent.GRPCClient.AnnotateExecution(jobName, execution.Key(), annotations)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, execution)
+}
+
+// jobExplainHandler describes the next run of the job, the nodes it
+// targets and why the others don't match.
+func (h *HTTPTransport) jobExplainHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	explanation, err := h.agent.explainJob(job)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, explanation)
+}
+
+// executionArtifactsHandler returns the metadata of the files produced
+// by the given execution.
+func (h *HTTPTransport) executionArtifactsHandler(c *gin.Context) {
+	execution, err := h.getExecution(c.Param("job"), c.Param("execution"))
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	artifacts := execution.Artifacts
+	if artifacts == nil {
+		artifacts = []*Artifact{}
+	}
+	renderJSON(c, http.StatusOK, artifacts)
+}
+
+// getExecution returns the execution of the job with the given ID or key.
+func (h *HTTPTransport) getExecution(jobName, id string) (*Execution, error) {
+	executions, err := h.agent.Store.GetExecutions(jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ex := range executions {
+		if ex.Id == id || ex.Key() == id {
+			return ex, nil
+		}
+	}
+	return nil, buntdb.ErrNotFound
+}
+
+func (h *HTTPTransport) executionGetHandler(c *gin.Context) {
+	execution, err := h.agent.Store.GetExecutionByID(c.Param("id"))
+	if err == buntdb.ErrNotFound {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderRead(c, http.StatusOK, execution)
 }
 
 func (h *HTTPTransport) membersHandler(c *gin.Context) {
@@ -326,11 +726,16 @@ func (h *HTTPTransport) jobToggleHandler(c *gin.Context) {
 		return
 	}
 
+	if !h.checkWritable(c, jobName) {
+		return
+	}
+
 	// Toggle job status
 	job.Disabled = !job.Disabled
+	h.stampJob(c, job)
 
 	// Call gRPC SetJob
-	if err := h.agent.GRPCClient.SetJob(job); err != nil {
+	if err := h.agent.GRPCClient.SetJob(job, c.GetHeader(adminTokenHeader)); err != nil {
 		c.AbortWithError(http.StatusUnprocessableEntity, err)
 		return
 	}
@@ -339,6 +744,572 @@ func (h *HTTPTransport) jobToggleHandler(c *gin.Context) {
 	renderJSON(c, http.StatusOK, job)
 }
 
+// jobCloneHandler creates a copy of a job, the request body holds the
+// name of the new job and the fields to override, like in a job update.
+func (h *HTTPTransport) jobCloneHandler(c *gin.Context) {
+	source, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	job := source.Clone("")
+	if err := c.BindJSON(job); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	if job.Name == "" || job.Name == source.Name {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(ErrCloneName.Error())
+		return
+	}
+
+	// Validate job
+	if err := job.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Job contains invalid value: %s.", err))
+		return
+	}
+
+	// Reject unowned jobs
+	if err := job.ValidateOwner(h.agent.config.RequiredOwnerFields); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Job contains invalid value: %s.", err))
+		return
+	}
+
+	// Reject untrusted specs
+	if err := h.agent.verifyJobSignature(job); err != nil {
+		c.AbortWithStatus(http.StatusForbidden)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	// Reject jobs denied by the job policies
+	if err := h.agent.admitJob(job); err != nil {
+		c.AbortWithStatus(http.StatusForbidden)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	if j, _ := h.agent.Store.GetJob(job.Name, nil); j != nil {
+		c.AbortWithStatus(http.StatusConflict)
+		c.Writer.WriteString(ErrJobExists.Error())
+		return
+	}
+
+	if !h.checkWritable(c, job.Name) || !h.checkExecutors(c, job) {
+		return
+	}
+	h.stampJob(c, job)
+
+	// Call gRPC SetJob
+	if err := h.agent.GRPCClient.SetJob(job, c.GetHeader(adminTokenHeader)); err != nil {
+		s := status.Convert(err)
+		if s.Message() == ErrParentJobNotFound.Error() {
+			c.AbortWithStatus(http.StatusNotFound)
+		} else {
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/v1/jobs/%s", job.Name))
+	renderJSON(c, http.StatusCreated, job)
+}
+
+// jobExportHandler converts the job to the format given in the query
+// string, a crontab entry or a Kubernetes CronJob manifest.
+func (h *HTTPTransport) jobExportHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	format := c.Query("format")
+	out, err := job.Export(format, c.Query("image"))
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if format == ExportK8sCronJob {
+		contentType = "application/yaml"
+	}
+	c.Data(http.StatusOK, contentType, out)
+}
+
+func (h *HTTPTransport) jobResetHandler(c *gin.Context) {
+	jobName := c.Param("job")
+
+	if !h.checkWritable(c, jobName) {
+		return
+	}
+
+	// Call gRPC ResetJob
+	job, err := h.agent.GRPCClient.ResetJob(jobName, c.GetHeader(adminTokenHeader))
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	c.Header("Location", c.Request.RequestURI)
+	renderJSON(c, http.StatusOK, job)
+}
+
+// importOptions reads the import options from the query string.
+func importOptions(c *gin.Context) *ImportOptions {
+	options := &ImportOptions{
+		Prefix: c.Query("prefix"),
+		System: c.Query("system") == "true",
+	}
+	for _, tag := range c.QueryArray("tag") {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) == 2 {
+			if options.Tags == nil {
+				options.Tags = make(map[string]string)
+			}
+			options.Tags[kv[0]] = kv[1]
+		}
+	}
+	return options
+}
+
+// importCrontabHandler creates jobs from the crontab in the request body.
+func (h *HTTPTransport) importCrontabHandler(c *gin.Context) {
+	result, err := ParseCrontab(c.Request.Body, importOptions(c))
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	h.importJobs(c, result)
+}
+
+// importSystemdHandler creates jobs from the systemd timer and service
+// unit files sent as multipart form files.
+func (h *HTTPTransport) importSystemdHandler(c *gin.Context) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	units := make(map[string]string)
+	for _, fh := range form.File["file"] {
+		f, err := fh.Open()
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		data, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		units[fh.Filename] = string(data)
+	}
+
+	result, err := ParseSystemdUnits(units, importOptions(c))
+	if err != nil {
+		c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	h.importJobs(c, result)
+}
+
+// importJobs creates the imported jobs, skipping the ones that exist or
+// fail, unless dry_run is requested.
+func (h *HTTPTransport) importJobs(c *gin.Context, result *ImportResult) {
+	if c.Query("dry_run") == "true" {
+		renderJSON(c, http.StatusOK, result)
+		return
+	}
+
+	if !h.checkWritable(c) {
+		return
+	}
+
+	jobs := result.Jobs
+	result.Jobs = []*Job{}
+	for _, job := range jobs {
+		if err := job.ValidateOwner(h.agent.config.RequiredOwnerFields); err != nil {
+			result.skip(job.Name, "%s", err)
+			continue
+		}
+		if err := h.agent.verifyJobSignature(job); err != nil {
+			result.skip(job.Name, "%s", err)
+			continue
+		}
+		if err := h.agent.admitJob(job); err != nil {
+			result.skip(job.Name, "%s", err)
+			continue
+		}
+		if err := h.executorsAllowed(c, job); err != nil {
+			result.skip(job.Name, "%s", err)
+			continue
+		}
+		if j, _ := h.agent.Store.GetJob(job.Name, nil); j != nil {
+			result.skip(job.Name, "%s", ErrJobExists)
+			continue
+		}
+		h.stampJob(c, job)
+		// Call gRPC SetJob
+		if err := h.agent.GRPCClient.SetJob(job, c.GetHeader(adminTokenHeader)); err != nil {
+			result.skip(job.Name, "%s", status.Convert(err).Message())
+			continue
+		}
+		result.Jobs = append(result.Jobs, job)
+	}
+
+	renderJSON(c, http.StatusCreated, result)
+}
+
+func (h *HTTPTransport) trashHandler(c *gin.Context) {
+	trash, err := h.agent.Store.GetTrash()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, trash)
+}
+
+func (h *HTTPTransport) jobBackfillHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+	if !h.checkExecutors(c, job) {
+		return
+	}
+
+	var backfill Backfill
+	if err := c.BindJSON(&backfill); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+	backfill.JobName = job.Name
+	backfill.RequestID = c.GetString(requestIDKey)
+
+	if _, err := backfillTicks(job, backfill.From, backfill.To); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Backfill contains invalid value: %s.", err))
+		return
+	}
+
+	// Call gRPC Backfill
+	b, err := h.agent.GRPCClient.Backfill(&backfill)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		c.Writer.WriteString(status.Convert(err).Message())
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("%s/backfills/%s", strings.TrimSuffix(c.Request.URL.Path, "/backfill"), b.ID))
+	renderJSON(c, http.StatusCreated, b)
+}
+
+func (h *HTTPTransport) backfillsHandler(c *gin.Context) {
+	backfills, err := h.agent.Store.GetBackfills(c.Param("job"))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, backfills)
+}
+
+func (h *HTTPTransport) backfillGetHandler(c *gin.Context) {
+	backfill, err := h.agent.Store.GetBackfill(c.Param("job"), c.Param("backfill"))
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, backfill)
+}
+
+func (h *HTTPTransport) backfillCancelHandler(c *gin.Context) {
+	// Call gRPC CancelBackfill
+	backfill, err := h.agent.GRPCClient.CancelBackfill(c.Param("job"), c.Param("backfill"))
+	if err != nil {
+		s := status.Convert(err)
+		switch s.Message() {
+		case ErrBackfillNotFound.Error():
+			c.AbortWithStatus(http.StatusNotFound)
+		case ErrBackfillNotRunning.Error():
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+
+	renderJSON(c, http.StatusOK, backfill)
+}
+
+func (h *HTTPTransport) trashRestoreHandler(c *gin.Context) {
+	jobName := c.Param("job")
+
+	if !h.checkWritable(c, jobName) {
+		return
+	}
+
+	// Call gRPC RestoreJob
+	job, err := h.agent.GRPCClient.RestoreJob(jobName, c.GetHeader(adminTokenHeader))
+	if err != nil {
+		s := status.Convert(err)
+		switch s.Message() {
+		case buntdb.ErrNotFound.Error():
+			c.AbortWithStatus(http.StatusNotFound)
+		case ErrJobExists.Error():
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+
+	renderJSON(c, http.StatusOK, job)
+}
+
+func (h *HTTPTransport) maintenanceHandler(c *gin.Context) {
+	windows, err := h.agent.Store.GetMaintenanceWindows()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, windows)
+}
+
+func (h *HTTPTransport) maintenanceSetHandler(c *gin.Context) {
+	var window MaintenanceWindow
+	if err := c.BindJSON(&window); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+	if window.Policy == "" {
+		window.Policy = MaintenanceSkip
+	}
+
+	if err := window.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Maintenance window contains invalid value: %s.", err))
+		return
+	}
+
+	// Call gRPC SetMaintenanceWindow
+	if err := h.agent.GRPCClient.SetMaintenanceWindow(&window); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		c.Writer.WriteString(status.Convert(err).Message())
+		return
+	}
+
+	log.WithField("window", window.Name).Info("api: Maintenance window set")
+	renderJSON(c, http.StatusCreated, &window)
+}
+
+func (h *HTTPTransport) maintenanceDeleteHandler(c *gin.Context) {
+	// Call gRPC DeleteMaintenanceWindow
+	window, err := h.agent.GRPCClient.DeleteMaintenanceWindow(c.Param("window"))
+	if err != nil {
+		s := status.Convert(err)
+		if s.Message() == buntdb.ErrNotFound.Error() {
+			c.AbortWithStatus(http.StatusNotFound)
+		} else {
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+
+	renderJSON(c, http.StatusOK, window)
+}
+
+// requestUser returns the user making the request, the client address if
+// the request doesn't name one.
+func requestUser(c *gin.Context) string {
+	if user := strings.TrimSpace(c.GetHeader(userHeader)); user != "" {
+		return user
+	}
+	return c.ClientIP()
+}
+
+// stampJob records the request changing the job in it, and when and by
+// whom the job was created, which the store keeps for existing jobs.
+func (h *HTTPTransport) stampJob(c *gin.Context, job *Job) {
+	user := requestUser(c)
+	job.UpdatedAt.Set(time.Now())
+	job.UpdatedBy = user
+
+	if ej, err := h.agent.Store.GetJob(job.Name, nil); err == nil {
+		job.CreatedAt = ej.CreatedAt
+		job.CreatedBy = ej.CreatedBy
+	} else {
+		job.CreatedAt = job.UpdatedAt
+		job.CreatedBy = user
+	}
+}
+
+// isAdmin returns whether the request carries the admin token.
+func (h *HTTPTransport) isAdmin(c *gin.Context) bool {
+	return h.agent.isAdminToken(c.GetHeader(adminTokenHeader))
+}
+
+// checkWritable aborts the request if the given jobs can't be changed,
+// because the cluster is read-only or some of them are locked, unless
+// the request carries the admin token.
+func (h *HTTPTransport) checkWritable(c *gin.Context, jobNames ...string) bool {
+	if h.isAdmin(c) {
+		return true
+	}
+
+	readOnly, err := h.agent.Store.ReadOnly()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return false
+	}
+	if readOnly {
+		c.AbortWithStatus(http.StatusForbidden)
+		c.Writer.WriteString(ErrReadOnly.Error())
+		return false
+	}
+
+	for _, name := range jobNames {
+		job, err := h.agent.Store.GetJob(name, nil)
+		if err != nil {
+			// New jobs are not locked
+			continue
+		}
+		if job.Locked {
+			c.AbortWithStatus(http.StatusLocked)
+			c.Writer.WriteString(fmt.Sprintf("%s: %s", name, ErrJobLocked))
+			return false
+		}
+		if !h.checkExecutors(c, job) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (h *HTTPTransport) readOnlyHandler(c *gin.Context) {
+	readOnly, err := h.agent.Store.ReadOnly()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, gin.H{"read_only": readOnly})
+}
+
+func (h *HTTPTransport) readOnlySetHandler(c *gin.Context) {
+	var body struct {
+		ReadOnly bool `json:"read_only"`
+	}
+	if err := c.BindJSON(&body); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	// Call gRPC SetReadOnly
+	if err := h.agent.GRPCClient.SetReadOnly(body.ReadOnly); err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	log.WithField("read_only", body.ReadOnly).Info("api: Read-only mode changed")
+	renderJSON(c, http.StatusOK, gin.H{"read_only": body.ReadOnly})
+}
+
+func (h *HTTPTransport) fsckHandler(c *gin.Context) {
+	report, err := h.agent.Store.Check()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, report)
+}
+
+func (h *HTTPTransport) fsckRepairHandler(c *gin.Context) {
+	if !h.checkWritable(c) {
+		return
+	}
+
+	// Call gRPC CheckStore on the leader
+	report, err := h.agent.GRPCClient.CheckStore(string(h.agent.raft.Leader()), true, c.GetHeader(adminTokenHeader))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, report)
+}
+
+// digestHandler returns the activity digests that the leader sends,
+// for the given period and namespace.
+func (h *HTTPTransport) digestHandler(c *gin.Context) {
+	period := h.agent.config.DigestPeriod
+	if p := c.Query("period"); p != "" {
+		d, err := time.ParseDuration(p)
+		if err != nil || d <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid period %q", p)})
+			return
+		}
+		period = d
+	}
+
+	digests, err := BuildDigests(h.agent.Store, time.Now(), period)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	if ns := c.Query("namespace"); ns != "" {
+		filtered := []*Digest{}
+		for _, d := range digests {
+			if d.Namespace == ns {
+				filtered = append(filtered, d)
+			}
+		}
+		digests = filtered
+	}
+
+	if c.Query("format") == "text" {
+		var out strings.Builder
+		for _, d := range digests {
+			text, err := d.Text()
+			if err != nil {
+				c.AbortWithError(http.StatusInternalServerError, err)
+				return
+			}
+			out.WriteString(text + "\n")
+		}
+		c.String(http.StatusOK, out.String())
+		return
+	}
+
+	renderJSON(c, http.StatusOK, digests)
+}
+
 func (h *HTTPTransport) busyHandler(c *gin.Context) {
 	executions := []*Execution{}
 
@@ -356,5 +1327,5 @@ func (h *HTTPTransport) busyHandler(c *gin.Context) {
 		return executions[i].StartedAt.Before(executions[j].StartedAt)
 	})
 
-	renderJSON(c, http.StatusOK, executions)
+	renderRead(c, http.StatusOK, executions)
 }
@@ -52,8 +52,9 @@ func setupAPITest(t *testing.T, port string) (dir string, a *Agent) {
 func TestAPIJobCreateUpdate(t *testing.T) {
 	port := "8091"
 	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
-	dir, _ := setupAPITest(t, port)
+	dir, a := setupAPITest(t, port)
 	defer os.RemoveAll(dir)
+	defer a.Stop()
 
 	jsonStr := []byte(`{
 		"name": "test_job",
@@ -85,7 +86,10 @@ func TestAPIJobCreateUpdate(t *testing.T) {
 		"executor_config": {"command": "test"},
 		"disabled": false
 	}`)
-	resp, err = http.Post(baseURL+"/jobs", "encoding/json", bytes.NewBuffer(jsonStr1))
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/jobs", bytes.NewBuffer(jsonStr1))
+	require.NoError(t, err)
+	req.Header.Set("X-Dkron-User", "jane")
+	resp, err = http.DefaultClient.Do(req)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -102,6 +106,18 @@ func TestAPIJobCreateUpdate(t *testing.T) {
 	assert.False(t, overwriteJob.Disabled)
 	assert.NotEqual(t, origJob.ExecutorConfig["command"], overwriteJob.ExecutorConfig["command"])
 	assert.Equal(t, "test", overwriteJob.ExecutorConfig["command"])
+
+	// The creation is kept, the update is recorded
+	assert.Equal(t, "127.0.0.1", origJob.CreatedBy)
+	assert.Equal(t, origJob.CreatedBy, overwriteJob.CreatedBy)
+	assert.True(t, origJob.CreatedAt.Get().Equal(overwriteJob.CreatedAt.Get()))
+	assert.Equal(t, "jane", overwriteJob.UpdatedBy)
+	assert.True(t, overwriteJob.UpdatedAt.Get().After(origJob.UpdatedAt.Get()))
+
+	stored, err := a.Store.GetJob("test_job", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", stored.CreatedBy)
+	assert.Equal(t, "jane", stored.UpdatedBy)
 }
 
 func TestAPIJobCreateUpdateParentJob_SameParent(t *testing.T) {
@@ -315,6 +331,289 @@ func TestAPIJobRestore(t *testing.T) {
 
 }
 
+func TestAPIExecutionAnnotations(t *testing.T) {
+	port := "8110"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	resp, err := http.Post(baseURL+"/jobs", "encoding/json", bytes.NewBufferString(`{
+		"name": "test_job",
+		"schedule": "@every 1m",
+		"executor": "shell",
+		"executor_config": {"command": "date"},
+		"disabled": true
+	}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ex := &Execution{
+		JobName:    "test_job",
+		StartedAt:  time.Now().UTC(),
+		FinishedAt: time.Now().UTC(),
+		NodeName:   "test",
+	}
+	_, err = a.Store.SetExecution(ex)
+	require.NoError(t, err)
+
+	resp, err = http.Post(baseURL+"/jobs/test_job/executions/"+ex.Key()+"/annotations",
+		"encoding/json", bytes.NewBufferString(`["re-run after incident INC-123"]`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/jobs/test_job/executions?annotation=INC-123")
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	var executions []Execution
+	require.NoError(t, json.Unmarshal(body, &executions))
+	require.Len(t, executions, 1)
+	assert.Equal(t, []string{"re-run after incident INC-123"}, executions[0].Annotations)
+
+	resp, err = http.Get(baseURL + "/jobs/test_job/executions?annotation=INC-456")
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	require.NoError(t, json.Unmarshal(body, &executions))
+	assert.Len(t, executions, 0)
+	// Executions with an ID are found by it
+	n := time.Now().UTC()
+	ex = &Execution{JobName: "test_job", StartedAt: n, FinishedAt: n, NodeName: "test", Id: newULID(n)}
+	_, err = a.Store.SetExecution(ex)
+	require.NoError(t, err)
+
+	resp, err = http.Post(baseURL+"/jobs/test_job/executions/"+ex.Id+"/annotations",
+		"encoding/json", bytes.NewBufferString(`["paged"]`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/executions/" + ex.Id)
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var found Execution
+	require.NoError(t, json.Unmarshal(body, &found))
+	assert.Equal(t, ex.Id, found.Id)
+	assert.Equal(t, []string{"paged"}, found.Annotations)
+
+	resp, err = http.Get(baseURL + "/executions/" + newULID(n))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPIReadOnlyAndLockedJobs(t *testing.T) {
+	port := "8111"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.AdminToken = "s3cret"
+
+	do := func(method, url, body string, admin bool) int {
+		req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if admin {
+			req.Header.Set(adminTokenHeader, "s3cret")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	job := `{
+		"name": "test_job",
+		"schedule": "@every 1m",
+		"executor": "shell",
+		"executor_config": {"command": "date"},
+		"disabled": true,
+		"locked": true
+	}`
+	unlocked := `{
+		"name": "test_job",
+		"schedule": "@every 1m",
+		"executor": "shell",
+		"executor_config": {"command": "date"},
+		"disabled": true
+	}`
+
+	// Anyone can lock a job, only the admin can change it afterwards
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, baseURL+"/jobs", job, false))
+	assert.Equal(t, http.StatusLocked, do(http.MethodPost, baseURL+"/jobs", unlocked, false))
+	assert.Equal(t, http.StatusLocked, do(http.MethodPost, baseURL+"/jobs/test_job/toggle", "", false))
+	assert.Equal(t, http.StatusLocked, do(http.MethodDelete, baseURL+"/jobs/test_job", "", false))
+
+	// The leader checks the changes sent by other nodes too
+	_, err := a.GRPCClient.DeleteJob("test_job", false, "")
+	assert.Error(t, err)
+	_, err = a.Store.GetJob("test_job", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, baseURL+"/jobs", unlocked, true))
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, baseURL+"/jobs/test_job/toggle", "", false))
+
+	// Read-only mode blocks every change
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPut, baseURL+"/readonly", `{"read_only": true}`, false))
+	assert.Equal(t, http.StatusOK, do(http.MethodPut, baseURL+"/readonly", `{"read_only": true}`, true))
+
+	readOnly, err := a.Store.ReadOnly()
+	require.NoError(t, err)
+	assert.True(t, readOnly)
+
+	stored, err := a.Store.GetJob("test_job", nil)
+	require.NoError(t, err)
+	assert.Error(t, a.GRPCClient.SetJob(stored, ""))
+	assert.NoError(t, a.GRPCClient.SetJob(stored, "s3cret"))
+
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, baseURL+"/jobs", unlocked, false))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodDelete, baseURL+"/jobs/test_job", "", false))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, baseURL+"/jobs/test_job/reset", "", false))
+	_, err = a.GRPCClient.ResetJob("test_job", "")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, baseURL+"/jobs/test_job/reset", "", true))
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, baseURL+"/jobs/test_job", "", true))
+
+	// Repairing the store is an admin operation, blocked by read-only mode
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, baseURL+"/fsck", "", false))
+	_, err = a.GRPCClient.CheckStore(string(a.raft.Leader()), true, "")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, baseURL+"/fsck", "", true))
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPut, baseURL+"/readonly", `{"read_only": false}`, true))
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, baseURL+"/jobs", unlocked, false))
+}
+
+func TestAPIJobClone(t *testing.T) {
+	port := "8112"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	resp, err := http.Post(baseURL+"/jobs", "encoding/json", bytes.NewBufferString(`{
+		"name": "customer_a",
+		"schedule": "@every 1m",
+		"executor": "shell",
+		"executor_config": {"command": "date", "env": "CUSTOMER=a"},
+		"disabled": true
+	}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = http.Post(baseURL+"/jobs/customer_a/clone", "encoding/json",
+		bytes.NewBufferString(`{"name": "customer_b", "executor_config": {"env": "CUSTOMER=b"}}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	job, err := a.Store.GetJob("customer_b", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "date", job.ExecutorConfig["command"])
+	assert.Equal(t, "CUSTOMER=b", job.ExecutorConfig["env"])
+	assert.True(t, job.Disabled)
+
+	// The new name is required and must be free
+	resp, err = http.Post(baseURL+"/jobs/customer_a/clone", "encoding/json", bytes.NewBufferString(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(baseURL+"/jobs/customer_a/clone", "encoding/json", bytes.NewBufferString(`{"name": "customer_b"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestAPIJobSearch(t *testing.T) {
+	port := "8149"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	for _, name := range []string{"search", "report"} {
+		resp, err := http.Post(baseURL+"/jobs", "encoding/json", bytes.NewBufferString(fmt.Sprintf(`{
+			"name": "%s",
+			"schedule": "@every 1m",
+			"executor": "shell",
+			"executor_config": {"command": "date"},
+			"disabled": true
+		}`, name)))
+		require.NoError(t, err)
+		resp.Body.Close()
+		// The name of the search route is reserved
+		if name == "search" {
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		}
+	}
+
+	resp, err := http.Get(baseURL + "/jobs/search?q=name:report")
+	require.NoError(t, err)
+	var jobs []Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&jobs))
+	resp.Body.Close()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "report", jobs[0].Name)
+
+	resp, err = http.Get(baseURL + "/jobs/search?q=color:red")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPIImportCrontab(t *testing.T) {
+	port := "8113"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	crontab := "0 3 * * * /opt/backup.sh\n@reboot /opt/start.sh\n"
+
+	resp, err := http.Post(baseURL+"/import/crontab?dry_run=true&tag=role=db", "text/plain", bytes.NewBufferString(crontab))
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result ImportResult
+	require.NoError(t, json.Unmarshal(body, &result))
+	require.Len(t, result.Jobs, 1)
+	assert.Equal(t, "db", result.Jobs[0].Tags["role"])
+	assert.Len(t, result.Skipped, 1)
+
+	jobs, err := a.Store.GetJobs(nil)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	resp, err = http.Post(baseURL+"/import/crontab", "text/plain", bytes.NewBufferString(crontab))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	job, err := a.Store.GetJob("backup-sh-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0 0 3 * * *", job.Schedule)
+
+	// Existing jobs are skipped
+	resp, err = http.Post(baseURL+"/import/crontab", "text/plain", bytes.NewBufferString(crontab))
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Empty(t, result.Jobs)
+	assert.Len(t, result.Skipped, 2)
+}
+
 // postJob POSTs the given json to the jobs endpoint and returns the response
 func postJob(t *testing.T, port string, jsonStr []byte) *http.Response {
 	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
@@ -327,3 +626,194 @@ func postJob(t *testing.T, port string, jsonStr []byte) *http.Response {
 
 	return resp
 }
+
+func TestAPIRequestID(t *testing.T) {
+	port := "8114"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	resp, err := http.Post(baseURL+"/jobs", "encoding/json", bytes.NewBufferString(`{
+		"name": "test_job",
+		"schedule": "@every 1h",
+		"executor": "shell",
+		"executor_config": {"command": "date"}
+	}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	// Generated when not sent
+	assert.Len(t, resp.Header.Get("X-Request-ID"), 32)
+
+	req, err := http.NewRequest(http.MethodPost, baseURL+"/jobs/test_job", nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Request-ID", "support-ticket-42")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "support-ticket-42", resp.Header.Get("X-Request-ID"))
+
+	var executions []*Execution
+	for i := 0; i < 50 && len(executions) == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		executions, _ = a.Store.GetExecutions("test_job")
+	}
+	require.Len(t, executions, 1)
+	assert.Equal(t, "support-ticket-42", executions[0].RequestID)
+}
+
+func TestAPIDebug(t *testing.T) {
+	port := "8115"
+	baseURL := fmt.Sprintf("http://localhost:%s", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.AdminToken = "s3cret"
+
+	get := func(path, token string) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set(adminTokenHeader, token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, body
+	}
+
+	resp, _ := get("/debug/state", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp, _ = get("/debug/vars", "wrong")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, body := get("/debug/state", "s3cret")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var state DebugState
+	require.NoError(t, json.Unmarshal(body, &state))
+	assert.True(t, state.Leader)
+	assert.Equal(t, "test", state.NodeName)
+	assert.Equal(t, "alive", state.Members["test"])
+	assert.NotEmpty(t, state.Raft["state"])
+	assert.NotZero(t, state.Goroutines)
+
+	resp, body = get("/debug/pprof/goroutine?debug=1", "s3cret")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "goroutine profile")
+
+	resp, _ = get("/debug/vars", "s3cret")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAPIJobExplain(t *testing.T) {
+	port := "8116"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	require.NoError(t, a.Store.SetJob(&Job{Name: "anywhere", Schedule: "@every 1m", Executor: "shell", Retries: 2, ExecutorConfig: map[string]string{"timeout": "30s"}}, false))
+	require.NoError(t, a.Store.SetJob(&Job{Name: "nowhere", Schedule: "@every 1m", Executor: "shell", Tags: map[string]string{"role": "web"}, Disabled: true}, false))
+
+	explain := func(job string) *JobExplanation {
+		resp, err := http.Get(baseURL + "/jobs/" + job + "/explain")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var e JobExplanation
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&e))
+		return &e
+	}
+
+	e := explain("anywhere")
+	assert.True(t, e.Runnable)
+	assert.Equal(t, []string{"test"}, e.Selected)
+	assert.Equal(t, "all matching nodes", e.Strategy)
+	assert.Equal(t, "30s", e.Timeout)
+	assert.Equal(t, uint(2), e.Retries)
+	assert.Equal(t, ConcurrencyAllow, e.Concurrency)
+	assert.False(t, e.Next.IsZero())
+
+	e = explain("nowhere")
+	assert.False(t, e.Runnable)
+	assert.Equal(t, []string{"job is disabled", "no node matches the tags"}, e.Reasons)
+	require.Len(t, e.Nodes, 1)
+	assert.Contains(t, e.Nodes[0].Reasons, "tag role is dkron, not web")
+
+	resp, err := http.Get(baseURL + "/jobs/missing/explain")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestAPIMembersMatch(t *testing.T) {
+	port := "8117"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	resp, err := http.Post(baseURL+"/members/match", "application/json", bytes.NewBufferString(`{"tags": {"role": "dkron:1"}}`))
+	require.NoError(t, err)
+	var m MemberMatch
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&m))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"test"}, m.Selected)
+	assert.Equal(t, "matching nodes among 1 random nodes with tag role=dkron", m.Strategy)
+
+	resp, err = http.Post(baseURL+"/members/match", "application/json", bytes.NewBufferString(`{"tags": {"role": "dkron:x"}}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPIMaintenance(t *testing.T) {
+	port := "8118"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	now := time.Now().UTC()
+	window := fmt.Sprintf(`{"name": "upgrade", "tags": {"role": "dkron"}, "start": %q, "end": %q}`,
+		now.Add(-time.Minute).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	resp, err := http.Post(baseURL+"/maintenance", "application/json", bytes.NewBufferString(window))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err = http.Post(baseURL+"/maintenance", "application/json", bytes.NewBufferString(`{"name": "empty"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/maintenance")
+	require.NoError(t, err)
+	var windows []*MaintenanceWindow
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&windows))
+	resp.Body.Close()
+	require.Len(t, windows, 1)
+	assert.Equal(t, MaintenanceSkip, windows[0].Policy)
+
+	// The only node is in maintenance
+	require.NoError(t, a.Store.SetJob(&Job{Name: "test_job", Schedule: "@every 1m", Executor: "shell"}, false))
+	resp, err = http.Get(baseURL + "/jobs/test_job/explain")
+	require.NoError(t, err)
+	var e JobExplanation
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&e))
+	resp.Body.Close()
+	assert.False(t, e.Runnable)
+	assert.Empty(t, e.Selected)
+	assert.Equal(t, []string{ErrNodesInMaintenance.Error()}, e.Reasons)
+
+	req, _ := http.NewRequest(http.MethodDelete, baseURL+"/maintenance/upgrade", nil)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
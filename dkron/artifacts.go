@@ -0,0 +1,200 @@
+package dkron
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+)
+
+var (
+	// ErrArtifactStoreScheme is returned when the artifact store URL has an unsupported scheme.
+	ErrArtifactStoreScheme = errors.New("artifacts: unsupported store URL, use file:// or http(s)://")
+)
+
+// Artifact is a file produced by an execution and uploaded to the artifact store.
+type Artifact struct {
+	// Name of the file, relative to the artifacts directory of the execution.
+	Name string `json:"name"`
+
+	// URL where the file was uploaded.
+	URL string `json:"url"`
+
+	// Size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// Hex encoded SHA-256 checksum of the file.
+	SHA256 string `json:"sha256"`
+}
+
+// ArtifactStore is the object store where execution artifacts are uploaded.
+type ArtifactStore interface {
+	// Put stores the content under the given key and returns its URL.
+	Put(key string, r io.Reader, size int64) (string, error)
+}
+
+// NewArtifactStore returns the artifact store for the given URL.
+func NewArtifactStore(storeURL string) (ArtifactStore, error) {
+	u, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "file":
+		return &fileArtifactStore{dir: u.Path}, nil
+	case "http", "https":
+		return &httpArtifactStore{base: u, client: http.DefaultClient}, nil
+	}
+	return nil, ErrArtifactStoreScheme
+}
+
+// fileArtifactStore stores artifacts in a local or shared directory.
+type fileArtifactStore struct {
+	dir string
+}
+
+func (s *fileArtifactStore) Put(key string, r io.Reader, size int64) (string, error) {
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(name)}).String(), nil
+}
+
+// httpArtifactStore uploads artifacts with PUT requests under a base URL,
+// as accepted by most object stores and artifact repositories.
+type httpArtifactStore struct {
+	base   *url.URL
+	client *http.Client
+}
+
+func (s *httpArtifactStore) Put(key string, r io.Reader, size int64) (string, error) {
+	u := *s.base
+	u.Path = path.Join(u.Path, key)
+
+	req, err := http.NewRequest(http.MethodPut, u.String(), r)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("artifacts: error uploading %s: %s", key, resp.Status)
+	}
+
+	// Don't expose the credentials in the artifact metadata
+	u.User = nil
+	return u.String(), nil
+}
+
+// uploadArtifacts uploads the files registered by the executor to the store
+// and returns their metadata. Files that can't be uploaded are logged and skipped.
+func uploadArtifacts(store ArtifactStore, execution *proto.Execution, dir string, files []string) []*proto.Artifact {
+	prefix := path.Join(execution.JobName, NewExecutionFromProto(execution).Key())
+
+	artifacts := []*proto.Artifact{}
+	for _, file := range files {
+		name := filepath.Base(file)
+		if rel, err := filepath.Rel(dir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			name = filepath.ToSlash(rel)
+		}
+
+		artifact, err := uploadArtifact(store, path.Join(prefix, name), file)
+		if err != nil {
+			log.WithError(err).WithField("job", execution.JobName).WithField("file", file).
+				Error("artifacts: error uploading artifact")
+			continue
+		}
+		artifact.Name = name
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts
+}
+
+func uploadArtifact(store ArtifactStore, key, file string) (*proto.Artifact, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("artifacts: %s is not a regular file", file)
+	}
+
+	h := sha256.New()
+	u, err := store.Put(key, io.TeeReader(f, h), fi.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Artifact{
+		Url:    u,
+		Size:   fi.Size(),
+		Sha256: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+func artifactsFromProto(pas []*proto.Artifact) []*Artifact {
+	if len(pas) == 0 {
+		return nil
+	}
+	artifacts := make([]*Artifact, 0, len(pas))
+	for _, pa := range pas {
+		artifacts = append(artifacts, &Artifact{
+			Name:   pa.Name,
+			URL:    pa.Url,
+			Size:   pa.Size,
+			SHA256: pa.Sha256,
+		})
+	}
+	return artifacts
+}
+
+func artifactsToProto(artifacts []*Artifact) []*proto.Artifact {
+	if len(artifacts) == 0 {
+		return nil
+	}
+	pas := make([]*proto.Artifact, 0, len(artifacts))
+	for _, a := range artifacts {
+		pas = append(pas, &proto.Artifact{
+			Name:   a.Name,
+			Url:    a.URL,
+			Size:   a.Size,
+			Sha256: a.SHA256,
+		})
+	}
+	return pas
+}
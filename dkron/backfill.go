@@ -0,0 +1,480 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// backfillPrefix is the key prefix of the backfills.
+	backfillPrefix = "backfill"
+
+	// backfillMaxRuns is the maximum number of runs of a backfill.
+	backfillMaxRuns = 10000
+
+	// BackfillRunning is the status of a backfill dispatching its runs.
+	BackfillRunning = "running"
+	// BackfillDone is the status of a backfill that dispatched all its runs.
+	BackfillDone = "done"
+	// BackfillCanceled is the status of a backfill canceled before dispatching all its runs.
+	BackfillCanceled = "canceled"
+	// BackfillFailed is the status of a backfill that couldn't dispatch its runs.
+	BackfillFailed = "failed"
+)
+
+var (
+	// ErrBackfillRange is returned when the backfill range is invalid.
+	ErrBackfillRange = errors.New("invalid backfill range")
+	// ErrBackfillChildJob is returned when backfilling a dependent job.
+	ErrBackfillChildJob = errors.New("dependent jobs can't be backfilled, backfill their parent job")
+	// ErrBackfillNotFound is returned when the backfill doesn't exist.
+	ErrBackfillNotFound = errors.New("backfill not found")
+	// ErrBackfillNotRunning is returned when canceling a finished backfill.
+	ErrBackfillNotRunning = errors.New("backfill is not running")
+)
+
+// Backfill runs a job once for every time its schedule fires in a past
+// range, passing the fire time as the scheduled time of the execution.
+type Backfill struct {
+	ID      string    `json:"id"`
+	JobName string    `json:"job_name"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+
+	// Parallelism is the number of runs dispatched at the same time.
+	Parallelism int `json:"parallelism"`
+
+	// Status is one of running, done, canceled or failed.
+	Status string `json:"status"`
+
+	// Progress of the runs, succeeded and failed count the finished ones.
+	Total      int `json:"total"`
+	Dispatched int `json:"dispatched"`
+	Succeeded  int `json:"succeeded"`
+	Failed     int `json:"failed"`
+
+	// Position is the scheduled time of the last dispatched run.
+	Position time.Time `json:"position,omitempty"`
+
+	// ID of the API request that created the backfill.
+	RequestID string `json:"request_id,omitempty"`
+
+	CreatedAt  time.Time `json:"created_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// NewBackfillFromProto returns a new Backfill from a proto.
+func NewBackfillFromProto(in *dkronpb.Backfill) *Backfill {
+	from, _ := ptypes.Timestamp(in.From)
+	to, _ := ptypes.Timestamp(in.To)
+	createdAt, _ := ptypes.Timestamp(in.CreatedAt)
+	b := &Backfill{
+		ID:          in.Id,
+		JobName:     in.JobName,
+		From:        from,
+		To:          to,
+		Parallelism: int(in.Parallelism),
+		Status:      in.Status,
+		Total:       int(in.Total),
+		Dispatched:  int(in.Dispatched),
+		Succeeded:   int(in.Succeeded),
+		Failed:      int(in.Failed),
+		RequestID:   in.RequestId,
+		CreatedAt:   createdAt,
+	}
+	if in.Position != nil {
+		b.Position, _ = ptypes.Timestamp(in.Position)
+	}
+	if in.FinishedAt != nil {
+		b.FinishedAt, _ = ptypes.Timestamp(in.FinishedAt)
+	}
+	return b
+}
+
+// ToProto returns the protobuf struct corresponding to the backfill.
+func (b *Backfill) ToProto() *dkronpb.Backfill {
+	from, _ := ptypes.TimestampProto(b.From)
+	to, _ := ptypes.TimestampProto(b.To)
+	createdAt, _ := ptypes.TimestampProto(b.CreatedAt)
+	pb := &dkronpb.Backfill{
+		Id:          b.ID,
+		JobName:     b.JobName,
+		From:        from,
+		To:          to,
+		Parallelism: int32(b.Parallelism),
+		Status:      b.Status,
+		Total:       int32(b.Total),
+		Dispatched:  int32(b.Dispatched),
+		Succeeded:   int32(b.Succeeded),
+		Failed:      int32(b.Failed),
+		RequestId:   b.RequestID,
+		CreatedAt:   createdAt,
+	}
+	if !b.Position.IsZero() {
+		pb.Position, _ = ptypes.TimestampProto(b.Position)
+	}
+	if !b.FinishedAt.IsZero() {
+		pb.FinishedAt, _ = ptypes.TimestampProto(b.FinishedAt)
+	}
+	return pb
+}
+
+// backfillTicks returns the times the schedule of the job fires at between
+// from and to, both included.
+func backfillTicks(job *Job, from, to time.Time) ([]time.Time, error) {
+	if job.ParentJob != "" {
+		return nil, ErrBackfillChildJob
+	}
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return nil, fmt.Errorf("%s: from must be before to", ErrBackfillRange)
+	}
+	if to.After(time.Now()) {
+		return nil, fmt.Errorf("%s: to can't be in the future", ErrBackfillRange)
+	}
+
+	s, err := extcron.Parse(job.cronSchedule())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrScheduleParse, err)
+	}
+
+	var ticks []time.Time
+	next := func(t time.Time) time.Time { return s.Next(t) }
+	t := from.Add(-time.Nanosecond)
+	// Interval schedules fire relative to the start of the range
+	if cds, ok := s.(cron.ConstantDelaySchedule); ok {
+		next = func(t time.Time) time.Time { return t.Add(cds.Delay) }
+		t = from.Add(-cds.Delay)
+	}
+
+	for t = next(t); !t.IsZero() && !t.After(to); t = next(t) {
+		if len(ticks) == backfillMaxRuns {
+			return nil, fmt.Errorf("%s: more than %d runs", ErrBackfillRange, backfillMaxRuns)
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks, nil
+}
+
+func backfillKey(jobName, id string) string {
+	return fmt.Sprintf("%s:%s:%s", backfillPrefix, jobName, id)
+}
+
+// SetBackfill stores a backfill.
+func (s *Store) SetBackfill(b *Backfill) error {
+	pbb, err := proto.Marshal(b.ToProto())
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(backfillKey(b.JobName, b.ID), string(pbb), nil)
+		return err
+	})
+}
+
+// GetBackfill returns a backfill of a job.
+func (s *Store) GetBackfill(jobName, id string) (*Backfill, error) {
+	var b *Backfill
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(backfillKey(jobName, id))
+		if err == buntdb.ErrNotFound {
+			return ErrBackfillNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var pbb dkronpb.Backfill
+		if err := proto.Unmarshal([]byte(value), &pbb); err != nil {
+			return err
+		}
+		b = NewBackfillFromProto(&pbb)
+		return nil
+	})
+	return b, err
+}
+
+// GetBackfills returns the backfills of a job, or of every job if jobName
+// is empty, oldest first.
+func (s *Store) GetBackfills(jobName string) ([]*Backfill, error) {
+	pattern := backfillPrefix + ":*"
+	if jobName != "" {
+		pattern = fmt.Sprintf("%s:%s:*", backfillPrefix, jobName)
+	}
+
+	backfills := []*Backfill{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(pattern, func(key, value string) bool {
+			var pbb dkronpb.Backfill
+			if err = proto.Unmarshal([]byte(value), &pbb); err != nil {
+				return false
+			}
+			backfills = append(backfills, NewBackfillFromProto(&pbb))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backfills, nil
+}
+
+// backfillRun is a backfill being dispatched by the leader.
+type backfillRun struct {
+	sync.Mutex
+	backfill *Backfill
+}
+
+// startBackfill stores a new backfill of the job and starts dispatching its runs.
+func (a *Agent) startBackfill(b *Backfill) (*Backfill, error) {
+	job, err := a.Store.GetJob(b.JobName, nil)
+	if err != nil {
+		return nil, err
+	}
+	ticks, err := backfillTicks(job, b.From, b.To)
+	if err != nil {
+		return nil, err
+	}
+
+	b.ID = strconv.FormatInt(time.Now().UnixNano(), 10)
+	b.Status = BackfillRunning
+	b.Total = len(ticks)
+	b.Dispatched, b.Succeeded, b.Failed = 0, 0, 0
+	b.Position = time.Time{}
+	b.CreatedAt = time.Now()
+	b.FinishedAt = time.Time{}
+	if b.Parallelism < 1 {
+		b.Parallelism = 1
+	}
+
+	if err := a.saveBackfill(b); err != nil {
+		return nil, err
+	}
+
+	log.WithFields(logrus.Fields{
+		"job":        b.JobName,
+		"backfill":   b.ID,
+		"runs":       b.Total,
+		"request_id": b.RequestID,
+	}).Info("agent: Starting backfill")
+
+	started := *b
+	run := &backfillRun{backfill: b}
+	a.backfillRuns.Store(b.ID, run)
+	go a.runBackfill(run)
+
+	return &started, nil
+}
+
+// resumeBackfills continues dispatching the backfills a previous leader
+// didn't finish, after the last run it dispatched.
+func (a *Agent) resumeBackfills() {
+	backfills, err := a.Store.GetBackfills("")
+	if err != nil {
+		log.WithError(err).Error("agent: Error getting backfills")
+		return
+	}
+
+	for _, b := range backfills {
+		if b.Status != BackfillRunning {
+			continue
+		}
+		run := &backfillRun{backfill: b}
+		if _, loaded := a.backfillRuns.LoadOrStore(b.ID, run); loaded {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"job":      b.JobName,
+			"backfill": b.ID,
+			"position": b.Position,
+		}).Info("agent: Resuming backfill")
+		go a.runBackfill(run)
+	}
+}
+
+// cancelBackfill stops dispatching the runs of a backfill, the runs
+// already dispatched finish.
+func (a *Agent) cancelBackfill(jobName, id string) (*Backfill, error) {
+	if v, ok := a.backfillRuns.Load(id); ok {
+		run := v.(*backfillRun)
+		run.Lock()
+		defer run.Unlock()
+
+		if run.backfill.Status != BackfillRunning {
+			return nil, ErrBackfillNotRunning
+		}
+		run.backfill.Status = BackfillCanceled
+		run.backfill.FinishedAt = time.Now()
+		if err := a.saveBackfill(run.backfill); err != nil {
+			return nil, err
+		}
+		canceled := *run.backfill
+		return &canceled, nil
+	}
+
+	b, err := a.Store.GetBackfill(jobName, id)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != BackfillRunning {
+		return nil, ErrBackfillNotRunning
+	}
+	b.Status = BackfillCanceled
+	b.FinishedAt = time.Now()
+	if err := a.saveBackfill(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// runBackfill dispatches the runs of the backfill after its position, up
+// to its parallelism at a time. It stops when the backfill is canceled or
+// the agent loses leadership, a new leader resumes it.
+func (a *Agent) runBackfill(run *backfillRun) {
+	defer a.backfillRuns.Delete(run.backfill.ID)
+
+	run.Lock()
+	b := run.backfill
+	run.Unlock()
+
+	job, err := a.Store.GetJob(b.JobName, nil)
+	var ticks []time.Time
+	if err == nil {
+		ticks, err = backfillTicks(job, b.From, b.To)
+	}
+	if err != nil {
+		log.WithError(err).WithField("backfill", b.ID).Error("agent: Error starting backfill")
+		run.Lock()
+		b.Status = BackfillFailed
+		b.FinishedAt = time.Now()
+		a.saveBackfillProgress(b)
+		run.Unlock()
+		return
+	}
+
+	sem := make(chan struct{}, b.Parallelism)
+	var wg sync.WaitGroup
+	interrupted := false
+
+	for _, tick := range ticks {
+		if !tick.After(b.Position) {
+			continue
+		}
+
+		sem <- struct{}{}
+		run.Lock()
+		if b.Status != BackfillRunning || !a.IsLeader() {
+			interrupted = b.Status == BackfillRunning
+			run.Unlock()
+			<-sem
+			break
+		}
+		b.Dispatched++
+		b.Position = tick
+		a.saveBackfillProgress(b)
+		run.Unlock()
+
+		wg.Add(1)
+		go func(tick time.Time) {
+			defer wg.Done()
+			success := a.runBackfillTick(b, tick)
+			<-sem
+
+			run.Lock()
+			if success {
+				b.Succeeded++
+			} else {
+				b.Failed++
+			}
+			a.saveBackfillProgress(b)
+			run.Unlock()
+		}(tick)
+	}
+	wg.Wait()
+
+	if interrupted {
+		return
+	}
+
+	run.Lock()
+	defer run.Unlock()
+	if b.Status == BackfillRunning {
+		b.Status = BackfillDone
+		b.FinishedAt = time.Now()
+		a.saveBackfillProgress(b)
+
+		log.WithFields(logrus.Fields{
+			"job":       b.JobName,
+			"backfill":  b.ID,
+			"succeeded": b.Succeeded,
+			"failed":    b.Failed,
+		}).Info("agent: Backfill done")
+	}
+}
+
+// runBackfillTick runs the job for a scheduled time of the backfill and
+// returns whether all its executions succeeded.
+func (a *Agent) runBackfillTick(b *Backfill, tick time.Time) bool {
+	ex := NewExecution(b.JobName)
+	ex.ScheduledAt = tick
+	ex.RequestID = b.RequestID
+	ex.Annotations = []string{"backfill " + b.ID}
+
+	if _, err := a.Run(b.JobName, ex); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"job":       b.JobName,
+			"backfill":  b.ID,
+			"scheduled": tick,
+		}).Error("agent: Error running backfill")
+		return false
+	}
+
+	executions, err := a.Store.GetExecutionGroup(ex)
+	if err != nil || len(executions) == 0 {
+		return false
+	}
+
+	// Only the last attempt of every node counts
+	for _, e := range lastAttempts(executions) {
+		if !e.Success && !e.Skipped {
+			return false
+		}
+	}
+	return true
+}
+
+// saveBackfill stores the backfill in the cluster.
+func (a *Agent) saveBackfill(b *Backfill) error {
+	cmd, err := Encode(SetBackfillType, b.ToProto())
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// saveBackfillProgress stores the backfill, logging the errors as the
+// progress is stored again with the next run.
+func (a *Agent) saveBackfillProgress(b *Backfill) {
+	if err := a.saveBackfill(b); err != nil {
+		log.WithError(err).WithField("backfill", b.ID).Error("agent: Error storing backfill progress")
+	}
+}
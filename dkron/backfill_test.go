@@ -0,0 +1,133 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillTicks(t *testing.T) {
+	from := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 5, 3, 12, 0, 0, 0, time.UTC)
+
+	daily := &Job{Name: "daily", Schedule: "0 0 2 * * *"}
+	ticks, err := backfillTicks(daily, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{from.Add(2 * time.Hour), from.Add(26 * time.Hour), from.Add(50 * time.Hour)}, ticks)
+
+	// Both ends are included
+	ticks, err = backfillTicks(daily, from.Add(2*time.Hour), from.Add(26*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, ticks, 2)
+
+	interval := &Job{Name: "interval", Schedule: "@every 12h"}
+	ticks, err = backfillTicks(interval, from, to)
+	require.NoError(t, err)
+	assert.Len(t, ticks, 6)
+	assert.Equal(t, from, ticks[0])
+
+	_, err = backfillTicks(daily, to, from)
+	assert.Error(t, err)
+
+	_, err = backfillTicks(daily, from, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+
+	_, err = backfillTicks(&Job{Name: "often", Schedule: "@every 1s"}, from, to)
+	assert.Error(t, err)
+
+	_, err = backfillTicks(&Job{Name: "child", ParentJob: "daily"}, from, to)
+	assert.Equal(t, ErrBackfillChildJob, err)
+}
+
+func TestAPIBackfill(t *testing.T) {
+	port := "8121"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	jsonStr := []byte(`{"name": "etl", "schedule": "0 0 2 * * *", "executor": "shell", "executor_config": {"command": "true"}}`)
+	resp, err := http.Post(baseURL+"/jobs", "encoding/json", bytes.NewBuffer(jsonStr))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	body := `{"from": "2020-05-01T00:00:00Z", "to": "2020-05-03T12:00:00Z", "parallelism": 2}`
+	resp, err = http.Post(baseURL+"/jobs/etl/backfill", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	var b Backfill
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&b))
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "/v1/jobs/etl/backfills/"+b.ID, resp.Header.Get("Location"))
+	assert.Equal(t, 3, b.Total)
+	assert.Equal(t, BackfillRunning, b.Status)
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(baseURL + "/jobs/etl/backfills/" + b.ID)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return json.NewDecoder(resp.Body).Decode(&b) == nil && b.Status == BackfillDone
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, 3, b.Dispatched)
+	assert.Equal(t, 3, b.Succeeded+b.Failed)
+
+	executions, err := a.Store.GetExecutions("etl")
+	require.NoError(t, err)
+	require.Len(t, executions, 3)
+	scheduled := map[time.Time]bool{}
+	for _, ex := range executions {
+		scheduled[ex.ScheduledAt.UTC()] = true
+		assert.Equal(t, []string{"backfill " + b.ID}, ex.Annotations)
+	}
+	assert.True(t, scheduled[time.Date(2020, 5, 2, 2, 0, 0, 0, time.UTC)])
+
+	// Finished backfills can't be canceled
+	req, _ := http.NewRequest(http.MethodDelete, baseURL+"/jobs/etl/backfills/"+b.ID, nil)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = http.Post(baseURL+"/jobs/etl/backfill", "application/json", bytes.NewBufferString(`{"from": "2020-05-03T00:00:00Z", "to": "2020-05-01T00:00:00Z"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/jobs/etl/backfills")
+	require.NoError(t, err)
+	var backfills []*Backfill
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&backfills))
+	resp.Body.Close()
+	assert.Len(t, backfills, 1)
+}
+
+func TestCancelBackfill(t *testing.T) {
+	dir, a := setupAPITest(t, "8122")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	// A backfill left running by a previous leader
+	b := &Backfill{ID: "1", JobName: "etl", Status: BackfillRunning, Total: 10, Dispatched: 4}
+	require.NoError(t, a.saveBackfill(b))
+
+	canceled, err := a.cancelBackfill("etl", "1")
+	require.NoError(t, err)
+	assert.Equal(t, BackfillCanceled, canceled.Status)
+	assert.Equal(t, 4, canceled.Dispatched)
+
+	_, err = a.cancelBackfill("etl", "1")
+	assert.Equal(t, ErrBackfillNotRunning, err)
+
+	_, err = a.cancelBackfill("etl", "2")
+	assert.Equal(t, ErrBackfillNotFound, err)
+}
@@ -0,0 +1,44 @@
+package dkron
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/hashicorp/raft"
+)
+
+// WriteRaftSnapshot writes the store contents as a raft snapshot in the
+// given data dir, with a single server configuration. An agent started in
+// server mode with this data dir, node name and advertised RPC address
+// restores the snapshot and elects itself leader, this is useful to test
+// restoring a backup without touching the production cluster.
+func WriteRaftSnapshot(s *Store, dataDir, nodeName, addr string) (string, error) {
+	snapshots, err := raft.NewFileSnapshotStore(filepath.Join(dataDir, "raft"), 1, ioutil.Discard)
+	if err != nil {
+		return "", err
+	}
+
+	configuration := raft.Configuration{
+		Servers: []raft.Server{
+			{
+				ID:      raft.ServerID(nodeName),
+				Address: raft.ServerAddress(addr),
+			},
+		},
+	}
+	// The transport is only used to encode the legacy peers list
+	_, trans := raft.NewInmemTransport(raft.ServerAddress(addr))
+	sink, err := snapshots.Create(raft.SnapshotVersionMax, 1, 1, configuration, 1, trans)
+	if err != nil {
+		return "", err
+	}
+	if err := s.Snapshot(sink); err != nil {
+		sink.Cancel()
+		return "", err
+	}
+	if err := sink.Close(); err != nil {
+		return "", err
+	}
+
+	return sink.ID(), nil
+}
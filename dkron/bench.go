@@ -0,0 +1,460 @@
+package dkron
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// benchPollInterval is how often the executions of the runs are read
+	// while waiting for them.
+	benchPollInterval = 500 * time.Millisecond
+	// benchMetadataKey marks the jobs created by a benchmark.
+	benchMetadataKey = "dkron_bench"
+)
+
+// ErrInvalidBench is returned when the options of a benchmark are not valid.
+var ErrInvalidBench = errors.New("invalid benchmark")
+
+// BenchOptions configures a load test of a cluster through the HTTP API
+// of one of its agents.
+type BenchOptions struct {
+	// HTTP API of the agent, like http://localhost:8080.
+	Address string
+
+	// Synthetic jobs created, the runs are spread over them.
+	Jobs int
+
+	// Runs triggered per second, over all the jobs, for Duration.
+	Rate     float64
+	Duration time.Duration
+
+	// Requests to the API in flight at the same time.
+	Concurrency int
+
+	// Bytes output by every execution, none when zero.
+	OutputSize int
+
+	// Target nodes tags of the jobs.
+	Tags map[string]string
+
+	// Prefix of the names of the jobs, also used for the request IDs of
+	// the runs.
+	Prefix string
+
+	// How long to wait for the executions once the last run was triggered.
+	Wait time.Duration
+
+	// Keep the jobs once done instead of deleting them.
+	Keep bool
+}
+
+func (o *BenchOptions) validate() error {
+	switch {
+	case o.Jobs < 1:
+		return fmt.Errorf("%s: jobs must be at least 1", ErrInvalidBench)
+	case o.Rate <= 0:
+		return fmt.Errorf("%s: rate must be positive", ErrInvalidBench)
+	case o.Duration <= 0:
+		return fmt.Errorf("%s: duration must be positive", ErrInvalidBench)
+	case o.Concurrency < 1:
+		return fmt.Errorf("%s: concurrency must be at least 1", ErrInvalidBench)
+	case o.OutputSize < 0:
+		return fmt.Errorf("%s: output size can't be negative", ErrInvalidBench)
+	case !validRequestID.MatchString(o.Prefix):
+		return fmt.Errorf("%s: prefix can only have letters, digits and ._:-", ErrInvalidBench)
+	}
+	return nil
+}
+
+// BenchStats are the latencies of a kind of operation of a benchmark.
+type BenchStats struct {
+	Count  int `json:"count"`
+	Errors int `json:"errors"`
+
+	// Operations done per second.
+	Throughput float64 `json:"throughput"`
+
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+func newBenchStats(latencies []time.Duration, errors int, elapsed time.Duration) BenchStats {
+	s := BenchStats{Count: len(latencies), Errors: errors}
+	if len(latencies) == 0 {
+		return s
+	}
+	if elapsed > 0 {
+		s.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	s.P50 = percentile(latencies, 50)
+	s.P90 = percentile(latencies, 90)
+	s.P99 = percentile(latencies, 99)
+	s.Max = latencies[len(latencies)-1]
+	return s
+}
+
+// percentile returns the nearest rank percentile p of the sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// BenchReport is the result of a benchmark.
+type BenchReport struct {
+	// Jobs created, the store writes of the job specs.
+	JobWrites BenchStats `json:"job_writes"`
+
+	// Run requests to the API, the throughput is the achieved run rate.
+	RunRequests BenchStats `json:"run_requests"`
+
+	// Time from the run request to the start of its executions on the
+	// agents, and to the end of them.
+	Dispatch   BenchStats `json:"dispatch"`
+	Completion BenchStats `json:"completion"`
+
+	// Executions finished, those that failed, and runs without finished
+	// executions once done waiting. The throughput of the store writes of
+	// the executions is the one of the completion.
+	Executions int `json:"executions"`
+	Failed     int `json:"failed"`
+	Missing    int `json:"missing"`
+}
+
+type benchRun struct {
+	job  string
+	sent time.Time
+}
+
+type bench struct {
+	opts   BenchOptions
+	client *http.Client
+	jobs   []string
+}
+
+// RunBench creates the synthetic jobs of the benchmark, triggers their runs
+// at the rate of the options and waits for their executions, measuring
+// the latencies along the way. The jobs are deleted once done unless kept.
+func RunBench(ctx context.Context, opts BenchOptions) (*BenchReport, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	b := &bench{
+		opts:   opts,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	report := &BenchReport{}
+
+	var err error
+	report.JobWrites, err = b.createJobs(ctx)
+	if !opts.Keep {
+		defer b.deleteJobs()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	runs, stats := b.triggerRuns(ctx)
+	report.RunRequests = stats
+	if len(runs) == 0 {
+		return report, nil
+	}
+
+	b.collect(ctx, runs, report)
+	return report, nil
+}
+
+// do sends a request to the API, failing on unexpected statuses.
+func (b *bench) do(ctx context.Context, method, path string, body interface{}, header http.Header, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, b.opts.Address+path, r)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, bytes.TrimSpace(data))
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// parallel calls fn for n items with the concurrency of the options,
+// returning the latencies of the successful calls, the failed ones, the
+// time it took and the first error.
+func (b *bench) parallel(n int, fn func(i int) error) ([]time.Duration, int, time.Duration, error) {
+	var mu sync.Mutex
+	var latencies []time.Duration
+	var failed int
+	var firstErr error
+
+	items := make(chan int)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < b.opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				t := time.Now()
+				err := fn(i)
+				d := time.Since(t)
+
+				mu.Lock()
+				if err != nil {
+					failed++
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					latencies = append(latencies, d)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		items <- i
+	}
+	close(items)
+	wg.Wait()
+
+	return latencies, failed, time.Since(start), firstErr
+}
+
+// executorConfig returns the config of the shell executor of the jobs,
+// outputting the bytes of the options.
+func (b *bench) executorConfig() map[string]string {
+	if b.opts.OutputSize == 0 {
+		return map[string]string{"command": "true"}
+	}
+	return map[string]string{
+		"shell":   "true",
+		"command": fmt.Sprintf("yes x | head -c %d", b.opts.OutputSize),
+	}
+}
+
+// createJobs creates the jobs of the benchmark, failing when none could be.
+func (b *bench) createJobs(ctx context.Context) (BenchStats, error) {
+	names := make([]string, b.opts.Jobs)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s%d", b.opts.Prefix, i)
+	}
+
+	var mu sync.Mutex
+	latencies, failed, elapsed, err := b.parallel(len(names), func(i int) error {
+		job := &Job{
+			Name:           names[i],
+			Schedule:       "@manually",
+			Executor:       "shell",
+			ExecutorConfig: b.executorConfig(),
+			Tags:           b.opts.Tags,
+			Concurrency:    ConcurrencyAllow,
+			Metadata:       map[string]string{benchMetadataKey: "true"},
+		}
+		if err := b.do(ctx, http.MethodPost, "/v1/jobs", job, nil, nil); err != nil {
+			return err
+		}
+		mu.Lock()
+		b.jobs = append(b.jobs, names[i])
+		mu.Unlock()
+		return nil
+	})
+	if len(b.jobs) == 0 {
+		return BenchStats{}, fmt.Errorf("bench: no job could be created: %s", err)
+	}
+	return newBenchStats(latencies, failed, elapsed), nil
+}
+
+// triggerRuns requests runs of the jobs, in turns, at the rate of the
+// options. Requests wait for a free slot when the concurrency is reached,
+// lowering the achieved rate.
+func (b *bench) triggerRuns(ctx context.Context) (map[string]*benchRun, BenchStats) {
+	runs := map[string]*benchRun{}
+	var mu sync.Mutex
+	var latencies []time.Duration
+	var failed int
+
+	interval := time.Duration(float64(time.Second) / b.opts.Rate)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slots := make(chan struct{}, b.opts.Concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+	end := start.Add(b.opts.Duration)
+	id := fmt.Sprintf("%s%d", b.opts.Prefix, start.UnixNano())
+
+	for n := 0; time.Now().Before(end); n++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			end = time.Now()
+			continue
+		}
+
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			job := b.jobs[n%len(b.jobs)]
+			requestID := fmt.Sprintf("%s-%d", id, n)
+			header := http.Header{requestIDHeader: []string{requestID}}
+			sent := time.Now()
+			err := b.do(ctx, http.MethodPost, "/v1/jobs/"+url.PathEscape(job), nil, header, nil)
+			d := time.Since(sent)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				log.WithError(err).WithField("job", job).Debug("bench: Error running job")
+				return
+			}
+			latencies = append(latencies, d)
+			runs[requestID] = &benchRun{job: job, sent: sent}
+		}(n)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			end = time.Now()
+		}
+	}
+	wg.Wait()
+
+	return runs, newBenchStats(latencies, failed, time.Since(start))
+}
+
+// collect waits for the executions of the runs until all of them are
+// finished, the wait is over or the benchmark is canceled, and adds their
+// latencies to the report.
+func (b *bench) collect(ctx context.Context, runs map[string]*benchRun, report *BenchReport) {
+	jobs := map[string]bool{}
+	var first time.Time
+	for _, r := range runs {
+		jobs[r.job] = true
+		if first.IsZero() || r.sent.Before(first) {
+			first = r.sent
+		}
+	}
+
+	var executions map[string][]*Execution
+	deadline := time.Now().Add(b.opts.Wait)
+	for {
+		executions = map[string][]*Execution{}
+		for job := range jobs {
+			var exs []*Execution
+			if err := b.do(context.Background(), http.MethodGet, "/v1/jobs/"+url.PathEscape(job)+"/executions", nil, nil, &exs); err != nil {
+				log.WithError(err).WithField("job", job).Debug("bench: Error reading executions")
+				continue
+			}
+			for _, ex := range exs {
+				if _, ok := runs[ex.RequestID]; ok {
+					executions[ex.RequestID] = append(executions[ex.RequestID], ex)
+				}
+			}
+		}
+		if benchDone(runs, executions) || !time.Now().Before(deadline) || ctx.Err() != nil {
+			break
+		}
+		time.Sleep(benchPollInterval)
+	}
+
+	var dispatch, completion []time.Duration
+	var last time.Time
+	for id, r := range runs {
+		exs := executions[id]
+		finished := 0
+		for _, ex := range exs {
+			if ex.FinishedAt.IsZero() {
+				continue
+			}
+			finished++
+			report.Executions++
+			if !ex.Success {
+				report.Failed++
+			}
+			dispatch = append(dispatch, ex.StartedAt.Sub(r.sent))
+			completion = append(completion, ex.FinishedAt.Sub(r.sent))
+			if ex.FinishedAt.After(last) {
+				last = ex.FinishedAt
+			}
+		}
+		if finished == 0 {
+			report.Missing++
+		}
+	}
+	report.Dispatch = newBenchStats(dispatch, 0, 0)
+	report.Completion = newBenchStats(completion, 0, last.Sub(first))
+}
+
+// benchDone returns whether every run has executions and all of them
+// finished.
+func benchDone(runs map[string]*benchRun, executions map[string][]*Execution) bool {
+	for id := range runs {
+		exs := executions[id]
+		if len(exs) == 0 {
+			return false
+		}
+		for _, ex := range exs {
+			if ex.FinishedAt.IsZero() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// deleteJobs deletes the jobs created by the benchmark.
+func (b *bench) deleteJobs() {
+	b.parallel(len(b.jobs), func(i int) error {
+		err := b.do(context.Background(), http.MethodDelete, "/v1/jobs/"+url.PathEscape(b.jobs[i]), nil, nil, nil)
+		if err != nil {
+			log.WithError(err).WithField("job", b.jobs[i]).Warn("bench: Error deleting job")
+		}
+		return err
+	})
+}
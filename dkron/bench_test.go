@@ -0,0 +1,85 @@
+package dkron
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchStats(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	s := newBenchStats(latencies, 2, 10*time.Second)
+	assert.Equal(t, 100, s.Count)
+	assert.Equal(t, 2, s.Errors)
+	assert.Equal(t, 10.0, s.Throughput)
+	assert.Equal(t, 50*time.Millisecond, s.P50)
+	assert.Equal(t, 90*time.Millisecond, s.P90)
+	assert.Equal(t, 99*time.Millisecond, s.P99)
+	assert.Equal(t, 100*time.Millisecond, s.Max)
+
+	s = newBenchStats([]time.Duration{time.Second}, 0, 0)
+	assert.Equal(t, time.Second, s.P50)
+	assert.Equal(t, time.Second, s.P99)
+	assert.Zero(t, s.Throughput)
+
+	assert.Equal(t, BenchStats{Errors: 1}, newBenchStats(nil, 1, time.Second))
+}
+
+func TestBenchOptionsValidate(t *testing.T) {
+	opts := BenchOptions{Jobs: 1, Rate: 1, Duration: time.Second, Concurrency: 1, Prefix: "bench-"}
+	assert.NoError(t, opts.validate())
+
+	for _, o := range []BenchOptions{
+		{Rate: 1, Duration: time.Second, Concurrency: 1, Prefix: "bench-"},
+		{Jobs: 1, Duration: time.Second, Concurrency: 1, Prefix: "bench-"},
+		{Jobs: 1, Rate: 1, Duration: time.Second, Prefix: "bench-"},
+		{Jobs: 1, Rate: 1, Duration: time.Second, Concurrency: 1, Prefix: "bench/"},
+	} {
+		err := o.validate()
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), ErrInvalidBench.Error())
+		}
+	}
+}
+
+func TestRunBench(t *testing.T) {
+	port := "8148"
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.ScheduleSimulate = true
+
+	report, err := RunBench(context.Background(), BenchOptions{
+		Address:     fmt.Sprintf("http://localhost:%s", port),
+		Jobs:        2,
+		Rate:        20,
+		Duration:    300 * time.Millisecond,
+		Concurrency: 4,
+		Prefix:      "bench-",
+		Wait:        10 * time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.JobWrites.Count)
+	assert.NotZero(t, report.RunRequests.Count)
+	assert.Zero(t, report.RunRequests.Errors)
+	assert.Equal(t, report.RunRequests.Count, report.Executions)
+	assert.Equal(t, report.Executions, report.Dispatch.Count)
+	assert.Zero(t, report.Failed)
+	assert.Zero(t, report.Missing)
+
+	// The jobs are deleted once done
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%s/v1/jobs/bench-0", port))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
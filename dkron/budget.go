@@ -0,0 +1,295 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+)
+
+// Periods of the run budgets.
+const (
+	BudgetDay   = "day"
+	BudgetMonth = "month"
+)
+
+var (
+	// ErrBudgetExceeded is returned when a job has used all the runs of
+	// its budget in the period.
+	ErrBudgetExceeded = errors.New("run budget exceeded")
+	// ErrInvalidBudget is returned when the run budget of a job is not
+	// valid.
+	ErrInvalidBudget = errors.New("invalid budget")
+)
+
+// Budget limits the runs of a job per day or month, for jobs calling pay
+// per use services.
+type Budget struct {
+	// Runs the job can start in every period.
+	MaxRuns int `json:"max_runs"`
+
+	// Period of the budget, day or month, in the timezone of the job.
+	Period string `json:"period"`
+
+	// Maximum number of unused runs of a period carried over to the next
+	// one. Zero doesn't carry any.
+	CarryOver int `json:"carry_over,omitempty"`
+
+	// Usage of the current period, set by the server.
+	PeriodStart time.Time `json:"period_start"`
+	Used        int       `json:"used"`
+	Carried     int       `json:"carried"`
+
+	// Runs of the period given back because they couldn't be dispatched.
+	Refunded int `json:"refunded"`
+
+	// When the budget of the period was exceeded, if it was.
+	ExceededAt time.Time `json:"exceeded_at"`
+}
+
+func budgetFromProto(in *proto.Budget) *Budget {
+	if in == nil {
+		return nil
+	}
+	b := &Budget{
+		MaxRuns:   int(in.MaxRuns),
+		Period:    in.Period,
+		CarryOver: int(in.CarryOver),
+		Used:      int(in.Used),
+		Carried:   int(in.Carried),
+		Refunded:  int(in.Refunded),
+	}
+	if in.PeriodStart != nil {
+		b.PeriodStart, _ = ptypes.Timestamp(in.PeriodStart)
+	}
+	if in.ExceededAt != nil {
+		b.ExceededAt, _ = ptypes.Timestamp(in.ExceededAt)
+	}
+	return b
+}
+
+func (b *Budget) toProto() *proto.Budget {
+	if b == nil {
+		return nil
+	}
+	pbb := &proto.Budget{
+		MaxRuns:   int32(b.MaxRuns),
+		Period:    b.Period,
+		CarryOver: int32(b.CarryOver),
+		Used:      int32(b.Used),
+		Carried:   int32(b.Carried),
+		Refunded:  int32(b.Refunded),
+	}
+	if !b.PeriodStart.IsZero() {
+		pbb.PeriodStart, _ = ptypes.TimestampProto(b.PeriodStart)
+	}
+	if !b.ExceededAt.IsZero() {
+		pbb.ExceededAt, _ = ptypes.TimestampProto(b.ExceededAt)
+	}
+	return pbb
+}
+
+func (b *Budget) validate() error {
+	if b.MaxRuns <= 0 {
+		return fmt.Errorf("%s: max runs must be positive", ErrInvalidBudget)
+	}
+	if b.Period != BudgetDay && b.Period != BudgetMonth {
+		return fmt.Errorf("%s: unknown period %q", ErrInvalidBudget, b.Period)
+	}
+	if b.CarryOver < 0 {
+		return fmt.Errorf("%s: carry over can't be negative", ErrInvalidBudget)
+	}
+	return nil
+}
+
+// mergeUsage keeps the usage of the stored budget unless this one is of a
+// later period, or of the same one with more runs used or refunded.
+func (b *Budget) mergeUsage(stored *Budget) {
+	if b.PeriodStart.After(stored.PeriodStart) {
+		return
+	}
+	if b.PeriodStart.Equal(stored.PeriodStart) {
+		if b.Used < stored.Used {
+			b.Used = stored.Used
+		}
+		if b.Refunded < stored.Refunded {
+			b.Refunded = stored.Refunded
+		}
+		if b.ExceededAt.IsZero() {
+			b.ExceededAt = stored.ExceededAt
+		}
+		return
+	}
+	b.PeriodStart = stored.PeriodStart
+	b.Used = stored.Used
+	b.Carried = stored.Carried
+	b.Refunded = stored.Refunded
+	b.ExceededAt = stored.ExceededAt
+}
+
+// periodStart returns the start of the period of the budget holding t.
+func (b *Budget) periodStart(t time.Time) time.Time {
+	if b.Period == BudgetMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// nextPeriod returns the start of the period following the one starting at
+// start.
+func (b *Budget) nextPeriod(start time.Time) time.Time {
+	if b.Period == BudgetMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// roll moves the budget to the period holding now, carrying over the
+// unused runs of the previous period. Periods without runs in between
+// leave all their runs unused, the first period has nothing to carry.
+func (b *Budget) roll(now time.Time) {
+	start := b.periodStart(now)
+	if !b.PeriodStart.Before(start) {
+		return
+	}
+
+	var unused int
+	switch {
+	case b.PeriodStart.IsZero():
+	case b.nextPeriod(b.PeriodStart).Equal(start):
+		unused = b.MaxRuns + b.Carried + b.Refunded - b.Used
+	default:
+		unused = b.MaxRuns
+	}
+	if unused > b.CarryOver {
+		unused = b.CarryOver
+	}
+	if unused < 0 {
+		unused = 0
+	}
+
+	b.PeriodStart = start
+	b.Used = 0
+	b.Carried = unused
+	b.Refunded = 0
+	b.ExceededAt = time.Time{}
+}
+
+// Remaining returns the runs left in the current period.
+func (b *Budget) Remaining() int {
+	if r := b.MaxRuns + b.Carried + b.Refunded - b.Used; r > 0 {
+		return r
+	}
+	return 0
+}
+
+// location returns the location of the timezone of the job, the budget
+// periods and the execution summaries by day are counted in.
+func (j *Job) location() *time.Location {
+	loc, err := time.LoadLocation(j.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// checkBudget returns an error if the job has no runs left in its budget,
+// without using any.
+func checkBudget(job *Job, now time.Time) error {
+	if job.Budget == nil {
+		return nil
+	}
+	b := *job.Budget
+	b.roll(now.In(job.location()))
+	if b.Remaining() == 0 {
+		return fmt.Errorf("%s: %d runs per %s used, next period starts at %s",
+			ErrBudgetExceeded, b.MaxRuns+b.Carried, b.Period, b.nextPeriod(b.PeriodStart).Format(time.RFC3339))
+	}
+	return nil
+}
+
+// useBudget uses a run of the budget of the job starting at now. When the
+// budget is exceeded the run is rejected and, the first time in the
+// period, notified.
+func (a *Agent) useBudget(job *Job, now time.Time) error {
+	if job.Budget == nil {
+		return nil
+	}
+
+	// The job can have namespace defaults that aren't stored
+	stored, err := a.Store.GetJob(job.Name, nil)
+	if err != nil {
+		return err
+	}
+	if stored.Budget == nil {
+		return nil
+	}
+
+	b := stored.Budget
+	b.roll(now.In(stored.location()))
+	exceeded := checkBudget(stored, now)
+	if exceeded == nil {
+		b.Used++
+	} else if b.ExceededAt.IsZero() {
+		b.ExceededAt = now
+	} else {
+		return exceeded
+	}
+
+	if err := a.applySetJob(stored.ToProto()); err != nil {
+		return err
+	}
+	if exceeded != nil {
+		a.notifyBudgetExceeded(job, exceeded)
+	}
+	return exceeded
+}
+
+// refundBudget gives back the run of the budget of the job used at now
+// when it couldn't be dispatched. Runs of a past period aren't refunded.
+// Usage only grows when merged, so the refund is counted apart from it.
+func (a *Agent) refundBudget(job *Job, now time.Time) {
+	if job.Budget == nil {
+		return
+	}
+	stored, err := a.Store.GetJob(job.Name, nil)
+	if err != nil || stored.Budget == nil {
+		return
+	}
+
+	b := stored.Budget
+	if !b.PeriodStart.Equal(b.periodStart(now.In(stored.location()))) || b.Used <= b.Refunded {
+		return
+	}
+	b.Refunded++
+	if err := a.applySetJob(stored.ToProto()); err != nil {
+		log.WithError(err).WithField("job", job.Name).Error("agent: Error refunding the run budget")
+	}
+}
+
+// notifyBudgetExceeded notifies the first run rejected in a period because
+// the budget of the job was exceeded.
+func (a *Agent) notifyBudgetExceeded(job *Job, exceeded error) {
+	log.WithError(exceeded).WithField("job", job.Name).Warn("agent: Job exceeded its run budget")
+	metrics.IncrCounterWithLabels([]string{"job", "budget_exceeded"}, 1, []metrics.Label{
+		{Name: "job", Value: job.Name},
+		{Name: "namespace", Value: job.Namespace()},
+	})
+
+	if silence := a.silenced(job, time.Now()); silence != nil {
+		return
+	}
+	ex := NewExecution(job.Name)
+	ex.StartedAt = time.Now()
+	ex.FinishedAt = ex.StartedAt
+	ex.Output = exceeded.Error()
+	n := Notification(a.notificationConfig(a.withNamespaceDefaults(job)), ex, []*Execution{ex}, job)
+	n.BudgetExceeded = true
+	if err := n.Send(); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{"job": job.Name}).Error("agent: Error notifying the exceeded run budget")
+	}
+}
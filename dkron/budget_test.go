@@ -0,0 +1,116 @@
+package dkron
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget_roll(t *testing.T) {
+	b := &Budget{MaxRuns: 10, Period: BudgetDay, CarryOver: 5}
+	day := time.Date(2020, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	b.roll(day)
+	assert.Equal(t, time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC), b.PeriodStart)
+	assert.Equal(t, 0, b.Carried)
+	assert.Equal(t, 10, b.Remaining())
+
+	// The unused runs of the previous day are carried up to the limit
+	b.Used = 8
+	b.roll(day.Add(24 * time.Hour))
+	assert.Equal(t, 2, b.Carried)
+	assert.Equal(t, 12, b.Remaining())
+
+	b.Used = 0
+	b.roll(day.Add(48 * time.Hour))
+	assert.Equal(t, 5, b.Carried)
+
+	// Skipped days carry the whole limit
+	b.Used = 12
+	b.roll(day.Add(96 * time.Hour))
+	assert.Equal(t, 5, b.Carried)
+
+	m := &Budget{MaxRuns: 100, Period: BudgetMonth}
+	m.roll(day)
+	assert.Equal(t, time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), m.PeriodStart)
+	assert.Equal(t, time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), m.nextPeriod(m.PeriodStart))
+}
+
+func TestUseBudget(t *testing.T) {
+	port := "8140"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:     "geocode",
+		Schedule: "@manually",
+		Executor: "shell",
+		Budget:   &Budget{MaxRuns: 2, Period: BudgetDay},
+	}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	now := time.Now()
+	assert.NoError(t, a.useBudget(job, now))
+	assert.NoError(t, a.useBudget(job, now))
+
+	err := a.useBudget(job, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrBudgetExceeded.Error())
+
+	stored, err := a.Store.GetJob(job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Budget.Used)
+	assert.False(t, stored.Budget.ExceededAt.IsZero())
+
+	// Updating the job keeps the usage
+	require.NoError(t, a.Store.SetJob(job, false))
+	stored, err = a.Store.GetJob(job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Budget.Used)
+
+	resp, err := http.Post(baseURL+"/jobs/geocode", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	// Runs that couldn't be dispatched give their run back, kept when
+	// updating the job
+	a.refundBudget(job, now)
+	require.NoError(t, a.Store.SetJob(job, false))
+	stored, err = a.Store.GetJob(job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Budget.Refunded)
+	assert.Equal(t, 1, stored.Budget.Remaining())
+	assert.NoError(t, a.useBudget(job, now))
+	assert.Error(t, a.useBudget(job, now))
+
+	unplaced := &Job{
+		Name:     "unplaced",
+		Schedule: "@manually",
+		Executor: "shell",
+		Tags:     map[string]string{"role": "missing"},
+		Budget:   &Budget{MaxRuns: 1, Period: BudgetDay},
+	}
+	require.NoError(t, a.Store.SetJob(unplaced, false))
+	resp, err = http.Post(baseURL+"/jobs/unplaced", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.NotEqual(t, http.StatusAccepted, resp.StatusCode)
+	stored, err = a.Store.GetJob(unplaced.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Budget.Used)
+	assert.Equal(t, 1, stored.Budget.Remaining())
+
+	// The next day has runs again
+	assert.NoError(t, a.useBudget(job, now.Add(24*time.Hour)))
+
+	job.Budget = &Budget{MaxRuns: 1, Period: "week"}
+	assert.Error(t, job.Validate())
+}
@@ -0,0 +1,236 @@
+package dkron
+
+import (
+	"sort"
+	"sync"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// jobCache is a read-through cache of the jobs and of their last execution
+// group, saving the decoding of every stored job on every read.
+//
+// Entries are invalidated in the write transactions changing them, while
+// holding the store write lock, so readers can't see them until the write
+// is committed. Readers filling the cache pass the generation they got
+// before reading the store, fills from reads that raced with a write are
+// dropped.
+type jobCache struct {
+	sync.RWMutex
+	gen uint64
+
+	jobs map[string]*dkronpb.Job
+	// stale are the jobs written since they were cached, new ones included.
+	stale map[string]bool
+	// complete is set when jobs and stale hold every job in the store.
+	complete bool
+
+	lastGroups map[string][]*dkronpb.Execution
+
+	// statuses are the statuses of the last execution group of the jobs,
+	// valid while the generation they were computed at is current.
+	statuses    map[string]string
+	statusesGen uint64
+
+	// disabled caches nothing, every read goes to the store.
+	disabled bool
+}
+
+func newJobCache() *jobCache {
+	c := &jobCache{}
+	c.reset()
+	return c
+}
+
+// reset empties the cache.
+func (c *jobCache) reset() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.gen++
+	c.jobs = map[string]*dkronpb.Job{}
+	c.stale = map[string]bool{}
+	c.complete = false
+	c.lastGroups = map[string][]*dkronpb.Execution{}
+	c.statuses = nil
+}
+
+// setDisabled enables or disables the cache, emptying it.
+func (c *jobCache) setDisabled(disabled bool) {
+	c.reset()
+
+	c.Lock()
+	defer c.Unlock()
+	c.disabled = disabled
+}
+
+// generation returns the generation to pass when filling the cache.
+func (c *jobCache) generation() uint64 {
+	c.RLock()
+	defer c.RUnlock()
+	return c.gen
+}
+
+// invalidateJob marks the job as changed, it's read again from the store.
+func (c *jobCache) invalidateJob(name string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.gen++
+	delete(c.jobs, name)
+	c.stale[name] = true
+}
+
+// invalidateExecutions drops the last execution group of the job.
+func (c *jobCache) invalidateExecutions(jobName string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.gen++
+	delete(c.lastGroups, jobName)
+}
+
+// job returns a copy of the cached job.
+func (c *jobCache) job(name string) (*dkronpb.Job, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	pbj, ok := c.jobs[name]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(pbj).(*dkronpb.Job), true
+}
+
+// setJob caches the job read from the store at the generation.
+func (c *jobCache) setJob(gen uint64, pbj *dkronpb.Job) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.disabled || gen != c.gen {
+		return
+	}
+	c.jobs[pbj.Name] = proto.Clone(pbj).(*dkronpb.Job)
+	delete(c.stale, pbj.Name)
+}
+
+// allJobs returns copies of the cached jobs sorted by name, the names of
+// the stale ones and the generation to refresh them, ok is false unless
+// the cache has every job.
+func (c *jobCache) allJobs() (jobs []*dkronpb.Job, stale []string, gen uint64, ok bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.complete {
+		return nil, nil, c.gen, false
+	}
+	for _, pbj := range c.jobs {
+		jobs = append(jobs, proto.Clone(pbj).(*dkronpb.Job))
+	}
+	for name := range c.stale {
+		stale = append(stale, name)
+	}
+	sortJobProtos(jobs)
+	return jobs, stale, c.gen, true
+}
+
+// setAllJobs caches every job in the store, read at the generation.
+func (c *jobCache) setAllJobs(gen uint64, jobs []*dkronpb.Job) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.disabled || gen != c.gen {
+		return
+	}
+	c.jobs = make(map[string]*dkronpb.Job, len(jobs))
+	for _, pbj := range jobs {
+		c.jobs[pbj.Name] = proto.Clone(pbj).(*dkronpb.Job)
+	}
+	c.stale = map[string]bool{}
+	c.complete = true
+}
+
+// refreshJobs caches the stale jobs read from the store at the
+// generation, the missing ones were deleted.
+func (c *jobCache) refreshJobs(gen uint64, names []string, jobs map[string]*dkronpb.Job) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.disabled || gen != c.gen {
+		return
+	}
+	for _, name := range names {
+		if pbj, ok := jobs[name]; ok {
+			c.jobs[name] = proto.Clone(pbj).(*dkronpb.Job)
+		} else {
+			delete(c.jobs, name)
+		}
+		delete(c.stale, name)
+	}
+}
+
+// lastGroup returns copies of the cached last execution group of the job.
+func (c *jobCache) lastGroup(jobName string) ([]*dkronpb.Execution, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	group, ok := c.lastGroups[jobName]
+	if !ok {
+		return nil, false
+	}
+	executions := make([]*dkronpb.Execution, 0, len(group))
+	for _, pbe := range group {
+		executions = append(executions, proto.Clone(pbe).(*dkronpb.Execution))
+	}
+	return executions, true
+}
+
+// setLastGroup caches the last execution group of the job read from the
+// store at the generation.
+func (c *jobCache) setLastGroup(gen uint64, jobName string, executions []*dkronpb.Execution) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.disabled || gen != c.gen {
+		return
+	}
+	c.lastGroups[jobName] = executions
+}
+
+// jobStatuses returns a copy of the cached statuses, they're computed again
+// after any write.
+func (c *jobCache) jobStatuses() (map[string]string, bool) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.statuses == nil || c.statusesGen != c.gen {
+		return nil, false
+	}
+	statuses := make(map[string]string, len(c.statuses))
+	for name, status := range c.statuses {
+		statuses[name] = status
+	}
+	return statuses, true
+}
+
+// setStatuses caches the statuses computed at the generation.
+func (c *jobCache) setStatuses(gen uint64, statuses map[string]string) {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.disabled || gen != c.gen {
+		return
+	}
+	c.statuses = make(map[string]string, len(statuses))
+	for name, status := range statuses {
+		c.statuses[name] = status
+	}
+	c.statusesGen = gen
+}
+
+func sortJobProtos(jobs []*dkronpb.Job) {
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+}
@@ -0,0 +1,108 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestStore_JobCache(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	newJob := func(name, command string) *Job {
+		return &Job{
+			Name:           name,
+			Schedule:       "@every 2s",
+			Executor:       "shell",
+			ExecutorConfig: map[string]string{"command": command},
+			Disabled:       true,
+		}
+	}
+	require.NoError(t, s.SetJob(newJob("a", "/bin/true"), true))
+	require.NoError(t, s.SetJob(newJob("b", "/bin/true"), true))
+
+	// Fill the cache
+	jobs, err := s.GetJobs(nil)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	job, err := s.GetJob("a", nil)
+	require.NoError(t, err)
+
+	// Changing the returned jobs doesn't change the cached ones
+	job.ExecutorConfig["command"] = "/bin/false"
+	jobs[1].Schedule = "@daily"
+	job, err = s.GetJob("a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/bin/true", job.ExecutorConfig["command"])
+
+	// Writes are seen by the next reads
+	require.NoError(t, s.SetJob(newJob("a", "/bin/false"), true))
+	require.NoError(t, s.SetJob(newJob("c", "/bin/true"), true))
+	_, err = s.DeleteJob("b")
+	require.NoError(t, err)
+
+	job, err = s.GetJob("a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/bin/false", job.ExecutorConfig["command"])
+	jobs, err = s.GetJobs(nil)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "a", jobs[0].Name)
+	assert.Equal(t, "/bin/false", jobs[0].ExecutorConfig["command"])
+	assert.Equal(t, "c", jobs[1].Name)
+	_, err = s.GetJob("b", nil)
+	assert.Equal(t, buntdb.ErrNotFound, err)
+
+	// The last execution group follows the executions
+	now := time.Now()
+	running := &Execution{JobName: "a", Group: now.UnixNano(), StartedAt: now, NodeName: "node1"}
+	_, err = s.SetExecution(running)
+	require.NoError(t, err)
+	group, err := s.GetLastExecutionGroup("a")
+	require.NoError(t, err)
+	require.Len(t, group, 1)
+	assert.True(t, group[0].FinishedAt.IsZero())
+	assert.Equal(t, running.Key(), group[0].Id)
+
+	running.FinishedAt = now.Add(time.Second)
+	running.Success = true
+	_, err = s.SetExecutionDone(running)
+	require.NoError(t, err)
+	group, err = s.GetLastExecutionGroup("a")
+	require.NoError(t, err)
+	require.Len(t, group, 1)
+	assert.True(t, group[0].Success)
+	job, err = s.GetJob("a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, int(job.SuccessCount))
+}
+
+func TestJobCache_dropsRacingFills(t *testing.T) {
+	c := newJobCache()
+
+	gen := c.generation()
+	c.invalidateJob("a")
+	c.setJob(gen, &dkronpb.Job{Name: "a"})
+	_, ok := c.job("a")
+	assert.False(t, ok)
+
+	gen = c.generation()
+	c.setAllJobs(gen, []*dkronpb.Job{{Name: "a"}})
+	c.invalidateJob("b")
+	jobs, stale, _, ok := c.allJobs()
+	require.True(t, ok)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, []string{"b"}, stale)
+
+	gen = c.generation()
+	c.invalidateExecutions("a")
+	c.setLastGroup(gen, "a", []*dkronpb.Execution{{JobName: "a"}})
+	_, ok = c.lastGroup("a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,344 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin"
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrInvalidCanary is returned when the canary of a job is not valid.
+	ErrInvalidCanary = errors.New("invalid canary")
+	// ErrCanaryNewJob is returned when starting a canary for a job that
+	// doesn't exist yet.
+	ErrCanaryNewJob = errors.New("canary needs an existing job to keep running")
+	// ErrNoCanary is returned when a job has no canary.
+	ErrNoCanary = errors.New("job has no canary")
+	// ErrCanaryServerFields is returned when a job update sets the fields
+	// of a canary set by the server, other than the ones of its running
+	// canary.
+	ErrCanaryServerFields = errors.New("started_at, ends_at and stats of a canary are set by the server")
+)
+
+// Canary rolls out a new spec of a job gradually. The job keeps running
+// its current spec and a share of its runs use the new one until the
+// canary ends, when the new spec replaces the current one.
+type Canary struct {
+	// Executor and executor config of the new spec.
+	Executor       string                      `json:"executor"`
+	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config"`
+
+	// Percentage of the fire times running the new spec.
+	Percent int `json:"percent,omitempty"`
+
+	// Nodes running the new spec on every fire time.
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Period of the canary, set by the server when updating the job.
+	StartedAt time.Time `json:"started_at"`
+	EndsAt    time.Time `json:"ends_at"`
+
+	// Duration of the canary, sets the end when updating the job.
+	Duration string `json:"duration,omitempty"`
+
+	// Results of the runs of each spec since the canary started.
+	Stable CanaryStats `json:"stable"`
+	Canary CanaryStats `json:"canary"`
+}
+
+// CanaryStats counts the runs of a spec of a job during its canary.
+type CanaryStats struct {
+	SuccessCount  int           `json:"success_count"`
+	ErrorCount    int           `json:"error_count"`
+	TotalDuration time.Duration `json:"total_duration"`
+}
+
+func canaryFromProto(in *proto.Canary) *Canary {
+	if in == nil {
+		return nil
+	}
+	c := &Canary{
+		Executor:       in.Executor,
+		ExecutorConfig: in.ExecutorConfig,
+		Percent:        int(in.Percent),
+		Nodes:          in.Nodes,
+		Stable:         canaryStatsFromProto(in.Stable),
+		Canary:         canaryStatsFromProto(in.Canary),
+	}
+	if in.StartedAt != nil {
+		c.StartedAt, _ = ptypes.Timestamp(in.StartedAt)
+	}
+	if in.EndsAt != nil {
+		c.EndsAt, _ = ptypes.Timestamp(in.EndsAt)
+	}
+	return c
+}
+
+func (c *Canary) toProto() *proto.Canary {
+	if c == nil {
+		return nil
+	}
+	pbc := &proto.Canary{
+		Executor:       c.Executor,
+		ExecutorConfig: c.ExecutorConfig,
+		Percent:        int32(c.Percent),
+		Nodes:          c.Nodes,
+		Stable:         c.Stable.toProto(),
+		Canary:         c.Canary.toProto(),
+	}
+	if !c.StartedAt.IsZero() {
+		pbc.StartedAt, _ = ptypes.TimestampProto(c.StartedAt)
+	}
+	if !c.EndsAt.IsZero() {
+		pbc.EndsAt, _ = ptypes.TimestampProto(c.EndsAt)
+	}
+	return pbc
+}
+
+func canaryStatsFromProto(in *proto.CanaryStats) CanaryStats {
+	return CanaryStats{
+		SuccessCount:  int(in.GetSuccessCount()),
+		ErrorCount:    int(in.GetErrorCount()),
+		TotalDuration: time.Duration(in.GetTotalDuration()),
+	}
+}
+
+func (s CanaryStats) toProto() *proto.CanaryStats {
+	return &proto.CanaryStats{
+		SuccessCount:  int32(s.SuccessCount),
+		ErrorCount:    int32(s.ErrorCount),
+		TotalDuration: int64(s.TotalDuration),
+	}
+}
+
+// validate checks the canary of the job.
+func (c *Canary) validate(job *Job) error {
+	if len(job.Steps) > 0 {
+		return fmt.Errorf("%s: jobs with steps can't have a canary", ErrInvalidCanary)
+	}
+	if c.Percent < 0 || c.Percent > 100 {
+		return fmt.Errorf("%s: percent must be between 0 and 100", ErrInvalidCanary)
+	}
+	if c.Percent == 0 && len(c.Nodes) == 0 {
+		return fmt.Errorf("%s: a percent or nodes are needed", ErrInvalidCanary)
+	}
+	if c.Duration != "" {
+		d, err := time.ParseDuration(c.Duration)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s: invalid duration %q", ErrInvalidCanary, c.Duration)
+		}
+	} else if !c.EndsAt.After(c.StartedAt) {
+		return fmt.Errorf("%s: a duration is needed", ErrInvalidCanary)
+	}
+	return nil
+}
+
+// serverSet returns whether the fields set by the server are set.
+func (c *Canary) serverSet() bool {
+	return !c.StartedAt.IsZero() || !c.EndsAt.IsZero() || c.Stable != (CanaryStats{}) || c.Canary != (CanaryStats{})
+}
+
+// update returns the canary of a job update given the canary of the
+// stored job. Updates start a new canary, or carry the running one as
+// read from the API, its percent and nodes aside.
+func (c *Canary) update(job, current *Job, now time.Time) (*Canary, error) {
+	if !c.serverSet() {
+		if current == nil {
+			return nil, ErrCanaryNewJob
+		}
+		c.start(job, current, now)
+		return c, nil
+	}
+
+	if current == nil || current.Canary == nil {
+		return nil, ErrCanaryServerFields
+	}
+	running := current.Canary
+	if c.Executor != running.Executor || !reflect.DeepEqual(c.ExecutorConfig, running.ExecutorConfig) ||
+		!c.StartedAt.Equal(running.StartedAt) || !c.EndsAt.Equal(running.EndsAt) {
+		return nil, ErrCanaryServerFields
+	}
+	kept := *running
+	kept.Percent = c.Percent
+	kept.Nodes = c.Nodes
+	return &kept, nil
+}
+
+// start turns the job update into a canary of the current job. The job
+// keeps the spec of current and the canary runs the updated one.
+func (c *Canary) start(job, current *Job, now time.Time) {
+	d, _ := time.ParseDuration(c.Duration)
+	c.Executor = job.Executor
+	c.ExecutorConfig = job.ExecutorConfig
+	c.StartedAt = now
+	c.EndsAt = now.Add(d)
+	c.Duration = ""
+	c.Stable = CanaryStats{}
+	c.Canary = CanaryStats{}
+
+	job.Executor = current.Executor
+	job.ExecutorConfig = current.ExecutorConfig
+}
+
+// routes returns whether the run of the execution group in the node uses
+// the new spec. Fire times are picked by their group so retries keep the
+// spec of their first attempt.
+func (c *Canary) routes(node string, group int64) bool {
+	for _, n := range c.Nodes {
+		if n == node {
+			return true
+		}
+	}
+	if c.Percent <= 0 {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(strconv.FormatInt(group, 10)))
+	return int(h.Sum32()%100) < c.Percent
+}
+
+// recordCanaryRun counts the finished execution in the stats of the spec
+// it ran.
+func recordCanaryRun(pbc *proto.Canary, pbe *proto.Execution) {
+	stats := pbc.Stable
+	if pbe.Canary {
+		stats = pbc.Canary
+	}
+	if stats == nil {
+		stats = &proto.CanaryStats{}
+	}
+	if pbe.Success {
+		stats.SuccessCount++
+	} else {
+		stats.ErrorCount++
+	}
+	started, _ := ptypes.Timestamp(pbe.StartedAt)
+	finished, _ := ptypes.Timestamp(pbe.FinishedAt)
+	if finished.After(started) {
+		stats.TotalDuration += int64(finished.Sub(started))
+	}
+	if pbe.Canary {
+		pbc.Canary = stats
+	} else {
+		pbc.Stable = stats
+	}
+}
+
+// canaryRun returns the job and execution run in the node, with the new
+// spec if the canary routes the run to it.
+func (j *Job) canaryRun(node string, ex *Execution) (*Job, *Execution) {
+	if j.Canary == nil || !j.Canary.routes(node, ex.Group) {
+		return j, ex
+	}
+	cj := *j
+	cj.Executor = j.Canary.Executor
+	cj.ExecutorConfig = j.Canary.ExecutorConfig
+	cex := *ex
+	cex.Canary = true
+	return &cj, &cex
+}
+
+// promoteCanary replaces the spec of the job with the one of its canary.
+func (j *Job) promoteCanary() {
+	j.Executor = j.Canary.Executor
+	j.ExecutorConfig = j.Canary.ExecutorConfig
+	j.Canary = nil
+}
+
+// canaryComparison compares the results of the specs of a job during its
+// canary.
+type canaryComparison struct {
+	*Canary
+	StableSummary canarySummary `json:"stable_summary"`
+	CanarySummary canarySummary `json:"canary_summary"`
+}
+
+type canarySummary struct {
+	Runs        int     `json:"runs"`
+	SuccessRate float64 `json:"success_rate"`
+	AvgDuration string  `json:"avg_duration"`
+}
+
+func (s CanaryStats) summary() canarySummary {
+	sum := canarySummary{Runs: s.SuccessCount + s.ErrorCount}
+	if sum.Runs > 0 {
+		sum.SuccessRate = float64(s.SuccessCount) / float64(sum.Runs)
+		sum.AvgDuration = (s.TotalDuration / time.Duration(sum.Runs)).String()
+	}
+	return sum
+}
+
+func (h *HTTPTransport) jobCanaryHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+	if job.Canary == nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		c.Writer.WriteString(ErrNoCanary.Error())
+		return
+	}
+
+	renderJSON(c, http.StatusOK, &canaryComparison{
+		Canary:        job.Canary,
+		StableSummary: job.Canary.Stable.summary(),
+		CanarySummary: job.Canary.Canary.summary(),
+	})
+}
+
+// jobCanaryEndHandler ends the canary of a job, promoting its new spec or
+// keeping the current one.
+func (h *HTTPTransport) jobCanaryEndHandler(promote bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+		if err != nil {
+			if err == buntdb.ErrNotFound {
+				c.AbortWithError(http.StatusNotFound, err)
+			} else {
+				c.AbortWithError(http.StatusInternalServerError, err)
+			}
+			return
+		}
+		if job.Canary == nil {
+			c.AbortWithStatus(http.StatusNotFound)
+			c.Writer.WriteString(ErrNoCanary.Error())
+			return
+		}
+		if !h.checkWritable(c, job.Name) {
+			return
+		}
+
+		if promote {
+			job.promoteCanary()
+		} else {
+			job.Canary = nil
+		}
+		h.stampJob(c, job)
+
+		// Call gRPC SetJob
+		if err := h.agent.GRPCClient.SetJob(job, c.GetHeader(adminTokenHeader)); err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			c.Writer.WriteString(status.Convert(err).Message())
+			return
+		}
+
+		log.WithFields(logrus.Fields{
+			"job":      job.Name,
+			"promoted": promote,
+		}).Info("api: Canary ended")
+		renderJSON(c, http.StatusOK, job)
+	}
+}
@@ -0,0 +1,177 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanary_routes(t *testing.T) {
+	c := &Canary{Nodes: []string{"node-2"}}
+	assert.True(t, c.routes("node-2", 1))
+	assert.False(t, c.routes("node-1", 1))
+
+	c = &Canary{Percent: 100}
+	assert.True(t, c.routes("node-1", 1))
+
+	// Around the percent of the fire times run the new spec, always the
+	// same ones
+	c = &Canary{Percent: 20}
+	routed := 0
+	for group := int64(0); group < 1000; group++ {
+		if c.routes("node-1", group) {
+			routed++
+		}
+		assert.Equal(t, c.routes("node-1", group), c.routes("node-2", group))
+	}
+	assert.InDelta(t, 200, routed, 60)
+
+	job := scaffoldJob()
+	job.Canary = &Canary{Executor: "http", ExecutorConfig: map[string]string{"url": "http://example.com"}, Nodes: []string{"node-2"}}
+	cj, cex := job.canaryRun("node-2", &Execution{JobName: job.Name})
+	assert.Equal(t, "http", cj.Executor)
+	assert.True(t, cex.Canary)
+	cj, cex = job.canaryRun("node-1", &Execution{JobName: job.Name})
+	assert.Equal(t, "shell", cj.Executor)
+	assert.False(t, cex.Canary)
+}
+
+func TestJobValidateCanary(t *testing.T) {
+	job := scaffoldJob()
+	job.Canary = &Canary{Percent: 10, Duration: "24h"}
+	assert.NoError(t, job.Validate())
+
+	job.Canary = &Canary{Percent: 10}
+	assert.Error(t, job.Validate())
+	job.Canary = &Canary{Duration: "24h"}
+	assert.Error(t, job.Validate())
+	job.Canary = &Canary{Percent: 110, Duration: "24h"}
+	assert.Error(t, job.Validate())
+}
+
+func TestStore_CanaryStats(t *testing.T) {
+	s := setupStore(t)
+	defer s.Shutdown()
+
+	now := time.Now()
+	job := scaffoldJob()
+	job.Canary = &Canary{
+		Executor:  "shell",
+		Percent:   50,
+		StartedAt: now,
+		EndsAt:    now.Add(time.Hour),
+	}
+	require.NoError(t, s.SetJob(job, false))
+
+	done := func(success, canary bool, d time.Duration) {
+		now = now.Add(time.Minute)
+		_, err := s.SetExecutionDone(&Execution{
+			JobName:    job.Name,
+			StartedAt:  now,
+			FinishedAt: now.Add(d),
+			Success:    success,
+			NodeName:   "testNode",
+			Group:      now.UnixNano(),
+			Canary:     canary,
+		})
+		require.NoError(t, err)
+	}
+	done(true, false, time.Second)
+	done(true, true, 3*time.Second)
+	done(false, true, time.Second)
+
+	c := loadJob(t, s, job.Name).Canary
+	require.NotNil(t, c)
+	assert.Equal(t, CanaryStats{SuccessCount: 1, TotalDuration: time.Second}, c.Stable)
+	assert.Equal(t, CanaryStats{SuccessCount: 1, ErrorCount: 1, TotalDuration: 4 * time.Second}, c.Canary)
+	assert.Equal(t, 0.5, c.Canary.summary().SuccessRate)
+	assert.Equal(t, "2s", c.Canary.summary().AvgDuration)
+
+	// Updating the job keeps the counts of its canary
+	require.NoError(t, s.SetJob(job, false))
+	assert.Equal(t, 2, loadJob(t, s, job.Name).Canary.Canary.summary().Runs)
+}
+
+func TestAPIJobCanary(t *testing.T) {
+	port := "8136"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	body := `{"name": "canary", "schedule": "@every 1h", "executor": "shell", "executor_config": {"command": "echo v1"}, "disabled": true}`
+	resp, err := http.Post(baseURL+"/jobs", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// A canary needs an existing job
+	body = `{"name": "other", "schedule": "@every 1h", "executor": "shell", "executor_config": {"command": "echo v2"}, "canary": {"percent": 10, "duration": "24h"}}`
+	resp, err = http.Post(baseURL+"/jobs", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	body = `{"name": "canary", "schedule": "@every 1h", "executor": "shell", "executor_config": {"command": "echo v2"}, "disabled": true, "canary": {"percent": 10, "duration": "24h"}}`
+	resp, err = http.Post(baseURL+"/jobs", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	job, err := a.Store.GetJob("canary", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "echo v1", job.ExecutorConfig["command"])
+	require.NotNil(t, job.Canary)
+	assert.Equal(t, "echo v2", job.Canary.ExecutorConfig["command"])
+	assert.Equal(t, 24*time.Hour, job.Canary.EndsAt.Sub(job.Canary.StartedAt))
+
+	// Updates carry the running canary as read, but can't set one
+	job.Canary.Percent = 20
+	data, err := json.Marshal(job)
+	require.NoError(t, err)
+	resp, err = http.Post(baseURL+"/jobs", "application/json", bytes.NewBuffer(data))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	stored, err := a.Store.GetJob("canary", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 20, stored.Canary.Percent)
+	assert.Equal(t, "echo v2", stored.Canary.ExecutorConfig["command"])
+
+	body = `{"name": "canary", "schedule": "@every 1h", "executor": "shell", "executor_config": {"command": "echo v1"}, "disabled": true, "canary": {"executor": "shell", "executor_config": {"command": "echo v3"}, "percent": 10, "started_at": "2020-06-01T10:00:00Z", "ends_at": "2030-06-01T10:00:00Z"}}`
+	resp, err = http.Post(baseURL+"/jobs", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/jobs/canary/canary")
+	require.NoError(t, err)
+	var comparison map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&comparison))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, comparison, "stable_summary")
+	assert.Contains(t, comparison, "canary_summary")
+
+	resp, err = http.Post(baseURL+"/jobs/canary/canary/promote", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	job, err = a.Store.GetJob("canary", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "echo v2", job.ExecutorConfig["command"])
+	assert.Nil(t, job.Canary)
+
+	resp, err = http.Get(baseURL + "/jobs/canary/canary")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
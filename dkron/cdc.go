@@ -0,0 +1,567 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	changesPrefix    = "cdc:changes"
+	changeOffsetKey  = "cdc:offset"
+	changeSeqKey     = "cdc:seq"
+	changePendingKey = "cdc:pending"
+
+	// maxChangeSeq is the highest sequence of a change in its offset.
+	maxChangeSeq = 999999
+
+	// cdcInterval is how often the leader looks for new changes once
+	// the sink caught up.
+	cdcInterval = time.Second
+	// cdcBatchSize is the number of raft logs whose changes are sent to
+	// the sink at once.
+	cdcBatchSize = 100
+	// cdcTimeout is the timeout of the requests to the sink.
+	cdcTimeout = 30 * time.Second
+)
+
+// Kinds of the objects changed.
+const (
+	ChangeJob       = "job"
+	ChangeExecution = "execution"
+)
+
+// Operations of the changes.
+const (
+	ChangeSet    = "set"
+	ChangeDelete = "delete"
+	// ChangePurge deletes all the executions of the job.
+	ChangePurge = "purge"
+)
+
+// ErrCDCKafkaTopic is returned when the Kafka REST Proxy is set without
+// the topic to produce to.
+var ErrCDCKafkaTopic = errors.New("the Kafka REST Proxy needs the topic to produce the changes to")
+
+// Change is a mutation of a job or an execution in the store, streamed to
+// the change data capture sink in order.
+type Change struct {
+	// Offset is the raft index of the mutation, the changes of the same
+	// mutation share it. Expirations take the index of the last log applied.
+	Offset uint64 `json:"offset"`
+	// Kind of the object changed, job or execution.
+	Kind string `json:"kind"`
+	// Op is set, delete or purge.
+	Op string `json:"op"`
+	// Job is the name of the job, or of the job of the execution.
+	Job string `json:"job"`
+	// Key of the execution in the executions of its job.
+	Key string `json:"key,omitempty"`
+	// Data is the job or execution as returned by the API, the last
+	// state of it for deletes.
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// seq orders the changes sharing the offset.
+	seq int
+}
+
+func newJobChange(op string, job *Job) *Change {
+	data, _ := json.Marshal(job)
+	return &Change{Kind: ChangeJob, Op: op, Job: job.Name, Data: data}
+}
+
+func newExecutionChange(op string, ex *Execution) *Change {
+	data, _ := json.Marshal(ex)
+	return &Change{Kind: ChangeExecution, Op: op, Job: ex.JobName, Key: ex.Key(), Data: data}
+}
+
+// CDCStatus is the state of the delivery of the changes to the sink.
+type CDCStatus struct {
+	Sink string `json:"sink"`
+	// Offset of the last change delivered.
+	Offset uint64 `json:"offset"`
+	// Pending is the number of changes not delivered yet.
+	Pending int `json:"pending"`
+}
+
+// cdcMaxPending returns the number of undelivered changes the servers
+// keep, zero when no sink is configured and the changes aren't recorded.
+func (c *Config) cdcMaxPending() int {
+	if c.CDCWebhook == "" && c.CDCKafkaProxy == "" {
+		return 0
+	}
+	return c.CDCMaxPending
+}
+
+func changeKey(offset uint64, seq int) string {
+	return fmt.Sprintf("%s:%020d:%06d", changesPrefix, offset, seq)
+}
+
+// parseChangeKey returns the offset and the sequence of the change stored
+// with the key.
+func parseChangeKey(key string) (uint64, int) {
+	parts := strings.Split(strings.TrimPrefix(key, changesPrefix+":"), ":")
+	offset, _ := strconv.ParseUint(parts[0], 10, 64)
+	seq := 0
+	if len(parts) > 1 {
+		seq, _ = strconv.Atoi(parts[1])
+	}
+	return offset, seq
+}
+
+func getUintTx(tx *buntdb.Tx, key string) (uint64, error) {
+	value, err := tx.Get(key)
+	if err == buntdb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// RecordChanges makes the store record its changes for the change data
+// capture sink, keeping up to maxPending undelivered changes. Zero doesn't
+// record them.
+func (s *Store) RecordChanges(maxPending int) {
+	s.cdcMaxPending = maxPending
+}
+
+// SetApplyIndex sets the raft index of the log being applied, the offset
+// of the changes it makes.
+func (s *Store) SetApplyIndex(index uint64) {
+	atomic.StoreUint64(&s.applyIndex, index)
+}
+
+// recordJobTx records the set of the job in the transaction writing it.
+func (s *Store) recordJobTx(tx *buntdb.Tx, pbj *dkronpb.Job) error {
+	if s.cdcMaxPending <= 0 {
+		return nil
+	}
+	return s.appendChangesTx(tx, false, newJobChange(ChangeSet, NewJobFromProto(pbj)))
+}
+
+// recordExecutionTx records the set of the execution in the transaction
+// writing it. Shadow runs aren't part of the state of the job.
+func (s *Store) recordExecutionTx(tx *buntdb.Tx, pbe *dkronpb.Execution) error {
+	if s.cdcMaxPending <= 0 || pbe.Shadow != "" {
+		return nil
+	}
+	ex := NewExecutionFromProto(pbe)
+	ex.setLegacyID()
+	return s.appendChangesTx(tx, false, newExecutionChange(ChangeSet, ex))
+}
+
+// recordDeleteTx records the deletion of the job or the execution stored
+// with the key and value in the transaction deleting it, other keys
+// aren't recorded. Expired keys are deleted outside of the raft log.
+func (s *Store) recordDeleteTx(tx *buntdb.Tx, key, value string, expired bool) error {
+	if s.cdcMaxPending <= 0 {
+		return nil
+	}
+
+	var c *Change
+	switch {
+	case strings.HasPrefix(key, jobsPrefix+":"):
+		var pbj dkronpb.Job
+		if err := decodeJob([]byte(value), &pbj); value != "" && err == nil {
+			c = newJobChange(ChangeDelete, NewJobFromProto(&pbj))
+		} else {
+			c = &Change{Kind: ChangeJob, Op: ChangeDelete, Job: strings.TrimPrefix(key, jobsPrefix+":")}
+		}
+	case strings.HasPrefix(key, executionsPrefix+":"):
+		var pbe dkronpb.Execution
+		if err := decodeExecution([]byte(value), &pbe); value != "" && err == nil {
+			ex := NewExecutionFromProto(&pbe)
+			ex.setLegacyID()
+			c = newExecutionChange(ChangeDelete, ex)
+		} else {
+			parts := strings.SplitN(key, ":", 3)
+			c = &Change{Kind: ChangeExecution, Op: ChangeDelete, Job: parts[1], Key: parts[2]}
+		}
+	default:
+		return nil
+	}
+	return s.appendChangesTx(tx, expired, c)
+}
+
+// appendChangesTx records the changes with the offset of the raft log
+// being applied, after the changes already recorded with it. A replayed
+// log, already delivered, isn't recorded again. Changes made outside of
+// the raft log take the offset of the last log applied, or the last
+// delivered if later, and go after the changes delivered. Past the max
+// pending undelivered changes the oldest are dropped.
+func (s *Store) appendChangesTx(tx *buntdb.Tx, expired bool, changes ...*Change) error {
+	committed, err := getUintTx(tx, changeOffsetKey)
+	if err != nil {
+		return err
+	}
+	offset := atomic.LoadUint64(&s.applyIndex)
+	if offset <= committed {
+		if !expired {
+			return nil
+		}
+		offset = committed
+	}
+
+	seq := 0
+	tx.DescendLessOrEqual("", changeKey(offset, maxChangeSeq), func(key, value string) bool {
+		if strings.HasPrefix(key, changesPrefix+":") {
+			if o, sq := parseChangeKey(key); o == offset {
+				seq = sq + 1
+			}
+		}
+		return false
+	})
+	if offset == committed {
+		next, err := getUintTx(tx, changeSeqKey)
+		if err != nil {
+			return err
+		}
+		if seq < int(next) {
+			seq = int(next)
+		}
+	}
+
+	pending, err := getUintTx(tx, changePendingKey)
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		c.Offset = offset
+		value, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(changeKey(offset, seq), string(value), nil); err != nil {
+			return err
+		}
+		seq++
+	}
+	pending += uint64(len(changes))
+
+	if max := uint64(s.cdcMaxPending); pending > max {
+		var keys []string
+		tx.AscendKeys(changesPrefix+":*", func(key, value string) bool {
+			keys = append(keys, key)
+			return uint64(len(keys)) < pending-max
+		})
+		for _, k := range keys {
+			if _, err := tx.Delete(k); err != nil {
+				return err
+			}
+		}
+		pending -= uint64(len(keys))
+		log.WithField("dropped", len(keys)).Warn("store: Dropped undelivered changes over the max pending")
+		metrics.IncrCounter([]string{"cdc", "dropped"}, float32(len(keys)))
+	}
+
+	_, _, err = tx.Set(changePendingKey, strconv.FormatUint(pending, 10), nil)
+	return err
+}
+
+// GetChanges returns the changes after the last delivered, of up to limit
+// raft logs.
+func (s *Store) GetChanges(limit int) ([]*Change, error) {
+	changes := []*Change{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		committed, err := getUintTx(tx, changeOffsetKey)
+		if err != nil {
+			return err
+		}
+		next, err := getUintTx(tx, changeSeqKey)
+		if err != nil {
+			return err
+		}
+
+		logs := 0
+		var last uint64
+		tx.AscendGreaterOrEqual("", changeKey(committed, int(next)), func(key, value string) bool {
+			if !strings.HasPrefix(key, changesPrefix+":") {
+				return false
+			}
+			var c Change
+			if err = json.Unmarshal([]byte(value), &c); err != nil {
+				return false
+			}
+			if c.Offset != last {
+				if logs == limit {
+					return false
+				}
+				logs++
+				last = c.Offset
+			}
+			_, c.seq = parseChangeKey(key)
+			changes = append(changes, &c)
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CommitChanges records the changes up to the one with the offset and
+// sequence as delivered and deletes them.
+func (s *Store) CommitChanges(offset uint64, seq int) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		committed, err := getUintTx(tx, changeOffsetKey)
+		if err != nil {
+			return err
+		}
+		next, err := getUintTx(tx, changeSeqKey)
+		if err != nil {
+			return err
+		}
+		if offset < committed || (offset == committed && uint64(seq) < next) {
+			return nil
+		}
+		pending, err := getUintTx(tx, changePendingKey)
+		if err != nil {
+			return err
+		}
+
+		last := changeKey(offset, seq)
+		var keys []string
+		tx.AscendKeys(changesPrefix+":*", func(key, value string) bool {
+			if key > last {
+				return false
+			}
+			keys = append(keys, key)
+			return true
+		})
+		for _, k := range keys {
+			if _, err := tx.Delete(k); err != nil {
+				return err
+			}
+		}
+		if uint64(len(keys)) < pending {
+			pending -= uint64(len(keys))
+		} else {
+			pending = 0
+		}
+
+		if _, _, err := tx.Set(changePendingKey, strconv.FormatUint(pending, 10), nil); err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(changeSeqKey, strconv.Itoa(seq+1), nil); err != nil {
+			return err
+		}
+		_, _, err = tx.Set(changeOffsetKey, strconv.FormatUint(offset, 10), nil)
+		return err
+	})
+}
+
+// GetChangeOffset returns the offset of the last change delivered and the
+// number of changes pending.
+func (s *Store) GetChangeOffset() (uint64, int, error) {
+	var offset, pending uint64
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		if offset, err = getUintTx(tx, changeOffsetKey); err != nil {
+			return err
+		}
+		pending, err = getUintTx(tx, changePendingKey)
+		return err
+	})
+	return offset, int(pending), err
+}
+
+// changeSink delivers the changes, in order, to an external system.
+type changeSink interface {
+	Name() string
+	Send(changes []*Change) error
+}
+
+// webhookSink posts the changes as a JSON array.
+type webhookSink struct {
+	url     string
+	headers []string
+	client  *http.Client
+}
+
+func (w *webhookSink) Name() string {
+	return "webhook"
+}
+
+func (w *webhookSink) Send(changes []*Change) error {
+	body, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	return postChanges(w.client, w.url, "application/json", w.headers, body)
+}
+
+// kafkaRESTSink produces the changes to a topic through the v2 API of a
+// Kafka REST Proxy, keyed by job so the changes of a job keep their
+// order in its partition.
+type kafkaRESTSink struct {
+	url     string
+	topic   string
+	headers []string
+	client  *http.Client
+}
+
+func (k *kafkaRESTSink) Name() string {
+	return "kafka"
+}
+
+func (k *kafkaRESTSink) Send(changes []*Change) error {
+	type record struct {
+		Key   string  `json:"key"`
+		Value *Change `json:"value"`
+	}
+	records := make([]record, 0, len(changes))
+	for _, c := range changes {
+		records = append(records, record{Key: c.Job, Value: c})
+	}
+	body, err := json.Marshal(struct {
+		Records []record `json:"records"`
+	}{records})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(k.url, "/"), k.topic)
+	return postChanges(k.client, url, "application/vnd.kafka.json.v2+json", k.headers, body)
+}
+
+func postChanges(client *http.Client, url, contentType string, headers []string, body []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for _, h := range headers {
+		if h != "" {
+			kv := strings.Split(h, ":")
+			req.Header.Set(kv[0], strings.TrimSpace(kv[1]))
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("cdc: Sink returned %s", resp.Status)
+	}
+	return nil
+}
+
+// newChangeSink returns the sink of the config, nil if none.
+func newChangeSink(c *Config) (changeSink, error) {
+	client := &http.Client{Timeout: cdcTimeout}
+	switch {
+	case c.CDCWebhook != "":
+		return &webhookSink{url: c.CDCWebhook, headers: c.CDCHeaders, client: client}, nil
+	case c.CDCKafkaProxy != "":
+		if c.CDCKafkaTopic == "" {
+			return nil, ErrCDCKafkaTopic
+		}
+		return &kafkaRESTSink{url: c.CDCKafkaProxy, topic: c.CDCKafkaTopic, headers: c.CDCHeaders, client: client}, nil
+	}
+	return nil, nil
+}
+
+// shipChanges delivers the changes to the sink while this agent is the
+// leader, resuming from the offset committed by the previous leader.
+func (a *Agent) shipChanges(stopCh chan struct{}) {
+	if a.changeSink == nil {
+		return
+	}
+
+	ticker := time.NewTicker(cdcInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep sending while there's a backlog
+		for {
+			n, err := a.shipChangeBatch()
+			if err != nil {
+				log.WithError(err).WithField("sink", a.changeSink.Name()).Error("leader: Error delivering changes")
+				metrics.IncrCounter([]string{"cdc", "errors"}, 1)
+			}
+			if err != nil || n == 0 {
+				break
+			}
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// shipChangeBatch delivers the next changes and commits their offset,
+// returning how many were delivered. A change is sent again if the
+// leader fails before committing it.
+func (a *Agent) shipChangeBatch() (int, error) {
+	_, pending, err := a.Store.GetChangeOffset()
+	if err != nil {
+		return 0, err
+	}
+	metrics.SetGauge([]string{"cdc", "pending"}, float32(pending))
+
+	changes, err := a.Store.GetChanges(cdcBatchSize)
+	if err != nil || len(changes) == 0 {
+		return 0, err
+	}
+	if err := a.changeSink.Send(changes); err != nil {
+		return 0, err
+	}
+
+	last := changes[len(changes)-1]
+	cmd, err := Encode(CommitChangesType, &dkronpb.CommitChangesRequest{
+		Offset: last.Offset,
+		Seq:    uint32(last.seq),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := a.RaftApply(cmd).Error(); err != nil {
+		return 0, err
+	}
+	metrics.IncrCounter([]string{"cdc", "delivered"}, float32(len(changes)))
+	return len(changes), nil
+}
+
+func (h *HTTPTransport) cdcHandler(c *gin.Context) {
+	if h.agent.changeSink == nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	offset, pending, err := h.agent.Store.GetChangeOffset()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, &CDCStatus{
+		Sink:    h.agent.changeSink.Name(),
+		Offset:  offset,
+		Pending: pending,
+	})
+}
@@ -0,0 +1,215 @@
+package dkron
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestFSMChanges(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+	s.RecordChanges(100)
+	s.maxExecutions = 2
+
+	fsm := newFSM(s, nil)
+	apply := func(index uint64, msgType MessageType, msg interface{}) interface{} {
+		cmd, err := Encode(msgType, msg)
+		require.NoError(t, err)
+		return fsm.Apply(&raft.Log{Index: index, Data: cmd})
+	}
+	kinds := func(changes []*Change) []string {
+		var k []string
+		for _, c := range changes {
+			k = append(k, fmt.Sprintf("%d %s %s %s", c.Offset, c.Kind, c.Op, c.Job))
+		}
+		return k
+	}
+
+	parent := &Job{Name: "parent", Schedule: "@every 1m", Executor: "shell"}
+	assert.Nil(t, apply(1, SetJobType, parent.ToProto()))
+	job := &Job{Name: "test", Schedule: "@every 1m", Executor: "shell", ParentJob: "parent"}
+	assert.Nil(t, apply(2, SetJobType, job.ToProto()))
+
+	now := time.Now()
+	ex := &Execution{JobName: "test", NodeName: "node", Group: 1, StartedAt: now, Attempt: 1}
+	apply(3, SetExecutionType, ex.ToProto())
+	ex.FinishedAt = now.Add(time.Second)
+	ex.Success = true
+	apply(4, ExecutionDoneType, &dkronpb.ExecutionDoneRequest{Execution: ex.ToProto()})
+
+	// Failed commands change nothing
+	apply(5, DeleteJobType, &dkronpb.DeleteJobRequest{JobName: "missing"})
+
+	// Adding the child updates the dependent jobs of the parent
+	changes, err := s.GetChanges(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"1 job set parent",
+		"2 job set test",
+		"2 job set parent",
+		"3 execution set test",
+		"4 execution set test",
+		"4 job set test",
+	}, kinds(changes))
+	var j Job
+	require.NoError(t, json.Unmarshal(changes[2].Data, &j))
+	assert.Equal(t, []string{"test"}, j.DependentJobs)
+	assert.Equal(t, ex.Key(), changes[3].Key)
+
+	// The job is sent as returned by the API
+	require.NoError(t, json.Unmarshal(changes[5].Data, &j))
+	assert.Equal(t, 1, j.SuccessCount)
+
+	// Batches keep the changes of a log together
+	changes, err = s.GetChanges(2)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	last := changes[2]
+	assert.Nil(t, apply(6, CommitChangesType, &dkronpb.CommitChangesRequest{Offset: last.Offset, Seq: uint32(last.seq)}))
+	offset, pending, err := s.GetChangeOffset()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), offset)
+	assert.Equal(t, 3, pending)
+
+	// Trimmed executions are deleted
+	for i := 1; i <= 2; i++ {
+		apply(uint64(6+i), SetExecutionType, (&Execution{JobName: "test", NodeName: "node", Group: int64(1 + i), StartedAt: now.Add(time.Duration(i) * time.Second)}).ToProto())
+	}
+	changes, err = s.GetChanges(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"3 execution set test",
+		"4 execution set test",
+		"4 job set test",
+		"7 execution set test",
+		"8 execution set test",
+		"8 execution delete test",
+	}, kinds(changes))
+	assert.Equal(t, ex.Key(), changes[5].Key)
+
+	// Expired executions are deleted after the last log applied
+	executions, err := s.GetExecutions("test")
+	require.NoError(t, err)
+	require.Len(t, executions, 2)
+	expired := executions[0]
+	require.NoError(t, s.db.Update(func(tx *buntdb.Tx) error {
+		key := fmt.Sprintf("%s:test:%s", executionsPrefix, expired.Key())
+		value, err := tx.Get(key)
+		if err != nil {
+			return err
+		}
+		return s.onExpired(key, value, tx)
+	}))
+
+	// Deleting the jobs deletes their executions
+	apply(9, DeleteJobType, &dkronpb.DeleteJobRequest{JobName: "parent", Cascade: true})
+	changes, err = s.GetChanges(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"3 execution set test",
+		"4 execution set test",
+		"4 job set test",
+		"7 execution set test",
+		"8 execution set test",
+		"8 execution delete test",
+		"8 execution delete test",
+		"9 job delete parent",
+		"9 execution delete test",
+		"9 job delete test",
+	}, kinds(changes))
+	assert.Equal(t, expired.Key(), changes[6].Key)
+	assert.Equal(t, executions[1].Key(), changes[8].Key)
+
+	// Replayed logs already delivered aren't recorded again
+	_, pending, err = s.GetChangeOffset()
+	require.NoError(t, err)
+	apply(2, SetJobType, job.ToProto())
+	_, replayed, err := s.GetChangeOffset()
+	require.NoError(t, err)
+	assert.Equal(t, pending, replayed)
+
+	// Expirations go after the changes delivered
+	last = changes[len(changes)-1]
+	require.NoError(t, s.CommitChanges(last.Offset, last.seq))
+	s.SetApplyIndex(9)
+	require.NoError(t, s.db.Update(func(tx *buntdb.Tx) error {
+		return s.recordDeleteTx(tx, executionsPrefix+":other:1-node", "", true)
+	}))
+	changes, err = s.GetChanges(10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, uint64(9), changes[0].Offset)
+	assert.Equal(t, "1-node", changes[0].Key)
+
+	// Going over the max pending drops the oldest
+	s.RecordChanges(2)
+	apply(10, SetJobType, parent.ToProto())
+	apply(11, SetJobType, parent.ToProto())
+	_, pending, err = s.GetChangeOffset()
+	require.NoError(t, err)
+	assert.Equal(t, 2, pending)
+	changes, err = s.GetChanges(10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10 job set parent", "11 job set parent"}, kinds(changes))
+}
+
+func TestChangeSinks(t *testing.T) {
+	var contentType, auth string
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		auth = r.Header.Get("Authorization")
+		body, _ = ioutil.ReadAll(r.Body)
+		if strings.HasPrefix(r.URL.Path, "/fail") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	changes := []*Change{{Offset: 7, Kind: ChangeExecution, Op: ChangePurge, Job: "billing"}}
+
+	c := DefaultConfig()
+	c.CDCWebhook = ts.URL
+	c.CDCHeaders = []string{"Authorization: Bearer secret"}
+	sink, err := newChangeSink(c)
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(changes))
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "Bearer secret", auth)
+	assert.JSONEq(t, `[{"offset": 7, "kind": "execution", "op": "purge", "job": "billing"}]`, string(body))
+
+	c = DefaultConfig()
+	c.CDCKafkaProxy = ts.URL
+	_, err = newChangeSink(c)
+	assert.Equal(t, ErrCDCKafkaTopic, err)
+	c.CDCKafkaTopic = "dkron"
+	sink, err = newChangeSink(c)
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(changes))
+	assert.Equal(t, "application/vnd.kafka.json.v2+json", contentType)
+	assert.JSONEq(t, `{"records": [{"key": "billing", "value": {"offset": 7, "kind": "execution", "op": "purge", "job": "billing"}}]}`, string(body))
+
+	c.CDCKafkaProxy = ts.URL + "/fail"
+	sink, err = newChangeSink(c)
+	require.NoError(t, err)
+	assert.Error(t, sink.Send(changes))
+
+	c = DefaultConfig()
+	sink, err = newChangeSink(c)
+	require.NoError(t, err)
+	assert.Nil(t, sink)
+	assert.Equal(t, 0, c.cdcMaxPending())
+}
@@ -0,0 +1,135 @@
+// +build chaos
+
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrFaultInjected is returned by the operations failed by the fault injection layer.
+	ErrFaultInjected = errors.New("chaos: fault injected")
+
+	faults   Faults
+	faultsMu sync.RWMutex
+)
+
+// Faults are the failures injected in a node, rates go from 0, never, to 1, always.
+type Faults struct {
+	// StoreWriteDelay delays every write to the store, in milliseconds.
+	StoreWriteDelay int64 `json:"store_write_delay_ms"`
+
+	// StoreWriteFailRate is the rate of writes to the store that fail.
+	StoreWriteFailRate float64 `json:"store_write_fail_rate"`
+
+	// DispatchDropRate is the rate of executions dispatched to agents that are dropped.
+	DispatchDropRate float64 `json:"dispatch_drop_rate"`
+}
+
+func (f Faults) validate() error {
+	if f.StoreWriteDelay < 0 {
+		return errors.New("chaos: store_write_delay_ms can not be negative")
+	}
+	for _, rate := range []float64{f.StoreWriteFailRate, f.DispatchDropRate} {
+		if rate < 0 || rate > 1 {
+			return errors.New("chaos: rates must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+func setFaults(f Faults) {
+	faultsMu.Lock()
+	faults = f
+	faultsMu.Unlock()
+}
+
+func getFaults() Faults {
+	faultsMu.RLock()
+	defer faultsMu.RUnlock()
+	return faults
+}
+
+// hit returns whether a fault with the given rate happens.
+func hit(rate float64) bool {
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+func injectStoreWriteFault() error {
+	f := getFaults()
+	if f.StoreWriteDelay > 0 {
+		time.Sleep(time.Duration(f.StoreWriteDelay) * time.Millisecond)
+	}
+	if hit(f.StoreWriteFailRate) {
+		log.Warn("chaos: Failing store write")
+		return fmt.Errorf("%s: store write failed", ErrFaultInjected)
+	}
+	return nil
+}
+
+func injectDispatchFault(addr string) error {
+	if hit(getFaults().DispatchDropRate) {
+		log.WithField("node", addr).Warn("chaos: Dropping execution dispatch")
+		return fmt.Errorf("%s: dispatch to %s dropped", ErrFaultInjected, addr)
+	}
+	return nil
+}
+
+// chaosRoutes registers the fault injection endpoints, they require the admin token.
+func (h *HTTPTransport) chaosRoutes(r *gin.RouterGroup) {
+	chaos := r.Group("/chaos", h.adminMiddleware())
+	chaos.GET("", h.chaosHandler)
+	chaos.PUT("", h.chaosSetHandler)
+	chaos.DELETE("", h.chaosClearHandler)
+	chaos.POST("/stepdown", h.chaosStepDownHandler)
+}
+
+func (h *HTTPTransport) chaosHandler(c *gin.Context) {
+	renderJSON(c, http.StatusOK, getFaults())
+}
+
+func (h *HTTPTransport) chaosSetHandler(c *gin.Context) {
+	var f Faults
+	if err := c.BindJSON(&f); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+	if err := f.validate(); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setFaults(f)
+	log.WithField("faults", f).Warn("chaos: Fault injection changed")
+	renderJSON(c, http.StatusOK, f)
+}
+
+func (h *HTTPTransport) chaosClearHandler(c *gin.Context) {
+	setFaults(Faults{})
+	log.Warn("chaos: Fault injection cleared")
+	renderJSON(c, http.StatusOK, getFaults())
+}
+
+// chaosStepDownHandler makes the leader transfer the leadership to another server.
+func (h *HTTPTransport) chaosStepDownHandler(c *gin.Context) {
+	if !h.agent.IsLeader() {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": ErrNotLeader.Error()})
+		return
+	}
+
+	log.Warn("chaos: Forcing leader step down")
+	if err := h.agent.raft.LeadershipTransfer().Error(); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	renderJSON(c, http.StatusOK, gin.H{"leader": string(h.agent.raft.Leader())})
+}
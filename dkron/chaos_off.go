@@ -0,0 +1,13 @@
+// +build !chaos
+
+package dkron
+
+import "github.com/gin-gonic/gin"
+
+// chaosRoutes registers nothing, fault injection is only available in
+// builds with the chaos tag.
+func (h *HTTPTransport) chaosRoutes(r *gin.RouterGroup) {}
+
+func injectStoreWriteFault() error { return nil }
+
+func injectDispatchFault(addr string) error { return nil }
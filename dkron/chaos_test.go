@@ -0,0 +1,57 @@
+// +build chaos
+
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectFaults(t *testing.T) {
+	defer setFaults(Faults{})
+
+	assert.NoError(t, injectStoreWriteFault())
+	assert.NoError(t, injectDispatchFault("node1"))
+
+	assert.Error(t, Faults{StoreWriteFailRate: 1.5}.validate())
+	assert.Error(t, Faults{StoreWriteDelay: -1}.validate())
+
+	setFaults(Faults{StoreWriteDelay: 50, StoreWriteFailRate: 1, DispatchDropRate: 1})
+
+	start := time.Now()
+	err := injectStoreWriteFault()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrFaultInjected.Error())
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	assert.Error(t, injectDispatchFault("node1"))
+}
+
+func TestRaftApplyStoreWriteFault(t *testing.T) {
+	defer setFaults(Faults{})
+
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	fsm := newFSM(s, nil)
+
+	cmd, err := Encode(SetJobType, (&Job{Name: "test", Schedule: "@every 1m", Executor: "shell"}).ToProto())
+	require.NoError(t, err)
+
+	// The command fails before it's in the log
+	setFaults(Faults{StoreWriteFailRate: 1})
+	a := &Agent{}
+	af := a.RaftApply(cmd)
+	require.Error(t, af.Error())
+	assert.Contains(t, af.Error().Error(), ErrFaultInjected.Error())
+
+	// Every server applies the commands in the log
+	assert.Nil(t, fsm.Apply(&raft.Log{Data: cmd}))
+	_, err = s.GetJob("test", nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,320 @@
+package dkron
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/tidwall/buntdb"
+)
+
+// Kinds of problems found when checking the store.
+const (
+	// ProblemUndecodableJob is a job record that can't be decoded.
+	ProblemUndecodableJob = "undecodable_job"
+	// ProblemUndecodableExecution is an execution record that can't be decoded.
+	ProblemUndecodableExecution = "undecodable_execution"
+	// ProblemMissingParent is a job whose parent job doesn't exist.
+	ProblemMissingParent = "missing_parent"
+	// ProblemNotInParent is a job not listed in the dependent jobs of its parent.
+	ProblemNotInParent = "not_in_parent"
+	// ProblemDanglingDependent is a dependent job that doesn't exist or has another parent.
+	ProblemDanglingDependent = "dangling_dependent"
+	// ProblemDuplicateDependent is a dependent job listed more than once in its parent.
+	ProblemDuplicateDependent = "duplicate_dependent"
+	// ProblemOrphanedExecution is an execution of a job that doesn't exist.
+	ProblemOrphanedExecution = "orphaned_execution"
+	// ProblemExecutionCompression is an execution not stored with the
+	// configured compression.
+	ProblemExecutionCompression = "execution_compression"
+)
+
+// Problem is an inconsistency found in the store.
+type Problem struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	Detail string `json:"detail"`
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s %s: %s", p.Kind, p.Key, p.Detail)
+}
+
+// CheckReport holds the statistics and problems found checking the store.
+type CheckReport struct {
+	SchemaVersion int       `json:"schema_version"`
+	Jobs          int       `json:"jobs"`
+	DisabledJobs  int       `json:"disabled_jobs"`
+	Executions    int       `json:"executions"`
+	Problems      []Problem `json:"problems"`
+	Repaired      int       `json:"repaired"`
+}
+
+// Check decodes every job and execution in the store and verifies the
+// references between them.
+func (s *Store) Check() (*CheckReport, error) {
+	report := &CheckReport{Problems: []Problem{}}
+
+	jobs := make(map[string]*dkronpb.Job)
+	executionJobs := make(map[string]string)
+
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		v, err := schemaVersionTx(tx)
+		if err != nil {
+			return err
+		}
+		report.SchemaVersion = v
+
+		return tx.Ascend("", func(key, value string) bool {
+			switch {
+			case strings.HasPrefix(key, jobsPrefix+":"):
+				var pbj dkronpb.Job
+				if err := decodeJob([]byte(value), &pbj); err != nil {
+					report.addProblem(ProblemUndecodableJob, key, err.Error())
+					return true
+				}
+				jobs[pbj.Name] = &pbj
+				report.Jobs++
+				if pbj.Disabled {
+					report.DisabledJobs++
+				}
+			case strings.HasPrefix(key, executionsPrefix+":"):
+				var pbe dkronpb.Execution
+				if err := decodeExecution([]byte(value), &pbe); err != nil {
+					report.addProblem(ProblemUndecodableExecution, key, err.Error())
+					return true
+				}
+				executionJobs[key] = pbe.JobName
+				report.Executions++
+				if b, err := s.recodedExecution(value, &pbe); err != nil {
+					report.addProblem(ProblemUndecodableExecution, key, err.Error())
+				} else if b != nil {
+					report.addProblem(ProblemExecutionCompression, key, fmt.Sprintf("not stored with the %s compression", s.executionCompression()))
+				}
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for name, job := range jobs {
+		key := jobsPrefix + ":" + name
+		if job.ParentJob != "" {
+			parent, ok := jobs[job.ParentJob]
+			if !ok {
+				report.addProblem(ProblemMissingParent, key, fmt.Sprintf("parent job %s not found", job.ParentJob))
+			} else if !contains(parent.DependentJobs, name) {
+				report.addProblem(ProblemNotInParent, key, fmt.Sprintf("not listed as dependent job of %s", job.ParentJob))
+			}
+		}
+		seen := make(map[string]bool)
+		for _, dj := range job.DependentJobs {
+			if seen[dj] {
+				report.addProblem(ProblemDuplicateDependent, key, fmt.Sprintf("dependent job %s listed more than once", dj))
+				continue
+			}
+			seen[dj] = true
+
+			child, ok := jobs[dj]
+			if !ok {
+				report.addProblem(ProblemDanglingDependent, key, fmt.Sprintf("dependent job %s not found", dj))
+			} else if child.ParentJob != name {
+				report.addProblem(ProblemDanglingDependent, key, fmt.Sprintf("dependent job %s has parent %q", dj, child.ParentJob))
+			}
+		}
+	}
+
+	for key, jobName := range executionJobs {
+		if _, ok := jobs[jobName]; !ok {
+			report.addProblem(ProblemOrphanedExecution, key, fmt.Sprintf("job %s not found", jobName))
+		}
+	}
+
+	sort.Slice(report.Problems, func(i, j int) bool {
+		return report.Problems[i].Key < report.Problems[j].Key
+	})
+
+	return report, nil
+}
+
+// Repair fixes the problems found by Check that can be fixed safely and
+// returns the number of fixes: undecodable records and orphaned executions
+// are deleted, executions are rewritten with the configured compression and
+// the dependent jobs of every job are rebuilt from the parent of the
+// existing jobs. Jobs with a missing parent are left as is.
+func (s *Store) Repair() (int, error) {
+	repaired := 0
+	var removedJobs []string
+	var updatedJobs []*dkronpb.Job
+
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		s.cache.reset()
+		jobs := make(map[string]*dkronpb.Job)
+		var names []string
+		var delkeys []string
+		executionJobs := make(map[string]string)
+		recoded := make(map[string]string)
+		recodedExecutions := make(map[string]*dkronpb.Execution)
+
+		tx.Ascend("", func(key, value string) bool {
+			switch {
+			case strings.HasPrefix(key, jobsPrefix+":"):
+				var pbj dkronpb.Job
+				if err := decodeJob([]byte(value), &pbj); err != nil {
+					delkeys = append(delkeys, key)
+					return true
+				}
+				jobs[pbj.Name] = &pbj
+				names = append(names, pbj.Name)
+			case strings.HasPrefix(key, executionsPrefix+":"):
+				var pbe dkronpb.Execution
+				if err := decodeExecution([]byte(value), &pbe); err != nil {
+					delkeys = append(delkeys, key)
+					return true
+				}
+				executionJobs[key] = pbe.JobName
+				if b, err := s.recodedExecution(value, &pbe); err == nil && b != nil {
+					recoded[key] = string(b)
+					recodedExecutions[key] = &pbe
+				}
+			}
+			return true
+		})
+
+		for key, jobName := range executionJobs {
+			if _, ok := jobs[jobName]; !ok {
+				delkeys = append(delkeys, key)
+				delete(recoded, key)
+			}
+		}
+		for k, v := range recoded {
+			if _, _, err := tx.Set(k, v, s.executionSetOptions(tx, recodedExecutions[k])); err != nil {
+				return err
+			}
+			repaired++
+		}
+
+		for _, k := range delkeys {
+			value, err := tx.Delete(k)
+			if err != nil {
+				return err
+			}
+			if err := s.recordDeleteTx(tx, k, value, false); err != nil {
+				return err
+			}
+			if strings.HasPrefix(k, jobsPrefix+":") {
+				removedJobs = append(removedJobs, strings.TrimPrefix(k, jobsPrefix+":"))
+			}
+			repaired++
+		}
+
+		// names is sorted as keys are traversed in order
+		children := make(map[string][]string)
+		for _, name := range names {
+			if p := jobs[name].ParentJob; p != "" {
+				children[p] = append(children[p], name)
+			}
+		}
+
+		for _, name := range names {
+			job := jobs[name]
+
+			// Keep the existing order of the valid dependent jobs
+			var deps []string
+			for _, dj := range job.DependentJobs {
+				if child, ok := jobs[dj]; ok && child.ParentJob == name && !contains(deps, dj) {
+					deps = append(deps, dj)
+				}
+			}
+			for _, c := range children[name] {
+				if !contains(deps, c) {
+					deps = append(deps, c)
+				}
+			}
+
+			added := 0
+			for _, dj := range deps {
+				if !contains(job.DependentJobs, dj) {
+					added++
+				}
+			}
+			removed := len(job.DependentJobs) - (len(deps) - added)
+			if added+removed == 0 {
+				continue
+			}
+
+			job.DependentJobs = deps
+			if err := s.setJobTxFunc(job)(tx); err != nil {
+				return err
+			}
+			updatedJobs = append(updatedJobs, job)
+			log.WithField("job", name).WithField("dependent_jobs", deps).
+				Info("store: Repaired dependent jobs")
+			repaired += added + removed
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range removedJobs {
+		s.index.remove(name)
+	}
+	for _, pbj := range updatedJobs {
+		s.index.update(pbj)
+	}
+
+	return repaired, nil
+}
+
+func (r *CheckReport) addProblem(kind, key, detail string) {
+	r.Problems = append(r.Problems, Problem{Kind: kind, Key: key, Detail: detail})
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ToProto returns the protobuf representation of the report.
+func (r *CheckReport) ToProto() *dkronpb.CheckStoreResponse {
+	res := &dkronpb.CheckStoreResponse{
+		SchemaVersion: int32(r.SchemaVersion),
+		Jobs:          int32(r.Jobs),
+		DisabledJobs:  int32(r.DisabledJobs),
+		Executions:    int32(r.Executions),
+		Repaired:      int32(r.Repaired),
+	}
+	for _, p := range r.Problems {
+		res.Problems = append(res.Problems, &dkronpb.StoreProblem{
+			Kind:   p.Kind,
+			Key:    p.Key,
+			Detail: p.Detail,
+		})
+	}
+	return res
+}
+
+// NewCheckReportFromProto creates a CheckReport from its protobuf representation.
+func NewCheckReportFromProto(in *dkronpb.CheckStoreResponse) *CheckReport {
+	r := &CheckReport{
+		SchemaVersion: int(in.SchemaVersion),
+		Jobs:          int(in.Jobs),
+		DisabledJobs:  int(in.DisabledJobs),
+		Executions:    int(in.Executions),
+		Repaired:      int(in.Repaired),
+		Problems:      []Problem{},
+	}
+	for _, p := range in.Problems {
+		r.Problems = append(r.Problems, Problem{Kind: p.Kind, Key: p.Key, Detail: p.Detail})
+	}
+	return r
+}
@@ -0,0 +1,90 @@
+package dkron
+
+import (
+	"encoding/binary"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/serf/serf"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// clockQuery is the serf query answered by every member with its time.
+	clockQuery = "dkron:clock"
+	// clockSkewInterval is how often the leader measures the clock skew.
+	clockSkewInterval = time.Minute
+)
+
+// clockSkew returns the skew of a remote clock that answered at remote a
+// query sent at sent and answered at received, along with the uncertainty
+// of the measure. The remote time is assumed to be taken halfway the round
+// trip, the uncertainty is half the round trip.
+func clockSkew(sent, received, remote time.Time) (skew, uncertainty time.Duration) {
+	uncertainty = received.Sub(sent) / 2
+	return remote.Sub(sent.Add(uncertainty)), uncertainty
+}
+
+// answerClockQuery responds to a clock query with the local time.
+func answerClockQuery(q *serf.Query) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(time.Now().UnixNano()))
+	if err := q.Respond(buf); err != nil {
+		log.WithError(err).Debug("agent: Error responding to the clock query")
+	}
+}
+
+// monitorClockSkew measures the clock skew of the members until stopCh is closed.
+func (a *Agent) monitorClockSkew(stopCh chan struct{}) {
+	ticker := time.NewTicker(clockSkewInterval)
+	defer ticker.Stop()
+
+	for {
+		a.checkClockSkew()
+
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// checkClockSkew queries the time of every member, records its skew
+// relative to the local clock and warns when it's over the threshold.
+func (a *Agent) checkClockSkew() {
+	sent := time.Now()
+	resp, err := a.serf.Query(clockQuery, nil, a.serf.DefaultQueryParams())
+	if err != nil {
+		log.WithError(err).Error("agent: Error querying the clock of the members")
+		return
+	}
+
+	for r := range resp.ResponseCh() {
+		received := time.Now()
+		if len(r.Payload) != 8 {
+			continue
+		}
+		remote := time.Unix(0, int64(binary.BigEndian.Uint64(r.Payload)))
+
+		skew, uncertainty := clockSkew(sent, received, remote)
+		a.clockSkews.Store(r.From, skew)
+		metrics.SetGaugeWithLabels([]string{"agent", "clock_skew"}, float32(skew)/float32(time.Millisecond),
+			[]metrics.Label{{Name: "node", Value: r.From}})
+
+		// Only warn when the skew is over the threshold despite the uncertainty
+		over := skew
+		if over < 0 {
+			over = -over
+		}
+		if t := a.config.ClockSkewThreshold; t > 0 && over-uncertainty > t {
+			log.WithFields(logrus.Fields{
+				"node":      r.From,
+				"skew":      skew.String(),
+				"threshold": t.String(),
+			}).Warning("agent: Member clock is skewed, execution times and job statuses can be wrong")
+		}
+	}
+}
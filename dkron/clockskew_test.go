@@ -0,0 +1,37 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkew(t *testing.T) {
+	sent := time.Date(2020, 5, 15, 8, 0, 0, 0, time.UTC)
+	received := sent.Add(200 * time.Millisecond)
+
+	skew, uncertainty := clockSkew(sent, received, sent.Add(100*time.Millisecond))
+	assert.Equal(t, time.Duration(0), skew)
+	assert.Equal(t, 100*time.Millisecond, uncertainty)
+
+	skew, _ = clockSkew(sent, received, sent.Add(-5*time.Second))
+	assert.Equal(t, -5100*time.Millisecond, skew)
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	dir, a := setupAPITest(t, "8120")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	a.checkClockSkew()
+
+	v, ok := a.clockSkews.Load("test")
+	require.True(t, ok)
+	skew := v.(time.Duration)
+	assert.True(t, skew < time.Second && skew > -time.Second)
+
+	assert.Contains(t, a.debugState().ClockSkew, "test")
+}
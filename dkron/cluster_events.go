@@ -0,0 +1,263 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/serf/serf"
+	"github.com/jordan-wright/email"
+	"github.com/sirupsen/logrus"
+)
+
+// Types of the cluster events.
+const (
+	ClusterEventLeaderElected  = "leader_elected"
+	ClusterEventLeaderLost     = "leader_lost"
+	ClusterEventServerJoined   = "server_joined"
+	ClusterEventServerLeft     = "server_left"
+	ClusterEventServerFailed   = "server_failed"
+	ClusterEventQuorumLost     = "quorum_lost"
+	ClusterEventQuorumRestored = "quorum_restored"
+)
+
+const (
+	// quorumCheckInterval is how often servers check that the cluster
+	// has a leader.
+	quorumCheckInterval = 5 * time.Second
+	// quorumLossGrace is the time without leader after which the quorum
+	// is considered lost, longer than an election.
+	quorumLossGrace = 30 * time.Second
+)
+
+// ClusterEvent is a change of the servers of the cluster that is notified.
+type ClusterEvent struct {
+	Type string `json:"type"`
+
+	// Server the event is about.
+	Server string `json:"server"`
+
+	// Node reporting the event.
+	Node   string    `json:"node"`
+	Region string    `json:"region"`
+	Time   time.Time `json:"time"`
+}
+
+// Text returns a human readable description of the event.
+func (e *ClusterEvent) Text() string {
+	var what string
+	switch e.Type {
+	case ClusterEventLeaderElected:
+		what = fmt.Sprintf("%s was elected leader", e.Server)
+	case ClusterEventLeaderLost:
+		what = fmt.Sprintf("%s lost the leadership", e.Server)
+	case ClusterEventServerJoined:
+		what = fmt.Sprintf("server %s joined", e.Server)
+	case ClusterEventServerLeft:
+		what = fmt.Sprintf("server %s left", e.Server)
+	case ClusterEventServerFailed:
+		what = fmt.Sprintf("server %s failed", e.Server)
+	case ClusterEventQuorumLost:
+		what = fmt.Sprintf("no leader for %s, the quorum of servers is lost and jobs aren't scheduled", quorumLossGrace)
+	case ClusterEventQuorumRestored:
+		what = "a leader was elected again, the quorum of servers is restored"
+	default:
+		what = e.Type
+	}
+	return fmt.Sprintf("Region %s: %s, reported by %s at %s", e.Region, what, e.Node, e.Time.Format(time.RFC3339))
+}
+
+// notifyClusterEvent logs, counts and sends the cluster event through the
+// configured webhook and mail recipients.
+func (a *Agent) notifyClusterEvent(eventType, server string) {
+	ev := &ClusterEvent{
+		Type:   eventType,
+		Server: server,
+		Node:   a.config.NodeName,
+		Region: a.config.Region,
+		Time:   time.Now(),
+	}
+
+	log.WithFields(logrus.Fields{
+		"event":  ev.Type,
+		"server": ev.Server,
+	}).Warn("agent: Cluster event")
+	metrics.IncrCounterWithLabels([]string{"cluster", "event"}, 1, []metrics.Label{{Name: "type", Value: ev.Type}})
+
+	// Don't hold the leadership and serf event loops on slow receivers
+	go func() {
+		if err := sendClusterEventEmail(a.config, ev); err != nil {
+			log.WithError(err).WithField("event", ev.Type).Error("agent: Error mailing the cluster event")
+		}
+		if err := postClusterEvent(a.config, ev); err != nil {
+			log.WithError(err).WithField("event", ev.Type).Error("agent: Error posting the cluster event")
+		}
+	}()
+}
+
+func sendClusterEventEmail(config *Config, ev *ClusterEvent) error {
+	if config.MailHost == "" || config.MailPort == 0 || len(config.ClusterEventsMailTo) == 0 {
+		return nil
+	}
+
+	e := &email.Email{
+		To:      config.ClusterEventsMailTo,
+		From:    config.MailFrom,
+		Subject: fmt.Sprintf("%scluster %s %s", config.MailSubjectPrefix, ev.Region, strings.Replace(ev.Type, "_", " ", -1)),
+		Text:    []byte(ev.Text()),
+		Headers: textproto.MIMEHeader{},
+	}
+
+	serverAddr := fmt.Sprintf("%s:%d", config.MailHost, config.MailPort)
+	return e.Send(serverAddr, mailAuth(config))
+}
+
+// postClusterEvent posts the event as JSON to the cluster events webhook,
+// along with its description in the text field read by chat webhooks.
+func postClusterEvent(config *Config, ev *ClusterEvent) error {
+	if config.ClusterEventsWebhook == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(struct {
+		*ClusterEvent
+		Text string `json:"text"`
+	}{ev, ev.Text()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", config.ClusterEventsWebhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, h := range config.WebhookHeaders {
+		if h != "" {
+			kv := strings.Split(h, ":")
+			req.Header.Set(kv[0], strings.TrimSpace(kv[1]))
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("agent: Cluster events webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// serverMemberEvent notifies the servers of the region joining, leaving or
+// failing, only from the leader so every change is notified once.
+func (a *Agent) serverMemberEvent(me serf.MemberEvent) {
+	if !a.config.Server || !a.IsLeader() {
+		return
+	}
+
+	var eventType string
+	switch me.EventType() {
+	case serf.EventMemberJoin:
+		eventType = ClusterEventServerJoined
+	case serf.EventMemberLeave:
+		eventType = ClusterEventServerLeft
+	case serf.EventMemberFailed:
+		eventType = ClusterEventServerFailed
+	default:
+		return
+	}
+
+	for _, m := range me.Members {
+		ok, parts := isServer(m)
+		if !ok || parts.Region != a.config.Region || m.Name == a.config.NodeName {
+			continue
+		}
+		a.notifyClusterEvent(eventType, m.Name)
+	}
+}
+
+// quorumMonitor tracks the time a server sees the cluster without leader.
+type quorumMonitor struct {
+	// since is when the leader was last missing, zero while there is one.
+	since time.Time
+	lost  bool
+}
+
+// update records whether there is a leader at now, returning the quorum
+// event to notify, if any.
+func (q *quorumMonitor) update(hasLeader bool, now time.Time) string {
+	if hasLeader {
+		q.since = time.Time{}
+		if q.lost {
+			q.lost = false
+			return ClusterEventQuorumRestored
+		}
+		return ""
+	}
+
+	if q.since.IsZero() {
+		q.since = now
+	}
+	if !q.lost && now.Sub(q.since) >= quorumLossGrace {
+		q.lost = true
+		return ClusterEventQuorumLost
+	}
+	return ""
+}
+
+// quorumReporter returns the name of the alive server of the region that
+// reports the quorum events, the first one by name.
+func quorumReporter(members []serf.Member, region string) string {
+	var names []string
+	for _, m := range members {
+		ok, parts := isServer(m)
+		if ok && m.Status == serf.StatusAlive && parts.Region == region {
+			names = append(names, m.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// monitorQuorum notifies when the cluster stays without leader, and when
+// it has one again. Without a leader no server can notify, so the alive
+// servers elect the reporter among them.
+func (a *Agent) monitorQuorum() {
+	ticker := time.NewTicker(quorumCheckInterval)
+	defer ticker.Stop()
+
+	var q quorumMonitor
+	reported := false
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.shutdownCh:
+			return
+		}
+
+		switch q.update(a.raft.Leader() != "", time.Now()) {
+		case ClusterEventQuorumLost:
+			if quorumReporter(a.serf.Members(), a.config.Region) == a.config.NodeName {
+				a.notifyClusterEvent(ClusterEventQuorumLost, "")
+				reported = true
+			}
+		case ClusterEventQuorumRestored:
+			if reported {
+				a.notifyClusterEvent(ClusterEventQuorumRestored, "")
+				reported = false
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package dkron
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumMonitor(t *testing.T) {
+	var q quorumMonitor
+	now := time.Now()
+
+	assert.Empty(t, q.update(true, now))
+
+	// Elections shorter than the grace aren't notified
+	assert.Empty(t, q.update(false, now))
+	assert.Empty(t, q.update(true, now.Add(10*time.Second)))
+
+	assert.Empty(t, q.update(false, now.Add(20*time.Second)))
+	assert.Equal(t, ClusterEventQuorumLost, q.update(false, now.Add(20*time.Second+quorumLossGrace)))
+	assert.Empty(t, q.update(false, now.Add(time.Hour)))
+	assert.Equal(t, ClusterEventQuorumRestored, q.update(true, now.Add(time.Hour)))
+	assert.Empty(t, q.update(true, now.Add(2*time.Hour)))
+}
+
+func TestQuorumReporter(t *testing.T) {
+	server := func(name, region string, status serf.MemberStatus) serf.Member {
+		return serf.Member{
+			Name:   name,
+			Status: status,
+			Tags:   map[string]string{"role": "dkron", "server": "true", "region": region, "port": "6868"},
+		}
+	}
+	members := []serf.Member{
+		server("c", "global", serf.StatusAlive),
+		server("a", "global", serf.StatusFailed),
+		server("0", "europe", serf.StatusAlive),
+		{Name: "agent", Status: serf.StatusAlive, Tags: map[string]string{"role": "dkron", "region": "global"}},
+		server("b", "global", serf.StatusAlive),
+	}
+	assert.Equal(t, "b", quorumReporter(members, "global"))
+	assert.Empty(t, quorumReporter(members, "asia"))
+}
+
+func TestPostClusterEvent(t *testing.T) {
+	var got map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer ts.Close()
+
+	config := &Config{ClusterEventsWebhook: ts.URL, WebhookHeaders: []string{"Authorization: secret"}}
+	ev := &ClusterEvent{Type: ClusterEventServerFailed, Server: "dkron2", Node: "dkron1", Region: "global", Time: time.Now()}
+	require.NoError(t, postClusterEvent(config, ev))
+
+	assert.Equal(t, ClusterEventServerFailed, got["type"])
+	assert.Equal(t, "dkron2", got["server"])
+	assert.Contains(t, got["text"], "server dkron2 failed")
+}
@@ -0,0 +1,121 @@
+package dkron
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/distribworks/dkron/v3/ntime"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+)
+
+// Record formats of the stored jobs and executions. Records written by
+// dkron v1 and v2 are JSON documents, since v3 they are protobuf messages.
+// The first byte of a protobuf Job or Execution is never '{' as field 15
+// with wire type 3 (groups) is not used, this allows telling them apart.
+const (
+	// RecordFormatV1 is the JSON format used by dkron 1.x, with the legacy
+	// command, shell and environment_variables job fields.
+	RecordFormatV1 = 1
+	// RecordFormatV2 is the JSON format used by dkron 2.x.
+	RecordFormatV2 = 2
+	// RecordFormatV3 is the protobuf format used since dkron 3.0.
+	RecordFormatV3 = 3
+)
+
+// legacyJob is the JSON representation of a job in dkron v1 and v2.
+type legacyJob struct {
+	Job
+
+	// Fields only present in v1
+	Command              string   `json:"command"`
+	Shell                bool     `json:"shell"`
+	EnvironmentVariables []string `json:"environment_variables"`
+}
+
+// legacyExecution is the JSON representation of an execution in dkron v1
+// and v2, where the output was a byte slice.
+type legacyExecution struct {
+	Execution
+
+	Output []byte `json:"output,omitempty"`
+}
+
+// jobRecordFormat returns the record format of a stored job.
+func jobRecordFormat(data []byte) int {
+	if len(data) == 0 || data[0] != '{' {
+		return RecordFormatV3
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil {
+		if _, ok := fields["command"]; ok {
+			return RecordFormatV1
+		}
+	}
+	return RecordFormatV2
+}
+
+// decodeJob decodes a stored job written in any of the supported record
+// formats into pbj.
+func decodeJob(data []byte, pbj *dkronpb.Job) error {
+	format := jobRecordFormat(data)
+	if format == RecordFormatV3 {
+		return proto.Unmarshal(data, pbj)
+	}
+
+	var lj legacyJob
+	if err := json.Unmarshal(data, &lj); err != nil {
+		return err
+	}
+	job := &lj.Job
+
+	if format == RecordFormatV1 && job.Executor == "" && lj.Command != "" {
+		job.Executor = "shell"
+		job.ExecutorConfig = map[string]string{
+			"command": lj.Command,
+		}
+		if lj.Shell {
+			job.ExecutorConfig["shell"] = "true"
+		}
+		if len(lj.EnvironmentVariables) > 0 {
+			job.ExecutorConfig["env"] = strings.Join(lj.EnvironmentVariables, ",")
+		}
+	}
+
+	// Old versions stored unset times as the zero time
+	unsetZero(&job.LastSuccess)
+	unsetZero(&job.LastError)
+
+	*pbj = *job.ToProto()
+	return nil
+}
+
+// decodeExecution decodes a stored execution written in any of the
+// supported record formats into pbe, compressed or not.
+func decodeExecution(data []byte, pbe *dkronpb.Execution) error {
+	if isCompressed(data) {
+		var err error
+		if data, err = decompress(data); err != nil {
+			return err
+		}
+	}
+	if len(data) == 0 || data[0] != '{' {
+		return proto.Unmarshal(data, pbe)
+	}
+
+	var le legacyExecution
+	if err := json.Unmarshal(data, &le); err != nil {
+		return err
+	}
+	le.Execution.Output = string(le.Output)
+
+	*pbe = *le.Execution.ToProto()
+	return nil
+}
+
+func unsetZero(t *ntime.NullableTime) {
+	if t.HasValue() && t.Get().Equal(time.Time{}) {
+		t.Unset()
+	}
+}
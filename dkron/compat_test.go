@@ -0,0 +1,183 @@
+package dkron
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	v1Job = `{
+		"name": "v1_job",
+		"schedule": "@every 10s",
+		"command": "echo hello",
+		"shell": true,
+		"environment_variables": ["FOO=bar"],
+		"owner": "mec",
+		"owner_email": "mec@example.com",
+		"success_count": 3,
+		"error_count": 1,
+		"last_success": "2017-03-01T10:00:00Z",
+		"last_error": "0001-01-01T00:00:00Z",
+		"disabled": false,
+		"tags": {"role": "web:1"},
+		"retries": 2,
+		"dependent_jobs": null,
+		"parent_job": "",
+		"concurrency": "allow"
+	}`
+
+	v2Job = `{
+		"name": "v2_job",
+		"displayname": "V2 job",
+		"timezone": "Europe/Berlin",
+		"schedule": "@every 1m",
+		"owner": "mec",
+		"owner_email": "mec@example.com",
+		"success_count": 26,
+		"error_count": 6,
+		"last_success": "2019-11-04T04:37:12.396866367Z",
+		"last_error": null,
+		"disabled": true,
+		"tags": null,
+		"metadata": {"env": "prod"},
+		"retries": 0,
+		"dependent_jobs": ["child"],
+		"parent_job": "",
+		"processors": {"files": {"forward": "true"}},
+		"concurrency": "forbid",
+		"executor": "http",
+		"executor_config": {"url": "http://example.com", "method": "GET"},
+		"status": "success",
+		"next": "2019-11-28T09:00:00Z"
+	}`
+
+	// Output is base64 encoded as it was a byte slice
+	v2Execution = `{
+		"job_name": "v2_job",
+		"started_at": "2019-11-04T04:37:10Z",
+		"finished_at": "2019-11-04T04:37:12Z",
+		"success": true,
+		"output": "aGVsbG8=",
+		"node_name": "node1",
+		"group": 1572842230000000000,
+		"attempt": 1
+	}`
+)
+
+func TestDecodeJob_V1(t *testing.T) {
+	assert.Equal(t, RecordFormatV1, jobRecordFormat([]byte(v1Job)))
+
+	var pbj dkronpb.Job
+	require.NoError(t, decodeJob([]byte(v1Job), &pbj))
+	job := NewJobFromProto(&pbj)
+
+	assert.Equal(t, "v1_job", job.Name)
+	assert.Equal(t, "shell", job.Executor)
+	assert.Equal(t, "echo hello", job.ExecutorConfig["command"])
+	assert.Equal(t, "true", job.ExecutorConfig["shell"])
+	assert.Equal(t, "FOO=bar", job.ExecutorConfig["env"])
+	assert.Equal(t, 3, job.SuccessCount)
+	assert.Equal(t, uint(2), job.Retries)
+	assert.True(t, job.LastSuccess.HasValue())
+	assert.False(t, job.LastError.HasValue())
+	assert.Equal(t, "web:1", job.Tags["role"])
+}
+
+func TestDecodeJob_V2(t *testing.T) {
+	assert.Equal(t, RecordFormatV2, jobRecordFormat([]byte(v2Job)))
+
+	var pbj dkronpb.Job
+	require.NoError(t, decodeJob([]byte(v2Job), &pbj))
+	job := NewJobFromProto(&pbj)
+
+	assert.Equal(t, "V2 job", job.DisplayName)
+	assert.Equal(t, "Europe/Berlin", job.Timezone)
+	assert.Equal(t, "http", job.Executor)
+	assert.Equal(t, "http://example.com", job.ExecutorConfig["url"])
+	assert.Equal(t, "prod", job.Metadata["env"])
+	assert.Equal(t, []string{"child"}, job.DependentJobs)
+	assert.Equal(t, "true", job.Processors["files"]["forward"])
+	assert.Equal(t, ConcurrencyForbid, job.Concurrency)
+	assert.True(t, job.Disabled)
+	assert.False(t, job.LastError.HasValue())
+	assert.Equal(t, time.Date(2019, 11, 28, 9, 0, 0, 0, time.UTC), job.Next)
+}
+
+func TestDecodeJob_V3RoundTrip(t *testing.T) {
+	var pbj dkronpb.Job
+	require.NoError(t, decodeJob([]byte(v2Job), &pbj))
+
+	b, err := proto.Marshal(&pbj)
+	require.NoError(t, err)
+	assert.Equal(t, RecordFormatV3, jobRecordFormat(b))
+
+	var decoded dkronpb.Job
+	require.NoError(t, decodeJob(b, &decoded))
+	assert.True(t, proto.Equal(&pbj, &decoded))
+}
+
+func TestDecodeExecution(t *testing.T) {
+	var pbe dkronpb.Execution
+	require.NoError(t, decodeExecution([]byte(v2Execution), &pbe))
+	ex := NewExecutionFromProto(&pbe)
+
+	assert.Equal(t, "v2_job", ex.JobName)
+	assert.Equal(t, "hello", ex.Output)
+	assert.Equal(t, "node1", ex.NodeName)
+	assert.True(t, ex.Success)
+	assert.Equal(t, uint(1), ex.Attempt)
+
+	b, err := proto.Marshal(&pbe)
+	require.NoError(t, err)
+
+	var decoded dkronpb.Execution
+	require.NoError(t, decodeExecution(b, &decoded))
+	assert.True(t, proto.Equal(&pbe, &decoded))
+}
+
+func TestStore_RestoreLegacyRecords(t *testing.T) {
+	old := setupStore(t)
+	require.NoError(t, old.db.Update(func(tx *buntdb.Tx) error {
+		if _, err := tx.Delete(schemaVersionKey); err != nil {
+			return err
+		}
+		if _, _, err := tx.Set("jobs:v1_job", v1Job, nil); err != nil {
+			return err
+		}
+		if _, _, err := tx.Set("jobs:v2_job", v2Job, nil); err != nil {
+			return err
+		}
+		_, _, err := tx.Set("executions:v2_job:1572842230000000000-node1", v2Execution, nil)
+		return err
+	}))
+
+	var buf bytes.Buffer
+	require.NoError(t, old.db.Save(&buf))
+
+	s := setupStore(t)
+	require.NoError(t, s.Restore(ioutil.NopCloser(&buf)))
+
+	// Records are stored as protobuf after the upgrade
+	require.NoError(t, s.db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			assert.NotEqual(t, byte('{'), value[0], key)
+			return true
+		})
+	}))
+
+	job := loadJob(t, s, "v1_job")
+	assert.Equal(t, "echo hello", job.ExecutorConfig["command"])
+
+	execs, err := s.GetExecutions("v2_job")
+	require.NoError(t, err)
+	require.Len(t, execs, 1)
+	assert.Equal(t, "hello", execs[0].Output)
+}
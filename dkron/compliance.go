@@ -0,0 +1,413 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	legalHoldPrefix  = "hold"
+	compliancePrefix = "compliance"
+)
+
+// Compliance actions on the execution history of a job.
+const (
+	// ComplianceHold places the history under legal hold, it's kept until
+	// the hold is released.
+	ComplianceHold = "hold"
+	// ComplianceRelease releases the legal hold of the history.
+	ComplianceRelease = "release"
+	// CompliancePurge deletes the whole history, like for an erasure
+	// request.
+	CompliancePurge = "purge"
+)
+
+var (
+	// ErrLegalHold is returned when deleting the history of a job under
+	// legal hold, or holding it twice.
+	ErrLegalHold = errors.New("the execution history of the job is under legal hold")
+	// ErrNoLegalHold is returned when releasing the history of a job not
+	// under legal hold.
+	ErrNoLegalHold = errors.New("the execution history of the job is not under legal hold")
+	// ErrComplianceEvent is returned when a compliance action is missing
+	// its job, user or reason, or is unknown.
+	ErrComplianceEvent = errors.New("invalid compliance action")
+)
+
+// ComplianceEvent is the audit record of a compliance action on the
+// execution history of a job. Records are kept forever.
+type ComplianceEvent struct {
+	ID     string    `json:"id"`
+	Action string    `json:"action"`
+	Job    string    `json:"job"`
+	User   string    `json:"user"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+
+	// Executions deleted by a purge.
+	Executions int `json:"executions,omitempty"`
+}
+
+// NewComplianceEventFromProto returns the compliance event of the proto.
+func NewComplianceEventFromProto(in *dkronpb.ComplianceEvent) *ComplianceEvent {
+	at, _ := ptypes.Timestamp(in.At)
+	return &ComplianceEvent{
+		ID:         in.Id,
+		Action:     in.Action,
+		Job:        in.JobName,
+		User:       in.User,
+		Reason:     in.Reason,
+		At:         at,
+		Executions: int(in.Executions),
+	}
+}
+
+// ToProto returns the protobuf struct of the compliance event.
+func (e *ComplianceEvent) ToProto() *dkronpb.ComplianceEvent {
+	at, _ := ptypes.TimestampProto(e.At)
+	return &dkronpb.ComplianceEvent{
+		Id:         e.ID,
+		Action:     e.Action,
+		JobName:    e.Job,
+		User:       e.User,
+		Reason:     e.Reason,
+		At:         at,
+		Executions: int32(e.Executions),
+	}
+}
+
+// Validate checks the compliance event.
+func (e *ComplianceEvent) Validate() error {
+	switch e.Action {
+	case ComplianceHold, ComplianceRelease, CompliancePurge:
+	default:
+		return fmt.Errorf("%s: unknown action %q", ErrComplianceEvent, e.Action)
+	}
+	if e.ID == "" || e.Job == "" {
+		return fmt.Errorf("%s: missing id or job", ErrComplianceEvent)
+	}
+	if strings.TrimSpace(e.Reason) == "" {
+		return fmt.Errorf("%s: a reason is required", ErrComplianceEvent)
+	}
+	return nil
+}
+
+func legalHoldKey(jobName string) string {
+	return fmt.Sprintf("%s:%s", legalHoldPrefix, jobName)
+}
+
+// heldTx returns whether the history of the job is under legal hold.
+func heldTx(tx *buntdb.Tx, jobName string) bool {
+	_, err := tx.Get(legalHoldKey(jobName))
+	return err == nil
+}
+
+// ApplyComplianceEvent applies the compliance action and records it, in
+// a single transaction. Holding the history of a job stops its executions
+// from expiring and being trimmed, releasing it applies the retention to
+// them again. Purging deletes the executions of the job, of its shadow
+// runs and kept in the trash with it, unless they're under legal hold.
+func (s *Store) ApplyComplianceEvent(event *ComplianceEvent) (*ComplianceEvent, error) {
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		held := heldTx(tx, event.Job)
+		switch event.Action {
+		case ComplianceHold:
+			if held {
+				return ErrLegalHold
+			}
+			b, err := proto.Marshal(event.ToProto())
+			if err != nil {
+				return err
+			}
+			if _, _, err := tx.Set(legalHoldKey(event.Job), string(b), nil); err != nil {
+				return err
+			}
+			if err := s.resetExecutionTTLsTxFunc(event.Job)(tx); err != nil {
+				return err
+			}
+		case ComplianceRelease:
+			if !held {
+				return ErrNoLegalHold
+			}
+			if _, err := tx.Delete(legalHoldKey(event.Job)); err != nil {
+				return err
+			}
+			if err := s.resetExecutionTTLsTxFunc(event.Job)(tx); err != nil {
+				return err
+			}
+		case CompliancePurge:
+			if held {
+				return ErrLegalHold
+			}
+			n, err := s.purgeExecutionsTxFunc(event.Job)(tx)
+			if err != nil {
+				return err
+			}
+			event.Executions = n
+			if s.cdcMaxPending > 0 {
+				if err := s.appendChangesTx(tx, false, &Change{Kind: ChangeExecution, Op: ChangePurge, Job: event.Job}); err != nil {
+					return err
+				}
+			}
+		}
+
+		b, err := proto.Marshal(event.ToProto())
+		if err != nil {
+			return err
+		}
+		_, _, err = tx.Set(fmt.Sprintf("%s:%s", compliancePrefix, event.ID), string(b), nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache.invalidateExecutions(event.Job)
+
+	return event, nil
+}
+
+// resetExecutionTTLsTxFunc stores the executions of the job again with the
+// options of the retention, without expiration while under legal hold.
+func (s *Store) resetExecutionTTLsTxFunc(jobName string) func(tx *buntdb.Tx) error {
+	return func(tx *buntdb.Tx) error {
+		values := map[string]string{}
+		options := map[string]*buntdb.SetOptions{}
+		var err error
+		tx.AscendKeys(fmt.Sprintf("%s:%s:*", executionsPrefix, jobName), func(key, value string) bool {
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				return false
+			}
+			values[key] = value
+			options[key] = s.executionSetOptions(tx, &pbe)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for k, v := range values {
+			if _, _, err := tx.Set(k, v, options[k]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// purgeExecutionsTxFunc deletes the executions of the job, returning how
+// many were deleted.
+func (s *Store) purgeExecutionsTxFunc(jobName string) func(tx *buntdb.Tx) (int, error) {
+	return func(tx *buntdb.Tx) (int, error) {
+		var keys []string
+		for _, prefix := range []string{executionsPrefix, shadowPrefix} {
+			tx.AscendKeys(fmt.Sprintf("%s:%s:*", prefix, jobName), func(key, value string) bool {
+				keys = append(keys, key)
+				return true
+			})
+		}
+		for _, k := range keys {
+			if _, err := tx.Delete(k); err != nil {
+				return 0, err
+			}
+		}
+		n := len(keys)
+
+		// The executions kept in the trash with the job
+		trashKey := fmt.Sprintf("%s:%s", trashPrefix, jobName)
+		value, err := tx.Get(trashKey)
+		if err == buntdb.ErrNotFound {
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		var tj dkronpb.TrashedJob
+		if err := proto.Unmarshal([]byte(value), &tj); err != nil {
+			return 0, err
+		}
+		if len(tj.Executions) == 0 {
+			return n, nil
+		}
+		n += len(tj.Executions)
+		tj.Executions = nil
+
+		ttl, err := tx.TTL(trashKey)
+		if err != nil {
+			return 0, err
+		}
+		b, err := proto.Marshal(&tj)
+		if err != nil {
+			return 0, err
+		}
+		var opts *buntdb.SetOptions
+		if ttl > 0 {
+			opts = &buntdb.SetOptions{Expires: true, TTL: ttl}
+		}
+		if _, _, err := tx.Set(trashKey, string(b), opts); err != nil {
+			return 0, err
+		}
+		return n, nil
+	}
+}
+
+// GetLegalHold returns the event that placed the history of the job under
+// legal hold.
+func (s *Store) GetLegalHold(jobName string) (*ComplianceEvent, error) {
+	var pbe dkronpb.ComplianceEvent
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(legalHoldKey(jobName))
+		if err != nil {
+			return err
+		}
+		return proto.Unmarshal([]byte(value), &pbe)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewComplianceEventFromProto(&pbe), nil
+}
+
+// GetLegalHolds returns the events that placed the histories under legal
+// hold, sorted by job.
+func (s *Store) GetLegalHolds() ([]*ComplianceEvent, error) {
+	return s.listComplianceEvents(legalHoldPrefix+":*", "")
+}
+
+// GetComplianceEvents returns the audit records of the compliance actions
+// on the history of the job, or of every job when empty, oldest first.
+func (s *Store) GetComplianceEvents(jobName string) ([]*ComplianceEvent, error) {
+	return s.listComplianceEvents(compliancePrefix+":*", jobName)
+}
+
+func (s *Store) listComplianceEvents(pattern, jobName string) ([]*ComplianceEvent, error) {
+	events := []*ComplianceEvent{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(pattern, func(key, value string) bool {
+			var pbe dkronpb.ComplianceEvent
+			if err = proto.Unmarshal([]byte(value), &pbe); err != nil {
+				return false
+			}
+			if jobName == "" || pbe.JobName == jobName {
+				events = append(events, NewComplianceEventFromProto(&pbe))
+			}
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// complianceRequest is the body of the compliance actions.
+type complianceRequest struct {
+	Reason string `json:"reason"`
+}
+
+// complianceHandler applies the compliance action to the history of the
+// job, recording who requested it and why.
+func (h *HTTPTransport) complianceHandler(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req complianceRequest
+		if err := c.BindJSON(&req); err != nil {
+			c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+			return
+		}
+
+		jobName := c.Param("job")
+		if action == ComplianceHold {
+			if _, err := h.agent.Store.GetJob(jobName, nil); err != nil {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			if !h.checkWritable(c, jobName) {
+				return
+			}
+		}
+
+		now := time.Now()
+		event := &ComplianceEvent{
+			ID:     newULID(now),
+			Action: action,
+			Job:    jobName,
+			User:   requestUser(c),
+			Reason: req.Reason,
+			At:     now,
+		}
+		if err := event.Validate(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// Call gRPC Compliance
+		event, err := h.agent.GRPCClient.Compliance(event)
+		if err != nil {
+			msg := status.Convert(err).Message()
+			switch msg {
+			case ErrLegalHold.Error(), ErrNoLegalHold.Error():
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": msg})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": msg})
+			}
+			return
+		}
+
+		log.WithFields(logrus.Fields{
+			"job":        event.Job,
+			"action":     event.Action,
+			"user":       event.User,
+			"reason":     event.Reason,
+			"executions": event.Executions,
+		}).Info("api: Compliance action applied")
+		renderJSON(c, http.StatusOK, event)
+	}
+}
+
+func (h *HTTPTransport) legalHoldHandler(c *gin.Context) {
+	hold, err := h.agent.Store.GetLegalHold(c.Param("job"))
+	if err == buntdb.ErrNotFound {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, hold)
+}
+
+func (h *HTTPTransport) legalHoldsHandler(c *gin.Context) {
+	holds, err := h.agent.Store.GetLegalHolds()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, holds)
+}
+
+func (h *HTTPTransport) complianceEventsHandler(c *gin.Context) {
+	events, err := h.agent.Store.GetComplianceEvents(c.Query("job"))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, events)
+}
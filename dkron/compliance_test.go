@@ -0,0 +1,161 @@
+package dkron
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestStore_Compliance(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+	s.maxExecutions = 2
+	s.executionRetention = time.Hour
+
+	require.NoError(t, s.SetJob(&Job{Name: "billing", Schedule: "@every 1h", Executor: "shell"}, false))
+	require.NoError(t, s.SetJob(&Job{Name: "billing2", Schedule: "@every 1h", Executor: "shell"}, false))
+
+	now := time.Now()
+	setExecution := func(job string, i int) {
+		_, err := s.SetExecution(&Execution{
+			JobName:    job,
+			Group:      int64(i),
+			NodeName:   "node",
+			StartedAt:  now.Add(time.Duration(i) * time.Minute),
+			FinishedAt: now.Add(time.Duration(i)*time.Minute + time.Second),
+			Attempt:    1,
+		})
+		require.NoError(t, err)
+	}
+	ttl := func(job string) time.Duration {
+		var d time.Duration
+		s.db.View(func(tx *buntdb.Tx) error {
+			tx.AscendKeys(executionsPrefix+":"+job+":*", func(key, value string) bool {
+				d, _ = tx.TTL(key)
+				return false
+			})
+			return nil
+		})
+		return d
+	}
+
+	setExecution("billing", 1)
+	setExecution("billing2", 1)
+	assert.True(t, ttl("billing") > 0)
+
+	// Events are listed by their IDs, in the order they happened
+	at := now
+	event := func(action, job string) *ComplianceEvent {
+		at = at.Add(time.Second)
+		return &ComplianceEvent{ID: newULID(at), Action: action, Job: job, User: "alice", Reason: "case 42", At: at}
+	}
+
+	// Held executions don't expire nor are trimmed
+	_, err = s.ApplyComplianceEvent(event(ComplianceHold, "billing"))
+	require.NoError(t, err)
+	assert.True(t, ttl("billing") < 0)
+	for i := 2; i <= 4; i++ {
+		setExecution("billing", i)
+	}
+	execs, err := s.GetExecutions("billing")
+	require.NoError(t, err)
+	assert.Len(t, execs, 4)
+	assert.True(t, ttl("billing") < 0)
+
+	_, err = s.ApplyComplianceEvent(event(ComplianceHold, "billing"))
+	assert.Equal(t, ErrLegalHold, err)
+	_, err = s.ApplyComplianceEvent(event(CompliancePurge, "billing"))
+	assert.Equal(t, ErrLegalHold, err)
+	_, err = s.DeleteJob("billing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrLegalHold.Error())
+
+	holds, err := s.GetLegalHolds()
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, "billing", holds[0].Job)
+
+	// Released executions expire again
+	_, err = s.ApplyComplianceEvent(event(ComplianceRelease, "billing"))
+	require.NoError(t, err)
+	assert.True(t, ttl("billing") > 0)
+	_, err = s.ApplyComplianceEvent(event(ComplianceRelease, "billing"))
+	assert.Equal(t, ErrNoLegalHold, err)
+
+	// Purging deletes the executions of the job only
+	purged, err := s.ApplyComplianceEvent(event(CompliancePurge, "billing"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, purged.Executions)
+	_, err = s.GetExecutions("billing")
+	assert.Equal(t, buntdb.ErrNotFound, err)
+	execs, err = s.GetExecutions("billing2")
+	require.NoError(t, err)
+	assert.Len(t, execs, 1)
+
+	// Including the ones kept in the trash
+	_, err = s.DeleteJobs("billing2", &DeleteOptions{Now: time.Now(), TrashExpiresAt: time.Now().Add(time.Hour), TrashExecutions: true})
+	require.NoError(t, err)
+	purged, err = s.ApplyComplianceEvent(event(CompliancePurge, "billing2"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged.Executions)
+	trash, err := s.GetTrash()
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, 0, trash[0].Executions)
+
+	events, err := s.GetComplianceEvents("billing")
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, []string{ComplianceHold, ComplianceRelease, CompliancePurge}, []string{events[0].Action, events[1].Action, events[2].Action})
+	assert.Equal(t, "alice", events[0].User)
+	assert.Equal(t, "case 42", events[0].Reason)
+
+	e := event(CompliancePurge, "billing")
+	e.Reason = " "
+	_, err = s.ApplyComplianceEvent(e)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrComplianceEvent.Error())
+}
+
+func TestAPICompliance(t *testing.T) {
+	dir, a := setupAPITest(t, "8143")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	require.NoError(t, a.GRPCClient.SetJob(&Job{Name: "billing", Schedule: "@every 1h", Executor: "shell", Disabled: true}, ""))
+
+	post := func(path, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:8143/v1/jobs/billing"+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set(userHeader, "alice")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post("/hold", `{}`).StatusCode)
+	assert.Equal(t, http.StatusOK, post("/hold", `{"reason": "case 42"}`).StatusCode)
+	assert.Equal(t, http.StatusConflict, post("/hold", `{"reason": "case 42"}`).StatusCode)
+
+	// Releasing and purging need the admin token
+	assert.Equal(t, http.StatusForbidden, post("/hold/release", `{"reason": "closed"}`).StatusCode)
+	assert.Equal(t, http.StatusForbidden, post("/purge", `{"reason": "erasure"}`).StatusCode)
+
+	resp, err := http.Get("http://localhost:8143/v1/jobs/billing/hold")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	events, err := a.Store.GetComplianceEvents("")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "alice", events[0].User)
+}
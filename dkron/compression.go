@@ -0,0 +1,97 @@
+package dkron
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+)
+
+const (
+	// CompressionNone stores the executions uncompressed.
+	CompressionNone = "none"
+	// CompressionSnappy stores the executions compressed with snappy.
+	CompressionSnappy = "snappy"
+)
+
+// ErrUnknownCompression is returned when the execution compression is
+// not supported.
+var ErrUnknownCompression = errors.New("unknown execution compression, use \"none\" or \"snappy\"")
+
+// snappyMagic is the stream identifier starting the compressed records,
+// written in the snappy framing format. It never starts a protobuf record,
+// 0xff 0x06 being a tag with the invalid wire type 7, nor a JSON one.
+var snappyMagic = []byte("\xff\x06\x00\x00sNaPpY")
+
+// validCompression returns an error if the compression is not supported,
+// empty is no compression.
+func validCompression(compression string) error {
+	switch compression {
+	case "", CompressionNone, CompressionSnappy:
+		return nil
+	}
+	return fmt.Errorf("%s: %s", ErrUnknownCompression, compression)
+}
+
+// encodeExecution returns the record of the execution to store,
+// compressed when it's smaller.
+func encodeExecution(pbe *dkronpb.Execution, compression string) ([]byte, error) {
+	b, err := proto.Marshal(pbe)
+	if err != nil {
+		return nil, err
+	}
+	if compression != CompressionSnappy {
+		return b, nil
+	}
+
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() >= len(b) {
+		return b, nil
+	}
+	return buf.Bytes(), nil
+}
+
+// isCompressed returns whether the record is compressed.
+func isCompressed(data []byte) bool {
+	return bytes.HasPrefix(data, snappyMagic)
+}
+
+// decompress returns the uncompressed record.
+func decompress(data []byte) ([]byte, error) {
+	return ioutil.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// recodedExecution returns the record of the execution to store if the
+// stored one isn't written with the compression of the store, nil when it
+// doesn't need to change. Records too small to be worth compressing are
+// left as they are.
+func (s *Store) recodedExecution(value string, pbe *dkronpb.Execution) ([]byte, error) {
+	if isCompressed([]byte(value)) == (s.compression == CompressionSnappy) {
+		return nil, nil
+	}
+	b, err := encodeExecution(pbe, s.compression)
+	if err != nil || string(b) == value {
+		return nil, err
+	}
+	return b, nil
+}
+
+// executionCompression returns the compression of the executions written
+// by the store.
+func (s *Store) executionCompression() string {
+	if s.compression == "" {
+		return CompressionNone
+	}
+	return s.compression
+}
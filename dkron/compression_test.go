@@ -0,0 +1,96 @@
+package dkron
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestEncodeExecution(t *testing.T) {
+	pbe := &dkronpb.Execution{
+		JobName: "report",
+		Output:  []byte(strings.Repeat("INFO processed batch\n", 100)),
+	}
+
+	b, err := encodeExecution(pbe, CompressionNone)
+	require.NoError(t, err)
+	assert.False(t, isCompressed(b))
+
+	b, err = encodeExecution(pbe, CompressionSnappy)
+	require.NoError(t, err)
+	assert.True(t, isCompressed(b))
+	assert.Less(t, len(b), len(pbe.Output))
+
+	var decoded dkronpb.Execution
+	require.NoError(t, decodeExecution(b, &decoded))
+	assert.Equal(t, pbe.Output, decoded.Output)
+
+	// Compressing doesn't pay off for small records
+	b, err = encodeExecution(&dkronpb.Execution{JobName: "report"}, CompressionSnappy)
+	require.NoError(t, err)
+	assert.False(t, isCompressed(b))
+
+	assert.NoError(t, validCompression(""))
+	assert.Error(t, validCompression("zstd"))
+}
+
+func TestStore_ExecutionCompression(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+	s.compression = CompressionSnappy
+	storeJob(t, s, "report")
+
+	output := strings.Repeat("INFO processed batch\n", 100)
+	ex := &Execution{
+		JobName:    "report",
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+		Success:    true,
+		Output:     output,
+		NodeName:   "node1",
+	}
+	key, err := s.SetExecution(ex)
+	require.NoError(t, err)
+
+	raw := func() string {
+		var v string
+		require.NoError(t, s.db.View(func(tx *buntdb.Tx) error {
+			v, err = tx.Get(key)
+			return err
+		}))
+		return v
+	}
+	assert.True(t, isCompressed([]byte(raw())))
+
+	executions, err := s.GetExecutions("report")
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, output, executions[0].Output)
+
+	// Existing records are reported and rewritten with the new compression
+	s.compression = CompressionNone
+	report, err := s.Check()
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 1)
+	assert.Equal(t, ProblemExecutionCompression, report.Problems[0].Kind)
+	assert.Equal(t, key, report.Problems[0].Key)
+
+	n, err := s.Repair()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.False(t, isCompressed([]byte(raw())))
+
+	report, err = s.Check()
+	require.NoError(t, err)
+	assert.Empty(t, report.Problems)
+
+	executions, err = s.GetExecutions("report")
+	require.NoError(t, err)
+	assert.Equal(t, output, executions[0].Output)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -159,13 +160,273 @@ type Config struct {
 
 	// EnablePrometheus enables serving of prometheus metrics at /metrics
 	EnablePrometheus bool `mapstructure:"enable-prometheus"`
+
+	// RequiredOwnerFields are the owner fields that every job must define,
+	// any of owner, owner_email, owner_team and owner_escalation_channel.
+	RequiredOwnerFields []string `mapstructure:"required-owner-fields"`
+
+	// AdminToken is the token allowing to change locked jobs, to turn
+	// the cluster read-only switch on or off and to access the diagnostics
+	// endpoints, sent in the X-Dkron-Admin-Token header. When empty these
+	// operations are not allowed.
+	AdminToken string `mapstructure:"admin-token"`
+
+	// KVTokenSecret is the secret the servers derive the tokens of the
+	// namespaces of the KV store from, it must be the same on all the
+	// servers. Jobs get the token of their namespace in DKRON_KV_TOKEN.
+	// When empty the KV store is open to every client.
+	KVTokenSecret string `mapstructure:"kv-token-secret"`
+
+	// TrashRetention is how long deleted jobs are kept in the trash where
+	// they can be restored from. Zero deletes jobs permanently.
+	TrashRetention time.Duration `mapstructure:"trash-retention"`
+
+	// TrashExecutions keeps the executions of deleted jobs in the trash
+	// with the job, restoring them along with it.
+	TrashExecutions bool `mapstructure:"trash-executions"`
+
+	// ArtifactStore is the URL of the object store where agents upload the
+	// artifact files produced by executions, either a file:// directory or
+	// an http(s):// base URL accepting PUT requests.
+	ArtifactStore string `mapstructure:"artifact-store"`
+
+	// DigestSchedule is the cron schedule of the activity digest sent by
+	// the leader for every namespace. Empty disables the digest.
+	DigestSchedule string `mapstructure:"digest-schedule"`
+
+	// DigestPeriod is the time span covered by the activity digest.
+	DigestPeriod time.Duration `mapstructure:"digest-period"`
+
+	// DigestMailTo are the recipients of the activity digest, either an
+	// address receiving every namespace or namespace=address.
+	DigestMailTo []string `mapstructure:"digest-mail-to"`
+
+	// DigestSlackWebhook is a Slack incoming webhook URL the activity
+	// digest is posted to.
+	DigestSlackWebhook string `mapstructure:"digest-slack-webhook"`
+
+	// ResultSpoolMax is the number of execution results an agent keeps in
+	// the data dir while it can't reach the servers, delivering them once
+	// it can. The oldest results are dropped when full, zero disables it.
+	ResultSpoolMax int `mapstructure:"result-spool-max"`
+
+	// ClockSkewThreshold is the clock skew between the leader and a member
+	// over which a warning is logged. Zero disables the warnings.
+	ClockSkewThreshold time.Duration `mapstructure:"clock-skew-threshold"`
+
+	// ResourceCPU is the number of CPU cores of this node available to
+	// jobs declaring resources. Zero doesn't limit them.
+	ResourceCPU float64 `mapstructure:"resource-cpu"`
+
+	// ResourceMemory is the memory in MB of this node available to jobs
+	// declaring resources. Zero doesn't limit them.
+	ResourceMemory int64 `mapstructure:"resource-memory"`
+
+	// PressureDiskThreshold is the percentage of the data dir filesystem
+	// in use over which the node stops accepting new executions. Zero
+	// disables it.
+	PressureDiskThreshold int `mapstructure:"pressure-disk-threshold"`
+
+	// PressureMemoryThreshold is the percentage of memory in use over
+	// which the node stops accepting new executions. Zero disables it.
+	PressureMemoryThreshold int `mapstructure:"pressure-memory-threshold"`
+
+	// WorkspaceDir is the directory the workspaces of the executions are
+	// created in, defaults to the workspaces directory in the data dir.
+	WorkspaceDir string `mapstructure:"workspace-dir"`
+
+	// WorkspaceRetention is how long the workspaces of finished executions
+	// are kept. Zero removes them when the execution finishes.
+	WorkspaceRetention time.Duration `mapstructure:"workspace-retention"`
+
+	// WorkspaceMaxSize is the size in MB of all the workspaces over which
+	// the oldest finished ones are removed. Zero doesn't limit it.
+	WorkspaceMaxSize int64 `mapstructure:"workspace-max-size"`
+
+	// ExecutionReapGrace is how long after starting an execution running
+	// on a node that is gone is finalized as failed by the leader. Zero
+	// disables it.
+	ExecutionReapGrace time.Duration `mapstructure:"execution-reap-grace"`
+
+	// ScheduleMacros are named schedules jobs can use, like
+	// @nightly-batch: "0 30 2 * * *". The leader publishes its macros to
+	// the cluster.
+	ScheduleMacros map[string]string `mapstructure:"schedule-macros"`
+
+	// ScheduleOffset shifts the schedules of every job, e.g. 6h runs them
+	// six hours later than their schedule. Used by staging clusters
+	// replaying the jobs of production.
+	ScheduleOffset time.Duration `mapstructure:"schedule-offset"`
+
+	// ScheduleSimulate records the runs of the jobs as successful without
+	// executing them.
+	ScheduleSimulate bool `mapstructure:"schedule-simulate"`
+
+	// OverloadDispatches is the number of runs being dispatched by the
+	// leader over which it's overloaded, deferring the scheduled runs of
+	// low priority jobs. Zero disables it.
+	OverloadDispatches int `mapstructure:"overload-dispatches"`
+
+	// OverloadWriteLatency is the average latency of the store writes done
+	// by the leader to run jobs over which it's overloaded. Zero disables it.
+	OverloadWriteLatency time.Duration `mapstructure:"overload-write-latency"`
+
+	// ExecutionCompression is the compression of the executions stored by
+	// servers, none or snappy. Executions stored with another compression
+	// are rewritten by repairing the store.
+	ExecutionCompression string `mapstructure:"execution-compression"`
+
+	// ExecutionRetention is how long servers keep the finished executions,
+	// they expire in the store. Zero keeps them until the MaxExecutions
+	// executions of the job are reached. The one of the leader is used by
+	// all the servers.
+	ExecutionRetention time.Duration `mapstructure:"execution-retention"`
+
+	// Slim runs the agent as a pure executor that writes nothing to the
+	// data dir, without workspaces nor result spool. Agents built with the
+	// slim tag always run slim.
+	Slim bool `mapstructure:"slim"`
+
+	// StoreProfile tunes the store and the raft log of servers, default or
+	// low-memory for small devices.
+	StoreProfile string `mapstructure:"store-profile"`
+
+	// PluginDirs are the directories plugins are discovered in after the
+	// default ones, the last plugin found with a name wins.
+	PluginDirs []string `mapstructure:"plugin-dir"`
+
+	// PluginChecksums pin the plugin binaries to their SHA256 checksum,
+	// in the file=sha256 format. Pinned plugins not matching aren't loaded.
+	PluginChecksums []string `mapstructure:"plugin-checksum"`
+
+	// PluginImages are the OCI images executor and processor plugins are
+	// pulled from, extracted in the plugin-images directory of the data dir.
+	PluginImages []string `mapstructure:"plugin-image"`
+
+	// PluginRegistryAuth are the credentials of the registries plugin
+	// images are pulled from, in the registry=user:password format.
+	PluginRegistryAuth []string `mapstructure:"plugin-registry-auth"`
+
+	// NamespaceDefaults are the default processors and notification
+	// settings of the jobs of every namespace, merged with the job ones.
+	NamespaceDefaults map[string]*NamespaceDefaults `mapstructure:"namespace-defaults"`
+
+	// JobSigningKeys are the files of the ed25519 public keys verifying
+	// the signatures of job specs, named after the file.
+	JobSigningKeys []string `mapstructure:"job-signing-key"`
+
+	// RequireSignedJobs rejects the jobs set through the API without a
+	// signature verified by a job signing key.
+	RequireSignedJobs bool `mapstructure:"require-signed-jobs"`
+
+	// ShadowTags are the key=value tags of the sandbox nodes shadow runs
+	// can target, shadow runs are rejected without them.
+	ShadowTags []string `mapstructure:"shadow-tags"`
+
+	// JobPolicies are the admission rules of the jobs set through the API
+	// and of their manual runs, every policy must admit a job.
+	JobPolicies []*JobPolicy `mapstructure:"job-policies"`
+
+	// APITokens restrict the executors of the jobs changed or run through
+	// the API. When set, these requests must carry one of the tokens or
+	// the admin token.
+	APITokens []*APIToken `mapstructure:"api-tokens"`
+
+	// ClusterEventsWebhook is the URL the cluster events, like leader
+	// elections, servers failing and quorum losses, are posted to.
+	ClusterEventsWebhook string `mapstructure:"cluster-events-webhook"`
+
+	// ClusterEventsMailTo are the recipients of the cluster events.
+	ClusterEventsMailTo []string `mapstructure:"cluster-events-mail-to"`
+
+	// RedactPatterns are the regular expressions of the secrets redacted
+	// from the output of every execution before it's processed and stored.
+	RedactPatterns []string `mapstructure:"redact-patterns"`
+
+	// CDCWebhook is the URL the changes of the jobs and executions are
+	// posted to in order, for change data capture.
+	CDCWebhook string `mapstructure:"cdc-webhook"`
+
+	// CDCKafkaProxy is the URL of the Kafka REST Proxy the changes are
+	// produced through, to the CDCKafkaTopic topic.
+	CDCKafkaProxy string `mapstructure:"cdc-kafka-proxy"`
+	CDCKafkaTopic string `mapstructure:"cdc-kafka-topic"`
+
+	// CDCHeaders are the headers of the requests to the change data
+	// capture sink.
+	CDCHeaders []string `mapstructure:"cdc-headers"`
+
+	// CDCMaxPending is the number of undelivered changes the servers keep,
+	// the oldest are dropped past it.
+	CDCMaxPending int `mapstructure:"cdc-max-pending"`
+
+	// ConsulAddr is the URL of the Consul agent the healthy instances of
+	// the Consul services targeted by jobs are looked up in.
+	ConsulAddr string `mapstructure:"consul-addr"`
+
+	// ConsulToken is the ACL token of the requests to Consul.
+	ConsulToken string `mapstructure:"consul-token"`
+
+	// VaultAddr is the URL of the Vault server the secrets of the jobs
+	// are read from by the agents running them.
+	VaultAddr string `mapstructure:"vault-addr"`
+
+	// VaultToken is the token of the requests to Vault.
+	VaultToken string `mapstructure:"vault-token"`
+
+	// VaultRoleID and VaultSecretID are the AppRole credentials the agent
+	// logs in to Vault with when there's no token.
+	VaultRoleID   string `mapstructure:"vault-role-id"`
+	VaultSecretID string `mapstructure:"vault-secret-id"`
+
+	// CredentialsKey is the base64 encoded 32 byte key the servers
+	// encrypt the credentials in the store with.
+	CredentialsKey string `mapstructure:"credentials-key"`
+
+	// CostRates are the costs per minute of the nodes by tags, the first
+	// rate whose tags a node has applies to the executions on it.
+	CostRates []*CostRate `mapstructure:"cost-rates"`
+
+	// RampUpRate is the number of missed runs per minute the leader
+	// catches up after the scheduler is resumed or it's elected. Zero
+	// disables catching them up.
+	RampUpRate int `mapstructure:"ramp-up-rate"`
+
+	// RampUpWindow is how far back a new leader catches up missed runs.
+	RampUpWindow time.Duration `mapstructure:"ramp-up-window"`
+
+	// ExecutionLogPath is the template of the path of the node local files
+	// the output of the executions is mirrored to. Empty disables them.
+	ExecutionLogPath string `mapstructure:"execution-log-path"`
+
+	// ExecutionLogMaxSize is the size in bytes over which an execution log
+	// file is rotated. Zero disables it.
+	ExecutionLogMaxSize int64 `mapstructure:"execution-log-max-size"`
+
+	// ExecutionLogMaxAge is the age over which an execution log file is
+	// rotated. Zero disables it.
+	ExecutionLogMaxAge time.Duration `mapstructure:"execution-log-max-age"`
+
+	// ExecutionLogMaxFiles is the number of rotated files kept of every
+	// execution log file. Zero keeps them all.
+	ExecutionLogMaxFiles int `mapstructure:"execution-log-max-files"`
 }
 
 // DefaultBindPort is the default port that dkron will use for Serf communication
 const (
-	DefaultBindPort      int           = 8946
-	DefaultRPCPort       int           = 6868
-	DefaultRetryInterval time.Duration = time.Second * 30
+	DefaultBindPort           int           = 8946
+	DefaultRPCPort            int           = 6868
+	DefaultRetryInterval      time.Duration = time.Second * 30
+	DefaultTrashRetention     time.Duration = 7 * 24 * time.Hour
+	DefaultDigestPeriod       time.Duration = 24 * time.Hour
+	DefaultResultSpoolMax     int           = 1000
+	DefaultClockSkew          time.Duration = time.Second
+	DefaultWorkspaceRetention time.Duration = 24 * time.Hour
+	DefaultExecutionReapGrace time.Duration = 15 * time.Minute
+	DefaultCDCMaxPending      int           = 100000
+	DefaultRampUpWindow       time.Duration = 24 * time.Hour
+	DefaultExecutionLogSize   int64         = 100 << 20
+	DefaultExecutionLogFiles  int           = 7
 )
 
 // DefaultConfig returns a Config struct pointer with sensible
@@ -193,6 +454,18 @@ func DefaultConfig() *Config {
 		ReconcileInterval:    60 * time.Second,
 		RaftMultiplier:       1,
 		SerfReconnectTimeout: "24h",
+		ResultSpoolMax:       DefaultResultSpoolMax,
+		ClockSkewThreshold:   DefaultClockSkew,
+		WorkspaceRetention:   DefaultWorkspaceRetention,
+		ExecutionReapGrace:   DefaultExecutionReapGrace,
+		ResourceCPU:          float64(runtime.NumCPU()),
+		TrashRetention:       DefaultTrashRetention,
+		DigestPeriod:         DefaultDigestPeriod,
+		CDCMaxPending:        DefaultCDCMaxPending,
+		RampUpWindow:         DefaultRampUpWindow,
+		ExecutionLogMaxSize:  DefaultExecutionLogSize,
+		ExecutionLogMaxAge:   24 * time.Hour,
+		ExecutionLogMaxFiles: DefaultExecutionLogFiles,
 	}
 }
 
@@ -240,6 +513,62 @@ func ConfigFlagSet() *flag.FlagSet {
 	cmdFlags.StringSlice("dog-statsd-tags", []string{}, "Datadog tags, specified as key:value")
 	cmdFlags.String("statsd-addr", "", "Statsd address")
 	cmdFlags.Bool("enable-prometheus", false, "Enable serving prometheus metrics")
+	cmdFlags.StringSlice("required-owner-fields", []string{}, "Owner fields that every job must define (owner, owner_email, owner_team, owner_escalation_channel). Can be specified multiple times")
+	cmdFlags.String("admin-token", "", "Token allowing to change locked jobs, to turn the read-only mode on or off and to access the diagnostics endpoints, sent in the X-Dkron-Admin-Token header")
+	cmdFlags.String("kv-token-secret", "", "Secret the servers derive the tokens of the KV store namespaces from, the same on all the servers. When empty the KV store is open to every client")
+	cmdFlags.String("trash-retention", c.TrashRetention.String(), "Time deleted jobs are kept in the trash before being permanently deleted, 0 disables the trash")
+	cmdFlags.Bool("trash-executions", false, "Keep the executions of deleted jobs in the trash to restore them along with the job")
+	cmdFlags.String("digest-schedule", "", "Cron schedule of the activity digest of every namespace, e.g. \"0 0 8 * * mon\". Empty disables the digest")
+	cmdFlags.String("digest-period", c.DigestPeriod.String(), "Time span covered by the activity digest")
+	cmdFlags.StringSlice("digest-mail-to", []string{}, "Recipient of the activity digest, either an address receiving every namespace or namespace=address. Can be specified multiple times")
+	cmdFlags.String("digest-slack-webhook", "", "Slack incoming webhook URL the activity digest is posted to")
+	cmdFlags.Int("result-spool-max", c.ResultSpoolMax, "Number of execution results kept in the data dir while the servers are unreachable, delivered once they are. Zero disables it")
+	cmdFlags.String("clock-skew-threshold", c.ClockSkewThreshold.String(), "Clock skew between the leader and a member over which a warning is logged. Zero disables the warnings")
+	cmdFlags.Float64("resource-cpu", c.ResourceCPU, "CPU cores of this node available to jobs declaring resources, defaults to the number of cores. Zero doesn't limit them")
+	cmdFlags.Int64("resource-memory", 0, "Memory in MB of this node available to jobs declaring resources. Zero doesn't limit them")
+	cmdFlags.Int("pressure-disk-threshold", 0, "Percentage of the data dir filesystem in use over which the node stops accepting new executions until it goes below. Zero disables it")
+	cmdFlags.Int("pressure-memory-threshold", 0, "Percentage of memory in use over which the node stops accepting new executions until it goes below. Zero disables it")
+	cmdFlags.String("workspace-dir", "", "Directory the workspaces of the executions are created in, defaults to the workspaces directory in the data dir")
+	cmdFlags.String("workspace-retention", c.WorkspaceRetention.String(), "Time the workspaces of finished executions are kept. Zero removes them when the execution finishes")
+	cmdFlags.Int64("workspace-max-size", 0, "Size in MB of all the workspaces over which the oldest finished ones are removed. Zero doesn't limit it")
+	cmdFlags.String("execution-reap-grace", c.ExecutionReapGrace.String(), "Time after starting that an execution running on a node that is gone is finalized as failed by the leader. Zero disables it")
+	cmdFlags.String("schedule-offset", "0s", "Time the schedules of every job are shifted by, e.g. 6h runs them six hours later, for staging clusters replaying production jobs")
+	cmdFlags.Bool("schedule-simulate", false, "Record the runs of the jobs as successful without executing them")
+	cmdFlags.Int("overload-dispatches", 0, "Number of runs being dispatched by the leader over which it defers the scheduled runs of low priority jobs. Zero disables it")
+	cmdFlags.String("overload-write-latency", "0s", "Average latency of the store writes of the leader over which it defers the scheduled runs of low priority jobs. Zero disables it")
+	cmdFlags.String("execution-compression", CompressionNone, "Compression of the executions stored by servers, none or snappy")
+	cmdFlags.String("execution-retention", "0s", "Time servers keep the finished executions, they expire in the store. The one of the leader is used by all the servers. Zero keeps the last executions of every job")
+	cmdFlags.Bool("slim", false, "Run the agent as a pure executor that writes nothing to the data dir, without workspaces nor result spool. Can't be used in servers")
+	cmdFlags.String("store-profile", StoreProfileDefault, "Tuning of the store and the raft log of servers, default or low-memory, caching fewer raft logs and no jobs for small devices")
+	cmdFlags.StringSlice("plugin-dir", []string{}, "Directory plugins are discovered in after the default ones, the last plugin found with a name wins. Can be specified multiple times")
+	cmdFlags.StringSlice("plugin-checksum", []string{}, "SHA256 checksum a plugin binary is pinned to, in the file=sha256 format. Pinned plugins not matching aren't loaded. Can be specified multiple times")
+	cmdFlags.StringSlice("plugin-image", []string{}, "OCI image plugin binaries are pulled from on start, pinned to the digest of its manifest like registry.example.com/dkron/plugins:1.0@sha256:<digest>. Can be specified multiple times")
+	cmdFlags.StringSlice("plugin-registry-auth", []string{}, "Credentials of a registry plugin images are pulled from, in the registry=user:password format. Can be specified multiple times")
+	cmdFlags.StringSlice("job-signing-key", []string{}, "File of an ed25519 public key verifying the signatures of job specs, as written by dkron sign keygen. Can be specified multiple times")
+	cmdFlags.Bool("require-signed-jobs", false, "Reject the jobs set through the API without a signature verified by a job signing key")
+	cmdFlags.StringSlice("shadow-tags", []string{}, "Tag of the sandbox nodes shadow runs can target, specified as key=value, shadow runs are rejected without them. Can be specified multiple times")
+	cmdFlags.String("cluster-events-webhook", "", "URL the cluster events, like leader elections, servers joining, leaving or failing and quorum losses, are posted to as JSON")
+	cmdFlags.StringSlice("cluster-events-mail-to", []string{}, "Recipient of the cluster events, mailed with the mail settings. Can be specified multiple times")
+	cmdFlags.StringSlice("redact-patterns", []string{}, "Regular expression of the secrets redacted from the output of every execution before it's processed and stored, only the groups are redacted from patterns with groups. Can be specified multiple times")
+	cmdFlags.String("cdc-webhook", "", "URL the changes of the jobs and executions are posted to in order as JSON, for change data capture")
+	cmdFlags.String("cdc-kafka-proxy", "", "URL of the Kafka REST Proxy the changes of the jobs and executions are produced through, for change data capture")
+	cmdFlags.String("cdc-kafka-topic", "", "Kafka topic the changes are produced to through the Kafka REST Proxy")
+	cmdFlags.StringSlice("cdc-headers", []string{}, "Header of the requests to the change data capture sink, in the key:value format. Can be specified multiple times")
+	cmdFlags.Int("cdc-max-pending", c.CDCMaxPending, "Number of undelivered changes kept by the servers for the change data capture sink, the oldest are dropped past it")
+	cmdFlags.String("consul-addr", "", "URL of the Consul agent the healthy instances of the Consul services targeted by jobs are looked up in, like http://127.0.0.1:8500")
+	cmdFlags.String("consul-token", "", "ACL token of the requests to Consul")
+	cmdFlags.String("vault-addr", "", "URL of the Vault server the secrets of the jobs are read from by the agents running them, like https://vault.example.com:8200")
+	cmdFlags.String("vault-token", "", "Token of the requests to Vault")
+	cmdFlags.String("vault-role-id", "", "Role ID of the AppRole the agent logs in to Vault with when there's no token")
+	cmdFlags.String("vault-secret-id", "", "Secret ID of the AppRole the agent logs in to Vault with when there's no token")
+	cmdFlags.String("credentials-key", "", "Key the servers encrypt the credentials of the jobs in the store with, a base64 encoded 32 byte key. Credentials can't be set without it")
+	cmdFlags.Int("ramp-up-rate", 0, "Number of missed runs per minute the leader catches up after the scheduler is resumed or a new leader is elected, normal priority jobs first. Zero disables catching up missed runs")
+	cmdFlags.String("ramp-up-window", c.RampUpWindow.String(), "How far back a new leader catches up missed runs when ramp-up-rate is set")
+	cmdFlags.String("execution-log-path", "", "Template of the path of the node local files the output of the executions run by the agent is mirrored to, like /var/log/dkron/{{.Job}}.log. Empty disables them")
+	cmdFlags.Int64("execution-log-max-size", c.ExecutionLogMaxSize, "Size in bytes over which an execution log file is rotated. Zero disables it")
+	cmdFlags.String("execution-log-max-age", c.ExecutionLogMaxAge.String(), "Age over which an execution log file is rotated. Zero disables it")
+	cmdFlags.Int("execution-log-max-files", c.ExecutionLogMaxFiles, "Number of rotated files kept of every execution log file. Zero keeps them all")
+	cmdFlags.String("artifact-store", "", "URL of the object store where execution artifacts are uploaded, a file:// directory or an http(s):// base URL accepting PUT requests")
 
 	return cmdFlags
 }
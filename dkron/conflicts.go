@@ -0,0 +1,296 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/serf/serf"
+)
+
+const (
+	// defaultConflictWindow is the window checked for conflicts when no
+	// end is given.
+	defaultConflictWindow = 24 * time.Hour
+	// defaultRunDuration is the estimated duration of the runs of jobs
+	// that never finished one.
+	defaultRunDuration = time.Minute
+	// maxConflictRuns bounds the runs of a job checked in the window.
+	maxConflictRuns = 10000
+	// maxConflictOverlaps bounds the overlaps listed per conflicting job.
+	maxConflictOverlaps = 10
+)
+
+// Reasons two jobs conflict.
+const (
+	// ConflictNodes jobs run on some of the same nodes.
+	ConflictNodes = "nodes"
+	// ConflictGroup jobs are in the same concurrency group, their runs
+	// wait for each other.
+	ConflictGroup = "concurrency_group"
+)
+
+// ErrNoSchedule is returned when checking the conflicts of a job without
+// schedule.
+var ErrNoSchedule = errors.New("job has no schedule to check")
+
+// ConflictReport lists the scheduled jobs whose runs overlap the runs of a
+// proposed job in a time window, on the same nodes or concurrency group.
+type ConflictReport struct {
+	Job  string    `json:"job"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Runs of the proposed job in the window and their estimated duration.
+	Runs     int           `json:"runs"`
+	Duration time.Duration `json:"duration"`
+
+	Conflicts []*Conflict `json:"conflicts"`
+}
+
+// Conflict is a job whose runs overlap the runs of the proposed job.
+type Conflict struct {
+	Job    string `json:"job"`
+	Reason string `json:"reason"`
+
+	// Nodes both jobs run on, or their concurrency group.
+	Nodes []string `json:"nodes,omitempty"`
+	Group string   `json:"group,omitempty"`
+
+	// Estimated duration of the runs of the job.
+	Duration time.Duration `json:"duration"`
+
+	// Number of overlapping periods, and the first of them.
+	Overlaps int        `json:"overlaps"`
+	Periods  []*Overlap `json:"periods"`
+}
+
+// Overlap is a period both jobs are running.
+type Overlap struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// runDuration estimates the duration of the runs of the job as the
+// average of its finished executions in the store.
+func runDuration(s Storage, jobName string) (time.Duration, error) {
+	sums, err := s.GetExecutionSummary(jobName, SummaryByStatus, time.UTC)
+	if err != nil {
+		return 0, err
+	}
+	var total time.Duration
+	var finished int
+	for _, sum := range sums {
+		n := sum.Executions - sum.Running
+		total += sum.AvgDuration * time.Duration(n)
+		finished += n
+	}
+	if finished == 0 {
+		return defaultRunDuration, nil
+	}
+	return total / time.Duration(finished), nil
+}
+
+// scheduledRuns returns the runs of the job overlapping the window,
+// starting at most duration before it.
+func scheduledRuns(job *Job, offset time.Duration, from, to time.Time, duration time.Duration) ([]*Overlap, error) {
+	sched, err := extcron.Parse(job.cronSchedule())
+	if err != nil {
+		return nil, err
+	}
+	sched = shiftSchedule(sched, offset)
+
+	var runs []*Overlap
+	for t := sched.Next(from.Add(-duration)); !t.IsZero() && t.Before(to) && len(runs) < maxConflictRuns; t = sched.Next(t) {
+		runs = append(runs, &Overlap{Start: t, End: t.Add(duration)})
+	}
+	return runs, nil
+}
+
+// overlaps returns the periods both sorted lists of runs are running.
+func overlaps(a, b []*Overlap) []*Overlap {
+	var periods []*Overlap
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start, end := a[i].Start, a[i].End
+		if b[j].Start.After(start) {
+			start = b[j].Start
+		}
+		if b[j].End.Before(end) {
+			end = b[j].End
+		}
+		if start.Before(end) {
+			periods = append(periods, &Overlap{Start: start, End: end})
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return periods
+}
+
+// matchingNodes returns the names of the alive members matching the tags
+// of the job.
+func matchingNodes(members []serf.Member, job *Job, region string) map[string]bool {
+	_, nodes := explainNodes(members, job.Tags, region)
+	matching := map[string]bool{}
+	for _, n := range nodes {
+		if n.Matches {
+			matching[n.Name] = true
+		}
+	}
+	return matching
+}
+
+// BuildConflicts reports the enabled scheduled jobs whose runs overlap the
+// runs of the proposed job between from and to, on nodes of the members
+// both run on or in the same concurrency group. A stored job with the name
+// of the proposed one is the job being edited and isn't checked. Without
+// duration the runs of the proposed job are estimated from its history.
+func BuildConflicts(s Storage, members []serf.Member, region string, offset time.Duration, job *Job, from, to time.Time, duration time.Duration) (*ConflictReport, error) {
+	if job.Schedule == "" {
+		return nil, ErrNoSchedule
+	}
+
+	var err error
+	if duration == 0 {
+		if duration, err = runDuration(s, job.Name); err != nil {
+			return nil, err
+		}
+	}
+	runs, err := scheduledRuns(job, offset, from, to, duration)
+	if err != nil {
+		return nil, err
+	}
+	report := &ConflictReport{
+		Job:       job.Name,
+		From:      from,
+		To:        to,
+		Runs:      len(runs),
+		Duration:  duration,
+		Conflicts: []*Conflict{},
+	}
+
+	jobs, err := s.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+	nodes := matchingNodes(members, job, region)
+	group := job.concurrencyLockName()
+
+	for _, other := range jobs {
+		if other.Name == job.Name || other.Disabled || other.Schedule == "" {
+			continue
+		}
+
+		c := &Conflict{Job: other.Name}
+		if g := other.concurrencyLockName(); g != "" && g == group {
+			c.Reason = ConflictGroup
+			c.Group = strings.TrimPrefix(g, concurrencyLockPrefix)
+		} else {
+			for n := range matchingNodes(members, other, region) {
+				if nodes[n] {
+					c.Nodes = append(c.Nodes, n)
+				}
+			}
+			if len(c.Nodes) == 0 {
+				continue
+			}
+			sort.Strings(c.Nodes)
+			c.Reason = ConflictNodes
+		}
+
+		if c.Duration, err = runDuration(s, other.Name); err != nil {
+			return nil, err
+		}
+		otherRuns, err := scheduledRuns(other, offset, from, to, c.Duration)
+		if err != nil {
+			// Invalid schedules are reported by fsck
+			continue
+		}
+		periods := overlaps(runs, otherRuns)
+		if len(periods) == 0 {
+			continue
+		}
+		c.Overlaps = len(periods)
+		if len(periods) > maxConflictOverlaps {
+			periods = periods[:maxConflictOverlaps]
+		}
+		c.Periods = periods
+		report.Conflicts = append(report.Conflicts, c)
+	}
+
+	// The most frequent conflicts first
+	sort.SliceStable(report.Conflicts, func(i, j int) bool {
+		if report.Conflicts[i].Overlaps != report.Conflicts[j].Overlaps {
+			return report.Conflicts[i].Overlaps > report.Conflicts[j].Overlaps
+		}
+		return report.Conflicts[i].Job < report.Conflicts[j].Job
+	})
+	return report, nil
+}
+
+// conflictsHandler checks the conflicts of the job in the request body,
+// new or an edit of a stored one, without changing anything.
+func (h *HTTPTransport) conflictsHandler(c *gin.Context) {
+	var job Job
+	if err := c.BindJSON(&job); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		return
+	}
+
+	from := time.Now()
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from %q", v)})
+			return
+		}
+		from = t
+	}
+	to := from.Add(defaultConflictWindow)
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to %q", v)})
+			return
+		}
+		to = t
+	}
+	if !from.Before(to) || to.Sub(from) > maxTimelineWindow {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("from must be before to and the window at most %s", maxTimelineWindow)})
+		return
+	}
+	var duration time.Duration
+	if v := c.Query("duration"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid duration %q", v)})
+			return
+		}
+		duration = d
+	}
+
+	if job.Schedule == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": ErrNoSchedule.Error()})
+		return
+	}
+	if _, err := extcron.Parse(job.cronSchedule()); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", ErrScheduleParse, err)})
+		return
+	}
+
+	report, err := BuildConflicts(h.agent.Store, h.agent.serf.Members(), h.agent.config.Region,
+		h.agent.config.ScheduleOffset, &job, from, to, duration)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, report)
+}
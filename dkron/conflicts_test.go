@@ -0,0 +1,66 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConflicts(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	members := []serf.Member{
+		{Name: "etl1", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "etl"}},
+		{Name: "web1", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "web"}},
+	}
+	for _, j := range []*Job{
+		{Name: "nightly", Schedule: "0 0 2 * * *", Executor: "shell", Tags: map[string]string{"role": "etl"}},
+		{Name: "web-cleanup", Schedule: "0 0 2 * * *", Executor: "shell", Tags: map[string]string{"role": "web"}},
+		{Name: "ledger", Schedule: "0 5 4 * * *", Executor: "shell", Tags: map[string]string{"role": "web"},
+			Concurrency: ConcurrencyForbid, ConcurrencyKey: "db", Metadata: map[string]string{"db": "main"}},
+		{Name: "later", Schedule: "0 0 5 * * *", Executor: "shell", Tags: map[string]string{"role": "etl"}},
+	} {
+		require.NoError(t, s.SetJob(j, false))
+	}
+
+	// The nightly runs take an hour
+	day := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.SetExecution(&Execution{JobName: "nightly", Group: 1, Attempt: 1, NodeName: "etl1",
+		StartedAt: day.Add(-22 * time.Hour), FinishedAt: day.Add(-21 * time.Hour), Success: true})
+	require.NoError(t, err)
+
+	proposed := &Job{Name: "reindex", Schedule: "0 0 * * * *", Executor: "shell", Tags: map[string]string{"role": "etl"},
+		Concurrency: ConcurrencyForbid, ConcurrencyKey: "db", Metadata: map[string]string{"db": "main"}}
+	report, err := BuildConflicts(s, members, "global", 0, proposed, day, day.Add(24*time.Hour), 10*time.Minute)
+	require.NoError(t, err)
+
+	assert.Equal(t, 24, report.Runs)
+	require.Len(t, report.Conflicts, 3)
+	// Equally frequent conflicts are sorted by name
+	assert.Equal(t, "later", report.Conflicts[0].Job)
+	assert.Equal(t, "ledger", report.Conflicts[1].Job)
+	assert.Equal(t, ConflictGroup, report.Conflicts[1].Reason)
+	assert.Equal(t, "db=main", report.Conflicts[1].Group)
+
+	// The 2am run overlaps the hour long nightly run
+	nightly := report.Conflicts[2]
+	assert.Equal(t, "nightly", nightly.Job)
+	assert.Equal(t, ConflictNodes, nightly.Reason)
+	assert.Equal(t, []string{"etl1"}, nightly.Nodes)
+	assert.Equal(t, time.Hour, nightly.Duration)
+	assert.Equal(t, 1, nightly.Overlaps)
+	assert.Equal(t, day.Add(2*time.Hour), nightly.Periods[0].Start)
+
+	// Editing a stored job doesn't conflict with itself
+	proposed.Name = "nightly"
+	report, err = BuildConflicts(s, members, "global", 0, proposed, day, day.Add(24*time.Hour), 10*time.Minute)
+	require.NoError(t, err)
+	for _, c := range report.Conflicts {
+		assert.NotEqual(t, "nightly", c.Job)
+	}
+}
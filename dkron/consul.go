@@ -0,0 +1,183 @@
+package dkron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/hashicorp/serf/serf"
+)
+
+// consulTimeout is the timeout of the requests to the Consul API.
+const consulTimeout = 10 * time.Second
+
+var (
+	// ErrInvalidConsulService is returned when the Consul service of a job
+	// is not valid.
+	ErrInvalidConsulService = errors.New("invalid consul service")
+	// ErrNoConsul is returned when running a job targeting a Consul
+	// service without the address of Consul configured.
+	ErrNoConsul = errors.New("consul-addr is not configured")
+)
+
+// ConsulService targets a job to the nodes running the healthy instances
+// of a service of the Consul catalog.
+type ConsulService struct {
+	// Service name in the catalog.
+	Service string `json:"service"`
+
+	// Tag the instances must have, any instance when empty.
+	Tag string `json:"tag,omitempty"`
+
+	// Count of nodes to run on, picked at random, all when zero.
+	Count int `json:"count,omitempty"`
+
+	// Datacenter of the service, the one of the Consul agent when empty.
+	Datacenter string `json:"datacenter,omitempty"`
+}
+
+func consulServiceFromProto(in *proto.ConsulService) *ConsulService {
+	if in == nil {
+		return nil
+	}
+	return &ConsulService{
+		Service:    in.Service,
+		Tag:        in.Tag,
+		Count:      int(in.Count),
+		Datacenter: in.Datacenter,
+	}
+}
+
+func (cs *ConsulService) toProto() *proto.ConsulService {
+	if cs == nil {
+		return nil
+	}
+	return &proto.ConsulService{
+		Service:    cs.Service,
+		Tag:        cs.Tag,
+		Count:      int32(cs.Count),
+		Datacenter: cs.Datacenter,
+	}
+}
+
+func (cs *ConsulService) validate() error {
+	if cs.Service == "" {
+		return fmt.Errorf("%s: service is required", ErrInvalidConsulService)
+	}
+	if cs.Count < 0 {
+		return fmt.Errorf("%s: count can't be negative", ErrInvalidConsulService)
+	}
+	return nil
+}
+
+// consulHealthEntry is an instance of a service returned by the health
+// endpoint of the Consul API.
+type consulHealthEntry struct {
+	Node struct {
+		Node    string `json:"Node"`
+		Address string `json:"Address"`
+	} `json:"Node"`
+	Service struct {
+		Address string `json:"Address"`
+	} `json:"Service"`
+}
+
+// consulInstances returns the instances of the service passing their
+// health checks.
+func (a *Agent) consulInstances(cs *ConsulService) ([]*consulHealthEntry, error) {
+	if a.config.ConsulAddr == "" {
+		return nil, ErrNoConsul
+	}
+
+	query := url.Values{"passing": {"true"}}
+	if cs.Tag != "" {
+		query.Set("tag", cs.Tag)
+	}
+	if cs.Datacenter != "" {
+		query.Set("dc", cs.Datacenter)
+	}
+	u := fmt.Sprintf("%s/v1/health/service/%s?%s", strings.TrimSuffix(a.config.ConsulAddr, "/"), url.PathEscape(cs.Service), query.Encode())
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if a.config.ConsulToken != "" {
+		req.Header.Set("X-Consul-Token", a.config.ConsulToken)
+	}
+
+	client := &http.Client{Timeout: consulTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul: Health of service %s returned %s", cs.Service, resp.Status)
+	}
+
+	var entries []*consulHealthEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// consulMembers returns the members running a healthy instance of the
+// service, matched by node name or address.
+func (a *Agent) consulMembers(members []serf.Member, cs *ConsulService) ([]serf.Member, error) {
+	entries, err := a.consulInstances(cs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	addrs := map[string]bool{}
+	for _, e := range entries {
+		names[e.Node.Node] = true
+		if e.Node.Address != "" {
+			addrs[e.Node.Address] = true
+		}
+		if e.Service.Address != "" {
+			addrs[e.Service.Address] = true
+		}
+	}
+
+	var healthy []serf.Member
+	for _, m := range members {
+		if names[m.Name] || addrs[m.Addr.String()] {
+			healthy = append(healthy, m)
+		}
+	}
+	return healthy, nil
+}
+
+// limitNodes returns count of the nodes picked with shuffle, all of them
+// when count is zero.
+func limitNodes(nodes map[string]string, count int, shuffle func(n int, swap func(i, j int))) map[string]string {
+	if count <= 0 || len(nodes) <= count {
+		return nodes
+	}
+
+	names := make([]string, 0, len(nodes))
+	for n := range nodes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+
+	limited := make(map[string]string, count)
+	for _, n := range names[:count] {
+		limited[n] = nodes[n]
+	}
+	return limited
+}
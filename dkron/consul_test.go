@@ -0,0 +1,77 @@
+package dkron
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsulMembers(t *testing.T) {
+	var query, token string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/health/service/etl-worker" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.RawQuery
+		token = r.Header.Get("X-Consul-Token")
+		fmt.Fprint(w, `[
+			{"Node": {"Node": "worker1", "Address": "10.0.0.1"}, "Service": {"Address": ""}},
+			{"Node": {"Node": "consul-node-7", "Address": "10.0.0.7"}, "Service": {"Address": "10.0.1.2"}}
+		]`)
+	}))
+	defer ts.Close()
+
+	c := DefaultConfig()
+	a := &Agent{config: c}
+	cs := &ConsulService{Service: "etl-worker", Tag: "blue", Datacenter: "dc2"}
+
+	members := []serf.Member{
+		{Name: "worker1", Addr: net.ParseIP("10.0.0.1")},
+		{Name: "worker2", Addr: net.ParseIP("10.0.1.2")},
+		{Name: "worker3", Addr: net.ParseIP("10.0.0.3")},
+	}
+
+	_, err := a.consulMembers(members, cs)
+	assert.Equal(t, ErrNoConsul, err)
+
+	c.ConsulAddr = ts.URL + "/"
+	c.ConsulToken = "acl-secret"
+	healthy, err := a.consulMembers(members, cs)
+	require.NoError(t, err)
+	require.Len(t, healthy, 2)
+	assert.Equal(t, "worker1", healthy[0].Name)
+	assert.Equal(t, "worker2", healthy[1].Name)
+	assert.Equal(t, "dc=dc2&passing=true&tag=blue", query)
+	assert.Equal(t, "acl-secret", token)
+
+	_, err = a.consulMembers(members, &ConsulService{Service: "missing"})
+	assert.Error(t, err)
+}
+
+func TestLimitNodes(t *testing.T) {
+	nodes := map[string]string{"a": "10.0.0.1:6868", "b": "10.0.0.2:6868", "c": "10.0.0.3:6868"}
+	noShuffle := func(n int, swap func(i, j int)) {}
+
+	assert.Equal(t, nodes, limitNodes(nodes, 0, noShuffle))
+	assert.Equal(t, nodes, limitNodes(nodes, 5, noShuffle))
+	assert.Equal(t, map[string]string{"a": "10.0.0.1:6868"}, limitNodes(nodes, 1, noShuffle))
+}
+
+func TestConsulServiceValidate(t *testing.T) {
+	job := scaffoldJob()
+	job.ConsulService = &ConsulService{Service: "etl-worker", Count: 1}
+	assert.NoError(t, job.Validate())
+	assert.Equal(t, job.ConsulService, NewJobFromProto(job.ToProto()).ConsulService)
+
+	job.ConsulService = &ConsulService{Count: 1}
+	assert.Error(t, job.Validate())
+	job.ConsulService = &ConsulService{Service: "etl-worker", Count: -1}
+	assert.Error(t, job.Validate())
+}
@@ -0,0 +1,107 @@
+package dkron
+
+import (
+	"compress/gzip"
+	"strings"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	pb "github.com/golang/protobuf/proto"
+)
+
+// protobufMIMETypes are the Accept types of the protobuf responses.
+var protobufMIMETypes = []string{"application/protobuf", "application/x-protobuf"}
+
+// wantsProtobuf returns whether the request accepts protobuf responses.
+func wantsProtobuf(c *gin.Context) bool {
+	accept := c.GetHeader("Accept")
+	for _, t := range protobufMIMETypes {
+		if strings.Contains(accept, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// readProto returns the protobuf message of the jobs and executions read
+// by the API.
+func readProto(v interface{}) (pb.Message, bool) {
+	switch o := v.(type) {
+	case *Job:
+		return o.ToProto(), true
+	case []*Job:
+		jl := &proto.JobList{}
+		for _, j := range o {
+			jl.Jobs = append(jl.Jobs, j.ToProto())
+		}
+		return jl, true
+	case *Execution:
+		return o.ToProto(), true
+	case []*Execution:
+		el := &proto.ExecutionList{}
+		for _, e := range o {
+			el.Executions = append(el.Executions, e.ToProto())
+		}
+		return el, true
+	}
+	return nil, false
+}
+
+// renderRead renders the jobs or executions read as protobuf when the
+// request accepts it, as JSON with only the requested fields otherwise.
+func renderRead(c *gin.Context, status int, v interface{}) {
+	c.Writer.Header().Add("Vary", "Accept")
+	if wantsProtobuf(c) {
+		if m, ok := readProto(v); ok {
+			c.ProtoBuf(status, m)
+			return
+		}
+	}
+	renderSparseJSON(c, status, v)
+}
+
+// gzipWriter compresses the body of the response, started on the first
+// write so responses without body stay empty.
+type gzipWriter struct {
+	gin.ResponseWriter
+	gz *gzip.Writer
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	if w.gz == nil {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Del("Content-Length")
+		w.gz = gzip.NewWriter(w.ResponseWriter)
+	}
+	return w.gz.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) close() {
+	if w.gz != nil {
+		w.gz.Close()
+	}
+}
+
+// gzipMiddleware compresses the responses of the requests accepting gzip,
+// for the listings that can be tens of MB of JSON.
+func gzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		if !strings.Contains(c.GetHeader("Accept-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		w := &gzipWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+		c.Next()
+	}
+}
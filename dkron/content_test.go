@@ -0,0 +1,61 @@
+package dkron
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderReadNegotiation(t *testing.T) {
+	jobs := []*Job{scaffoldJob(), scaffoldJob()}
+	jobs[1].Name = "other"
+
+	r := gin.New()
+	r.GET("/jobs", gzipMiddleware(), func(c *gin.Context) {
+		renderRead(c, http.StatusOK, jobs)
+	})
+	r.GET("/empty", gzipMiddleware(), func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusNotModified)
+	})
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// JSON by default
+	w := get("/jobs", nil)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	// Protobuf, compressed
+	w = get("/jobs", map[string]string{"Accept": "application/protobuf", "Accept-Encoding": "gzip, deflate"})
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	gz, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(gz)
+	require.NoError(t, err)
+
+	var jl proto.JobList
+	require.NoError(t, pb.Unmarshal(body, &jl))
+	require.Len(t, jl.Jobs, 2)
+	assert.Equal(t, "other", jl.Jobs[1].Name)
+
+	// Responses without body aren't compressed
+	w = get("/empty", map[string]string{"Accept-Encoding": "gzip"})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Zero(t, w.Body.Len())
+}
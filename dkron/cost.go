@@ -0,0 +1,226 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/tidwall/buntdb"
+)
+
+// Groupings and periods of the cost reports.
+const (
+	CostByJob       = "job"
+	CostByNamespace = "namespace"
+	CostPerDay      = "day"
+	CostPerMonth    = "month"
+)
+
+var (
+	// ErrInvalidCost is returned when a cost of the model is negative.
+	ErrInvalidCost = errors.New("costs can't be negative")
+	// ErrInvalidCostGroupBy is returned when the grouping of a cost
+	// report is unknown.
+	ErrInvalidCostGroupBy = errors.New("invalid group by, use \"job\" or \"namespace\"")
+	// ErrInvalidCostPeriod is returned when the period of a cost report
+	// is unknown.
+	ErrInvalidCostPeriod = errors.New("invalid period, use \"day\" or \"month\"")
+)
+
+// CostRate is the cost per minute of running on the nodes with its tags.
+type CostRate struct {
+	// Tags the nodes must have, any node when empty.
+	Tags map[string]string `mapstructure:"tags"`
+
+	// PerMinute is the cost of a minute of execution on the nodes.
+	PerMinute float64 `mapstructure:"per-minute"`
+}
+
+// validCostRates checks that the cost rates aren't negative.
+func validCostRates(rates []*CostRate) error {
+	for _, r := range rates {
+		if r == nil || r.PerMinute < 0 {
+			return ErrInvalidCost
+		}
+	}
+	return nil
+}
+
+// nodeCostRate returns the cost per minute of the node, the one of the
+// first rate whose tags the node has. Nodes that left the cluster only
+// match the rates without tags.
+func (a *Agent) nodeCostRate(nodeName string) float64 {
+	if len(a.config.CostRates) == 0 {
+		return 0
+	}
+
+	var tags map[string]string
+	if a.serf != nil {
+		for _, m := range a.serf.Members() {
+			if m.Name == nodeName {
+				tags = m.Tags
+				break
+			}
+		}
+	}
+
+	for _, r := range a.config.CostRates {
+		match := true
+		for k, v := range r.Tags {
+			if tags[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			return r.PerMinute
+		}
+	}
+	return 0
+}
+
+// executionCost returns the cost of the finished execution of the job,
+// its minutes at the rate of its node plus the cost per run of the job.
+func (a *Agent) executionCost(job *Job, pbe *dkronpb.Execution) float64 {
+	cost := job.CostPerRun
+	startedAt, err := ptypes.Timestamp(pbe.StartedAt)
+	if err != nil {
+		return cost
+	}
+	finishedAt, err := ptypes.Timestamp(pbe.FinishedAt)
+	if err != nil || finishedAt.Before(startedAt) {
+		return cost
+	}
+	return cost + finishedAt.Sub(startedAt).Minutes()*a.nodeCostRate(pbe.NodeName)
+}
+
+// CostReport is the cost of the executions of a job or a namespace in a
+// day or a month.
+type CostReport struct {
+	// Key of the group, the job or the namespace.
+	Key string `json:"key"`
+
+	// Period as YYYY-MM-DD or YYYY-MM, in UTC.
+	Period string `json:"period"`
+
+	Executions int     `json:"executions"`
+	Minutes    float64 `json:"minutes"`
+	Cost       float64 `json:"cost"`
+}
+
+// GetCostReport aggregates the cost of the executions that started
+// between since and until, when set, by job or namespace and by day or
+// month, sorted by period and key.
+func (s *Store) GetCostReport(groupBy, period string, since, until time.Time) ([]*CostReport, error) {
+	switch groupBy {
+	case CostByJob, CostByNamespace:
+	default:
+		return nil, ErrInvalidCostGroupBy
+	}
+	layout := "2006-01-02"
+	switch period {
+	case CostPerDay:
+	case CostPerMonth:
+		layout = "2006-01"
+	default:
+		return nil, ErrInvalidCostPeriod
+	}
+
+	jobs, err := s.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := map[string]string{}
+	for _, j := range jobs {
+		namespaces[j.Name] = j.Namespace()
+	}
+
+	groups := map[string]*CostReport{}
+	err = s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(executionsPrefix+":*", func(key, value string) bool {
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				err = fmt.Errorf("key %s: %s", key, err)
+				return false
+			}
+
+			startedAt, _ := ptypes.Timestamp(pbe.StartedAt)
+			finishedAt, ferr := ptypes.Timestamp(pbe.FinishedAt)
+			if pbe.FinishedAt == nil || ferr != nil || finishedAt.IsZero() {
+				return true
+			}
+			if (!since.IsZero() && startedAt.Before(since)) || (!until.IsZero() && !startedAt.Before(until)) {
+				return true
+			}
+
+			k := pbe.JobName
+			if groupBy == CostByNamespace {
+				k = namespaces[pbe.JobName]
+			}
+			p := startedAt.UTC().Format(layout)
+			report, ok := groups[p+"\x00"+k]
+			if !ok {
+				report = &CostReport{Key: k, Period: p}
+				groups[p+"\x00"+k] = report
+			}
+			report.Executions++
+			report.Minutes += finishedAt.Sub(startedAt).Minutes()
+			report.Cost += pbe.Cost
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*CostReport, 0, len(groups))
+	for _, r := range groups {
+		reports = append(reports, r)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		if reports[i].Period != reports[j].Period {
+			return reports[i].Period < reports[j].Period
+		}
+		return reports[i].Key < reports[j].Key
+	})
+	return reports, nil
+}
+
+// costsHandler serves the cost of the executions by job or namespace and
+// by day or month.
+func (h *HTTPTransport) costsHandler(c *gin.Context) {
+	var since, until time.Time
+	for param, t := range map[string]*time.Time{"since": &since, "until": &until} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			c.Writer.WriteString(fmt.Sprintf("Invalid %s, use RFC3339: %s", param, err))
+			return
+		}
+		*t = parsed
+	}
+
+	reports, err := h.agent.Store.GetCostReport(c.DefaultQuery("group_by", CostByNamespace), c.DefaultQuery("period", CostPerMonth), since, until)
+	if err == ErrInvalidCostGroupBy || err == ErrInvalidCostPeriod {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, reports)
+}
@@ -0,0 +1,80 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionCost(t *testing.T) {
+	c := DefaultConfig()
+	a := &Agent{config: c}
+	job := &Job{Name: "report", CostPerRun: 0.05}
+
+	now := time.Now()
+	ex := (&Execution{StartedAt: now, FinishedAt: now.Add(10 * time.Minute), NodeName: "gone"}).ToProto()
+	assert.Equal(t, 0.05, a.executionCost(job, ex))
+
+	// Nodes not in the cluster only match the rates without tags
+	c.CostRates = []*CostRate{
+		{Tags: map[string]string{"instance": "gpu"}, PerMinute: 0.5},
+		{PerMinute: 0.01},
+	}
+	assert.InDelta(t, 0.15, a.executionCost(job, ex), 1e-9)
+
+	job = scaffoldJob()
+	job.CostPerRun = -1
+	assert.Equal(t, ErrInvalidCost, job.Validate())
+	assert.Equal(t, ErrInvalidCost, validCostRates([]*CostRate{{PerMinute: -0.1}}))
+}
+
+func TestStore_GetCostReport(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.SetJob(&Job{Name: "etl", Schedule: "@every 1h", Executor: "shell", Disabled: true, Metadata: map[string]string{"namespace": "data"}}, false))
+	require.NoError(t, s.SetJob(&Job{Name: "backup", Schedule: "@every 1h", Executor: "shell", Disabled: true, Metadata: map[string]string{"namespace": "data"}}, false))
+	require.NoError(t, s.SetJob(&Job{Name: "billing", Schedule: "@every 1h", Executor: "shell", Disabled: true}, false))
+
+	march := time.Date(2020, 3, 31, 23, 0, 0, 0, time.UTC)
+	april := time.Date(2020, 4, 1, 1, 0, 0, 0, time.UTC)
+	for i, ex := range []*Execution{
+		{JobName: "etl", NodeName: "a", StartedAt: march, FinishedAt: march.Add(30 * time.Minute), Cost: 3},
+		{JobName: "backup", NodeName: "a", StartedAt: march, FinishedAt: march.Add(10 * time.Minute), Cost: 1},
+		{JobName: "etl", NodeName: "a", StartedAt: april, FinishedAt: april.Add(20 * time.Minute), Cost: 2},
+		{JobName: "billing", NodeName: "b", StartedAt: april, FinishedAt: april.Add(time.Minute), Cost: 0.5},
+		{JobName: "billing", NodeName: "b", StartedAt: april.Add(time.Hour)},
+	} {
+		ex.Group = int64(i)
+		ex.Attempt = 1
+		_, err := s.SetExecution(ex)
+		require.NoError(t, err)
+	}
+
+	byNamespace, err := s.GetCostReport(CostByNamespace, CostPerMonth, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, byNamespace, 3)
+	assert.Equal(t, &CostReport{Key: "data", Period: "2020-03", Executions: 2, Minutes: 40, Cost: 4}, byNamespace[0])
+	assert.Equal(t, &CostReport{Key: "data", Period: "2020-04", Executions: 1, Minutes: 20, Cost: 2}, byNamespace[1])
+	assert.Equal(t, &CostReport{Key: "default", Period: "2020-04", Executions: 1, Minutes: 1, Cost: 0.5}, byNamespace[2])
+
+	byJob, err := s.GetCostReport(CostByJob, CostPerDay, april, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, byJob, 2)
+	assert.Equal(t, "billing", byJob[0].Key)
+	assert.Equal(t, "2020-04-01", byJob[0].Period)
+	assert.Equal(t, "etl", byJob[1].Key)
+
+	_, err = s.GetCostReport("node", CostPerDay, time.Time{}, time.Time{})
+	assert.Equal(t, ErrInvalidCostGroupBy, err)
+	_, err = s.GetCostReport(CostByJob, "year", time.Time{}, time.Time{})
+	assert.Equal(t, ErrInvalidCostPeriod, err)
+
+	summaries, err := s.GetExecutionSummary("etl", SummaryByStatus, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, float64(5), summaries[0].Cost)
+}
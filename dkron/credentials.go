@@ -0,0 +1,479 @@
+package dkron
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+// credentialPrefix is the key prefix of the credentials.
+const credentialPrefix = "credential"
+
+// Types of credentials, the type decides the environment variables the
+// executor gets besides the path of the file.
+const (
+	CredentialSSHKey     = "ssh-key"
+	CredentialAWSProfile = "aws-profile"
+	CredentialKeytab     = "kerberos-keytab"
+	CredentialFile       = "file"
+)
+
+var (
+	// ErrInvalidCredential is returned when a credential is not valid.
+	ErrInvalidCredential = errors.New("invalid credential")
+	// ErrNoCredentialsKey is returned when managing credentials without
+	// the key encrypting them configured.
+	ErrNoCredentialsKey = errors.New("credentials-key is not configured")
+	// ErrCredentialNotAllowed is returned when a job uses a credential
+	// that doesn't exist or doesn't allow the job.
+	ErrCredentialNotAllowed = errors.New("credential doesn't exist or doesn't allow the job")
+	// ErrCredentialInUse is returned when deleting a credential jobs use.
+	ErrCredentialInUse = errors.New("credential is in use by jobs")
+
+	credentialName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+	// credentialTypeEnv are the environment variables set to the path of
+	// the file of the credentials of each type.
+	credentialTypeEnv = map[string]string{
+		CredentialSSHKey:     "",
+		CredentialAWSProfile: "AWS_SHARED_CREDENTIALS_FILE",
+		CredentialKeytab:     "KRB5_CLIENT_KTNAME",
+		CredentialFile:       "",
+	}
+)
+
+// Credential is a secret file, like an SSH key or a cloud profile, the
+// servers keep encrypted and deliver to the agent running a job using it
+// only for the duration of the run.
+type Credential struct {
+	// Name the jobs use the credential by.
+	Name string `json:"name"`
+
+	// Type of the credential: ssh-key, aws-profile, kerberos-keytab or file.
+	Type string `json:"type"`
+
+	// Data of the credential, write only, encrypted in the store.
+	Data []byte `json:"data,omitempty"`
+
+	// Name patterns of the jobs allowed to use the credential, * matches
+	// any sequence of characters.
+	Jobs []string `json:"jobs"`
+
+	// Who changed the credential last and when, set by the server.
+	Author    string    `json:"author"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewCredentialFromProto returns a new Credential from a proto.
+func NewCredentialFromProto(in *dkronpb.Credential) *Credential {
+	c := &Credential{
+		Name:   in.Name,
+		Type:   in.Type,
+		Data:   in.Data,
+		Jobs:   in.Jobs,
+		Author: in.Author,
+	}
+	if in.UpdatedAt != nil {
+		c.UpdatedAt, _ = ptypes.Timestamp(in.UpdatedAt)
+	}
+	return c
+}
+
+// ToProto returns the protobuf struct corresponding to the credential.
+func (c *Credential) ToProto() *dkronpb.Credential {
+	pbc := &dkronpb.Credential{
+		Name:   c.Name,
+		Type:   c.Type,
+		Data:   c.Data,
+		Jobs:   c.Jobs,
+		Author: c.Author,
+	}
+	pbc.UpdatedAt, _ = ptypes.TimestampProto(c.UpdatedAt)
+	return pbc
+}
+
+// Validate checks the credential definition.
+func (c *Credential) Validate() error {
+	if !credentialName.MatchString(c.Name) {
+		return fmt.Errorf("%s: name must be lowercase letters, digits, - and _", ErrInvalidCredential)
+	}
+	if _, ok := credentialTypeEnv[c.Type]; !ok {
+		return fmt.Errorf("%s: unknown type %q", ErrInvalidCredential, c.Type)
+	}
+	if len(c.Data) == 0 {
+		return fmt.Errorf("%s: data is required", ErrInvalidCredential)
+	}
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("%s: jobs allowed to use it are required", ErrInvalidCredential)
+	}
+	for _, p := range c.Jobs {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("%s: invalid job pattern %q: %s", ErrInvalidCredential, p, err)
+		}
+	}
+	return nil
+}
+
+// allows returns whether the job can use the credential.
+func (c *Credential) allows(job *Job) bool {
+	for _, p := range c.Jobs {
+		if ok, _ := path.Match(p, job.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCredentials checks that the credentials of the job are named
+// once each.
+func (j *Job) validateCredentials() error {
+	names := map[string]bool{}
+	for _, n := range j.Credentials {
+		if !credentialName.MatchString(n) {
+			return fmt.Errorf("%s: invalid name %q", ErrInvalidCredential, n)
+		}
+		if names[n] {
+			return fmt.Errorf("%s: duplicated credential %s", ErrInvalidCredential, n)
+		}
+		names[n] = true
+	}
+	return nil
+}
+
+// credentialsCipher returns the cipher of the credentials, AES-256-GCM
+// with the base64 encoded key of the config.
+func credentialsCipher(c *Config) (cipher.AEAD, error) {
+	if c.CredentialsKey == "" {
+		return nil, ErrNoCredentialsKey
+	}
+	key, err := base64.StdEncoding.DecodeString(c.CredentialsKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, errors.New("credentials-key must be a base64 encoded 32 byte key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealCredential encrypts the data of the credential, bound to its name.
+func sealCredential(aead cipher.AEAD, cred *Credential) error {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	cred.Data = aead.Seal(nonce, nonce, cred.Data, []byte(cred.Name))
+	return nil
+}
+
+// openCredential decrypts the data of the credential.
+func openCredential(aead cipher.AEAD, cred *Credential) error {
+	if len(cred.Data) < aead.NonceSize() {
+		return fmt.Errorf("%s: data of %s is not encrypted", ErrInvalidCredential, cred.Name)
+	}
+	nonce, data := cred.Data[:aead.NonceSize()], cred.Data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, data, []byte(cred.Name))
+	if err != nil {
+		return fmt.Errorf("%s: can't decrypt %s, %s", ErrInvalidCredential, cred.Name, err)
+	}
+	cred.Data = plain
+	return nil
+}
+
+// checkJobCredentials checks that the credentials of the job exist and
+// allow it.
+func (a *Agent) checkJobCredentials(job *Job) error {
+	for _, name := range job.Credentials {
+		cred, err := a.Store.GetCredential(name)
+		if err == buntdb.ErrNotFound || (err == nil && !cred.allows(job)) {
+			return fmt.Errorf("%s: %s", ErrCredentialNotAllowed, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobCredentials returns the decrypted credentials of the job, to deliver
+// to the agent running it.
+func (a *Agent) jobCredentials(job *Job) ([]*dkronpb.Credential, error) {
+	if len(job.Credentials) == 0 {
+		return nil, nil
+	}
+	if err := a.checkJobCredentials(job); err != nil {
+		return nil, err
+	}
+	aead, err := credentialsCipher(a.config)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds []*dkronpb.Credential
+	for _, name := range job.Credentials {
+		cred, err := a.Store.GetCredential(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := openCredential(aead, cred); err != nil {
+			return nil, err
+		}
+		creds = append(creds, &dkronpb.Credential{Name: cred.Name, Type: cred.Type, Data: cred.Data})
+	}
+	return creds, nil
+}
+
+// credentialFiles are the credentials of a run written by the agent.
+type credentialFiles struct {
+	dir string
+	// env are the environment variables pointing to the files.
+	env map[string]string
+	// contents are the data of the credentials, redacted from the output.
+	contents map[string]string
+}
+
+// writeCredentials writes the credentials to files only the agent user
+// can read, returning the environment variables pointing to them.
+func writeCredentials(creds []*dkronpb.Credential) (*credentialFiles, error) {
+	if len(creds) == 0 {
+		return nil, nil
+	}
+	dir, err := ioutil.TempDir("", "dkron-credentials-")
+	if err != nil {
+		return nil, err
+	}
+
+	cf := &credentialFiles{
+		dir:      dir,
+		env:      map[string]string{},
+		contents: map[string]string{},
+	}
+	for _, c := range creds {
+		file := filepath.Join(dir, c.Name)
+		if err := ioutil.WriteFile(file, c.Data, 0600); err != nil {
+			cf.wipe()
+			return nil, err
+		}
+		cf.contents[c.Name] = string(c.Data)
+
+		cf.env["DKRON_CREDENTIAL_"+strings.ToUpper(strings.ReplaceAll(c.Name, "-", "_"))] = file
+		// The first credential of a type sets its variables
+		if c.Type == CredentialSSHKey {
+			if _, ok := cf.env["GIT_SSH_COMMAND"]; !ok {
+				cf.env["GIT_SSH_COMMAND"] = fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes", file)
+			}
+		}
+		if name := credentialTypeEnv[c.Type]; name != "" {
+			if _, ok := cf.env[name]; !ok {
+				cf.env[name] = file
+			}
+		}
+	}
+	return cf, nil
+}
+
+// mergeEnv returns the variables of both maps, the ones of b win.
+func mergeEnv(a, b map[string]string) map[string]string {
+	merged := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
+
+// wipe overwrites the files of the credentials with zeros and removes
+// them.
+func (cf *credentialFiles) wipe() {
+	if cf == nil {
+		return
+	}
+	for name, data := range cf.contents {
+		file := filepath.Join(cf.dir, name)
+		if err := ioutil.WriteFile(file, make([]byte, len(data)), 0600); err != nil {
+			log.WithError(err).WithField("credential", name).Error("grpc_agent: Error wiping credential")
+		}
+	}
+	if err := os.RemoveAll(cf.dir); err != nil {
+		log.WithError(err).Error("grpc_agent: Error removing credentials directory")
+	}
+}
+
+// SetCredential stores a credential, replacing the one of the same name.
+func (s *Store) SetCredential(cred *Credential) error {
+	if err := cred.Validate(); err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(cred.ToProto())
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(fmt.Sprintf("%s:%s", credentialPrefix, cred.Name), string(b), nil)
+		return err
+	})
+}
+
+// DeleteCredential deletes a credential.
+func (s *Store) DeleteCredential(name string) (*Credential, error) {
+	var cred *Credential
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		value, err := tx.Delete(fmt.Sprintf("%s:%s", credentialPrefix, name))
+		if err != nil {
+			return err
+		}
+		var pbc dkronpb.Credential
+		if err := proto.Unmarshal([]byte(value), &pbc); err != nil {
+			return err
+		}
+		cred = NewCredentialFromProto(&pbc)
+		return nil
+	})
+	return cred, err
+}
+
+// GetCredential returns the credential with its encrypted data.
+func (s *Store) GetCredential(name string) (*Credential, error) {
+	var cred *Credential
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(fmt.Sprintf("%s:%s", credentialPrefix, name))
+		if err != nil {
+			return err
+		}
+		var pbc dkronpb.Credential
+		if err := proto.Unmarshal([]byte(value), &pbc); err != nil {
+			return err
+		}
+		cred = NewCredentialFromProto(&pbc)
+		return nil
+	})
+	return cred, err
+}
+
+// GetCredentials returns the credentials sorted by name, without their data.
+func (s *Store) GetCredentials() ([]*Credential, error) {
+	creds := []*Credential{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(credentialPrefix+":*", func(key, value string) bool {
+			var pbc dkronpb.Credential
+			if err = proto.Unmarshal([]byte(value), &pbc); err != nil {
+				return false
+			}
+			cred := NewCredentialFromProto(&pbc)
+			cred.Data = nil
+			creds = append(creds, cred)
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+func (h *HTTPTransport) credentialsHandler(c *gin.Context) {
+	creds, err := h.agent.Store.GetCredentials()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, creds)
+}
+
+func (h *HTTPTransport) credentialSetHandler(c *gin.Context) {
+	var cred Credential
+	if err := c.BindJSON(&cred); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	aead, err := credentialsCipher(h.agent.config)
+	if err != nil {
+		c.AbortWithStatus(http.StatusServiceUnavailable)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	cred.Author = requestUser(c)
+	cred.UpdatedAt = time.Now()
+	if err := cred.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Credential contains invalid value: %s.", err))
+		return
+	}
+	if err := sealCredential(aead, &cred); err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	// Call gRPC SetCredential
+	if err := h.agent.GRPCClient.SetCredential(&cred); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		c.Writer.WriteString(status.Convert(err).Message())
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"credential": cred.Name,
+		"type":       cred.Type,
+		"author":     cred.Author,
+	}).Info("api: Credential set")
+	cred.Data = nil
+	renderJSON(c, http.StatusCreated, &cred)
+}
+
+func (h *HTTPTransport) credentialDeleteHandler(c *gin.Context) {
+	// Call gRPC DeleteCredential
+	cred, err := h.agent.GRPCClient.DeleteCredential(c.Param("credential"))
+	if err != nil {
+		s := status.Convert(err)
+		switch {
+		case s.Message() == buntdb.ErrNotFound.Error():
+			c.AbortWithStatus(http.StatusNotFound)
+		case strings.HasPrefix(s.Message(), ErrCredentialInUse.Error()):
+			c.AbortWithStatus(http.StatusConflict)
+		default:
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"credential": cred.Name,
+		"author":     requestUser(c),
+	}).Info("api: Credential deleted")
+	cred.Data = nil
+	renderJSON(c, http.StatusOK, cred)
+}
@@ -0,0 +1,175 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// DeadlineSkipDependents skips the dependent jobs of runs finishing
+	// after the deadline, the default.
+	DeadlineSkipDependents = "skip-dependents"
+	// DeadlineRunDependents runs the dependent jobs of late runs anyway.
+	DeadlineRunDependents = "run-dependents"
+
+	// deadlineCheckInterval is how often the leader looks for runs past
+	// their deadline.
+	deadlineCheckInterval = 30 * time.Second
+)
+
+// ErrInvalidDeadline is returned when the deadline of a job is not valid.
+var ErrInvalidDeadline = errors.New("invalid deadline")
+
+func (j *Job) validateDeadline() error {
+	if j.Deadline != "" {
+		d, err := time.ParseDuration(j.Deadline)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s: %q is not a positive duration", ErrInvalidDeadline, j.Deadline)
+		}
+	}
+	switch j.DeadlineAction {
+	case "", DeadlineSkipDependents, DeadlineRunDependents:
+	default:
+		return fmt.Errorf("%s: unknown action %q", ErrInvalidDeadline, j.DeadlineAction)
+	}
+	return nil
+}
+
+// groupStart returns the time the run of the execution group was scheduled
+// at, or started for manual runs. Dependent jobs keep the scheduled time of
+// the run that triggered them.
+func groupStart(ex *Execution) time.Time {
+	if ex.ScheduledAt.IsZero() {
+		return time.Unix(0, ex.Group)
+	}
+	return ex.ScheduledAt
+}
+
+// groupDeadline returns the time the execution group must be finished by.
+func (j *Job) groupDeadline(ex *Execution) time.Time {
+	d, _ := time.ParseDuration(j.Deadline)
+	return groupStart(ex).Add(d)
+}
+
+// groupFinished returns the last execution of the execution group once
+// its run is over, no execution is running nor will be retried, nil
+// otherwise.
+func groupFinished(exg []*Execution, retries uint) *Execution {
+	var last *Execution
+	for _, ex := range exg {
+		if ex.FinishedAt.IsZero() {
+			return nil
+		}
+		if last == nil || ex.FinishedAt.After(last.FinishedAt) {
+			last = ex
+		}
+	}
+	if last == nil || (!last.Success && !last.Skipped && last.Attempt < retries+1) {
+		return nil
+	}
+	return last
+}
+
+// pastDeadline returns whether the execution group of the job missed its
+// deadline at now, either still running or finished late.
+func (j *Job) pastDeadline(exg []*Execution, now time.Time) bool {
+	if j.Deadline == "" || len(exg) == 0 {
+		return false
+	}
+	deadline := j.groupDeadline(exg[0])
+	if last := groupFinished(exg, j.Retries); last != nil {
+		return last.FinishedAt.After(deadline)
+	}
+	return now.After(deadline)
+}
+
+// monitorDeadlines notifies the runs missing their deadline while this
+// agent is the leader.
+func (a *Agent) monitorDeadlines(stopCh chan struct{}) {
+	ticker := time.NewTicker(deadlineCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		case <-a.shutdownCh:
+			return
+		}
+
+		if err := a.checkDeadlines(time.Now()); err != nil {
+			log.WithError(err).Error("leader: Error checking the deadlines of the jobs")
+		}
+	}
+}
+
+// checkDeadlines breaches the last runs of the jobs past their deadline.
+func (a *Agent) checkDeadlines(now time.Time) error {
+	jobs, err := a.Store.GetJobs(nil)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Deadline == "" {
+			continue
+		}
+		exg, err := a.Store.GetLastExecutionGroup(job.Name)
+		if err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+		if len(exg) == 0 || exg[0].Group == job.BreachedGroup || !job.pastDeadline(exg, now) {
+			continue
+		}
+		if err := a.breachDeadline(a.withNamespaceDefaults(job), exg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// breachDeadline records that the execution group of the job missed its
+// deadline, so it's notified once, and notifies it.
+func (a *Agent) breachDeadline(job *Job, exg []*Execution) error {
+	ex := exg[0]
+	for _, e := range exg {
+		if e.StartedAt.After(ex.StartedAt) {
+			ex = e
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"job":      job.Name,
+		"group":    ex.Group,
+		"deadline": job.groupDeadline(ex),
+	}).Warn("leader: Job missed its deadline")
+	metrics.IncrCounterWithLabels([]string{"job", "deadline_breached"}, 1, []metrics.Label{
+		{Name: "job", Value: job.Name},
+		{Name: "namespace", Value: job.Namespace()},
+	})
+
+	// The job can have namespace defaults that aren't stored
+	stored, err := a.Store.GetJob(job.Name, nil)
+	if err != nil {
+		return err
+	}
+	stored.BreachedGroup = ex.Group
+	if err := a.applySetJob(stored.ToProto()); err != nil {
+		return err
+	}
+
+	if silence := a.silenced(job, time.Now()); silence != nil {
+		return nil
+	}
+	n := Notification(a.notificationConfig(job), ex, exg, job)
+	n.Deadline = job.groupDeadline(ex)
+	if err := n.Send(); err != nil {
+		log.WithError(err).WithField("job", job.Name).Error("leader: Error notifying the missed deadline")
+	}
+	return nil
+}
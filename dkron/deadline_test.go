@@ -0,0 +1,72 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPastDeadline(t *testing.T) {
+	job := scaffoldJob()
+	job.Deadline = "1h"
+	job.Retries = 1
+	require.NoError(t, job.Validate())
+
+	scheduled := time.Date(2026, 10, 15, 6, 0, 0, 0, time.UTC)
+	ex := &Execution{JobName: job.Name, Group: 1, Attempt: 1, ScheduledAt: scheduled, StartedAt: scheduled}
+	exg := []*Execution{ex}
+
+	// Running
+	assert.False(t, job.pastDeadline(exg, scheduled.Add(30*time.Minute)))
+	assert.True(t, job.pastDeadline(exg, scheduled.Add(61*time.Minute)))
+
+	// Failed but retried
+	ex.FinishedAt = scheduled.Add(10 * time.Minute)
+	assert.True(t, job.pastDeadline(exg, scheduled.Add(61*time.Minute)))
+
+	// Finished in time, no matter when it's checked
+	ex.Success = true
+	assert.False(t, job.pastDeadline(exg, scheduled.Add(2*time.Hour)))
+	ex.FinishedAt = scheduled.Add(70 * time.Minute)
+	assert.True(t, job.pastDeadline(exg, scheduled.Add(2*time.Hour)))
+
+	job.Deadline = "-1h"
+	assert.Error(t, job.Validate())
+	job.Deadline = "1h"
+	job.DeadlineAction = "wait"
+	assert.Error(t, job.Validate())
+}
+
+func TestCheckDeadlines(t *testing.T) {
+	dir, a := setupAPITest(t, "8137")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:     "extract",
+		Schedule: "@every 1h",
+		Executor: "shell",
+		Deadline: "30m",
+	}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	now := time.Now()
+	ex := &Execution{JobName: job.Name, Group: now.Add(-time.Hour).UnixNano(), Attempt: 1, NodeName: "test", StartedAt: now.Add(-time.Hour)}
+	_, err := a.Store.SetExecution(ex)
+	require.NoError(t, err)
+
+	require.NoError(t, a.checkDeadlines(now))
+	stored, err := a.Store.GetJob(job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ex.Group, stored.BreachedGroup)
+
+	// Updating the job keeps the breached group, it's notified once
+	job.Schedule = "@every 2h"
+	require.NoError(t, a.Store.SetJob(job, false))
+	stored, err = a.Store.GetJob(job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ex.Group, stored.BreachedGroup)
+}
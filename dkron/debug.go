@@ -0,0 +1,133 @@
+package dkron
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/gin-contrib/expvar"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/raft"
+)
+
+// DebugState is a snapshot of the runtime and cluster state of a server.
+type DebugState struct {
+	NodeName string    `json:"node_name"`
+	Version  string    `json:"version"`
+	Time     time.Time `json:"time"`
+
+	// Leadership and raft state
+	Leader      bool              `json:"leader"`
+	LeaderAddr  string            `json:"leader_addr"`
+	Raft        map[string]string `json:"raft"`
+	RaftServers []raft.Server     `json:"raft_servers"`
+
+	// Serf members and their status
+	Members map[string]string `json:"members"`
+
+	// Clock skew of the members measured by the leader
+	ClockSkew map[string]string `json:"clock_skew"`
+
+	// Scheduler state
+	SchedulerStarted bool `json:"scheduler_started"`
+	ScheduledJobs    int  `json:"scheduled_jobs"`
+	ActiveExecutions int  `json:"active_executions"`
+
+	// Runtime state
+	Goroutines int    `json:"goroutines"`
+	HeapAlloc  uint64 `json:"heap_alloc"`
+	Sys        uint64 `json:"sys"`
+	NumGC      uint32 `json:"num_gc"`
+}
+
+// debugRoutes registers the diagnostics endpoints, they require the admin token.
+func (h *HTTPTransport) debugRoutes(r *gin.RouterGroup) {
+	debug := r.Group("/debug", h.adminMiddleware())
+	debug.GET("/vars", expvar.Handler())
+	debug.GET("/state", h.debugStateHandler)
+	debug.GET("/pprof/*profile", pprofHandler)
+	debug.POST("/pprof/*profile", pprofHandler)
+}
+
+// adminMiddleware aborts the requests without the admin token.
+func (h *HTTPTransport) adminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !h.isAdmin(c) {
+			c.AbortWithStatus(http.StatusForbidden)
+			c.Writer.WriteString(ErrAdminTokenRequired.Error())
+			return
+		}
+		c.Next()
+	}
+}
+
+// pprofHandler serves the net/http/pprof handlers under /debug/pprof.
+func pprofHandler(c *gin.Context) {
+	switch c.Param("profile") {
+	case "/cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "/profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "/symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "/trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		// Index serves the named profiles too
+		pprof.Index(c.Writer, c.Request)
+	}
+}
+
+func (h *HTTPTransport) debugStateHandler(c *gin.Context) {
+	renderJSON(c, http.StatusOK, h.agent.debugState())
+}
+
+// debugState returns the current state of the agent.
+func (a *Agent) debugState() *DebugState {
+	state := &DebugState{
+		NodeName:  a.config.NodeName,
+		Version:   Version,
+		Time:      time.Now(),
+		Members:   map[string]string{},
+		ClockSkew: map[string]string{},
+	}
+
+	if a.raft != nil {
+		state.Leader = a.IsLeader()
+		state.LeaderAddr = string(a.raft.Leader())
+		state.Raft = a.raft.Stats()
+		if f := a.raft.GetConfiguration(); f.Error() == nil {
+			state.RaftServers = f.Configuration().Servers
+		}
+	}
+
+	if a.serf != nil {
+		for _, m := range a.serf.Members() {
+			state.Members[m.Name] = m.Status.String()
+		}
+	}
+
+	a.clockSkews.Range(func(k, v interface{}) bool {
+		state.ClockSkew[k.(string)] = v.(time.Duration).String()
+		return true
+	})
+
+	if a.sched != nil && a.sched.Started {
+		state.SchedulerStarted = true
+		state.ScheduledJobs = len(a.sched.Cron.Entries())
+	}
+	a.activeExecutions.Range(func(k, v interface{}) bool {
+		state.ActiveExecutions++
+		return true
+	})
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	state.Goroutines = runtime.NumGoroutine()
+	state.HeapAlloc = ms.HeapAlloc
+	state.Sys = ms.Sys
+	state.NumGC = ms.NumGC
+
+	return state
+}
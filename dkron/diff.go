@@ -0,0 +1,263 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxDiffLines bounds the output lines listed per node in a diff.
+const maxDiffLines = 20
+
+// exitStatusPattern matches the exit status of the commands reported at
+// the end of the output of failed executions.
+var exitStatusPattern = regexp.MustCompile(`exit status (\d+)`)
+
+// ErrNoExecutionGroup is returned when diffing execution groups a job
+// doesn't have.
+var ErrNoExecutionGroup = errors.New("execution group not found")
+
+// ExecutionDiff compares two execution groups of a job, like last night's
+// good run and tonight's bad one.
+type ExecutionDiff struct {
+	Job  string         `json:"job"`
+	From *GroupSnapshot `json:"from"`
+	To   *GroupSnapshot `json:"to"`
+
+	// Change of the duration of the groups, and whether the job spec
+	// changed between them.
+	DurationChange time.Duration `json:"duration_change"`
+	VersionChanged bool          `json:"version_changed"`
+
+	// Nodes that only ran one of the groups.
+	NodesAdded   []string `json:"nodes_added"`
+	NodesRemoved []string `json:"nodes_removed"`
+
+	// Output differences of the nodes that ran both groups.
+	Outputs []*OutputDiff `json:"outputs"`
+}
+
+// GroupSnapshot is the result of an execution group.
+type GroupSnapshot struct {
+	Group      int64         `json:"group"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	Success    bool          `json:"success"`
+	JobVersion int64         `json:"job_version,omitempty"`
+	Nodes      []*NodeResult `json:"nodes"`
+}
+
+// NodeResult is the last attempt of an execution group in a node.
+type NodeResult struct {
+	Node     string        `json:"node"`
+	Success  bool          `json:"success"`
+	Attempt  uint          `json:"attempt"`
+	Duration time.Duration `json:"duration"`
+	// Exit code of the command, when known.
+	ExitCode *int `json:"exit_code"`
+}
+
+// OutputDiff summarizes the lines of the output of a node that only one of
+// the groups has.
+type OutputDiff struct {
+	Node         string   `json:"node"`
+	Identical    bool     `json:"identical"`
+	Added        int      `json:"added"`
+	Removed      int      `json:"removed"`
+	AddedLines   []string `json:"added_lines"`
+	RemovedLines []string `json:"removed_lines"`
+}
+
+// exitCode returns the exit code of the execution, zero when it succeeded
+// and the last exit status of its output when it failed.
+func exitCode(ex *Execution) *int {
+	if ex.FinishedAt.IsZero() {
+		return nil
+	}
+	if ex.Success {
+		code := 0
+		return &code
+	}
+	m := exitStatusPattern.FindAllStringSubmatch(ex.Output, -1)
+	if len(m) == 0 {
+		return nil
+	}
+	code, err := strconv.Atoi(m[len(m)-1][1])
+	if err != nil {
+		return nil
+	}
+	return &code
+}
+
+func snapshotGroup(group int64, exg []*Execution) *GroupSnapshot {
+	snap := &GroupSnapshot{
+		Group:     group,
+		StartedAt: groupStart(exg[0]),
+		Success:   true,
+		Nodes:     []*NodeResult{},
+	}
+	var started, finished time.Time
+	for node, ex := range lastAttempts(exg) {
+		nr := &NodeResult{
+			Node:     node,
+			Success:  ex.Success,
+			Attempt:  ex.Attempt,
+			ExitCode: exitCode(ex),
+		}
+		if !ex.FinishedAt.IsZero() {
+			nr.Duration = ex.FinishedAt.Sub(ex.StartedAt)
+		}
+		snap.Nodes = append(snap.Nodes, nr)
+		snap.Success = snap.Success && ex.Success
+		if ex.JobVersion > snap.JobVersion {
+			snap.JobVersion = ex.JobVersion
+		}
+	}
+	for _, ex := range exg {
+		if started.IsZero() || ex.StartedAt.Before(started) {
+			started = ex.StartedAt
+		}
+		if ex.FinishedAt.After(finished) {
+			finished = ex.FinishedAt
+		}
+	}
+	if finished.After(started) {
+		snap.Duration = finished.Sub(started)
+	}
+	sort.Slice(snap.Nodes, func(i, j int) bool { return snap.Nodes[i].Node < snap.Nodes[j].Node })
+	return snap
+}
+
+// diffOutput counts the lines of each output the other one doesn't have,
+// regardless of their order.
+func diffOutput(node, from, to string) *OutputDiff {
+	d := &OutputDiff{Node: node, Identical: from == to, AddedLines: []string{}, RemovedLines: []string{}}
+	if d.Identical {
+		return d
+	}
+
+	counts := map[string]int{}
+	for _, l := range strings.Split(from, "\n") {
+		counts[l]++
+	}
+	for _, l := range strings.Split(to, "\n") {
+		if counts[l] > 0 {
+			counts[l]--
+			continue
+		}
+		d.Added++
+		if len(d.AddedLines) < maxDiffLines {
+			d.AddedLines = append(d.AddedLines, l)
+		}
+	}
+	for _, l := range strings.Split(from, "\n") {
+		if counts[l] == 0 {
+			continue
+		}
+		counts[l]--
+		d.Removed++
+		if len(d.RemovedLines) < maxDiffLines {
+			d.RemovedLines = append(d.RemovedLines, l)
+		}
+	}
+	return d
+}
+
+// BuildExecutionDiff compares the execution groups from and to of the job.
+// Without to the last group is compared, without from the last successful
+// group before to.
+func BuildExecutionDiff(s Storage, jobName string, from, to int64) (*ExecutionDiff, error) {
+	groups, byGroup, err := s.GetGroupedExecutions(jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Groups are sorted from the newest
+	if to == 0 && len(byGroup) > 0 {
+		to = byGroup[0]
+	}
+	if from == 0 {
+		for _, g := range byGroup {
+			if g >= to {
+				continue
+			}
+			if snapshotGroup(g, groups[g]).Success {
+				from = g
+				break
+			}
+		}
+	}
+	if len(groups[from]) == 0 || len(groups[to]) == 0 {
+		return nil, ErrNoExecutionGroup
+	}
+
+	diff := &ExecutionDiff{
+		Job:          jobName,
+		From:         snapshotGroup(from, groups[from]),
+		To:           snapshotGroup(to, groups[to]),
+		NodesAdded:   []string{},
+		NodesRemoved: []string{},
+		Outputs:      []*OutputDiff{},
+	}
+	diff.DurationChange = diff.To.Duration - diff.From.Duration
+	diff.VersionChanged = diff.From.JobVersion != diff.To.JobVersion
+
+	fromNodes, toNodes := lastAttempts(groups[from]), lastAttempts(groups[to])
+	for node, ex := range toNodes {
+		fex, ok := fromNodes[node]
+		if !ok {
+			diff.NodesAdded = append(diff.NodesAdded, node)
+			continue
+		}
+		diff.Outputs = append(diff.Outputs, diffOutput(node, fex.Output, ex.Output))
+	}
+	for node := range fromNodes {
+		if _, ok := toNodes[node]; !ok {
+			diff.NodesRemoved = append(diff.NodesRemoved, node)
+		}
+	}
+	sort.Strings(diff.NodesAdded)
+	sort.Strings(diff.NodesRemoved)
+	sort.Slice(diff.Outputs, func(i, j int) bool { return diff.Outputs[i].Node < diff.Outputs[j].Node })
+	return diff, nil
+}
+
+// executionDiffHandler compares the execution groups of the job given
+// with ?from=<group>&to=<group>.
+func (h *HTTPTransport) executionDiffHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	var groups [2]int64
+	for i, p := range []string{"from", "to"} {
+		if v := c.Query(p); v != "" {
+			g, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid %s group %q", p, v)})
+				return
+			}
+			groups[i] = g
+		}
+	}
+
+	diff, err := BuildExecutionDiff(h.agent.Store, job.Name, groups[0], groups[1])
+	if err == ErrNoExecutionGroup {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, diff)
+}
@@ -0,0 +1,74 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildExecutionDiff(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.SetJob(&Job{Name: "report", Schedule: "@every 1h", Executor: "shell", Disabled: true}, false))
+
+	night := time.Date(2020, 3, 10, 2, 0, 0, 0, time.UTC)
+	tonight := night.Add(24 * time.Hour)
+	for _, ex := range []*Execution{
+		// Good run
+		{JobName: "report", Group: 1, NodeName: "a", StartedAt: night, FinishedAt: night.Add(time.Minute), Success: true, JobVersion: 1,
+			Output: "loading\nrows: 100\ndone"},
+		{JobName: "report", Group: 1, NodeName: "b", StartedAt: night, FinishedAt: night.Add(time.Minute), Success: true, JobVersion: 1},
+		// Failed run
+		{JobName: "report", Group: 2, NodeName: "a", StartedAt: night.Add(time.Hour), FinishedAt: night.Add(time.Hour + time.Minute), JobVersion: 1},
+		// Bad run, retried
+		{JobName: "report", Group: 3, NodeName: "a", StartedAt: tonight, FinishedAt: tonight.Add(time.Minute), JobVersion: 2,
+			Output: "loading\nexit status 1"},
+		{JobName: "report", Group: 3, NodeName: "a", Attempt: 2, StartedAt: tonight.Add(2 * time.Minute), FinishedAt: tonight.Add(5 * time.Minute), JobVersion: 2,
+			Output: "loading\ntimeout\nexit status 2"},
+		{JobName: "report", Group: 3, NodeName: "c", StartedAt: tonight, FinishedAt: tonight.Add(time.Minute), Success: true, JobVersion: 2},
+	} {
+		if ex.Attempt == 0 {
+			ex.Attempt = 1
+		}
+		_, err := s.SetExecution(ex)
+		require.NoError(t, err)
+	}
+
+	// The last group against the last successful one
+	diff, err := BuildExecutionDiff(s, "report", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), diff.From.Group)
+	assert.Equal(t, int64(3), diff.To.Group)
+	assert.True(t, diff.From.Success)
+	assert.False(t, diff.To.Success)
+	assert.Equal(t, 4*time.Minute, diff.DurationChange)
+	assert.True(t, diff.VersionChanged)
+	assert.Equal(t, []string{"c"}, diff.NodesAdded)
+	assert.Equal(t, []string{"b"}, diff.NodesRemoved)
+
+	require.Len(t, diff.To.Nodes, 2)
+	assert.Equal(t, uint(2), diff.To.Nodes[0].Attempt)
+	require.NotNil(t, diff.To.Nodes[0].ExitCode)
+	assert.Equal(t, 2, *diff.To.Nodes[0].ExitCode)
+	require.NotNil(t, diff.To.Nodes[1].ExitCode)
+	assert.Equal(t, 0, *diff.To.Nodes[1].ExitCode)
+
+	require.Len(t, diff.Outputs, 1)
+	out := diff.Outputs[0]
+	assert.Equal(t, "a", out.Node)
+	assert.False(t, out.Identical)
+	assert.Equal(t, []string{"timeout", "exit status 2"}, out.AddedLines)
+	assert.Equal(t, []string{"rows: 100", "done"}, out.RemovedLines)
+
+	diff, err = BuildExecutionDiff(s, "report", 2, 1)
+	require.NoError(t, err)
+	assert.False(t, diff.VersionChanged)
+	assert.Nil(t, diff.From.Nodes[0].ExitCode)
+
+	_, err = BuildExecutionDiff(s, "report", 4, 0)
+	assert.Equal(t, ErrNoExecutionGroup, err)
+}
@@ -0,0 +1,317 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	"github.com/jordan-wright/email"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// digestTopJobs is the number of jobs listed as the slowest ones.
+	digestTopJobs = 5
+	// digestUpcomingRuns is the number of upcoming runs listed.
+	digestUpcomingRuns = 10
+)
+
+var digestTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"time": func(t time.Time) string { return t.Format("2006-01-02 15:04 MST") },
+}).Parse(`Activity of namespace {{.Namespace}} from {{time .From}} to {{time .To}}
+
+Executions: {{.Executions}}, failed: {{.Failures}}, success rate: {{printf "%.1f" .SuccessRate}}%
+
+Success rates:
+{{range .Jobs}}  {{.Name}}: {{printf "%.1f" .SuccessRate}}% of {{.Executions}} executions
+{{else}}  No executions
+{{end}}
+New failures:
+{{range .NewFailures}}  {{.Name}}: {{.Failures}} failed executions, last at {{time .LastFailure}}
+{{else}}  None
+{{end}}
+Slowest jobs:
+{{range .Slowest}}  {{.Name}}: {{.MaxDuration}}
+{{else}}  None
+{{end}}
+Upcoming runs:
+{{range .Upcoming}}  {{.Job}}: {{time .Next}}
+{{else}}  None
+{{end}}{{if .Disabled}}
+Disabled jobs:
+{{range .Disabled}}  {{.}}
+{{end}}{{end}}`))
+
+// Digest summarizes the activity of the jobs of a namespace over a period.
+type Digest struct {
+	Namespace string    `json:"namespace"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+
+	// Executions and failures of all the jobs in the period.
+	Executions  int     `json:"executions"`
+	Failures    int     `json:"failures"`
+	SuccessRate float64 `json:"success_rate"`
+
+	// Jobs that run in the period, with the lowest success rate first.
+	Jobs []*DigestJob `json:"jobs"`
+
+	// Jobs that failed in the period but not in the previous one.
+	NewFailures []*DigestJob `json:"new_failures"`
+
+	// Jobs with the longest executions in the period.
+	Slowest []*DigestJob `json:"slowest"`
+
+	// Next runs of the jobs in the following period.
+	Upcoming []*DigestRun `json:"upcoming"`
+
+	// Disabled jobs, that won't run until enabled.
+	Disabled []string `json:"disabled"`
+}
+
+// DigestJob is the activity of a job in a digest.
+type DigestJob struct {
+	Name        string        `json:"name"`
+	Executions  int           `json:"executions"`
+	Failures    int           `json:"failures"`
+	SuccessRate float64       `json:"success_rate"`
+	MaxDuration time.Duration `json:"max_duration"`
+	LastFailure time.Time     `json:"last_failure,omitempty"`
+}
+
+// DigestRun is an upcoming run of a job.
+type DigestRun struct {
+	Job  string    `json:"job"`
+	Next time.Time `json:"next"`
+}
+
+// BuildDigests returns the activity digest of every namespace for the period ending at to.
+func BuildDigests(store Storage, to time.Time, period time.Duration) ([]*Digest, error) {
+	jobs, err := store.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	from := to.Add(-period)
+	digests := map[string]*Digest{}
+
+	for _, job := range jobs {
+		ns := job.Namespace()
+		d, ok := digests[ns]
+		if !ok {
+			d = &Digest{
+				Namespace:   ns,
+				From:        from,
+				To:          to,
+				Jobs:        []*DigestJob{},
+				NewFailures: []*DigestJob{},
+				Slowest:     []*DigestJob{},
+				Upcoming:    []*DigestRun{},
+				Disabled:    []string{},
+			}
+			digests[ns] = d
+		}
+
+		if job.Disabled {
+			d.Disabled = append(d.Disabled, job.Name)
+		} else if job.ParentJob == "" {
+			if s, err := extcron.Parse(job.cronSchedule()); err == nil {
+				if next := s.Next(to); !next.IsZero() && next.Before(to.Add(period)) {
+					d.Upcoming = append(d.Upcoming, &DigestRun{Job: job.Name, Next: next})
+				}
+			}
+		}
+
+		executions, err := store.GetExecutions(job.Name)
+		if err != nil && err != buntdb.ErrNotFound {
+			return nil, err
+		}
+
+		dj := &DigestJob{Name: job.Name}
+		var previousFailures int
+		for _, ex := range executions {
+			if ex.StartedAt.Before(from.Add(-period)) || !ex.StartedAt.Before(to) {
+				continue
+			}
+			// Skipped runs are neither successful nor failed
+			if ex.Skipped {
+				continue
+			}
+			if ex.StartedAt.Before(from) {
+				if !ex.Success {
+					previousFailures++
+				}
+				continue
+			}
+
+			dj.Executions++
+			if !ex.Success {
+				dj.Failures++
+				if ex.StartedAt.After(dj.LastFailure) {
+					dj.LastFailure = ex.StartedAt
+				}
+			}
+			if !ex.FinishedAt.IsZero() {
+				if duration := ex.FinishedAt.Sub(ex.StartedAt); duration > dj.MaxDuration {
+					dj.MaxDuration = duration
+				}
+			}
+		}
+		if dj.Executions == 0 {
+			continue
+		}
+
+		dj.SuccessRate = successRate(dj.Executions, dj.Failures)
+		d.Jobs = append(d.Jobs, dj)
+		d.Executions += dj.Executions
+		d.Failures += dj.Failures
+		if dj.Failures > 0 && previousFailures == 0 {
+			d.NewFailures = append(d.NewFailures, dj)
+		}
+	}
+
+	result := make([]*Digest, 0, len(digests))
+	for _, d := range digests {
+		d.SuccessRate = successRate(d.Executions, d.Failures)
+
+		sort.SliceStable(d.Jobs, func(i, j int) bool {
+			return d.Jobs[i].SuccessRate < d.Jobs[j].SuccessRate
+		})
+
+		for _, dj := range d.Jobs {
+			if dj.MaxDuration > 0 {
+				d.Slowest = append(d.Slowest, dj)
+			}
+		}
+		sort.SliceStable(d.Slowest, func(i, j int) bool {
+			return d.Slowest[i].MaxDuration > d.Slowest[j].MaxDuration
+		})
+		if len(d.Slowest) > digestTopJobs {
+			d.Slowest = d.Slowest[:digestTopJobs]
+		}
+
+		sort.SliceStable(d.Upcoming, func(i, j int) bool {
+			return d.Upcoming[i].Next.Before(d.Upcoming[j].Next)
+		})
+		if len(d.Upcoming) > digestUpcomingRuns {
+			d.Upcoming = d.Upcoming[:digestUpcomingRuns]
+		}
+
+		result = append(result, d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+
+	return result, nil
+}
+
+// successRate returns the percentage of successful executions.
+func successRate(executions, failures int) float64 {
+	if executions == 0 {
+		return 0
+	}
+	return float64(executions-failures) * 100 / float64(executions)
+}
+
+// Text renders the digest as plain text.
+func (d *Digest) Text() (string, error) {
+	var out bytes.Buffer
+	if err := digestTemplate.Execute(&out, d); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// digestRecipients returns the addresses receiving the digest of the namespace.
+func digestRecipients(mailTo []string, namespace string) []string {
+	recipients := []string{}
+	for _, r := range mailTo {
+		kv := strings.SplitN(r, "=", 2)
+		if len(kv) == 1 {
+			recipients = append(recipients, kv[0])
+		} else if kv[0] == namespace {
+			recipients = append(recipients, kv[1])
+		}
+	}
+	return recipients
+}
+
+// digestJob is the system job that sends the activity digests, scheduled
+// by the leader along with the cluster jobs.
+type digestJob struct {
+	agent *Agent
+}
+
+// Run builds and sends the activity digest of every namespace.
+func (dj *digestJob) Run() {
+	config := dj.agent.config
+
+	digests, err := BuildDigests(dj.agent.Store, time.Now(), config.DigestPeriod)
+	if err != nil {
+		log.WithError(err).Error("digest: Error building the activity digests")
+		return
+	}
+
+	for _, d := range digests {
+		text, err := d.Text()
+		if err != nil {
+			log.WithError(err).Error("digest: Error rendering the activity digest")
+			return
+		}
+
+		if err := sendDigestEmail(config, d, text); err != nil {
+			log.WithError(err).WithField("namespace", d.Namespace).Error("digest: Error sending the activity digest")
+		}
+		if err := postDigestSlack(config, text); err != nil {
+			log.WithError(err).WithField("namespace", d.Namespace).Error("digest: Error posting the activity digest")
+		}
+	}
+}
+
+func sendDigestEmail(config *Config, d *Digest, text string) error {
+	recipients := digestRecipients(config.DigestMailTo, d.Namespace)
+	if config.MailHost == "" || config.MailPort == 0 || len(recipients) == 0 {
+		return nil
+	}
+
+	e := &email.Email{
+		To:      recipients,
+		From:    config.MailFrom,
+		Subject: fmt.Sprintf("%s%s activity digest", config.MailSubjectPrefix, d.Namespace),
+		Text:    []byte(text),
+		Headers: textproto.MIMEHeader{},
+	}
+
+	serverAddr := fmt.Sprintf("%s:%d", config.MailHost, config.MailPort)
+	return e.Send(serverAddr, mailAuth(config))
+}
+
+func postDigestSlack(config *Config, text string) error {
+	if config.DigestSlackWebhook == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(config.DigestSlackWebhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("digest: Slack webhook returned %s", resp.Status)
+	}
+	return nil
+}
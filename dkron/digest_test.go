@@ -0,0 +1,101 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDigests(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	jobs := []*Job{
+		{Name: "backup", Schedule: "0 0 2 * * *", Executor: "shell", Metadata: map[string]string{"namespace": "ops"}},
+		{Name: "report", Schedule: "@every 1h", Executor: "shell", Metadata: map[string]string{"namespace": "ops"}},
+		{Name: "cleanup", Schedule: "@every 1h", Executor: "shell", Metadata: map[string]string{"namespace": "ops"}, Disabled: true},
+		{Name: "ping", Schedule: "@every 1m", Executor: "shell"},
+	}
+	for _, job := range jobs {
+		require.NoError(t, s.SetJob(job, false))
+	}
+
+	now := time.Date(2020, 5, 15, 8, 0, 0, 0, time.UTC)
+	executions := []struct {
+		job     string
+		ago     time.Duration
+		took    time.Duration
+		success bool
+	}{
+		{"backup", 6 * time.Hour, 40 * time.Minute, true},
+		{"report", 30 * time.Hour, time.Minute, false},
+		{"report", 3 * time.Hour, 2 * time.Minute, false},
+		{"report", 2 * time.Hour, time.Minute, true},
+		{"cleanup", 4 * time.Hour, time.Second, false},
+		{"cleanup", 50 * time.Hour, time.Second, true},
+		{"ping", time.Minute, time.Second, true},
+	}
+	for i, e := range executions {
+		startedAt := now.Add(-e.ago)
+		_, err := s.SetExecution(&Execution{
+			JobName:    e.job,
+			StartedAt:  startedAt,
+			FinishedAt: startedAt.Add(e.took),
+			Success:    e.success,
+			NodeName:   "node" + string(rune('a'+i)),
+		})
+		require.NoError(t, err)
+	}
+
+	digests, err := BuildDigests(s, now, 24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, digests, 2)
+
+	d := digests[0]
+	assert.Equal(t, DefaultNamespace, d.Namespace)
+	assert.Equal(t, 1, d.Executions)
+	assert.Equal(t, float64(100), d.SuccessRate)
+
+	d = digests[1]
+	assert.Equal(t, "ops", d.Namespace)
+	assert.Equal(t, 4, d.Executions)
+	assert.Equal(t, 2, d.Failures)
+	assert.Equal(t, float64(50), d.SuccessRate)
+
+	require.Len(t, d.Jobs, 3)
+	assert.Equal(t, "cleanup", d.Jobs[0].Name)
+	assert.Equal(t, "report", d.Jobs[1].Name)
+	assert.Equal(t, float64(50), d.Jobs[1].SuccessRate)
+	assert.Equal(t, "backup", d.Jobs[2].Name)
+
+	// report already failed in the previous period
+	require.Len(t, d.NewFailures, 1)
+	assert.Equal(t, "cleanup", d.NewFailures[0].Name)
+	assert.Equal(t, now.Add(-4*time.Hour), d.NewFailures[0].LastFailure)
+
+	require.Len(t, d.Slowest, 3)
+	assert.Equal(t, "backup", d.Slowest[0].Name)
+	assert.Equal(t, 40*time.Minute, d.Slowest[0].MaxDuration)
+
+	require.Len(t, d.Upcoming, 2)
+	assert.Equal(t, "report", d.Upcoming[0].Job)
+	assert.Equal(t, "backup", d.Upcoming[1].Job)
+	assert.Equal(t, []string{"cleanup"}, d.Disabled)
+
+	text, err := d.Text()
+	require.NoError(t, err)
+	assert.Contains(t, text, "Activity of namespace ops from 2020-05-14 08:00 UTC to 2020-05-15 08:00 UTC")
+	assert.Contains(t, text, "report: 50.0% of 2 executions")
+	assert.Contains(t, text, "cleanup: 1 failed executions, last at 2020-05-15 04:00 UTC")
+	assert.Contains(t, text, "backup: 40m0s")
+}
+
+func TestDigestRecipients(t *testing.T) {
+	mailTo := []string{"ops@example.com", "payments=pay@example.com", "ops=oncall@example.com"}
+
+	assert.Equal(t, []string{"ops@example.com", "oncall@example.com"}, digestRecipients(mailTo, "ops"))
+	assert.Equal(t, []string{"ops@example.com", "pay@example.com"}, digestRecipients(mailTo, "payments"))
+}
@@ -0,0 +1,105 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+
+	metrics "github.com/armon/go-metrics"
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidEscalation is returned when an escalation step is not valid.
+var ErrInvalidEscalation = errors.New("invalid escalation")
+
+// EscalationStep is notified when a job reaches a number of consecutive
+// failed runs.
+type EscalationStep struct {
+	// Number of consecutive failed runs reaching the step.
+	After int `json:"after"`
+
+	// Webhook URL posted to, with Payload or the configured webhook payload.
+	Webhook string `json:"webhook,omitempty"`
+	Payload string `json:"payload,omitempty"`
+
+	// Email address mailed to.
+	Email string `json:"email,omitempty"`
+
+	// Disable the job.
+	Disable bool `json:"disable,omitempty"`
+}
+
+func escalationFromProto(in []*proto.EscalationStep) []*EscalationStep {
+	var steps []*EscalationStep
+	for _, s := range in {
+		steps = append(steps, &EscalationStep{
+			After:   int(s.After),
+			Webhook: s.Webhook,
+			Payload: s.Payload,
+			Email:   s.Email,
+			Disable: s.Disable,
+		})
+	}
+	return steps
+}
+
+func escalationToProto(steps []*EscalationStep) []*proto.EscalationStep {
+	var out []*proto.EscalationStep
+	for _, s := range steps {
+		out = append(out, &proto.EscalationStep{
+			After:   int32(s.After),
+			Webhook: s.Webhook,
+			Payload: s.Payload,
+			Email:   s.Email,
+			Disable: s.Disable,
+		})
+	}
+	return out
+}
+
+// validateEscalation checks that the steps are reached in order and do
+// something.
+func validateEscalation(steps []*EscalationStep) error {
+	after := 0
+	for i, s := range steps {
+		if s == nil || s.After <= after {
+			return fmt.Errorf("%s: step %d must be reached after more failed runs than the previous one", ErrInvalidEscalation, i+1)
+		}
+		if s.Webhook == "" && s.Email == "" && !s.Disable {
+			return fmt.Errorf("%s: step %d has no webhook, email or disable", ErrInvalidEscalation, i+1)
+		}
+		after = s.After
+	}
+	return nil
+}
+
+// escalationLevel returns the number of steps reached by the failed runs.
+func escalationLevel(steps []*EscalationStep, failedRuns int) int {
+	level := 0
+	for _, s := range steps {
+		if s.After > failedRuns {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// escalate notifies the escalation steps reached by the job since prevLevel.
+func (a *Agent) escalate(job *Job, execution *Execution, exg []*Execution, prevLevel int) {
+	for i := prevLevel; i < job.EscalationLevel && i < len(job.Escalation); i++ {
+		step := job.Escalation[i]
+		log.WithFields(logrus.Fields{
+			"job":         job.Name,
+			"step":        i + 1,
+			"failed_runs": step.After,
+		}).Warn("grpc: Escalating failed job")
+		metrics.IncrCounterWithLabels([]string{"job", "escalated"}, 1, jobMetricLabels(job, StatusFailed))
+
+		n := Notification(a.notificationConfig(job), execution, exg, job)
+		n.Escalation = step
+		if err := n.escalate(); err != nil {
+			log.WithError(err).WithField("job", job.Name).Error("grpc: Error notifying escalation")
+		}
+	}
+}
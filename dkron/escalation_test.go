@@ -0,0 +1,102 @@
+package dkron
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEscalation(t *testing.T) {
+	assert.NoError(t, validateEscalation([]*EscalationStep{
+		{After: 1, Webhook: "https://hooks.slack.com/services/T000"},
+		{After: 3, Webhook: "https://events.pagerduty.com/v2/enqueue"},
+		{After: 10, Email: "manager@example.com", Disable: true},
+	}))
+
+	err := validateEscalation([]*EscalationStep{{After: 3, Disable: true}, {After: 3, Disable: true}})
+	assert.Error(t, err)
+	err = validateEscalation([]*EscalationStep{{After: 0, Disable: true}})
+	assert.Error(t, err)
+	err = validateEscalation([]*EscalationStep{{After: 1}})
+	assert.Error(t, err)
+}
+
+func TestStore_Escalation(t *testing.T) {
+	s := setupStore(t)
+
+	job := scaffoldJob()
+	job.Disabled = false
+	job.Retries = 1
+	job.Escalation = []*EscalationStep{
+		{After: 1, Webhook: "http://slack"},
+		{After: 2, Disable: true},
+	}
+	require.NoError(t, s.SetJob(job, false))
+
+	now := time.Now()
+	done := func(attempt uint, success bool) *Job {
+		now = now.Add(time.Second)
+		_, err := s.SetExecutionDone(&Execution{
+			JobName:    job.Name,
+			StartedAt:  now,
+			FinishedAt: now,
+			Success:    success,
+			NodeName:   "testNode",
+			Group:      now.UnixNano(),
+			Attempt:    attempt,
+		})
+		require.NoError(t, err)
+		return loadJob(t, s, job.Name)
+	}
+
+	// Failed attempts that are retried don't escalate
+	assert.Equal(t, 0, done(1, false).EscalationLevel)
+	assert.Equal(t, 1, done(2, false).EscalationLevel)
+
+	// The escalation restarts on success
+	assert.Equal(t, 0, done(1, true).EscalationLevel)
+	done(1, false)
+	assert.Equal(t, 1, done(2, false).EscalationLevel)
+	done(1, false)
+	job = done(2, false)
+	assert.Equal(t, 2, job.EscalationLevel)
+	assert.True(t, job.Disabled)
+	assert.Equal(t, StatusTripped, job.Status)
+
+	job, err := s.ResetJob(job.Name)
+	require.NoError(t, err)
+	assert.Equal(t, 0, job.EscalationLevel)
+}
+
+func TestAgentEscalate(t *testing.T) {
+	var posted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		posted = append(posted, r.URL.Path+" "+string(body))
+	}))
+	defer ts.Close()
+
+	a := &Agent{config: &Config{WebhookPayload: "{{.JobName}}"}}
+	job := &Job{
+		Name: "test",
+		Escalation: []*EscalationStep{
+			{After: 1, Webhook: ts.URL + "/slack"},
+			{After: 3, Webhook: ts.URL + "/pagerduty", Payload: "escalated {{.JobName}}"},
+			{After: 10, Disable: true},
+		},
+		EscalationLevel: 2,
+	}
+
+	a.escalate(job, &Execution{JobName: "test"}, nil, 0)
+	assert.Equal(t, []string{"/slack test", "/pagerduty escalated test"}, posted)
+
+	// Steps already reached aren't notified again
+	posted = nil
+	a.escalate(job, &Execution{JobName: "test"}, nil, 2)
+	assert.Empty(t, posted)
+}
@@ -3,14 +3,20 @@ package dkron
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	proto "github.com/distribworks/dkron/v3/plugin/types"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 )
 
 // Execution type holds all of the details of a specific Execution.
 type Execution struct {
+	// Id is the stable unique ID of this execution, a ULID with its start
+	// time. Executions stored before it existed use their key.
+	Id string `json:"id,omitempty"`
+
 	// Name of the job this executions refers to.
 	JobName string `json:"job_name,omitempty"`
 
@@ -34,6 +40,58 @@ type Execution struct {
 
 	// Retry attempt of this execution.
 	Attempt uint `json:"attempt,omitempty"`
+
+	// Free-form annotations attached to this execution, e.g. the reason
+	// of a manual run.
+	Annotations []string `json:"annotations,omitempty"`
+
+	// Files produced by the execution, uploaded to the artifact store.
+	Artifacts []*Artifact `json:"artifacts,omitempty"`
+
+	// ID of the API request that triggered this execution, if any.
+	RequestID string `json:"request_id,omitempty"`
+
+	// Time the schedule fired for this execution, retries and dependent
+	// jobs keep it. Zero for manual runs.
+	ScheduledAt time.Time `json:"scheduled_at,omitempty"`
+
+	// Label of the shadow run this execution belongs to, shadow executions
+	// are kept apart from the history of the job.
+	Shadow string `json:"shadow,omitempty"`
+
+	// Whether the execution ran the canary spec of the job.
+	Canary bool `json:"canary,omitempty"`
+
+	// Params of the execution passed to the executors, like the member of
+	// a member trigger.
+	Params map[string]string `json:"params,omitempty"`
+
+	// Version of the job spec the execution ran with.
+	JobVersion int64 `json:"job_version,omitempty"`
+
+	// Cost of the execution, set by the server when it's done.
+	Cost float64 `json:"cost,omitempty"`
+
+	// Metrics reported by the executors, like the latency of a probe.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+
+	// Item of the matrix of the job the execution runs, like
+	// customer=42, and the number of items of its run.
+	MatrixItem string `json:"matrix_item,omitempty"`
+	MatrixSize int    `json:"matrix_size,omitempty"`
+
+	// Whether the execution ran the discovery step of the matrix of the
+	// job, listing the items of its run.
+	Discovery bool `json:"discovery,omitempty"`
+
+	// Whether the run was skipped as its pre-check reported no work, or
+	// the run of its parent job was skipped. Skipped runs are neither
+	// successful nor failed.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Output of the parent job's run, expanded into the items of the
+	// matrix taking it.
+	parentOutput string
 }
 
 // NewExecution creates a new execution.
@@ -49,15 +107,34 @@ func NewExecution(jobName string) *Execution {
 func NewExecutionFromProto(e *proto.Execution) *Execution {
 	startedAt, _ := ptypes.Timestamp(e.GetStartedAt())
 	finishedAt, _ := ptypes.Timestamp(e.GetFinishedAt())
+	var scheduledAt time.Time
+	if e.GetScheduledAt() != nil {
+		scheduledAt, _ = ptypes.Timestamp(e.GetScheduledAt())
+	}
 	return &Execution{
-		JobName:    e.JobName,
-		Success:    e.Success,
-		Output:     string(e.Output),
-		NodeName:   e.NodeName,
-		Group:      e.Group,
-		Attempt:    uint(e.Attempt),
-		StartedAt:  startedAt,
-		FinishedAt: finishedAt,
+		JobName:     e.JobName,
+		Success:     e.Success,
+		Output:      string(e.Output),
+		NodeName:    e.NodeName,
+		Group:       e.Group,
+		Attempt:     uint(e.Attempt),
+		StartedAt:   startedAt,
+		FinishedAt:  finishedAt,
+		Annotations: e.Annotations,
+		Artifacts:   artifactsFromProto(e.Artifacts),
+		RequestID:   e.RequestId,
+		ScheduledAt: scheduledAt,
+		Id:          e.Id,
+		Shadow:      e.Shadow,
+		Canary:      e.Canary,
+		Params:      e.Params,
+		JobVersion:  e.JobVersion,
+		Cost:        e.Cost,
+		Metrics:     e.Metrics,
+		MatrixItem:  e.MatrixItem,
+		MatrixSize:  int(e.MatrixSize),
+		Discovery:   e.Discovery,
+		Skipped:     e.Skipped,
 	}
 }
 
@@ -66,16 +143,46 @@ func NewExecutionFromProto(e *proto.Execution) *Execution {
 func (e *Execution) ToProto() *proto.Execution {
 	startedAt, _ := ptypes.TimestampProto(e.StartedAt)
 	finishedAt, _ := ptypes.TimestampProto(e.FinishedAt)
+	var scheduledAt *timestamp.Timestamp
+	if !e.ScheduledAt.IsZero() {
+		scheduledAt, _ = ptypes.TimestampProto(e.ScheduledAt)
+	}
 	return &proto.Execution{
-		JobName:    e.JobName,
-		Success:    e.Success,
-		Output:     []byte(e.Output),
-		NodeName:   e.NodeName,
-		Group:      e.Group,
-		Attempt:    uint32(e.Attempt),
-		StartedAt:  startedAt,
-		FinishedAt: finishedAt,
+		JobName:     e.JobName,
+		Success:     e.Success,
+		Output:      []byte(e.Output),
+		NodeName:    e.NodeName,
+		Group:       e.Group,
+		Attempt:     uint32(e.Attempt),
+		StartedAt:   startedAt,
+		FinishedAt:  finishedAt,
+		Annotations: e.Annotations,
+		Artifacts:   artifactsToProto(e.Artifacts),
+		RequestId:   e.RequestID,
+		ScheduledAt: scheduledAt,
+		Id:          e.Id,
+		Shadow:      e.Shadow,
+		Canary:      e.Canary,
+		Params:      e.Params,
+		JobVersion:  e.JobVersion,
+		Cost:        e.Cost,
+		Metrics:     e.Metrics,
+		MatrixItem:  e.MatrixItem,
+		MatrixSize:  int32(e.MatrixSize),
+		Discovery:   e.Discovery,
+		Skipped:     e.Skipped,
+	}
+}
+
+// HasAnnotation returns whether any of the execution annotations
+// contains the given text.
+func (e *Execution) HasAnnotation(text string) bool {
+	for _, a := range e.Annotations {
+		if strings.Contains(a, text) {
+			return true
+		}
 	}
+	return false
 }
 
 // Key wil generate the execution Id for an execution.
@@ -83,7 +190,47 @@ func (e *Execution) Key() string {
 	return fmt.Sprintf("%d-%s", e.StartedAt.UnixNano(), e.NodeName)
 }
 
+// assignExecutionID gives an ID to the executions reported without one,
+// by agents of older versions or by the leader itself, keeping the ID of
+// the stored execution if any.
+func (a *Agent) assignExecutionID(pbe *proto.Execution) {
+	if pbe.Id != "" {
+		return
+	}
+	ex := NewExecutionFromProto(pbe)
+	get := a.Store.GetExecution
+	if ex.Shadow != "" {
+		get = func(jobName, key string) (*Execution, error) {
+			return a.Store.GetShadowExecution(jobName, ex.Shadow, key)
+		}
+	}
+	if stored, err := get(ex.JobName, ex.Key()); err == nil {
+		pbe.Id = stored.Id
+		return
+	}
+	pbe.Id = newULID(ex.StartedAt)
+}
+
+// setLegacyID uses the key as the ID of executions stored without one.
+func (e *Execution) setLegacyID() {
+	if e.Id == "" {
+		e.Id = e.Key()
+	}
+}
+
 // GetGroup is the getter for the execution group.
 func (e *Execution) GetGroup() string {
 	return strconv.FormatInt(e.Group, 10)
 }
+
+// lastAttempts returns the last attempt of every node among the
+// executions of a group.
+func lastAttempts(executions []*Execution) map[string]*Execution {
+	last := map[string]*Execution{}
+	for _, e := range executions {
+		if l, ok := last[e.NodeName]; !ok || e.Attempt > l.Attempt {
+			last[e.NodeName] = e
+		}
+	}
+	return last
+}
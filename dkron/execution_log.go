@@ -0,0 +1,296 @@
+package dkron
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/ptypes"
+)
+
+const (
+	// executionLogMarker starts the lines the agent adds around the output
+	// of every execution.
+	executionLogMarker = "==="
+	// executionLogTimeFormat is the time format of the suffix of the
+	// rotated files, sorted by time.
+	executionLogTimeFormat = "20060102T150405.000000000"
+)
+
+// ErrInvalidExecutionLogPath is returned when the path template of the
+// execution log files can't be parsed.
+var ErrInvalidExecutionLogPath = errors.New("invalid execution log path")
+
+// executionLogVars are the values of the path template of the execution
+// log files.
+type executionLogVars struct {
+	Job       string
+	Namespace string
+	Group     int64
+	Execution string
+	Node      string
+	// Date is the day the execution started, as YYYY-MM-DD.
+	Date string
+}
+
+// executionLogs mirrors the output of the executions to node local files,
+// rotated by size and age.
+type executionLogs struct {
+	path     *template.Template
+	maxSize  int64
+	maxAge   time.Duration
+	maxFiles int
+
+	mu    sync.Mutex
+	files map[string]*executionLogFile
+}
+
+// executionLogFile is a log file written by the executions sharing its
+// path, open while any of them is running.
+type executionLogFile struct {
+	sync.Mutex
+
+	path    string
+	file    *os.File
+	size    int64
+	created time.Time
+	refs    int
+}
+
+// newExecutionLogs returns the execution log files of the configuration,
+// nil when disabled.
+func newExecutionLogs(c *Config) (*executionLogs, error) {
+	if c.ExecutionLogPath == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("execution-log-path").Option("missingkey=error").Parse(c.ExecutionLogPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrInvalidExecutionLogPath, err)
+	}
+	if err := tmpl.Execute(&bytes.Buffer{}, &executionLogVars{}); err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrInvalidExecutionLogPath, err)
+	}
+	return &executionLogs{
+		path:     tmpl,
+		maxSize:  c.ExecutionLogMaxSize,
+		maxAge:   c.ExecutionLogMaxAge,
+		maxFiles: c.ExecutionLogMaxFiles,
+		files:    map[string]*executionLogFile{},
+	}, nil
+}
+
+// open returns the log of the execution, nil when disabled. The header of
+// the execution is written to it.
+func (l *executionLogs) open(job *types.Job, execution *types.Execution) (*executionLog, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	startedAt, _ := ptypes.Timestamp(execution.StartedAt)
+	var b bytes.Buffer
+	if err := l.path.Execute(&b, &executionLogVars{
+		Job:       job.Name,
+		Namespace: NewJobFromProto(job).Namespace(),
+		Group:     execution.Group,
+		Execution: execution.Id,
+		Node:      execution.NodeName,
+		Date:      startedAt.UTC().Format("2006-01-02"),
+	}); err != nil {
+		return nil, err
+	}
+
+	f, err := l.acquire(filepath.Clean(b.String()))
+	if err != nil {
+		return nil, err
+	}
+	el := &executionLog{
+		logs: l,
+		file: f,
+		id:   fmt.Sprintf("job=%s execution=%s group=%d node=%s", job.Name, execution.Id, execution.Group, execution.NodeName),
+	}
+	el.writeLine(fmt.Sprintf("%s %s %s started", executionLogMarker, startedAt.UTC().Format(time.RFC3339), el.id))
+	return el, nil
+}
+
+func (l *executionLogs) acquire(path string) (*executionLogFile, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if f, ok := l.files[path]; ok {
+		f.refs++
+		return f, nil
+	}
+	f := &executionLogFile{path: path}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.refs = 1
+	l.files[path] = f
+	return f, nil
+}
+
+func (l *executionLogs) release(f *executionLogFile) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f.refs--
+	if f.refs > 0 {
+		return
+	}
+	delete(l.files, f.path)
+	f.Lock()
+	defer f.Unlock()
+	f.file.Close()
+}
+
+// open opens the file for appending, its creation time is the one of its
+// first line.
+func (f *executionLogFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = fi.Size()
+	f.created = time.Now()
+	if f.size > 0 {
+		if created, ok := executionLogCreated(f.path); ok {
+			f.created = created
+		}
+	}
+	return nil
+}
+
+// executionLogCreated returns the time of the header starting the file.
+func executionLogCreated(path string) (time.Time, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer file.Close()
+
+	line, _ := bufio.NewReader(file).ReadString('\n')
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != executionLogMarker {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, fields[1])
+	return t, err == nil
+}
+
+// write appends the lines to the file, rotating it first when they would
+// take it over the max size or it's older than the max age.
+func (l *executionLogs) write(f *executionLogFile, b []byte) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.size > 0 && ((l.maxSize > 0 && f.size+int64(len(b)) > l.maxSize) ||
+		(l.maxAge > 0 && time.Since(f.created) > l.maxAge)) {
+		if err := l.rotate(f); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(b)
+	f.size += int64(n)
+	return err
+}
+
+// rotate renames the file with the time as suffix, deleting the oldest
+// rotated files over the max files, and starts a new one.
+func (l *executionLogs) rotate(f *executionLogFile) error {
+	f.file.Close()
+	rotated := f.path + "." + time.Now().UTC().Format(executionLogTimeFormat)
+	if err := os.Rename(f.path, rotated); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if l.maxFiles > 0 {
+		matches, err := filepath.Glob(f.path + ".*")
+		if err != nil {
+			return err
+		}
+		var old []string
+		for _, m := range matches {
+			if _, err := time.Parse(executionLogTimeFormat, strings.TrimPrefix(m, f.path+".")); err == nil {
+				old = append(old, m)
+			}
+		}
+		sort.Strings(old)
+		for len(old) > l.maxFiles {
+			os.Remove(old[0])
+			old = old[1:]
+		}
+	}
+	return nil
+}
+
+// executionLog is the log of an execution, written line by line so the
+// lines of executions sharing a file don't mix.
+type executionLog struct {
+	logs *executionLogs
+	file *executionLogFile
+	id   string
+
+	partial []byte
+	failed  bool
+}
+
+// Write mirrors the output to the file. Errors are logged once and don't
+// fail the execution.
+func (l *executionLog) Write(b []byte) {
+	if l == nil {
+		return
+	}
+	l.partial = append(l.partial, b...)
+	i := bytes.LastIndexByte(l.partial, '\n')
+	if i < 0 {
+		return
+	}
+	l.writeBytes(l.partial[:i+1])
+	l.partial = append(l.partial[:0], l.partial[i+1:]...)
+}
+
+func (l *executionLog) writeLine(line string) {
+	l.writeBytes([]byte(line + "\n"))
+}
+
+func (l *executionLog) writeBytes(b []byte) {
+	if err := l.logs.write(l.file, b); err != nil && !l.failed {
+		l.failed = true
+		log.WithError(err).WithField("path", l.file.path).Error("grpc_agent: error writing execution log file")
+	}
+}
+
+// Close writes the rest of the output and the footer of the execution.
+func (l *executionLog) Close(success bool) {
+	if l == nil {
+		return
+	}
+	if len(l.partial) > 0 {
+		l.writeBytes(append(l.partial, '\n'))
+		l.partial = nil
+	}
+	l.writeLine(fmt.Sprintf("%s %s %s finished success=%t", executionLogMarker, time.Now().UTC().Format(time.RFC3339), l.id, success))
+	l.logs.release(l.file)
+}
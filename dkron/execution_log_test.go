@@ -0,0 +1,82 @@
+package dkron
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionLogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-execution-logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := DefaultConfig()
+	el, err := newExecutionLogs(c)
+	require.NoError(t, err)
+	assert.Nil(t, el)
+
+	c.ExecutionLogPath = filepath.Join(dir, "{{.Jobs}}.log")
+	_, err = newExecutionLogs(c)
+	assert.Error(t, err)
+
+	c.ExecutionLogPath = filepath.Join(dir, "{{.Namespace}}", "{{.Job}}.log")
+	c.ExecutionLogMaxSize = 1000
+	c.ExecutionLogMaxFiles = 1
+	el, err = newExecutionLogs(c)
+	require.NoError(t, err)
+
+	job := &types.Job{Name: "backup"}
+	startedAt := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Second)
+	run := func(id string, output ...string) {
+		ts, _ := ptypes.TimestampProto(startedAt)
+		l, err := el.open(job, &types.Execution{Id: id, Group: 1, NodeName: "node1", StartedAt: ts})
+		require.NoError(t, err)
+		for _, o := range output {
+			l.Write([]byte(o))
+		}
+		l.Close(true)
+	}
+
+	path := filepath.Join(dir, "default", "backup.log")
+	run("e1", "copying ", "files\n", "done")
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "=== "+startedAt.Format(time.RFC3339)+" job=backup execution=e1 group=1 node=node1 started", lines[0])
+	assert.Equal(t, "copying files", lines[1])
+	assert.Equal(t, "done", lines[2])
+	assert.True(t, strings.HasSuffix(lines[3], "job=backup execution=e1 group=1 node=node1 finished success=true"))
+
+	created, ok := executionLogCreated(path)
+	assert.True(t, ok)
+	assert.Equal(t, startedAt, created)
+
+	// Files older than the max age are rotated
+	el.maxAge = time.Hour
+	run("e2", "late\n")
+	b, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(b), "=== "+startedAt.Format(time.RFC3339)+" job=backup execution=e2"))
+
+	// And so are the ones going over the max size, keeping the max files
+	el.maxAge = 0
+	run("e3", strings.Repeat("x", 900)+"\n")
+	rotated, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	assert.Len(t, rotated, 1)
+	b, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "execution=e3")
+	assert.NotContains(t, string(b), "execution=e2")
+	assert.Empty(t, el.files)
+}
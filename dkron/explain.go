@@ -0,0 +1,326 @@
+package dkron
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	"github.com/distribworks/dkron/v3/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/serf/serf"
+)
+
+// JobExplanation describes what would happen on the next run of a job.
+type JobExplanation struct {
+	Job string `json:"job"`
+
+	// Whether the job would run, and why not otherwise.
+	Runnable bool     `json:"runnable"`
+	Reasons  []string `json:"reasons"`
+
+	// Effective schedule, timezone included, and next scheduled run.
+	Schedule  string    `json:"schedule"`
+	Next      time.Time `json:"next"`
+	ParentJob string    `json:"parent_job,omitempty"`
+
+	// Tags used to filter the nodes, region included, and how the
+	// target nodes are selected among the matching ones.
+	Tags     map[string]string  `json:"tags"`
+	Strategy string             `json:"strategy"`
+	Nodes    []*NodeExplanation `json:"nodes"`
+	Selected []string           `json:"selected"`
+
+	// Effective execution settings.
+	Executor               string                      `json:"executor"`
+	ExecutorConfig         plugin.ExecutorPluginConfig `json:"executor_config"`
+	Steps                  []*Step                     `json:"steps,omitempty"`
+	Resources              *Resources                  `json:"resources,omitempty"`
+	Timeout                string                      `json:"timeout,omitempty"`
+	Retries                uint                        `json:"retries"`
+	Concurrency            string                      `json:"concurrency"`
+	ConcurrencyKey         string                      `json:"concurrency_key,omitempty"`
+	MaxConsecutiveFailures int                         `json:"max_consecutive_failures"`
+	Processors             map[string]plugin.Config    `json:"processors"`
+}
+
+// NodeExplanation tells whether a node matches the tags of a job and why.
+type NodeExplanation struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Matches bool     `json:"matches"`
+	Reasons []string `json:"reasons"`
+}
+
+// explainJob describes the next run of the job in the current cluster.
+func (a *Agent) explainJob(job *Job) (*JobExplanation, error) {
+	e := &JobExplanation{
+		Job:                    job.Name,
+		Runnable:               true,
+		Reasons:                []string{},
+		Schedule:               job.cronSchedule(),
+		ParentJob:              job.ParentJob,
+		Executor:               job.Executor,
+		ExecutorConfig:         job.ExecutorConfig,
+		Steps:                  job.Steps,
+		Resources:              job.Resources,
+		Timeout:                job.ExecutorConfig["timeout"],
+		Retries:                job.Retries,
+		Concurrency:            job.Concurrency,
+		ConcurrencyKey:         job.ConcurrencyKey,
+		MaxConsecutiveFailures: job.MaxConsecutiveFailures,
+		Processors:             a.withNamespaceDefaults(job).Processors,
+	}
+	if e.Concurrency == "" {
+		e.Concurrency = ConcurrencyAllow
+	}
+
+	if job.ParentJob != "" {
+		e.Reasons = append(e.Reasons, fmt.Sprintf("runs after each successful run of %s", job.ParentJob))
+	} else if job.Schedule != "" {
+		s, err := extcron.Parse(e.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		e.Next = shiftSchedule(s, a.config.ScheduleOffset).Next(time.Now())
+	}
+	if job.StandbyFor != "" {
+		e.Reasons = append(e.Reasons, fmt.Sprintf("standby of %s, runs only if its last run failed or it missed its run", job.StandbyFor))
+	}
+
+	if job.Disabled {
+		e.Runnable = false
+		if job.Status == StatusTripped {
+			e.Reasons = append(e.Reasons, fmt.Sprintf("disabled by the circuit breaker after %d consecutive failures", job.ConsecutiveFailures))
+		} else {
+			e.Reasons = append(e.Reasons, "job is disabled")
+		}
+	}
+	if err := checkBudget(job, time.Now()); err != nil {
+		e.Runnable = false
+		e.Reasons = append(e.Reasons, err.Error())
+	}
+	if a.GlobalLock {
+		e.Runnable = false
+		e.Reasons = append(e.Reasons, "global lock is active")
+	}
+	if job.Concurrency == ConcurrencyForbid {
+		exs, err := a.GetActiveExecutions()
+		if err != nil {
+			return nil, err
+		}
+		for _, ex := range exs {
+			if ex.JobName == job.Name {
+				e.Runnable = false
+				e.Reasons = append(e.Reasons, "concurrency is forbidden and an execution is running")
+				break
+			}
+		}
+	}
+	if name := job.concurrencyLockName(); name != "" {
+		locks, err := a.Store.GetLocks()
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range locks {
+			if l.Name == name {
+				e.Runnable = false
+				e.Reasons = append(e.Reasons, fmt.Sprintf("concurrency group %s is running %s", strings.TrimPrefix(name, concurrencyLockPrefix), l.Holder))
+			}
+		}
+	}
+
+	now := time.Now()
+	e.Tags, e.Nodes = explainNodes(a.serf.Members(), job.Tags, a.config.Region)
+	_, excluded := a.maintenanceMembers(now)
+	inMaintenance := false
+	for _, n := range e.Nodes {
+		for _, w := range excluded[n.Name] {
+			inMaintenance = inMaintenance || n.Matches
+			n.Matches = false
+			n.Reasons = append(n.Reasons, fmt.Sprintf("in maintenance window %s until %s", w.Name, w.activeUntil(now).Format(time.RFC3339)))
+		}
+	}
+	_, cordoned := cordonedMembers(a.serf.Members())
+	underPressure := false
+	for _, n := range e.Nodes {
+		if p, ok := cordoned[n.Name]; ok {
+			underPressure = underPressure || n.Matches
+			n.Matches = false
+			n.Reasons = append(n.Reasons, fmt.Sprintf("under %s pressure", p))
+		}
+	}
+	if job.Resources != nil {
+		members := map[string]serf.Member{}
+		for _, m := range a.serf.Members() {
+			members[m.Name] = m
+		}
+		for _, n := range e.Nodes {
+			if n.Matches && !fitsMember(members[n.Name], job.Resources) {
+				n.Reasons = append(n.Reasons, "saturated by the resources reserved by other executions")
+			}
+		}
+	}
+	e.Strategy = selectionStrategy(job.Tags)
+
+	nodes, _, err := a.processFilteredNodes(job)
+	if err != nil {
+		return nil, err
+	}
+	e.Selected = make([]string, 0, len(nodes))
+	for n := range nodes {
+		e.Selected = append(e.Selected, n)
+	}
+	sort.Strings(e.Selected)
+	if len(e.Selected) == 0 {
+		e.Runnable = false
+		if inMaintenance {
+			e.Reasons = append(e.Reasons, ErrNodesInMaintenance.Error())
+		} else if underPressure {
+			e.Reasons = append(e.Reasons, ErrNodesUnderPressure.Error())
+		} else {
+			e.Reasons = append(e.Reasons, "no node matches the tags")
+		}
+	}
+
+	return e, nil
+}
+
+// explainNodes returns the tags used to filter the members and, for every
+// member, whether it matches them and why.
+func explainNodes(members []serf.Member, jobTags map[string]string, region string) (map[string]string, []*NodeExplanation) {
+	tags := map[string]string{"region": region}
+	for k, v := range jobTags {
+		// Drop the cardinality, it doesn't take part in matching
+		tags[k] = strings.Split(v, ":")[0]
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	nodes := make([]*NodeExplanation, 0, len(members))
+	for _, m := range members {
+		n := &NodeExplanation{
+			Name:    m.Name,
+			Status:  m.Status.String(),
+			Matches: m.Status == serf.StatusAlive,
+			Reasons: []string{},
+		}
+		if !n.Matches {
+			n.Reasons = append(n.Reasons, fmt.Sprintf("node is %s", n.Status))
+		}
+		for _, k := range keys {
+			v, ok := m.Tags[k]
+			switch {
+			case !ok:
+				n.Matches = false
+				n.Reasons = append(n.Reasons, fmt.Sprintf("missing tag %s", k))
+			case v != tags[k]:
+				n.Matches = false
+				n.Reasons = append(n.Reasons, fmt.Sprintf("tag %s is %s, not %s", k, v, tags[k]))
+			default:
+				n.Reasons = append(n.Reasons, fmt.Sprintf("tag %s is %s", k, v))
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return tags, nodes
+}
+
+// selectionStrategy describes how the target nodes are picked among the
+// ones matching the tags.
+func selectionStrategy(jobTags map[string]string) string {
+	keys := make([]string, 0, len(jobTags))
+	for k := range jobTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	limits := []string{}
+	for _, k := range keys {
+		tc := strings.Split(jobTags[k], ":")
+		if len(tc) != 2 {
+			continue
+		}
+		if n, err := strconv.Atoi(tc[1]); err == nil {
+			limits = append(limits, fmt.Sprintf("%d random nodes with tag %s=%s", n, k, tc[0]))
+		}
+	}
+	if len(limits) == 0 {
+		return "all matching nodes"
+	}
+	return "matching nodes among " + strings.Join(limits, " and ")
+}
+
+// MemberMatch is the result of matching job tags against the members
+// of the cluster.
+type MemberMatch struct {
+	Tags     map[string]string  `json:"tags"`
+	Strategy string             `json:"strategy"`
+	Nodes    []*NodeExplanation `json:"nodes"`
+	Matching []serf.Member      `json:"matching"`
+	Selected []string           `json:"selected"`
+}
+
+// matchMembers returns the members matching the tags, as given in a job,
+// and the ones that would be selected to run it.
+func matchMembers(members []serf.Member, jobTags map[string]string, region string, shuffle func(n int, swap func(i, j int))) (*MemberMatch, error) {
+	nodes, _, err := filterNodes(members, jobTags, region, shuffle)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MemberMatch{
+		Strategy: selectionStrategy(jobTags),
+		Matching: []serf.Member{},
+		Selected: make([]string, 0, len(nodes)),
+	}
+	m.Tags, m.Nodes = explainNodes(members, jobTags, region)
+
+	for _, n := range m.Nodes {
+		if !n.Matches {
+			continue
+		}
+		for _, member := range members {
+			if member.Name == n.Name {
+				m.Matching = append(m.Matching, member)
+			}
+		}
+	}
+	for n := range nodes {
+		m.Selected = append(m.Selected, n)
+	}
+	sort.Strings(m.Selected)
+
+	return m, nil
+}
+
+// membersMatchHandler previews the members targeted by the given job tags.
+func (h *HTTPTransport) membersMatchHandler(c *gin.Context) {
+	var body struct {
+		Tags map[string]string `json:"tags"`
+	}
+	if err := c.BindJSON(&body); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	match, err := matchMembers(h.agent.serf.Members(), body.Tags, h.agent.config.Region, rand.Shuffle)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+	renderJSON(c, http.StatusOK, match)
+}
@@ -0,0 +1,66 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainNodes(t *testing.T) {
+	members := []serf.Member{
+		{Name: "web2", Status: serf.StatusFailed, Tags: map[string]string{"region": "global", "role": "web"}},
+		{Name: "web1", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "web"}},
+		{Name: "db1", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "db"}},
+		{Name: "eu1", Status: serf.StatusAlive, Tags: map[string]string{"region": "eu"}},
+	}
+
+	tags, nodes := explainNodes(members, map[string]string{"role": "web:1"}, "global")
+	assert.Equal(t, map[string]string{"region": "global", "role": "web"}, tags)
+	require.Len(t, nodes, 4)
+
+	assert.Equal(t, "db1", nodes[0].Name)
+	assert.False(t, nodes[0].Matches)
+	assert.Equal(t, []string{"tag region is global", "tag role is db, not web"}, nodes[0].Reasons)
+
+	assert.Equal(t, "eu1", nodes[1].Name)
+	assert.Equal(t, []string{"tag region is eu, not global", "missing tag role"}, nodes[1].Reasons)
+
+	assert.Equal(t, "web1", nodes[2].Name)
+	assert.True(t, nodes[2].Matches)
+
+	assert.Equal(t, "web2", nodes[3].Name)
+	assert.False(t, nodes[3].Matches)
+	assert.Equal(t, "node is failed", nodes[3].Reasons[0])
+}
+
+func TestSelectionStrategy(t *testing.T) {
+	assert.Equal(t, "all matching nodes", selectionStrategy(map[string]string{"role": "web"}))
+	assert.Equal(t, "matching nodes among 2 random nodes with tag role=web and 1 random nodes with tag zone=a",
+		selectionStrategy(map[string]string{"role": "web:2", "zone": "a:1", "os": "linux"}))
+}
+
+func TestMatchMembers(t *testing.T) {
+	members := []serf.Member{
+		{Name: "web1", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "web", "rpc_addr": "10.0.0.1:6868"}},
+		{Name: "web2", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "web", "rpc_addr": "10.0.0.2:6868"}},
+		{Name: "db1", Status: serf.StatusAlive, Tags: map[string]string{"region": "global", "role": "db", "rpc_addr": "10.0.0.3:6868"}},
+	}
+	noShuffle := func(n int, swap func(i, j int)) {}
+
+	m, err := matchMembers(members, map[string]string{"role": "web:1"}, "global", noShuffle)
+	require.NoError(t, err)
+	require.Len(t, m.Matching, 2)
+	assert.Equal(t, "web1", m.Matching[0].Name)
+	assert.Equal(t, "web2", m.Matching[1].Name)
+	assert.Equal(t, []string{"web1"}, m.Selected)
+	assert.Len(t, m.Nodes, 3)
+
+	m, err = matchMembers(members, nil, "global", noShuffle)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db1", "web1", "web2"}, m.Selected)
+
+	_, err = matchMembers(members, map[string]string{"role": "web:one"}, "global", noShuffle)
+	assert.Error(t, err)
+}
@@ -0,0 +1,247 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// ExportCrontab is the crontab export format.
+	ExportCrontab = "crontab"
+	// ExportK8sCronJob is the Kubernetes CronJob manifest export format.
+	ExportK8sCronJob = "k8s-cronjob"
+
+	// defaultExportImage is the container image of exported CronJobs.
+	defaultExportImage = "alpine:latest"
+)
+
+var (
+	// ErrExportFormat is returned when exporting to an unknown format.
+	ErrExportFormat = errors.New("export: unknown format, use crontab or k8s-cronjob")
+	// ErrExportUnsupported is returned when a job can't be expressed in the export format.
+	ErrExportUnsupported = errors.New("export: job can't be exported")
+
+	cronDescriptors = map[string]bool{
+		"@yearly":   true,
+		"@annually": true,
+		"@monthly":  true,
+		"@weekly":   true,
+		"@daily":    true,
+		"@midnight": true,
+		"@hourly":   true,
+	}
+)
+
+type k8sCronJob struct {
+	APIVersion string         `yaml:"apiVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   k8sMetadata    `yaml:"metadata"`
+	Spec       k8sCronJobSpec `yaml:"spec"`
+}
+
+type k8sMetadata struct {
+	Name        string            `yaml:"name"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type k8sCronJobSpec struct {
+	Schedule          string         `yaml:"schedule"`
+	TimeZone          string         `yaml:"timeZone,omitempty"`
+	ConcurrencyPolicy string         `yaml:"concurrencyPolicy"`
+	Suspend           bool           `yaml:"suspend"`
+	JobTemplate       k8sJobTemplate `yaml:"jobTemplate"`
+}
+
+type k8sJobTemplate struct {
+	Spec struct {
+		BackoffLimit int `yaml:"backoffLimit"`
+		Template     struct {
+			Spec k8sPodSpec `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+}
+
+type k8sPodSpec struct {
+	RestartPolicy string         `yaml:"restartPolicy"`
+	Containers    []k8sContainer `yaml:"containers"`
+}
+
+type k8sContainer struct {
+	Name       string      `yaml:"name"`
+	Image      string      `yaml:"image"`
+	Command    []string    `yaml:"command"`
+	Env        []k8sEnvVar `yaml:"env,omitempty"`
+	WorkingDir string      `yaml:"workingDir,omitempty"`
+}
+
+type k8sEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// Export converts the job to the given format, image is the container
+// image used by the k8s-cronjob format.
+func (j *Job) Export(format, image string) ([]byte, error) {
+	switch format {
+	case ExportCrontab:
+		return j.exportCrontab()
+	case ExportK8sCronJob:
+		return j.exportK8sCronJob(image)
+	}
+	return nil, ErrExportFormat
+}
+
+func (j *Job) exportCrontab() ([]byte, error) {
+	schedule, err := j.standardSchedule()
+	if err != nil {
+		return nil, err
+	}
+	command, err := j.shellCommand()
+	if err != nil {
+		return nil, err
+	}
+
+	line := schedule + " "
+	if env := j.ExecutorConfig["env"]; env != "" {
+		line += strings.Join(strings.Split(env, ","), " ") + " "
+	}
+	if cwd := j.ExecutorConfig["cwd"]; cwd != "" {
+		line += "cd " + cwd + " && "
+	}
+	// % are newlines in crontab commands
+	line += strings.Replace(command, "%", `\%`, -1)
+
+	var b strings.Builder
+	name := j.Name
+	if j.DisplayName != "" {
+		name = j.DisplayName
+	}
+	fmt.Fprintf(&b, "# %s\n", name)
+	if j.OwnerEmail != "" {
+		fmt.Fprintf(&b, "MAILTO=%s\n", j.OwnerEmail)
+	}
+	if j.Timezone != "" {
+		fmt.Fprintf(&b, "CRON_TZ=%s\n", j.Timezone)
+	}
+	if j.Disabled {
+		b.WriteString("# disabled: ")
+	}
+	b.WriteString(line + "\n")
+
+	return []byte(b.String()), nil
+}
+
+func (j *Job) exportK8sCronJob(image string) ([]byte, error) {
+	schedule, err := j.standardSchedule()
+	if err != nil {
+		return nil, err
+	}
+	command, err := j.shellCommand()
+	if err != nil {
+		return nil, err
+	}
+	if image == "" {
+		image = defaultExportImage
+	}
+
+	// Names must be DNS subdomains, CronJob names are limited to 52 characters
+	name := strings.Replace(j.Name, "_", "-", -1)
+	if len(name) > 52 {
+		name = name[:52]
+	}
+
+	cj := k8sCronJob{
+		APIVersion: "batch/v1",
+		Kind:       "CronJob",
+		Metadata: k8sMetadata{
+			Name:        name,
+			Annotations: map[string]string{"dkron.io/job": j.Name},
+		},
+		Spec: k8sCronJobSpec{
+			Schedule:          schedule,
+			TimeZone:          j.Timezone,
+			ConcurrencyPolicy: "Allow",
+			Suspend:           j.Disabled,
+		},
+	}
+	for k, v := range j.Metadata {
+		cj.Metadata.Annotations["dkron.io/metadata."+k] = v
+	}
+	if j.Concurrency == ConcurrencyForbid {
+		cj.Spec.ConcurrencyPolicy = "Forbid"
+	}
+
+	container := k8sContainer{
+		Name:       "job",
+		Image:      image,
+		Command:    []string{"/bin/sh", "-c", command},
+		WorkingDir: j.ExecutorConfig["cwd"],
+	}
+	if env := j.ExecutorConfig["env"]; env != "" {
+		for _, e := range strings.Split(env, ",") {
+			kv := strings.SplitN(e, "=", 2)
+			if len(kv) == 2 {
+				container.Env = append(container.Env, k8sEnvVar{Name: kv[0], Value: kv[1]})
+			}
+		}
+	}
+
+	spec := &cj.Spec.JobTemplate.Spec
+	spec.BackoffLimit = int(j.Retries)
+	spec.Template.Spec = k8sPodSpec{
+		RestartPolicy: "Never",
+		Containers:    []k8sContainer{container},
+	}
+
+	return yaml.Marshal(cj)
+}
+
+// shellCommand returns the command of shell jobs.
+func (j *Job) shellCommand() (string, error) {
+	if j.Executor != "shell" || j.ExecutorConfig["command"] == "" {
+		return "", fmt.Errorf("%s: only shell jobs can be exported", ErrExportUnsupported)
+	}
+	return j.ExecutorConfig["command"], nil
+}
+
+// standardSchedule converts the job schedule to the standard five fields
+// cron format, without seconds.
+func (j *Job) standardSchedule() (string, error) {
+	if cronDescriptors[j.Schedule] {
+		return j.Schedule, nil
+	}
+
+	const every = "@every "
+	if strings.HasPrefix(j.Schedule, every) {
+		d, err := time.ParseDuration(strings.TrimPrefix(j.Schedule, every))
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case d == time.Minute:
+			return "* * * * *", nil
+		case d%time.Minute == 0 && d < time.Hour && time.Hour%d == 0:
+			return fmt.Sprintf("*/%d * * * *", d/time.Minute), nil
+		case d == time.Hour:
+			return "0 * * * *", nil
+		case d%time.Hour == 0 && d < 24*time.Hour && (24*time.Hour)%d == 0:
+			return fmt.Sprintf("0 */%d * * *", d/time.Hour), nil
+		case d == 24*time.Hour:
+			return "0 0 * * *", nil
+		}
+		return "", fmt.Errorf("%s: interval %s can't be expressed as a cron schedule", ErrExportUnsupported, d)
+	}
+
+	fields := strings.Fields(j.Schedule)
+	if len(fields) != 6 {
+		return "", fmt.Errorf("%s: unsupported schedule %s", ErrExportUnsupported, j.Schedule)
+	}
+	if fields[0] != "0" && fields[0] != "00" {
+		return "", fmt.Errorf("%s: schedules with seconds are not supported", ErrExportUnsupported)
+	}
+	return strings.Join(fields[1:], " "), nil
+}
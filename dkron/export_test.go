@@ -0,0 +1,105 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobExport_Crontab(t *testing.T) {
+	job := &Job{
+		Name:        "backup",
+		DisplayName: "Nightly backup",
+		Schedule:    "0 30 2 * * *",
+		Timezone:    "Europe/Berlin",
+		OwnerEmail:  "ops@example.com",
+		Executor:    "shell",
+		ExecutorConfig: map[string]string{
+			"command": "backup.sh --date $(date +%F)",
+			"env":     "FOO=bar,BAZ=qux",
+			"cwd":     "/opt",
+		},
+	}
+
+	out, err := job.Export(ExportCrontab, "")
+	require.NoError(t, err)
+	assert.Equal(t, `# Nightly backup
+MAILTO=ops@example.com
+CRON_TZ=Europe/Berlin
+30 2 * * * FOO=bar BAZ=qux cd /opt && backup.sh --date $(date +\%F)
+`, string(out))
+
+	job.Disabled = true
+	out, err = job.Export(ExportCrontab, "")
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "# disabled: 30 2 * * *")
+}
+
+func TestJobExport_K8sCronJob(t *testing.T) {
+	job := &Job{
+		Name:           "report_daily",
+		Schedule:       "@every 6h",
+		Timezone:       "UTC",
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "report --daily", "env": "LANG=C"},
+		Concurrency:    ConcurrencyForbid,
+		Retries:        2,
+		Metadata:       map[string]string{"team": "data"},
+	}
+
+	out, err := job.Export(ExportK8sCronJob, "example/report:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report-daily
+  annotations:
+    dkron.io/job: report_daily
+    dkron.io/metadata.team: data
+spec:
+  schedule: 0 */6 * * *
+  timeZone: UTC
+  concurrencyPolicy: Forbid
+  suspend: false
+  jobTemplate:
+    spec:
+      backoffLimit: 2
+      template:
+        spec:
+          restartPolicy: Never
+          containers:
+          - name: job
+            image: example/report:1.0
+            command:
+            - /bin/sh
+            - -c
+            - report --daily
+            env:
+            - name: LANG
+              value: C
+`, string(out))
+}
+
+func TestJobExport_Unsupported(t *testing.T) {
+	job := &Job{
+		Name:           "test",
+		Schedule:       "@every 7m",
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "true"},
+	}
+
+	_, err := job.Export("nomad", "")
+	assert.Equal(t, ErrExportFormat, err)
+
+	for _, schedule := range []string{"@every 7m", "30 * * * * *", "@manually"} {
+		job.Schedule = schedule
+		_, err = job.Export(ExportCrontab, "")
+		assert.Error(t, err, schedule)
+	}
+
+	job.Schedule = "@hourly"
+	job.Executor = "http"
+	_, err = job.Export(ExportK8sCronJob, "")
+	assert.Error(t, err)
+}
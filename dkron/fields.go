@@ -0,0 +1,78 @@
+package dkron
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fieldsParam is the query param listing the fields of the returned
+// objects, like fields=name,schedule,status,next.
+const fieldsParam = "fields"
+
+// sparse returns v with only the fields listed in the request, for the
+// list views and pollers that don't need the executor config and the
+// processors of every job. Objects and lists of objects are filtered on
+// their top level fields, v is returned as is without fields.
+func sparse(c *gin.Context, v interface{}) (interface{}, error) {
+	fields := requestedFields(c)
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+
+	switch o := out.(type) {
+	case map[string]interface{}:
+		return keepFields(o, fields), nil
+	case []interface{}:
+		for i, e := range o {
+			if m, ok := e.(map[string]interface{}); ok {
+				o[i] = keepFields(m, fields)
+			}
+		}
+	}
+	return out, nil
+}
+
+// requestedFields returns the set of fields of the request, given comma
+// separated or repeating the param.
+func requestedFields(c *gin.Context) map[string]bool {
+	fields := map[string]bool{}
+	for _, p := range c.QueryArray(fieldsParam) {
+		for _, f := range strings.Split(p, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				fields[f] = true
+			}
+		}
+	}
+	return fields
+}
+
+func keepFields(m map[string]interface{}, fields map[string]bool) map[string]interface{} {
+	for k := range m {
+		if !fields[k] {
+			delete(m, k)
+		}
+	}
+	return m
+}
+
+// renderSparseJSON renders v with only the fields listed in the request.
+func renderSparseJSON(c *gin.Context, status int, v interface{}) {
+	out, err := sparse(c, v)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, status, out)
+}
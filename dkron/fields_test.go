@@ -0,0 +1,43 @@
+package dkron
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSparseJSON(t *testing.T) {
+	render := func(url string, v interface{}) string {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodGet, url, nil)
+		renderSparseJSON(c, http.StatusOK, v)
+		return w.Body.String()
+	}
+
+	jobs := []*Job{scaffoldJob(), scaffoldJob()}
+
+	var sparseJobs []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(render("/v1/jobs?fields=name,schedule&fields=status", jobs)), &sparseJobs))
+	require.Len(t, sparseJobs, 2)
+	assert.Len(t, sparseJobs[0], 3)
+	assert.Equal(t, jobs[0].Name, sparseJobs[0]["name"])
+	assert.Contains(t, sparseJobs[1], "status")
+	assert.NotContains(t, sparseJobs[1], "executor_config")
+
+	ex := NewExecution("test")
+	var sparseEx map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(render("/v1/executions/x?fields=job_name,success", ex)), &sparseEx))
+	assert.Len(t, sparseEx, 2)
+	assert.Equal(t, "test", sparseEx["job_name"])
+
+	// Without fields everything is returned
+	var full []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(render("/v1/jobs", jobs)), &full))
+	assert.Contains(t, full[0], "executor_config")
+}
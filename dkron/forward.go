@@ -0,0 +1,102 @@
+package dkron
+
+import (
+	"context"
+	"errors"
+
+	metrics "github.com/armon/go-metrics"
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// forwardedKey is the metadata key set on the requests forwarded to the
+// leader, holding the name of the server that forwarded them.
+const forwardedKey = "dkron-forwarded-by"
+
+var (
+	// ErrNoLeader is returned when forwarding a request while the cluster
+	// has no leader.
+	ErrNoLeader = errors.New("grpc: Error, the cluster has no leader")
+	// ErrForwardedNotLeader is returned when a forwarded request reaches a
+	// server that lost the leadership, it's not forwarded again.
+	ErrForwardedNotLeader = errors.New("grpc: Error, the request was forwarded to a server that is no longer the leader")
+)
+
+// leaderMethods are the methods only the leader serves, with the type of
+// their response. Followers forward them to the leader, ExecutionDone isn't
+// here as it already forwards itself.
+var leaderMethods = map[string]func() interface{}{
+	"/types.Dkron/SetJob":                  func() interface{} { return new(proto.SetJobResponse) },
+	"/types.Dkron/DeleteJob":               func() interface{} { return new(proto.DeleteJobResponse) },
+	"/types.Dkron/RunJob":                  func() interface{} { return new(proto.RunJobResponse) },
+	"/types.Dkron/ResetJob":                func() interface{} { return new(proto.ResetJobResponse) },
+	"/types.Dkron/RestoreJob":              func() interface{} { return new(proto.RestoreJobResponse) },
+	"/types.Dkron/SetReadOnly":             func() interface{} { return new(proto.SetReadOnlyResponse) },
+	"/types.Dkron/SetMaintenanceWindow":    func() interface{} { return new(proto.SetMaintenanceWindowResponse) },
+	"/types.Dkron/DeleteMaintenanceWindow": func() interface{} { return new(proto.DeleteMaintenanceWindowResponse) },
+	"/types.Dkron/RaftRemovePeerByID":      func() interface{} { return new(empty.Empty) },
+	"/types.Dkron/SetExecution":            func() interface{} { return new(empty.Empty) },
+	"/types.Dkron/Backfill":                func() interface{} { return new(proto.BackfillResponse) },
+	"/types.Dkron/CancelBackfill":          func() interface{} { return new(proto.CancelBackfillResponse) },
+	"/types.Dkron/AcquireLock":             func() interface{} { return new(proto.AcquireLockResponse) },
+	"/types.Dkron/ReleaseLock":             func() interface{} { return new(empty.Empty) },
+	"/types.Dkron/SetKV":                   func() interface{} { return new(empty.Empty) },
+	"/types.Dkron/DeleteKV":                func() interface{} { return new(empty.Empty) },
+	"/types.Dkron/ReadIndex":               func() interface{} { return new(proto.ReadIndexResponse) },
+	"/types.Dkron/SetSilence":              func() interface{} { return new(proto.SetSilenceResponse) },
+	"/types.Dkron/DeleteSilence":           func() interface{} { return new(proto.DeleteSilenceResponse) },
+	"/types.Dkron/Compliance":              func() interface{} { return new(proto.ComplianceResponse) },
+	"/types.Dkron/SetCredential":           func() interface{} { return new(proto.SetCredentialResponse) },
+	"/types.Dkron/DeleteCredential":        func() interface{} { return new(proto.DeleteCredentialResponse) },
+	"/types.Dkron/SetSchedulerPause":       func() interface{} { return new(proto.SetSchedulerPauseResponse) },
+	"/types.Dkron/AnnotateExecution":       func() interface{} { return new(proto.AnnotateExecutionResponse) },
+}
+
+// forwardToLeader is a gRPC interceptor forwarding the requests only the
+// leader serves when this server is a follower, along with their metadata,
+// so clients can send them to any server.
+func (grpcs *GRPCServer) forwardToLeader(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	newResponse, ok := leaderMethods[info.FullMethod]
+	if !ok || grpcs.agent.IsLeader() {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	// Requests are forwarded once, the leader could change meanwhile
+	if len(md.Get(forwardedKey)) > 0 {
+		return nil, ErrForwardedNotLeader
+	}
+
+	addr := grpcs.agent.raft.Leader()
+	if addr == "" {
+		return nil, ErrNoLeader
+	}
+
+	log.WithFields(logrus.Fields{
+		"method": info.FullMethod,
+		"leader": addr,
+	}).Debug("grpc: Forwarding request to the leader")
+	metrics.IncrCounterWithLabels([]string{"grpc", "forwarded"}, 1, []metrics.Label{
+		{Name: "method", Value: info.FullMethod},
+	})
+
+	conn, err := grpcs.agent.GRPCClient.Connect(string(addr))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	out := md.Copy()
+	delete(out, ":authority")
+	delete(out, "user-agent")
+	out.Set(forwardedKey, grpcs.agent.config.NodeName)
+
+	resp := newResponse()
+	if err := conn.Invoke(metadata.NewOutgoingContext(ctx, out), info.FullMethod, req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
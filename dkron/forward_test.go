@@ -0,0 +1,91 @@
+package dkron
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/hashicorp/serf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGRPCForwardToLeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dir2, err := ioutil.TempDir("", "dkron-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir2)
+
+	ip1, returnFn1 := testutil.TakeIP()
+	defer returnFn1()
+	a1Addr := ip1.String()
+	ip2, returnFn2 := testutil.TakeIP()
+	defer returnFn2()
+
+	c := DefaultConfig()
+	c.BindAddr = a1Addr
+	c.NodeName = "test-forward1"
+	c.Server = true
+	c.LogLevel = logLevel
+	c.BootstrapExpect = 1
+	c.DevMode = true
+	c.DataDir = dir
+	c.ReconcileInterval = time.Second
+
+	a1 := NewAgent(c)
+	require.NoError(t, a1.Start())
+	defer a1.Stop()
+
+	for !a1.IsLeader() {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	c = DefaultConfig()
+	c.BindAddr = ip2.String()
+	c.StartJoin = []string{a1Addr + ":8946"}
+	c.NodeName = "test-forward2"
+	c.Server = true
+	c.LogLevel = logLevel
+	c.DataDir = dir2
+
+	a2 := NewAgent(c)
+	require.NoError(t, a2.Start())
+	defer a2.Stop()
+
+	// Wait for the follower to know the leader
+	for a2.raft.Leader() == "" {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.False(t, a2.IsLeader())
+
+	conn, err := a2.GRPCClient.Connect(a2.advertiseRPCAddr())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := types.NewDkronClient(conn)
+
+	// Writes sent to the follower are applied by the leader
+	job := &Job{
+		Name:     "forwarded",
+		Schedule: "@every 1h",
+		Executor: "shell",
+		Disabled: true,
+	}
+	_, err = client.SetJob(context.Background(), &types.SetJobRequest{Job: job.ToProto()})
+	require.NoError(t, err)
+
+	stored, err := a1.Store.GetJob("forwarded", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "@every 1h", stored.Schedule)
+
+	// Forwarded requests aren't forwarded again
+	ctx := metadata.AppendToOutgoingContext(context.Background(), forwardedKey, "test-forward1")
+	_, err = client.DeleteJob(ctx, &types.DeleteJobRequest{JobName: "forwarded"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrForwardedNotLeader.Error())
+}
@@ -2,9 +2,12 @@ package dkron
 
 import (
 	"io"
+	"sync/atomic"
+	"time"
 
 	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/raft"
 )
 
@@ -23,6 +26,60 @@ const (
 	// ExecutionDoneType is the command to perform the logic needed once an exeuction
 	// is done.
 	ExecutionDoneType
+	// ResetJobType is the command used to reset the circuit breaker of a job.
+	ResetJobType
+	// RepairStoreType is the command used to repair the store inconsistencies.
+	RepairStoreType
+	// SetReadOnlyType is the command used to turn the cluster read-only switch on or off.
+	SetReadOnlyType
+	// RestoreJobType is the command used to restore a Job from the trash.
+	RestoreJobType
+	// SetMaintenanceWindowType is the command used to store a maintenance window.
+	SetMaintenanceWindowType
+	// DeleteMaintenanceWindowType is the command used to delete a maintenance window.
+	DeleteMaintenanceWindowType
+	// SetDispatchIntentType is the command used to journal the dispatch of an execution.
+	SetDispatchIntentType
+	// DeleteDispatchIntentType is the command used to complete the dispatch of an execution.
+	DeleteDispatchIntentType
+	// SetBackfillType is the command used to store a backfill and its progress.
+	SetBackfillType
+	// AcquireLockType is the command used to acquire or renew a lock.
+	AcquireLockType
+	// ReleaseLockType is the command used to release a lock.
+	ReleaseLockType
+	// SetKVType is the command used to set a key of the KV store.
+	SetKVType
+	// DeleteKVType is the command used to delete a key of the KV store.
+	DeleteKVType
+	// SetScheduleMacrosType is the command used to set the schedule macros of the cluster.
+	SetScheduleMacrosType
+	// SetSilenceType is the command used to store a notification silence.
+	SetSilenceType
+	// DeleteSilenceType is the command used to delete a notification silence.
+	DeleteSilenceType
+	// ComplianceType is the command used to apply a compliance action to
+	// the execution history of a job.
+	ComplianceType
+	// CommitChangesType is the command used to commit the changes
+	// delivered to the change data capture sink.
+	CommitChangesType
+	// SetCredentialType is the command used to store a credential.
+	SetCredentialType
+	// DeleteCredentialType is the command used to delete a credential.
+	DeleteCredentialType
+	// SchedulerPauseType is the command used to pause or resume the
+	// dispatch of jobs of the whole cluster.
+	SchedulerPauseType
+	// SetExecutionRetentionType is the command used to set the execution
+	// retention of the cluster.
+	SetExecutionRetentionType
+	// AnnotateExecutionType is the command used to append annotations to
+	// an execution.
+	AnnotateExecutionType
+	// MarkDispatchedType is the command used to journal the dispatch of an
+	// execution to a node.
+	MarkDispatchedType
 )
 
 // LogApplier is the definition of a function that can apply a Raft log
@@ -35,6 +92,10 @@ type LogAppliers map[MessageType]LogApplier
 type dkronFSM struct {
 	store Storage
 
+	// index is the raft index of the last log applied to the store since
+	// it was last restored, zero if none.
+	index uint64
+
 	// proAppliers holds the set of pro only LogAppliers
 	proAppliers LogAppliers
 }
@@ -51,9 +112,16 @@ func newFSM(store Storage, logAppliers LogAppliers) *dkronFSM {
 func (d *dkronFSM) Apply(l *raft.Log) interface{} {
 	buf := l.Data
 	msgType := MessageType(buf[0])
+	defer atomic.StoreUint64(&d.index, l.Index)
 
 	log.WithField("command", msgType).Debug("fsm: received command")
 
+	// The changes of the log are recorded by the store as it writes them
+	d.store.SetApplyIndex(l.Index)
+	return d.apply(msgType, buf, l.Index)
+}
+
+func (d *dkronFSM) apply(msgType MessageType, buf []byte, index uint64) interface{} {
 	switch msgType {
 	case SetJobType:
 		return d.applySetJob(buf[1:])
@@ -63,11 +131,59 @@ func (d *dkronFSM) Apply(l *raft.Log) interface{} {
 		return d.applyExecutionDone(buf[1:])
 	case SetExecutionType:
 		return d.applySetExecution(buf[1:])
+	case ResetJobType:
+		return d.applyResetJob(buf[1:])
+	case RepairStoreType:
+		return d.applyRepairStore()
+	case SetReadOnlyType:
+		return d.applySetReadOnly(buf[1:])
+	case RestoreJobType:
+		return d.applyRestoreJob(buf[1:])
+	case SetMaintenanceWindowType:
+		return d.applySetMaintenanceWindow(buf[1:])
+	case DeleteMaintenanceWindowType:
+		return d.applyDeleteMaintenanceWindow(buf[1:])
+	case SetDispatchIntentType:
+		return d.applySetDispatchIntent(buf[1:])
+	case DeleteDispatchIntentType:
+		return d.applyDeleteDispatchIntent(buf[1:])
+	case SetBackfillType:
+		return d.applySetBackfill(buf[1:])
+	case AcquireLockType:
+		return d.applyAcquireLock(buf[1:])
+	case ReleaseLockType:
+		return d.applyReleaseLock(buf[1:])
+	case SetKVType:
+		return d.applySetKV(buf[1:])
+	case DeleteKVType:
+		return d.applyDeleteKV(buf[1:])
+	case SetScheduleMacrosType:
+		return d.applySetScheduleMacros(buf[1:])
+	case SetSilenceType:
+		return d.applySetSilence(buf[1:])
+	case DeleteSilenceType:
+		return d.applyDeleteSilence(buf[1:])
+	case ComplianceType:
+		return d.applyCompliance(buf[1:])
+	case CommitChangesType:
+		return d.applyCommitChanges(buf[1:])
+	case SetCredentialType:
+		return d.applySetCredential(buf[1:])
+	case DeleteCredentialType:
+		return d.applyDeleteCredential(buf[1:])
+	case SchedulerPauseType:
+		return d.applySchedulerPause(buf[1:])
+	case SetExecutionRetentionType:
+		return d.applySetExecutionRetention(buf[1:])
+	case AnnotateExecutionType:
+		return d.applyAnnotateExecution(buf[1:])
+	case MarkDispatchedType:
+		return d.applyMarkDispatched(buf[1:])
 	}
 
 	// Check enterprise only message types.
 	if applier, ok := d.proAppliers[msgType]; ok {
-		return applier(buf[1:], l.Index)
+		return applier(buf[1:], index)
 	}
 
 	return nil
@@ -90,13 +206,245 @@ func (d *dkronFSM) applyDeleteJob(buf []byte) interface{} {
 	if err := proto.Unmarshal(buf, &djr); err != nil {
 		return err
 	}
-	job, err := d.store.DeleteJob(djr.GetJobName())
+	options := &DeleteOptions{
+		Cascade:         djr.GetCascade(),
+		Now:             time.Now(),
+		TrashExecutions: djr.GetTrashExecutions(),
+	}
+	if djr.Now != nil {
+		options.Now, _ = ptypes.Timestamp(djr.Now)
+	}
+	if djr.TrashExpiresAt != nil {
+		options.TrashExpiresAt, _ = ptypes.Timestamp(djr.TrashExpiresAt)
+	} else if djr.TrashRetentionSeconds > 0 {
+		// Commands of leaders predating the expiry in the command
+		options.TrashExpiresAt = options.Now.Add(time.Duration(djr.TrashRetentionSeconds) * time.Second)
+	}
+	jobs, err := d.store.DeleteJobs(djr.GetJobName(), options)
+	if err != nil {
+		return err
+	}
+	return jobs
+}
+
+func (d *dkronFSM) applyRestoreJob(buf []byte) interface{} {
+	var rjr dkronpb.RestoreJobRequest
+	if err := proto.Unmarshal(buf, &rjr); err != nil {
+		return err
+	}
+	job, err := d.store.RestoreJob(rjr.GetJobName())
+	if err != nil {
+		return err
+	}
+	return job
+}
+
+func (d *dkronFSM) applyResetJob(buf []byte) interface{} {
+	var rjr dkronpb.ResetJobRequest
+	if err := proto.Unmarshal(buf, &rjr); err != nil {
+		return err
+	}
+	job, err := d.store.ResetJob(rjr.GetJobName())
 	if err != nil {
 		return err
 	}
 	return job
 }
 
+func (d *dkronFSM) applyAnnotateExecution(buf []byte) interface{} {
+	var aer dkronpb.AnnotateExecutionRequest
+	if err := proto.Unmarshal(buf, &aer); err != nil {
+		return err
+	}
+	execution, err := d.store.AnnotateExecution(aer.JobName, aer.Key, aer.Annotations)
+	if err != nil {
+		return err
+	}
+	return execution
+}
+
+func (d *dkronFSM) applyRepairStore() interface{} {
+	repaired, err := d.store.Repair()
+	if err != nil {
+		return err
+	}
+	return repaired
+}
+
+func (d *dkronFSM) applySetReadOnly(buf []byte) interface{} {
+	var srr dkronpb.SetReadOnlyRequest
+	if err := proto.Unmarshal(buf, &srr); err != nil {
+		return err
+	}
+	return d.store.SetReadOnly(srr.ReadOnly)
+}
+
+func (d *dkronFSM) applySetMaintenanceWindow(buf []byte) interface{} {
+	var smr dkronpb.SetMaintenanceWindowRequest
+	if err := proto.Unmarshal(buf, &smr); err != nil {
+		return err
+	}
+	return d.store.SetMaintenanceWindow(NewMaintenanceWindowFromProto(smr.Window))
+}
+
+func (d *dkronFSM) applySetSilence(buf []byte) interface{} {
+	var ssr dkronpb.SetSilenceRequest
+	if err := proto.Unmarshal(buf, &ssr); err != nil {
+		return err
+	}
+	return d.store.SetSilence(NewSilenceFromProto(ssr.Silence))
+}
+
+func (d *dkronFSM) applyDeleteSilence(buf []byte) interface{} {
+	var dsr dkronpb.DeleteSilenceRequest
+	if err := proto.Unmarshal(buf, &dsr); err != nil {
+		return err
+	}
+	s, err := d.store.DeleteSilence(dsr.Id)
+	if err != nil {
+		return err
+	}
+	return s
+}
+
+func (d *dkronFSM) applySetCredential(buf []byte) interface{} {
+	var scr dkronpb.SetCredentialRequest
+	if err := proto.Unmarshal(buf, &scr); err != nil {
+		return err
+	}
+	return d.store.SetCredential(NewCredentialFromProto(scr.Credential))
+}
+
+func (d *dkronFSM) applyDeleteCredential(buf []byte) interface{} {
+	var dcr dkronpb.DeleteCredentialRequest
+	if err := proto.Unmarshal(buf, &dcr); err != nil {
+		return err
+	}
+	c, err := d.store.DeleteCredential(dcr.Name)
+	if err != nil {
+		return err
+	}
+	return c
+}
+
+func (d *dkronFSM) applyCompliance(buf []byte) interface{} {
+	var cr dkronpb.ComplianceRequest
+	if err := proto.Unmarshal(buf, &cr); err != nil {
+		return err
+	}
+	e, err := d.store.ApplyComplianceEvent(NewComplianceEventFromProto(cr.Event))
+	if err != nil {
+		return err
+	}
+	return e
+}
+
+func (d *dkronFSM) applySchedulerPause(buf []byte) interface{} {
+	var spr dkronpb.SetSchedulerPauseRequest
+	if err := proto.Unmarshal(buf, &spr); err != nil {
+		return err
+	}
+	e, err := d.store.ApplySchedulerEvent(NewSchedulerEventFromProto(spr.Event))
+	if err != nil {
+		return err
+	}
+	return e
+}
+
+func (d *dkronFSM) applyCommitChanges(buf []byte) interface{} {
+	var ccr dkronpb.CommitChangesRequest
+	if err := proto.Unmarshal(buf, &ccr); err != nil {
+		return err
+	}
+	return d.store.CommitChanges(ccr.Offset, int(ccr.Seq))
+}
+
+func (d *dkronFSM) applyDeleteMaintenanceWindow(buf []byte) interface{} {
+	var dmr dkronpb.DeleteMaintenanceWindowRequest
+	if err := proto.Unmarshal(buf, &dmr); err != nil {
+		return err
+	}
+	w, err := d.store.DeleteMaintenanceWindow(dmr.Name)
+	if err != nil {
+		return err
+	}
+	return w
+}
+
+func (d *dkronFSM) applySetDispatchIntent(buf []byte) interface{} {
+	var pbdi dkronpb.DispatchIntent
+	if err := proto.Unmarshal(buf, &pbdi); err != nil {
+		return err
+	}
+	return d.store.SetDispatchIntent(NewDispatchIntentFromProto(&pbdi))
+}
+
+func (d *dkronFSM) applyDeleteDispatchIntent(buf []byte) interface{} {
+	var ddr dkronpb.DeleteDispatchIntentRequest
+	if err := proto.Unmarshal(buf, &ddr); err != nil {
+		return err
+	}
+	return d.store.DeleteDispatchIntent(ddr.JobName, ddr.Group, ddr.MatrixItem)
+}
+
+func (d *dkronFSM) applyMarkDispatched(buf []byte) interface{} {
+	var mdr dkronpb.MarkDispatchedRequest
+	if err := proto.Unmarshal(buf, &mdr); err != nil {
+		return err
+	}
+	return d.store.MarkDispatched(mdr.JobName, mdr.Group, mdr.MatrixItem, mdr.NodeName)
+}
+
+func (d *dkronFSM) applySetBackfill(buf []byte) interface{} {
+	var pbb dkronpb.Backfill
+	if err := proto.Unmarshal(buf, &pbb); err != nil {
+		return err
+	}
+	return d.store.SetBackfill(NewBackfillFromProto(&pbb))
+}
+
+func (d *dkronFSM) applyAcquireLock(buf []byte) interface{} {
+	var alr dkronpb.AcquireLockRequest
+	if err := proto.Unmarshal(buf, &alr); err != nil {
+		return err
+	}
+	// The time of the request keeps the expiration checks deterministic
+	now, _ := ptypes.Timestamp(alr.Now)
+	acquire := d.store.AcquireLock
+	if alr.Renew {
+		acquire = d.store.RenewLock
+	}
+	l, err := acquire(NewLockFromProto(alr.Lock), now)
+	if err != nil {
+		return err
+	}
+	return l
+}
+
+func (d *dkronFSM) applyReleaseLock(buf []byte) interface{} {
+	var rlr dkronpb.ReleaseLockRequest
+	if err := proto.Unmarshal(buf, &rlr); err != nil {
+		return err
+	}
+	return d.store.ReleaseLock(rlr.Name, rlr.Holder)
+}
+
+func (d *dkronFSM) applySetKV(buf []byte) interface{} {
+	var skr dkronpb.SetKVRequest
+	if err := proto.Unmarshal(buf, &skr); err != nil {
+		return err
+	}
+	return d.store.SetKV(NewKVFromProto(skr.Kv))
+}
+
+func (d *dkronFSM) applyDeleteKV(buf []byte) interface{} {
+	var dkr dkronpb.DeleteKVRequest
+	if err := proto.Unmarshal(buf, &dkr); err != nil {
+		return err
+	}
+	return d.store.DeleteKV(dkr.Namespace, dkr.Key)
+}
+
 func (d *dkronFSM) applyExecutionDone(buf []byte) interface{} {
 	var execDoneReq dkronpb.ExecutionDoneRequest
 	if err := proto.Unmarshal(buf, &execDoneReq); err != nil {
@@ -137,9 +485,18 @@ func (d *dkronFSM) Snapshot() (raft.FSMSnapshot, error) {
 // Restore stores the key-value store to a previous state.
 func (d *dkronFSM) Restore(r io.ReadCloser) error {
 	defer r.Close()
+	// The index of the snapshot is unknown here
+	atomic.StoreUint64(&d.index, 0)
 	return d.store.Restore(r)
 }
 
+// appliedIndex returns the raft index of the last log applied to the store
+// since it was last restored, zero if none. Unlike the applied index of
+// raft, the store already holds its changes.
+func (d *dkronFSM) appliedIndex() uint64 {
+	return atomic.LoadUint64(&d.index)
+}
+
 type dkronSnapshot struct {
 	store Storage
 }
@@ -159,3 +516,19 @@ func (d *dkronSnapshot) Persist(sink raft.SnapshotSink) error {
 }
 
 func (d *dkronSnapshot) Release() {}
+
+func (d *dkronFSM) applySetScheduleMacros(buf []byte) interface{} {
+	var pbm dkronpb.ScheduleMacros
+	if err := proto.Unmarshal(buf, &pbm); err != nil {
+		return err
+	}
+	return d.store.SetScheduleMacros(pbm.Macros)
+}
+
+func (d *dkronFSM) applySetExecutionRetention(buf []byte) interface{} {
+	var pbr dkronpb.ExecutionRetention
+	if err := proto.Unmarshal(buf, &pbr); err != nil {
+		return err
+	}
+	return d.store.SetExecutionRetention(time.Duration(pbr.RetentionSeconds) * time.Second)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/distribworks/dkron/v3/plugin"
 	proto "github.com/distribworks/dkron/v3/plugin/types"
 	pb "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -54,7 +56,7 @@ func NewGRPCServer(agent *Agent) DkronGRPCServer {
 
 // Serve creates and start a new gRPC dkron server
 func (grpcs *GRPCServer) Serve(lis net.Listener) error {
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(grpcs.forwardToLeader))
 	proto.RegisterDkronServer(grpcServer, grpcs)
 
 	as := NewAgentServer(grpcs.agent)
@@ -85,6 +87,25 @@ func (grpcs *GRPCServer) SetJob(ctx context.Context, setJobReq *proto.SetJobRequ
 		"job": setJobReq.Job.Name,
 	}).Debug("grpc: Received SetJob")
 
+	if err := NewJobFromProto(setJobReq.Job).ValidateOwner(grpcs.agent.config.RequiredOwnerFields); err != nil {
+		return nil, err
+	}
+	if err := grpcs.agent.checkJobCredentials(NewJobFromProto(setJobReq.Job)); err != nil {
+		return nil, err
+	}
+	if err := grpcs.agent.checkWritable(setJobReq.AdminToken, setJobReq.Job.Name); err != nil {
+		return nil, err
+	}
+
+	// The leader stamps the changes, the clock of the node taking the
+	// request could be behind the stored change and hide it from the
+	// version history
+	updatedAt, _ := ptypes.TimestampProto(grpcs.agent.changeTime(setJobReq.Job.Name))
+	setJobReq.Job.UpdatedAt = &proto.Job_NullableTime{
+		HasValue: true,
+		Time:     updatedAt,
+	}
+
 	if err := grpcs.agent.applySetJob(setJobReq.Job); err != nil {
 		return nil, err
 	}
@@ -105,23 +126,44 @@ func (grpcs *GRPCServer) DeleteJob(ctx context.Context, delJobReq *proto.DeleteJ
 	defer metrics.MeasureSince([]string{"grpc", "delete_job"}, time.Now())
 	log.WithField("job", delJobReq.GetJobName()).Debug("grpc: Received DeleteJob")
 
+	// Every job of the tree must be writable when cascading
+	names := grpcs.agent.deletedJobNames(delJobReq.JobName, delJobReq.Cascade)
+	if err := grpcs.agent.checkWritable(delJobReq.AdminToken, names...); err != nil {
+		return nil, err
+	}
+	// The token isn't kept in the raft log
+	delJobReq.AdminToken = ""
+
+	// The leader decides whether deleted jobs go to the trash and until
+	// when, the servers apply the same times
+	now := time.Now()
+	delJobReq.Now, _ = ptypes.TimestampProto(now)
+	delJobReq.TrashRetentionSeconds = 0
+	delJobReq.TrashExpiresAt = nil
+	if grpcs.agent.config.TrashRetention > 0 {
+		delJobReq.TrashExpiresAt, _ = ptypes.TimestampProto(now.Add(grpcs.agent.config.TrashRetention))
+	}
+	delJobReq.TrashExecutions = grpcs.agent.config.TrashExecutions
+
 	cmd, err := Encode(DeleteJobType, delJobReq)
 	if err != nil {
 		return nil, err
 	}
-	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	af := grpcs.agent.RaftApply(cmd)
 	if err := af.Error(); err != nil {
 		return nil, err
 	}
 	res := af.Response()
-	job, ok := res.(*Job)
+	jobs, ok := res.([]*Job)
 	if !ok {
 		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeleteJob: %v", res)
 	}
-	jpb := job.ToProto()
+	jpb := jobs[0].ToProto()
 
-	// If everything is ok, remove the job
-	grpcs.agent.sched.RemoveJob(job)
+	// If everything is ok, remove the jobs
+	for _, job := range jobs {
+		grpcs.agent.sched.RemoveJob(job)
+	}
 
 	return &proto.DeleteJobResponse{Job: jpb}, nil
 }
@@ -144,6 +186,7 @@ func (grpcs *GRPCServer) GetJob(ctx context.Context, getJobReq *proto.GetJobRequ
 	gjr.Job.Name = j.Name
 	gjr.Job.Executor = j.Executor
 	gjr.Job.ExecutorConfig = j.ExecutorConfig
+	gjr.Job.Steps = j.ToProto().Steps
 
 	return gjr, nil
 }
@@ -152,43 +195,72 @@ func (grpcs *GRPCServer) GetJob(ctx context.Context, getJobReq *proto.GetJobRequ
 func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *proto.ExecutionDoneRequest) (*proto.ExecutionDoneResponse, error) {
 	defer metrics.MeasureSince([]string{"grpc", "execution_done"}, time.Now())
 	log.WithFields(logrus.Fields{
-		"group": execDoneReq.Execution.Group,
-		"job":   execDoneReq.Execution.JobName,
-		"from":  execDoneReq.Execution.NodeName,
+		"group":        execDoneReq.Execution.Group,
+		"job":          execDoneReq.Execution.JobName,
+		"from":         execDoneReq.Execution.NodeName,
+		"execution_id": execDoneReq.Execution.Id,
 	}).Debug("grpc: Received execution done")
 
 	// Get the leader address and compare with the current node address.
 	// Forward the request to the leader in case current node is not the leader.
 	if !grpcs.agent.IsLeader() {
 		addr := grpcs.agent.raft.Leader()
-		grpcs.agent.GRPCClient.ExecutionDone(string(addr), NewExecutionFromProto(execDoneReq.Execution))
+		// Report failed forwards so the agent keeps the result to retry
+		if err := grpcs.agent.GRPCClient.ExecutionDone(string(addr), NewExecutionFromProto(execDoneReq.Execution)); err != nil {
+			return nil, err
+		}
 		return nil, ErrNotLeader
 	}
 
+	// Shadow runs only record their executions
+	if execDoneReq.Execution.Shadow != "" {
+		return grpcs.shadowExecutionDone(execDoneReq.Execution)
+	}
+
 	// This is the leader at this point, so process the execution, encode the value and apply the log to the cluster.
+	grpcs.agent.assignExecutionID(execDoneReq.Execution)
+
 	// Get the defined output types for the job, and call them
 	job, err := grpcs.agent.Store.GetJob(execDoneReq.Execution.JobName, nil)
 	if err != nil {
 		return nil, err
 	}
+	job = grpcs.agent.withNamespaceDefaults(job)
+
+	prevFailures := job.ConsecutiveFailures
+	prevLevel := job.EscalationLevel
 
 	pbex := *execDoneReq.Execution
+	pbex.Output = grpcs.agent.redactOutput(job, pbex.Output)
+	pbex.Cost = grpcs.agent.executionCost(job, &pbex)
 	for k, v := range job.Processors {
 		log.WithField("plugin", k).Info("grpc: Processing execution with plugin")
-		if processor, ok := grpcs.agent.ProcessorPlugins[k]; ok {
+		if processor, done, ok := grpcs.agent.processor(k); ok {
 			v["reporting_node"] = grpcs.agent.config.NodeName
 			pbex = processor.Process(&plugin.ProcessorArgs{Execution: pbex, Config: v})
+			done()
 		} else {
 			log.WithField("plugin", k).Error("grpc: Specified plugin not found")
 		}
 	}
 
+	// The output of a matrix discovery must list the items of the run
+	var discovered []map[string]string
+	if pbex.Discovery && pbex.Success {
+		discovered, err = discoveredItems(job, string(pbex.Output))
+		if err != nil {
+			pbex.Success = false
+			pbex.Output = append(pbex.Output, []byte("\n"+err.Error())...)
+		}
+		pbex.MatrixSize = int32(len(discovered))
+	}
+
 	execDoneReq.Execution = &pbex
 	cmd, err := Encode(ExecutionDoneType, execDoneReq)
 	if err != nil {
 		return nil, err
 	}
-	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	af := grpcs.agent.RaftApply(cmd)
 	if err := af.Error(); err != nil {
 		return nil, err
 	}
@@ -199,10 +271,24 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *proto.E
 		log.WithError(err).WithField("job", job.Name).Error("grpc: Error retrieving job from store")
 		return nil, err
 	}
+	job = grpcs.agent.withNamespaceDefaults(job)
+
+	// Stop scheduling the job if its circuit breaker tripped
+	if job.Status == StatusTripped {
+		log.WithFields(logrus.Fields{
+			"job":                  job.Name,
+			"consecutive_failures": job.ConsecutiveFailures,
+		}).Warn("grpc: Circuit breaker tripped, disabling job")
+		metrics.IncrCounter([]string{"grpc", "job_tripped"}, 1)
+		metrics.IncrCounterWithLabels([]string{"job", "tripped"}, 1, jobMetricLabels(job, StatusTripped))
+		grpcs.agent.sched.RemoveJob(job)
+	}
 
 	// If the execution failed, retry it until retries limit (default: don't retry)
 	execution := NewExecutionFromProto(&pbex)
-	if !execution.Success && uint(execution.Attempt) < job.Retries+1 {
+	emitExecutionMetrics(job, execution)
+
+	if !execution.Success && !execution.Skipped && uint(execution.Attempt) < job.Retries+1 && job.Status != StatusTripped {
 		execution.Attempt++
 
 		// Keep all execution properties intact except the last output
@@ -222,6 +308,15 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *proto.E
 		}, nil
 	}
 
+	// The items of a matrix run once its discovery listed them
+	if execution.Discovery && len(discovered) > 0 {
+		go grpcs.agent.runDiscoveredItems(job, execution, discovered)
+		return &proto.ExecutionDoneResponse{
+			From:    grpcs.agent.config.NodeName,
+			Payload: []byte("saved"),
+		}, nil
+	}
+
 	exg, err := grpcs.agent.Store.GetExecutionGroup(execution)
 	if err != nil {
 		log.WithError(err).WithField("group", execution.Group).Error("grpc: Error getting execution group.")
@@ -229,21 +324,72 @@ func (grpcs *GRPCServer) ExecutionDone(ctx context.Context, execDoneReq *proto.E
 	}
 
 	// Send notification
-	if err := Notification(grpcs.agent.config, execution, exg, job).Send(); err != nil {
-		return nil, err
+	if silence := grpcs.agent.silenced(job, time.Now()); silence != nil {
+		log.WithFields(logrus.Fields{
+			"job":     job.Name,
+			"silence": silence.ID,
+			"reason":  silence.Reason,
+		}).Info("grpc: Notification silenced")
+		metrics.IncrCounterWithLabels([]string{"job", "silenced"}, 1, []metrics.Label{
+			{Name: "job", Value: job.Name},
+			{Name: "namespace", Value: job.Namespace()},
+		})
+	} else {
+		if notifyExecution(job, execution, prevFailures) {
+			if err := Notification(grpcs.agent.notificationConfig(job), execution, exg, job).Send(); err != nil {
+				return nil, err
+			}
+		} else {
+			log.WithField("job", job.Name).Debug("grpc: Skipping notification, the job state didn't change")
+		}
+		grpcs.agent.escalate(job, execution, exg, prevLevel)
 	}
 
-	// Jobs that have dependent jobs are a bit more expensive because we need to call the Status() method for every execution.
-	// Check first if there's dependent jobs and then check for the job status to begin execution dependent jobs on success.
-	if len(job.DependentJobs) > 0 && job.Status == StatusSuccess {
+	// Runs finishing late are breached here if the leader didn't see
+	// them running past their deadline
+	late := job.pastDeadline(exg, time.Now())
+	if late && execution.Group != job.BreachedGroup {
+		if err := grpcs.agent.breachDeadline(job, exg); err != nil {
+			log.WithError(err).WithField("job", job.Name).Error("grpc: Error breaching the deadline of the job")
+		}
+	}
+	if late && len(job.DependentJobs) > 0 && job.DeadlineAction != DeadlineRunDependents {
+		log.WithFields(logrus.Fields{
+			"job":            job.Name,
+			"dependent_jobs": job.DependentJobs,
+		}).Warn("grpc: Skipping dependent jobs of run past its deadline")
+		metrics.IncrCounterWithLabels([]string{"job", "dependents_skipped"}, 1, []metrics.Label{
+			{Name: "job", Value: job.Name},
+			{Name: "namespace", Value: job.Namespace()},
+		})
+	} else if len(job.DependentJobs) > 0 && (job.Status == StatusSuccess || job.Status == StatusSkipped) && !matrixPending(job, execution, exg) {
+		// Jobs that have dependent jobs are a bit more expensive because we need to call the Status() method for every execution.
+		// Check first if there's dependent jobs and then check for the job status to begin execution dependent jobs on success.
 		for _, djn := range job.DependentJobs {
 			dj, err := grpcs.agent.Store.GetJob(djn, nil)
 			dj.Agent = grpcs.agent
 			if err != nil {
 				return nil, err
 			}
-			log.WithField("job", djn).Debug("grpc: Running dependent job")
-			dj.Run()
+			// Dependent jobs of a skipped run skip or run as they say
+			if job.Status == StatusSkipped {
+				if dj.OnParentSkip == ParentSkipSkip {
+					grpcs.agent.skipRun(dj, execution)
+				}
+				if dj.OnParentSkip != ParentSkipRun {
+					continue
+				}
+			}
+			log.WithField("job", djn).WithField("request_id", execution.RequestID).
+				Debug("grpc: Running dependent job")
+			ex := NewExecution(dj.Name)
+			ex.RequestID = execution.RequestID
+			ex.ScheduledAt = execution.ScheduledAt
+			// The matrix of the dependent job may take its items from the output
+			if dj.Matrix != nil && dj.Matrix.FromParent != "" {
+				ex.parentOutput = execution.Output
+			}
+			dj.startRun(ex)
 		}
 	}
 
@@ -260,8 +406,42 @@ func (grpcs *GRPCServer) Leave(ctx context.Context, in *empty.Empty) (*empty.Emp
 
 // RunJob runs a job in the cluster
 func (grpcs *GRPCServer) RunJob(ctx context.Context, req *proto.RunJobRequest) (*proto.RunJobResponse, error) {
+	log.WithFields(logrus.Fields{
+		"job":        req.JobName,
+		"request_id": req.RequestId,
+	}).Info("grpc: Running job")
+
 	ex := NewExecution(req.JobName)
-	job, err := grpcs.agent.Run(req.JobName, ex)
+	ex.Annotations = req.Annotations
+	ex.RequestID = req.RequestId
+	ex.Params = req.Params
+
+	var job *Job
+	if err := grpcs.agent.checkSchedulerPaused(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if req.Shadow != nil {
+		job, err = grpcs.agent.shadowRun(req.JobName, shadowRunFromProto(req.Shadow), ex)
+	} else {
+		job, err = grpcs.agent.Store.GetJob(req.JobName, nil)
+		if err == nil {
+			err = grpcs.agent.checkMinInterval(job, time.Now())
+		}
+		now := time.Now()
+		if err == nil {
+			err = grpcs.agent.useBudget(job, now)
+		}
+		if err == nil {
+			// Runs that couldn't be dispatched don't use the budget
+			var run *Job
+			if run, err = grpcs.agent.Run(req.JobName, ex); err != nil {
+				grpcs.agent.refundBudget(job, now)
+			}
+			job = run
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -270,6 +450,378 @@ func (grpcs *GRPCServer) RunJob(ctx context.Context, req *proto.RunJobRequest) (
 	return &proto.RunJobResponse{Job: jpb}, nil
 }
 
+// ResetJob resets the circuit breaker of a job and adds it back to
+// the scheduler. This only works on the leader
+func (grpcs *GRPCServer) ResetJob(ctx context.Context, req *proto.ResetJobRequest) (*proto.ResetJobResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "reset_job"}, time.Now())
+	log.WithField("job", req.GetJobName()).Debug("grpc: Received ResetJob")
+
+	if err := grpcs.agent.checkWritable(req.AdminToken, req.JobName); err != nil {
+		return nil, err
+	}
+	// The token isn't kept in the raft log
+	req.AdminToken = ""
+
+	cmd, err := Encode(ResetJobType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	job, ok := res.(*Job)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in ResetJob: %v", res)
+	}
+
+	job.Agent = grpcs.agent
+	if err := grpcs.agent.sched.AddJob(job); err != nil {
+		return nil, err
+	}
+
+	return &proto.ResetJobResponse{Job: job.ToProto()}, nil
+}
+
+// CheckStore checks the store consistency, repairing it first if requested.
+// Repairing only works on the leader
+func (grpcs *GRPCServer) CheckStore(ctx context.Context, req *proto.CheckStoreRequest) (*proto.CheckStoreResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "check_store"}, time.Now())
+	log.WithField("repair", req.Repair).Debug("grpc: Received CheckStore")
+
+	var repaired int
+	if req.Repair {
+		if !grpcs.agent.IsLeader() {
+			return nil, ErrNotLeader
+		}
+		if err := grpcs.agent.checkWritable(req.AdminToken); err != nil {
+			return nil, err
+		}
+		// The token isn't kept in the raft log
+		req.AdminToken = ""
+
+		cmd, err := Encode(RepairStoreType, req)
+		if err != nil {
+			return nil, err
+		}
+		af := grpcs.agent.RaftApply(cmd)
+		if err := af.Error(); err != nil {
+			return nil, err
+		}
+		res := af.Response()
+		if err, ok := res.(error); ok {
+			return nil, err
+		}
+		repaired, _ = res.(int)
+	}
+
+	report, err := grpcs.agent.Store.Check()
+	if err != nil {
+		return nil, err
+	}
+	report.Repaired = repaired
+
+	return report.ToProto(), nil
+}
+
+// RestoreJob restores a job from the trash and adds it back to the
+// scheduler. This only works on the leader
+func (grpcs *GRPCServer) RestoreJob(ctx context.Context, req *proto.RestoreJobRequest) (*proto.RestoreJobResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "restore_job"}, time.Now())
+	log.WithField("job", req.GetJobName()).Debug("grpc: Received RestoreJob")
+
+	if err := grpcs.agent.checkWritable(req.AdminToken, req.JobName); err != nil {
+		return nil, err
+	}
+	// The token isn't kept in the raft log
+	req.AdminToken = ""
+
+	cmd, err := Encode(RestoreJobType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	job, ok := res.(*Job)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in RestoreJob: %v", res)
+	}
+
+	job.Agent = grpcs.agent
+	if err := grpcs.agent.sched.AddJob(job); err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreJobResponse{Job: job.ToProto()}, nil
+}
+
+// SetReadOnly turns the cluster read-only switch on or off. This only
+// works on the leader
+func (grpcs *GRPCServer) SetReadOnly(ctx context.Context, req *proto.SetReadOnlyRequest) (*proto.SetReadOnlyResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_read_only"}, time.Now())
+	log.WithField("read_only", req.ReadOnly).Debug("grpc: Received SetReadOnly")
+
+	cmd, err := Encode(SetReadOnlyType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	return &proto.SetReadOnlyResponse{ReadOnly: req.ReadOnly}, nil
+}
+
+// SetMaintenanceWindow stores a maintenance window. This only works on the leader
+func (grpcs *GRPCServer) SetMaintenanceWindow(ctx context.Context, req *proto.SetMaintenanceWindowRequest) (*proto.SetMaintenanceWindowResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_maintenance_window"}, time.Now())
+	log.WithField("window", req.Window.GetName()).Debug("grpc: Received SetMaintenanceWindow")
+
+	if err := NewMaintenanceWindowFromProto(req.Window).Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd, err := Encode(SetMaintenanceWindowType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	return &proto.SetMaintenanceWindowResponse{Window: req.Window}, nil
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window. This only works on the leader
+func (grpcs *GRPCServer) DeleteMaintenanceWindow(ctx context.Context, req *proto.DeleteMaintenanceWindowRequest) (*proto.DeleteMaintenanceWindowResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_maintenance_window"}, time.Now())
+	log.WithField("window", req.GetName()).Debug("grpc: Received DeleteMaintenanceWindow")
+
+	cmd, err := Encode(DeleteMaintenanceWindowType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	w, ok := res.(*MaintenanceWindow)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeleteMaintenanceWindow: %v", res)
+	}
+
+	return &proto.DeleteMaintenanceWindowResponse{Window: w.ToProto()}, nil
+}
+
+// SetSilence stores a notification silence. This only works on the leader
+func (grpcs *GRPCServer) SetSilence(ctx context.Context, req *proto.SetSilenceRequest) (*proto.SetSilenceResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_silence"}, time.Now())
+	log.WithField("silence", req.Silence.GetId()).Debug("grpc: Received SetSilence")
+
+	if err := NewSilenceFromProto(req.Silence).Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd, err := Encode(SetSilenceType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	return &proto.SetSilenceResponse{Silence: req.Silence}, nil
+}
+
+// DeleteSilence deletes a notification silence. This only works on the leader
+func (grpcs *GRPCServer) DeleteSilence(ctx context.Context, req *proto.DeleteSilenceRequest) (*proto.DeleteSilenceResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_silence"}, time.Now())
+	log.WithField("silence", req.GetId()).Debug("grpc: Received DeleteSilence")
+
+	cmd, err := Encode(DeleteSilenceType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	s, ok := res.(*Silence)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeleteSilence: %v", res)
+	}
+
+	return &proto.DeleteSilenceResponse{Silence: s.ToProto()}, nil
+}
+
+// SetCredential stores a credential. This only works on the leader
+func (grpcs *GRPCServer) SetCredential(ctx context.Context, req *proto.SetCredentialRequest) (*proto.SetCredentialResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_credential"}, time.Now())
+	log.WithField("credential", req.Credential.GetName()).Debug("grpc: Received SetCredential")
+
+	if err := NewCredentialFromProto(req.Credential).Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd, err := Encode(SetCredentialType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	if err, ok := af.Response().(error); ok {
+		return nil, err
+	}
+
+	return &proto.SetCredentialResponse{Credential: req.Credential}, nil
+}
+
+// DeleteCredential deletes a credential no job uses. This only works on
+// the leader
+func (grpcs *GRPCServer) DeleteCredential(ctx context.Context, req *proto.DeleteCredentialRequest) (*proto.DeleteCredentialResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_credential"}, time.Now())
+	log.WithField("credential", req.GetName()).Debug("grpc: Received DeleteCredential")
+
+	jobs, err := grpcs.agent.Store.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+	var users []string
+	for _, j := range jobs {
+		for _, n := range j.Credentials {
+			if n == req.Name {
+				users = append(users, j.Name)
+			}
+		}
+	}
+	if len(users) > 0 {
+		return nil, fmt.Errorf("%s: %s", ErrCredentialInUse, strings.Join(users, ", "))
+	}
+
+	cmd, err := Encode(DeleteCredentialType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	c, ok := res.(*Credential)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in DeleteCredential: %v", res)
+	}
+
+	return &proto.DeleteCredentialResponse{Credential: c.ToProto()}, nil
+}
+
+// Compliance applies a compliance action to the execution history of a
+// job. This only works on the leader
+func (grpcs *GRPCServer) Compliance(ctx context.Context, req *proto.ComplianceRequest) (*proto.ComplianceResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "compliance"}, time.Now())
+	log.WithFields(logrus.Fields{
+		"job":    req.Event.GetJobName(),
+		"action": req.Event.GetAction(),
+	}).Debug("grpc: Received Compliance")
+
+	if err := NewComplianceEventFromProto(req.Event).Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd, err := Encode(ComplianceType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	e, ok := res.(*ComplianceEvent)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in Compliance: %v", res)
+	}
+	metrics.IncrCounterWithLabels([]string{"compliance", "action"}, 1, []metrics.Label{{Name: "action", Value: e.Action}})
+
+	return &proto.ComplianceResponse{Event: e.ToProto()}, nil
+}
+
+// SetSchedulerPause pauses or resumes the dispatch of jobs of the whole
+// cluster. This only works on the leader
+func (grpcs *GRPCServer) SetSchedulerPause(ctx context.Context, req *proto.SetSchedulerPauseRequest) (*proto.SetSchedulerPauseResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_scheduler_pause"}, time.Now())
+	log.WithField("action", req.Event.GetAction()).Debug("grpc: Received SetSchedulerPause")
+
+	if err := NewSchedulerEventFromProto(req.Event).Validate(); err != nil {
+		return nil, err
+	}
+	var pausedAt time.Time
+	if pause, err := grpcs.agent.Store.SchedulerPause(); err == nil && pause != nil {
+		pausedAt = pause.At
+	}
+
+	cmd, err := Encode(SchedulerPauseType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	e, ok := res.(*SchedulerEvent)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in SetSchedulerPause: %v", res)
+	}
+
+	// The runs missed while paused are caught up gradually
+	if e.Action == SchedulerPause {
+		grpcs.agent.rampUp.stop()
+	} else {
+		grpcs.agent.startRampUp(pausedAt)
+	}
+
+	return &proto.SetSchedulerPauseResponse{Event: e.ToProto()}, nil
+}
+
 // ToggleJob toggle the enablement of a job
 func (grpcs *GRPCServer) ToggleJob(ctx context.Context, getJobReq *proto.ToggleJobRequest) (*proto.ToggleJobResponse, error) {
 	return nil, nil
@@ -387,12 +939,13 @@ func (grpcs *GRPCServer) SetExecution(ctx context.Context, execution *proto.Exec
 		"execution": execution.Key(),
 	}).Debug("grpc: Received SetExecution")
 
+	grpcs.agent.assignExecutionID(execution)
 	cmd, err := Encode(SetExecutionType, execution)
 	if err != nil {
 		log.WithError(err).Fatal("agent: encode error in SetExecution")
 		return nil, err
 	}
-	af := grpcs.agent.raft.Apply(cmd, raftTimeout)
+	af := grpcs.agent.RaftApply(cmd)
 	if err := af.Error(); err != nil {
 		log.WithError(err).Fatal("agent: error applying SetExecutionType")
 		return nil, err
@@ -400,3 +953,123 @@ func (grpcs *GRPCServer) SetExecution(ctx context.Context, execution *proto.Exec
 
 	return new(empty.Empty), nil
 }
+
+// AnnotateExecution appends annotations to an execution. This only works
+// on the leader
+func (grpcs *GRPCServer) AnnotateExecution(ctx context.Context, req *proto.AnnotateExecutionRequest) (*proto.AnnotateExecutionResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "annotate_execution"}, time.Now())
+	log.WithFields(logrus.Fields{
+		"job":       req.JobName,
+		"execution": req.Key,
+	}).Debug("grpc: Received AnnotateExecution")
+
+	cmd, err := Encode(AnnotateExecutionType, req)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	res := af.Response()
+	if err, ok := res.(error); ok {
+		return nil, err
+	}
+	execution, ok := res.(*Execution)
+	if !ok {
+		return nil, fmt.Errorf("grpc: Error wrong response from apply in AnnotateExecution: %v", res)
+	}
+
+	return &proto.AnnotateExecutionResponse{Execution: execution.ToProto()}, nil
+}
+
+// Backfill starts a backfill of a job. This only works on the leader
+func (grpcs *GRPCServer) Backfill(ctx context.Context, req *proto.BackfillRequest) (*proto.BackfillResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "backfill"}, time.Now())
+	log.WithField("job", req.Backfill.GetJobName()).Debug("grpc: Received Backfill")
+
+	b, err := grpcs.agent.startBackfill(NewBackfillFromProto(req.Backfill))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.BackfillResponse{Backfill: b.ToProto()}, nil
+}
+
+// CancelBackfill cancels a running backfill. This only works on the leader
+func (grpcs *GRPCServer) CancelBackfill(ctx context.Context, req *proto.CancelBackfillRequest) (*proto.CancelBackfillResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "cancel_backfill"}, time.Now())
+	log.WithField("backfill", req.GetId()).Debug("grpc: Received CancelBackfill")
+
+	b, err := grpcs.agent.cancelBackfill(req.JobName, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.CancelBackfillResponse{Backfill: b.ToProto()}, nil
+}
+
+// AcquireLock acquires or renews a lock. This only works on the leader
+func (grpcs *GRPCServer) AcquireLock(ctx context.Context, req *proto.AcquireLockRequest) (*proto.AcquireLockResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "acquire_lock"}, time.Now())
+	log.WithField("lock", req.Lock.GetName()).Debug("grpc: Received AcquireLock")
+
+	acquire := grpcs.agent.acquireLock
+	if req.Renew {
+		acquire = grpcs.agent.renewLock
+	}
+	l, err := acquire(NewLockFromProto(req.Lock))
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AcquireLockResponse{Lock: l.ToProto()}, nil
+}
+
+// ReleaseLock releases a lock. This only works on the leader
+func (grpcs *GRPCServer) ReleaseLock(ctx context.Context, req *proto.ReleaseLockRequest) (*empty.Empty, error) {
+	defer metrics.MeasureSince([]string{"grpc", "release_lock"}, time.Now())
+	log.WithField("lock", req.GetName()).Debug("grpc: Received ReleaseLock")
+
+	if err := grpcs.agent.releaseLock(req.Name, req.Holder); err != nil {
+		return nil, err
+	}
+
+	return new(empty.Empty), nil
+}
+
+// SetKV sets a key of the KV store. This only works on the leader
+func (grpcs *GRPCServer) SetKV(ctx context.Context, req *proto.SetKVRequest) (*empty.Empty, error) {
+	defer metrics.MeasureSince([]string{"grpc", "set_kv"}, time.Now())
+	log.WithField("key", req.Kv.GetKey()).Debug("grpc: Received SetKV")
+
+	if err := grpcs.agent.setKV(NewKVFromProto(req.Kv)); err != nil {
+		return nil, err
+	}
+
+	return new(empty.Empty), nil
+}
+
+// DeleteKV deletes a key of the KV store. This only works on the leader
+func (grpcs *GRPCServer) DeleteKV(ctx context.Context, req *proto.DeleteKVRequest) (*empty.Empty, error) {
+	defer metrics.MeasureSince([]string{"grpc", "delete_kv"}, time.Now())
+	log.WithField("key", req.GetKey()).Debug("grpc: Received DeleteKV")
+
+	if err := grpcs.agent.deleteKV(req.Namespace, req.Key); err != nil {
+		return nil, err
+	}
+
+	return new(empty.Empty), nil
+}
+
+// ReadIndex returns the index of the writes applied by the leader, once
+// it verified it's still the leader. Followers serve consistent reads
+// after applying them.
+func (grpcs *GRPCServer) ReadIndex(ctx context.Context, in *empty.Empty) (*proto.ReadIndexResponse, error) {
+	defer metrics.MeasureSince([]string{"grpc", "read_index"}, time.Now())
+
+	if err := grpcs.agent.raft.VerifyLeader().Error(); err != nil {
+		return nil, err
+	}
+	return &proto.ReadIndexResponse{Index: grpcs.agent.raft.AppliedIndex()}, nil
+}
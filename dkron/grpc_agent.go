@@ -2,34 +2,87 @@ package dkron
 
 import (
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"time"
 
 	"github.com/armon/circbuf"
 	metrics "github.com/armon/go-metrics"
+	"github.com/distribworks/dkron/v3/extcron"
 	"github.com/distribworks/dkron/v3/plugin/types"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/sirupsen/logrus"
 )
 
 const (
 	// maxBufSize limits how much data we collect from a handler.
 	maxBufSize = 256000
+
+	// outputChunkSize is the maximum size of the output sent to the server
+	// in a single stream message.
+	outputChunkSize = 64 * 1024
 )
 
 type statusAgentHelper struct {
 	execution *types.Execution
 	stream    types.Agent_AgentRunServer
+	// output keeps the last maxBufSize bytes of the streamed output.
+	output *circbuf.Buffer
+	// secrets are the values read from Vault, redacted from the output.
+	secrets map[string]string
+	// log mirrors the output to the execution log file, if any.
+	log *executionLog
 }
 
+// Update receives partial output from the executor, buffers its tail and
+// streams it to the server in chunks. Sending blocks when the server
+// is not keeping up, this gives backpressure to the executor.
 func (s *statusAgentHelper) Update(b []byte, c bool) (int64, error) {
-	s.execution.Output = b
-	// Send partial execution
-	if err := s.stream.Send(&types.AgentRunStream{
-		Execution: s.execution,
-	}); err != nil {
-		return 0, err
+	b = redactSecrets(b, s.secrets)
+	s.output.Write(b)
+	s.log.Write(b)
+
+	for len(b) > 0 {
+		chunk := b
+		if len(chunk) > outputChunkSize {
+			chunk = chunk[:outputChunkSize]
+		}
+		b = b[len(chunk):]
+
+		s.execution.Output = chunk
+		// Send partial execution
+		if err := s.stream.Send(&types.AgentRunStream{
+			Execution: s.execution,
+		}); err != nil {
+			return 0, err
+		}
 	}
-	return 0, nil
+	return s.output.TotalWritten(), nil
+}
+
+// executionScheduleTimes returns the time the schedule of the job fired
+// for the execution and the time it fired before. Manual runs use the
+// time they started at instead.
+func executionScheduleTimes(job *types.Job, execution *types.Execution) (scheduled, previous *timestamp.Timestamp) {
+	scheduled = execution.ScheduledAt
+	if scheduled == nil {
+		scheduled = execution.StartedAt
+	}
+
+	t, err := ptypes.Timestamp(scheduled)
+	if err != nil {
+		return scheduled, nil
+	}
+	s, err := extcron.Parse(NewJobFromProto(job).cronSchedule())
+	if err != nil {
+		return scheduled, nil
+	}
+	if prev := prevScheduleTime(s, t); !prev.IsZero() {
+		previous, _ = ptypes.TimestampProto(prev)
+	}
+	return scheduled, previous
 }
 
 // GRPCAgentServer is the local implementation of the gRPC server interface.
@@ -52,22 +105,31 @@ func (as *AgentServer) AgentRun(req *types.AgentRunRequest, stream types.Agent_A
 	job := req.Job
 	execution := req.Execution
 
+	// Every run is a new execution, retries included, its ID has the start time
+	now := time.Now()
+	execution.Id = newULID(now)
+
 	log.WithFields(logrus.Fields{
-		"job": job.Name,
+		"job":          job.Name,
+		"request_id":   execution.RequestId,
+		"execution_id": execution.Id,
 	}).Info("grpc_agent: Starting job")
 
 	output, _ := circbuf.NewBuffer(maxBufSize)
+	streamed, _ := circbuf.NewBuffer(maxBufSize)
 
-	var success bool
-
-	jex := job.Executor
-	exc := job.ExecutorConfig
-	if jex == "" {
-		return errors.New("grpc_agent: No executor defined, nothing to do")
+	// Jobs without steps run their executor as a single step
+	steps := job.Steps
+	composite := len(steps) > 0
+	if !composite {
+		if job.Executor == "" {
+			return errors.New("grpc_agent: No executor defined, nothing to do")
+		}
+		steps = []*types.JobStep{{Executor: job.Executor, ExecutorConfig: job.ExecutorConfig}}
 	}
 
 	// Send the first update with the initial execution state to be stored in the server
-	execution.StartedAt = ptypes.TimestampNow()
+	execution.StartedAt, _ = ptypes.TimestampProto(now)
 	execution.NodeName = as.agent.config.NodeName
 
 	if err := stream.Send(&types.AgentRunStream{
@@ -76,40 +138,181 @@ func (as *AgentServer) AgentRun(req *types.AgentRunRequest, stream types.Agent_A
 		return err
 	}
 
-	// Check if executor exists
-	if executor, ok := as.agent.ExecutorPlugins[jex]; ok {
-		log.WithField("plugin", jex).Debug("grpc_agent: calling executor plugin")
-		runningExecutions.Store(execution.GetGroup(), execution)
-		out, err := executor.Execute(&types.ExecuteRequest{
-			JobName: job.Name,
-			Config:  exc,
-		}, &statusAgentHelper{
-			stream:    stream,
-			execution: execution,
-		})
+	runningExecutions.Store(execution.GetGroup(), execution)
+	defer as.agent.reserveResources(newResourcesFromProto(job.Resources))()
+
+	// Executors leave the files to upload in the artifacts directory
+	var artifactsDir string
+	if as.agent.ArtifactStore != nil {
+		dir, err := ioutil.TempDir("", "dkron-artifacts-")
+		if err != nil {
+			log.WithError(err).WithField("job", job.Name).Error("grpc_agent: error creating artifacts directory")
+		} else {
+			artifactsDir = dir
+			defer os.RemoveAll(dir)
+		}
+	}
+
+	// Every execution gets its own workspace, shared by its steps
+	var workspaceDir string
+	if as.agent.workspaces != nil {
+		dir, err := as.agent.workspaces.Create(job.Name, execution.Group)
+		if err != nil {
+			log.WithError(err).WithField("job", job.Name).Error("grpc_agent: error creating workspace directory")
+		} else {
+			workspaceDir = dir
+			defer as.agent.workspaces.Release(dir)
+		}
+	}
+
+	helper := &statusAgentHelper{
+		stream:    stream,
+		execution: execution,
+		output:    streamed,
+	}
+	// The output is mirrored to the execution log file as it's produced
+	el, err := as.agent.executionLogs.open(job, execution)
+	if err != nil {
+		log.WithError(err).WithField("job", job.Name).Error("grpc_agent: error opening execution log file")
+	}
+	helper.log = el
+	// Composite jobs report everything in order through the streamed output
+	report := func(msg string) {
+		if composite {
+			helper.Update([]byte(msg), true)
+		} else {
+			output.Write([]byte(msg))
+			el.Write(redactSecrets([]byte(msg), helper.secrets))
+		}
+	}
+
+	// Secrets are read just before running and their leases revoked once
+	// done, credentials are written for the run only and wiped once done
+	secrets, leases, err := as.agent.vault.resolveSecrets(job.Secrets)
+	defer as.agent.vault.revoke(leases)
+	var creds *credentialFiles
+	if err == nil {
+		creds, err = writeCredentials(req.Credentials)
+		defer creds.wipe()
+	}
+	execSecrets := secrets
+	helper.secrets = secrets
+	if creds != nil {
+		execSecrets = mergeEnv(secrets, creds.env)
+		helper.secrets = mergeEnv(secrets, creds.contents)
+	}
+	if err != nil {
+		log.WithError(err).WithField("job", job.Name).Error("grpc_agent: error reading secrets")
+		report(fmt.Sprintf("grpc_agent: Error reading secrets, %s\n", err))
+		// Nothing runs without its secrets
+		steps = nil
+	}
+
+	scheduledTime, previousTime := executionScheduleTimes(job, execution)
+	executeRequest := func(config map[string]string) *types.ExecuteRequest {
+		return &types.ExecuteRequest{
+			JobName:               job.Name,
+			Config:                config,
+			ArtifactsDir:          artifactsDir,
+			ScheduledTime:         scheduledTime,
+			PreviousScheduledTime: previousTime,
+			WorkspaceDir:          workspaceDir,
+			Namespace:             NewJobFromProto(job).Namespace(),
+			KvToken:               req.KvToken,
+			Params:                execution.Params,
+			Secrets:               execSecrets,
+		}
+	}
+	var out *types.ExecuteResponse
+	var artifacts []string
+	var execMetrics map[string]float64
+	success := err == nil
+
+	// Runs whose pre-check reports no work are skipped
+	skipped := false
+	if job.Precheck != nil && success {
+		var checkOutput []byte
+		skipped, checkOutput, err = as.agent.precheck(job.Precheck, executeRequest(job.Precheck.ExecutorConfig))
+		report(string(checkOutput))
+		if err != nil {
+			log.WithError(err).WithField("job", job.Name).Error("grpc_agent: pre-check error")
+			report(fmt.Sprintf("grpc_agent: Pre-check failed, %s\n", err))
+			success = false
+			steps = nil
+		} else if skipped {
+			log.WithField("job", job.Name).Info("grpc_agent: Skipping run, the pre-check reported no work")
+			report("grpc_agent: Pre-check reported no work, skipping the run\n")
+			steps = nil
+		}
+	}
+
+	for _, step := range steps {
+		if composite {
+			helper.Update([]byte(fmt.Sprintf("==> step %s\n", step.Name)), false)
+		}
+
+		executor, done, ok := as.agent.executor(step.Executor)
+		if !ok {
+			log.WithField("executor", step.Executor).Error("grpc_agent: Specified executor is not present")
+			report("grpc_agent: Specified executor is not present")
+			success = false
+			break
+		}
+		log.WithField("plugin", step.Executor).Debug("grpc_agent: calling executor plugin")
+
+		var err error
+		out, err = executor.Execute(executeRequest(step.ExecutorConfig), helper)
+		done()
 
 		if err == nil && out.Error != "" {
 			err = errors.New(out.Error)
 		}
+		if out != nil {
+			artifacts = append(artifacts, out.Artifacts...)
+			// The metrics of later steps replace the ones of the same name
+			for name, v := range out.Metrics {
+				if execMetrics == nil {
+					execMetrics = map[string]float64{}
+				}
+				execMetrics[name] = v
+			}
+		}
 		if err != nil {
-			log.WithError(err).WithField("job", job.Name).WithField("plugin", executor).Error("grpc_agent: command error output")
+			log.WithError(err).WithField("job", job.Name).WithField("plugin", step.Executor).Error("grpc_agent: command error output")
 			success = false
-			output.Write([]byte(err.Error() + "\n"))
-		} else {
-			success = true
+			if composite {
+				// The remaining steps are skipped
+				report(fmt.Sprintf("==> step %s failed: %s\n", step.Name, err))
+			} else {
+				report(err.Error() + "\n")
+			}
+			break
 		}
+	}
 
-		if out != nil {
-			output.Write(out.Output)
+	// Prefer the streamed output as the executor might have returned
+	// a truncated copy of it.
+	if streamed.TotalWritten() > 0 {
+		output.Write(streamed.Bytes())
+	} else if out != nil {
+		output.Write(out.Output)
+		el.Write(redactSecrets(out.Output, helper.secrets))
+	}
+	el.Close(success)
+
+	if len(artifacts) > 0 {
+		if as.agent.ArtifactStore == nil {
+			log.WithField("job", job.Name).Warn("grpc_agent: execution produced artifacts but no artifact store is configured")
+		} else {
+			execution.Artifacts = uploadArtifacts(as.agent.ArtifactStore, execution, artifactsDir, artifacts)
 		}
-	} else {
-		log.WithField("executor", jex).Error("grpc_agent: Specified executor is not present")
-		output.Write([]byte("grpc_agent: Specified executor is not present"))
 	}
 
 	execution.FinishedAt = ptypes.TimestampNow()
-	execution.Success = success
-	execution.Output = output.Bytes()
+	execution.Success = success && !skipped
+	execution.Skipped = skipped
+	execution.Metrics = execMetrics
+	execution.Output = redactSecrets(output.Bytes(), helper.secrets)
 
 	runningExecutions.Delete(execution.GetGroup())
 
@@ -120,10 +323,13 @@ func (as *AgentServer) AgentRun(req *types.AgentRunRequest, stream types.Agent_A
 		// In case of error means that maybe the server is gone so fallback to ExecutionDone
 		log.WithError(err).WithField("job", job.Name).Error("grpc_agent: error sending the final execution, falling back to ExecutionDone")
 		rpcServer, err := as.agent.checkAndSelectServer()
+		if err == nil {
+			err = as.agent.GRPCClient.ExecutionDone(rpcServer, NewExecutionFromProto(execution))
+		}
 		if err != nil {
-			return err
+			// Keep the result until a server is reachable
+			return as.agent.spoolResult(execution, err)
 		}
-		return as.agent.GRPCClient.ExecutionDone(rpcServer, NewExecutionFromProto(execution))
 	}
 
 	return nil
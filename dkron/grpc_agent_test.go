@@ -0,0 +1,47 @@
+package dkron
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/armon/circbuf"
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAgentRunStream struct {
+	types.Agent_AgentRunServer
+	sent [][]byte
+}
+
+func (s *fakeAgentRunStream) Send(m *types.AgentRunStream) error {
+	s.sent = append(s.sent, append([]byte(nil), m.Execution.Output...))
+	return nil
+}
+
+func TestStatusAgentHelper_Update(t *testing.T) {
+	stream := &fakeAgentRunStream{}
+	output, err := circbuf.NewBuffer(maxBufSize)
+	require.NoError(t, err)
+	helper := &statusAgentHelper{
+		execution: &types.Execution{JobName: "test"},
+		stream:    stream,
+		output:    output,
+	}
+
+	// Big outputs are streamed in chunks, only their tail is kept
+	b := bytes.Repeat([]byte("a"), maxBufSize+outputChunkSize)
+	n, err := helper.Update(b, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(b), n)
+	assert.Len(t, stream.sent, len(b)/outputChunkSize+1)
+	assert.Len(t, stream.sent[0], outputChunkSize)
+	assert.Len(t, output.Bytes(), maxBufSize)
+
+	n, err = helper.Update([]byte("done"), true)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(b)+4, n)
+	assert.Equal(t, "done", string(stream.sent[len(stream.sent)-1]))
+	assert.True(t, bytes.HasSuffix(output.Bytes(), []byte("adone")))
+}
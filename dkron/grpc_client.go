@@ -20,15 +20,38 @@ type DkronGRPCClient interface {
 	Connect(string) (*grpc.ClientConn, error)
 	ExecutionDone(string, *Execution) error
 	GetJob(string, string) (*Job, error)
-	SetJob(*Job) error
-	DeleteJob(string) (*Job, error)
+	SetJob(*Job, string) error
+	DeleteJob(string, bool, string) (*Job, error)
 	Leave(string) error
-	RunJob(string) (*Job, error)
+	RunJob(string, []string, string) (*Job, error)
+	TriggerJob(string, map[string]string, []string, string) (*Job, error)
+	ShadowRunJob(string, *ShadowRun, string) (*Job, error)
+	ResetJob(string, string) (*Job, error)
+	AnnotateExecution(string, string, []string) (*Execution, error)
+	RestoreJob(string, string) (*Job, error)
+	CheckStore(addr string, repair bool, adminToken string) (*CheckReport, error)
+	SetReadOnly(bool) error
+	SetMaintenanceWindow(*MaintenanceWindow) error
+	DeleteMaintenanceWindow(string) (*MaintenanceWindow, error)
+	SetSilence(*Silence) error
+	DeleteSilence(string) (*Silence, error)
+	SetCredential(*Credential) error
+	DeleteCredential(string) (*Credential, error)
+	Compliance(*ComplianceEvent) (*ComplianceEvent, error)
+	SetSchedulerPause(*SchedulerEvent) (*SchedulerEvent, error)
+	Backfill(*Backfill) (*Backfill, error)
+	CancelBackfill(string, string) (*Backfill, error)
+	AcquireLock(*Lock, bool) (*Lock, error)
+	ReleaseLock(string, string) error
+	SetKV(*KV) error
+	DeleteKV(string, string) error
+	ReadIndex() (uint64, error)
 	RaftGetConfiguration(string) (*proto.RaftGetConfigurationResponse, error)
 	RaftRemovePeerByID(string, string) error
 	GetActiveExecutions(string) ([]*proto.Execution, error)
 	SetExecution(execution *proto.Execution) error
 	AgentRun(addr string, job *proto.Job, execution *proto.Execution) error
+	ReloadPlugin(addr, path string) (*proto.ReloadPluginResponse, error)
 }
 
 // GRPCClient is the local implementation of the DkronGRPCClient interface.
@@ -161,8 +184,8 @@ func (grpcc *GRPCClient) Leave(addr string) error {
 	return nil
 }
 
-// SetJob calls the leader passing the job
-func (grpcc *GRPCClient) SetJob(job *Job) error {
+// SetJob calls the leader passing the job and the admin token of the request
+func (grpcc *GRPCClient) SetJob(job *Job, adminToken string) error {
 	var conn *grpc.ClientConn
 
 	addr := grpcc.agent.raft.Leader()
@@ -181,7 +204,8 @@ func (grpcc *GRPCClient) SetJob(job *Job) error {
 	// Synchronous call
 	d := proto.NewDkronClient(conn)
 	_, err = d.SetJob(context.Background(), &proto.SetJobRequest{
-		Job: job.ToProto(),
+		Job:        job.ToProto(),
+		AdminToken: adminToken,
 	})
 	if err != nil {
 		log.WithError(err).WithFields(logrus.Fields{
@@ -193,8 +217,9 @@ func (grpcc *GRPCClient) SetJob(job *Job) error {
 	return nil
 }
 
-// DeleteJob calls the leader passing the job name
-func (grpcc *GRPCClient) DeleteJob(jobName string) (*Job, error) {
+// DeleteJob calls the leader passing the job name, whether
+// to delete its dependent jobs too and the admin token of the request
+func (grpcc *GRPCClient) DeleteJob(jobName string, cascade bool, adminToken string) (*Job, error) {
 	var conn *grpc.ClientConn
 
 	addr := grpcc.agent.raft.Leader()
@@ -213,7 +238,9 @@ func (grpcc *GRPCClient) DeleteJob(jobName string) (*Job, error) {
 	// Synchronous call
 	d := proto.NewDkronClient(conn)
 	res, err := d.DeleteJob(context.Background(), &proto.DeleteJobRequest{
-		JobName: jobName,
+		JobName:    jobName,
+		Cascade:    cascade,
+		AdminToken: adminToken,
 	})
 	if err != nil {
 		log.WithError(err).WithFields(logrus.Fields{
@@ -228,8 +255,15 @@ func (grpcc *GRPCClient) DeleteJob(jobName string) (*Job, error) {
 	return job, nil
 }
 
-// RunJob calls the leader passing the job name
-func (grpcc *GRPCClient) RunJob(jobName string) (*Job, error) {
+// RunJob calls the leader passing the job name, the annotations to attach
+// to the new execution and the ID of the request that triggered it
+func (grpcc *GRPCClient) RunJob(jobName string, annotations []string, requestID string) (*Job, error) {
+	return grpcc.TriggerJob(jobName, nil, annotations, requestID)
+}
+
+// TriggerJob calls the leader to run the job like RunJob, passing the
+// params of the new execution too.
+func (grpcc *GRPCClient) TriggerJob(jobName string, params map[string]string, annotations []string, requestID string) (*Job, error) {
 	var conn *grpc.ClientConn
 
 	addr := grpcc.agent.raft.Leader()
@@ -248,7 +282,10 @@ func (grpcc *GRPCClient) RunJob(jobName string) (*Job, error) {
 	// Synchronous call
 	d := proto.NewDkronClient(conn)
 	res, err := d.RunJob(context.Background(), &proto.RunJobRequest{
-		JobName: jobName,
+		JobName:     jobName,
+		Annotations: annotations,
+		RequestId:   requestID,
+		Params:      params,
 	})
 	if err != nil {
 		log.WithError(err).WithFields(logrus.Fields{
@@ -263,6 +300,151 @@ func (grpcc *GRPCClient) RunJob(jobName string) (*Job, error) {
 	return job, nil
 }
 
+// ShadowRunJob calls the leader passing the job name and the shadow run
+// of the job to dispatch.
+func (grpcc *GRPCClient) ShadowRunJob(jobName string, shadow *ShadowRun, requestID string) (*Job, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ShadowRunJob",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.RunJob(context.Background(), &proto.RunJobRequest{
+		JobName:   jobName,
+		RequestId: requestID,
+		Shadow:    shadow.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ShadowRunJob",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewJobFromProto(res.Job), nil
+}
+
+// ResetJob calls the leader passing the job name and the admin token
+// of the request
+func (grpcc *GRPCClient) ResetJob(jobName string, adminToken string) (*Job, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ResetJob",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.ResetJob(context.Background(), &proto.ResetJobRequest{
+		JobName:    jobName,
+		AdminToken: adminToken,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ResetJob",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	job := NewJobFromProto(res.Job)
+
+	return job, nil
+}
+
+// AnnotateExecution calls the leader passing the job name, the key of the
+// execution and the annotations to append to it
+func (grpcc *GRPCClient) AnnotateExecution(jobName, key string, annotations []string) (*Execution, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "AnnotateExecution",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.AnnotateExecution(context.Background(), &proto.AnnotateExecutionRequest{
+		JobName:     jobName,
+		Key:         key,
+		Annotations: annotations,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "AnnotateExecution",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	execution := NewExecutionFromProto(res.Execution)
+	execution.setLegacyID()
+	return execution, nil
+}
+
+// CheckStore checks the store of the given server, repairing it
+// if requested, repairing only works on the leader and takes the
+// admin token while the cluster is read-only
+func (grpcc *GRPCClient) CheckStore(addr string, repair bool, adminToken string) (*CheckReport, error) {
+	var conn *grpc.ClientConn
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(addr)
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "CheckStore",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.CheckStore(context.Background(), &proto.CheckStoreRequest{
+		Repair:     repair,
+		AdminToken: adminToken,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "CheckStore",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewCheckReportFromProto(res), nil
+}
+
 // RaftGetConfiguration get the current raft configuration of peers
 func (grpcc *GRPCClient) RaftGetConfiguration(addr string) (*proto.RaftGetConfigurationResponse, error) {
 	var conn *grpc.ClientConn
@@ -387,8 +569,18 @@ func (grpcc *GRPCClient) AgentRun(addr string, job *proto.Job, execution *proto.
 	defer metrics.MeasureSince([]string{"grpc_client", "agent_run"}, time.Now())
 	var conn *grpc.ClientConn
 
+	if err := injectDispatchFault(addr); err != nil {
+		return err
+	}
+
+	// Credentials are decrypted on the leader for the chosen agent only
+	creds, err := grpcc.agent.jobCredentials(NewJobFromProto(job))
+	if err != nil {
+		return err
+	}
+
 	// Initiate a connection with the server
-	conn, err := grpcc.Connect(string(addr))
+	conn, err = grpcc.Connect(string(addr))
 	if err != nil {
 		log.WithError(err).WithFields(logrus.Fields{
 			"method":      "AgentRun",
@@ -401,13 +593,19 @@ func (grpcc *GRPCClient) AgentRun(addr string, job *proto.Job, execution *proto.
 	// Streaming call
 	a := proto.NewAgentClient(conn)
 	stream, err := a.AgentRun(context.Background(), &proto.AgentRunRequest{
-		Job:       job,
-		Execution: execution,
+		Job:         job,
+		Execution:   execution,
+		KvToken:     kvToken(grpcc.agent.config.KVTokenSecret, NewJobFromProto(job).Namespace()),
+		Credentials: creds,
 	})
 	if err != nil {
 		return err
 	}
 
+	// The partial output is redacted before it's shown or stored, secrets
+	// split between chunks are only redacted once the execution is done
+	j := NewJobFromProto(job)
+
 	var first bool
 	for {
 		ars, err := stream.Recv()
@@ -436,6 +634,8 @@ func (grpcc *GRPCClient) AgentRun(addr string, job *proto.Job, execution *proto.
 			return err
 		}
 
+		ars.Execution.Output = grpcc.agent.redactOutput(j, ars.Execution.Output)
+
 		// Registers an active stream
 		grpcc.agent.activeExecutions.Store(ars.Execution.Key(), ars.Execution)
 		log.WithField("key", ars.Execution.Key()).Debug("grpc: received execution stream")
@@ -452,3 +652,600 @@ func (grpcc *GRPCClient) AgentRun(addr string, job *proto.Job, execution *proto.
 		}
 	}
 }
+
+// RestoreJob calls the leader passing the job name to restore from the trash
+// and the admin token of the request
+func (grpcc *GRPCClient) RestoreJob(jobName string, adminToken string) (*Job, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "RestoreJob",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.RestoreJob(context.Background(), &proto.RestoreJobRequest{
+		JobName:    jobName,
+		AdminToken: adminToken,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "RestoreJob",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	job := NewJobFromProto(res.Job)
+
+	return job, nil
+}
+
+// SetReadOnly calls the leader passing the read-only switch state
+func (grpcc *GRPCClient) SetReadOnly(readOnly bool) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetReadOnly",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.SetReadOnly(context.Background(), &proto.SetReadOnlyRequest{
+		ReadOnly: readOnly,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetReadOnly",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// SetMaintenanceWindow calls the leader passing the maintenance window to store
+func (grpcc *GRPCClient) SetMaintenanceWindow(w *MaintenanceWindow) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.SetMaintenanceWindow(context.Background(), &proto.SetMaintenanceWindowRequest{
+		Window: w.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteMaintenanceWindow calls the leader passing the name of the maintenance window to delete
+func (grpcc *GRPCClient) DeleteMaintenanceWindow(name string) (*MaintenanceWindow, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.DeleteMaintenanceWindow(context.Background(), &proto.DeleteMaintenanceWindowRequest{
+		Name: name,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteMaintenanceWindow",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewMaintenanceWindowFromProto(res.Window), nil
+}
+
+// Backfill calls the leader passing the backfill to start
+func (grpcc *GRPCClient) Backfill(b *Backfill) (*Backfill, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "Backfill",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.Backfill(context.Background(), &proto.BackfillRequest{
+		Backfill: b.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "Backfill",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewBackfillFromProto(res.Backfill), nil
+}
+
+// CancelBackfill calls the leader passing the backfill to cancel
+func (grpcc *GRPCClient) CancelBackfill(jobName, id string) (*Backfill, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "CancelBackfill",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.CancelBackfill(context.Background(), &proto.CancelBackfillRequest{
+		JobName: jobName,
+		Id:      id,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "CancelBackfill",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewBackfillFromProto(res.Backfill), nil
+}
+
+// AcquireLock calls the leader passing the lock to acquire, or to renew
+func (grpcc *GRPCClient) AcquireLock(l *Lock, renew bool) (*Lock, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "AcquireLock",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.AcquireLock(context.Background(), &proto.AcquireLockRequest{
+		Lock:  l.ToProto(),
+		Renew: renew,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "AcquireLock",
+			"server_addr": addr,
+		}).Debug("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewLockFromProto(res.Lock), nil
+}
+
+// ReleaseLock calls the leader passing the lock to release
+func (grpcc *GRPCClient) ReleaseLock(name, holder string) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ReleaseLock",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.ReleaseLock(context.Background(), &proto.ReleaseLockRequest{
+		Name:   name,
+		Holder: holder,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ReleaseLock",
+			"server_addr": addr,
+		}).Debug("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// SetKV calls the leader passing the key to set
+func (grpcc *GRPCClient) SetKV(kv *KV) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetKV",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.SetKV(context.Background(), &proto.SetKVRequest{
+		Kv: kv.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetKV",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteKV calls the leader passing the key to delete
+func (grpcc *GRPCClient) DeleteKV(namespace, key string) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteKV",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.DeleteKV(context.Background(), &proto.DeleteKVRequest{
+		Namespace: namespace,
+		Key:       key,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteKV",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// ReadIndex calls the leader to get the index of the writes it applied
+func (grpcc *GRPCClient) ReadIndex() (uint64, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ReadIndex",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return 0, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.ReadIndex(context.Background(), &empty.Empty{})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ReadIndex",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return 0, err
+	}
+
+	return res.Index, nil
+}
+
+// ReloadPlugin calls the agent to load the plugin binary in path
+func (grpcc *GRPCClient) ReloadPlugin(addr, path string) (*proto.ReloadPluginResponse, error) {
+	var conn *grpc.ClientConn
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(addr)
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ReloadPlugin",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	a := proto.NewAgentClient(conn)
+	res, err := a.ReloadPlugin(context.Background(), &proto.ReloadPluginRequest{Path: path})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "ReloadPlugin",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetSilence calls the leader passing the silence to store
+func (grpcc *GRPCClient) SetSilence(s *Silence) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetSilence",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.SetSilence(context.Background(), &proto.SetSilenceRequest{
+		Silence: s.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetSilence",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSilence calls the leader passing the id of the silence to delete
+func (grpcc *GRPCClient) DeleteSilence(id string) (*Silence, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteSilence",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.DeleteSilence(context.Background(), &proto.DeleteSilenceRequest{
+		Id: id,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteSilence",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewSilenceFromProto(res.Silence), nil
+}
+
+// SetCredential calls the leader passing the credential to store
+func (grpcc *GRPCClient) SetCredential(c *Credential) error {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetCredential",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	_, err = d.SetCredential(context.Background(), &proto.SetCredentialRequest{
+		Credential: c.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetCredential",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCredential calls the leader passing the name of the credential to delete
+func (grpcc *GRPCClient) DeleteCredential(name string) (*Credential, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteCredential",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.DeleteCredential(context.Background(), &proto.DeleteCredentialRequest{
+		Name: name,
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "DeleteCredential",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewCredentialFromProto(res.Credential), nil
+}
+
+// Compliance calls the leader passing the compliance action to apply
+func (grpcc *GRPCClient) Compliance(e *ComplianceEvent) (*ComplianceEvent, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "Compliance",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.Compliance(context.Background(), &proto.ComplianceRequest{
+		Event: e.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "Compliance",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewComplianceEventFromProto(res.Event), nil
+}
+
+// SetSchedulerPause calls the leader passing the pause or resume of the
+// scheduler to apply
+func (grpcc *GRPCClient) SetSchedulerPause(e *SchedulerEvent) (*SchedulerEvent, error) {
+	var conn *grpc.ClientConn
+
+	addr := grpcc.agent.raft.Leader()
+
+	// Initiate a connection with the server
+	conn, err := grpcc.Connect(string(addr))
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetSchedulerPause",
+			"server_addr": addr,
+		}).Error("grpc: error dialing.")
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Synchronous call
+	d := proto.NewDkronClient(conn)
+	res, err := d.SetSchedulerPause(context.Background(), &proto.SetSchedulerPauseRequest{
+		Event: e.ToProto(),
+	})
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"method":      "SetSchedulerPause",
+			"server_addr": addr,
+		}).Error("grpc: Error calling gRPC method")
+		return nil, err
+	}
+
+	return NewSchedulerEventFromProto(res.Event), nil
+}
@@ -3,9 +3,12 @@ package dkron
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/distribworks/dkron/v3/plugin"
+	"github.com/distribworks/dkron/v3/plugin/types"
 	"github.com/hashicorp/serf/testutil"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -104,3 +107,53 @@ func TestGRPCExecutionDone(t *testing.T) {
 
 	assert.Error(t, err, ErrExecutionDoneForDeletedJob)
 }
+
+// stepExecutor is an executor checking the workspace shared by the steps.
+type stepExecutor struct{}
+
+func (stepExecutor) Execute(args *types.ExecuteRequest, cb plugin.StatusHelper) (*types.ExecuteResponse, error) {
+	marker := filepath.Join(args.WorkspaceDir, "extracted")
+	switch args.Config["action"] {
+	case "extract":
+		cb.Update([]byte("extracting\n"), false)
+		return &types.ExecuteResponse{}, ioutil.WriteFile(marker, []byte("data"), 0644)
+	case "load":
+		data, err := ioutil.ReadFile(marker)
+		if err != nil {
+			return nil, err
+		}
+		cb.Update([]byte("loading "+string(data)+"\n"), false)
+		return &types.ExecuteResponse{Error: "load failed"}, nil
+	}
+	cb.Update([]byte("reporting\n"), false)
+	return &types.ExecuteResponse{}, nil
+}
+
+func TestAgentRunSteps(t *testing.T) {
+	dir, a := setupAPITest(t, "8123")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	a.ExecutorPlugins = map[string]plugin.Executor{"steps": stepExecutor{}}
+
+	job := &Job{
+		Name:     "pipeline",
+		Schedule: "@manually",
+		Steps: []*Step{
+			{Name: "extract", Executor: "steps", ExecutorConfig: map[string]string{"action": "extract"}},
+			{Name: "load", Executor: "steps", ExecutorConfig: map[string]string{"action": "load"}},
+			{Name: "report", Executor: "steps"},
+		},
+	}
+	require.NoError(t, job.Validate())
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	ex := NewExecution(job.Name)
+	require.NoError(t, a.GRPCClient.AgentRun(a.advertiseRPCAddr(), job.ToProto(), ex.ToProto()))
+
+	executions, err := a.Store.GetExecutions(job.Name)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.False(t, executions[0].Success)
+	assert.Equal(t, "==> step extract\nextracting\n==> step load\nloading data\n==> step load failed: load failed\n", executions[0].Output)
+}
@@ -0,0 +1,148 @@
+package dkron
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/buntdb"
+)
+
+// Reasons a job is affected by another one.
+const (
+	// ImpactDependent jobs don't run when the job fails, and are deleted
+	// with it by cascading deletes.
+	ImpactDependent = "dependent"
+	// ImpactStandby jobs run when the job fails, and on every schedule once
+	// it's deleted.
+	ImpactStandby = "standby"
+)
+
+// Impact lists the jobs affected if a job fails or is deleted, and who to
+// ask before changing it.
+type Impact struct {
+	Job  string         `json:"job"`
+	Jobs []*ImpactedJob `json:"jobs"`
+
+	// Distinct owners, teams and escalation channels of the affected jobs.
+	Owners   []string `json:"owners"`
+	Teams    []string `json:"teams"`
+	Channels []string `json:"channels"`
+}
+
+// ImpactedJob is a job downstream of the job of an impact analysis.
+type ImpactedJob struct {
+	Job string `json:"job"`
+
+	// Job it's affected through and how.
+	Via    string `json:"via"`
+	Reason string `json:"reason"`
+
+	// Distance to the job of the analysis.
+	Depth    int  `json:"depth"`
+	Disabled bool `json:"disabled"`
+
+	Owner                  string `json:"owner,omitempty"`
+	OwnerEmail             string `json:"owner_email,omitempty"`
+	OwnerTeam              string `json:"owner_team,omitempty"`
+	OwnerEscalationChannel string `json:"owner_escalation_channel,omitempty"`
+}
+
+// buildImpact walks breadth first the jobs downstream of the job, its
+// dependent jobs and the standby jobs of them all, transitively.
+func buildImpact(s Storage, name string) (*Impact, error) {
+	if _, err := s.GetJob(name, nil); err != nil {
+		return nil, err
+	}
+	all, err := s.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+	jobs := map[string]*Job{}
+	standbys := map[string][]string{}
+	for _, j := range all {
+		jobs[j.Name] = j
+		if j.StandbyFor != "" {
+			standbys[j.StandbyFor] = append(standbys[j.StandbyFor], j.Name)
+		}
+	}
+
+	impact := &Impact{
+		Job:      name,
+		Jobs:     []*ImpactedJob{},
+		Owners:   []string{},
+		Teams:    []string{},
+		Channels: []string{},
+	}
+	seen := map[string]bool{name: true}
+	queue := []*ImpactedJob{{Job: name}}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+
+		var next []*ImpactedJob
+		for _, c := range jobs[parent.Job].DependentJobs {
+			next = append(next, &ImpactedJob{Job: c, Via: parent.Job, Reason: ImpactDependent, Depth: parent.Depth + 1})
+		}
+		for _, c := range standbys[parent.Job] {
+			next = append(next, &ImpactedJob{Job: c, Via: parent.Job, Reason: ImpactStandby, Depth: parent.Depth + 1})
+		}
+		sort.SliceStable(next, func(i, j int) bool { return next[i].Job < next[j].Job })
+
+		for _, ij := range next {
+			j, ok := jobs[ij.Job]
+			// Dangling references are reported by fsck
+			if !ok || seen[ij.Job] {
+				continue
+			}
+			seen[ij.Job] = true
+			ij.Disabled = j.Disabled
+			ij.Owner = j.Owner
+			ij.OwnerEmail = j.OwnerEmail
+			ij.OwnerTeam = j.OwnerTeam
+			ij.OwnerEscalationChannel = j.OwnerEscalationChannel
+			impact.Jobs = append(impact.Jobs, ij)
+			queue = append(queue, ij)
+		}
+	}
+
+	owners, teams, channels := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	for _, ij := range impact.Jobs {
+		owner := ij.OwnerEmail
+		if owner == "" {
+			owner = ij.Owner
+		}
+		owners[owner] = true
+		teams[ij.OwnerTeam] = true
+		channels[ij.OwnerEscalationChannel] = true
+	}
+	impact.Owners = sortedSet(owners)
+	impact.Teams = sortedSet(teams)
+	impact.Channels = sortedSet(channels)
+	return impact, nil
+}
+
+// sortedSet returns the non empty values of the set, sorted.
+func sortedSet(set map[string]bool) []string {
+	values := []string{}
+	for v := range set {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+func (h *HTTPTransport) jobImpactHandler(c *gin.Context) {
+	impact, err := buildImpact(h.agent.Store, c.Param("job"))
+	if err == buntdb.ErrNotFound {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, impact)
+}
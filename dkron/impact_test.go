@@ -0,0 +1,47 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestBuildImpact(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	for _, j := range []*Job{
+		{Name: "extract", Schedule: "@every 1h", Executor: "shell", OwnerTeam: "data"},
+		{Name: "transform", ParentJob: "extract", Executor: "shell", OwnerEmail: "etl@example.com", OwnerTeam: "data"},
+		{Name: "report", ParentJob: "transform", Executor: "shell", Owner: "finance", OwnerEscalationChannel: "#finance"},
+		{Name: "report-standby", Schedule: "@every 1h", Executor: "shell", StandbyFor: "report", OwnerTeam: "finance"},
+		{Name: "unrelated", Schedule: "@every 1h", Executor: "shell", OwnerTeam: "ops"},
+	} {
+		require.NoError(t, s.SetJob(j, true))
+	}
+
+	impact, err := buildImpact(s, "extract")
+	require.NoError(t, err)
+
+	require.Len(t, impact.Jobs, 3)
+	assert.Equal(t, &ImpactedJob{Job: "transform", Via: "extract", Reason: ImpactDependent, Depth: 1, OwnerEmail: "etl@example.com", OwnerTeam: "data"}, impact.Jobs[0])
+	assert.Equal(t, "report", impact.Jobs[1].Job)
+	assert.Equal(t, 2, impact.Jobs[1].Depth)
+	assert.Equal(t, "report-standby", impact.Jobs[2].Job)
+	assert.Equal(t, ImpactStandby, impact.Jobs[2].Reason)
+	assert.Equal(t, "report", impact.Jobs[2].Via)
+
+	assert.Equal(t, []string{"etl@example.com", "finance"}, impact.Owners)
+	assert.Equal(t, []string{"data", "finance"}, impact.Teams)
+	assert.Equal(t, []string{"#finance"}, impact.Channels)
+
+	impact, err = buildImpact(s, "unrelated")
+	require.NoError(t, err)
+	assert.Empty(t, impact.Jobs)
+
+	_, err = buildImpact(s, "missing")
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
@@ -0,0 +1,537 @@
+package dkron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+)
+
+var (
+	cronEnvPattern     = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+	systemdSpanPattern = regexp.MustCompile(`(\d+)\s*([a-zA-Z]*)`)
+	illegalNamePattern = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+	systemdSpanUnits = map[string]time.Duration{
+		"":        time.Second,
+		"s":       time.Second,
+		"sec":     time.Second,
+		"second":  time.Second,
+		"seconds": time.Second,
+		"m":       time.Minute,
+		"min":     time.Minute,
+		"minute":  time.Minute,
+		"minutes": time.Minute,
+		"h":       time.Hour,
+		"hr":      time.Hour,
+		"hour":    time.Hour,
+		"hours":   time.Hour,
+		"d":       24 * time.Hour,
+		"day":     24 * time.Hour,
+		"days":    24 * time.Hour,
+		"w":       7 * 24 * time.Hour,
+		"week":    7 * 24 * time.Hour,
+		"weeks":   7 * 24 * time.Hour,
+	}
+
+	systemdCalendarShorthands = map[string]string{
+		"minutely":     "0 * * * * *",
+		"hourly":       "0 0 * * * *",
+		"daily":        "0 0 0 * * *",
+		"weekly":       "0 0 0 * * mon",
+		"monthly":      "0 0 0 1 * *",
+		"quarterly":    "0 0 0 1 1,4,7,10 *",
+		"semiannually": "0 0 0 1 1,7 *",
+		"yearly":       "0 0 0 1 1 *",
+		"annually":     "0 0 0 1 1 *",
+	}
+)
+
+// ImportOptions are the options applied to the jobs created when importing
+// from other schedulers.
+type ImportOptions struct {
+	// Prefix of the names of the imported jobs.
+	Prefix string
+	// Tags of the target nodes of the imported jobs.
+	Tags map[string]string
+	// System crontabs have a user field before the command.
+	System bool
+}
+
+// ImportSkip is an entry that couldn't be imported.
+type ImportSkip struct {
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// ImportResult holds the jobs imported from other schedulers and the
+// entries that were skipped.
+type ImportResult struct {
+	Jobs    []*Job       `json:"jobs"`
+	Skipped []ImportSkip `json:"skipped"`
+}
+
+func (r *ImportResult) skip(source string, format string, args ...interface{}) {
+	r.Skipped = append(r.Skipped, ImportSkip{Source: source, Reason: fmt.Sprintf(format, args...)})
+}
+
+// add validates the job before adding it to the result.
+func (r *ImportResult) add(source string, job *Job) {
+	if err := job.Validate(); err != nil {
+		r.skip(source, "%s", err)
+		return
+	}
+	r.Jobs = append(r.Jobs, job)
+}
+
+// ParseCrontab converts the entries of a crontab into shell jobs. Comments
+// right above an entry become the display name of the job, MAILTO the
+// owner email, CRON_TZ the timezone and other variables are passed to
+// the command environment.
+func ParseCrontab(r io.Reader, options *ImportOptions) (*ImportResult, error) {
+	result := &ImportResult{Jobs: []*Job{}, Skipped: []ImportSkip{}}
+
+	var env []string
+	var comment, mailto, timezone string
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		source := fmt.Sprintf("line %d", n)
+
+		switch {
+		case line == "":
+			comment = ""
+			continue
+		case strings.HasPrefix(line, "#"):
+			comment = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			continue
+		}
+
+		if m := cronEnvPattern.FindStringSubmatch(line); m != nil {
+			value := strings.Trim(m[2], `"'`)
+			switch m[1] {
+			case "MAILTO":
+				mailto = value
+			case "CRON_TZ", "TZ":
+				timezone = value
+			case "SHELL":
+			default:
+				env = append(env, m[1]+"="+value)
+			}
+			continue
+		}
+
+		descriptor := strings.HasPrefix(line, "@")
+		fields := 5
+		if descriptor {
+			fields = 1
+		}
+		if options.System {
+			fields++
+		}
+		tokens, command := splitFields(line, fields)
+		if command == "" {
+			result.skip(source, "missing command")
+			continue
+		}
+
+		var schedule string
+		if descriptor {
+			schedule = tokens[0]
+		} else {
+			schedule = "0 " + strings.Join(tokens[:5], " ")
+		}
+		if schedule == "@reboot" {
+			result.skip(source, "@reboot is not supported")
+			continue
+		}
+
+		// Unescaped % are newlines sent to the command input
+		if strings.Contains(strings.Replace(command, `\%`, "", -1), "%") {
+			result.skip(source, "%% command input is not supported")
+			continue
+		}
+		command = strings.Replace(command, `\%`, "%", -1)
+
+		job := &Job{
+			Name:        importJobName(options.Prefix, command, n),
+			DisplayName: comment,
+			Schedule:    schedule,
+			Timezone:    timezone,
+			OwnerEmail:  mailto,
+			Executor:    "shell",
+			ExecutorConfig: map[string]string{
+				"shell":   "true",
+				"command": command,
+			},
+			Tags:        copyTags(options.Tags),
+			Concurrency: ConcurrencyAllow,
+			Metadata: map[string]string{
+				"imported_from": "crontab:" + strconv.Itoa(n),
+			},
+		}
+		if len(env) > 0 {
+			job.ExecutorConfig["env"] = strings.Join(env, ",")
+		}
+		if options.System {
+			job.Metadata["user"] = tokens[len(tokens)-1]
+		}
+		comment = ""
+
+		result.add(source, job)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseSystemdUnits converts systemd timers and the services they activate
+// into shell jobs. Units are given by file name, every timer needs its
+// service to be present.
+func ParseSystemdUnits(units map[string]string, options *ImportOptions) (*ImportResult, error) {
+	result := &ImportResult{Jobs: []*Job{}, Skipped: []ImportSkip{}}
+
+	var timers []string
+	for name := range units {
+		if strings.HasSuffix(name, ".timer") {
+			timers = append(timers, name)
+		}
+	}
+	sort.Strings(timers)
+
+	for _, name := range timers {
+		base := strings.TrimSuffix(path.Base(name), ".timer")
+
+		timer, err := parseUnitFile(units[name])
+		if err != nil {
+			result.skip(name, "%s", err)
+			continue
+		}
+
+		schedule, timezone, err := systemdTimerSchedule(timer)
+		if err != nil {
+			result.skip(name, "%s", err)
+			continue
+		}
+
+		serviceName := timer.get("Timer", "Unit")
+		if serviceName == "" {
+			serviceName = base + ".service"
+		}
+		content, ok := units[serviceName]
+		if !ok {
+			content, ok = units[path.Join(path.Dir(name), serviceName)]
+		}
+		if !ok {
+			result.skip(name, "service %s not found", serviceName)
+			continue
+		}
+		service, err := parseUnitFile(content)
+		if err != nil {
+			result.skip(serviceName, "%s", err)
+			continue
+		}
+
+		var commands []string
+		for _, c := range service.values("Service", "ExecStart") {
+			if c = strings.TrimLeft(c, "-@:+!"); c != "" {
+				commands = append(commands, c)
+			}
+		}
+		if len(commands) == 0 {
+			result.skip(serviceName, "missing ExecStart")
+			continue
+		}
+
+		description := timer.get("Unit", "Description")
+		if description == "" {
+			description = service.get("Unit", "Description")
+		}
+
+		job := &Job{
+			Name:        importJobName(options.Prefix, base, 0),
+			DisplayName: description,
+			Schedule:    schedule,
+			Timezone:    timezone,
+			Executor:    "shell",
+			ExecutorConfig: map[string]string{
+				"shell":   "true",
+				"command": strings.Join(commands, " && "),
+			},
+			Tags:        copyTags(options.Tags),
+			Concurrency: ConcurrencyForbid,
+			Metadata: map[string]string{
+				"imported_from": "systemd:" + path.Base(name),
+			},
+		}
+		var env []string
+		for _, e := range service.values("Service", "Environment") {
+			env = append(env, splitQuoted(e)...)
+		}
+		if len(env) > 0 {
+			job.ExecutorConfig["env"] = strings.Join(env, ",")
+		}
+		if cwd := service.get("Service", "WorkingDirectory"); cwd != "" {
+			job.ExecutorConfig["cwd"] = cwd
+		}
+		if user := service.get("Service", "User"); user != "" {
+			job.Metadata["user"] = user
+		}
+
+		result.add(name, job)
+	}
+
+	return result, nil
+}
+
+// systemdTimerSchedule returns the cron schedule and timezone equivalent
+// to the timer triggers.
+func systemdTimerSchedule(timer unitFile) (string, string, error) {
+	calendars := timer.values("Timer", "OnCalendar")
+	if len(calendars) > 1 {
+		return "", "", fmt.Errorf("multiple OnCalendar are not supported")
+	}
+	if len(calendars) == 1 {
+		return systemdCalendarToCron(calendars[0])
+	}
+
+	for _, key := range []string{"OnUnitActiveSec", "OnUnitInactiveSec"} {
+		if span := timer.get("Timer", key); span != "" {
+			d, err := parseSystemdSpan(span)
+			if err != nil {
+				return "", "", err
+			}
+			return "@every " + d.String(), "", nil
+		}
+	}
+
+	return "", "", fmt.Errorf("timer without OnCalendar or OnUnitActiveSec is not supported")
+}
+
+// systemdCalendarToCron converts a systemd calendar event expression
+// ([weekdays] [date] [time] [timezone]) into a cron schedule.
+func systemdCalendarToCron(spec string) (string, string, error) {
+	tokens := strings.Fields(strings.ToLower(spec))
+	if len(tokens) == 0 {
+		return "", "", fmt.Errorf("empty OnCalendar")
+	}
+
+	if s, ok := systemdCalendarShorthands[tokens[0]]; ok && len(tokens) == 1 {
+		return s, "", nil
+	}
+
+	// The timezone is case sensitive, take it from the original spec
+	timezone := ""
+	if last := strings.Fields(spec)[len(tokens)-1]; strings.Contains(last, "/") || last == "UTC" {
+		if _, err := time.LoadLocation(last); err == nil {
+			timezone = last
+			tokens = tokens[:len(tokens)-1]
+		}
+	}
+
+	dow := "*"
+	if len(tokens) > 0 && tokens[0][0] >= 'a' && tokens[0][0] <= 'z' {
+		dow = strings.Replace(tokens[0], "..", "-", -1)
+		tokens = tokens[1:]
+	}
+
+	date := []string{"*", "*", "*"}
+	clock := []string{"00", "00", "00"}
+	for _, t := range tokens {
+		switch {
+		case strings.Contains(t, ":"):
+			parts := strings.Split(t, ":")
+			if len(parts) < 2 || len(parts) > 3 {
+				return "", "", fmt.Errorf("invalid time %s", t)
+			}
+			copy(clock, parts)
+		case strings.Contains(t, "-"):
+			parts := strings.Split(t, "-")
+			if len(parts) == 2 {
+				parts = append([]string{"*"}, parts...)
+			}
+			if len(parts) != 3 {
+				return "", "", fmt.Errorf("invalid date %s", t)
+			}
+			date = parts
+		default:
+			return "", "", fmt.Errorf("unsupported calendar expression %s", t)
+		}
+	}
+	if date[0] != "*" {
+		return "", "", fmt.Errorf("years are not supported")
+	}
+
+	fields := []string{clock[2], clock[1], clock[0], date[2], date[1], dow}
+	for i, f := range fields {
+		if strings.ContainsAny(f, "~.") && !strings.Contains(f, "..") {
+			return "", "", fmt.Errorf("unsupported calendar expression %s", spec)
+		}
+		fields[i] = strings.Replace(f, "..", "-", -1)
+	}
+
+	schedule := strings.Join(fields, " ")
+	if _, err := extcron.Parse(schedule); err != nil {
+		return "", "", fmt.Errorf("unsupported calendar expression %s: %s", spec, err)
+	}
+
+	return schedule, timezone, nil
+}
+
+// parseSystemdSpan parses a systemd time span like "1h 30min".
+func parseSystemdSpan(span string) (time.Duration, error) {
+	matches := systemdSpanPattern.FindAllStringSubmatch(span, -1)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("invalid time span %s", span)
+	}
+
+	var d time.Duration
+	for _, m := range matches {
+		unit, ok := systemdSpanUnits[m[2]]
+		if !ok {
+			return 0, fmt.Errorf("unsupported time span unit %s", m[2])
+		}
+		v, _ := strconv.Atoi(m[1])
+		d += time.Duration(v) * unit
+	}
+	return d, nil
+}
+
+// unitFile holds the values of a systemd unit file by section and key.
+type unitFile map[string]map[string][]string
+
+func (u unitFile) values(section, key string) []string {
+	return u[section][key]
+}
+
+func (u unitFile) get(section, key string) string {
+	v := u.values(section, key)
+	if len(v) == 0 {
+		return ""
+	}
+	return v[len(v)-1]
+}
+
+func parseUnitFile(content string) (unitFile, error) {
+	u := make(unitFile)
+	section := ""
+
+	var line string
+	for _, l := range strings.Split(content, "\n") {
+		l = strings.TrimSpace(l)
+		if strings.HasSuffix(l, `\`) {
+			line += strings.TrimSpace(strings.TrimSuffix(l, `\`)) + " "
+			continue
+		}
+		line, l = "", line+l
+
+		switch {
+		case l == "" || strings.HasPrefix(l, "#") || strings.HasPrefix(l, ";"):
+		case strings.HasPrefix(l, "[") && strings.HasSuffix(l, "]"):
+			section = l[1 : len(l)-1]
+			if u[section] == nil {
+				u[section] = make(map[string][]string)
+			}
+		default:
+			kv := strings.SplitN(l, "=", 2)
+			if len(kv) != 2 || section == "" {
+				return nil, fmt.Errorf("invalid unit file line: %s", l)
+			}
+			key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+			// An empty value resets the list
+			if value == "" {
+				delete(u[section], key)
+				continue
+			}
+			u[section][key] = append(u[section][key], value)
+		}
+	}
+
+	return u, nil
+}
+
+// splitFields returns the first n whitespace separated fields of the line
+// and the rest of it.
+func splitFields(line string, n int) ([]string, string) {
+	var fields []string
+	rest := strings.TrimSpace(line)
+	for len(fields) < n && rest != "" {
+		i := strings.IndexAny(rest, " \t")
+		if i < 0 {
+			fields = append(fields, rest)
+			rest = ""
+			break
+		}
+		fields = append(fields, rest[:i])
+		rest = strings.TrimSpace(rest[i:])
+	}
+	if len(fields) < n {
+		return fields, ""
+	}
+	return fields, rest
+}
+
+// splitQuoted splits space separated, optionally double quoted, values.
+func splitQuoted(s string) []string {
+	var values []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if current.Len() > 0 {
+				values = append(values, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		values = append(values, current.String())
+	}
+	return values
+}
+
+// importJobName builds a valid job name from the command or unit name,
+// suffixed with the crontab line number if given.
+func importJobName(prefix, command string, n int) string {
+	name := command
+	if fields := strings.Fields(command); len(fields) > 0 {
+		name = path.Base(fields[0])
+	}
+	name = strings.Trim(illegalNamePattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	if name == "" {
+		name = "job"
+	}
+	if n > 0 {
+		name = fmt.Sprintf("%s-%d", name, n)
+	}
+	return prefix + name
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,156 @@
+package dkron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCrontab(t *testing.T) {
+	crontab := `
+SHELL=/bin/bash
+MAILTO=ops@example.com
+CRON_TZ=Europe/Berlin
+PATH=/usr/local/bin:/usr/bin
+
+# Nightly backup
+30 2 * * * /opt/scripts/backup.sh --full > /var/log/backup.log 2>&1
+*/15 * * * 1-5 curl -s http://localhost/ping
+@daily find /tmp -mtime +7 -delete
+@reboot /opt/scripts/start.sh
+0 0 * * * echo "$(date +\%F)" | mail -s report ops%body
+61 * * * * /bin/true
+`
+	result, err := ParseCrontab(strings.NewReader(crontab), &ImportOptions{
+		Prefix: "legacy-",
+		Tags:   map[string]string{"role": "web"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Jobs, 3)
+
+	job := result.Jobs[0]
+	assert.Equal(t, "legacy-backup-sh-8", job.Name)
+	assert.Equal(t, "Nightly backup", job.DisplayName)
+	assert.Equal(t, "0 30 2 * * *", job.Schedule)
+	assert.Equal(t, "Europe/Berlin", job.Timezone)
+	assert.Equal(t, "ops@example.com", job.OwnerEmail)
+	assert.Equal(t, "/opt/scripts/backup.sh --full > /var/log/backup.log 2>&1", job.ExecutorConfig["command"])
+	assert.Equal(t, "PATH=/usr/local/bin:/usr/bin", job.ExecutorConfig["env"])
+	assert.Equal(t, "web", job.Tags["role"])
+	assert.Equal(t, "crontab:8", job.Metadata["imported_from"])
+
+	assert.Equal(t, "0 */15 * * * 1-5", result.Jobs[1].Schedule)
+	assert.Empty(t, result.Jobs[1].DisplayName)
+	assert.Equal(t, "@daily", result.Jobs[2].Schedule)
+
+	require.Len(t, result.Skipped, 3)
+	assert.Equal(t, "line 11", result.Skipped[0].Source)
+	assert.Contains(t, result.Skipped[0].Reason, "@reboot")
+	assert.Contains(t, result.Skipped[1].Reason, "% command input")
+	assert.Contains(t, result.Skipped[2].Reason, ErrScheduleParse.Error())
+}
+
+func TestParseCrontab_System(t *testing.T) {
+	crontab := "17 * * * * root cd / && run-parts --report /etc/cron.hourly\n"
+
+	result, err := ParseCrontab(strings.NewReader(crontab), &ImportOptions{System: true})
+	require.NoError(t, err)
+	require.Len(t, result.Jobs, 1)
+	assert.Equal(t, "cd-1", result.Jobs[0].Name)
+	assert.Equal(t, "0 17 * * * *", result.Jobs[0].Schedule)
+	assert.Equal(t, "root", result.Jobs[0].Metadata["user"])
+	assert.Equal(t, "cd / && run-parts --report /etc/cron.hourly", result.Jobs[0].ExecutorConfig["command"])
+}
+
+func TestParseSystemdUnits(t *testing.T) {
+	units := map[string]string{
+		"report.timer": `[Unit]
+Description=Weekly report
+
+[Timer]
+OnCalendar=Mon..Fri *-*-* 06:30 Europe/Madrid
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`,
+		"report.service": `[Unit]
+Description=Report generator
+
+[Service]
+Type=oneshot
+User=reports
+WorkingDirectory=/srv/reports
+Environment="LANG=C" "OUT=/srv/out"
+ExecStart=-/usr/bin/generate \
+  --format pdf
+ExecStart=/usr/bin/upload
+`,
+		"cleanup.timer": `[Timer]
+OnUnitActiveSec=1h 30min
+Unit=tmp-cleanup.service
+`,
+		"tmp-cleanup.service": `[Service]
+ExecStart=/usr/bin/cleanup
+`,
+		"orphan.timer": `[Timer]
+OnCalendar=daily
+`,
+		"boot.timer": `[Timer]
+OnBootSec=5min
+`,
+	}
+
+	result, err := ParseSystemdUnits(units, &ImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Jobs, 2)
+
+	job := result.Jobs[0]
+	assert.Equal(t, "cleanup", job.Name)
+	assert.Equal(t, "@every 1h30m0s", job.Schedule)
+	assert.Equal(t, "/usr/bin/cleanup", job.ExecutorConfig["command"])
+
+	job = result.Jobs[1]
+	assert.Equal(t, "report", job.Name)
+	assert.Equal(t, "Weekly report", job.DisplayName)
+	assert.Equal(t, "00 30 06 * * mon-fri", job.Schedule)
+	assert.Equal(t, "Europe/Madrid", job.Timezone)
+	assert.Equal(t, "/usr/bin/generate --format pdf && /usr/bin/upload", job.ExecutorConfig["command"])
+	assert.Equal(t, "LANG=C,OUT=/srv/out", job.ExecutorConfig["env"])
+	assert.Equal(t, "/srv/reports", job.ExecutorConfig["cwd"])
+	assert.Equal(t, "reports", job.Metadata["user"])
+
+	require.Len(t, result.Skipped, 2)
+	assert.Equal(t, "boot.timer", result.Skipped[0].Source)
+	assert.Equal(t, "orphan.timer", result.Skipped[1].Source)
+	assert.Contains(t, result.Skipped[1].Reason, "orphan.service not found")
+}
+
+func TestSystemdCalendarToCron(t *testing.T) {
+	tests := []struct {
+		spec     string
+		schedule string
+		err      bool
+	}{
+		{"hourly", "0 0 * * * *", false},
+		{"weekly", "0 0 0 * * mon", false},
+		{"*-*-* 02:00:00", "00 00 02 * * *", false},
+		{"*:0/15", "00 0/15 * * * *", false},
+		{"Sat,Sun 10:00", "00 00 10 * * sat,sun", false},
+		{"*-*-01 00:00", "00 00 00 01 * *", false},
+		{"2021-*-* 00:00", "", true},
+		{"*-*~03", "", true},
+	}
+
+	for _, tt := range tests {
+		schedule, _, err := systemdCalendarToCron(tt.spec)
+		if tt.err {
+			assert.Error(t, err, tt.spec)
+			continue
+		}
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.schedule, schedule, tt.spec)
+	}
+}
@@ -0,0 +1,57 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/tidwall/buntdb"
+)
+
+var (
+	// ErrMinInterval is returned when a job is started again before its
+	// minimum interval since the previous start.
+	ErrMinInterval = errors.New("job can't start more often than its min interval")
+	// ErrInvalidMinInterval is returned when the minimum interval of a
+	// job is not valid.
+	ErrInvalidMinInterval = errors.New("invalid min interval")
+)
+
+func (j *Job) validateMinInterval() error {
+	if j.MinInterval == "" {
+		return nil
+	}
+	if d, err := time.ParseDuration(j.MinInterval); err != nil || d <= 0 {
+		return fmt.Errorf("%s: %q is not a positive duration", ErrInvalidMinInterval, j.MinInterval)
+	}
+	return nil
+}
+
+// checkMinInterval returns an error if starting a run of the job at now is
+// too soon after its previous run. Retries, backfills and replayed
+// dispatches aren't new starts and aren't checked.
+func (a *Agent) checkMinInterval(job *Job, now time.Time) error {
+	if job.MinInterval == "" {
+		return nil
+	}
+	d, _ := time.ParseDuration(job.MinInterval)
+
+	exg, err := a.Store.GetLastExecutionGroup(job.Name)
+	if err != nil && err != buntdb.ErrNotFound {
+		return err
+	}
+	if len(exg) == 0 {
+		return nil
+	}
+
+	next := time.Unix(0, exg[0].Group).Add(d)
+	if now.Before(next) {
+		metrics.IncrCounterWithLabels([]string{"job", "min_interval_rejected"}, 1, []metrics.Label{
+			{Name: "job", Value: job.Name},
+			{Name: "namespace", Value: job.Namespace()},
+		})
+		return fmt.Errorf("%s %s: next start allowed at %s", ErrMinInterval, job.MinInterval, next.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package dkron
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinInterval(t *testing.T) {
+	port := "8139"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:        "export",
+		Schedule:    "@manually",
+		Executor:    "shell",
+		MinInterval: "1h",
+	}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	// The first run can start
+	now := time.Now()
+	assert.NoError(t, a.checkMinInterval(job, now))
+
+	ex := &Execution{JobName: job.Name, Group: now.Add(-time.Minute).UnixNano(), Attempt: 1, NodeName: "test", StartedAt: now.Add(-time.Minute)}
+	_, err := a.Store.SetExecution(ex)
+	require.NoError(t, err)
+
+	err = a.checkMinInterval(job, now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrMinInterval.Error())
+	assert.NoError(t, a.checkMinInterval(job, now.Add(time.Hour)))
+
+	resp, err := http.Post(baseURL+"/jobs/export", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	job.MinInterval = "soon"
+	assert.Error(t, job.Validate())
+}
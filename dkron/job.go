@@ -4,12 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/distribworks/dkron/v3/extcron"
 	"github.com/distribworks/dkron/v3/ntime"
 	"github.com/distribworks/dkron/v3/plugin"
 	proto "github.com/distribworks/dkron/v3/plugin/types"
+	pb "github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 	"github.com/tidwall/buntdb"
@@ -26,11 +28,34 @@ const (
 	StatusFailed = "failed"
 	// StatusPartialyFailed is status of a job whose last run was successful on only some nodes.
 	StatusPartialyFailed = "partially_failed"
+	// StatusTripped is status of a job that was disabled by its circuit breaker
+	// after reaching the maximum number of consecutive failures.
+	StatusTripped = "tripped"
+	// StatusSkipped is status of a job whose last run was skipped, its
+	// pre-check reported no work.
+	StatusSkipped = "skipped"
 
 	// ConcurrencyAllow allows a job to execute concurrency.
 	ConcurrencyAllow = "allow"
 	// ConcurrencyForbid forbids a job from executing concurrency.
 	ConcurrencyForbid = "forbid"
+
+	// PriorityLow is the priority of jobs whose scheduled runs are deferred
+	// while the leader is overloaded.
+	PriorityLow = "low"
+	// PriorityNormal is the default priority of jobs.
+	PriorityNormal = "normal"
+
+	// NotifyAlways notifies every finished run of a job.
+	NotifyAlways = "always"
+	// NotifyStateChange only notifies the runs changing the state of a job,
+	// from success to failure and back.
+	NotifyStateChange = "state-change"
+
+	// DefaultNamespace is the namespace of jobs that don't set one.
+	DefaultNamespace = "default"
+	// namespaceKey is the metadata key holding the namespace of a job.
+	namespaceKey = "namespace"
 )
 
 var (
@@ -46,8 +71,40 @@ var (
 	ErrNoCommand = errors.New("unspecified command for job")
 	// ErrWrongConcurrency is returned when Concurrency is set to a non existing setting.
 	ErrWrongConcurrency = errors.New("invalid concurrency policy value, use \"allow\" or \"forbid\"")
+	// ErrWrongPriority is returned when Priority is set to a non existing setting.
+	ErrWrongPriority = errors.New("invalid priority value, use \"low\" or \"normal\"")
+	// ErrWrongNotifyOn is returned when NotifyOn is set to a non existing setting.
+	ErrWrongNotifyOn = errors.New("invalid notify_on value, use \"always\" or \"state-change\"")
+	// ErrNegativeNotifyEvery is returned when NotifyEvery is negative.
+	ErrNegativeNotifyEvery = errors.New("notify_every can not be negative")
+	// ErrNegativeMaxFailures is returned when MaxConsecutiveFailures is negative.
+	ErrNegativeMaxFailures = errors.New("max_consecutive_failures can not be negative")
+	// ErrMissingOwner is returned when a job lacks an owner field required by the cluster.
+	ErrMissingOwner = errors.New("job is missing a required owner field")
+	// ErrUnknownOwnerField is returned when a required owner field doesn't exist.
+	ErrUnknownOwnerField = errors.New("unknown owner field, use owner, owner_email, owner_team or owner_escalation_channel")
+	// ErrJobLocked is returned when changing a locked job without the admin token.
+	ErrJobLocked = errors.New("job is locked, it can only be changed using the admin token")
+	// ErrCloneName is returned when cloning a job without a new name.
+	ErrCloneName = errors.New("the clone needs a name different from the job")
+	// ErrStepsWithExecutor is returned when a job sets both an executor and steps.
+	ErrStepsWithExecutor = errors.New("a job with steps can't set an executor, set it in every step")
+	// ErrInvalidStep is returned when a step lacks a unique name or an executor.
+	ErrInvalidStep = errors.New("invalid step")
+	// ErrConcurrencyKey is returned when a job sets a concurrency key
+	// without forbidding concurrency or the metadata key.
+	ErrConcurrencyKey = errors.New("a concurrency key requires the forbid concurrency policy and the key in the job metadata")
+	// ErrInvalidResources is returned when the resources of a job are negative or of an unknown io class.
+	ErrInvalidResources = errors.New("invalid resources")
+	// ErrReservedName is returned when the name of a job is taken by an API route.
+	ErrReservedName = errors.New("the job name is reserved")
 )
 
+// reservedJobNames are taken by the routes under /v1/jobs.
+var reservedJobNames = map[string]bool{
+	"search": true,
+}
+
 // Job descibes a scheduled Job.
 type Job struct {
 	// Job name. Must be unique, acts as the id.
@@ -69,12 +126,21 @@ type Job struct {
 	// Owner email of the job.
 	OwnerEmail string `json:"owner_email"`
 
+	// Team owning the job.
+	OwnerTeam string `json:"owner_team"`
+
+	// Channel to escalate problems with the job to, e.g. a chat channel.
+	OwnerEscalationChannel string `json:"owner_escalation_channel"`
+
 	// Number of successful executions of this job.
 	SuccessCount int `json:"success_count"`
 
 	// Number of errors running this job.
 	ErrorCount int `json:"error_count"`
 
+	// Number of runs of this job skipped for having no work.
+	SkipCount int `json:"skip_count"`
+
 	// Last time this job executed succesful.
 	LastSuccess ntime.NullableTime `json:"last_success"`
 
@@ -84,6 +150,10 @@ type Job struct {
 	// Is this job disabled?
 	Disabled bool `json:"disabled"`
 
+	// Is this job locked? Locked jobs can only be updated or deleted
+	// using the admin token.
+	Locked bool `json:"locked"`
+
 	// Tags of the target servers to run this job against.
 	Tags map[string]string `json:"tags"`
 
@@ -111,17 +181,146 @@ type Job struct {
 	// Concurrency policy for this job (allow, forbid)
 	Concurrency string `json:"concurrency"`
 
+	// ConcurrencyKey is a metadata key extending the forbid concurrency
+	// policy to all the jobs sharing its value, which run one at a time.
+	ConcurrencyKey string `json:"concurrency_key,omitempty"`
+
+	// Priority of the job (low, normal), the scheduled runs of low
+	// priority jobs are deferred while the leader is overloaded.
+	Priority string `json:"priority,omitempty"`
+
 	// Executor plugin to be used in this job
 	Executor string `json:"executor"`
 
 	// Executor args
 	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config"`
 
+	// Steps run in order on the same node instead of the executor, with a
+	// shared workspace, until one of them fails.
+	Steps []*Step `json:"steps,omitempty"`
+
+	// Resources reserved on the node while the job runs, used to avoid
+	// placing it on nodes already saturated by other executions.
+	Resources *Resources `json:"resources,omitempty"`
+
 	// Computed job status
 	Status string `json:"status"`
 
+	// Number of consecutive failed executions after which the job is
+	// disabled. Zero means the circuit breaker is disabled.
+	MaxConsecutiveFailures int `json:"max_consecutive_failures"`
+
+	// Number of failed executions since the last successful one.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	// When to notify the finished runs of the job (always, state-change).
+	// With state-change only the first failed run and the recovery are
+	// notified.
+	NotifyOn string `json:"notify_on,omitempty"`
+
+	// With state-change, notify every this many failed runs while the job
+	// keeps failing. Zero only notifies the first one.
+	NotifyEvery int `json:"notify_every,omitempty"`
+
+	// Escalation steps notified as the job keeps failing.
+	Escalation []*EscalationStep `json:"escalation,omitempty"`
+
+	// Number of escalation steps reached by the failed runs since the last
+	// successful one.
+	EscalationLevel int `json:"escalation_level"`
+
+	// Canary rolling out a new spec of the job.
+	Canary *Canary `json:"canary,omitempty"`
+
+	// Signature of the spec of the job, base64 encoded ed25519.
+	Signature string `json:"signature,omitempty"`
+
+	// Name of the job signing key that verified the signature, set by the
+	// server.
+	SignedBy string `json:"signed_by,omitempty"`
+
+	// Member events running the job, with the member as params of the
+	// execution.
+	Triggers []*MemberTrigger `json:"triggers,omitempty"`
+
+	// Time the runs of the job must be finished by, a duration counted
+	// from their scheduled time. Late runs are notified.
+	Deadline string `json:"deadline,omitempty"`
+
+	// What to do with the dependent jobs of runs finishing after the
+	// deadline (skip-dependents, run-dependents).
+	DeadlineAction string `json:"deadline_action,omitempty"`
+
+	// Execution group of the last run that missed the deadline, set by
+	// the server.
+	BreachedGroup int64 `json:"breached_group,omitempty"`
+
+	// Job this one is the standby of, its scheduled runs are skipped
+	// unless the last run of the primary job failed or it missed its run.
+	StandbyFor string `json:"standby_for,omitempty"`
+
+	// Minimum time between the starts of the runs of the job, scheduled or
+	// not, runs starting sooner are rejected.
+	MinInterval string `json:"min_interval,omitempty"`
+
+	// Runs the job can start per day or month, runs past it are skipped.
+	Budget *Budget `json:"budget,omitempty"`
+
+	// Regular expressions of the secrets redacted from the output of the
+	// runs, with the ones of the cluster and the namespace.
+	RedactPatterns []string `json:"redact_patterns,omitempty"`
+
+	// Consul service whose healthy instances the job runs on, along with
+	// the tags of the job.
+	ConsulService *ConsulService `json:"consul_service,omitempty"`
+
+	// Secrets read from Vault by the agent running the job before every
+	// run, their leases are revoked once the run is done.
+	Secrets []*Secret `json:"secrets,omitempty"`
+
+	// Names of the credentials delivered to the agent running the job for
+	// the duration of every run.
+	Credentials []string `json:"credentials,omitempty"`
+
+	// Cost of every run besides the minutes it runs for, like the calls
+	// to a paid external API.
+	CostPerRun float64 `json:"cost_per_run,omitempty"`
+
+	// Params matrix expanding every run into one execution per item.
+	Matrix *Matrix `json:"matrix,omitempty"`
+
+	// Check run before every run, skipping it when there is no work.
+	Precheck *Precheck `json:"precheck,omitempty"`
+
+	// What the job does when the run of its parent job was skipped: skip
+	// or run. It doesn't run by default.
+	OnParentSkip string `json:"on_parent_skip,omitempty"`
+
+	// Version of the job spec, increased by the server on every change.
+	Version int64 `json:"version"`
+
 	// Computed next execution
 	Next time.Time `json:"next"`
+
+	// When and by whom the job was created, set by the server.
+	CreatedAt ntime.NullableTime `json:"created_at"`
+	CreatedBy string             `json:"created_by"`
+
+	// When and by whom the job was last changed, set by the server.
+	UpdatedAt ntime.NullableTime `json:"updated_at"`
+	UpdatedBy string             `json:"updated_by"`
+}
+
+// Step is an inline step of a composite job.
+type Step struct {
+	// Step name, unique in the job.
+	Name string `json:"name"`
+
+	// Executor plugin running the step.
+	Executor string `json:"executor"`
+
+	// Executor args
+	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config"`
 }
 
 // NewJobFromProto create a new Job from a PB Job struct
@@ -137,17 +336,51 @@ func NewJobFromProto(in *proto.Job) *Job {
 		OwnerEmail:     in.OwnerEmail,
 		SuccessCount:   int(in.SuccessCount),
 		ErrorCount:     int(in.ErrorCount),
+		SkipCount:      int(in.SkipCount),
 		Disabled:       in.Disabled,
 		Tags:           in.Tags,
 		Retries:        uint(in.Retries),
 		DependentJobs:  in.DependentJobs,
 		ParentJob:      in.ParentJob,
 		Concurrency:    in.Concurrency,
+		ConcurrencyKey: in.ConcurrencyKey,
+		Priority:       in.Priority,
 		Executor:       in.Executor,
 		ExecutorConfig: in.ExecutorConfig,
 		Status:         in.Status,
 		Metadata:       in.Metadata,
 		Next:           next,
+
+		MaxConsecutiveFailures: int(in.MaxConsecutiveFailures),
+		ConsecutiveFailures:    int(in.ConsecutiveFailures),
+		NotifyOn:               in.NotifyOn,
+		NotifyEvery:            int(in.NotifyEvery),
+		Escalation:             escalationFromProto(in.Escalation),
+		EscalationLevel:        int(in.EscalationLevel),
+		Canary:                 canaryFromProto(in.Canary),
+		Signature:              in.Signature,
+		SignedBy:               in.SignedBy,
+		Triggers:               triggersFromProto(in.Triggers),
+		Deadline:               in.Deadline,
+		DeadlineAction:         in.DeadlineAction,
+		BreachedGroup:          in.BreachedGroup,
+		StandbyFor:             in.StandbyFor,
+		MinInterval:            in.MinInterval,
+		Budget:                 budgetFromProto(in.Budget),
+		Version:                in.Version,
+		RedactPatterns:         in.RedactPatterns,
+		ConsulService:          consulServiceFromProto(in.ConsulService),
+		Secrets:                secretsFromProto(in.Secrets),
+		Credentials:            in.Credentials,
+		CostPerRun:             in.CostPerRun,
+		Matrix:                 matrixFromProto(in.Matrix),
+		Precheck:               precheckFromProto(in.Precheck),
+		OnParentSkip:           in.OnParentSkip,
+		OwnerTeam:              in.OwnerTeam,
+		OwnerEscalationChannel: in.OwnerEscalationChannel,
+		Locked:                 in.Locked,
+		CreatedBy:              in.CreatedBy,
+		UpdatedBy:              in.UpdatedBy,
 	}
 	if in.GetLastSuccess().GetHasValue() {
 		t, _ := ptypes.Timestamp(in.GetLastSuccess().GetTime())
@@ -157,6 +390,14 @@ func NewJobFromProto(in *proto.Job) *Job {
 		t, _ := ptypes.Timestamp(in.GetLastError().GetTime())
 		job.LastError.Set(t)
 	}
+	if in.GetCreatedAt().GetHasValue() {
+		t, _ := ptypes.Timestamp(in.GetCreatedAt().GetTime())
+		job.CreatedAt.Set(t)
+	}
+	if in.GetUpdatedAt().GetHasValue() {
+		t, _ := ptypes.Timestamp(in.GetUpdatedAt().GetTime())
+		job.UpdatedAt.Set(t)
+	}
 
 	procs := make(map[string]plugin.Config)
 	for k, v := range in.Processors {
@@ -167,6 +408,15 @@ func NewJobFromProto(in *proto.Job) *Job {
 	}
 	job.Processors = procs
 
+	for _, s := range in.Steps {
+		job.Steps = append(job.Steps, &Step{
+			Name:           s.Name,
+			Executor:       s.Executor,
+			ExecutorConfig: s.ExecutorConfig,
+		})
+	}
+	job.Resources = newResourcesFromProto(in.Resources)
+
 	return job
 }
 
@@ -184,12 +434,32 @@ func (j *Job) ToProto() *proto.Job {
 	if j.LastError.HasValue() {
 		lastError.Time, _ = ptypes.TimestampProto(j.LastError.Get())
 	}
+	createdAt := &proto.Job_NullableTime{
+		HasValue: j.CreatedAt.HasValue(),
+	}
+	if j.CreatedAt.HasValue() {
+		createdAt.Time, _ = ptypes.TimestampProto(j.CreatedAt.Get())
+	}
+	updatedAt := &proto.Job_NullableTime{
+		HasValue: j.UpdatedAt.HasValue(),
+	}
+	if j.UpdatedAt.HasValue() {
+		updatedAt.Time, _ = ptypes.TimestampProto(j.UpdatedAt.Get())
+	}
 	next, _ := ptypes.TimestampProto(j.Next)
 
 	processors := make(map[string]*proto.PluginConfig)
 	for k, v := range j.Processors {
 		processors[k] = &proto.PluginConfig{Config: v}
 	}
+	var steps []*proto.JobStep
+	for _, s := range j.Steps {
+		steps = append(steps, &proto.JobStep{
+			Name:           s.Name,
+			Executor:       s.Executor,
+			ExecutorConfig: s.ExecutorConfig,
+		})
+	}
 	return &proto.Job{
 		Name:           j.Name,
 		Displayname:    j.DisplayName,
@@ -199,25 +469,91 @@ func (j *Job) ToProto() *proto.Job {
 		OwnerEmail:     j.OwnerEmail,
 		SuccessCount:   int32(j.SuccessCount),
 		ErrorCount:     int32(j.ErrorCount),
+		SkipCount:      int32(j.SkipCount),
 		Disabled:       j.Disabled,
 		Tags:           j.Tags,
 		Retries:        uint32(j.Retries),
 		DependentJobs:  j.DependentJobs,
 		ParentJob:      j.ParentJob,
 		Concurrency:    j.Concurrency,
+		ConcurrencyKey: j.ConcurrencyKey,
+		Priority:       j.Priority,
 		Processors:     processors,
 		Executor:       j.Executor,
 		ExecutorConfig: j.ExecutorConfig,
+		Steps:          steps,
+		Resources:      j.Resources.toProto(),
 		Status:         j.Status,
 		Metadata:       j.Metadata,
 		LastSuccess:    lastSuccess,
 		LastError:      lastError,
 		Next:           next,
+
+		MaxConsecutiveFailures: int32(j.MaxConsecutiveFailures),
+		ConsecutiveFailures:    int32(j.ConsecutiveFailures),
+		NotifyOn:               j.NotifyOn,
+		NotifyEvery:            int32(j.NotifyEvery),
+		Escalation:             escalationToProto(j.Escalation),
+		EscalationLevel:        int32(j.EscalationLevel),
+		Canary:                 j.Canary.toProto(),
+		Signature:              j.Signature,
+		SignedBy:               j.SignedBy,
+		Triggers:               triggersToProto(j.Triggers),
+		Deadline:               j.Deadline,
+		DeadlineAction:         j.DeadlineAction,
+		BreachedGroup:          j.BreachedGroup,
+		StandbyFor:             j.StandbyFor,
+		MinInterval:            j.MinInterval,
+		Budget:                 j.Budget.toProto(),
+		Version:                j.Version,
+		RedactPatterns:         j.RedactPatterns,
+		ConsulService:          j.ConsulService.toProto(),
+		Secrets:                secretsToProto(j.Secrets),
+		Credentials:            j.Credentials,
+		CostPerRun:             j.CostPerRun,
+		Matrix:                 j.Matrix.toProto(),
+		Precheck:               j.Precheck.toProto(),
+		OnParentSkip:           j.OnParentSkip,
+		OwnerTeam:              j.OwnerTeam,
+		OwnerEscalationChannel: j.OwnerEscalationChannel,
+		Locked:                 j.Locked,
+		CreatedAt:              createdAt,
+		CreatedBy:              j.CreatedBy,
+		UpdatedAt:              updatedAt,
+		UpdatedBy:              j.UpdatedBy,
 	}
 }
 
 // Run the job
 func (j *Job) Run() {
+	// The scheduler entry keeps the time it fired the job at
+	var scheduledAt time.Time
+	if j.Agent != nil && j.Agent.sched != nil && j.Agent.sched.Started {
+		if e, ok := j.Agent.sched.GetEntry(j.Name); ok {
+			scheduledAt = e.Prev
+		}
+	}
+	if j.Agent != nil && j.Agent.deferScheduledRun(j, scheduledAt) {
+		return
+	}
+	if j.StandbyFor != "" && j.Agent != nil && !j.Agent.standbyRuns(j, scheduledAt) {
+		return
+	}
+	j.run("", scheduledAt)
+}
+
+// run runs the job, requestID is the ID of the API request that
+// triggered the run and scheduledAt the time the schedule fired,
+// both kept in the execution.
+func (j *Job) run(requestID string, scheduledAt time.Time) {
+	ex := NewExecution(j.Name)
+	ex.RequestID = requestID
+	ex.ScheduledAt = scheduledAt
+	j.startRun(ex)
+}
+
+// startRun runs the new execution of the job if it's runnable.
+func (j *Job) startRun(ex *Execution) {
 	// As this function should comply with the Job interface of the cron package we will use
 	// the aget property on execution, this is why it need to check if it's set and otherwise fail.
 	if j.Agent == nil {
@@ -233,11 +569,46 @@ func (j *Job) Run() {
 
 		cronInspect.Set(j.Name, j)
 
-		// Simple execution wrapper
-		ex := NewExecution(j.Name)
+		if err := j.Agent.checkSchedulerPaused(); err != nil {
+			log.WithError(err).WithField("job", j.Name).Warn("job: Skipping execution")
+			return
+		}
+		if err := j.Agent.checkMinInterval(j, time.Now()); err != nil {
+			log.WithError(err).WithField("job", j.Name).Warn("job: Skipping execution")
+			return
+		}
+		now := time.Now()
+		if err := j.Agent.useBudget(j, now); err != nil {
+			log.WithError(err).WithField("job", j.Name).Warn("job: Skipping execution")
+			return
+		}
+
+		// Jobs of a concurrency group hold its lock while Agent.Run waits
+		// for the run. Retries and dependent jobs start from ExecutionDone
+		// before it returns, so they are covered, but the items listed by a
+		// matrix discovery run in the background and results reported
+		// after the stream to the node broke arrive once the lock is gone.
+		if name := j.concurrencyLockName(); name != "" {
+			holder := fmt.Sprintf("%s/%d", j.Name, ex.Group)
+			if _, err := j.Agent.acquireLock(&Lock{Name: name, Holder: holder, AcquiredAt: time.Now()}); err != nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"job":  j.Name,
+					"lock": name,
+				}).Info("job: Skipping execution, concurrency group is running")
+				j.Agent.refundBudget(j, now)
+				return
+			}
+			defer func() {
+				if err := j.Agent.releaseLock(name, holder); err != nil {
+					log.WithError(err).WithField("lock", name).Error("job: Error releasing concurrency lock")
+				}
+			}()
+		}
 
+		// Runs that couldn't be dispatched don't use the budget
 		if _, err := j.Agent.Run(j.Name, ex); err != nil {
 			log.WithError(err).Error("job: Error running job")
+			j.Agent.refundBudget(j, now)
 		}
 	}
 }
@@ -282,6 +653,30 @@ func (j *Job) GetNext() (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// Namespace returns the namespace of the job, set with the "namespace"
+// metadata key, used to group jobs of the same team or application.
+func (j *Job) Namespace() string {
+	if ns := j.Metadata[namespaceKey]; ns != "" {
+		return ns
+	}
+	return DefaultNamespace
+}
+
+// cronSchedule returns the job schedule including its timezone.
+func (j *Job) cronSchedule() string {
+	// If Timezone is set on the job, and not explicitly in its schedule,
+	// AND its not a descriptor (that don't support timezones), add the
+	// timezone to the schedule so robfig/cron knows about it.
+	schedule := extcron.Expand(j.Schedule)
+	if j.Timezone != "" &&
+		!strings.HasPrefix(schedule, "@") &&
+		!strings.HasPrefix(schedule, "TZ=") &&
+		!strings.HasPrefix(schedule, "CRON_TZ=") {
+		schedule = "CRON_TZ=" + j.Timezone + " " + schedule
+	}
+	return schedule
+}
+
 func (j *Job) isRunnable() bool {
 	if j.Disabled {
 		return false
@@ -315,6 +710,41 @@ func (j *Job) isRunnable() bool {
 	return true
 }
 
+// Clone returns a copy of the job spec with the given name, without the
+// execution counters, status and history of the job. Dependent jobs and
+// the lock are not copied either.
+func (j *Job) Clone(name string) *Job {
+	pbj := pb.Clone(j.ToProto()).(*proto.Job)
+	pbj.Name = name
+	pbj.SuccessCount = 0
+	pbj.ErrorCount = 0
+	pbj.SkipCount = 0
+	pbj.LastSuccess = nil
+	pbj.LastError = nil
+	pbj.Status = ""
+	pbj.Next = nil
+	pbj.ConsecutiveFailures = 0
+	pbj.EscalationLevel = 0
+	pbj.Canary = nil
+	pbj.Signature = ""
+	pbj.SignedBy = ""
+	pbj.BreachedGroup = 0
+	if pbj.Budget != nil {
+		pbj.Budget = &proto.Budget{
+			MaxRuns:   pbj.Budget.MaxRuns,
+			Period:    pbj.Budget.Period,
+			CarryOver: pbj.Budget.CarryOver,
+		}
+	}
+	pbj.DependentJobs = nil
+	pbj.Locked = false
+	pbj.Version = 0
+
+	clone := NewJobFromProto(pbj)
+	clone.Next = time.Time{}
+	return clone
+}
+
 // Validate validates whether all values in the job are acceptable.
 func (j *Job) Validate() error {
 	if j.Name == "" {
@@ -325,6 +755,10 @@ func (j *Job) Validate() error {
 		return fmt.Errorf("name contains illegal character '%s'", chr)
 	}
 
+	if reservedJobNames[j.Name] {
+		return fmt.Errorf("%s: %s", ErrReservedName, j.Name)
+	}
+
 	if j.ParentJob == j.Name {
 		return ErrSameParent
 	}
@@ -340,6 +774,104 @@ func (j *Job) Validate() error {
 		return ErrWrongConcurrency
 	}
 
+	if j.Priority != PriorityLow && j.Priority != PriorityNormal && j.Priority != "" {
+		return ErrWrongPriority
+	}
+
+	if j.ConcurrencyKey != "" && (j.Concurrency != ConcurrencyForbid || j.Metadata[j.ConcurrencyKey] == "") {
+		return ErrConcurrencyKey
+	}
+
+	if j.MaxConsecutiveFailures < 0 {
+		return ErrNegativeMaxFailures
+	}
+
+	if j.NotifyOn != NotifyAlways && j.NotifyOn != NotifyStateChange && j.NotifyOn != "" {
+		return ErrWrongNotifyOn
+	}
+
+	if j.NotifyEvery < 0 {
+		return ErrNegativeNotifyEvery
+	}
+
+	if err := j.validateSteps(); err != nil {
+		return err
+	}
+
+	if err := validateEscalation(j.Escalation); err != nil {
+		return err
+	}
+
+	if err := validateTriggers(j.Triggers); err != nil {
+		return err
+	}
+
+	if err := j.validateDeadline(); err != nil {
+		return err
+	}
+
+	if err := j.validateStandby(); err != nil {
+		return err
+	}
+
+	if err := j.validateMinInterval(); err != nil {
+		return err
+	}
+
+	if j.Budget != nil {
+		if err := j.Budget.validate(); err != nil {
+			return err
+		}
+	}
+
+	if j.Canary != nil {
+		if err := j.Canary.validate(j); err != nil {
+			return err
+		}
+	}
+
+	if j.ConsulService != nil {
+		if err := j.ConsulService.validate(); err != nil {
+			return err
+		}
+	}
+
+	if j.Matrix != nil {
+		if err := j.Matrix.validate(j); err != nil {
+			return err
+		}
+	}
+
+	if j.Precheck != nil {
+		if err := j.Precheck.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := j.validateOnParentSkip(); err != nil {
+		return err
+	}
+
+	if err := j.validateSecrets(); err != nil {
+		return err
+	}
+
+	if err := j.validateCredentials(); err != nil {
+		return err
+	}
+
+	if j.CostPerRun < 0 {
+		return ErrInvalidCost
+	}
+
+	if err := j.Resources.validate(); err != nil {
+		return err
+	}
+
+	if _, err := compileRedactPatterns(j.RedactPatterns); err != nil {
+		return err
+	}
+
 	// An empty string is a valid timezone for LoadLocation
 	if _, err := time.LoadLocation(j.Timezone); err != nil {
 		return err
@@ -348,6 +880,53 @@ func (j *Job) Validate() error {
 	return nil
 }
 
+// validateSteps checks that every step has a unique name and an executor.
+func (j *Job) validateSteps() error {
+	if len(j.Steps) == 0 {
+		return nil
+	}
+	if j.Executor != "" {
+		return ErrStepsWithExecutor
+	}
+
+	names := map[string]bool{}
+	for i, s := range j.Steps {
+		if s == nil || s.Name == "" {
+			return fmt.Errorf("%s: step %d has no name", ErrInvalidStep, i+1)
+		}
+		if names[s.Name] {
+			return fmt.Errorf("%s: duplicated step name %s", ErrInvalidStep, s.Name)
+		}
+		names[s.Name] = true
+		if s.Executor == "" {
+			return fmt.Errorf("%s: step %s has no executor", ErrInvalidStep, s.Name)
+		}
+	}
+	return nil
+}
+
+// ValidateOwner checks that the given owner fields are set in the job.
+func (j *Job) ValidateOwner(required []string) error {
+	fields := map[string]string{
+		"owner":                    j.Owner,
+		"owner_email":              j.OwnerEmail,
+		"owner_team":               j.OwnerTeam,
+		"owner_escalation_channel": j.OwnerEscalationChannel,
+	}
+
+	for _, f := range required {
+		v, ok := fields[f]
+		if !ok {
+			return fmt.Errorf("%s: %s", ErrUnknownOwnerField, f)
+		}
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("%s: %s", ErrMissingOwner, f)
+		}
+	}
+
+	return nil
+}
+
 // isSlug determines whether the given string is a proper value to be used as
 // key in the backend store (a "slug"). If false, the 2nd return value
 // will contain the first illegal character found.
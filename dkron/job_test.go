@@ -102,6 +102,56 @@ func TestToProto(t *testing.T) {
 	assert.Equal(t, jpb.Processors, proc)
 }
 
+func TestJobValidateOwner(t *testing.T) {
+	j := &Job{
+		Owner:     "mec",
+		OwnerTeam: "platform",
+	}
+
+	assert.NoError(t, j.ValidateOwner(nil))
+	assert.NoError(t, j.ValidateOwner([]string{"owner", "owner_team"}))
+
+	err := j.ValidateOwner([]string{"owner", "owner_escalation_channel"})
+	assert.EqualError(t, err, ErrMissingOwner.Error()+": owner_escalation_channel")
+
+	err = j.ValidateOwner([]string{"team"})
+	assert.EqualError(t, err, ErrUnknownOwnerField.Error()+": team")
+}
+
+func TestJobClone(t *testing.T) {
+	job := &Job{
+		Name:           "customer_a",
+		Schedule:       "@every 1m",
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "sync --customer a"},
+		Metadata:       map[string]string{"customer": "a"},
+		SuccessCount:   10,
+		ErrorCount:     2,
+		Status:         StatusSuccess,
+		DependentJobs:  []string{"child"},
+		Locked:         true,
+		Next:           time.Now(),
+	}
+	job.LastSuccess.Set(time.Now())
+
+	clone := job.Clone("customer_b")
+	assert.Equal(t, "customer_b", clone.Name)
+	assert.Equal(t, job.Schedule, clone.Schedule)
+	assert.Equal(t, job.ExecutorConfig, clone.ExecutorConfig)
+	assert.Equal(t, job.Metadata, clone.Metadata)
+	assert.Zero(t, clone.SuccessCount)
+	assert.Zero(t, clone.ErrorCount)
+	assert.Empty(t, clone.Status)
+	assert.Empty(t, clone.DependentJobs)
+	assert.False(t, clone.Locked)
+	assert.False(t, clone.LastSuccess.HasValue())
+	assert.True(t, clone.Next.IsZero())
+
+	// The clone doesn't share the maps
+	clone.ExecutorConfig["command"] = "sync --customer b"
+	assert.Equal(t, "sync --customer a", job.ExecutorConfig["command"])
+}
+
 func Test_isRunnable(t *testing.T) {
 	ip1, returnFn1 := testutil.TakeIP()
 	defer returnFn1()
@@ -162,16 +212,52 @@ func Test_isRunnable(t *testing.T) {
 type gRPCClientMock struct {
 }
 
-func (gRPCClientMock) Connect(s string) (*grpc.ClientConn, error) { return nil, nil }
-func (gRPCClientMock) ExecutionDone(s string, e *Execution) error { return nil }
-func (gRPCClientMock) GetJob(s string, a string) (*Job, error)    { return nil, nil }
-func (gRPCClientMock) SetJob(j *Job) error                        { return nil }
-func (gRPCClientMock) DeleteJob(s string) (*Job, error)           { return nil, nil }
-func (gRPCClientMock) Leave(s string) error                       { return nil }
-func (gRPCClientMock) RunJob(s string) (*Job, error)              { return nil, nil }
+func (gRPCClientMock) Connect(s string) (*grpc.ClientConn, error)          { return nil, nil }
+func (gRPCClientMock) ExecutionDone(s string, e *Execution) error          { return nil }
+func (gRPCClientMock) GetJob(s string, a string) (*Job, error)             { return nil, nil }
+func (gRPCClientMock) SetJob(j *Job, t string) error                       { return nil }
+func (gRPCClientMock) DeleteJob(s string, c bool, t string) (*Job, error)  { return nil, nil }
+func (gRPCClientMock) Leave(s string) error                                { return nil }
+func (gRPCClientMock) RunJob(s string, a []string, r string) (*Job, error) { return nil, nil }
+func (gRPCClientMock) TriggerJob(s string, p map[string]string, a []string, r string) (*Job, error) {
+	return nil, nil
+}
+func (gRPCClientMock) ShadowRunJob(s string, sr *ShadowRun, r string) (*Job, error) {
+	return nil, nil
+}
+func (gRPCClientMock) ResetJob(s string, t string) (*Job, error) { return nil, nil }
+func (gRPCClientMock) AnnotateExecution(j, k string, a []string) (*Execution, error) {
+	return nil, nil
+}
 func (gRPCClientMock) RaftGetConfiguration(s string) (*proto.RaftGetConfigurationResponse, error) {
 	return nil, nil
 }
+func (gRPCClientMock) CheckStore(s string, r bool, t string) (*CheckReport, error) { return nil, nil }
+func (gRPCClientMock) SetReadOnly(r bool) error                                    { return nil }
+func (gRPCClientMock) RestoreJob(s string, t string) (*Job, error)                 { return nil, nil }
+func (gRPCClientMock) SetMaintenanceWindow(w *MaintenanceWindow) error             { return nil }
+func (gRPCClientMock) SetSilence(s *Silence) error                                 { return nil }
+func (gRPCClientMock) DeleteSilence(id string) (*Silence, error)                   { return nil, nil }
+func (gRPCClientMock) SetCredential(c *Credential) error                           { return nil }
+func (gRPCClientMock) DeleteCredential(name string) (*Credential, error)           { return nil, nil }
+func (gRPCClientMock) DeleteMaintenanceWindow(s string) (*MaintenanceWindow, error) {
+	return nil, nil
+}
+func (gRPCClientMock) Compliance(e *ComplianceEvent) (*ComplianceEvent, error) {
+	return e, nil
+}
+func (gRPCClientMock) SetSchedulerPause(e *SchedulerEvent) (*SchedulerEvent, error) {
+	return e, nil
+}
+func (gRPCClientMock) Backfill(b *Backfill) (*Backfill, error) { return b, nil }
+func (gRPCClientMock) CancelBackfill(j string, id string) (*Backfill, error) {
+	return nil, nil
+}
+func (gRPCClientMock) AcquireLock(l *Lock, r bool) (*Lock, error)  { return l, nil }
+func (gRPCClientMock) ReleaseLock(n string, h string) error        { return nil }
+func (gRPCClientMock) SetKV(kv *KV) error                          { return nil }
+func (gRPCClientMock) DeleteKV(n string, k string) error           { return nil }
+func (gRPCClientMock) ReadIndex() (uint64, error)                  { return 0, nil }
 func (gRPCClientMock) RaftRemovePeerByID(s string, a string) error { return nil }
 func (gRPCClientMock) GetActiveExecutions(s string) ([]*proto.Execution, error) {
 	return []*proto.Execution{
@@ -184,6 +270,9 @@ func (gRPCClientMock) SetExecution(execution *proto.Execution) error { return ni
 func (gRPCClientMock) AgentRun(addr string, job *proto.Job, execution *proto.Execution) error {
 	return nil
 }
+func (gRPCClientMock) ReloadPlugin(addr, path string) (*proto.ReloadPluginResponse, error) {
+	return nil, nil
+}
 
 func Test_generateJobTree(t *testing.T) {
 	jsonString := `[
@@ -463,3 +552,84 @@ func Test_generateJobTree(t *testing.T) {
 	}
 	assert.Equal(t, len(jobTree), 3)
 }
+
+func TestJobValidateSteps(t *testing.T) {
+	j := &Job{
+		Name:     "pipeline",
+		Schedule: "@every 1h",
+		Steps: []*Step{
+			{Name: "extract", Executor: "shell"},
+			{Name: "load", Executor: "http"},
+		},
+	}
+	assert.NoError(t, j.Validate())
+
+	j.Executor = "shell"
+	assert.Equal(t, ErrStepsWithExecutor, j.Validate())
+
+	j.Executor = ""
+	j.Steps[1].Name = "extract"
+	assert.EqualError(t, j.Validate(), ErrInvalidStep.Error()+": duplicated step name extract")
+
+	j.Steps[1] = &Step{Name: "load"}
+	assert.EqualError(t, j.Validate(), ErrInvalidStep.Error()+": step load has no executor")
+
+	jpb := j.ToProto()
+	assert.Equal(t, j.Steps, NewJobFromProto(jpb).Steps)
+}
+
+func TestJobValidateConcurrencyKey(t *testing.T) {
+	j := &Job{
+		Name:           "load",
+		Schedule:       "@every 1h",
+		Executor:       "shell",
+		Concurrency:    ConcurrencyForbid,
+		ConcurrencyKey: "lock",
+		Metadata:       map[string]string{"lock": "warehouse"},
+	}
+	assert.NoError(t, j.Validate())
+	assert.Equal(t, "concurrency:lock=warehouse", j.concurrencyLockName())
+	assert.Equal(t, "lock", NewJobFromProto(j.ToProto()).ConcurrencyKey)
+
+	j.Concurrency = ConcurrencyAllow
+	assert.Equal(t, ErrConcurrencyKey, j.Validate())
+	assert.Empty(t, j.concurrencyLockName())
+
+	j.Concurrency = ConcurrencyForbid
+	j.Metadata = nil
+	assert.Equal(t, ErrConcurrencyKey, j.Validate())
+}
+
+func TestJobValidatePriority(t *testing.T) {
+	j := &Job{
+		Name:     "report",
+		Schedule: "@every 1h",
+		Executor: "shell",
+		Priority: PriorityLow,
+	}
+	assert.NoError(t, j.Validate())
+	assert.Equal(t, PriorityLow, NewJobFromProto(j.ToProto()).Priority)
+
+	j.Priority = "urgent"
+	assert.Equal(t, ErrWrongPriority, j.Validate())
+}
+
+func TestJobValidateNotifyOn(t *testing.T) {
+	j := &Job{
+		Name:        "report",
+		Schedule:    "@every 1h",
+		Executor:    "shell",
+		NotifyOn:    NotifyStateChange,
+		NotifyEvery: 12,
+	}
+	assert.NoError(t, j.Validate())
+	pj := NewJobFromProto(j.ToProto())
+	assert.Equal(t, NotifyStateChange, pj.NotifyOn)
+	assert.Equal(t, 12, pj.NotifyEvery)
+
+	j.NotifyEvery = -1
+	assert.Equal(t, ErrNegativeNotifyEvery, j.Validate())
+
+	j.NotifyOn = "never"
+	assert.Equal(t, ErrWrongNotifyOn, j.Validate())
+}
@@ -0,0 +1,319 @@
+package dkron
+
+import (
+	"fmt"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/serf/serf"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// dispatchPrefix is the key prefix of the dispatch journal.
+	dispatchPrefix = "dispatch"
+
+	// dispatchIntentTTL is how long the intents are kept after they were
+	// created, intents never completed expire after it.
+	dispatchIntentTTL = 24 * time.Hour
+)
+
+// DispatchIntent is the record the leader writes before dispatching the
+// first attempt of an execution to the agents, deleted once dispatched.
+// Intents left by a crashed leader are reconciled by the next one.
+type DispatchIntent struct {
+	JobName   string            `json:"job_name"`
+	Group     int64             `json:"group"`
+	RequestID string            `json:"request_id,omitempty"`
+	Nodes     map[string]string `json:"nodes"`
+	CreatedAt time.Time         `json:"created_at"`
+
+	// Params and matrix item of the execution.
+	Params     map[string]string `json:"params,omitempty"`
+	MatrixItem string            `json:"matrix_item,omitempty"`
+	MatrixSize int               `json:"matrix_size,omitempty"`
+	Discovery  bool              `json:"discovery,omitempty"`
+
+	// Dispatched are the nodes the execution was sent to.
+	Dispatched []string `json:"dispatched,omitempty"`
+}
+
+// NewDispatchIntentFromProto returns a new DispatchIntent from a proto.
+func NewDispatchIntentFromProto(in *dkronpb.DispatchIntent) *DispatchIntent {
+	createdAt, _ := ptypes.Timestamp(in.CreatedAt)
+	return &DispatchIntent{
+		JobName:    in.JobName,
+		Group:      in.Group,
+		RequestID:  in.RequestId,
+		Nodes:      in.Nodes,
+		CreatedAt:  createdAt,
+		Params:     in.Params,
+		MatrixItem: in.MatrixItem,
+		MatrixSize: int(in.MatrixSize),
+		Discovery:  in.Discovery,
+		Dispatched: in.Dispatched,
+	}
+}
+
+// ToProto returns the protobuf struct corresponding to the intent.
+func (di *DispatchIntent) ToProto() *dkronpb.DispatchIntent {
+	createdAt, _ := ptypes.TimestampProto(di.CreatedAt)
+	return &dkronpb.DispatchIntent{
+		JobName:    di.JobName,
+		Group:      di.Group,
+		RequestId:  di.RequestID,
+		Nodes:      di.Nodes,
+		CreatedAt:  createdAt,
+		Params:     di.Params,
+		MatrixItem: di.MatrixItem,
+		MatrixSize: int32(di.MatrixSize),
+		Discovery:  di.Discovery,
+		Dispatched: di.Dispatched,
+	}
+}
+
+// dispatchKey returns the key of the intent, the items of a matrix run
+// have one each.
+func dispatchKey(jobName string, group int64, matrixItem string) string {
+	if matrixItem != "" {
+		return fmt.Sprintf("%s:%s:%d:%s", dispatchPrefix, jobName, group, matrixItem)
+	}
+	return fmt.Sprintf("%s:%s:%d", dispatchPrefix, jobName, group)
+}
+
+// setDispatchIntentTx stores the intent to expire after the TTL from its
+// creation, the same time in every server.
+func setDispatchIntentTx(tx *buntdb.Tx, di *DispatchIntent) error {
+	b, err := proto.Marshal(di.ToProto())
+	if err != nil {
+		return err
+	}
+	key := dispatchKey(di.JobName, di.Group, di.MatrixItem)
+	ttl := time.Until(di.CreatedAt.Add(dispatchIntentTTL))
+	if ttl <= 0 {
+		if _, err := tx.Delete(key); err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+		return nil
+	}
+	_, _, err = tx.Set(key, string(b), &buntdb.SetOptions{Expires: true, TTL: ttl})
+	return err
+}
+
+// SetDispatchIntent stores a dispatch intent.
+func (s *Store) SetDispatchIntent(di *DispatchIntent) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		return setDispatchIntentTx(tx, di)
+	})
+}
+
+// MarkDispatched adds the node to the nodes the execution of the intent
+// was sent to, marking the execution of a missing intent is not an error.
+func (s *Store) MarkDispatched(jobName string, group int64, matrixItem, nodeName string) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(dispatchKey(jobName, group, matrixItem))
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var pbdi dkronpb.DispatchIntent
+		if err := proto.Unmarshal([]byte(value), &pbdi); err != nil {
+			return err
+		}
+		di := NewDispatchIntentFromProto(&pbdi)
+		if contains(di.Dispatched, nodeName) {
+			return nil
+		}
+		di.Dispatched = append(di.Dispatched, nodeName)
+		return setDispatchIntentTx(tx, di)
+	})
+}
+
+// DeleteDispatchIntent deletes a dispatch intent, deleting a missing one
+// is not an error.
+func (s *Store) DeleteDispatchIntent(jobName string, group int64, matrixItem string) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(dispatchKey(jobName, group, matrixItem))
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		return err
+	})
+}
+
+// GetDispatchIntents returns the pending dispatch intents.
+func (s *Store) GetDispatchIntents() ([]*DispatchIntent, error) {
+	intents := []*DispatchIntent{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(dispatchPrefix+":*", func(key, value string) bool {
+			var pbdi dkronpb.DispatchIntent
+			if err = proto.Unmarshal([]byte(value), &pbdi); err != nil {
+				return false
+			}
+			intents = append(intents, NewDispatchIntentFromProto(&pbdi))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return intents, nil
+}
+
+// journalDispatch records the intent to dispatch the execution to the nodes.
+func (a *Agent) journalDispatch(ex *Execution, nodes map[string]string) error {
+	di := &DispatchIntent{
+		JobName:    ex.JobName,
+		Group:      ex.Group,
+		RequestID:  ex.RequestID,
+		Nodes:      nodes,
+		CreatedAt:  time.Now(),
+		Params:     ex.Params,
+		MatrixItem: ex.MatrixItem,
+		MatrixSize: ex.MatrixSize,
+		Discovery:  ex.Discovery,
+	}
+	cmd, err := Encode(SetDispatchIntentType, di.ToProto())
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// markDispatched journals the dispatch of the execution to the node, a
+// new leader doesn't send it to the node again.
+func (a *Agent) markDispatched(ex *Execution, nodeName string) error {
+	cmd, err := Encode(MarkDispatchedType, &dkronpb.MarkDispatchedRequest{
+		JobName:    ex.JobName,
+		Group:      ex.Group,
+		MatrixItem: ex.MatrixItem,
+		NodeName:   nodeName,
+	})
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// completeDispatch deletes the intent of a dispatched execution.
+func (a *Agent) completeDispatch(ex *Execution) {
+	cmd, err := Encode(DeleteDispatchIntentType, &dkronpb.DeleteDispatchIntentRequest{
+		JobName:    ex.JobName,
+		Group:      ex.Group,
+		MatrixItem: ex.MatrixItem,
+	})
+	if err == nil {
+		af := a.RaftApply(cmd)
+		if err = af.Error(); err == nil {
+			err, _ = af.Response().(error)
+		}
+	}
+	if err != nil {
+		log.WithError(err).WithField("job", ex.JobName).Error("agent: Error completing dispatch intent")
+	}
+}
+
+// reconcileDispatches replays the dispatches a previous leader journaled
+// but didn't complete. The execution is sent again to the nodes of the
+// intent it wasn't sent to.
+func (a *Agent) reconcileDispatches() {
+	intents, err := a.Store.GetDispatchIntents()
+	if err != nil {
+		log.WithError(err).Error("agent: Error getting dispatch intents")
+		return
+	}
+
+	for _, di := range intents {
+		ex := &Execution{
+			JobName:    di.JobName,
+			Group:      di.Group,
+			Attempt:    1,
+			RequestID:  di.RequestID,
+			Params:     di.Params,
+			MatrixItem: di.MatrixItem,
+			MatrixSize: di.MatrixSize,
+			Discovery:  di.Discovery,
+		}
+
+		executions, err := a.Store.GetExecutionGroup(ex)
+		if err != nil && err != buntdb.ErrNotFound {
+			log.WithError(err).WithField("job", di.JobName).Error("agent: Error getting executions of dispatch intent")
+			continue
+		}
+		dispatched := map[string]bool{}
+		for _, e := range executions {
+			if e.MatrixItem == di.MatrixItem && e.Discovery == di.Discovery {
+				dispatched[e.NodeName] = true
+			}
+		}
+		for _, node := range di.Dispatched {
+			if !dispatched[node] {
+				log.WithFields(logrus.Fields{
+					"job":   di.JobName,
+					"group": di.Group,
+					"node":  node,
+				}).Warning("agent: Not replaying dispatch sent without execution stored")
+				dispatched[node] = true
+			}
+		}
+
+		job, err := a.Store.GetJob(di.JobName, nil)
+		if err != nil {
+			// The job is gone, nothing to dispatch
+			a.completeDispatch(ex)
+			continue
+		}
+		if di.Discovery {
+			if job.Matrix == nil || job.Matrix.Discovery == nil {
+				a.completeDispatch(ex)
+				continue
+			}
+			job = job.discoveryJob()
+		}
+
+		nodes := map[string]string{}
+		for _, m := range a.serf.Members() {
+			if _, ok := di.Nodes[m.Name]; ok && !dispatched[m.Name] && m.Status == serf.StatusAlive {
+				nodes[m.Name] = m.Tags["rpc_addr"]
+			}
+		}
+		if len(nodes) == 0 {
+			a.completeDispatch(ex)
+			continue
+		}
+
+		log.WithFields(logrus.Fields{
+			"job":        di.JobName,
+			"group":      di.Group,
+			"nodes":      nodes,
+			"request_id": di.RequestID,
+		}).Warning("agent: Replaying unfinished dispatch")
+
+		go func(job *Job, ex *Execution, nodes map[string]string) {
+			a.dispatch(job, ex, nodes, true)
+			a.completeDispatch(ex)
+		}(job, ex, nodes)
+	}
+}
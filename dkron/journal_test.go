@@ -0,0 +1,117 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestStore_DispatchIntents(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, s.SetDispatchIntent(&DispatchIntent{JobName: "job1", Group: 1, Nodes: map[string]string{"node1": "10.0.0.1:6868"}, CreatedAt: createdAt}))
+	require.NoError(t, s.SetDispatchIntent(&DispatchIntent{JobName: "job1", Group: 2, RequestID: "abc", CreatedAt: createdAt}))
+
+	intents, err := s.GetDispatchIntents()
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	assert.Equal(t, "10.0.0.1:6868", intents[0].Nodes["node1"])
+	assert.Equal(t, createdAt, intents[0].CreatedAt.UTC())
+	assert.Equal(t, "abc", intents[1].RequestID)
+
+	require.NoError(t, s.DeleteDispatchIntent("job1", 1, ""))
+	require.NoError(t, s.DeleteDispatchIntent("job1", 1, ""))
+
+	intents, err = s.GetDispatchIntents()
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, int64(2), intents[0].Group)
+
+	// Every item of a matrix run has its own intent
+	for _, item := range []string{"customer=1", "customer=2"} {
+		require.NoError(t, s.SetDispatchIntent(&DispatchIntent{JobName: "job2", Group: 3, MatrixItem: item, MatrixSize: 2, Params: map[string]string{"customer": item[9:]}, CreatedAt: createdAt}))
+	}
+	require.NoError(t, s.DeleteDispatchIntent("job2", 3, "customer=1"))
+	intents, err = s.GetDispatchIntents()
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	assert.Equal(t, "customer=2", intents[1].MatrixItem)
+	assert.Equal(t, "2", intents[1].Params["customer"])
+}
+
+func TestStore_MarkDispatched(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	createdAt := time.Now().Add(-time.Hour)
+	require.NoError(t, s.SetDispatchIntent(&DispatchIntent{JobName: "job1", Group: 1, Nodes: map[string]string{"node1": "", "node2": ""}, CreatedAt: createdAt}))
+
+	require.NoError(t, s.MarkDispatched("job1", 1, "", "node1"))
+	require.NoError(t, s.MarkDispatched("job1", 1, "", "node1"))
+	// Marking a missing intent is ignored
+	require.NoError(t, s.MarkDispatched("job1", 2, "", "node1"))
+
+	intents, err := s.GetDispatchIntents()
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, []string{"node1"}, intents[0].Dispatched)
+
+	// Intents expire a TTL after they were created
+	require.NoError(t, s.db.View(func(tx *buntdb.Tx) error {
+		ttl, err := tx.TTL(dispatchKey("job1", 1, ""))
+		assert.InDelta(t, dispatchIntentTTL-time.Hour, ttl, float64(time.Minute))
+		return err
+	}))
+	require.NoError(t, s.SetDispatchIntent(&DispatchIntent{JobName: "job1", Group: 3, CreatedAt: time.Now().Add(-dispatchIntentTTL)}))
+	intents, err = s.GetDispatchIntents()
+	require.NoError(t, err)
+	assert.Len(t, intents, 1)
+}
+
+func TestReconcileDispatches(t *testing.T) {
+	dir, a := setupAPITest(t, "8119")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{Name: "journaled", Schedule: "@every 1h", Executor: "shell", ExecutorConfig: map[string]string{"command": "true"}}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	// A leader crashed after journaling the dispatch
+	ex := &Execution{JobName: job.Name, Group: time.Now().UnixNano(), Attempt: 1}
+	require.NoError(t, a.journalDispatch(ex, map[string]string{"test": a.advertiseRPCAddr()}))
+
+	a.reconcileDispatches()
+
+	require.Eventually(t, func() bool {
+		intents, err := a.Store.GetDispatchIntents()
+		return err == nil && len(intents) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	executions, err := a.Store.GetExecutionGroup(ex)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.Equal(t, "test", executions[0].NodeName)
+
+	// Nodes the execution was sent to aren't called again
+	group := time.Now().UnixNano()
+	ex = &Execution{JobName: job.Name, Group: group, Attempt: 1}
+	require.NoError(t, a.journalDispatch(ex, map[string]string{"test": a.advertiseRPCAddr()}))
+	require.NoError(t, a.markDispatched(ex, "test"))
+
+	a.reconcileDispatches()
+
+	intents, err := a.Store.GetDispatchIntents()
+	require.NoError(t, err)
+	assert.Len(t, intents, 0)
+	executions, err = a.Store.GetExecutionGroup(ex)
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+}
@@ -0,0 +1,307 @@
+package dkron
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// kvPrefix is the key prefix of the KV store.
+	kvPrefix = "kv"
+	// kvTokenHeader is the header carrying the KV token of a namespace.
+	kvTokenHeader = "X-Dkron-KV-Token"
+
+	// maxKVKeySize bounds the length of the keys of the KV store.
+	maxKVKeySize = 512
+	// maxKVValueSize bounds the size of the values of the KV store, it's
+	// meant for cursors and watermarks, not for data.
+	maxKVValueSize = 64 * 1024
+)
+
+var (
+	// ErrInvalidKV is returned when setting an invalid key or value.
+	ErrInvalidKV = errors.New("invalid key or value")
+	// ErrKVTokenRequired is returned when accessing a namespace of the KV
+	// store without its token.
+	ErrKVTokenRequired = errors.New("this namespace requires its KV token")
+)
+
+// KV is a key of the KV store, namespaced like the jobs using it.
+type KV struct {
+	Namespace string    `json:"namespace"`
+	Key       string    `json:"key"`
+	Value     []byte    `json:"-"`
+	Size      int       `json:"size"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewKVFromProto returns a new KV from a proto.
+func NewKVFromProto(in *dkronpb.KV) *KV {
+	updatedAt, _ := ptypes.Timestamp(in.UpdatedAt)
+	return &KV{
+		Namespace: in.Namespace,
+		Key:       in.Key,
+		Value:     in.Value,
+		Size:      len(in.Value),
+		UpdatedAt: updatedAt,
+	}
+}
+
+// ToProto returns the protobuf struct corresponding to the KV.
+func (kv *KV) ToProto() *dkronpb.KV {
+	updatedAt, _ := ptypes.TimestampProto(kv.UpdatedAt)
+	return &dkronpb.KV{
+		Namespace: kv.Namespace,
+		Key:       kv.Key,
+		Value:     kv.Value,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// Validate returns an error if the namespace, the key or the value are invalid.
+func (kv *KV) Validate() error {
+	if kv.Namespace == "" || strings.Contains(kv.Namespace, ":") {
+		return fmt.Errorf("%s: the namespace can't be empty or contain colons", ErrInvalidKV)
+	}
+	if kv.Key == "" || len(kv.Key) > maxKVKeySize {
+		return fmt.Errorf("%s: the key can't be empty or longer than %d bytes", ErrInvalidKV, maxKVKeySize)
+	}
+	if len(kv.Value) > maxKVValueSize {
+		return fmt.Errorf("%s: the value can't be larger than %d bytes", ErrInvalidKV, maxKVValueSize)
+	}
+	return nil
+}
+
+func kvKey(namespace, key string) string {
+	return fmt.Sprintf("%s:%s:%s", kvPrefix, namespace, key)
+}
+
+// SetKV stores the key.
+func (s *Store) SetKV(kv *KV) error {
+	b, err := proto.Marshal(kv.ToProto())
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(kvKey(kv.Namespace, kv.Key), string(b), nil)
+		return err
+	})
+}
+
+// GetKV returns the key of the namespace.
+func (s *Store) GetKV(namespace, key string) (*KV, error) {
+	var kv *KV
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(kvKey(namespace, key))
+		if err != nil {
+			return err
+		}
+		var pbkv dkronpb.KV
+		if err := proto.Unmarshal([]byte(v), &pbkv); err != nil {
+			return err
+		}
+		kv = NewKVFromProto(&pbkv)
+		return nil
+	})
+	return kv, err
+}
+
+// GetKVs returns the keys of the namespace sorted, values included.
+func (s *Store) GetKVs(namespace string) ([]*KV, error) {
+	kvs := []*KV{}
+	prefix := kvKey(namespace, "")
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendGreaterOrEqual("", prefix, func(key, value string) bool {
+			if !strings.HasPrefix(key, prefix) {
+				return false
+			}
+			var pbkv dkronpb.KV
+			if err = proto.Unmarshal([]byte(value), &pbkv); err != nil {
+				return false
+			}
+			kvs = append(kvs, NewKVFromProto(&pbkv))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kvs, nil
+}
+
+// DeleteKV deletes the key of the namespace.
+func (s *Store) DeleteKV(namespace, key string) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(kvKey(namespace, key))
+		return err
+	})
+}
+
+// kvToken returns the token granting access to the namespace of the KV
+// store, derived from the secret shared by the servers.
+func kvToken(secret, namespace string) string {
+	if secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(namespace))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// setKV sets the key in the cluster.
+func (a *Agent) setKV(kv *KV) error {
+	cmd, err := Encode(SetKVType, &dkronpb.SetKVRequest{Kv: kv.ToProto()})
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// deleteKV deletes the key in the cluster.
+func (a *Agent) deleteKV(namespace, key string) error {
+	cmd, err := Encode(DeleteKVType, &dkronpb.DeleteKVRequest{
+		Namespace: namespace,
+		Key:       key,
+	})
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// checkKVToken aborts the request unless it carries the token of the
+// namespace or the admin token. Namespaces are open when no secret is set.
+func (h *HTTPTransport) checkKVToken(c *gin.Context) bool {
+	token := kvToken(h.agent.config.KVTokenSecret, c.Param("namespace"))
+	if token == "" || h.isAdmin(c) {
+		return true
+	}
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader(kvTokenHeader)), []byte(token)) == 1 {
+		return true
+	}
+	c.AbortWithStatus(http.StatusForbidden)
+	c.Writer.WriteString(ErrKVTokenRequired.Error())
+	return false
+}
+
+// kvParams returns the namespace and the key of the request, the key is
+// empty when listing the namespace.
+func kvParams(c *gin.Context) (string, string) {
+	return c.Param("namespace"), strings.TrimPrefix(c.Param("key"), "/")
+}
+
+func (h *HTTPTransport) kvGetHandler(c *gin.Context) {
+	if !h.checkKVToken(c) {
+		return
+	}
+	namespace, key := kvParams(c)
+
+	if key == "" {
+		kvs, err := h.agent.Store.GetKVs(namespace)
+		if err != nil {
+			c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		renderJSON(c, http.StatusOK, kvs)
+		return
+	}
+
+	kv, err := h.agent.Store.GetKV(namespace, key)
+	if err == buntdb.ErrNotFound {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	c.Header("Last-Modified", kv.UpdatedAt.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, "application/octet-stream", kv.Value)
+}
+
+func (h *HTTPTransport) kvPutHandler(c *gin.Context) {
+	if !h.checkKVToken(c) {
+		return
+	}
+	namespace, key := kvParams(c)
+
+	// Read one byte more than allowed to tell too large values apart
+	value, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxKVValueSize+1))
+	if err != nil && len(value) <= maxKVValueSize {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Unable to read value: %s.", err))
+		return
+	}
+
+	kv := &KV{
+		Namespace: namespace,
+		Key:       key,
+		Value:     value,
+		Size:      len(value),
+		UpdatedAt: time.Now(),
+	}
+	if err := kv.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	// Call gRPC SetKV
+	if err := h.agent.GRPCClient.SetKV(kv); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		c.Writer.WriteString(status.Convert(err).Message())
+		return
+	}
+	renderJSON(c, http.StatusOK, kv)
+}
+
+func (h *HTTPTransport) kvDeleteHandler(c *gin.Context) {
+	if !h.checkKVToken(c) {
+		return
+	}
+	namespace, key := kvParams(c)
+
+	// Call gRPC DeleteKV
+	if err := h.agent.GRPCClient.DeleteKV(namespace, key); err != nil {
+		s := status.Convert(err)
+		if s.Message() == buntdb.ErrNotFound.Error() {
+			c.AbortWithStatus(http.StatusNotFound)
+		} else {
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
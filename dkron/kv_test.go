@@ -0,0 +1,94 @@
+package dkron
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestStoreKV(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.SetKV(&KV{Namespace: "billing", Key: "cursor", Value: []byte("42"), UpdatedAt: time.Now()}))
+	require.NoError(t, s.SetKV(&KV{Namespace: "billing", Key: "sync/watermark", Value: []byte("2020-05-15")}))
+	require.NoError(t, s.SetKV(&KV{Namespace: "billing-eu", Key: "cursor", Value: []byte("7")}))
+
+	kv, err := s.GetKV("billing", "cursor")
+	require.NoError(t, err)
+	assert.Equal(t, "42", string(kv.Value))
+	assert.Equal(t, 2, kv.Size)
+
+	// Namespaces sharing a prefix don't see each other's keys
+	kvs, err := s.GetKVs("billing")
+	require.NoError(t, err)
+	require.Len(t, kvs, 2)
+	assert.Equal(t, "cursor", kvs[0].Key)
+	assert.Equal(t, "sync/watermark", kvs[1].Key)
+
+	require.NoError(t, s.DeleteKV("billing", "cursor"))
+	assert.Equal(t, buntdb.ErrNotFound, s.DeleteKV("billing", "cursor"))
+	_, err = s.GetKV("billing", "cursor")
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
+
+func TestKVValidate(t *testing.T) {
+	assert.NoError(t, (&KV{Namespace: "billing", Key: "cursor"}).Validate())
+	assert.Error(t, (&KV{Namespace: "a:b", Key: "cursor"}).Validate())
+	assert.Error(t, (&KV{Namespace: "billing", Key: strings.Repeat("k", maxKVKeySize+1)}).Validate())
+	assert.Error(t, (&KV{Namespace: "billing", Key: "cursor", Value: make([]byte, maxKVValueSize+1)}).Validate())
+}
+
+func TestKVAPI(t *testing.T) {
+	dir, a := setupAPITest(t, "8128")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.KVTokenSecret = "shared-secret"
+
+	baseURL := "http://localhost:8128/v1/kv/billing"
+	do := func(method, path, token, body string) (int, string) {
+		req, err := http.NewRequest(method, baseURL+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set(kvTokenHeader, token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+	token := kvToken("shared-secret", "billing")
+
+	code, _ := do(http.MethodPut, "/cursor", "", "42")
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = do(http.MethodPut, "/cursor", kvToken("shared-secret", "marketing"), "42")
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code, _ = do(http.MethodPut, "/cursor", token, "42")
+	require.Equal(t, http.StatusOK, code)
+	code, body := do(http.MethodGet, "/cursor", token, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "42", body)
+
+	code, body = do(http.MethodGet, "/", token, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, `"key":"cursor"`)
+
+	code, _ = do(http.MethodPut, "/dump", token, strings.Repeat("x", maxKVValueSize+1))
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(http.MethodDelete, "/cursor", token, "")
+	assert.Equal(t, http.StatusNoContent, code)
+	code, _ = do(http.MethodGet, "/cursor", token, "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
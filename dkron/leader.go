@@ -42,6 +42,7 @@ func (a *Agent) monitorLeadership() {
 					a.leaderLoop(ch)
 				}(weAreLeaderCh)
 				log.Info("dkron: cluster leadership acquired")
+				a.notifyClusterEvent(ClusterEventLeaderElected, a.config.NodeName)
 
 			default:
 				if weAreLeaderCh == nil {
@@ -54,6 +55,7 @@ func (a *Agent) monitorLeadership() {
 				leaderLoop.Wait()
 				weAreLeaderCh = nil
 				log.Info("dkron: cluster leadership lost")
+				a.notifyClusterEvent(ClusterEventLeaderLost, a.config.NodeName)
 			}
 
 		case <-a.shutdownCh:
@@ -187,8 +189,46 @@ func (a *Agent) establishLeadership(stopCh chan struct{}) error {
 	if err != nil {
 		log.Fatal(err)
 	}
+	// The runs holding concurrency group locks ended with the previous
+	// leadership, release them before scheduling
+	a.releaseConcurrencyLocks()
+	if err := a.publishScheduleMacros(); err != nil {
+		log.WithError(err).Error("agent: Error publishing schedule macros")
+	}
+	if err := a.publishExecutionRetention(); err != nil {
+		log.WithError(err).Error("agent: Error publishing execution retention")
+	}
 	a.sched.Start(jobs, a)
 
+	// Replay the dispatches a previous leader didn't complete
+	a.reconcileDispatches()
+
+	// Continue the backfills a previous leader didn't finish
+	a.resumeBackfills()
+
+	// Catch up the runs missed without leader, unless paused
+	if pause, err := a.Store.SchedulerPause(); err == nil && pause == nil {
+		a.startRampUp(time.Now().Add(-a.config.RampUpWindow))
+	}
+
+	go a.monitorClockSkew(stopCh)
+
+	// Finalize the executions left running by nodes that are gone
+	go a.reapExecutions(stopCh)
+
+	go a.monitorOverload(stopCh)
+
+	go a.monitorDeadlines(stopCh)
+
+	// Deliver the changes of the store from the last committed offset
+	go a.shipChanges(stopCh)
+
+	if a.config.DigestSchedule != "" {
+		if _, err := a.sched.Cron.AddJob(a.config.DigestSchedule, &digestJob{agent: a}); err != nil {
+			log.WithError(err).Error("agent: Error scheduling the activity digest")
+		}
+	}
+
 	return nil
 }
 
@@ -197,6 +237,13 @@ func (a *Agent) establishLeadership(stopCh chan struct{}) error {
 func (a *Agent) revokeLeadership() error {
 	defer metrics.MeasureSince([]string{"dkron", "leader", "revoke_leadership"}, time.Now())
 	a.sched.Stop()
+	a.overload.reset()
+	a.rampUp.stop()
+	if a.serf.LocalMember().Tags[overloadTag] != "" {
+		if err := a.setTags(map[string]string{overloadTag: ""}); err != nil {
+			log.WithError(err).Error("agent: Error clearing the overload tag")
+		}
+	}
 
 	return nil
 }
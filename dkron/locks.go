@@ -0,0 +1,424 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// lockPrefix is the key prefix of the locks.
+	lockPrefix = "lock"
+	// concurrencyLockPrefix is the name prefix of the locks serializing
+	// the jobs of a concurrency group.
+	concurrencyLockPrefix = "concurrency:"
+
+	// defaultLockTTL is the expiration of the locks acquired through the
+	// API when no TTL is given.
+	defaultLockTTL = time.Minute
+	// maxLockTTL bounds the expiration of the locks acquired through the
+	// API, so a crashed holder can't keep one forever.
+	maxLockTTL = 24 * time.Hour
+)
+
+var (
+	// ErrLockHeld is returned when acquiring or releasing a lock held by
+	// someone else.
+	ErrLockHeld = errors.New("lock is held")
+	// ErrLockNotFound is returned when releasing a lock that isn't held.
+	ErrLockNotFound = errors.New("lock not found")
+	// ErrInvalidLock is returned when a lock request is invalid.
+	ErrInvalidLock = errors.New("invalid lock")
+)
+
+// Lock is a named lock in the store, held by a holder until released or,
+// if it has an expiration, until it expires.
+type Lock struct {
+	Name       string    `json:"name"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at,omitempty"`
+}
+
+// NewLockFromProto returns a new Lock from a proto.
+func NewLockFromProto(in *dkronpb.Lock) *Lock {
+	acquiredAt, _ := ptypes.Timestamp(in.AcquiredAt)
+	var expiresAt time.Time
+	if in.ExpiresAt != nil {
+		expiresAt, _ = ptypes.Timestamp(in.ExpiresAt)
+	}
+	return &Lock{
+		Name:       in.Name,
+		Holder:     in.Holder,
+		AcquiredAt: acquiredAt,
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// ToProto returns the protobuf struct corresponding to the lock.
+func (l *Lock) ToProto() *dkronpb.Lock {
+	acquiredAt, _ := ptypes.TimestampProto(l.AcquiredAt)
+	var expiresAt *timestamp.Timestamp
+	if !l.ExpiresAt.IsZero() {
+		expiresAt, _ = ptypes.TimestampProto(l.ExpiresAt)
+	}
+	return &dkronpb.Lock{
+		Name:       l.Name,
+		Holder:     l.Holder,
+		AcquiredAt: acquiredAt,
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// expired returns whether the lock expired at t.
+func (l *Lock) expired(t time.Time) bool {
+	return !l.ExpiresAt.IsZero() && !t.Before(l.ExpiresAt)
+}
+
+func lockKey(name string) string {
+	return fmt.Sprintf("%s:%s", lockPrefix, name)
+}
+
+// AcquireLock stores the lock unless another holder has it and it didn't
+// expire at now. Acquiring a lock already held by the same holder renews
+// it with the new expiration.
+func (s *Store) AcquireLock(l *Lock, now time.Time) (*Lock, error) {
+	return s.setLock(l, now, false)
+}
+
+// RenewLock updates the expiration of a lock held by the holder, it fails
+// if the lock isn't held or expired at now.
+func (s *Store) RenewLock(l *Lock, now time.Time) (*Lock, error) {
+	return s.setLock(l, now, true)
+}
+
+func (s *Store) setLock(l *Lock, now time.Time, renew bool) (*Lock, error) {
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(lockKey(l.Name))
+		if err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+		held := false
+		if err == nil {
+			var pbl dkronpb.Lock
+			if err := proto.Unmarshal([]byte(v), &pbl); err != nil {
+				return err
+			}
+			el := NewLockFromProto(&pbl)
+			if el.Holder != l.Holder && !el.expired(now) {
+				return fmt.Errorf("%s: by %s", ErrLockHeld, el.Holder)
+			}
+			if el.Holder == l.Holder && !el.expired(now) {
+				l.AcquiredAt = el.AcquiredAt
+				held = true
+			}
+		}
+		if renew && !held {
+			return ErrLockNotFound
+		}
+
+		b, err := proto.Marshal(l.ToProto())
+		if err != nil {
+			return err
+		}
+		_, _, err = tx.Set(lockKey(l.Name), string(b), nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ReleaseLock deletes the lock if held by the holder, any holder if empty.
+func (s *Store) ReleaseLock(name, holder string) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(lockKey(name))
+		if err == buntdb.ErrNotFound {
+			return ErrLockNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var pbl dkronpb.Lock
+		if err := proto.Unmarshal([]byte(v), &pbl); err != nil {
+			return err
+		}
+		if holder != "" && pbl.Holder != holder {
+			return fmt.Errorf("%s: by %s", ErrLockHeld, pbl.Holder)
+		}
+		_, err = tx.Delete(lockKey(name))
+		return err
+	})
+}
+
+// GetLock returns the lock, expired or not.
+func (s *Store) GetLock(name string) (*Lock, error) {
+	var l *Lock
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(lockKey(name))
+		if err != nil {
+			return err
+		}
+		var pbl dkronpb.Lock
+		if err := proto.Unmarshal([]byte(v), &pbl); err != nil {
+			return err
+		}
+		l = NewLockFromProto(&pbl)
+		return nil
+	})
+	return l, err
+}
+
+// GetLocks returns the locks, expired ones included.
+func (s *Store) GetLocks() ([]*Lock, error) {
+	locks := []*Lock{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(lockPrefix+":*", func(key, value string) bool {
+			var pbl dkronpb.Lock
+			if err = proto.Unmarshal([]byte(value), &pbl); err != nil {
+				return false
+			}
+			locks = append(locks, NewLockFromProto(&pbl))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
+// acquireLock acquires the lock in the cluster.
+func (a *Agent) acquireLock(l *Lock) (*Lock, error) {
+	return a.applyLock(l, false)
+}
+
+// renewLock renews the lock held in the cluster.
+func (a *Agent) renewLock(l *Lock) (*Lock, error) {
+	return a.applyLock(l, true)
+}
+
+func (a *Agent) applyLock(l *Lock, renew bool) (*Lock, error) {
+	now, _ := ptypes.TimestampProto(time.Now())
+	cmd, err := Encode(AcquireLockType, &dkronpb.AcquireLockRequest{
+		Lock:  l.ToProto(),
+		Now:   now,
+		Renew: renew,
+	})
+	if err != nil {
+		return nil, err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+	switch res := af.Response().(type) {
+	case error:
+		return nil, res
+	case *Lock:
+		return res, nil
+	}
+	return l, nil
+}
+
+// releaseLock releases the lock held by the holder in the cluster.
+func (a *Agent) releaseLock(name, holder string) error {
+	cmd, err := Encode(ReleaseLockType, &dkronpb.ReleaseLockRequest{
+		Name:   name,
+		Holder: holder,
+	})
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	return nil
+}
+
+// concurrencyLockName returns the name of the lock serializing the jobs
+// sharing the value of the concurrency key of the job, empty if the job
+// isn't in a concurrency group.
+func (j *Job) concurrencyLockName() string {
+	if j.Concurrency != ConcurrencyForbid || j.ConcurrencyKey == "" {
+		return ""
+	}
+	return concurrencyLockPrefix + j.ConcurrencyKey + "=" + j.Metadata[j.ConcurrencyKey]
+}
+
+// releaseConcurrencyLocks releases the concurrency group locks left by a
+// previous leader, their runs ended with its leadership.
+func (a *Agent) releaseConcurrencyLocks() {
+	locks, err := a.Store.GetLocks()
+	if err != nil {
+		log.WithError(err).Error("agent: Error getting locks")
+		return
+	}
+	for _, l := range locks {
+		if !strings.HasPrefix(l.Name, concurrencyLockPrefix) {
+			continue
+		}
+		if err := a.releaseLock(l.Name, l.Holder); err != nil {
+			log.WithError(err).WithField("lock", l.Name).Error("agent: Error releasing concurrency lock")
+		}
+	}
+}
+
+// lockRequest is the payload of the lock API.
+type lockRequest struct {
+	// Holder of the lock, generated when acquiring without one.
+	Holder string `json:"holder"`
+	// TTL of the lock as a duration, like 30s.
+	TTL string `json:"ttl"`
+}
+
+// bindLockRequest parses the lock request of the API, the body can be empty.
+func bindLockRequest(c *gin.Context, holderRequired bool) (*Lock, error) {
+	name := c.Param("name")
+	if strings.HasPrefix(name, concurrencyLockPrefix) {
+		return nil, fmt.Errorf("%s: the %s prefix is reserved for concurrency groups", ErrInvalidLock, concurrencyLockPrefix)
+	}
+
+	var req lockRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %s", ErrInvalidLock, err)
+	}
+	if req.Holder == "" && holderRequired {
+		return nil, fmt.Errorf("%s: holder is required", ErrInvalidLock)
+	}
+
+	ttl := defaultLockTTL
+	if req.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(req.TTL); err != nil {
+			return nil, fmt.Errorf("%s: %s", ErrInvalidLock, err)
+		}
+		if ttl <= 0 || ttl > maxLockTTL {
+			return nil, fmt.Errorf("%s: ttl must be positive and at most %s", ErrInvalidLock, maxLockTTL)
+		}
+	}
+
+	now := time.Now()
+	return &Lock{
+		Name:       name,
+		Holder:     req.Holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}, nil
+}
+
+// abortWithLockError answers with the status matching the lock error.
+func abortWithLockError(c *gin.Context, err error) {
+	msg := status.Convert(err).Message()
+	switch {
+	case strings.HasPrefix(msg, ErrLockHeld.Error()):
+		c.AbortWithStatus(http.StatusConflict)
+	case msg == ErrLockNotFound.Error():
+		c.AbortWithStatus(http.StatusNotFound)
+	default:
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}
+	c.Writer.WriteString(msg)
+}
+
+func (h *HTTPTransport) locksHandler(c *gin.Context) {
+	locks, err := h.agent.Store.GetLocks()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	// Expired locks are free, they're only overwritten when acquired again
+	now := time.Now()
+	held := []*Lock{}
+	for _, l := range locks {
+		if !l.expired(now) {
+			held = append(held, l)
+		}
+	}
+	renderJSON(c, http.StatusOK, held)
+}
+
+func (h *HTTPTransport) lockGetHandler(c *gin.Context) {
+	l, err := h.agent.Store.GetLock(c.Param("name"))
+	if err == buntdb.ErrNotFound || (err == nil && l.expired(time.Now())) {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, l)
+}
+
+func (h *HTTPTransport) lockAcquireHandler(c *gin.Context) {
+	l, err := bindLockRequest(c, false)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+	if l.Holder == "" {
+		l.Holder = newRequestID()
+	}
+
+	// Call gRPC AcquireLock
+	l, err = h.agent.GRPCClient.AcquireLock(l, false)
+	if err != nil {
+		abortWithLockError(c, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, l)
+}
+
+func (h *HTTPTransport) lockRenewHandler(c *gin.Context) {
+	l, err := bindLockRequest(c, true)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	// Call gRPC AcquireLock
+	l, err = h.agent.GRPCClient.AcquireLock(l, true)
+	if err != nil {
+		abortWithLockError(c, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, l)
+}
+
+func (h *HTTPTransport) lockReleaseHandler(c *gin.Context) {
+	l, err := bindLockRequest(c, true)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+
+	// Call gRPC ReleaseLock
+	if err := h.agent.GRPCClient.ReleaseLock(l.Name, l.Holder); err != nil {
+		abortWithLockError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
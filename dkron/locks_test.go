@@ -0,0 +1,141 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreLocks(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	now := time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC)
+	l, err := s.AcquireLock(&Lock{Name: "warehouse", Holder: "load/1", AcquiredAt: now, ExpiresAt: now.Add(time.Minute)}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "load/1", l.Holder)
+
+	// Held by another holder until it expires
+	_, err = s.AcquireLock(&Lock{Name: "warehouse", Holder: "vacuum/2", AcquiredAt: now}, now.Add(30*time.Second))
+	assert.EqualError(t, err, ErrLockHeld.Error()+": by load/1")
+
+	// The holder renews it, keeping the time it was acquired
+	l, err = s.AcquireLock(&Lock{Name: "warehouse", Holder: "load/1", AcquiredAt: now.Add(30 * time.Second), ExpiresAt: now.Add(90 * time.Second)}, now.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, now, l.AcquiredAt)
+
+	_, err = s.AcquireLock(&Lock{Name: "warehouse", Holder: "vacuum/2", AcquiredAt: now}, now.Add(90*time.Second))
+	require.NoError(t, err)
+
+	locks, err := s.GetLocks()
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "vacuum/2", locks[0].Holder)
+	assert.True(t, locks[0].ExpiresAt.IsZero())
+
+	err = s.ReleaseLock("warehouse", "load/1")
+	assert.EqualError(t, err, ErrLockHeld.Error()+": by vacuum/2")
+	assert.NoError(t, s.ReleaseLock("warehouse", "vacuum/2"))
+	assert.Equal(t, ErrLockNotFound, s.ReleaseLock("warehouse", ""))
+
+	// Only held locks can be renewed
+	_, err = s.RenewLock(&Lock{Name: "warehouse", Holder: "load/1", ExpiresAt: now.Add(time.Hour)}, now)
+	assert.Equal(t, ErrLockNotFound, err)
+}
+
+func TestConcurrencyGroupLock(t *testing.T) {
+	dir, a := setupAPITest(t, "8126")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:           "load",
+		Schedule:       "@every 1h",
+		Executor:       "shell",
+		Concurrency:    ConcurrencyForbid,
+		ConcurrencyKey: "lock",
+		Metadata:       map[string]string{"lock": "warehouse"},
+		Agent:          a,
+	}
+	require.NoError(t, a.applySetJob(job.ToProto()))
+
+	_, err := a.acquireLock(&Lock{Name: job.concurrencyLockName(), Holder: "vacuum/1", AcquiredAt: time.Now()})
+	require.NoError(t, err)
+
+	// The run is skipped while another job of the group holds the lock
+	job.run("", time.Time{})
+	_, err = a.Store.GetExecutions("load")
+	assert.Error(t, err)
+
+	explanation, err := a.explainJob(job)
+	require.NoError(t, err)
+	assert.False(t, explanation.Runnable)
+	assert.Contains(t, explanation.Reasons, "concurrency group lock=warehouse is running vacuum/1")
+
+	// A new leader releases the locks of the runs of the previous one
+	a.releaseConcurrencyLocks()
+	locks, err := a.Store.GetLocks()
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+}
+
+func TestLocksAPI(t *testing.T) {
+	dir, a := setupAPITest(t, "8127")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	baseURL := "http://localhost:8127/v1/locks"
+	post := func(path, body string) (int, *Lock) {
+		resp, err := http.Post(baseURL+path, "application/json", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		var l Lock
+		json.Unmarshal(b, &l)
+		return resp.StatusCode, &l
+	}
+
+	code, l := post("/deploy/acquire", `{"holder": "ci-1", "ttl": "1m"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ci-1", l.Holder)
+
+	code, _ = post("/deploy/acquire", `{"holder": "ci-2"}`)
+	assert.Equal(t, http.StatusConflict, code)
+
+	code, l = post("/deploy/renew", `{"holder": "ci-1", "ttl": "2m"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, l.ExpiresAt.After(l.AcquiredAt.Add(time.Minute)))
+
+	code, _ = post("/deploy/release", `{"holder": "ci-2"}`)
+	assert.Equal(t, http.StatusConflict, code)
+	code, _ = post("/deploy/release", `{"holder": "ci-1"}`)
+	assert.Equal(t, http.StatusNoContent, code)
+	code, _ = post("/deploy/renew", `{"holder": "ci-1"}`)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// A holder is generated when acquiring without one
+	code, l = post("/deploy/acquire", ``)
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, l.Holder)
+
+	resp, err := http.Get(baseURL)
+	require.NoError(t, err)
+	b, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	var locks []*Lock
+	require.NoError(t, json.Unmarshal(b, &locks))
+	assert.Len(t, locks, 1)
+
+	code, _ = post("/deploy/acquire", `{"ttl": "48h"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = post("/concurrency:lock=warehouse/acquire", ``)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
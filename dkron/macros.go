@@ -0,0 +1,117 @@
+package dkron
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/tidwall/buntdb"
+)
+
+// scheduleMacrosKey is the key holding the schedule macros of the cluster.
+const scheduleMacrosKey = "meta:schedule_macros"
+
+// scheduleMacros returns the schedule macros of the config, the leading @
+// of the names is optional in the config.
+func scheduleMacros(config map[string]string) map[string]string {
+	macros := make(map[string]string, len(config))
+	for name, spec := range config {
+		if !strings.HasPrefix(name, "@") {
+			name = "@" + name
+		}
+		macros[name] = spec
+	}
+	return macros
+}
+
+// SetScheduleMacros stores the schedule macros of the cluster, expanded
+// from then on when parsing schedules.
+func (s *Store) SetScheduleMacros(macros map[string]string) error {
+	if err := extcron.ValidateMacros(macros); err != nil {
+		return err
+	}
+	b, err := proto.Marshal(&dkronpb.ScheduleMacros{Macros: macros})
+	if err != nil {
+		return err
+	}
+	err = s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(scheduleMacrosKey, string(b), nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return extcron.SetMacros(macros)
+}
+
+// GetScheduleMacros returns the schedule macros of the cluster.
+func (s *Store) GetScheduleMacros() (map[string]string, error) {
+	var pbm dkronpb.ScheduleMacros
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(scheduleMacrosKey)
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return proto.Unmarshal([]byte(v), &pbm)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pbm.Macros == nil {
+		return map[string]string{}, nil
+	}
+	return pbm.Macros, nil
+}
+
+// loadScheduleMacros sets the stored schedule macros in the parser.
+func (s *Store) loadScheduleMacros() error {
+	macros, err := s.GetScheduleMacros()
+	if err != nil {
+		return err
+	}
+	return extcron.SetMacros(macros)
+}
+
+// publishScheduleMacros sets the schedule macros of the leader config in
+// the cluster when they changed, so every server validates and schedules
+// jobs using the same ones.
+func (a *Agent) publishScheduleMacros() error {
+	macros := scheduleMacros(a.config.ScheduleMacros)
+	current, err := a.Store.GetScheduleMacros()
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(current, macros) {
+		return nil
+	}
+
+	cmd, err := Encode(SetScheduleMacrosType, &dkronpb.ScheduleMacros{Macros: macros})
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	log.WithField("macros", len(macros)).Info("agent: Published schedule macros")
+	return nil
+}
+
+func (h *HTTPTransport) scheduleMacrosHandler(c *gin.Context) {
+	macros, err := h.agent.Store.GetScheduleMacros()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, macros)
+}
@@ -0,0 +1,52 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_ScheduleMacros(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+	defer extcron.SetMacros(nil)
+
+	macros, err := s.GetScheduleMacros()
+	require.NoError(t, err)
+	assert.Empty(t, macros)
+
+	job := &Job{
+		Name:           "nightly",
+		Schedule:       "@nightly-batch",
+		Executor:       "shell",
+		ExecutorConfig: map[string]string{"command": "/bin/true"},
+		Timezone:       "Europe/Madrid",
+		Disabled:       true,
+	}
+	assert.Error(t, s.SetJob(job, true))
+
+	assert.Error(t, s.SetScheduleMacros(map[string]string{"@daily": "0 0 1 * * *"}))
+	assert.Error(t, s.SetScheduleMacros(map[string]string{"@nightly-batch": "not a schedule"}))
+
+	require.NoError(t, s.SetScheduleMacros(map[string]string{"@nightly-batch": "0 30 2 * * *"}))
+	require.NoError(t, s.SetJob(job, true))
+	assert.Equal(t, "CRON_TZ=Europe/Madrid 0 30 2 * * *", job.cronSchedule())
+
+	macros, err = s.GetScheduleMacros()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"@nightly-batch": "0 30 2 * * *"}, macros)
+}
+
+func TestScheduleMacros_config(t *testing.T) {
+	macros := scheduleMacros(map[string]string{
+		"nightly-batch": "0 30 2 * * *",
+		"@weekend":      "0 0 9 * * SAT,SUN",
+	})
+	assert.Equal(t, map[string]string{
+		"@nightly-batch": "0 30 2 * * *",
+		"@weekend":       "0 0 9 * * SAT,SUN",
+	}, macros)
+}
@@ -0,0 +1,334 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/serf/serf"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// maintenancePrefix is the key prefix of the maintenance windows.
+	maintenancePrefix = "maintenance"
+
+	// MaintenanceSkip skips the runs of jobs whose target nodes are all
+	// in maintenance.
+	MaintenanceSkip = "skip"
+	// MaintenanceDefer runs the jobs whose target nodes are all in
+	// maintenance once the window ends.
+	MaintenanceDefer = "defer"
+)
+
+var (
+	// ErrMaintenanceWindowSpec is returned when a maintenance window sets
+	// neither a schedule and duration nor a start and end.
+	ErrMaintenanceWindowSpec = errors.New("maintenance window needs a schedule and a duration, or a start and an end")
+	// ErrMaintenancePolicy is returned when a maintenance window policy is unknown.
+	ErrMaintenancePolicy = errors.New("invalid maintenance policy value, use \"skip\" or \"defer\"")
+	// ErrNodesInMaintenance is returned when running a job whose target
+	// nodes are all in maintenance.
+	ErrNodesInMaintenance = errors.New("all the target nodes are in maintenance")
+)
+
+// MaintenanceWindow is a period during which the nodes matching its tags
+// are excluded from running jobs. Windows are recurring, starting on a
+// cron schedule for a duration, or one-off, from a start to an end time.
+type MaintenanceWindow struct {
+	// Name of the window, acts as the id.
+	Name string `json:"name"`
+
+	// Tags of the nodes in maintenance, all the nodes when empty.
+	Tags map[string]string `json:"tags"`
+
+	// Cron expression of the start of a recurring window.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Timezone of the schedule, empty means local time.
+	Timezone string `json:"timezone,omitempty"`
+
+	// Duration of a recurring window, e.g. "2h".
+	Duration string `json:"duration,omitempty"`
+
+	// Start and end of a one-off window.
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+
+	// What to do with jobs whose target nodes are all in the window,
+	// skip (default) or defer them to the end of the window.
+	Policy string `json:"policy"`
+}
+
+// NewMaintenanceWindowFromProto returns a new MaintenanceWindow from a proto.
+func NewMaintenanceWindowFromProto(in *dkronpb.MaintenanceWindow) *MaintenanceWindow {
+	w := &MaintenanceWindow{
+		Name:     in.Name,
+		Tags:     in.Tags,
+		Schedule: in.Schedule,
+		Timezone: in.Timezone,
+		Duration: in.Duration,
+		Policy:   in.Policy,
+	}
+	if in.Start != nil {
+		w.Start, _ = ptypes.Timestamp(in.Start)
+	}
+	if in.End != nil {
+		w.End, _ = ptypes.Timestamp(in.End)
+	}
+	return w
+}
+
+// ToProto returns the protobuf struct corresponding to the window.
+func (w *MaintenanceWindow) ToProto() *dkronpb.MaintenanceWindow {
+	pbw := &dkronpb.MaintenanceWindow{
+		Name:     w.Name,
+		Tags:     w.Tags,
+		Schedule: w.Schedule,
+		Timezone: w.Timezone,
+		Duration: w.Duration,
+		Policy:   w.Policy,
+	}
+	if !w.Start.IsZero() {
+		pbw.Start, _ = ptypes.TimestampProto(w.Start)
+	}
+	if !w.End.IsZero() {
+		pbw.End, _ = ptypes.TimestampProto(w.End)
+	}
+	return pbw
+}
+
+// Validate checks the window definition.
+func (w *MaintenanceWindow) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if valid, chr := isSlug(w.Name); !valid {
+		return fmt.Errorf("name contains illegal character '%s'", chr)
+	}
+
+	switch {
+	case w.Schedule != "" && w.Duration != "":
+		if _, err := extcron.Parse(w.cronSchedule()); err != nil {
+			return fmt.Errorf("%s: %s", ErrScheduleParse, err)
+		}
+		if d, err := time.ParseDuration(w.Duration); err != nil || d <= 0 {
+			return fmt.Errorf("%s: invalid duration %q", ErrMaintenanceWindowSpec, w.Duration)
+		}
+	case !w.Start.IsZero() && !w.End.IsZero():
+		if !w.End.After(w.Start) {
+			return fmt.Errorf("%s: the end must be after the start", ErrMaintenanceWindowSpec)
+		}
+	default:
+		return ErrMaintenanceWindowSpec
+	}
+
+	if w.Policy != "" && w.Policy != MaintenanceSkip && w.Policy != MaintenanceDefer {
+		return ErrMaintenancePolicy
+	}
+
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// cronSchedule returns the window schedule including its timezone.
+func (w *MaintenanceWindow) cronSchedule() string {
+	j := &Job{Schedule: w.Schedule, Timezone: w.Timezone}
+	return j.cronSchedule()
+}
+
+// activeUntil returns the end of the window if it's active at t, or the
+// zero time otherwise.
+func (w *MaintenanceWindow) activeUntil(t time.Time) time.Time {
+	if w.Schedule == "" {
+		if !t.Before(w.Start) && t.Before(w.End) {
+			return w.End
+		}
+		return time.Time{}
+	}
+
+	s, err := extcron.Parse(w.cronSchedule())
+	if err != nil {
+		return time.Time{}
+	}
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil {
+		return time.Time{}
+	}
+	// The window is active if it started in the last duration
+	if start := s.Next(t.Add(-d)); !start.IsZero() && !start.After(t) {
+		return start.Add(d)
+	}
+	return time.Time{}
+}
+
+// matches returns whether the member is covered by the window.
+func (w *MaintenanceWindow) matches(m serf.Member) bool {
+	for k, v := range w.Tags {
+		if m.Tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeMaintenance returns the windows active at t covering the member.
+func nodeMaintenance(windows []*MaintenanceWindow, m serf.Member, t time.Time) []*MaintenanceWindow {
+	active := []*MaintenanceWindow{}
+	for _, w := range windows {
+		if w.matches(m) && !w.activeUntil(t).IsZero() {
+			active = append(active, w)
+		}
+	}
+	return active
+}
+
+// availableMembers returns the members not in maintenance at t, and the
+// windows excluding the rest.
+func availableMembers(members []serf.Member, windows []*MaintenanceWindow, t time.Time) ([]serf.Member, map[string][]*MaintenanceWindow) {
+	available := []serf.Member{}
+	excluded := map[string][]*MaintenanceWindow{}
+	for _, m := range members {
+		if active := nodeMaintenance(windows, m, t); len(active) > 0 {
+			excluded[m.Name] = active
+			continue
+		}
+		available = append(available, m)
+	}
+	return available, excluded
+}
+
+// maintenanceDeferral returns when to run a job whose target nodes are
+// all in the given windows, or the zero time to skip it. Jobs are only
+// deferred when every window defers them, until the last one ends.
+func maintenanceDeferral(excluded map[string][]*MaintenanceWindow, nodes []string, t time.Time) time.Time {
+	var until time.Time
+	for _, n := range nodes {
+		for _, w := range excluded[n] {
+			if w.Policy != MaintenanceDefer {
+				return time.Time{}
+			}
+			if end := w.activeUntil(t); end.After(until) {
+				until = end
+			}
+		}
+	}
+	return until
+}
+
+// maintenanceMembers returns the members not in maintenance at t, and the
+// windows excluding the rest.
+func (a *Agent) maintenanceMembers(t time.Time) ([]serf.Member, map[string][]*MaintenanceWindow) {
+	windows, err := a.Store.GetMaintenanceWindows()
+	if err != nil {
+		log.WithError(err).Error("agent: Error getting maintenance windows")
+		return a.serf.Members(), nil
+	}
+	return availableMembers(a.serf.Members(), windows, t)
+}
+
+// checkMaintenance returns an error if the nodes targeted by the job are
+// all in maintenance, scheduling a deferred run if the windows defer it.
+func (a *Agent) checkMaintenance(job *Job, ex *Execution) error {
+	now := time.Now()
+	_, excluded := a.maintenanceMembers(now)
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	_, nodes := explainNodes(a.serf.Members(), job.Tags, a.config.Region)
+	inMaintenance := []string{}
+	for _, n := range nodes {
+		if _, ok := excluded[n.Name]; ok && n.Matches {
+			inMaintenance = append(inMaintenance, n.Name)
+		}
+	}
+	if len(inMaintenance) == 0 {
+		return nil
+	}
+
+	until := maintenanceDeferral(excluded, inMaintenance, now)
+	if until.IsZero() {
+		return fmt.Errorf("%s: skipped run of job %s", ErrNodesInMaintenance, job.Name)
+	}
+
+	name := job.Name
+	time.AfterFunc(until.Sub(now), func() {
+		// Leadership could have changed while waiting
+		if !a.IsLeader() {
+			return
+		}
+		job, err := a.Store.GetJob(name, nil)
+		if err != nil {
+			log.WithError(err).WithField("job", name).Error("agent: Error getting deferred job")
+			return
+		}
+		job.Agent = a
+		job.run(ex.RequestID, ex.ScheduledAt)
+	})
+
+	return fmt.Errorf("%s: run of job %s deferred until %s", ErrNodesInMaintenance, job.Name, until.Format(time.RFC3339))
+}
+
+// SetMaintenanceWindow stores a maintenance window.
+func (s *Store) SetMaintenanceWindow(w *MaintenanceWindow) error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(w.ToProto())
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(fmt.Sprintf("%s:%s", maintenancePrefix, w.Name), string(b), nil)
+		return err
+	})
+}
+
+// DeleteMaintenanceWindow deletes a maintenance window.
+func (s *Store) DeleteMaintenanceWindow(name string) (*MaintenanceWindow, error) {
+	var w *MaintenanceWindow
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		value, err := tx.Delete(fmt.Sprintf("%s:%s", maintenancePrefix, name))
+		if err != nil {
+			return err
+		}
+		var pbw dkronpb.MaintenanceWindow
+		if err := proto.Unmarshal([]byte(value), &pbw); err != nil {
+			return err
+		}
+		w = NewMaintenanceWindowFromProto(&pbw)
+		return nil
+	})
+	return w, err
+}
+
+// GetMaintenanceWindows returns the maintenance windows sorted by name.
+func (s *Store) GetMaintenanceWindows() ([]*MaintenanceWindow, error) {
+	windows := []*MaintenanceWindow{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(maintenancePrefix+":*", func(key, value string) bool {
+			var pbw dkronpb.MaintenanceWindow
+			if err = proto.Unmarshal([]byte(value), &pbw); err != nil {
+				return false
+			}
+			windows = append(windows, NewMaintenanceWindowFromProto(&pbw))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
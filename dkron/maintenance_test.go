@@ -0,0 +1,98 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, (&MaintenanceWindow{Name: "db", Schedule: "0 0 2 * * 0", Duration: "2h"}).Validate())
+	assert.NoError(t, (&MaintenanceWindow{Name: "db", Start: now, End: now.Add(time.Hour), Policy: MaintenanceDefer}).Validate())
+
+	assert.Equal(t, ErrMaintenanceWindowSpec, (&MaintenanceWindow{Name: "db", Schedule: "0 0 2 * * 0"}).Validate())
+	assert.Error(t, (&MaintenanceWindow{Name: "db", Schedule: "0 0 2 * * 0", Duration: "-1h"}).Validate())
+	assert.Error(t, (&MaintenanceWindow{Name: "db", Start: now, End: now}).Validate())
+	assert.Equal(t, ErrMaintenancePolicy, (&MaintenanceWindow{Name: "db", Start: now, End: now.Add(time.Hour), Policy: "wait"}).Validate())
+	assert.Error(t, (&MaintenanceWindow{Name: "db window", Start: now, End: now.Add(time.Hour)}).Validate())
+}
+
+func TestMaintenanceWindow_activeUntil(t *testing.T) {
+	// Sundays from 02:00 to 04:00 in Madrid
+	w := &MaintenanceWindow{Name: "db", Schedule: "0 0 2 * * 0", Timezone: "Europe/Madrid", Duration: "2h"}
+	madrid, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+
+	sunday := time.Date(2020, 5, 17, 0, 0, 0, 0, madrid)
+	end := sunday.Add(4 * time.Hour)
+	assert.True(t, w.activeUntil(sunday.Add(time.Hour)).IsZero())
+	assert.Equal(t, end, w.activeUntil(sunday.Add(2*time.Hour)))
+	assert.Equal(t, end, w.activeUntil(sunday.Add(3*time.Hour+59*time.Minute)))
+	assert.True(t, w.activeUntil(end).IsZero())
+	assert.True(t, w.activeUntil(sunday.Add(26*time.Hour)).IsZero())
+
+	start := time.Date(2020, 5, 15, 8, 0, 0, 0, time.UTC)
+	w = &MaintenanceWindow{Name: "upgrade", Start: start, End: start.Add(time.Hour)}
+	assert.True(t, w.activeUntil(start.Add(-time.Second)).IsZero())
+	assert.Equal(t, start.Add(time.Hour), w.activeUntil(start))
+	assert.True(t, w.activeUntil(start.Add(time.Hour)).IsZero())
+}
+
+func TestAvailableMembers(t *testing.T) {
+	now := time.Now()
+	members := []serf.Member{
+		{Name: "db1", Tags: map[string]string{"role": "db", "zone": "a"}},
+		{Name: "db2", Tags: map[string]string{"role": "db", "zone": "b"}},
+		{Name: "web1", Tags: map[string]string{"role": "web", "zone": "a"}},
+	}
+	windows := []*MaintenanceWindow{
+		{Name: "db", Tags: map[string]string{"role": "db"}, Start: now.Add(-time.Hour), End: now.Add(time.Hour), Policy: MaintenanceDefer},
+		{Name: "zone-a", Tags: map[string]string{"zone": "a"}, Start: now.Add(-time.Hour), End: now.Add(2 * time.Hour), Policy: MaintenanceSkip},
+		{Name: "later", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+	}
+
+	available, excluded := availableMembers(members, windows, now)
+	assert.Empty(t, available)
+	assert.Len(t, excluded["db1"], 2)
+	assert.Len(t, excluded["db2"], 1)
+	assert.Len(t, excluded["web1"], 1)
+
+	// db2 is only in a deferring window, the others in a skipping one
+	assert.Equal(t, now.Add(time.Hour), maintenanceDeferral(excluded, []string{"db2"}, now))
+	assert.True(t, maintenanceDeferral(excluded, []string{"db1", "db2"}, now).IsZero())
+
+	available, _ = availableMembers(members, windows[2:], now)
+	assert.Len(t, available, 3)
+}
+
+func TestStore_MaintenanceWindows(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	start := time.Date(2020, 5, 15, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, s.SetMaintenanceWindow(&MaintenanceWindow{Name: "upgrade", Start: start, End: start.Add(time.Hour)}))
+	require.NoError(t, s.SetMaintenanceWindow(&MaintenanceWindow{Name: "db", Tags: map[string]string{"role": "db"}, Schedule: "0 0 2 * * 0", Duration: "2h"}))
+	assert.Error(t, s.SetMaintenanceWindow(&MaintenanceWindow{Name: "invalid"}))
+
+	windows, err := s.GetMaintenanceWindows()
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+	assert.Equal(t, "db", windows[0].Name)
+	assert.Equal(t, "db", windows[0].Tags["role"])
+	assert.True(t, windows[0].Start.IsZero())
+	assert.Equal(t, "upgrade", windows[1].Name)
+	assert.Equal(t, start, windows[1].Start.UTC())
+
+	w, err := s.DeleteMaintenanceWindow("upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade", w.Name)
+
+	_, err = s.DeleteMaintenanceWindow("upgrade")
+	assert.Error(t, err)
+}
@@ -0,0 +1,609 @@
+package dkron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// matrixMaxItems is the maximum number of items of a run of a matrix.
+	matrixMaxItems = 10000
+
+	// MatrixItemRetrying is the status of a failed matrix item with
+	// attempts left.
+	MatrixItemRetrying = "retrying"
+)
+
+var (
+	// ErrInvalidMatrix is returned when the matrix of a job is not valid.
+	ErrInvalidMatrix = errors.New("invalid matrix")
+	// ErrMatrixEmpty is returned when running a matrix without items.
+	ErrMatrixEmpty = errors.New("matrix has no items")
+	// ErrMatrixTooLarge is returned when a run of a matrix has more than
+	// the maximum items.
+	ErrMatrixTooLarge = errors.New("matrix has too many items")
+	// ErrMatrixRunNotFound is returned when the job has no matrix run.
+	ErrMatrixRunNotFound = errors.New("matrix run not found")
+	// ErrInvalidDiscovery is returned when the output of the discovery
+	// step of a matrix is not a JSON list.
+	ErrInvalidDiscovery = errors.New("discovery output is not a JSON list")
+)
+
+// Matrix expands every run of a job into one execution per combination of
+// its params, in the same execution group.
+type Matrix struct {
+	// Values of every param, the items are all their combinations.
+	Params map[string][]string `json:"params,omitempty"`
+
+	// Param set to every line of the output of the parent job's run,
+	// combined with the other params.
+	FromParent string `json:"from_parent,omitempty"`
+
+	// Items dispatched at the same time, all when zero.
+	Parallelism int `json:"parallelism,omitempty"`
+
+	// Step run first on one of the target nodes of the job, its output is
+	// a JSON list of items combined with the other params.
+	Discovery *Step `json:"discovery,omitempty"`
+
+	// Run every item on one of the target nodes, the one running the
+	// fewest items of the run, instead of on all of them.
+	Spread bool `json:"spread,omitempty"`
+}
+
+func matrixFromProto(in *proto.Matrix) *Matrix {
+	if in == nil {
+		return nil
+	}
+	m := &Matrix{
+		FromParent:  in.FromParent,
+		Parallelism: int(in.Parallelism),
+		Spread:      in.Spread,
+	}
+	if in.Discovery != nil {
+		m.Discovery = &Step{
+			Name:           in.Discovery.Name,
+			Executor:       in.Discovery.Executor,
+			ExecutorConfig: in.Discovery.ExecutorConfig,
+		}
+	}
+	if len(in.Params) > 0 {
+		m.Params = make(map[string][]string, len(in.Params))
+		for k, v := range in.Params {
+			m.Params[k] = v.GetValues()
+		}
+	}
+	return m
+}
+
+func (m *Matrix) toProto() *proto.Matrix {
+	if m == nil {
+		return nil
+	}
+	out := &proto.Matrix{
+		FromParent:  m.FromParent,
+		Parallelism: int32(m.Parallelism),
+		Spread:      m.Spread,
+	}
+	if m.Discovery != nil {
+		out.Discovery = &proto.JobStep{
+			Name:           m.Discovery.Name,
+			Executor:       m.Discovery.Executor,
+			ExecutorConfig: m.Discovery.ExecutorConfig,
+		}
+	}
+	if len(m.Params) > 0 {
+		out.Params = make(map[string]*proto.MatrixValues, len(m.Params))
+		for k, v := range m.Params {
+			out.Params[k] = &proto.MatrixValues{Values: v}
+		}
+	}
+	return out
+}
+
+func (m *Matrix) validate(j *Job) error {
+	if len(m.Params) == 0 && m.FromParent == "" && m.Discovery == nil {
+		return fmt.Errorf("%s: params, from_parent or discovery is required", ErrInvalidMatrix)
+	}
+	if m.Discovery != nil {
+		if m.Discovery.Executor == "" {
+			return fmt.Errorf("%s: discovery has no executor", ErrInvalidMatrix)
+		}
+		if m.FromParent != "" {
+			return fmt.Errorf("%s: from_parent and discovery can't be combined", ErrInvalidMatrix)
+		}
+	}
+	for k, v := range m.Params {
+		if k == "" {
+			return fmt.Errorf("%s: empty param name", ErrInvalidMatrix)
+		}
+		if len(v) == 0 {
+			return fmt.Errorf("%s: param %s has no values", ErrInvalidMatrix, k)
+		}
+	}
+	if _, ok := m.Params[m.FromParent]; ok {
+		return fmt.Errorf("%s: param %s is also from_parent", ErrInvalidMatrix, m.FromParent)
+	}
+	if m.FromParent != "" && j.ParentJob == "" {
+		return fmt.Errorf("%s: from_parent needs a parent job", ErrInvalidMatrix)
+	}
+	if m.Parallelism < 0 {
+		return fmt.Errorf("%s: parallelism can't be negative", ErrInvalidMatrix)
+	}
+	if _, err := m.items("", nil); err == ErrMatrixTooLarge {
+		return fmt.Errorf("%s: more than %d items", ErrInvalidMatrix, matrixMaxItems)
+	}
+	return nil
+}
+
+// items returns the params of every item, the combinations of the values
+// of the params, the lines of the output of the parent job and the items
+// discovered. Repeated items run once.
+func (m *Matrix) items(parentOutput string, discovered []map[string]string) ([]map[string]string, error) {
+	names := make([]string, 0, len(m.Params)+1)
+	values := map[string][]string{}
+	for k, v := range m.Params {
+		names = append(names, k)
+		values[k] = v
+	}
+	if m.FromParent != "" {
+		names = append(names, m.FromParent)
+		values[m.FromParent] = outputLines(parentOutput)
+	}
+	sort.Strings(names)
+
+	total := 1
+	if m.Discovery != nil {
+		total = len(discovered)
+	}
+	for _, name := range names {
+		total *= len(values[name])
+		if total > matrixMaxItems {
+			return nil, ErrMatrixTooLarge
+		}
+	}
+	if total == 0 {
+		return nil, ErrMatrixEmpty
+	}
+
+	items := []map[string]string{{}}
+	for _, name := range names {
+		var next []map[string]string
+		for _, item := range items {
+			for _, v := range values[name] {
+				params := make(map[string]string, len(item)+1)
+				for k, pv := range item {
+					params[k] = pv
+				}
+				params[name] = v
+				next = append(next, params)
+			}
+		}
+		items = next
+	}
+	if m.Discovery != nil {
+		var next []map[string]string
+		for _, item := range items {
+			for _, d := range discovered {
+				params := make(map[string]string, len(item)+len(d))
+				for k, v := range item {
+					params[k] = v
+				}
+				for k, v := range d {
+					params[k] = v
+				}
+				next = append(next, params)
+			}
+		}
+		items = next
+	}
+
+	seen := map[string]bool{}
+	unique := items[:0]
+	for _, item := range items {
+		if key := matrixItem(item); !seen[key] {
+			seen[key] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique, nil
+}
+
+// outputLines returns the non empty lines of the output.
+func outputLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseDiscovery returns the params of the items listed by the output of
+// a discovery step, a JSON list. The fields of the objects are params of
+// their item, other elements are the item param.
+func parseDiscovery(output string) ([]map[string]string, error) {
+	var list []json.RawMessage
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrInvalidDiscovery, err)
+	}
+
+	param := func(raw json.RawMessage) string {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+		return string(raw)
+	}
+	items := make([]map[string]string, 0, len(list))
+	for _, raw := range list {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			items = append(items, map[string]string{"item": param(raw)})
+			continue
+		}
+		item := make(map[string]string, len(fields))
+		for k, v := range fields {
+			item[k] = param(v)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// matrixItem returns the name of the item with the params, like
+// customer=42&region=eu.
+func matrixItem(params map[string]string) string {
+	v := url.Values{}
+	for k, p := range params {
+		v.Set(k, p)
+	}
+	return v.Encode()
+}
+
+// runMatrix runs an execution for every item of the matrix of the job,
+// waiting for them to finish.
+func (a *Agent) runMatrix(job *Job, ex *Execution) error {
+	items, err := job.Matrix.items(ex.parentOutput, nil)
+	if err != nil {
+		return fmt.Errorf("agent: Run error expanding matrix of job %s: %w", job.Name, err)
+	}
+	return a.runMatrixItems(job, ex, items)
+}
+
+// runMatrixItems runs an execution of the group for every item, waiting
+// for them to finish. Items not dispatched yet are dropped when the
+// scheduler is paused.
+func (a *Agent) runMatrixItems(job *Job, ex *Execution, items []map[string]string) error {
+	var spread *matrixSpread
+	if job.Matrix.Spread {
+		nodes, _, err := a.processFilteredNodes(job)
+		if err != nil {
+			return fmt.Errorf("run error processing filtered nodes: %w", err)
+		}
+		if len(nodes) == 0 {
+			return fmt.Errorf("no target nodes found to run job %s", job.Name)
+		}
+		spread = newMatrixSpread(nodes)
+	}
+
+	parallelism := job.Matrix.Parallelism
+	if parallelism <= 0 || parallelism > len(items) {
+		parallelism = len(items)
+	}
+	log.WithFields(logrus.Fields{
+		"job":         job.Name,
+		"group":       ex.Group,
+		"items":       len(items),
+		"parallelism": parallelism,
+		"spread":      spread != nil,
+	}).Info("agent: Running matrix")
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for _, params := range items {
+		sem <- struct{}{}
+		if err := a.checkSchedulerPaused(); err != nil {
+			<-sem
+			log.WithError(err).WithField("job", job.Name).Warn("agent: Stopping matrix dispatch")
+			break
+		}
+
+		item := *ex
+		item.Params = make(map[string]string, len(ex.Params)+len(params))
+		for k, v := range ex.Params {
+			item.Params[k] = v
+		}
+		for k, v := range params {
+			item.Params[k] = v
+		}
+		item.MatrixItem = matrixItem(params)
+		item.MatrixSize = len(items)
+
+		wg.Add(1)
+		go func(item *Execution) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			var err error
+			if spread != nil {
+				node, addr := spread.acquire()
+				err = a.dispatchExecution(job, item, map[string]string{node: addr})
+				spread.release(node)
+			} else {
+				err = a.runExecution(job, item)
+			}
+			if err != nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"job":  job.Name,
+					"item": item.MatrixItem,
+				}).Error("agent: Error running matrix item")
+			}
+		}(&item)
+	}
+	wg.Wait()
+	return nil
+}
+
+// matrixSpread assigns the items of a matrix run to the node running the
+// fewest of them.
+type matrixSpread struct {
+	sync.Mutex
+	nodes   []string
+	addrs   map[string]string
+	running map[string]int
+}
+
+func newMatrixSpread(nodes map[string]string) *matrixSpread {
+	ms := &matrixSpread{addrs: nodes, running: map[string]int{}}
+	for name := range nodes {
+		ms.nodes = append(ms.nodes, name)
+	}
+	sort.Strings(ms.nodes)
+	return ms
+}
+
+func (ms *matrixSpread) acquire() (string, string) {
+	ms.Lock()
+	defer ms.Unlock()
+	node := ms.nodes[0]
+	for _, n := range ms.nodes[1:] {
+		if ms.running[n] < ms.running[node] {
+			node = n
+		}
+	}
+	ms.running[node]++
+	return node, ms.addrs[node]
+}
+
+func (ms *matrixSpread) release(node string) {
+	ms.Lock()
+	defer ms.Unlock()
+	ms.running[node]--
+}
+
+// discoveryJob returns the job running the discovery step of its matrix
+// instead of its executor.
+func (j *Job) discoveryJob() *Job {
+	dj := *j
+	dj.Executor = j.Matrix.Discovery.Executor
+	dj.ExecutorConfig = j.Matrix.Discovery.ExecutorConfig
+	dj.Steps = nil
+	dj.Canary = nil
+	return &dj
+}
+
+// runDiscovery runs the discovery step of the matrix of the job on one of
+// its target nodes, the items run once it's done.
+func (a *Agent) runDiscovery(job *Job, ex *Execution) error {
+	if job.Matrix == nil || job.Matrix.Discovery == nil {
+		return fmt.Errorf("agent: Run error, job %s has no discovery step", job.Name)
+	}
+	log.WithFields(logrus.Fields{
+		"job":     job.Name,
+		"group":   ex.Group,
+		"attempt": ex.Attempt,
+	}).Info("agent: Running matrix discovery")
+	return a.runExecution(job.discoveryJob(), ex)
+}
+
+// discoveredItems returns the items of the matrix run listed by the output
+// of its discovery step.
+func discoveredItems(job *Job, output string) ([]map[string]string, error) {
+	if job.Matrix == nil || job.Matrix.Discovery == nil {
+		return nil, fmt.Errorf("job %s has no discovery step", job.Name)
+	}
+	discovered, err := parseDiscovery(output)
+	if err != nil || len(discovered) == 0 {
+		return nil, err
+	}
+	return job.Matrix.items("", discovered)
+}
+
+// runDiscoveredItems runs the items of the matrix run of the successful
+// discovery execution.
+func (a *Agent) runDiscoveredItems(job *Job, discovery *Execution, items []map[string]string) {
+	ex := &Execution{
+		JobName:     job.Name,
+		Group:       discovery.Group,
+		Attempt:     1,
+		RequestID:   discovery.RequestID,
+		ScheduledAt: discovery.ScheduledAt,
+		Params:      discovery.Params,
+		JobVersion:  job.Version,
+	}
+	if err := a.runMatrixItems(job, ex, items); err != nil {
+		log.WithError(err).WithField("job", job.Name).Error("agent: Error running discovered matrix items")
+	}
+}
+
+// MatrixItemStatus is the status of an item of a matrix run, given by its
+// last attempt.
+type MatrixItemStatus struct {
+	Item        string            `json:"item"`
+	Params      map[string]string `json:"params"`
+	Status      string            `json:"status"`
+	Attempts    int               `json:"attempts"`
+	NodeName    string            `json:"node_name"`
+	ExecutionID string            `json:"execution_id"`
+	FinishedAt  time.Time         `json:"finished_at"`
+}
+
+// MatrixRun is the progress of the items of a run of a job with a matrix.
+type MatrixRun struct {
+	JobName string `json:"job_name"`
+	Group   int64  `json:"group"`
+
+	// Status of the run as a whole, running until all its items are done.
+	Status string `json:"status"`
+
+	// Items of the run, succeeded, failed and skipped count the finished
+	// ones, pending the ones not finished or retrying.
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Pending   int `json:"pending"`
+
+	// Discovery step of the run, if the matrix has one.
+	Discovery *MatrixItemStatus `json:"discovery,omitempty"`
+
+	Items []*MatrixItemStatus `json:"items"`
+}
+
+// newMatrixRun returns the progress of the matrix run of the executions of
+// a group.
+func newMatrixRun(job *Job, executions []*Execution) *MatrixRun {
+	mr := &MatrixRun{JobName: job.Name, Items: []*MatrixItemStatus{}}
+	last := map[string]*Execution{}
+	var discovery *Execution
+	for _, ex := range executions {
+		if ex.MatrixItem == "" && !ex.Discovery {
+			continue
+		}
+		mr.Group = ex.Group
+		if ex.MatrixSize > mr.Total {
+			mr.Total = ex.MatrixSize
+		}
+		if ex.Discovery {
+			if discovery == nil || ex.Attempt > discovery.Attempt {
+				discovery = ex
+			}
+		} else if l, ok := last[ex.MatrixItem]; !ok || ex.Attempt > l.Attempt {
+			last[ex.MatrixItem] = ex
+		}
+	}
+
+	itemStatus := func(ex *Execution) *MatrixItemStatus {
+		is := &MatrixItemStatus{
+			Item:        ex.MatrixItem,
+			Params:      ex.Params,
+			Attempts:    int(ex.Attempt),
+			NodeName:    ex.NodeName,
+			ExecutionID: ex.Id,
+			FinishedAt:  ex.FinishedAt,
+		}
+		switch {
+		case ex.Skipped:
+			is.Status = StatusSkipped
+		case ex.Success:
+			is.Status = StatusSuccess
+		case uint(ex.Attempt) < job.Retries+1:
+			is.Status = MatrixItemRetrying
+		default:
+			is.Status = StatusFailed
+		}
+		return is
+	}
+	for _, ex := range last {
+		is := itemStatus(ex)
+		switch is.Status {
+		case StatusSuccess:
+			mr.Succeeded++
+		case StatusFailed:
+			mr.Failed++
+		case StatusSkipped:
+			mr.Skipped++
+		}
+		mr.Items = append(mr.Items, is)
+	}
+	sort.Slice(mr.Items, func(i, j int) bool { return mr.Items[i].Item < mr.Items[j].Item })
+	mr.Pending = mr.Total - mr.Succeeded - mr.Failed - mr.Skipped
+
+	if discovery != nil {
+		mr.Discovery = itemStatus(discovery)
+	}
+	switch {
+	case mr.Discovery != nil && mr.Discovery.Status == StatusFailed:
+		mr.Status = StatusFailed
+	case mr.Discovery != nil && mr.Discovery.Status == StatusSkipped:
+		mr.Status = StatusSkipped
+	case mr.Pending > 0 || (mr.Discovery != nil && mr.Discovery.Status == MatrixItemRetrying):
+		mr.Status = StatusRunning
+	case mr.Total > 0 && mr.Skipped == mr.Total:
+		mr.Status = StatusSkipped
+	case mr.Failed == 0:
+		mr.Status = StatusSuccess
+	case mr.Succeeded == 0:
+		mr.Status = StatusFailed
+	default:
+		mr.Status = StatusPartialyFailed
+	}
+	return mr
+}
+
+// matrixPending returns whether the execution is an item of a matrix run
+// with items left, the dependent jobs run once all of them are done.
+func matrixPending(job *Job, execution *Execution, group []*Execution) bool {
+	if execution.MatrixItem == "" {
+		return false
+	}
+	return newMatrixRun(job, group).Pending > 0
+}
+
+// jobMatrixHandler returns the progress of a matrix run of the job, the
+// last one unless the group is given.
+func (h *HTTPTransport) jobMatrixHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithError(http.StatusNotFound, err)
+		return
+	}
+
+	groups, byGroup, err := h.agent.Store.GetGroupedExecutions(job.Name)
+	if err != nil && err != buntdb.ErrNotFound {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	if g := c.Query("group"); g != "" {
+		group, err := strconv.ParseInt(g, 10, 64)
+		if err != nil {
+			c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+		byGroup = []int64{group}
+	}
+	for _, group := range byGroup {
+		if mr := newMatrixRun(job, groups[group]); mr.Total > 0 || mr.Discovery != nil {
+			renderJSON(c, http.StatusOK, mr)
+			return
+		}
+	}
+	c.AbortWithError(http.StatusNotFound, ErrMatrixRunNotFound)
+}
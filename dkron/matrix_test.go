@@ -0,0 +1,222 @@
+package dkron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixItems(t *testing.T) {
+	m := &Matrix{
+		Params: map[string][]string{
+			"region": {"eu", "us"},
+			"tier":   {"gold", "gold", "silver"},
+		},
+		FromParent: "customer",
+	}
+	items, err := m.items("42\n\n 43 \n42\n", nil)
+	require.NoError(t, err)
+	require.Len(t, items, 8)
+	assert.Equal(t, map[string]string{"customer": "42", "region": "eu", "tier": "gold"}, items[0])
+	assert.Equal(t, "customer=42&region=eu&tier=gold", matrixItem(items[0]))
+	assert.Equal(t, "customer=43&region=us&tier=silver", matrixItem(items[7]))
+
+	_, err = m.items("", nil)
+	assert.Equal(t, ErrMatrixEmpty, err)
+
+	values := make([]string, 101)
+	for i := range values {
+		values[i] = fmt.Sprint(i)
+	}
+	m = &Matrix{Params: map[string][]string{"a": values, "b": values}}
+	_, err = m.items("", nil)
+	assert.Equal(t, ErrMatrixTooLarge, err)
+}
+
+func TestMatrixValidate(t *testing.T) {
+	job := &Job{Name: "child", ParentJob: "parent"}
+	assert.NoError(t, (&Matrix{FromParent: "customer"}).validate(job))
+	assert.NoError(t, (&Matrix{Params: map[string][]string{"region": {"eu"}}}).validate(&Job{Name: "job"}))
+	assert.NoError(t, (&Matrix{Discovery: &Step{Executor: "shell"}, Spread: true}).validate(&Job{Name: "job"}))
+
+	for _, m := range []*Matrix{
+		{},
+		{Params: map[string][]string{"region": {}}},
+		{Params: map[string][]string{"customer": {"42"}}, FromParent: "customer"},
+		{Params: map[string][]string{"region": {"eu"}}, Parallelism: -1},
+		{Discovery: &Step{}},
+		{FromParent: "customer", Discovery: &Step{Executor: "shell"}},
+	} {
+		err := m.validate(job)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), ErrInvalidMatrix.Error())
+		}
+	}
+	assert.Error(t, (&Matrix{FromParent: "customer"}).validate(&Job{Name: "job"}))
+}
+
+func TestMatrixRun(t *testing.T) {
+	job := &Job{Name: "sync", Retries: 1}
+	group := []*Execution{
+		{Group: 1, MatrixItem: "customer=1", MatrixSize: 4, Attempt: 1, Success: true},
+		{Group: 1, MatrixItem: "customer=2", MatrixSize: 4, Attempt: 1},
+		{Group: 1, MatrixItem: "customer=3", MatrixSize: 4, Attempt: 1},
+		{Group: 1, MatrixItem: "customer=3", MatrixSize: 4, Attempt: 2},
+	}
+	mr := newMatrixRun(job, group)
+	assert.Equal(t, 4, mr.Total)
+	assert.Equal(t, 1, mr.Succeeded)
+	assert.Equal(t, 1, mr.Failed)
+	assert.Equal(t, 2, mr.Pending)
+	require.Len(t, mr.Items, 3)
+	assert.Equal(t, MatrixItemRetrying, mr.Items[1].Status)
+	assert.Equal(t, StatusFailed, mr.Items[2].Status)
+	assert.Equal(t, 2, mr.Items[2].Attempts)
+
+	assert.Equal(t, StatusRunning, mr.Status)
+	assert.Nil(t, mr.Discovery)
+
+	assert.True(t, matrixPending(job, group[0], group))
+	assert.False(t, matrixPending(job, &Execution{}, group))
+
+	// Runs with a discovery step are failed if it failed
+	job.Retries = 0
+	mr = newMatrixRun(job, []*Execution{{Group: 2, Discovery: true, Attempt: 1}})
+	assert.Equal(t, StatusFailed, mr.Status)
+	assert.Equal(t, 0, mr.Total)
+	require.NotNil(t, mr.Discovery)
+
+	mr = newMatrixRun(job, []*Execution{
+		{Group: 3, Discovery: true, Attempt: 1, Success: true, MatrixSize: 2},
+		{Group: 3, MatrixItem: "item=1", MatrixSize: 2, Attempt: 1, Success: true},
+		{Group: 3, MatrixItem: "item=2", MatrixSize: 2, Attempt: 1},
+	})
+	assert.Equal(t, StatusPartialyFailed, mr.Status)
+	assert.Equal(t, 2, mr.Total)
+	assert.Len(t, mr.Items, 2)
+}
+
+func TestParseDiscovery(t *testing.T) {
+	items, err := parseDiscovery(`["shard-1", 2, {"shard": "3", "replicas": 2, "tags": ["a"]}]`)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"item": "shard-1"},
+		{"item": "2"},
+		{"shard": "3", "replicas": "2", "tags": `["a"]`},
+	}, items)
+
+	_, err = parseDiscovery("shard-1\nshard-2")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), ErrInvalidDiscovery.Error())
+	}
+
+	job := &Job{Name: "shards", Matrix: &Matrix{
+		Params:    map[string][]string{"mode": {"full", "delta"}},
+		Discovery: &Step{Executor: "shell"},
+	}}
+	items, err = discoveredItems(job, `["s1", "s2", "s1"]`)
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	assert.Equal(t, "item=s1&mode=full", matrixItem(items[0]))
+
+	items, err = discoveredItems(job, `[]`)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestMatrixSpread(t *testing.T) {
+	ms := newMatrixSpread(map[string]string{"node1": "a1", "node2": "a2"})
+	n1, addr := ms.acquire()
+	assert.Equal(t, "node1", n1)
+	assert.Equal(t, "a1", addr)
+	n2, _ := ms.acquire()
+	assert.Equal(t, "node2", n2)
+	ms.release(n2)
+	n3, _ := ms.acquire()
+	assert.Equal(t, "node2", n3)
+}
+
+func TestAgentRunMatrix(t *testing.T) {
+	port := "8146"
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.ScheduleSimulate = true
+
+	require.NoError(t, a.Store.SetJob(&Job{Name: "customers", Schedule: "@every 1h", Executor: "shell"}, false))
+	job := &Job{
+		Name:      "sync",
+		Schedule:  "@every 1h",
+		Executor:  "shell",
+		ParentJob: "customers",
+		Matrix: &Matrix{
+			Params:      map[string][]string{"region": {"eu", "us"}},
+			FromParent:  "customer",
+			Parallelism: 2,
+		},
+	}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	ex := NewExecution(job.Name)
+	ex.Params = map[string]string{"source": "api"}
+	ex.parentOutput = "42\n43\n"
+	_, err := a.Run(job.Name, ex)
+	require.NoError(t, err)
+
+	executions, err := a.Store.GetExecutions(job.Name)
+	require.NoError(t, err)
+	require.Len(t, executions, 4)
+	items := map[string]bool{}
+	for _, e := range executions {
+		assert.Equal(t, ex.Group, e.Group)
+		assert.Equal(t, 4, e.MatrixSize)
+		assert.Equal(t, "api", e.Params["source"])
+		assert.Equal(t, e.MatrixItem, fmt.Sprintf("customer=%s&region=%s", e.Params["customer"], e.Params["region"]))
+		items[e.MatrixItem] = true
+	}
+	assert.Len(t, items, 4)
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%s/v1/jobs/sync/matrix", port))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var mr MatrixRun
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&mr))
+	assert.Equal(t, ex.Group, mr.Group)
+	assert.Equal(t, 4, mr.Total)
+	assert.Equal(t, 4, mr.Succeeded)
+	assert.Equal(t, 0, mr.Pending)
+	assert.Len(t, mr.Items, 4)
+
+	assert.Equal(t, StatusSuccess, mr.Status)
+
+	resp, err = http.Get(fmt.Sprintf("http://localhost:%s/v1/jobs/customers/matrix", port))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Spread items run on one node each
+	require.NoError(t, a.Store.SetJob(&Job{
+		Name:      "shards",
+		Schedule:  "@every 1h",
+		Executor:  "shell",
+		ParentJob: "customers",
+		Matrix: &Matrix{
+			Params: map[string][]string{"shard": {"1", "2", "3"}},
+			Spread: true,
+		},
+	}, false))
+	_, err = a.Run("shards", NewExecution("shards"))
+	require.NoError(t, err)
+	executions, err = a.Store.GetExecutions("shards")
+	require.NoError(t, err)
+	require.Len(t, executions, 3)
+	for _, e := range executions {
+		assert.Equal(t, "test", e.NodeName)
+	}
+}
@@ -14,50 +14,96 @@ func initMetrics(a *Agent) error {
 	inm := metrics.NewInmemSink(10*time.Second, time.Minute)
 	metrics.DefaultInmemSignal(inm)
 
+	fanout, err := newMetricsSinks(a.config)
+	if err != nil {
+		return err
+	}
+
+	// Initialize the global sink
+	if len(fanout) > 0 {
+		fanout = append(fanout, inm)
+		metrics.NewGlobal(metrics.DefaultConfig("dkron"), fanout)
+	} else {
+		metrics.NewGlobal(metrics.DefaultConfig("dkron"), inm)
+	}
+
+	return nil
+}
+
+// newMetricsSinks returns the configured metrics sinks. Metric labels are
+// sent as tags by the DogStatsd and Prometheus sinks, the statsd sink
+// appends them to the metric name.
+func newMetricsSinks(config *Config) (metrics.FanoutSink, error) {
 	var fanout metrics.FanoutSink
 
 	// Configure the prometheus sink
-	if a.config.EnablePrometheus {
+	if config.EnablePrometheus {
 		promSink, err := prometheus.NewPrometheusSink()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		fanout = append(fanout, promSink)
 	}
 
 	// Configure the statsd sink
-	if a.config.StatsdAddr != "" {
-		sink, err := metrics.NewStatsdSink(a.config.StatsdAddr)
+	if config.StatsdAddr != "" {
+		sink, err := metrics.NewStatsdSink(config.StatsdAddr)
 		if err != nil {
-			return fmt.Errorf("failed to start statsd sink. Got: %s", err)
+			return nil, fmt.Errorf("failed to start statsd sink. Got: %s", err)
 		}
 		fanout = append(fanout, sink)
 	}
 
 	// Configure the DogStatsd sink
-	if a.config.DogStatsdAddr != "" {
+	if config.DogStatsdAddr != "" {
 		var tags []string
 
-		if a.config.DogStatsdTags != nil {
-			tags = a.config.DogStatsdTags
+		if config.DogStatsdTags != nil {
+			tags = config.DogStatsdTags
 		}
 
-		sink, err := datadog.NewDogStatsdSink(a.config.DogStatsdAddr, a.config.NodeName)
+		sink, err := datadog.NewDogStatsdSink(config.DogStatsdAddr, config.NodeName)
 		if err != nil {
-			return fmt.Errorf("failed to start DogStatsd sink. Got: %s", err)
+			return nil, fmt.Errorf("failed to start DogStatsd sink. Got: %s", err)
 		}
 		sink.SetTags(tags)
 		fanout = append(fanout, sink)
 	}
 
-	// Initialize the global sink
-	if len(fanout) > 0 {
-		fanout = append(fanout, inm)
-		metrics.NewGlobal(metrics.DefaultConfig("dkron"), fanout)
-	} else {
-		metrics.NewGlobal(metrics.DefaultConfig("dkron"), inm)
+	return fanout, nil
+}
+
+// jobMetricLabels returns the labels of the per job metrics.
+func jobMetricLabels(job *Job, status string) []metrics.Label {
+	return []metrics.Label{
+		{Name: "job", Value: job.Name},
+		{Name: "namespace", Value: job.Namespace()},
+		{Name: "status", Value: status},
 	}
+}
 
-	return nil
+// emitExecutionMetrics counts the finished execution, samples its
+// duration in milliseconds, adds up its cost and sets the metrics reported
+// by its executors, labeled with the job and the result.
+func emitExecutionMetrics(job *Job, execution *Execution) {
+	status := StatusSuccess
+	if execution.Skipped {
+		status = StatusSkipped
+	} else if !execution.Success {
+		status = StatusFailed
+	}
+	labels := jobMetricLabels(job, status)
+
+	metrics.IncrCounterWithLabels([]string{"job", "executions"}, 1, labels)
+	if !execution.FinishedAt.IsZero() {
+		duration := execution.FinishedAt.Sub(execution.StartedAt)
+		metrics.AddSampleWithLabels([]string{"job", "duration"}, float32(duration)/float32(time.Millisecond), labels)
+	}
+	if execution.Cost > 0 {
+		metrics.IncrCounterWithLabels([]string{"job", "cost"}, float32(execution.Cost), labels)
+	}
+	for name, v := range execution.Metrics {
+		metrics.SetGaugeWithLabels([]string{"job", "metric", name}, float32(v), labels)
+	}
 }
@@ -0,0 +1,33 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitExecutionMetrics(t *testing.T) {
+	inm := metrics.NewInmemSink(time.Minute, time.Minute)
+	cfg := metrics.DefaultConfig("dkron")
+	cfg.EnableHostname = false
+	metrics.NewGlobal(cfg, inm)
+
+	job := &Job{Name: "charge", Metadata: map[string]string{"namespace": "payments"}}
+	now := time.Now()
+	emitExecutionMetrics(job, &Execution{StartedAt: now, FinishedAt: now.Add(2 * time.Second), Success: true})
+	emitExecutionMetrics(job, &Execution{StartedAt: now, FinishedAt: now.Add(time.Second), Success: false})
+
+	data := inm.Data()
+	require.Len(t, data, 1)
+
+	counter, ok := data[0].Counters["dkron.job.executions;job=charge;namespace=payments;status=success"]
+	require.True(t, ok)
+	assert.Equal(t, 1, counter.Count)
+
+	sample, ok := data[0].Samples["dkron.job.duration;job=charge;namespace=payments;status=failed"]
+	require.True(t, ok)
+	assert.Equal(t, float64(1000), sample.Max)
+}
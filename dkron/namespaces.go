@@ -0,0 +1,101 @@
+package dkron
+
+import (
+	"github.com/distribworks/dkron/v3/plugin"
+)
+
+// NamespaceDefaults are the settings of the jobs of a namespace that don't
+// set their own, so policies apply to every job of the namespace.
+type NamespaceDefaults struct {
+	// Processors of every job, merged with the processors of the job. The
+	// config of a processor set by the job replaces the default one.
+	Processors map[string]plugin.Config `mapstructure:"processors"`
+
+	// Owner fields of the jobs without them, used by the notifications.
+	OwnerEmail             string `mapstructure:"owner-email"`
+	OwnerTeam              string `mapstructure:"owner-team"`
+	OwnerEscalationChannel string `mapstructure:"owner-escalation-channel"`
+
+	// When to notify the finished runs of the jobs that don't set it.
+	NotifyOn    string `mapstructure:"notify-on"`
+	NotifyEvery int    `mapstructure:"notify-every"`
+
+	// Webhook notifying the runs of the jobs instead of the agent one.
+	WebhookURL     string   `mapstructure:"webhook-url"`
+	WebhookPayload string   `mapstructure:"webhook-payload"`
+	WebhookHeaders []string `mapstructure:"webhook-headers"`
+
+	// Regular expressions of the secrets redacted from the output of the
+	// runs of the jobs, with the ones of the cluster and the job.
+	RedactPatterns []string `mapstructure:"redact-patterns"`
+}
+
+// apply returns a copy of the job with the defaults merged in.
+func (d *NamespaceDefaults) apply(job *Job) *Job {
+	j := *job
+
+	if len(d.Processors) > 0 {
+		j.Processors = make(map[string]plugin.Config, len(d.Processors)+len(job.Processors))
+		for name, config := range d.Processors {
+			j.Processors[name] = copyConfig(config)
+		}
+		for name, config := range job.Processors {
+			j.Processors[name] = config
+		}
+	}
+
+	if j.OwnerEmail == "" {
+		j.OwnerEmail = d.OwnerEmail
+	}
+	if j.OwnerTeam == "" {
+		j.OwnerTeam = d.OwnerTeam
+	}
+	if j.OwnerEscalationChannel == "" {
+		j.OwnerEscalationChannel = d.OwnerEscalationChannel
+	}
+	if j.NotifyOn == "" {
+		j.NotifyOn = d.NotifyOn
+		if j.NotifyEvery == 0 {
+			j.NotifyEvery = d.NotifyEvery
+		}
+	}
+	return &j
+}
+
+// copyConfig copies the processor config, processors add keys to it.
+func copyConfig(config plugin.Config) plugin.Config {
+	c := make(plugin.Config, len(config))
+	for k, v := range config {
+		c[k] = v
+	}
+	return c
+}
+
+// withNamespaceDefaults returns the job with the defaults of its namespace
+// merged in, or the job itself if its namespace has none.
+func (a *Agent) withNamespaceDefaults(job *Job) *Job {
+	d, ok := a.config.NamespaceDefaults[job.Namespace()]
+	if !ok || d == nil {
+		return job
+	}
+	return d.apply(job)
+}
+
+// notificationConfig returns the config notifying the runs of the job,
+// with the webhook of its namespace if it has one.
+func (a *Agent) notificationConfig(job *Job) *Config {
+	d, ok := a.config.NamespaceDefaults[job.Namespace()]
+	if !ok || d == nil || d.WebhookURL == "" {
+		return a.config
+	}
+
+	config := *a.config
+	config.WebhookURL = d.WebhookURL
+	if d.WebhookPayload != "" {
+		config.WebhookPayload = d.WebhookPayload
+	}
+	if len(d.WebhookHeaders) > 0 {
+		config.WebhookHeaders = d.WebhookHeaders
+	}
+	return &config
+}
@@ -0,0 +1,57 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/distribworks/dkron/v3/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentWithNamespaceDefaults(t *testing.T) {
+	a := &Agent{config: &Config{
+		WebhookURL:     "https://hooks.example.com/all",
+		WebhookPayload: "{{.Report}}",
+		NamespaceDefaults: map[string]*NamespaceDefaults{
+			"payments": {
+				Processors: map[string]plugin.Config{
+					"elasticsearch": {"index": "payments"},
+					"log":           {"forward": "false"},
+				},
+				OwnerTeam:              "payments",
+				OwnerEscalationChannel: "#payments-oncall",
+				NotifyOn:               NotifyStateChange,
+				WebhookURL:             "https://hooks.example.com/payments",
+			},
+		},
+	}}
+
+	job := &Job{
+		Name:       "settle",
+		Metadata:   map[string]string{"namespace": "payments"},
+		OwnerTeam:  "billing",
+		Processors: map[string]plugin.Config{"log": {"forward": "true"}},
+	}
+	j := a.withNamespaceDefaults(job)
+	assert.Equal(t, map[string]plugin.Config{
+		"elasticsearch": {"index": "payments"},
+		"log":           {"forward": "true"},
+	}, j.Processors)
+	assert.Equal(t, "billing", j.OwnerTeam)
+	assert.Equal(t, "#payments-oncall", j.OwnerEscalationChannel)
+	assert.Equal(t, NotifyStateChange, j.NotifyOn)
+
+	// The stored job and the defaults are untouched
+	j.Processors["elasticsearch"]["reporting_node"] = "dkron1"
+	assert.Len(t, job.Processors, 1)
+	assert.NotContains(t, a.config.NamespaceDefaults["payments"].Processors["elasticsearch"], "reporting_node")
+
+	config := a.notificationConfig(job)
+	assert.Equal(t, "https://hooks.example.com/payments", config.WebhookURL)
+	assert.Equal(t, "{{.Report}}", config.WebhookPayload)
+	assert.Equal(t, "https://hooks.example.com/all", a.config.WebhookURL)
+
+	// Jobs of other namespaces don't get the defaults
+	other := &Job{Name: "cleanup"}
+	assert.Equal(t, other, a.withNamespaceDefaults(other))
+	assert.Equal(t, a.config, a.notificationConfig(other))
+}
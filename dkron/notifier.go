@@ -21,6 +21,12 @@ type Notifier struct {
 	Job            *Job
 	Execution      *Execution
 	ExecutionGroup []*Execution
+	// Escalation is the escalation step notified, if any.
+	Escalation *EscalationStep
+	// Deadline is the deadline missed by the execution group, if any.
+	Deadline time.Time
+	// BudgetExceeded is set when a run was rejected by the run budget.
+	BudgetExceeded bool
 }
 
 // Notification creates a new Notifier instance
@@ -45,6 +51,42 @@ func (n *Notifier) Send() error {
 	return nil
 }
 
+// escalate sends the notifications of the escalation step.
+func (n *Notifier) escalate() error {
+	if n.Escalation.Email != "" && n.Config.MailHost != "" && n.Config.MailPort != 0 {
+		if err := n.sendEmail(n.Escalation.Email); err != nil {
+			return err
+		}
+	}
+	if n.Escalation.Webhook != "" {
+		payload := n.Escalation.Payload
+		if payload == "" {
+			payload = n.Config.WebhookPayload
+		}
+		return n.callWebhook(n.Escalation.Webhook, payload)
+	}
+	return nil
+}
+
+// notifyExecution returns whether the finished run of the execution is
+// notified, prevFailures are the consecutive failed runs of the job before it.
+// Jobs notifying on state change only notify the first failed run, every
+// NotifyEvery failed runs after it and the recovery.
+func notifyExecution(job *Job, execution *Execution, prevFailures int) bool {
+	// Skipped runs have nothing to report
+	if execution.Skipped {
+		return false
+	}
+	if job.NotifyOn != NotifyStateChange || job.Status == StatusTripped {
+		return true
+	}
+
+	if execution.Success {
+		return prevFailures > 0
+	}
+	return prevFailures == 0 || (job.NotifyEvery > 0 && prevFailures%job.NotifyEvery == 0)
+}
+
 func (n *Notifier) report() string {
 	var exgStr string
 	for _, ex := range n.ExecutionGroup {
@@ -56,8 +98,24 @@ func (n *Notifier) report() string {
 			ex.Success)
 	}
 
-	return fmt.Sprintf("Executed: %s\nReporting node: %s\nStart time: %s\nEnd time: %s\nSuccess: %t\nNode: %s\nOutput: %s\nExecution group: %d\n%s",
+	var tripped string
+	if n.Escalation != nil {
+		tripped = fmt.Sprintf("Escalated after %d consecutive failed runs\n", n.Escalation.After)
+	}
+	if !n.Deadline.IsZero() {
+		tripped += fmt.Sprintf("Deadline missed, the run had to finish by %s\n", n.Deadline)
+	}
+	if n.BudgetExceeded {
+		tripped += "Run budget exceeded, the run was skipped\n"
+	}
+	if n.Job != nil && n.Job.Status == StatusTripped {
+		tripped += fmt.Sprintf("Circuit breaker tripped after %d consecutive failures, job disabled\n", n.Job.ConsecutiveFailures)
+	}
+
+	return fmt.Sprintf("%sExecuted: %s\nExecution ID: %s\nReporting node: %s\nStart time: %s\nEnd time: %s\nSuccess: %t\nNode: %s\nOutput: %s\nExecution group: %d\n%s",
+		tripped,
 		n.Execution.JobName,
+		n.Execution.Id,
 		n.Config.NodeName,
 		n.Execution.StartedAt,
 		n.Execution.FinishedAt,
@@ -71,24 +129,39 @@ func (n *Notifier) report() string {
 func (n *Notifier) buildTemplate(templ string) *bytes.Buffer {
 	t := template.Must(template.New("report").Parse(templ))
 
+	job := n.Job
+	if job == nil {
+		job = &Job{}
+	}
+
 	data := struct {
 		Report        string
 		JobName       string
+		ExecutionID   string
 		ReportingNode string
 		StartTime     time.Time
 		FinishedAt    time.Time
 		Success       string
 		NodeName      string
 		Output        string
+		Owner         string
+		OwnerEmail    string
+		OwnerTeam     string
+		Escalation    string
 	}{
 		n.report(),
 		n.Execution.JobName,
+		n.Execution.Id,
 		n.Config.NodeName,
 		n.Execution.StartedAt,
 		n.Execution.FinishedAt,
 		fmt.Sprintf("%t", n.Execution.Success),
 		n.Execution.NodeName,
 		n.Execution.Output,
+		job.Owner,
+		job.OwnerEmail,
+		job.OwnerTeam,
+		job.OwnerEscalationChannel,
 	}
 
 	out := &bytes.Buffer{}
@@ -101,6 +174,10 @@ func (n *Notifier) buildTemplate(templ string) *bytes.Buffer {
 }
 
 func (n *Notifier) sendExecutionEmail() error {
+	return n.sendEmail(n.Job.OwnerEmail)
+}
+
+func (n *Notifier) sendEmail(to string) error {
 	var data *bytes.Buffer
 	if n.Config.MailPayload != "" {
 		data = n.buildTemplate(n.Config.MailPayload)
@@ -108,7 +185,7 @@ func (n *Notifier) sendExecutionEmail() error {
 		data = bytes.NewBuffer([]byte(n.Execution.Output))
 	}
 	e := &email.Email{
-		To:      []string{n.Job.OwnerEmail},
+		To:      []string{to},
 		From:    n.Config.MailFrom,
 		Subject: fmt.Sprintf("%s%s %s execution report", n.Config.MailSubjectPrefix, n.statusString(n.Execution), n.Execution.JobName),
 		Text:    []byte(data.Bytes()),
@@ -124,18 +201,27 @@ func (n *Notifier) sendExecutionEmail() error {
 }
 
 func (n *Notifier) auth() smtp.Auth {
+	return mailAuth(n.Config)
+}
+
+// mailAuth returns the authentication to use with the configured mail server.
+func mailAuth(config *Config) smtp.Auth {
 	var auth smtp.Auth
 
-	if n.Config.MailUsername != "" && n.Config.MailPassword != "" {
-		auth = smtp.PlainAuth("", n.Config.MailUsername, n.Config.MailPassword, n.Config.MailHost)
+	if config.MailUsername != "" && config.MailPassword != "" {
+		auth = smtp.PlainAuth("", config.MailUsername, config.MailPassword, config.MailHost)
 	}
 
 	return auth
 }
 
 func (n *Notifier) callExecutionWebhook() error {
-	out := n.buildTemplate(n.Config.WebhookPayload)
-	req, err := http.NewRequest("POST", n.Config.WebhookURL, out)
+	return n.callWebhook(n.Config.WebhookURL, n.Config.WebhookPayload)
+}
+
+func (n *Notifier) callWebhook(url string, payload string) error {
+	out := n.buildTemplate(payload)
+	req, err := http.NewRequest("POST", url, out)
 	if err != nil {
 		return err
 	}
@@ -164,6 +250,15 @@ func (n *Notifier) callExecutionWebhook() error {
 }
 
 func (n *Notifier) statusString(execution *Execution) string {
+	if !n.Deadline.IsZero() {
+		return "Late"
+	}
+	if n.BudgetExceeded {
+		return "Budget exceeded"
+	}
+	if n.Job != nil && n.Job.Status == StatusTripped {
+		return "Tripped"
+	}
 	if execution.Success {
 		return "Success"
 	}
@@ -111,7 +111,13 @@ func TestNotifier_buildTemplate(t *testing.T) {
 		ex1,
 	}
 
-	n := Notification(c, ex1, exg, nil)
+	job := &Job{
+		Name:                   "test",
+		OwnerTeam:              "platform",
+		OwnerEscalationChannel: "#platform-oncall",
+	}
+
+	n := Notification(c, ex1, exg, job)
 	for _, tc := range templateTestCases(n) {
 		got := n.buildTemplate(tc.template).String()
 
@@ -169,5 +175,41 @@ var templateTestCases = func(n *Notifier) []templateTestCase {
 			exp:      fmt.Sprintf("%s", n.Execution.Output),
 			template: "{{.Output}}",
 		},
+		{
+			desc:     "OwnerTeam template variable",
+			exp:      n.Job.OwnerTeam,
+			template: "{{.OwnerTeam}}",
+		},
+		{
+			desc:     "Escalation template variable",
+			exp:      n.Job.OwnerEscalationChannel,
+			template: "{{.Escalation}}",
+		},
+	}
+}
+
+func TestNotifyExecution(t *testing.T) {
+	tests := []struct {
+		name         string
+		job          *Job
+		execution    *Execution
+		prevFailures int
+		notify       bool
+	}{
+		{"always", &Job{}, &Execution{Attempt: 1, Success: true}, 0, true},
+		{"first success", &Job{NotifyOn: NotifyStateChange}, &Execution{Attempt: 1, Success: true}, 0, false},
+		{"first failure", &Job{NotifyOn: NotifyStateChange}, &Execution{Attempt: 1}, 0, true},
+		{"still failing", &Job{NotifyOn: NotifyStateChange}, &Execution{Attempt: 1}, 1, false},
+		{"recovery", &Job{NotifyOn: NotifyStateChange}, &Execution{Attempt: 1, Success: true}, 3, true},
+		{"reminder", &Job{NotifyOn: NotifyStateChange, NotifyEvery: 3}, &Execution{Attempt: 1}, 3, true},
+		{"no reminder", &Job{NotifyOn: NotifyStateChange, NotifyEvery: 3}, &Execution{Attempt: 1}, 4, false},
+		{"tripped", &Job{NotifyOn: NotifyStateChange, Status: StatusTripped}, &Execution{Attempt: 1}, 4, true},
+		// Failed attempts of the run aren't failed runs
+		{"failure after retries", &Job{NotifyOn: NotifyStateChange, Retries: 2}, &Execution{Attempt: 3}, 0, true},
+		{"success after retry", &Job{NotifyOn: NotifyStateChange, Retries: 2}, &Execution{Attempt: 2, Success: true}, 0, false},
+		{"recovery after retries", &Job{NotifyOn: NotifyStateChange, Retries: 2}, &Execution{Attempt: 2, Success: true}, 1, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.notify, notifyExecution(tt.job, tt.execution, tt.prevFailures), tt.name)
 	}
 }
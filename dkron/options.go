@@ -9,6 +9,10 @@ func WithPlugins(plugins Plugins) AgentOption {
 	return func(agent *Agent) {
 		agent.ProcessorPlugins = plugins.Processors
 		agent.ExecutorPlugins = plugins.Executors
+		agent.pluginLoader = plugins.Loader
+		for p, stop := range plugins.Stops {
+			agent.pluginCalls.stops[p] = stop
+		}
 	}
 }
 
@@ -0,0 +1,306 @@
+package dkron
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// OverloadModeNormal is the mode of a leader running every job.
+	OverloadModeNormal = "normal"
+	// OverloadModeDegraded is the mode of an overloaded leader, deferring
+	// the scheduled runs of low priority jobs.
+	OverloadModeDegraded = "degraded"
+
+	// overloadTag is the serf tag holding the mode of the leader while it
+	// isn't normal.
+	overloadTag = "overload"
+	// overloadEvent is the serf user event sent by the leader when its
+	// mode changes.
+	overloadEvent = "dkron:overload"
+	// overloadInterval is how often the leader checks its load.
+	overloadInterval = 5 * time.Second
+)
+
+// OverloadStatus is the load of the leader and the mode it runs jobs in.
+type OverloadStatus struct {
+	Mode   string    `json:"mode"`
+	Since  time.Time `json:"since"`
+	Leader string    `json:"leader"`
+
+	// Dispatches is the number of runs being dispatched.
+	Dispatches int `json:"dispatches"`
+	// WriteLatency is the average latency of the store writes done to
+	// run jobs.
+	WriteLatency string `json:"write_latency"`
+	// Deferred are the low priority jobs waiting for the normal mode.
+	Deferred []string `json:"deferred"`
+}
+
+// overload tracks the load of the leader, the dispatches in flight and
+// the latency of the store writes done to run jobs, and the runs deferred
+// while it's overloaded.
+type overload struct {
+	sync.Mutex
+
+	dispatches int
+
+	// latency is the average write latency of the last window, sum and
+	// count the writes of the current one.
+	latency time.Duration
+	sum     time.Duration
+	count   int
+
+	mode  string
+	since time.Time
+
+	// deferred holds the time the schedule of every deferred job fired.
+	deferred map[string]time.Time
+}
+
+func (o *overload) dispatchStarted() {
+	o.Lock()
+	defer o.Unlock()
+	o.dispatches++
+}
+
+func (o *overload) dispatchDone() {
+	o.Lock()
+	defer o.Unlock()
+	o.dispatches--
+}
+
+// observeWrite records the latency of a store write.
+func (o *overload) observeWrite(d time.Duration) {
+	o.Lock()
+	defer o.Unlock()
+	o.sum += d
+	o.count++
+}
+
+// tick ends the current latency window, a window without writes has no
+// latency.
+func (o *overload) tick() {
+	o.Lock()
+	defer o.Unlock()
+	o.latency = 0
+	if o.count > 0 {
+		o.latency = o.sum / time.Duration(o.count)
+	}
+	o.sum, o.count = 0, 0
+}
+
+// writeLatency returns the highest of the average latencies of the last
+// and the current window.
+func (o *overload) writeLatency() time.Duration {
+	latency := o.latency
+	if o.count > 0 && o.sum/time.Duration(o.count) > latency {
+		latency = o.sum / time.Duration(o.count)
+	}
+	return latency
+}
+
+// saturated returns whether the dispatches or the write latency are over
+// their thresholds, zero thresholds are disabled.
+func (o *overload) saturated(maxDispatches int, maxLatency time.Duration) bool {
+	o.Lock()
+	defer o.Unlock()
+	return (maxDispatches > 0 && o.dispatches >= maxDispatches) ||
+		(maxLatency > 0 && o.writeLatency() >= maxLatency)
+}
+
+// setMode changes the mode, returning whether it changed and the deferred
+// runs to start when it's back to normal.
+func (o *overload) setMode(mode string, now time.Time) (bool, map[string]time.Time) {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.mode == "" {
+		o.mode = OverloadModeNormal
+	}
+	if mode == o.mode {
+		return false, nil
+	}
+	o.mode = mode
+	o.since = now
+
+	var deferred map[string]time.Time
+	if mode == OverloadModeNormal {
+		deferred = o.deferred
+		o.deferred = nil
+	}
+	return true, deferred
+}
+
+// deferRun defers the run of the job, a job deferred several times runs
+// once, at its first scheduled time.
+func (o *overload) deferRun(jobName string, scheduledAt time.Time) {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.deferred == nil {
+		o.deferred = map[string]time.Time{}
+	}
+	if _, ok := o.deferred[jobName]; !ok {
+		o.deferred[jobName] = scheduledAt
+	}
+}
+
+// reset goes back to the normal mode dropping the deferred runs, used
+// when the leadership is lost.
+func (o *overload) reset() {
+	o.Lock()
+	defer o.Unlock()
+	o.mode = OverloadModeNormal
+	o.since = time.Time{}
+	o.deferred = nil
+}
+
+func (o *overload) status() *OverloadStatus {
+	o.Lock()
+	defer o.Unlock()
+
+	s := &OverloadStatus{
+		Mode:         o.mode,
+		Since:        o.since,
+		Dispatches:   o.dispatches,
+		WriteLatency: o.writeLatency().String(),
+		Deferred:     []string{},
+	}
+	if s.Mode == "" {
+		s.Mode = OverloadModeNormal
+	}
+	for name := range o.deferred {
+		s.Deferred = append(s.Deferred, name)
+	}
+	sort.Strings(s.Deferred)
+	return s
+}
+
+// overloadEnabled returns whether any overload threshold is set.
+func (a *Agent) overloadEnabled() bool {
+	return a.config.OverloadDispatches > 0 || a.config.OverloadWriteLatency > 0
+}
+
+// monitorOverload checks the load of the leader until stopCh is closed.
+func (a *Agent) monitorOverload(stopCh chan struct{}) {
+	if !a.overloadEnabled() {
+		return
+	}
+
+	ticker := time.NewTicker(overloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.overload.tick()
+			a.checkOverload()
+		case <-stopCh:
+			return
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// checkOverload sets the mode of the leader from its load and returns it.
+// Changes are logged and sent to the cluster, and the deferred runs start
+// when it's back to normal.
+func (a *Agent) checkOverload() string {
+	mode := OverloadModeNormal
+	if a.overload.saturated(a.config.OverloadDispatches, a.config.OverloadWriteLatency) {
+		mode = OverloadModeDegraded
+	}
+
+	changed, deferred := a.overload.setMode(mode, time.Now())
+	if !changed {
+		return mode
+	}
+
+	status := a.overload.status()
+	if mode == OverloadModeDegraded {
+		metrics.SetGauge([]string{"leader", "overloaded"}, 1)
+		log.WithFields(logrus.Fields{
+			"dispatches":    status.Dispatches,
+			"write_latency": status.WriteLatency,
+		}).Warning("leader: Overloaded, deferring the runs of low priority jobs")
+	} else {
+		metrics.SetGauge([]string{"leader", "overloaded"}, 0)
+		log.WithField("deferred", len(deferred)).Info("leader: Load back to normal, running the deferred jobs")
+	}
+
+	tag := mode
+	if mode == OverloadModeNormal {
+		tag = ""
+	}
+	if err := a.setTags(map[string]string{overloadTag: tag}); err != nil {
+		log.WithError(err).Error("leader: Error setting the overload tag")
+	}
+	status.Leader = a.config.NodeName
+	payload, _ := json.Marshal(status)
+	if err := a.serf.UserEvent(overloadEvent, payload, true); err != nil {
+		log.WithError(err).Error("leader: Error sending the overload event")
+	}
+
+	for name, scheduledAt := range deferred {
+		job, err := a.Store.GetJob(name, nil)
+		if err != nil {
+			log.WithError(err).WithField("job", name).Error("leader: Error running deferred job")
+			continue
+		}
+		job.Agent = a
+		go job.run("", scheduledAt)
+	}
+	return mode
+}
+
+// deferScheduledRun defers the scheduled run of a low priority job while
+// the leader is overloaded, returning whether it was deferred.
+func (a *Agent) deferScheduledRun(job *Job, scheduledAt time.Time) bool {
+	if job.Priority != PriorityLow || !a.overloadEnabled() {
+		return false
+	}
+	if a.checkOverload() != OverloadModeDegraded {
+		return false
+	}
+
+	a.overload.deferRun(job.Name, scheduledAt)
+	metrics.IncrCounterWithLabels([]string{"leader", "runs_deferred"}, 1, []metrics.Label{
+		{Name: "job", Value: job.Name},
+	})
+	log.WithField("job", job.Name).Info("leader: Deferring run of low priority job, the leader is overloaded")
+	return true
+}
+
+func (h *HTTPTransport) overloadHandler(c *gin.Context) {
+	if h.agent.IsLeader() {
+		status := h.agent.overload.status()
+		status.Leader = h.agent.config.NodeName
+		renderJSON(c, http.StatusOK, status)
+		return
+	}
+
+	// Followers only know the mode of the leader
+	member, err := h.agent.leaderMember()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	status := &OverloadStatus{
+		Mode:     OverloadModeNormal,
+		Leader:   member.Name,
+		Deferred: []string{},
+	}
+	if mode := member.Tags[overloadTag]; mode != "" {
+		status.Mode = mode
+	}
+	renderJSON(c, http.StatusOK, status)
+}
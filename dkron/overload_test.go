@@ -0,0 +1,81 @@
+package dkron
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverload(t *testing.T) {
+	var o overload
+
+	assert.False(t, o.saturated(2, 0))
+	o.dispatchStarted()
+	o.dispatchStarted()
+	assert.True(t, o.saturated(2, 0))
+	assert.False(t, o.saturated(0, 0))
+	o.dispatchDone()
+	assert.False(t, o.saturated(2, 0))
+
+	// The latency of the current window counts until the next one ends
+	o.observeWrite(300 * time.Millisecond)
+	o.observeWrite(100 * time.Millisecond)
+	assert.True(t, o.saturated(0, 200*time.Millisecond))
+	o.tick()
+	assert.True(t, o.saturated(0, 200*time.Millisecond))
+	o.tick()
+	assert.False(t, o.saturated(0, 200*time.Millisecond))
+
+	now := time.Now()
+	changed, _ := o.setMode(OverloadModeNormal, now)
+	assert.False(t, changed)
+	changed, _ = o.setMode(OverloadModeDegraded, now)
+	assert.True(t, changed)
+
+	o.deferRun("report", now)
+	o.deferRun("report", now.Add(time.Minute))
+	o.deferRun("cleanup", now)
+	assert.Equal(t, []string{"cleanup", "report"}, o.status().Deferred)
+
+	changed, deferred := o.setMode(OverloadModeNormal, now)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]time.Time{"report": now, "cleanup": now}, deferred)
+	assert.Empty(t, o.status().Deferred)
+}
+
+func TestAgentDeferScheduledRun(t *testing.T) {
+	dir, a := setupAPITest(t, "8131")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.OverloadDispatches = 1
+
+	low := &Job{Name: "report", Schedule: "@every 1h", Executor: "shell", Priority: PriorityLow}
+	normal := &Job{Name: "billing", Schedule: "@every 1h", Executor: "shell"}
+	now := time.Now()
+
+	assert.False(t, a.deferScheduledRun(low, now))
+
+	a.overload.dispatchStarted()
+	assert.True(t, a.deferScheduledRun(low, now))
+	assert.False(t, a.deferScheduledRun(normal, now))
+
+	resp, err := http.Get("http://localhost:8131/v1/overload")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var status OverloadStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, OverloadModeDegraded, status.Mode)
+	assert.Equal(t, "test", status.Leader)
+	assert.Equal(t, []string{"report"}, status.Deferred)
+	assert.Equal(t, OverloadModeDegraded, a.serf.LocalMember().Tags[overloadTag])
+
+	a.overload.dispatchDone()
+	assert.Equal(t, OverloadModeNormal, a.checkOverload())
+	assert.Empty(t, a.overload.status().Deferred)
+	assert.Empty(t, a.serf.LocalMember().Tags[overloadTag])
+}
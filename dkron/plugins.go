@@ -0,0 +1,220 @@
+package dkron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/distribworks/dkron/v3/plugin"
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/hashicorp/serf/serf"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrNoPluginLoader is returned when reloading plugins in an agent
+	// started without a plugin loader.
+	ErrNoPluginLoader = errors.New("plugins: This agent can't load plugins")
+	// ErrUnknownPluginType is returned when the loaded plugin is neither an
+	// executor nor a processor.
+	ErrUnknownPluginType = errors.New("plugins: Unknown plugin type")
+)
+
+// LoadedPlugin is a plugin started by a PluginLoader.
+type LoadedPlugin struct {
+	Name   string
+	Type   string
+	SHA256 string
+
+	// Plugin is the plugin.Executor or plugin.Processor.
+	Plugin interface{}
+	// Stop stops the plugin process.
+	Stop func()
+}
+
+// PluginLoader starts the plugin binary in path.
+type PluginLoader func(path string) (*LoadedPlugin, error)
+
+// pluginCalls counts the running calls of every plugin, so the replaced
+// ones are stopped once they finish.
+type pluginCalls struct {
+	sync.Mutex
+	idle    *sync.Cond
+	running map[interface{}]int
+	// stops are the functions stopping the reloaded plugins.
+	stops map[interface{}]func()
+}
+
+func newPluginCalls() *pluginCalls {
+	p := &pluginCalls{
+		running: map[interface{}]int{},
+		stops:   map[interface{}]func(){},
+	}
+	p.idle = sync.NewCond(p)
+	return p
+}
+
+// start records a call to the plugin, the returned function must be called
+// when it's done.
+func (p *pluginCalls) start(pl interface{}) func() {
+	p.Lock()
+	defer p.Unlock()
+	p.running[pl]++
+
+	return func() {
+		p.Lock()
+		defer p.Unlock()
+		p.running[pl]--
+		if p.running[pl] == 0 {
+			delete(p.running, pl)
+			p.idle.Broadcast()
+		}
+	}
+}
+
+// drain waits for the running calls of the plugin to finish.
+func (p *pluginCalls) drain(pl interface{}) {
+	p.Lock()
+	defer p.Unlock()
+	for p.running[pl] > 0 {
+		p.idle.Wait()
+	}
+}
+
+// executor returns the executor plugin with a function to call when done
+// using it.
+func (a *Agent) executor(name string) (plugin.Executor, func(), bool) {
+	a.pluginsLock.RLock()
+	defer a.pluginsLock.RUnlock()
+
+	executor, ok := a.ExecutorPlugins[name]
+	if !ok {
+		return nil, nil, false
+	}
+	return executor, a.pluginCalls.start(executor), true
+}
+
+// processor returns the processor plugin with a function to call when done
+// using it.
+func (a *Agent) processor(name string) (plugin.Processor, func(), bool) {
+	a.pluginsLock.RLock()
+	defer a.pluginsLock.RUnlock()
+
+	processor, ok := a.ProcessorPlugins[name]
+	if !ok {
+		return nil, nil, false
+	}
+	return processor, a.pluginCalls.start(processor), true
+}
+
+// reloadPlugin starts the plugin binary in path and switches to it, the
+// plugin it replaces is stopped once its running calls finish. Returns
+// whether a plugin was replaced.
+func (a *Agent) reloadPlugin(path string) (*LoadedPlugin, bool, error) {
+	if a.pluginLoader == nil {
+		return nil, false, ErrNoPluginLoader
+	}
+	loaded, err := a.pluginLoader(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	a.pluginsLock.Lock()
+	var old interface{}
+	switch p := loaded.Plugin.(type) {
+	case plugin.Executor:
+		if a.ExecutorPlugins == nil {
+			a.ExecutorPlugins = map[string]plugin.Executor{}
+		}
+		if e, ok := a.ExecutorPlugins[loaded.Name]; ok {
+			old = e
+		}
+		a.ExecutorPlugins[loaded.Name] = p
+	case plugin.Processor:
+		if a.ProcessorPlugins == nil {
+			a.ProcessorPlugins = map[string]plugin.Processor{}
+		}
+		if pr, ok := a.ProcessorPlugins[loaded.Name]; ok {
+			old = pr
+		}
+		a.ProcessorPlugins[loaded.Name] = p
+	default:
+		a.pluginsLock.Unlock()
+		loaded.Stop()
+		return nil, false, fmt.Errorf("%s: %s", ErrUnknownPluginType, path)
+	}
+	a.pluginsLock.Unlock()
+
+	a.pluginCalls.Lock()
+	a.pluginCalls.stops[loaded.Plugin] = loaded.Stop
+	stop := a.pluginCalls.stops[old]
+	delete(a.pluginCalls.stops, old)
+	a.pluginCalls.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"plugin": loaded.Name,
+		"type":   loaded.Type,
+		"sha256": loaded.SHA256,
+	}).Info("agent: Plugin reloaded")
+
+	if old == nil {
+		return loaded, false, nil
+	}
+	// Plugins loaded on start are stopped with the agent
+	if stop != nil {
+		go func() {
+			a.pluginCalls.drain(old)
+			stop()
+			log.WithField("plugin", loaded.Name).Info("agent: Replaced plugin drained and stopped")
+		}()
+	}
+	return loaded, true, nil
+}
+
+// ReloadPlugin loads the plugin binary in the path of the request, replacing
+// the loaded plugin with its name.
+func (as *AgentServer) ReloadPlugin(ctx context.Context, req *types.ReloadPluginRequest) (*types.ReloadPluginResponse, error) {
+	loaded, replaced, err := as.agent.reloadPlugin(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ReloadPluginResponse{
+		Name:     loaded.Name,
+		Type:     loaded.Type,
+		Sha256:   loaded.SHA256,
+		Replaced: replaced,
+	}, nil
+}
+
+func (h *HTTPTransport) pluginReloadHandler(c *gin.Context) {
+	var body struct {
+		Node string `json:"node"`
+		Path string `json:"path"`
+	}
+	if err := c.BindJSON(&body); err != nil {
+		return
+	}
+
+	addr := ""
+	for _, m := range h.agent.serf.Members() {
+		if m.Name == body.Node && m.Status == serf.StatusAlive {
+			addr = m.Tags["rpc_addr"]
+		}
+	}
+	if addr == "" {
+		c.AbortWithStatus(http.StatusNotFound)
+		c.Writer.WriteString(fmt.Sprintf("node not found: %s", body.Node))
+		return
+	}
+
+	res, err := h.agent.GRPCClient.ReloadPlugin(addr, body.Path)
+	if err != nil {
+		c.AbortWithStatus(http.StatusUnprocessableEntity)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+	renderJSON(c, http.StatusOK, res)
+}
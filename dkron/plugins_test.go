@@ -0,0 +1,125 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin"
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type versionExecutor struct {
+	version string
+}
+
+func (e *versionExecutor) Execute(args *types.ExecuteRequest, cb plugin.StatusHelper) (*types.ExecuteResponse, error) {
+	return &types.ExecuteResponse{Output: []byte(e.version)}, nil
+}
+
+// fakePluginLoader loads executors named after the binary, the version
+// being its directory.
+func fakePluginLoader(stopped chan string) PluginLoader {
+	return func(path string) (*LoadedPlugin, error) {
+		version := filepath.Base(filepath.Dir(path))
+		return &LoadedPlugin{
+			Name:   filepath.Base(path),
+			Type:   plugin.ExecutorPluginName,
+			Plugin: &versionExecutor{version: version},
+			Stop:   func() { stopped <- version },
+		}, nil
+	}
+}
+
+func TestAgentReloadPlugin(t *testing.T) {
+	stopped := make(chan string, 2)
+	a := NewAgent(DefaultConfig(), WithPlugins(Plugins{Loader: fakePluginLoader(stopped)}))
+
+	loaded, replaced, err := a.reloadPlugin("/plugins/v1/test")
+	require.NoError(t, err)
+	assert.Equal(t, "test", loaded.Name)
+	assert.False(t, replaced)
+
+	// A running execution keeps the old plugin
+	executor, done, ok := a.executor("test")
+	require.True(t, ok)
+
+	_, replaced, err = a.reloadPlugin("/plugins/v2/test")
+	require.NoError(t, err)
+	assert.True(t, replaced)
+
+	out, err := executor.Execute(&types.ExecuteRequest{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(out.Output))
+
+	// New executions use the new one
+	next, nextDone, ok := a.executor("test")
+	require.True(t, ok)
+	out, err = next.Execute(&types.ExecuteRequest{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(out.Output))
+	nextDone()
+
+	// The old one is stopped once drained
+	select {
+	case <-stopped:
+		t.Fatal("plugin stopped while running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	done()
+	select {
+	case version := <-stopped:
+		assert.Equal(t, "v1", version)
+	case <-time.After(time.Second):
+		t.Fatal("plugin not stopped")
+	}
+
+	_, _, err = NewAgent(DefaultConfig()).reloadPlugin("/plugins/v1/test")
+	assert.Equal(t, ErrNoPluginLoader, err)
+}
+
+func TestAPIPluginReload(t *testing.T) {
+	dir, a := setupAPITest(t, "8133")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.AdminToken = "s3cret"
+	a.pluginLoader = fakePluginLoader(make(chan string, 1))
+
+	reload := func(node string, admin bool) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:8133/v1/plugins/reload",
+			bytes.NewBufferString(`{"node": "`+node+`", "path": "/plugins/v2/test"}`))
+		require.NoError(t, err)
+		if admin {
+			req.Header.Set(adminTokenHeader, "s3cret")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := reload("test", false)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = reload("missing", true)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = reload("test", true)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var res types.ReloadPluginResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	assert.Equal(t, "test", res.Name)
+	assert.Equal(t, plugin.ExecutorPluginName, res.Type)
+
+	_, _, ok := a.executor("test")
+	assert.True(t, ok)
+}
@@ -0,0 +1,176 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrPolicyDenied is returned when a job is rejected by a job policy.
+	ErrPolicyDenied = errors.New("job denied by policy")
+	// ErrInvalidPolicy is returned when a job policy can't be compiled.
+	ErrInvalidPolicy = errors.New("invalid job policy")
+)
+
+// JobPolicy is an admission rule of the jobs set through the API and of
+// their manual runs.
+type JobPolicy struct {
+	// Name of the policy, reported when it denies a job.
+	Name string `mapstructure:"name"`
+
+	// Search query selecting the jobs the policy applies to, with the
+	// syntax of the job search. All jobs when empty.
+	Match string `mapstructure:"match"`
+
+	// Regular expressions the shell commands of the jobs can't match.
+	DenyCommands []string `mapstructure:"deny-commands"`
+
+	// Regular expressions the shell commands of the jobs must match one
+	// of. Any command when empty.
+	AllowCommands []string `mapstructure:"allow-commands"`
+
+	// Owner fields the jobs must set.
+	RequireOwner []string `mapstructure:"require-owner"`
+
+	// Tags the jobs can't target, in the key=value format or the key
+	// alone for any value.
+	DenyTags []string `mapstructure:"deny-tags"`
+}
+
+// jobPolicy is a compiled job policy.
+type jobPolicy struct {
+	*JobPolicy
+	match []searchTerm
+	deny  []*regexp.Regexp
+	allow []*regexp.Regexp
+}
+
+func compileJobPolicies(policies []*JobPolicy) ([]*jobPolicy, error) {
+	var compiled []*jobPolicy
+	for i, p := range policies {
+		if p == nil || p.Name == "" {
+			return nil, fmt.Errorf("%s: policy %d has no name", ErrInvalidPolicy, i+1)
+		}
+		jp := &jobPolicy{JobPolicy: p}
+
+		if strings.TrimSpace(p.Match) != "" {
+			terms, err := parseQuery(p.Match, false)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %s", ErrInvalidPolicy, p.Name, err)
+			}
+			jp.match = terms
+		}
+		for _, expr := range p.DenyCommands {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %s", ErrInvalidPolicy, p.Name, err)
+			}
+			jp.deny = append(jp.deny, re)
+		}
+		for _, expr := range p.AllowCommands {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %s", ErrInvalidPolicy, p.Name, err)
+			}
+			jp.allow = append(jp.allow, re)
+		}
+		if err := (&Job{Owner: "-", OwnerEmail: "-", OwnerTeam: "-", OwnerEscalationChannel: "-"}).ValidateOwner(p.RequireOwner); err != nil {
+			return nil, fmt.Errorf("%s: %s: %s", ErrInvalidPolicy, p.Name, err)
+		}
+		compiled = append(compiled, jp)
+	}
+	return compiled, nil
+}
+
+// shellCommands returns the commands the job runs with the shell executor,
+// of the job or of its steps, of its canary, of its matrix discovery and
+// of its pre-check.
+func (j *Job) shellCommands() []string {
+	var commands []string
+	if j.Executor == "shell" {
+		commands = append(commands, j.ExecutorConfig["command"])
+	}
+	for _, s := range j.Steps {
+		if s.Executor == "shell" {
+			commands = append(commands, s.ExecutorConfig["command"])
+		}
+	}
+	if j.Canary != nil && j.Canary.Executor == "shell" {
+		commands = append(commands, j.Canary.ExecutorConfig["command"])
+	}
+	if j.Matrix != nil && j.Matrix.Discovery != nil && j.Matrix.Discovery.Executor == "shell" {
+		commands = append(commands, j.Matrix.Discovery.ExecutorConfig["command"])
+	}
+	if j.Precheck != nil && j.Precheck.Executor == "shell" {
+		commands = append(commands, j.Precheck.ExecutorConfig["command"])
+	}
+	return commands
+}
+
+// check returns why the policy denies the job, if it does.
+func (p *jobPolicy) check(job *Job) error {
+	if p.match != nil && !docMatches(jobDoc(job.ToProto()), p.match) {
+		return nil
+	}
+
+	for _, command := range job.shellCommands() {
+		for _, re := range p.deny {
+			if re.MatchString(command) {
+				return fmt.Errorf("command matches denied pattern %q", re)
+			}
+		}
+		if len(p.allow) == 0 {
+			continue
+		}
+		allowed := false
+		for _, re := range p.allow {
+			if re.MatchString(command) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("command %q is not allowed", command)
+		}
+	}
+
+	if err := job.ValidateOwner(p.RequireOwner); err != nil {
+		return err
+	}
+
+	for _, tag := range p.DenyTags {
+		kv := strings.SplitN(tag, "=", 2)
+		v, ok := job.Tags[kv[0]]
+		if !ok {
+			continue
+		}
+		// Tag values can end with the count of nodes to run in
+		if len(kv) == 1 || strings.SplitN(v, ":", 2)[0] == kv[1] {
+			return fmt.Errorf("targets denied tag %s", tag)
+		}
+	}
+	return nil
+}
+
+// admitJob checks the job against the job policies.
+func (a *Agent) admitJob(job *Job) error {
+	for _, p := range a.policies {
+		if err := p.check(job); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"job":    job.Name,
+				"policy": p.Name,
+			}).Warn("agent: Job denied by policy")
+			metrics.IncrCounterWithLabels([]string{"policy", "denied"}, 1, []metrics.Label{
+				{Name: "policy", Value: p.Name},
+				{Name: "namespace", Value: job.Namespace()},
+			})
+			return fmt.Errorf("%s %s: %s", ErrPolicyDenied, p.Name, err)
+		}
+	}
+	return nil
+}
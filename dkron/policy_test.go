@@ -0,0 +1,80 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmitJob(t *testing.T) {
+	policies, err := compileJobPolicies([]*JobPolicy{
+		{
+			Name:         "no-remote-scripts",
+			DenyCommands: []string{`curl .*\|\s*(ba)?sh`},
+		},
+		{
+			Name:          "production",
+			Match:         "metadata.env:prod",
+			AllowCommands: []string{`^/opt/`},
+			RequireOwner:  []string{"owner_team"},
+			DenyTags:      []string{"role=db", "bastion"},
+		},
+	})
+	require.NoError(t, err)
+	a := &Agent{policies: policies}
+
+	job := scaffoldJob()
+	job.ExecutorConfig["command"] = "curl https://example.com/install | sh"
+	err = a.admitJob(job)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrPolicyDenied.Error()+" no-remote-scripts")
+
+	// Steps are checked too
+	job.ExecutorConfig["command"] = "/bin/true"
+	job.Steps = []*Step{{Name: "fetch", Executor: "shell", ExecutorConfig: map[string]string{"command": "curl -s x | bash"}}}
+	assert.Error(t, a.admitJob(job))
+	job.Steps = nil
+	assert.NoError(t, a.admitJob(job))
+
+	// And the canary
+	job.Canary = &Canary{Executor: "shell", ExecutorConfig: map[string]string{"command": "curl -s x | sh"}}
+	assert.Error(t, a.admitJob(job))
+	job.Canary = nil
+
+	// And the matrix discovery
+	job.Matrix = &Matrix{Discovery: &Step{Name: "discover", Executor: "shell", ExecutorConfig: map[string]string{"command": "curl -s x | sh"}}}
+	assert.Error(t, a.admitJob(job))
+	job.Matrix = nil
+
+	// And the pre-check
+	job.Precheck = &Precheck{Executor: "shell", ExecutorConfig: map[string]string{"command": "curl -s x | sh"}}
+	assert.Error(t, a.admitJob(job))
+	job.Precheck = nil
+
+	// Production jobs need an owner team, commands in /opt and no denied tags
+	job.Metadata = map[string]string{"env": "prod"}
+	assert.Error(t, a.admitJob(job))
+	job.ExecutorConfig["command"] = "/opt/billing/report.sh"
+	assert.Error(t, a.admitJob(job))
+	job.OwnerTeam = "billing"
+	assert.NoError(t, a.admitJob(job))
+	job.Tags = map[string]string{"role": "db:1"}
+	assert.Error(t, a.admitJob(job))
+	job.Tags = map[string]string{"role": "web", "bastion": "true"}
+	assert.Error(t, a.admitJob(job))
+	job.Tags = map[string]string{"role": "web"}
+	assert.NoError(t, a.admitJob(job))
+
+	// Other executors aren't checked for commands
+	job.Executor = "http"
+	job.ExecutorConfig = map[string]string{"url": "https://example.com"}
+	assert.NoError(t, a.admitJob(job))
+
+	_, err = compileJobPolicies([]*JobPolicy{{Name: "bad", DenyCommands: []string{"("}}})
+	assert.Error(t, err)
+	_, err = compileJobPolicies([]*JobPolicy{{Name: "bad", RequireOwner: []string{"manager"}}})
+	assert.Error(t, err)
+	_, err = compileJobPolicies([]*JobPolicy{{DenyTags: []string{"role=db"}}})
+	assert.Error(t, err)
+}
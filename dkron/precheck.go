@@ -0,0 +1,145 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/armon/circbuf"
+	"github.com/distribworks/dkron/v3/plugin"
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ParentSkipSkip records the runs of a dependent job as skipped when
+	// the run of its parent job was.
+	ParentSkipSkip = "skip"
+	// ParentSkipRun runs a dependent job when the run of its parent job
+	// was skipped.
+	ParentSkipRun = "run"
+)
+
+// ErrInvalidPrecheck is returned when the pre-check of a job is not valid.
+var ErrInvalidPrecheck = errors.New("invalid pre-check")
+
+// Precheck is run by the agent before every run of a job, the run is
+// skipped when it reports there is no work to do.
+type Precheck struct {
+	// Executor plugin running the check.
+	Executor string `json:"executor"`
+
+	// Configuration arguments of the executor plugin.
+	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config"`
+
+	// Regexp matching the output of the check when there is no work. When
+	// empty, there is no work when the check fails.
+	NoWorkPattern string `json:"no_work_pattern,omitempty"`
+}
+
+func precheckFromProto(in *proto.JobPrecheck) *Precheck {
+	if in == nil {
+		return nil
+	}
+	return &Precheck{
+		Executor:       in.Executor,
+		ExecutorConfig: in.ExecutorConfig,
+		NoWorkPattern:  in.NoWorkPattern,
+	}
+}
+
+func (pc *Precheck) toProto() *proto.JobPrecheck {
+	if pc == nil {
+		return nil
+	}
+	return &proto.JobPrecheck{
+		Executor:       pc.Executor,
+		ExecutorConfig: pc.ExecutorConfig,
+		NoWorkPattern:  pc.NoWorkPattern,
+	}
+}
+
+func (pc *Precheck) validate() error {
+	if pc.Executor == "" {
+		return fmt.Errorf("%s: executor is required", ErrInvalidPrecheck)
+	}
+	if _, err := regexp.Compile(pc.NoWorkPattern); err != nil {
+		return fmt.Errorf("%s: no_work_pattern: %s", ErrInvalidPrecheck, err)
+	}
+	return nil
+}
+
+func (j *Job) validateOnParentSkip() error {
+	switch j.OnParentSkip {
+	case "", ParentSkipSkip, ParentSkipRun:
+		return nil
+	}
+	return fmt.Errorf("invalid on_parent_skip %q, must be %s or %s", j.OnParentSkip, ParentSkipSkip, ParentSkipRun)
+}
+
+// precheckHelper keeps the output of a pre-check, it's not streamed.
+type precheckHelper struct {
+	output *circbuf.Buffer
+}
+
+func (h *precheckHelper) Update(b []byte, c bool) (int64, error) {
+	n, err := h.output.Write(b)
+	return int64(n), err
+}
+
+// precheck runs the pre-check of a job, returning whether it reported no
+// work along with its output. Checks with a no work pattern fail the run
+// when they fail.
+func (a *Agent) precheck(pc *proto.JobPrecheck, req *proto.ExecuteRequest) (bool, []byte, error) {
+	executor, done, ok := a.executor(pc.Executor)
+	if !ok {
+		return false, nil, fmt.Errorf("pre-check executor %s is not present", pc.Executor)
+	}
+
+	output, _ := circbuf.NewBuffer(maxBufSize)
+	out, err := executor.Execute(req, &precheckHelper{output: output})
+	done()
+
+	if err == nil && out.Error != "" {
+		err = errors.New(out.Error)
+	}
+	if output.TotalWritten() == 0 && out != nil {
+		output.Write(out.Output)
+	}
+
+	if pc.NoWorkPattern == "" {
+		return err != nil, output.Bytes(), nil
+	}
+	if err != nil {
+		return false, output.Bytes(), err
+	}
+	re, err := regexp.Compile(pc.NoWorkPattern)
+	if err != nil {
+		return false, output.Bytes(), err
+	}
+	return re.Match(output.Bytes()), output.Bytes(), nil
+}
+
+// skipRun records a skipped run of a dependent job whose parent job's run
+// was skipped, its own dependent jobs follow it.
+func (a *Agent) skipRun(job *Job, parent *Execution) {
+	ex := NewExecution(job.Name)
+	ex.RequestID = parent.RequestID
+	ex.ScheduledAt = parent.ScheduledAt
+	ex.NodeName = a.config.NodeName
+	ex.StartedAt = time.Now()
+	ex.FinishedAt = ex.StartedAt
+	ex.Skipped = true
+	ex.Output = fmt.Sprintf("skipped: the run of parent job %s was skipped\n", parent.JobName)
+
+	log.WithFields(logrus.Fields{
+		"job":        job.Name,
+		"parent_job": parent.JobName,
+		"request_id": parent.RequestID,
+	}).Info("agent: Skipping dependent job")
+
+	if err := a.GRPCClient.ExecutionDone(string(a.raft.Leader()), ex); err != nil {
+		log.WithError(err).WithField("job", job.Name).Error("agent: Error recording skipped run")
+	}
+}
@@ -0,0 +1,112 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin"
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecheckValidate(t *testing.T) {
+	assert.NoError(t, (&Precheck{Executor: "shell", NoWorkPattern: "^0 files"}).validate())
+
+	for _, pc := range []*Precheck{
+		{},
+		{Executor: "shell", NoWorkPattern: "("},
+	} {
+		err := pc.validate()
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), ErrInvalidPrecheck.Error())
+		}
+	}
+
+	assert.Error(t, (&Job{Name: "child", OnParentSkip: "fail"}).validateOnParentSkip())
+}
+
+// checkExecutor outputs and fails as its config says.
+type checkExecutor struct{}
+
+func (checkExecutor) Execute(args *types.ExecuteRequest, cb plugin.StatusHelper) (*types.ExecuteResponse, error) {
+	cb.Update([]byte(args.Config["output"]), false)
+	return &types.ExecuteResponse{Error: args.Config["error"]}, nil
+}
+
+func TestAgentRunPrecheck(t *testing.T) {
+	dir, a := setupAPITest(t, "8147")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	a.ExecutorPlugins = map[string]plugin.Executor{"check": checkExecutor{}}
+
+	job := &Job{
+		Name:           "poll",
+		Schedule:       "@manually",
+		Executor:       "check",
+		ExecutorConfig: map[string]string{"output": "processed\n"},
+		Precheck: &Precheck{
+			Executor:       "check",
+			ExecutorConfig: map[string]string{"output": "0 files\n"},
+			NoWorkPattern:  "^0 files",
+		},
+	}
+	require.NoError(t, job.Validate())
+	require.NoError(t, a.Store.SetJob(job, false))
+	for _, dj := range []*Job{
+		{Name: "skip_child", ParentJob: job.Name, Executor: "check", OnParentSkip: ParentSkipSkip},
+		{Name: "run_child", ParentJob: job.Name, Executor: "check", OnParentSkip: ParentSkipRun},
+		{Name: "default_child", ParentJob: job.Name, Executor: "check"},
+	} {
+		require.NoError(t, a.Store.SetJob(dj, false))
+	}
+
+	ex := NewExecution(job.Name)
+	require.NoError(t, a.GRPCClient.AgentRun(a.advertiseRPCAddr(), job.ToProto(), ex.ToProto()))
+
+	executions, err := a.Store.GetExecutions(job.Name)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.True(t, executions[0].Skipped)
+	assert.False(t, executions[0].Success)
+	assert.Equal(t, "0 files\ngrpc_agent: Pre-check reported no work, skipping the run\n", executions[0].Output)
+
+	stored, err := a.Store.GetJob(job.Name, nil)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSkipped, stored.Status)
+	assert.Equal(t, 1, stored.SkipCount)
+	assert.Equal(t, 0, stored.ErrorCount)
+
+	// Dependent jobs skip or run as they say
+	require.Eventually(t, func() bool {
+		skipped, err := a.Store.GetExecutions("skip_child")
+		if err != nil || len(skipped) != 1 {
+			return false
+		}
+		run, err := a.Store.GetExecutions("run_child")
+		return err == nil && len(run) == 1 && skipped[0].Skipped && run[0].Success
+	}, 5*time.Second, 50*time.Millisecond)
+	_, err = a.Store.GetExecutions("default_child")
+	assert.Error(t, err)
+
+	// Checks with a no work pattern fail the run when they fail
+	job.Precheck.ExecutorConfig = map[string]string{"error": "database unreachable"}
+	ex = NewExecution(job.Name)
+	require.NoError(t, a.GRPCClient.AgentRun(a.advertiseRPCAddr(), job.ToProto(), ex.ToProto()))
+	executions, err = a.Store.GetExecutionGroup(ex)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.False(t, executions[0].Success)
+	assert.False(t, executions[0].Skipped)
+
+	// Checks without one skip the run when they fail
+	job.Precheck.NoWorkPattern = ""
+	ex = NewExecution(job.Name)
+	require.NoError(t, a.GRPCClient.AgentRun(a.advertiseRPCAddr(), job.ToProto(), ex.ToProto()))
+	executions, err = a.Store.GetExecutionGroup(ex)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.True(t, executions[0].Skipped)
+}
@@ -0,0 +1,156 @@
+package dkron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/serf/serf"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// pressureTag is the serf tag listing the resources a node is under
+	// pressure of, the leader doesn't assign new executions to it.
+	pressureTag = "pressure"
+	// pressureEvent is the serf user event sent when the pressure of a
+	// node changes.
+	pressureEvent = "dkron:pressure"
+	// pressureInterval is how often agents check their disk and memory.
+	pressureInterval = 10 * time.Second
+)
+
+// ErrNodesUnderPressure is returned when running a job whose target
+// nodes are under disk or memory pressure.
+var ErrNodesUnderPressure = errors.New("the target nodes are under pressure")
+
+// pressureChange is the payload of the pressure event.
+type pressureChange struct {
+	Node     string `json:"node"`
+	Pressure string `json:"pressure"`
+}
+
+// monitorPressure checks the disk and memory usage of the node until
+// the agent is shut down.
+func (a *Agent) monitorPressure() {
+	if a.config.PressureDiskThreshold <= 0 && a.config.PressureMemoryThreshold <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(pressureInterval)
+	defer ticker.Stop()
+
+	for {
+		a.checkPressure()
+
+		select {
+		case <-ticker.C:
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// checkPressure sets the pressure tag of the node to the resources over
+// their threshold, notifying the cluster when it changes.
+func (a *Agent) checkPressure() {
+	pressure := []string{}
+	if t := a.config.PressureDiskThreshold; t > 0 {
+		used, err := diskUsage(a.config.DataDir)
+		if err != nil {
+			log.WithError(err).Debug("agent: Error checking the disk usage")
+		} else {
+			metrics.SetGauge([]string{"agent", "disk_usage"}, float32(used))
+			if used >= t {
+				pressure = append(pressure, "disk")
+			}
+		}
+	}
+	if t := a.config.PressureMemoryThreshold; t > 0 {
+		used, err := memoryUsage()
+		if err != nil {
+			log.WithError(err).Debug("agent: Error checking the memory usage")
+		} else {
+			metrics.SetGauge([]string{"agent", "memory_usage"}, float32(used))
+			if used >= t {
+				pressure = append(pressure, "memory")
+			}
+		}
+	}
+
+	p := strings.Join(pressure, ",")
+	if p == a.serf.LocalMember().Tags[pressureTag] {
+		return
+	}
+
+	if err := a.setTags(map[string]string{pressureTag: p}); err != nil {
+		log.WithError(err).Error("agent: Error setting the pressure tag")
+		return
+	}
+	if p != "" {
+		log.WithField("pressure", p).Warning("agent: Node under pressure, no new executions will be assigned to it")
+	} else {
+		log.Info("agent: Node pressure cleared, accepting new executions")
+	}
+
+	payload, _ := json.Marshal(&pressureChange{Node: a.config.NodeName, Pressure: p})
+	if err := a.serf.UserEvent(pressureEvent, payload, true); err != nil {
+		log.WithError(err).Error("agent: Error sending the pressure event")
+	}
+}
+
+// handlePressureEvent logs the cordoning of nodes by the leader.
+func (a *Agent) handlePressureEvent(e serf.UserEvent) {
+	if !a.IsLeader() {
+		return
+	}
+	var c pressureChange
+	if err := json.Unmarshal(e.Payload, &c); err != nil {
+		log.WithError(err).Error("leader: Error decoding the pressure event")
+		return
+	}
+
+	if c.Pressure != "" {
+		log.WithFields(logrus.Fields{
+			"node":     c.Node,
+			"pressure": c.Pressure,
+		}).Warning("leader: Node cordoned, no new executions will be assigned to it")
+	} else {
+		log.WithField("node", c.Node).Info("leader: Node uncordoned, assigning new executions to it")
+	}
+}
+
+// cordonedMembers returns the members not under pressure and the names
+// of the ones that are.
+func cordonedMembers(members []serf.Member) ([]serf.Member, map[string]string) {
+	available := []serf.Member{}
+	cordoned := map[string]string{}
+	for _, m := range members {
+		if p := m.Tags[pressureTag]; p != "" {
+			cordoned[m.Name] = p
+			continue
+		}
+		available = append(available, m)
+	}
+	return available, cordoned
+}
+
+// checkCordoned returns an error if nodes matching the job tags are under
+// pressure, explaining why it has no target nodes.
+func (a *Agent) checkCordoned(job *Job) error {
+	_, cordoned := cordonedMembers(a.serf.Members())
+	if len(cordoned) == 0 {
+		return nil
+	}
+
+	_, nodes := explainNodes(a.serf.Members(), job.Tags, a.config.Region)
+	for _, n := range nodes {
+		if p, ok := cordoned[n.Name]; ok && n.Matches {
+			return fmt.Errorf("%s: skipped run of job %s, %s is under %s pressure", ErrNodesUnderPressure, job.Name, n.Name, p)
+		}
+	}
+	return nil
+}
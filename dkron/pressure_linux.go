@@ -0,0 +1,63 @@
+// +build linux
+
+package dkron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// diskUsage returns the percentage used of the filesystem holding path.
+func diskUsage(path string) (int, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	if st.Blocks == 0 {
+		return 0, fmt.Errorf("no blocks in filesystem of %s", path)
+	}
+	return int((st.Blocks - st.Bavail) * 100 / st.Blocks), nil
+}
+
+// memoryUsage returns the percentage of memory in use.
+func memoryUsage() (int, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return parseMeminfo(f)
+}
+
+// parseMeminfo returns the percentage of memory in use from the
+// /proc/meminfo format, the available memory counts as free.
+func parseMeminfo(r io.Reader) (int, error) {
+	values := map[string]uint64{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[strings.TrimSuffix(fields[0], ":")] = v
+	}
+	if err := s.Err(); err != nil {
+		return 0, err
+	}
+
+	total, available := values["MemTotal"], values["MemAvailable"]
+	if total == 0 || available > total {
+		return 0, fmt.Errorf("invalid meminfo, total %d kB, available %d kB", total, available)
+	}
+	return int((total - available) * 100 / total), nil
+}
@@ -0,0 +1,55 @@
+// +build linux
+
+package dkron
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMeminfo(t *testing.T) {
+	used, err := parseMeminfo(strings.NewReader(`MemTotal:       16000000 kB
+MemFree:         1000000 kB
+MemAvailable:    4000000 kB
+Buffers:          200000 kB
+`))
+	require.NoError(t, err)
+	assert.Equal(t, 75, used)
+
+	_, err = parseMeminfo(strings.NewReader("MemFree: 1000 kB\n"))
+	assert.Error(t, err)
+}
+
+func TestCheckPressure(t *testing.T) {
+	dir, a := setupAPITest(t, "8125")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	used, err := diskUsage(dir)
+	require.NoError(t, err)
+	if used == 0 {
+		t.Skip("the filesystem of the data dir is empty")
+	}
+
+	a.config.PressureDiskThreshold = used
+	a.checkPressure()
+	assert.Equal(t, "disk", a.serf.LocalMember().Tags[pressureTag])
+
+	job := &Job{Name: "cordoned", Executor: "shell"}
+	nodes, _, err := a.processFilteredNodes(job)
+	require.NoError(t, err)
+	assert.Empty(t, nodes)
+	assert.Error(t, a.checkCordoned(job))
+
+	a.config.PressureDiskThreshold = 100
+	a.checkPressure()
+	assert.NotContains(t, a.serf.LocalMember().Tags, pressureTag)
+
+	nodes, _, err = a.processFilteredNodes(job)
+	require.NoError(t, err)
+	assert.Len(t, nodes, 1)
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package dkron
+
+import "errors"
+
+var errPressureUnsupported = errors.New("disk and memory pressure are only monitored on linux")
+
+func diskUsage(path string) (int, error) {
+	return 0, errPressureUnsupported
+}
+
+func memoryUsage() (int, error) {
+	return 0, errPressureUnsupported
+}
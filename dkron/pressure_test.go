@@ -0,0 +1,20 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCordonedMembers(t *testing.T) {
+	members := []serf.Member{
+		{Name: "n1", Tags: map[string]string{"role": "web"}},
+		{Name: "n2", Tags: map[string]string{"role": "web", pressureTag: "disk,memory"}},
+	}
+
+	available, cordoned := cordonedMembers(members)
+	assert.Len(t, available, 1)
+	assert.Equal(t, "n1", available[0].Name)
+	assert.Equal(t, map[string]string{"n2": "disk,memory"}, cordoned)
+}
@@ -0,0 +1,63 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// StoreProfileDefault keeps the default store and raft settings.
+	StoreProfileDefault = "default"
+	// StoreProfileLowMemory trades history and read speed for memory, for
+	// servers on small devices.
+	StoreProfileLowMemory = "low-memory"
+)
+
+// ErrUnknownStoreProfile is returned when the store profile is not
+// supported.
+var ErrUnknownStoreProfile = errors.New("unknown store profile, use \"default\" or \"low-memory\"")
+
+// storeProfile are the settings of the store and of the raft log tuned by
+// a profile. They are local to the server, settings changing what the store
+// keeps, like the number of executions, would make the stores of the servers
+// differ, as they apply the same commands.
+type storeProfile struct {
+	// cacheJobs enables the job cache, holding a decoded copy of the jobs
+	// and their last executions.
+	cacheJobs bool
+	// raftLogCache is the number of raft logs cached in memory.
+	raftLogCache int
+	// raftSnapshots is the number of raft snapshots retained on disk.
+	raftSnapshots int
+}
+
+var storeProfiles = map[string]storeProfile{
+	StoreProfileDefault: {
+		cacheJobs:     true,
+		raftLogCache:  raftLogCacheSize,
+		raftSnapshots: 3,
+	},
+	StoreProfileLowMemory: {
+		cacheJobs:     false,
+		raftLogCache:  64,
+		raftSnapshots: 1,
+	},
+}
+
+// getStoreProfile returns the settings of the profile, empty is the
+// default one.
+func getStoreProfile(name string) (storeProfile, error) {
+	if name == "" {
+		name = StoreProfileDefault
+	}
+	p, ok := storeProfiles[name]
+	if !ok {
+		return storeProfile{}, fmt.Errorf("%s: %s", ErrUnknownStoreProfile, name)
+	}
+	return p, nil
+}
+
+// applyProfile sets the store settings of the profile.
+func (s *Store) applyProfile(p storeProfile) {
+	s.cache.setDisabled(!p.cacheJobs)
+}
@@ -0,0 +1,42 @@
+package dkron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStoreProfile(t *testing.T) {
+	p, err := getStoreProfile("")
+	require.NoError(t, err)
+	assert.Equal(t, storeProfiles[StoreProfileDefault], p)
+
+	_, err = getStoreProfile("tiny")
+	assert.Error(t, err)
+}
+
+func TestStore_LowMemoryProfile(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	profile, err := getStoreProfile(StoreProfileLowMemory)
+	require.NoError(t, err)
+	s.applyProfile(profile)
+
+	require.NoError(t, s.SetJob(&Job{
+		Name:     "report",
+		Schedule: "@every 1h",
+		Executor: "shell",
+	}, false))
+
+	// Jobs aren't cached
+	_, err = s.GetJob("report", nil)
+	require.NoError(t, err)
+	_, ok := s.cache.job("report")
+	assert.False(t, ok)
+
+	// The same executions as other servers are kept
+	assert.Equal(t, MaxExecutions, s.maxExecutions)
+}
@@ -0,0 +1,200 @@
+package dkron
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus"
+)
+
+// rampUpRun is a scheduled run missed by a job, at the time its schedule
+// last fired before it stopped running.
+type rampUpRun struct {
+	job      string
+	missedAt time.Time
+}
+
+// RampUpStatus is the progress of the release of the missed runs.
+type RampUpStatus struct {
+	Since time.Time `json:"since"`
+
+	// Rate is the number of runs released per minute.
+	Rate int `json:"rate"`
+
+	// Pending is the number of missed runs not released yet.
+	Pending int `json:"pending"`
+}
+
+// rampUp holds the missed runs the leader is releasing, in release order.
+type rampUp struct {
+	sync.Mutex
+
+	// stopCh stops the current release, nil when there's none.
+	stopCh  chan struct{}
+	since   time.Time
+	pending []*rampUpRun
+}
+
+// start replaces the runs being released, stopping the previous release,
+// and returns the channel that stops the new one.
+func (r *rampUp) start(runs []*rampUpRun, now time.Time) chan struct{} {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.stopCh != nil {
+		close(r.stopCh)
+	}
+	r.stopCh = make(chan struct{})
+	r.since = now
+	r.pending = runs
+	return r.stopCh
+}
+
+// stop drops the runs not released yet.
+func (r *rampUp) stop() {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.stopCh != nil {
+		close(r.stopCh)
+	}
+	r.stopCh = nil
+	r.pending = nil
+}
+
+// next returns the next run to release, false once all the runs of the
+// release are out or it was stopped.
+func (r *rampUp) next(stopCh chan struct{}) (*rampUpRun, bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	if stopCh != r.stopCh {
+		return nil, false
+	}
+	if len(r.pending) == 0 {
+		r.stopCh = nil
+		return nil, false
+	}
+	run := r.pending[0]
+	r.pending = r.pending[1:]
+	return run, true
+}
+
+func (r *rampUp) status(rate int) *RampUpStatus {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.stopCh == nil {
+		return nil
+	}
+	return &RampUpStatus{Since: r.since, Rate: rate, Pending: len(r.pending)}
+}
+
+// missedRuns returns the runs missed by the scheduled jobs since the given
+// time, the jobs whose next run is in the past. Normal priority jobs go
+// first, then the ones that have been waiting the longest.
+func missedRuns(jobs []*Job, since, now time.Time) []*rampUpRun {
+	var missed []*Job
+	for _, j := range jobs {
+		if j.Disabled || j.ParentJob != "" || j.Next.IsZero() {
+			continue
+		}
+		if j.Next.Before(since) || !j.Next.Before(now) {
+			continue
+		}
+		missed = append(missed, j)
+	}
+
+	sort.SliceStable(missed, func(i, k int) bool {
+		if li, lk := missed[i].Priority == PriorityLow, missed[k].Priority == PriorityLow; li != lk {
+			return lk
+		}
+		if !missed[i].Next.Equal(missed[k].Next) {
+			return missed[i].Next.Before(missed[k].Next)
+		}
+		return missed[i].Name < missed[k].Name
+	})
+
+	runs := make([]*rampUpRun, 0, len(missed))
+	for _, j := range missed {
+		runs = append(runs, &rampUpRun{job: j.Name, missedAt: j.Next})
+	}
+	return runs
+}
+
+// startRampUp releases the runs missed since the given time at the
+// configured rate, replacing a release in progress. Nothing is caught up
+// when the rate isn't set.
+func (a *Agent) startRampUp(since time.Time) {
+	if a.config.RampUpRate <= 0 {
+		return
+	}
+
+	jobs, err := a.Store.GetJobs(nil)
+	if err != nil {
+		log.WithError(err).Error("leader: Error listing the jobs with missed runs")
+		return
+	}
+	runs := missedRuns(jobs, since, time.Now())
+	if len(runs) == 0 {
+		return
+	}
+
+	stopCh := a.rampUp.start(runs, time.Now())
+	log.WithFields(logrus.Fields{
+		"missed": len(runs),
+		"rate":   a.config.RampUpRate,
+	}).Info("leader: Ramping up the missed runs")
+	go a.releaseMissedRuns(stopCh)
+}
+
+// releaseMissedRuns runs the missed runs one at a time at the configured
+// rate, until they're all out or stopCh is closed.
+func (a *Agent) releaseMissedRuns(stopCh chan struct{}) {
+	ticker := time.NewTicker(time.Minute / time.Duration(a.config.RampUpRate))
+	defer ticker.Stop()
+
+	for {
+		run, ok := a.rampUp.next(stopCh)
+		if !ok {
+			metrics.SetGauge([]string{"scheduler", "ramp_up_pending"}, 0)
+			return
+		}
+		// Runs no longer missed don't use a slot
+		if !a.releaseMissedRun(run) {
+			continue
+		}
+		if s := a.rampUp.status(a.config.RampUpRate); s != nil {
+			metrics.SetGauge([]string{"scheduler", "ramp_up_pending"}, float32(s.Pending))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// releaseMissedRun runs the job of the missed run unless it was deleted,
+// disabled or has run since, returning whether it was run.
+func (a *Agent) releaseMissedRun(run *rampUpRun) bool {
+	job, err := a.Store.GetJob(run.job, nil)
+	if err != nil || job.Disabled || !job.Next.Equal(run.missedAt) {
+		log.WithField("job", run.job).Debug("leader: Skipping missed run, the job changed or ran since")
+		return false
+	}
+
+	metrics.IncrCounter([]string{"scheduler", "ramp_up_released"}, 1)
+	log.WithFields(logrus.Fields{
+		"job":       job.Name,
+		"missed_at": run.missedAt,
+	}).Info("leader: Releasing missed run")
+	job.Agent = a
+	go job.run("", run.missedAt)
+	return true
+}
@@ -0,0 +1,83 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissedRuns(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-time.Hour)
+	jobs := []*Job{
+		{Name: "report", Priority: PriorityLow, Next: now.Add(-50 * time.Minute)},
+		{Name: "billing", Next: now.Add(-10 * time.Minute)},
+		{Name: "etl", Priority: PriorityNormal, Next: now.Add(-30 * time.Minute)},
+		{Name: "backup", Next: now.Add(-30 * time.Minute)},
+		{Name: "future", Next: now.Add(time.Minute)},
+		{Name: "old", Next: now.Add(-2 * time.Hour)},
+		{Name: "off", Disabled: true, Next: now.Add(-10 * time.Minute)},
+		{Name: "child", ParentJob: "etl", Next: now.Add(-10 * time.Minute)},
+		{Name: "manual"},
+	}
+
+	var names []string
+	for _, r := range missedRuns(jobs, since, now) {
+		names = append(names, r.job)
+	}
+	assert.Equal(t, []string{"backup", "etl", "billing", "report"}, names)
+}
+
+func TestRampUp(t *testing.T) {
+	var r rampUp
+	assert.Nil(t, r.status(10))
+
+	now := time.Now()
+	stopCh := r.start([]*rampUpRun{{job: "a"}, {job: "b"}}, now)
+	assert.Equal(t, &RampUpStatus{Since: now, Rate: 10, Pending: 2}, r.status(10))
+
+	run, ok := r.next(stopCh)
+	require.True(t, ok)
+	assert.Equal(t, "a", run.job)
+
+	// A new release stops the previous one
+	newCh := r.start([]*rampUpRun{{job: "c"}}, now)
+	_, ok = r.next(stopCh)
+	assert.False(t, ok)
+	select {
+	case <-stopCh:
+	default:
+		t.Fatal("previous release not stopped")
+	}
+
+	run, ok = r.next(newCh)
+	require.True(t, ok)
+	assert.Equal(t, "c", run.job)
+	_, ok = r.next(newCh)
+	assert.False(t, ok)
+	assert.Nil(t, r.status(10))
+
+	r.start([]*rampUpRun{{job: "d"}}, now)
+	r.stop()
+	assert.Nil(t, r.status(10))
+}
+
+func TestReleaseMissedRun(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	a := &Agent{Store: s}
+	job := scaffoldJob()
+	job.Disabled = true
+	require.NoError(t, s.SetJob(job, false))
+	stored, err := s.GetJob(job.Name, nil)
+	require.NoError(t, err)
+
+	// Disabled, ran since or deleted jobs are skipped
+	assert.False(t, a.releaseMissedRun(&rampUpRun{job: job.Name, missedAt: stored.Next}))
+	assert.False(t, a.releaseMissedRun(&rampUpRun{job: job.Name, missedAt: stored.Next.Add(-time.Hour)}))
+	assert.False(t, a.releaseMissedRun(&rampUpRun{job: "gone"}))
+}
@@ -0,0 +1,115 @@
+package dkron
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// readOnlyKey is the key holding the cluster read-only switch.
+	readOnlyKey = "meta:read_only"
+)
+
+var (
+	// ErrReadOnly is returned when changing jobs while the cluster is read-only.
+	ErrReadOnly = errors.New("cluster is in read-only mode, jobs can only be changed using the admin token")
+	// ErrAdminTokenRequired is returned when an admin operation is requested without a valid admin token.
+	ErrAdminTokenRequired = errors.New("this operation requires the admin token")
+)
+
+// SetReadOnly turns the cluster read-only switch on or off.
+func (s *Store) SetReadOnly(readOnly bool) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		if !readOnly {
+			_, err := tx.Delete(readOnlyKey)
+			if err == buntdb.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		_, _, err := tx.Set(readOnlyKey, "true", nil)
+		return err
+	})
+}
+
+// ReadOnly returns whether the cluster is in read-only mode.
+func (s *Store) ReadOnly() (bool, error) {
+	readOnly := false
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		_, err := tx.Get(readOnlyKey)
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		readOnly = true
+		return nil
+	})
+	return readOnly, err
+}
+
+// isAdminToken returns whether the token is the admin token of the agent.
+func (a *Agent) isAdminToken(token string) bool {
+	if a.config.AdminToken == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(a.config.AdminToken)) == 1
+}
+
+// checkWritable returns why the given jobs can't be changed, because the
+// cluster is read-only or some of them are locked, unless adminToken is the
+// admin token. The leader checks it for the changes it receives, whatever
+// node they come from.
+func (a *Agent) checkWritable(adminToken string, jobNames ...string) error {
+	if a.isAdminToken(adminToken) {
+		return nil
+	}
+
+	readOnly, err := a.Store.ReadOnly()
+	if err != nil {
+		return err
+	}
+	if readOnly {
+		return ErrReadOnly
+	}
+
+	for _, name := range jobNames {
+		job, err := a.Store.GetJob(name, nil)
+		if err != nil {
+			// New jobs are not locked
+			continue
+		}
+		if job.Locked {
+			return fmt.Errorf("%s: %s", name, ErrJobLocked)
+		}
+	}
+	return nil
+}
+
+// deletedJobNames returns the names of the jobs deleted with the job, along
+// with its dependent jobs, recursively, when cascading. Jobs listed twice
+// or depending on each other are returned once.
+func (a *Agent) deletedJobNames(name string, cascade bool) []string {
+	names := []string{name}
+	if !cascade {
+		return names
+	}
+	seen := map[string]bool{name: true}
+	for i := 0; i < len(names); i++ {
+		job, err := a.Store.GetJob(names[i], nil)
+		if err != nil {
+			continue
+		}
+		for _, dj := range job.DependentJobs {
+			if !seen[dj] {
+				seen[dj] = true
+				names = append(names, dj)
+			}
+		}
+	}
+	return names
+}
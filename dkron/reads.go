@@ -0,0 +1,96 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// lastIndexHeader is the header holding the raft index of the store
+	// that served a read.
+	lastIndexHeader = "X-Dkron-LastIndex"
+	// readIndexTimeout is how long followers wait to apply the writes of
+	// the leader before serving a consistent read.
+	readIndexTimeout = 5 * time.Second
+)
+
+// ErrReadIndexTimeout is returned when a follower doesn't apply the writes
+// of the leader in time to serve a consistent read.
+var ErrReadIndexTimeout = errors.New("timed out applying the writes of the leader")
+
+// readMiddleware serves reads from the local store. Followers first apply
+// every write the leader applied, unless the request allows stale reads
+// with ?stale=true.
+func (h *HTTPTransport) readMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stale, _ := strconv.ParseBool(c.Query("stale"))
+		if !stale && !h.agent.IsLeader() {
+			if err := h.agent.waitReadIndex(readIndexTimeout); err != nil {
+				c.AbortWithError(http.StatusServiceUnavailable, err)
+				return
+			}
+		}
+		c.Header(lastIndexHeader, strconv.FormatUint(h.agent.raft.AppliedIndex(), 10))
+		c.Next()
+	}
+}
+
+// etagMiddleware tags the responses of the job and execution reads with
+// the raft index of the last write applied to the store, and answers 304
+// Not Modified when the request carries it in If-None-Match. Every write,
+// executions included, changes the tag. Pollers of unchanged jobs then
+// don't get them again.
+func (h *HTTPTransport) etagMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Read before the handler, the response holds at least these writes
+		index := h.agent.fsm.appliedIndex()
+		if index == 0 {
+			c.Next()
+			return
+		}
+
+		etag := fmt.Sprintf(`W/"%d"`, index)
+		c.Header("ETag", etag)
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+		c.Next()
+	}
+}
+
+// etagMatches returns whether the If-None-Match header matches the tag,
+// compared weakly.
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if strings.TrimPrefix(t, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// waitReadIndex waits until this server applied the writes the leader
+// applied.
+func (a *Agent) waitReadIndex(timeout time.Duration) error {
+	index, err := a.GRPCClient.ReadIndex()
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for a.raft.AppliedIndex() < index {
+		if time.Now().After(deadline) {
+			return ErrReadIndexTimeout
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package dkron
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIReadLastIndex(t *testing.T) {
+	dir, a := setupAPITest(t, "8132")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	require.NoError(t, a.GRPCClient.SetJob(&Job{
+		Name:     "report",
+		Schedule: "@every 1h",
+		Executor: "shell",
+		Disabled: true,
+	}, ""))
+	index := a.raft.AppliedIndex()
+
+	for _, path := range []string{"/jobs", "/jobs/report", "/jobs?stale=true", "/members"} {
+		resp, err := http.Get("http://localhost:8132/v1" + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+
+		last, err := strconv.ParseUint(resp.Header.Get(lastIndexHeader), 10, 64)
+		require.NoError(t, err, path)
+		assert.GreaterOrEqual(t, last, index, path)
+	}
+
+	// The leader answers the read index of followers
+	readIndex, err := a.GRPCClient.ReadIndex()
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, readIndex, index)
+	assert.NoError(t, a.waitReadIndex(readIndexTimeout))
+}
+
+func TestAPIReadETag(t *testing.T) {
+	dir, a := setupAPITest(t, "8141")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:     "report",
+		Schedule: "@every 1h",
+		Executor: "shell",
+		Disabled: true,
+	}
+	require.NoError(t, a.GRPCClient.SetJob(job, ""))
+
+	get := func(path, etag string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:8141/v1"+path, nil)
+		require.NoError(t, err)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get("/jobs/report", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp = get("/jobs/report", etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	resp = get("/jobs", `"0", `+etag)
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	// Any write changes the tag
+	job.Schedule = "@every 2h"
+	require.NoError(t, a.GRPCClient.SetJob(job, ""))
+	resp = get("/jobs/report", etag)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEqual(t, etag, resp.Header.Get("ETag"))
+}
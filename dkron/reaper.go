@@ -0,0 +1,117 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/serf/serf"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+// reapInterval is how often the leader looks for lost executions.
+const reapInterval = time.Minute
+
+// ErrExecutionLost is the output of the executions finalized by the leader
+// because their node is gone.
+var ErrExecutionLost = errors.New("execution lost, its node is gone")
+
+// reapExecutions finalizes the lost executions while this agent is the leader.
+func (a *Agent) reapExecutions(stopCh chan struct{}) {
+	if a.config.ExecutionReapGrace <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := a.reapLostExecutions(time.Now()); err != nil {
+			log.WithError(err).Error("leader: Error reaping lost executions")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+// lostExecutions returns the executions still running after the grace
+// period on nodes that are not alive, whose stream this leader isn't
+// receiving.
+func (a *Agent) lostExecutions(now time.Time) ([]*Execution, error) {
+	alive := map[string]bool{}
+	for _, m := range a.serf.Members() {
+		if m.Status == serf.StatusAlive {
+			alive[m.Name] = true
+		}
+	}
+
+	jobs, err := a.Store.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lost := []*Execution{}
+	for _, job := range jobs {
+		executions, err := a.Store.GetExecutions(job.Name)
+		if err != nil && err != buntdb.ErrNotFound {
+			return nil, err
+		}
+		for _, ex := range executions {
+			if !ex.FinishedAt.IsZero() || now.Sub(ex.StartedAt) <= a.config.ExecutionReapGrace {
+				continue
+			}
+			if alive[ex.NodeName] {
+				continue
+			}
+			if _, ok := a.activeExecutions.Load(ex.Key()); ok {
+				continue
+			}
+			lost = append(lost, ex)
+		}
+	}
+	return lost, nil
+}
+
+// reapLostExecutions finalizes the lost executions as failed, like the
+// executions whose stream breaks, so retries, dependent jobs and
+// notifications follow. It returns the number of executions reaped.
+func (a *Agent) reapLostExecutions(now time.Time) (int, error) {
+	lost, err := a.lostExecutions(now)
+	if err != nil {
+		return 0, err
+	}
+
+	reaped := 0
+	for _, ex := range lost {
+		log.WithFields(logrus.Fields{
+			"job":        ex.JobName,
+			"group":      ex.Group,
+			"node":       ex.NodeName,
+			"started_at": ex.StartedAt,
+		}).Warning("leader: Reaping lost execution")
+
+		ex.FinishedAt = now
+		ex.Success = false
+		ex.Output = fmt.Sprintf("%s: %s", ErrExecutionLost, ex.NodeName)
+
+		addr := a.raft.Leader()
+		if err := a.GRPCClient.ExecutionDone(string(addr), ex); err != nil {
+			log.WithError(err).WithField("job", ex.JobName).Error("leader: Error finalizing lost execution")
+			continue
+		}
+		metrics.IncrCounterWithLabels([]string{"leader", "executions_reaped"}, 1, []metrics.Label{
+			{Name: "job", Value: ex.JobName},
+			{Name: "node", Value: ex.NodeName},
+		})
+		reaped++
+	}
+	return reaped, nil
+}
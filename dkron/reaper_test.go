@@ -0,0 +1,62 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReapLostExecutions(t *testing.T) {
+	dir, a := setupAPITest(t, "8129")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.ExecutionReapGrace = time.Hour
+
+	job := &Job{
+		Name:     "sync",
+		Schedule: "@every 1h",
+		Executor: "shell",
+	}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	now := time.Now()
+	running := func(node string, startedAt time.Time, group int64) *Execution {
+		ex := &Execution{
+			JobName:   job.Name,
+			Group:     group,
+			Attempt:   1,
+			NodeName:  node,
+			StartedAt: startedAt,
+		}
+		_, err := a.Store.SetExecution(ex)
+		require.NoError(t, err)
+		return ex
+	}
+	running("gone", now.Add(-2*time.Hour), 1)
+	running("gone", now.Add(-time.Minute), 2)
+	running("test", now.Add(-2*time.Hour), 3)
+
+	// Only the execution past the grace period on a node that is gone
+	reaped, err := a.reapLostExecutions(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reaped)
+
+	executions, err := a.Store.GetExecutions(job.Name)
+	require.NoError(t, err)
+	for _, ex := range executions {
+		if ex.Group == 1 {
+			assert.False(t, ex.Success)
+			assert.Equal(t, now.Unix(), ex.FinishedAt.Unix())
+			assert.Equal(t, ErrExecutionLost.Error()+": gone", ex.Output)
+		} else {
+			assert.True(t, ex.FinishedAt.IsZero())
+		}
+	}
+
+	reaped, err = a.reapLostExecutions(now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, reaped)
+}
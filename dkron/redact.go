@@ -0,0 +1,103 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// redactedText replaces the secrets in the output of the executions.
+const redactedText = "[REDACTED]"
+
+// ErrInvalidRedactPattern is returned when a redaction pattern is not a
+// valid regular expression.
+var ErrInvalidRedactPattern = errors.New("invalid redact pattern")
+
+func compileRedactPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", ErrInvalidRedactPattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// outputRedactions are the compiled redaction patterns of the cluster and
+// of the namespaces.
+type outputRedactions struct {
+	cluster    []*regexp.Regexp
+	namespaces map[string][]*regexp.Regexp
+}
+
+func compileOutputRedactions(c *Config) (*outputRedactions, error) {
+	cluster, err := compileRedactPatterns(c.RedactPatterns)
+	if err != nil {
+		return nil, err
+	}
+	r := &outputRedactions{
+		cluster:    cluster,
+		namespaces: map[string][]*regexp.Regexp{},
+	}
+	for ns, d := range c.NamespaceDefaults {
+		if d == nil {
+			continue
+		}
+		patterns, err := compileRedactPatterns(d.RedactPatterns)
+		if err != nil {
+			return nil, fmt.Errorf("namespace %s: %s", ns, err)
+		}
+		r.namespaces[ns] = patterns
+	}
+	return r, nil
+}
+
+// redact replaces the text matching the pattern. Patterns with groups
+// only replace the text of their groups, like the value in password=(\S+).
+func redact(re *regexp.Regexp, b []byte) []byte {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllLiteral(b, []byte(redactedText))
+	}
+
+	var out []byte
+	last := 0
+	for _, m := range re.FindAllSubmatchIndex(b, -1) {
+		for i := 2; i < len(m); i += 2 {
+			// Unmatched groups and groups nested in a redacted one
+			if m[i] < last {
+				continue
+			}
+			out = append(out, b[last:m[i]]...)
+			out = append(out, redactedText...)
+			last = m[i+1]
+		}
+	}
+	if out == nil {
+		return b
+	}
+	return append(out, b[last:]...)
+}
+
+// redactOutput returns the output of a run of the job with the secrets
+// matching the redaction patterns of the cluster, of the namespace of the
+// job and of the job replaced.
+func (a *Agent) redactOutput(job *Job, output []byte) []byte {
+	var patterns []*regexp.Regexp
+	if a.redactions != nil {
+		patterns = append(patterns, a.redactions.cluster...)
+		patterns = append(patterns, a.redactions.namespaces[job.Namespace()]...)
+	}
+	own, err := compileRedactPatterns(job.RedactPatterns)
+	if err != nil {
+		// Jobs are validated when they are set
+		log.WithError(err).WithField("job", job.Name).Error("agent: Error compiling redact patterns")
+	}
+	patterns = append(patterns, own...)
+
+	for _, re := range patterns {
+		output = redact(re, output)
+	}
+	return output
+}
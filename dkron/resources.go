@@ -0,0 +1,207 @@
+package dkron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/hashicorp/serf/serf"
+)
+
+const (
+	// IOClassLight jobs barely use the disk or the network.
+	IOClassLight = "light"
+	// IOClassNormal jobs use the disk or the network moderately.
+	IOClassNormal = "normal"
+	// IOClassHeavy jobs saturate the disk or the network, a node only
+	// runs one of them at a time.
+	IOClassHeavy = "heavy"
+
+	// capacityTag is the serf tag with the resources of a node.
+	capacityTag = "capacity"
+	// reservedTag is the serf tag with the resources reserved on a node
+	// by the executions it's running.
+	reservedTag = "reserved"
+)
+
+// Resources are the hints of what a job uses while it runs.
+type Resources struct {
+	// CPU cores used by the job, can be fractional.
+	CPU float64 `json:"cpu,omitempty"`
+
+	// Memory used by the job in MB.
+	Memory int64 `json:"memory,omitempty"`
+
+	// IOClass is how much disk and network the job uses: light, normal or heavy.
+	IOClass string `json:"io_class,omitempty"`
+}
+
+func newResourcesFromProto(in *types.JobResources) *Resources {
+	if in == nil {
+		return nil
+	}
+	return &Resources{
+		CPU:     in.Cpu,
+		Memory:  in.Memory,
+		IOClass: in.IoClass,
+	}
+}
+
+func (r *Resources) toProto() *types.JobResources {
+	if r == nil {
+		return nil
+	}
+	return &types.JobResources{
+		Cpu:     r.CPU,
+		Memory:  r.Memory,
+		IoClass: r.IOClass,
+	}
+}
+
+func (r *Resources) validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.CPU < 0 || r.Memory < 0 {
+		return fmt.Errorf("%s: cpu and memory can't be negative", ErrInvalidResources)
+	}
+	switch r.IOClass {
+	case "", IOClassLight, IOClassNormal, IOClassHeavy:
+	default:
+		return fmt.Errorf("%s: unknown io class %s", ErrInvalidResources, r.IOClass)
+	}
+	return nil
+}
+
+// nodeResources are resources of a node, as gossiped in its tags.
+type nodeResources struct {
+	CPU     float64
+	Memory  int64
+	HeavyIO int
+}
+
+func (n nodeResources) String() string {
+	return fmt.Sprintf("cpu=%s,memory=%d,heavy_io=%d",
+		strconv.FormatFloat(n.CPU, 'f', -1, 64), n.Memory, n.HeavyIO)
+}
+
+// add returns the node resources with the job resources added, or
+// removed when sign is negative.
+func (n nodeResources) add(r *Resources, sign int) nodeResources {
+	n.CPU += float64(sign) * r.CPU
+	n.Memory += int64(sign) * r.Memory
+	if r.IOClass == IOClassHeavy {
+		n.HeavyIO += sign
+	}
+	return n
+}
+
+// parseNodeResources parses the node resources in a tag, unknown keys
+// are ignored.
+func parseNodeResources(s string) (nodeResources, error) {
+	var n nodeResources
+	for _, kv := range strings.Split(s, ",") {
+		p := strings.SplitN(kv, "=", 2)
+		if len(p) != 2 {
+			return n, fmt.Errorf("invalid node resources %q", s)
+		}
+		var err error
+		switch p[0] {
+		case "cpu":
+			n.CPU, err = strconv.ParseFloat(p[1], 64)
+		case "memory":
+			n.Memory, err = strconv.ParseInt(p[1], 10, 64)
+		case "heavy_io":
+			n.HeavyIO, err = strconv.Atoi(p[1])
+		}
+		if err != nil {
+			return n, fmt.Errorf("invalid node resources %q: %s", s, err)
+		}
+	}
+	return n, nil
+}
+
+// fitsMember returns whether the member has room for the job resources
+// besides the ones reserved by the executions it's running. Zero
+// capacities are unbounded, and members not gossiping their resources
+// always fit.
+func fitsMember(m serf.Member, r *Resources) bool {
+	capacity, err := parseNodeResources(m.Tags[capacityTag])
+	if err != nil {
+		return true
+	}
+	reserved, err := parseNodeResources(m.Tags[reservedTag])
+	if err != nil {
+		return true
+	}
+
+	if capacity.CPU > 0 && r.CPU > 0 && reserved.CPU+r.CPU > capacity.CPU {
+		return false
+	}
+	if capacity.Memory > 0 && r.Memory > 0 && reserved.Memory+r.Memory > capacity.Memory {
+		return false
+	}
+	if r.IOClass == IOClassHeavy && reserved.HeavyIO > 0 {
+		return false
+	}
+	return true
+}
+
+// unsaturatedMembers returns the members with room for the job resources.
+func unsaturatedMembers(members []serf.Member, r *Resources) []serf.Member {
+	if r == nil {
+		return members
+	}
+	available := []serf.Member{}
+	for _, m := range members {
+		if fitsMember(m, r) {
+			available = append(available, m)
+		}
+	}
+	return available
+}
+
+// reserveResources adds the job resources to the ones reserved on this
+// node and gossips them, the returned function releases them.
+func (a *Agent) reserveResources(r *Resources) func() {
+	if r == nil || (r.CPU == 0 && r.Memory == 0 && r.IOClass != IOClassHeavy) {
+		return func() {}
+	}
+
+	a.updateReserved(r, 1)
+	return func() {
+		a.updateReserved(r, -1)
+	}
+}
+
+func (a *Agent) updateReserved(r *Resources, sign int) {
+	a.reservedLock.Lock()
+	a.reserved = a.reserved.add(r, sign)
+	reserved := a.reserved.String()
+	a.reservedLock.Unlock()
+
+	if err := a.setTags(map[string]string{reservedTag: reserved}); err != nil {
+		log.WithError(err).Error("agent: Error gossiping the reserved resources")
+	}
+}
+
+// setTags updates the given serf tags of this node, keeping the others.
+// Tags set to an empty value are removed.
+func (a *Agent) setTags(tags map[string]string) error {
+	a.tagsLock.Lock()
+	defer a.tagsLock.Unlock()
+
+	t := map[string]string{}
+	for k, v := range a.serf.LocalMember().Tags {
+		t[k] = v
+	}
+	for k, v := range tags {
+		if v == "" {
+			delete(t, k)
+			continue
+		}
+		t[k] = v
+	}
+	return a.serf.SetTags(t)
+}
@@ -0,0 +1,78 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeResources(t *testing.T) {
+	n := nodeResources{CPU: 1.5, Memory: 512, HeavyIO: 1}
+	assert.Equal(t, "cpu=1.5,memory=512,heavy_io=1", n.String())
+
+	p, err := parseNodeResources(n.String())
+	require.NoError(t, err)
+	assert.Equal(t, n, p)
+
+	n = n.add(&Resources{CPU: 0.5, Memory: 256, IOClass: IOClassHeavy}, -1)
+	assert.Equal(t, nodeResources{CPU: 1, Memory: 256}, n)
+
+	_, err = parseNodeResources("")
+	assert.Error(t, err)
+	_, err = parseNodeResources("cpu=many")
+	assert.Error(t, err)
+}
+
+func TestFitsMember(t *testing.T) {
+	member := func(capacity, reserved string) serf.Member {
+		return serf.Member{Tags: map[string]string{capacityTag: capacity, reservedTag: reserved}}
+	}
+	busy := member("cpu=4,memory=1024,heavy_io=0", "cpu=3,memory=512,heavy_io=1")
+
+	assert.True(t, fitsMember(busy, &Resources{CPU: 1, Memory: 512}))
+	assert.False(t, fitsMember(busy, &Resources{CPU: 2}))
+	assert.False(t, fitsMember(busy, &Resources{Memory: 1024}))
+	assert.False(t, fitsMember(busy, &Resources{IOClass: IOClassHeavy}))
+	assert.True(t, fitsMember(busy, &Resources{IOClass: IOClassNormal}))
+
+	// Zero capacities are unbounded
+	assert.True(t, fitsMember(member("cpu=0,memory=0,heavy_io=0", "cpu=64,memory=4096,heavy_io=0"), &Resources{CPU: 8, Memory: 1024}))
+
+	// Members not gossiping their resources always fit
+	assert.True(t, fitsMember(serf.Member{}, &Resources{CPU: 8, IOClass: IOClassHeavy}))
+
+	members := []serf.Member{busy, member("cpu=4,memory=1024,heavy_io=0", "cpu=0,memory=0,heavy_io=0")}
+	assert.Len(t, unsaturatedMembers(members, &Resources{CPU: 2}), 1)
+	assert.Len(t, unsaturatedMembers(members, nil), 2)
+}
+
+func TestResourcesValidate(t *testing.T) {
+	assert.NoError(t, (*Resources)(nil).validate())
+	assert.NoError(t, (&Resources{CPU: 0.5, Memory: 128, IOClass: IOClassHeavy}).validate())
+	assert.Error(t, (&Resources{CPU: -1}).validate())
+	assert.Error(t, (&Resources{IOClass: "extreme"}).validate())
+}
+
+func TestReserveResources(t *testing.T) {
+	dir, a := setupAPITest(t, "8124")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	assert.Equal(t, "cpu=0,memory=0,heavy_io=0", a.serf.LocalMember().Tags[reservedTag])
+	assert.Contains(t, a.serf.LocalMember().Tags, capacityTag)
+
+	release := a.reserveResources(&Resources{CPU: 2, Memory: 256, IOClass: IOClassHeavy})
+	assert.Equal(t, "cpu=2,memory=256,heavy_io=1", a.serf.LocalMember().Tags[reservedTag])
+
+	// The node can't run another heavy io job until released
+	job := &Job{Name: "heavy", Resources: &Resources{IOClass: IOClassHeavy}}
+	explanation, err := a.explainJob(job)
+	require.NoError(t, err)
+	assert.Contains(t, explanation.Nodes[0].Reasons, "saturated by the resources reserved by other executions")
+
+	release()
+	assert.Equal(t, "cpu=0,memory=0,heavy_io=0", a.serf.LocalMember().Tags[reservedTag])
+}
@@ -0,0 +1,169 @@
+package dkron
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/status"
+)
+
+// resultSpoolInterval is how often agents try to deliver spooled results.
+const resultSpoolInterval = 10 * time.Second
+
+// resultSpool is a bounded disk queue of the execution results an agent
+// couldn't deliver to the servers, delivered once they are reachable.
+type resultSpool struct {
+	sync.Mutex
+	dir string
+	max int
+	seq int
+}
+
+func newResultSpool(dir string, max int) (*resultSpool, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &resultSpool{dir: dir, max: max}, nil
+}
+
+// Push spools the execution, dropping the oldest ones over the limit.
+func (rs *resultSpool) Push(execution *types.Execution) error {
+	rs.Lock()
+	defer rs.Unlock()
+
+	b, err := proto.Marshal(execution)
+	if err != nil {
+		return err
+	}
+
+	// Names sort in spooling order
+	rs.seq++
+	name := fmt.Sprintf("%020d-%06d.pb", time.Now().UnixNano(), rs.seq%1000000)
+	if err := ioutil.WriteFile(filepath.Join(rs.dir, name), b, 0600); err != nil {
+		return err
+	}
+
+	files, err := rs.files()
+	if err != nil {
+		return err
+	}
+	for len(files) > rs.max {
+		log.WithField("file", files[0]).Warning("agent: Result spool full, dropping the oldest result")
+		if err := os.Remove(filepath.Join(rs.dir, files[0])); err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+	return nil
+}
+
+// Len returns the number of spooled results.
+func (rs *resultSpool) Len() int {
+	rs.Lock()
+	defer rs.Unlock()
+	files, _ := rs.files()
+	return len(files)
+}
+
+// Flush delivers the spooled results in order, stopping at the first one
+// that can't be delivered. Returns the number of results delivered.
+func (rs *resultSpool) Flush(deliver func(*Execution) error) (int, error) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	files, err := rs.files()
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, name := range files {
+		path := filepath.Join(rs.dir, name)
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return delivered, err
+		}
+
+		var pbex types.Execution
+		if err := proto.Unmarshal(b, &pbex); err != nil {
+			log.WithError(err).WithField("file", path).Error("agent: Dropping undecodable spooled result")
+		} else if err := deliver(NewExecutionFromProto(&pbex)); err != nil {
+			// The job is gone, there's nobody to deliver the result to
+			if status.Convert(err).Message() != ErrExecutionDoneForDeletedJob.Error() {
+				return delivered, err
+			}
+		} else {
+			delivered++
+		}
+
+		if err := os.Remove(path); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// files returns the names of the spooled results, oldest first.
+func (rs *resultSpool) files() ([]string, error) {
+	infos, err := ioutil.ReadDir(rs.dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, fi := range infos {
+		if strings.HasSuffix(fi.Name(), ".pb") {
+			files = append(files, fi.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// spoolResult keeps the result of an execution that couldn't be delivered.
+func (a *Agent) spoolResult(execution *types.Execution, cause error) error {
+	if a.resultSpool == nil {
+		return cause
+	}
+	log.WithError(cause).WithField("job", execution.JobName).
+		Warning("agent: Can not deliver the execution result, spooling it")
+	return a.resultSpool.Push(execution)
+}
+
+// deliverSpooledResults periodically delivers the spooled results.
+func (a *Agent) deliverSpooledResults() {
+	ticker := time.NewTicker(resultSpoolInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.flushResultSpool()
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
+
+func (a *Agent) flushResultSpool() {
+	n, err := a.resultSpool.Flush(func(ex *Execution) error {
+		rpcServer, err := a.checkAndSelectServer()
+		if err != nil {
+			return err
+		}
+		return a.GRPCClient.ExecutionDone(rpcServer, ex)
+	})
+	if n > 0 {
+		log.WithField("results", n).Info("agent: Delivered spooled execution results")
+	}
+	if err != nil {
+		log.WithError(err).WithField("pending", a.resultSpool.Len()).Debug("agent: Can not deliver spooled execution results yet")
+	}
+}
@@ -0,0 +1,54 @@
+package dkron
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-results")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rs, err := newResultSpool(dir, 3)
+	require.NoError(t, err)
+
+	for _, job := range []string{"job1", "job2", "job3", "job4"} {
+		require.NoError(t, rs.Push(&types.Execution{JobName: job, Success: true}))
+	}
+	// The oldest result was dropped
+	assert.Equal(t, 3, rs.Len())
+
+	// Delivery stops at the first failure and keeps the rest
+	var delivered []string
+	n, err := rs.Flush(func(ex *Execution) error {
+		if ex.JobName == "job3" {
+			return errors.New("unreachable")
+		}
+		delivered = append(delivered, ex.JobName)
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"job2"}, delivered)
+	assert.Equal(t, 2, rs.Len())
+
+	// Results of deleted jobs are dropped
+	n, err = rs.Flush(func(ex *Execution) error {
+		if ex.JobName == "job3" {
+			return ErrExecutionDoneForDeletedJob
+		}
+		delivered = append(delivered, ex.JobName)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"job2", "job4"}, delivered)
+	assert.Equal(t, 0, rs.Len())
+}
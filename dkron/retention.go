@@ -0,0 +1,177 @@
+package dkron
+
+import (
+	"strings"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// minExecutionTTL is the TTL of the executions already past the
+	// retention when written, so they're still readable by the write that
+	// stores them.
+	minExecutionTTL = time.Second
+
+	// executionRetentionKey is the key holding the execution retention of
+	// the cluster.
+	executionRetentionKey = "meta:execution_retention"
+)
+
+// SetExecutionRetention stores the execution retention of the cluster and
+// sets the TTL of the stored executions by it.
+func (s *Store) SetExecutionRetention(retention time.Duration) error {
+	b, err := proto.Marshal(&dkronpb.ExecutionRetention{RetentionSeconds: int64(retention.Seconds())})
+	if err != nil {
+		return err
+	}
+	err = s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(executionRetentionKey, string(b), nil)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.executionRetention = retention
+	n, err := s.expireExecutions(true)
+	if err != nil {
+		return err
+	}
+	log.WithField("retention", retention).WithField("executions", n).
+		Info("store: Set the execution retention")
+	return nil
+}
+
+// GetExecutionRetention returns the execution retention of the cluster,
+// zero when not set.
+func (s *Store) GetExecutionRetention() (time.Duration, error) {
+	var pbr dkronpb.ExecutionRetention
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		v, err := tx.Get(executionRetentionKey)
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return proto.Unmarshal([]byte(v), &pbr)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(pbr.RetentionSeconds) * time.Second, nil
+}
+
+// publishExecutionRetention sets the execution retention of the leader
+// config in the cluster when it differs from the stored one, so all the
+// servers expire the executions alike.
+func (a *Agent) publishExecutionRetention() error {
+	current, err := a.Store.GetExecutionRetention()
+	if err != nil {
+		return err
+	}
+	retention := a.config.ExecutionRetention.Truncate(time.Second)
+	if current == retention {
+		return nil
+	}
+
+	cmd, err := Encode(SetExecutionRetentionType, &dkronpb.ExecutionRetention{RetentionSeconds: int64(retention.Seconds())})
+	if err != nil {
+		return err
+	}
+	af := a.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return err
+	}
+	if err, ok := af.Response().(error); ok {
+		return err
+	}
+	log.WithField("retention", retention).Info("agent: Published execution retention")
+	return nil
+}
+
+// executionSetOptions returns the options to store the execution with, it
+// expires when it's older than the retention since it finished. Running
+// executions and the ones under legal hold don't expire.
+func (s *Store) executionSetOptions(tx *buntdb.Tx, pbe *dkronpb.Execution) *buntdb.SetOptions {
+	if s.executionRetention <= 0 || pbe.GetFinishedAt() == nil || heldTx(tx, pbe.JobName) {
+		return nil
+	}
+	finishedAt, err := ptypes.Timestamp(pbe.GetFinishedAt())
+	if err != nil || finishedAt.IsZero() {
+		return nil
+	}
+
+	ttl := s.executionRetention - time.Since(finishedAt)
+	if ttl < minExecutionTTL {
+		ttl = minExecutionTTL
+	}
+	return &buntdb.SetOptions{Expires: true, TTL: ttl}
+}
+
+// onExpired deletes the items expired by the store, dropping the cached
+// execution groups of the expired executions and recording their changes.
+func (s *Store) onExpired(key, value string, tx *buntdb.Tx) error {
+	if _, err := tx.Delete(key); err != nil && err != buntdb.ErrNotFound {
+		return err
+	}
+	if err := s.recordDeleteTx(tx, key, value, true); err != nil {
+		return err
+	}
+	if strings.HasPrefix(key, executionsPrefix+":") {
+		s.cache.invalidateExecutions(strings.SplitN(key, ":", 3)[1])
+	}
+	return nil
+}
+
+// expireExecutions sets the TTL of the stored executions that don't have
+// one, written before the retention was set, returning how many were set.
+// With all set, the executions having one are set again too, as when the
+// retention changes.
+func (s *Store) expireExecutions(all bool) (int, error) {
+	if s.executionRetention <= 0 && !all {
+		return 0, nil
+	}
+
+	updated := map[string]*buntdb.SetOptions{}
+	values := map[string]string{}
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(executionsPrefix+":*", func(key, value string) bool {
+			var ttl time.Duration
+			if ttl, err = tx.TTL(key); err != nil || (ttl >= 0 && !all) {
+				err = nil
+				return true
+			}
+
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				return false
+			}
+			// Executions that don't expire anymore are set without TTL
+			if opts := s.executionSetOptions(tx, &pbe); opts != nil || ttl >= 0 {
+				updated[key] = opts
+				values[key] = value
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		for k, opts := range updated {
+			if _, _, err := tx.Set(k, values[k], opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(updated), nil
+}
@@ -0,0 +1,73 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestStore_ExecutionRetention(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	now := time.Now()
+	execution := func(startedAt, finishedAt time.Time) string {
+		key, err := s.SetExecution(&Execution{
+			JobName:    "report",
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+			NodeName:   "node1",
+		})
+		require.NoError(t, err)
+		return key
+	}
+	ttl := func(key string) time.Duration {
+		var d time.Duration
+		require.NoError(t, s.db.View(func(tx *buntdb.Tx) error {
+			d, err = tx.TTL(key)
+			return err
+		}))
+		return d
+	}
+
+	// Executions stored before setting the retention
+	before := execution(now.Add(-2*time.Minute), now.Add(-time.Minute))
+	assert.Equal(t, time.Duration(-1), ttl(before))
+
+	s.executionRetention = time.Hour
+	n, err := s.expireExecutions(false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.InDelta(t, float64(59*time.Minute), float64(ttl(before)), float64(time.Second))
+
+	// Setting the retention of the cluster sets the TTL of all of them
+	require.NoError(t, s.SetExecutionRetention(2*time.Hour))
+	retention, err := s.GetExecutionRetention()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, retention)
+	assert.InDelta(t, float64(119*time.Minute), float64(ttl(before)), float64(time.Second))
+	require.NoError(t, s.SetExecutionRetention(0))
+	assert.Equal(t, time.Duration(-1), ttl(before))
+	require.NoError(t, s.SetExecutionRetention(time.Hour))
+
+	running := execution(now, time.Time{})
+	assert.Equal(t, time.Duration(-1), ttl(running))
+
+	old := execution(now.Add(-3*time.Hour), now.Add(-2*time.Hour))
+	executions, err := s.GetExecutions("report")
+	require.NoError(t, err)
+	assert.Len(t, executions, 3)
+
+	// Expired by the store in the background
+	time.Sleep(2500 * time.Millisecond)
+	executions, err = s.GetExecutions("report")
+	require.NoError(t, err)
+	assert.Len(t, executions, 2)
+	for _, ex := range executions {
+		assert.NotEqual(t, old, executionsPrefix+":report:"+ex.Key())
+	}
+}
@@ -3,37 +3,96 @@ package dkron
 import (
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/distribworks/dkron/v3/extcron"
 	"github.com/hashicorp/serf/serf"
 	"github.com/sirupsen/logrus"
 )
 
 // Run call the agents to run a job. Returns a job with it's new status and next schedule.
 func (a *Agent) Run(jobName string, ex *Execution) (*Job, error) {
+	// Running executions finish while paused, new ones aren't dispatched
+	if err := a.checkSchedulerPaused(); err != nil {
+		return nil, err
+	}
+
+	a.overload.dispatchStarted()
+	defer a.overload.dispatchDone()
+
 	job, err := a.Store.GetJob(jobName, nil)
 	if err != nil {
 		return nil, fmt.Errorf("agent: Run error retrieving job: %s from store: %w", jobName, err)
 	}
 
+	// The new spec of the job replaces the current one when its canary ends
+	promoted := false
+	if job.Canary != nil && !job.Canary.EndsAt.After(time.Now()) {
+		log.WithFields(logrus.Fields{
+			"job":    jobName,
+			"stable": job.Canary.Stable,
+			"canary": job.Canary.Canary,
+		}).Info("agent: Promoting canary of job")
+		job.promoteCanary()
+		// The promoted spec is a new version of the job
+		job.UpdatedAt.Set(a.changeTime(jobName))
+		promoted = true
+	}
+
 	// In case the job is not a child job, compute the next execution time
 	if job.ParentJob == "" {
 		if e, ok := a.sched.GetEntry(jobName); ok {
 			job.Next = e.Next
-			if err := a.applySetJob(job.ToProto()); err != nil {
+			start := time.Now()
+			err := a.applySetJob(job.ToProto())
+			a.overload.observeWrite(time.Since(start))
+			if err != nil {
 				return nil, fmt.Errorf("agent: Run error storing job %s before running: %w", jobName, err)
 			}
 		} else {
 			return nil, fmt.Errorf("agent: Run error retrieving job: %s from scheduler", jobName)
 		}
+	} else if promoted {
+		if err := a.applySetJob(job.ToProto()); err != nil {
+			return nil, fmt.Errorf("agent: Run error storing job %s before running: %w", jobName, err)
+		}
+	}
+	if promoted {
+		if stored, err := a.Store.GetJob(jobName, nil); err == nil {
+			job.Version = stored.Version
+		}
 	}
+	ex.JobVersion = job.Version
 
+	// Runs of jobs with a matrix run once for every item, discovering
+	// them first if the matrix has a discovery step
+	matrixRun := job.Matrix != nil && ex.MatrixItem == "" && ex.Attempt <= 1
+	switch {
+	case ex.Discovery || (matrixRun && job.Matrix.Discovery != nil):
+		ex.Discovery = true
+		err = a.runDiscovery(job, ex)
+	case matrixRun:
+		err = a.runMatrix(job, ex)
+	default:
+		err = a.runExecution(job, ex)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// runExecution dispatches the execution to the target nodes of the job,
+// or to the node of the execution when retrying it.
+func (a *Agent) runExecution(job *Job, ex *Execution) error {
 	// In the first execution attempt we build and filter the target nodes
 	// but we use the existing node target in case of retry.
 	var filterMap map[string]string
 	if ex.Attempt <= 1 {
+		var err error
 		filterMap, _, err = a.processFilteredNodes(job)
 		if err != nil {
-			return nil, fmt.Errorf("run error processing filtered nodes: %w", err)
+			return fmt.Errorf("run error processing filtered nodes: %w", err)
 		}
 	} else {
 		// In case of retrying, find the rpc address of the node or return with an error
@@ -43,40 +102,131 @@ func (a *Agent) Run(jobName string, ex *Execution) (*Job, error) {
 				if m.Status == serf.StatusAlive {
 					addr = m.Tags["rpc_addr"]
 				} else {
-					return nil, fmt.Errorf("retry node is gone: %s for job %s", ex.NodeName, ex.JobName)
+					return fmt.Errorf("retry node is gone: %s for job %s", ex.NodeName, ex.JobName)
 				}
 			}
 		}
 		filterMap = map[string]string{ex.NodeName: addr}
 	}
 
+	// The discovery step of a matrix runs on one of the target nodes
+	if ex.Discovery {
+		for name, addr := range filterMap {
+			filterMap = map[string]string{name: addr}
+			break
+		}
+	}
+
 	// In case no nodes found, return reporting the error
 	if len(filterMap) < 1 {
-		return nil, fmt.Errorf("no target nodes found to run job %s", ex.JobName)
+		if ex.Attempt <= 1 {
+			if err := a.checkMaintenance(job, ex); err != nil {
+				return err
+			}
+			if err := a.checkCordoned(job); err != nil {
+				return err
+			}
+		}
+		return fmt.Errorf("no target nodes found to run job %s", ex.JobName)
 	}
 	log.WithField("nodes", filterMap).Debug("agent: Filtered nodes to run")
 
+	return a.dispatchExecution(job, ex, filterMap)
+}
+
+// dispatchExecution calls the nodes to run the execution, journaling its
+// first attempt.
+func (a *Agent) dispatchExecution(job *Job, ex *Execution, filterMap map[string]string) error {
+	// Journal the first attempt so a new leader can replay it if this
+	// one crashes before dispatching it
+	if ex.Attempt <= 1 {
+		if err := a.journalDispatch(ex, filterMap); err != nil {
+			return fmt.Errorf("agent: Run error journaling dispatch of job %s: %w", job.Name, err)
+		}
+		defer a.completeDispatch(ex)
+	}
+
+	if a.config.ScheduleSimulate {
+		a.simulateDispatch(ex, filterMap)
+	} else {
+		a.dispatch(job, ex, filterMap, ex.Attempt <= 1)
+	}
+	return nil
+}
+
+// simulatedOutput is the output of the runs of a cluster simulating its
+// schedules.
+const simulatedOutput = "simulated run, the job wasn't executed"
+
+// simulateDispatch records the execution as a successful run in every node
+// without calling them, dependent jobs and notifications follow.
+func (a *Agent) simulateDispatch(ex *Execution, nodes map[string]string) {
+	addr := a.raft.Leader()
+	for node := range nodes {
+		sim := *ex
+		sim.NodeName = node
+		sim.StartedAt = time.Now()
+		sim.FinishedAt = sim.StartedAt
+		sim.Success = true
+		sim.Output = simulatedOutput
+
+		log.WithFields(logrus.Fields{
+			"job_name":   ex.JobName,
+			"node":       node,
+			"request_id": ex.RequestID,
+		}).Info("agent: Simulating run")
+
+		if err := a.GRPCClient.ExecutionDone(string(addr), &sim); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"job_name": ex.JobName,
+				"node":     node,
+			}).Error("agent: Error recording simulated run")
+		}
+	}
+}
+
+// dispatch calls the nodes to run the execution and waits for them. The
+// nodes of a journaled execution are marked in its intent before they're
+// called.
+func (a *Agent) dispatch(job *Job, ex *Execution, nodes map[string]string, journaled bool) {
 	var wg sync.WaitGroup
-	for _, v := range filterMap {
+	for name, v := range nodes {
 		// Call here client GRPC AgentRun
 		wg.Add(1)
-		go func(node string, wg *sync.WaitGroup) {
+		go func(name, node string, wg *sync.WaitGroup) {
 			defer wg.Done()
 			log.WithFields(logrus.Fields{
-				"job_name": job.Name,
-				"node":     node,
+				"job_name":   job.Name,
+				"node":       node,
+				"request_id": ex.RequestID,
 			}).Info("agent: Calling AgentRun")
 
-			err := a.GRPCClient.AgentRun(node, job.ToProto(), ex.ToProto())
+			// Nodes the dispatch can't be journaled for are left to the next leader
+			if journaled {
+				if err := a.markDispatched(ex, name); err != nil {
+					log.WithError(err).WithFields(logrus.Fields{
+						"job_name": job.Name,
+						"node":     node,
+					}).Error("agent: Error journaling dispatch")
+					return
+				}
+			}
+
+			// Runs routed to the canary of the job use its new spec
+			job, ex := job.canaryRun(name, ex)
+
+			// Agents may not know the schedule macros of the cluster
+			pbj := job.ToProto()
+			pbj.Schedule = extcron.Expand(pbj.Schedule)
+			err := a.GRPCClient.AgentRun(node, pbj, ex.ToProto())
 			if err != nil {
-				log.WithFields(logrus.Fields{
+				log.WithError(err).WithFields(logrus.Fields{
 					"job_name": job.Name,
 					"node":     node,
 				}).Error("agent: Error calling AgentRun")
 			}
-		}(v, &wg)
+		}(name, v, &wg)
 	}
 
 	wg.Wait()
-	return job, nil
 }
@@ -0,0 +1,34 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentSimulateDispatch(t *testing.T) {
+	dir, a := setupAPITest(t, "8130")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{
+		Name:     "sync",
+		Schedule: "@every 1h",
+		Executor: "shell",
+	}
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	ex := NewExecution(job.Name)
+	ex.Attempt = 1
+	a.simulateDispatch(ex, map[string]string{"test": a.bindRPCAddr()})
+
+	executions, err := a.Store.GetExecutions(job.Name)
+	require.NoError(t, err)
+	require.Len(t, executions, 1)
+	assert.True(t, executions[0].Success)
+	assert.Equal(t, "test", executions[0].NodeName)
+	assert.Equal(t, simulatedOutput, executions[0].Output)
+	assert.NotEmpty(t, executions[0].Id)
+}
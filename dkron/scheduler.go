@@ -3,8 +3,8 @@ package dkron
 import (
 	"errors"
 	"expvar"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/distribworks/dkron/v3/extcron"
@@ -26,6 +26,8 @@ type Scheduler struct {
 	Cron        *cron.Cron
 	Started     bool
 	EntryJobMap sync.Map //map[string]cron.EntryID
+	// Offset shifts the schedules of the jobs.
+	Offset time.Duration
 }
 
 // NewScheduler creates a new Scheduler instance
@@ -90,8 +92,8 @@ func (s *Scheduler) ClearCron() {
 // the current time, and whether or not the entry was found.
 func (s *Scheduler) GetEntry(jobName string) (cron.Entry, bool) {
 	for _, e := range s.Cron.Entries() {
-		j, _ := e.Job.(*Job)
-		if j.Name == jobName {
+		// System jobs like the activity digest are not dkron jobs
+		if j, ok := e.Job.(*Job); ok && j.Name == jobName {
 			return e, true
 		}
 	}
@@ -116,21 +118,11 @@ func (s *Scheduler) AddJob(job *Job) error {
 	cronInspect.Set(job.Name, job)
 	metrics.EmitKey([]string{"scheduler", "job/update", "add", job.Name}, 1)
 
-	// If Timezone is set on the job, and not explicitly in its schedule,
-	// AND its not a descriptor (that don't support timezones), add the
-	// timezone to the schedule so robfig/cron knows about it.
-	schedule := job.Schedule
-	if job.Timezone != "" &&
-		!strings.HasPrefix(schedule, "@") &&
-		!strings.HasPrefix(schedule, "TZ=") &&
-		!strings.HasPrefix(schedule, "CRON_TZ=") {
-		schedule = "CRON_TZ=" + job.Timezone + " " + schedule
-	}
-
-	id, err := s.Cron.AddJob(schedule, job)
+	schedule, err := extcron.Parse(job.cronSchedule())
 	if err != nil {
 		return err
 	}
+	id := s.Cron.Schedule(shiftSchedule(schedule, s.Offset), job)
 	s.EntryJobMap.Store(job.Name, id)
 
 	return nil
@@ -146,3 +138,47 @@ func (s *Scheduler) RemoveJob(job *Job) {
 		s.EntryJobMap.Delete(job.Name)
 	}
 }
+
+// offsetSchedule shifts the times of a schedule by a fixed offset.
+type offsetSchedule struct {
+	cron.Schedule
+	offset time.Duration
+}
+
+// Next returns the next time of the schedule shifted by the offset.
+func (s offsetSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t.Add(-s.offset))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(s.offset)
+}
+
+// shiftSchedule returns the schedule shifted by the offset.
+func shiftSchedule(s cron.Schedule, offset time.Duration) cron.Schedule {
+	if offset == 0 {
+		return s
+	}
+	return offsetSchedule{Schedule: s, offset: offset}
+}
+
+// prevScheduleTime returns the last time before t the schedule fired at,
+// or the zero time if it didn't fire in the previous year.
+func prevScheduleTime(s cron.Schedule, t time.Time) time.Time {
+	// Interval schedules fire relative to when they were scheduled
+	if cds, ok := s.(cron.ConstantDelaySchedule); ok {
+		return t.Add(-cds.Delay)
+	}
+
+	for lookback := time.Minute; lookback <= 366*24*time.Hour; lookback *= 2 {
+		prev := s.Next(t.Add(-lookback))
+		if prev.IsZero() || !prev.Before(t) {
+			continue
+		}
+		for next := s.Next(prev); !next.IsZero() && next.Before(t); next = s.Next(prev) {
+			prev = next
+		}
+		return prev
+	}
+	return time.Time{}
+}
@@ -0,0 +1,274 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// schedulerPauseKey is the key holding the event that paused the
+	// scheduler, while it's paused.
+	schedulerPauseKey = "meta:scheduler_pause"
+	// schedulerEventPrefix is the prefix of the audit records of the
+	// pauses and resumes of the scheduler.
+	schedulerEventPrefix = "scheduler"
+)
+
+// Actions on the scheduler of the cluster.
+const (
+	// SchedulerPause stops the dispatch of jobs in the whole cluster.
+	SchedulerPause = "pause"
+	// SchedulerResume dispatches jobs again.
+	SchedulerResume = "resume"
+)
+
+var (
+	// ErrSchedulerPaused is returned when running a job while the
+	// scheduler is paused, or pausing it twice.
+	ErrSchedulerPaused = errors.New("the scheduler is paused, jobs are not dispatched")
+	// ErrSchedulerNotPaused is returned when resuming the scheduler while
+	// it isn't paused.
+	ErrSchedulerNotPaused = errors.New("the scheduler is not paused")
+	// ErrSchedulerEvent is returned when a scheduler action is missing its
+	// id, or is unknown.
+	ErrSchedulerEvent = errors.New("invalid scheduler action")
+)
+
+// SchedulerEvent is the audit record of a pause or resume of the
+// scheduler of the cluster. Records are kept forever.
+type SchedulerEvent struct {
+	ID     string    `json:"id"`
+	Action string    `json:"action"`
+	User   string    `json:"user"`
+	Reason string    `json:"reason,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// NewSchedulerEventFromProto returns the scheduler event of the proto.
+func NewSchedulerEventFromProto(in *dkronpb.SchedulerEvent) *SchedulerEvent {
+	at, _ := ptypes.Timestamp(in.At)
+	return &SchedulerEvent{
+		ID:     in.Id,
+		Action: in.Action,
+		User:   in.User,
+		Reason: in.Reason,
+		At:     at,
+	}
+}
+
+// ToProto returns the protobuf struct of the scheduler event.
+func (e *SchedulerEvent) ToProto() *dkronpb.SchedulerEvent {
+	at, _ := ptypes.TimestampProto(e.At)
+	return &dkronpb.SchedulerEvent{
+		Id:     e.ID,
+		Action: e.Action,
+		User:   e.User,
+		Reason: e.Reason,
+		At:     at,
+	}
+}
+
+// Validate checks the scheduler event.
+func (e *SchedulerEvent) Validate() error {
+	switch e.Action {
+	case SchedulerPause, SchedulerResume:
+	default:
+		return fmt.Errorf("%s: unknown action %q", ErrSchedulerEvent, e.Action)
+	}
+	if e.ID == "" {
+		return fmt.Errorf("%s: missing id", ErrSchedulerEvent)
+	}
+	return nil
+}
+
+// ApplySchedulerEvent pauses or resumes the scheduler and records it, in
+// a single transaction. The pause is stored with the jobs so it holds on
+// every server after a leader election.
+func (s *Store) ApplySchedulerEvent(event *SchedulerEvent) (*SchedulerEvent, error) {
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	b, err := proto.Marshal(event.ToProto())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Get(schedulerPauseKey)
+		paused := err == nil
+		switch event.Action {
+		case SchedulerPause:
+			if paused {
+				return ErrSchedulerPaused
+			}
+			if _, _, err := tx.Set(schedulerPauseKey, string(b), nil); err != nil {
+				return err
+			}
+		case SchedulerResume:
+			if !paused {
+				return ErrSchedulerNotPaused
+			}
+			if _, err := tx.Delete(schedulerPauseKey); err != nil {
+				return err
+			}
+		}
+
+		_, _, err = tx.Set(fmt.Sprintf("%s:%s", schedulerEventPrefix, event.ID), string(b), nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// SchedulerPause returns the event that paused the scheduler, nil when it
+// isn't paused.
+func (s *Store) SchedulerPause() (*SchedulerEvent, error) {
+	var event *SchedulerEvent
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(schedulerPauseKey)
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var pbe dkronpb.SchedulerEvent
+		if err := proto.Unmarshal([]byte(value), &pbe); err != nil {
+			return err
+		}
+		event = NewSchedulerEventFromProto(&pbe)
+		return nil
+	})
+	return event, err
+}
+
+// GetSchedulerEvents returns the pauses and resumes of the scheduler, in
+// the order they happened.
+func (s *Store) GetSchedulerEvents() ([]*SchedulerEvent, error) {
+	events := []*SchedulerEvent{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(schedulerEventPrefix+":*", func(key, value string) bool {
+			var pbe dkronpb.SchedulerEvent
+			if err = proto.Unmarshal([]byte(value), &pbe); err != nil {
+				return false
+			}
+			events = append(events, NewSchedulerEventFromProto(&pbe))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// checkSchedulerPaused returns ErrSchedulerPaused while the scheduler of
+// the cluster is paused.
+func (a *Agent) checkSchedulerPaused() error {
+	pause, err := a.Store.SchedulerPause()
+	if err != nil {
+		return err
+	}
+	if pause != nil {
+		metrics.IncrCounter([]string{"scheduler", "paused_dispatch"}, 1)
+		return ErrSchedulerPaused
+	}
+	return nil
+}
+
+// schedulerStatus is the state of the scheduler of the cluster.
+type schedulerStatus struct {
+	Paused bool            `json:"paused"`
+	Pause  *SchedulerEvent `json:"pause,omitempty"`
+
+	// RampUp is the release of the missed runs in progress, only known
+	// by the leader.
+	RampUp *RampUpStatus `json:"ramp_up,omitempty"`
+}
+
+func (h *HTTPTransport) schedulerHandler(c *gin.Context) {
+	pause, err := h.agent.Store.SchedulerPause()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, &schedulerStatus{
+		Paused: pause != nil,
+		Pause:  pause,
+		RampUp: h.agent.rampUp.status(h.agent.config.RampUpRate),
+	})
+}
+
+func (h *HTTPTransport) schedulerEventsHandler(c *gin.Context) {
+	events, err := h.agent.Store.GetSchedulerEvents()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, events)
+}
+
+// schedulerRequest is the body of the scheduler actions.
+type schedulerRequest struct {
+	Reason string `json:"reason"`
+}
+
+// schedulerActionHandler pauses or resumes the scheduler of the cluster,
+// recording who requested it and why.
+func (h *HTTPTransport) schedulerActionHandler(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req schedulerRequest
+		if c.Request.ContentLength != 0 {
+			if err := c.BindJSON(&req); err != nil {
+				c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+				return
+			}
+		}
+
+		now := time.Now()
+		event := &SchedulerEvent{
+			ID:     newULID(now),
+			Action: action,
+			User:   requestUser(c),
+			Reason: req.Reason,
+			At:     now,
+		}
+
+		// Call gRPC SetSchedulerPause
+		event, err := h.agent.GRPCClient.SetSchedulerPause(event)
+		if err != nil {
+			msg := status.Convert(err).Message()
+			switch msg {
+			case ErrSchedulerPaused.Error(), ErrSchedulerNotPaused.Error():
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": msg})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": msg})
+			}
+			return
+		}
+
+		log.WithFields(logrus.Fields{
+			"action": event.Action,
+			"user":   event.User,
+			"reason": event.Reason,
+		}).Warn("api: Scheduler of the cluster changed")
+		renderJSON(c, http.StatusOK, event)
+	}
+}
@@ -0,0 +1,117 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_ApplySchedulerEvent(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	pause, err := s.SchedulerPause()
+	require.NoError(t, err)
+	assert.Nil(t, pause)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	_, err = s.ApplySchedulerEvent(&SchedulerEvent{ID: newULID(now), Action: SchedulerResume, User: "ops", At: now})
+	assert.Equal(t, ErrSchedulerNotPaused, err)
+
+	event := &SchedulerEvent{ID: newULID(now), Action: SchedulerPause, User: "ops", Reason: "bad deploy", At: now}
+	_, err = s.ApplySchedulerEvent(event)
+	require.NoError(t, err)
+	_, err = s.ApplySchedulerEvent(&SchedulerEvent{ID: newULID(now), Action: SchedulerPause, At: now})
+	assert.Equal(t, ErrSchedulerPaused, err)
+
+	pause, err = s.SchedulerPause()
+	require.NoError(t, err)
+	assert.Equal(t, event, pause)
+
+	a := &Agent{Store: s}
+	_, err = a.Run("any", NewExecution("any"))
+	assert.Equal(t, ErrSchedulerPaused, err)
+
+	later := now.Add(time.Minute)
+	_, err = s.ApplySchedulerEvent(&SchedulerEvent{ID: newULID(later), Action: SchedulerResume, User: "ops", At: later})
+	require.NoError(t, err)
+	assert.NoError(t, a.checkSchedulerPaused())
+
+	events, err := s.GetSchedulerEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, SchedulerPause, events[0].Action)
+	assert.Equal(t, SchedulerResume, events[1].Action)
+
+	_, err = s.ApplySchedulerEvent(&SchedulerEvent{ID: newULID(later), Action: "stop"})
+	assert.Error(t, err)
+}
+
+func TestAPISchedulerPause(t *testing.T) {
+	port := "8145"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+	a.config.AdminToken = "s3cret"
+
+	do := func(method, url, body string, admin bool) int {
+		req, err := http.NewRequest(method, url, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if admin {
+			req.Header.Set(adminTokenHeader, "s3cret")
+		}
+		req.Header.Set(userHeader, "ops")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	index := func() map[string]map[string]string {
+		resp, err := http.Get(baseURL + "/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var stats map[string]map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+		return stats
+	}
+
+	job := `{
+		"name": "test_job",
+		"schedule": "@every 1m",
+		"executor": "shell",
+		"executor_config": {"command": "date"},
+		"disabled": true
+	}`
+	require.Equal(t, http.StatusCreated, do(http.MethodPost, baseURL+"/jobs", job, false))
+	assert.Equal(t, "false", index()["scheduler"]["paused"])
+
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, baseURL+"/scheduler/pause", "", false))
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, baseURL+"/scheduler/pause", `{"reason": "incident"}`, true))
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, baseURL+"/scheduler/pause", "", true))
+
+	stats := index()
+	assert.Equal(t, "true", stats["scheduler"]["paused"])
+	assert.Equal(t, "ops", stats["scheduler"]["paused_by"])
+	assert.Equal(t, "incident", stats["scheduler"]["reason"])
+
+	// Jobs can't be run while paused
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, baseURL+"/jobs/test_job", "", false))
+
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, baseURL+"/scheduler/resume", "", true))
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, baseURL+"/scheduler/resume", "", true))
+	assert.Equal(t, "false", index()["scheduler"]["paused"])
+
+	events, err := a.Store.GetSchedulerEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "ops", events[1].User)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/distribworks/dkron/v3/extcron"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,3 +64,47 @@ func TestTimezoneAwareJob(t *testing.T) {
 	assert.Len(t, sched.Cron.Entries(), 1)
 	sched.Stop()
 }
+
+func TestPrevScheduleTime(t *testing.T) {
+	at := time.Date(2020, 5, 15, 2, 0, 0, 0, time.UTC)
+
+	s, err := extcron.Parse("0 0 2 * * *")
+	assert.NoError(t, err)
+	assert.Equal(t, at.Add(-24*time.Hour), prevScheduleTime(s, at))
+	// Delayed runs still get the fire time before the scheduled one
+	assert.Equal(t, at, prevScheduleTime(s, at.Add(time.Hour)))
+
+	s, err = extcron.Parse("0 */15 * * * *")
+	assert.NoError(t, err)
+	assert.Equal(t, at.Add(-15*time.Minute), prevScheduleTime(s, at))
+
+	s, err = extcron.Parse("@every 1h")
+	assert.NoError(t, err)
+	assert.Equal(t, at.Add(-time.Hour), prevScheduleTime(s, at))
+
+	s, err = extcron.Parse("@at 2020-05-15T02:00:00Z")
+	assert.NoError(t, err)
+	assert.True(t, prevScheduleTime(s, at).IsZero())
+}
+
+func TestShiftSchedule(t *testing.T) {
+	now := time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	s, err := extcron.Parse("0 30 2 * * *")
+	assert.NoError(t, err)
+	assert.Equal(t, s, shiftSchedule(s, 0))
+	assert.Equal(t, now.Add(8*time.Hour+30*time.Minute), shiftSchedule(s, 6*time.Hour).Next(now))
+
+	manual, err := extcron.Parse("@manually")
+	assert.NoError(t, err)
+	assert.True(t, shiftSchedule(manual, 6*time.Hour).Next(now).IsZero())
+
+	sched := NewScheduler()
+	sched.Offset = time.Hour
+	sched.Start([]*Job{{Name: "nightly", Schedule: "0 30 2 * * *"}}, &Agent{})
+	defer sched.Stop()
+	entry, ok := sched.GetEntry("nightly")
+	assert.True(t, ok)
+	assert.Equal(t, 3, entry.Next.Hour())
+	assert.Equal(t, 30, entry.Next.Minute())
+}
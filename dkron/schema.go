@@ -0,0 +1,185 @@
+package dkron
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	pb "github.com/golang/protobuf/proto"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// schemaVersionKey is the key holding the version of the data layout
+	// of the store.
+	schemaVersionKey = "meta:schema_version"
+)
+
+// schemaMigration is an upgrade step of the store data layout. Steps must be
+// idempotent as they can be run again on data partially upgraded.
+type schemaMigration struct {
+	description string
+	up          func(tx *buntdb.Tx) error
+}
+
+// schemaMigrations are the upgrade steps of the store, the step at index i
+// upgrades the store from version i to version i+1. New steps must be
+// appended, never reordered or removed.
+var schemaMigrations = []schemaMigration{
+	{
+		description: "initial versioned schema",
+		up:          func(tx *buntdb.Tx) error { return nil },
+	},
+	{
+		description: "convert v1 and v2 JSON records to protobuf",
+		up:          upgradeLegacyRecords,
+	},
+}
+
+// SchemaVersion is the store schema version supported by this build.
+var SchemaVersion = len(schemaMigrations)
+
+// ErrSchemaTooNew is returned when the store data was written by a newer
+// version of dkron with an unknown layout.
+type ErrSchemaTooNew struct {
+	Version int
+}
+
+func (e ErrSchemaTooNew) Error() string {
+	return fmt.Sprintf("store: schema version %d is newer than the supported version %d, refusing to downgrade", e.Version, SchemaVersion)
+}
+
+// schemaVersionTx returns the schema version of the stored data, stores
+// without version are considered version 0.
+func schemaVersionTx(tx *buntdb.Tx) (int, error) {
+	v, err := tx.Get(schemaVersionKey)
+	if err == buntdb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(v)
+}
+
+// checkSnapshotSchema returns ErrSchemaTooNew when the snapshot was written
+// with a newer schema, reading its version from a scratch store.
+func checkSnapshotSchema(data []byte) error {
+	db, err := buntdb.Open(":memory:")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := db.Load(bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	var version int
+	err = db.View(func(tx *buntdb.Tx) error {
+		var err error
+		version, err = schemaVersionTx(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion {
+		return ErrSchemaTooNew{Version: version}
+	}
+	return nil
+}
+
+// SchemaVersion returns the schema version of the stored data.
+func (s *Store) SchemaVersion() (int, error) {
+	var version int
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		version, err = schemaVersionTx(tx)
+		return err
+	})
+	return version, err
+}
+
+// upgradeLegacyRecords rewrites the jobs and executions stored as JSON by
+// dkron v1 and v2 in the current protobuf format.
+func upgradeLegacyRecords(tx *buntdb.Tx) error {
+	updated := make(map[string]string)
+
+	var err error
+	tx.Ascend("", func(key, value string) bool {
+		if len(value) == 0 || value[0] != '{' {
+			return true
+		}
+
+		var m pb.Message
+		switch {
+		case strings.HasPrefix(key, jobsPrefix+":"):
+			var pbj dkronpb.Job
+			err = decodeJob([]byte(value), &pbj)
+			m = &pbj
+		case strings.HasPrefix(key, executionsPrefix+":"):
+			var pbe dkronpb.Execution
+			err = decodeExecution([]byte(value), &pbe)
+			m = &pbe
+		default:
+			return true
+		}
+		if err != nil {
+			err = fmt.Errorf("key %s: %s", key, err)
+			return false
+		}
+
+		b, merr := pb.Marshal(m)
+		if merr != nil {
+			err = merr
+			return false
+		}
+		updated[key] = string(b)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for k, v := range updated {
+		if _, _, err := tx.Set(k, v, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upgrade runs the pending schema migrations, every step is run and
+// recorded in its own transaction so an interrupted upgrade resumes
+// from the last completed step.
+func (s *Store) upgrade() error {
+	version, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version > SchemaVersion {
+		return ErrSchemaTooNew{Version: version}
+	}
+
+	for v := version; v < SchemaVersion; v++ {
+		m := schemaMigrations[v]
+		log.WithField("version", v+1).WithField("migration", m.description).
+			Info("store: Upgrading schema")
+
+		err := s.db.Update(func(tx *buntdb.Tx) error {
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			s.cache.reset()
+			_, _, err := tx.Set(schemaVersionKey, strconv.Itoa(v+1), nil)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("store: error upgrading schema to version %d: %s", v+1, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package dkron
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+)
+
+var (
+	// ErrEmptyQuery is returned when searching jobs without a query.
+	ErrEmptyQuery = errors.New("search: empty query")
+	// ErrUnknownSearchField is returned when a query term uses a non searchable field.
+	ErrUnknownSearchField = errors.New("search: unknown field, use name, displayname, executor, command, owner or metadata")
+)
+
+// searchFields are the fields that can be used in field queries.
+var searchFields = map[string]bool{
+	"name":        true,
+	"displayname": true,
+	"executor":    true,
+	"command":     true,
+	"owner":       true,
+	"metadata":    true,
+}
+
+// jobIndex is an in-memory index of the searchable fields of every job,
+// it's kept up to date by the store on every job change.
+type jobIndex struct {
+	sync.RWMutex
+	docs map[string]map[string][]string
+}
+
+func newJobIndex() *jobIndex {
+	return &jobIndex{
+		docs: make(map[string]map[string][]string),
+	}
+}
+
+// update indexes the given job replacing any previous version of it.
+func (i *jobIndex) update(pbj *dkronpb.Job) {
+	doc := jobDoc(pbj)
+
+	i.Lock()
+	i.docs[pbj.Name] = doc
+	i.Unlock()
+}
+
+// jobDoc returns the searchable fields of the job.
+func jobDoc(pbj *dkronpb.Job) map[string][]string {
+	doc := map[string][]string{
+		"name":        {pbj.Name},
+		"displayname": {pbj.Displayname},
+		"executor":    {pbj.Executor},
+		"owner":       {pbj.Owner, pbj.OwnerEmail, pbj.OwnerTeam, pbj.OwnerEscalationChannel},
+	}
+	for _, v := range pbj.ExecutorConfig {
+		doc["command"] = append(doc["command"], v)
+	}
+	for k, v := range pbj.Metadata {
+		doc["metadata"] = append(doc["metadata"], k+"="+v)
+		doc["metadata."+k] = []string{v}
+	}
+	return doc
+}
+
+// remove deletes the given job from the index.
+func (i *jobIndex) remove(name string) {
+	i.Lock()
+	delete(i.docs, name)
+	i.Unlock()
+}
+
+// reset empties the index.
+func (i *jobIndex) reset() {
+	i.Lock()
+	i.docs = make(map[string]map[string][]string)
+	i.Unlock()
+}
+
+type searchTerm struct {
+	field   string
+	matcher func(string) bool
+}
+
+// parseQuery splits the query in whitespace separated terms. A term can be
+// in the form field:value to match only that field, metadata.key:value to
+// match a metadata key or plain text to match any field. Values are matched
+// as case insensitive substrings or as regular expressions if regex is set.
+func parseQuery(q string, regex bool) ([]searchTerm, error) {
+	var terms []searchTerm
+	for _, t := range strings.Fields(q) {
+		term := searchTerm{}
+		value := t
+		if kv := strings.SplitN(t, ":", 2); len(kv) == 2 {
+			field := strings.ToLower(kv[0])
+			if !searchFields[field] && !strings.HasPrefix(field, "metadata.") {
+				return nil, ErrUnknownSearchField
+			}
+			term.field = field
+			value = kv[1]
+		}
+
+		if regex {
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, err
+			}
+			term.matcher = re.MatchString
+		} else {
+			value = strings.ToLower(value)
+			term.matcher = func(s string) bool {
+				return strings.Contains(strings.ToLower(s), value)
+			}
+		}
+		terms = append(terms, term)
+	}
+
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	return terms, nil
+}
+
+// search returns the sorted names of the jobs matching all the terms.
+func (i *jobIndex) search(terms []searchTerm) []string {
+	i.RLock()
+	defer i.RUnlock()
+
+	var names []string
+	for name, doc := range i.docs {
+		if docMatches(doc, terms) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func docMatches(doc map[string][]string, terms []searchTerm) bool {
+	for _, t := range terms {
+		matched := false
+		for field, values := range doc {
+			// Metadata keys are only matched explicitly
+			if t.field == "" && strings.HasPrefix(field, "metadata.") {
+				continue
+			}
+			if t.field != "" && t.field != field {
+				continue
+			}
+			for _, v := range values {
+				if v != "" && t.matcher(v) {
+					matched = true
+					break
+				}
+			}
+			if matched {
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
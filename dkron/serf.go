@@ -110,7 +110,25 @@ func (a *Agent) maybeBootstrap() {
 		return
 	}
 
-	// TODO: Query each of the servers and make sure they report no Raft peers.
+	// Query each of the servers and make sure they report no Raft peers,
+	// a server replacing another one in an existing cluster, like in
+	// autoscaling groups, must join it instead of bootstrapping a new one.
+	for _, server := range servers {
+		if server.Name == a.config.NodeName {
+			continue
+		}
+		peers, err := a.GRPCClient.RaftGetConfiguration(server.RPCAddr.String())
+		if err != nil {
+			// Retry on the next member event
+			log.WithError(err).WithField("server", server.Name).Error("agent: Failed to confirm peer status")
+			return
+		}
+		if len(peers.Servers) > 0 {
+			log.WithField("server", server.Name).Info("agent: Existing raft peers reported by server, disabling bootstrap mode")
+			a.config.BootstrapExpect = 0
+			return
+		}
+	}
 
 	// Update the peer set
 	// Attempt a live bootstrap!
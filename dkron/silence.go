@@ -0,0 +1,280 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+	"google.golang.org/grpc/status"
+)
+
+// silencePrefix is the key prefix of the notification silences.
+const silencePrefix = "silence"
+
+var (
+	// ErrSilenceSpec is returned when a silence doesn't end after it starts.
+	ErrSilenceSpec = errors.New("silence needs an end after its start, or a duration")
+	// ErrSilenceReason is returned when a silence has no reason.
+	ErrSilenceReason = errors.New("silence needs a reason")
+)
+
+// Silence suppresses the notifications of the jobs it selects for a
+// period, their executions are still recorded.
+type Silence struct {
+	// ID of the silence, set by the server.
+	ID string `json:"id"`
+
+	// Name pattern of the silenced jobs, * matches any sequence of
+	// characters. All jobs when empty.
+	Job string `json:"job,omitempty"`
+
+	// Metadata the silenced jobs have.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Period of the silence, starting now by default.
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+
+	// Duration of the silence, sets the end from the start when creating it.
+	Duration string `json:"duration,omitempty"`
+
+	// Why and by whom the notifications are silenced.
+	Reason string `json:"reason"`
+	Author string `json:"author"`
+}
+
+// NewSilenceFromProto returns a new Silence from a proto.
+func NewSilenceFromProto(in *dkronpb.Silence) *Silence {
+	s := &Silence{
+		ID:       in.Id,
+		Job:      in.Job,
+		Metadata: in.Metadata,
+		Reason:   in.Reason,
+		Author:   in.Author,
+	}
+	if in.StartsAt != nil {
+		s.StartsAt, _ = ptypes.Timestamp(in.StartsAt)
+	}
+	if in.EndsAt != nil {
+		s.EndsAt, _ = ptypes.Timestamp(in.EndsAt)
+	}
+	return s
+}
+
+// ToProto returns the protobuf struct corresponding to the silence.
+func (s *Silence) ToProto() *dkronpb.Silence {
+	pbs := &dkronpb.Silence{
+		Id:       s.ID,
+		Job:      s.Job,
+		Metadata: s.Metadata,
+		Reason:   s.Reason,
+		Author:   s.Author,
+	}
+	pbs.StartsAt, _ = ptypes.TimestampProto(s.StartsAt)
+	pbs.EndsAt, _ = ptypes.TimestampProto(s.EndsAt)
+	return pbs
+}
+
+// Validate checks the silence definition.
+func (s *Silence) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if s.Reason == "" {
+		return ErrSilenceReason
+	}
+	if !s.EndsAt.After(s.StartsAt) {
+		return ErrSilenceSpec
+	}
+	if _, err := path.Match(s.Job, ""); err != nil {
+		return fmt.Errorf("invalid job pattern %q: %s", s.Job, err)
+	}
+	return nil
+}
+
+// active returns whether the silence is in effect at t.
+func (s *Silence) active(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// matches returns whether the job is selected by the silence.
+func (s *Silence) matches(job *Job) bool {
+	if s.Job != "" {
+		if ok, _ := path.Match(s.Job, job.Name); !ok {
+			return false
+		}
+	}
+	for k, v := range s.Metadata {
+		if job.Metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// silenced returns the silence in effect at t for the job, if any.
+func (a *Agent) silenced(job *Job, t time.Time) *Silence {
+	silences, err := a.Store.GetSilences()
+	if err != nil {
+		log.WithError(err).Error("agent: Error getting silences")
+		return nil
+	}
+	for _, s := range silences {
+		if s.active(t) && s.matches(job) {
+			return s
+		}
+	}
+	return nil
+}
+
+// SetSilence stores a silence.
+func (s *Store) SetSilence(silence *Silence) error {
+	if err := silence.Validate(); err != nil {
+		return err
+	}
+
+	b, err := proto.Marshal(silence.ToProto())
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(fmt.Sprintf("%s:%s", silencePrefix, silence.ID), string(b), nil)
+		return err
+	})
+}
+
+// DeleteSilence deletes a silence.
+func (s *Store) DeleteSilence(id string) (*Silence, error) {
+	var silence *Silence
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		value, err := tx.Delete(fmt.Sprintf("%s:%s", silencePrefix, id))
+		if err != nil {
+			return err
+		}
+		var pbs dkronpb.Silence
+		if err := proto.Unmarshal([]byte(value), &pbs); err != nil {
+			return err
+		}
+		silence = NewSilenceFromProto(&pbs)
+		return nil
+	})
+	return silence, err
+}
+
+// GetSilences returns the silences sorted by ID, the order they were
+// created in.
+func (s *Store) GetSilences() ([]*Silence, error) {
+	silences := []*Silence{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(silencePrefix+":*", func(key, value string) bool {
+			var pbs dkronpb.Silence
+			if err = proto.Unmarshal([]byte(value), &pbs); err != nil {
+				return false
+			}
+			silences = append(silences, NewSilenceFromProto(&pbs))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+func (h *HTTPTransport) silencesHandler(c *gin.Context) {
+	silences, err := h.agent.Store.GetSilences()
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	// Expired silences are only listed on request
+	if c.Query("expired") != "true" {
+		now := time.Now()
+		current := []*Silence{}
+		for _, s := range silences {
+			if s.EndsAt.After(now) {
+				current = append(current, s)
+			}
+		}
+		silences = current
+	}
+
+	renderJSON(c, http.StatusOK, silences)
+}
+
+func (h *HTTPTransport) silenceCreateHandler(c *gin.Context) {
+	var silence Silence
+	if err := c.BindJSON(&silence); err != nil {
+		c.Writer.WriteString(fmt.Sprintf("Unable to parse payload: %s.", err))
+		log.Error(err)
+		return
+	}
+
+	now := time.Now()
+	silence.ID = newULID(now)
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = now
+	}
+	if silence.Duration != "" {
+		d, err := time.ParseDuration(silence.Duration)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			c.Writer.WriteString(fmt.Sprintf("Silence contains invalid value: %s: %s.", ErrSilenceSpec, err))
+			return
+		}
+		silence.EndsAt = silence.StartsAt.Add(d)
+		silence.Duration = ""
+	}
+	if silence.Author == "" {
+		silence.Author = requestUser(c)
+	}
+
+	if err := silence.Validate(); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(fmt.Sprintf("Silence contains invalid value: %s.", err))
+		return
+	}
+
+	// Call gRPC SetSilence
+	if err := h.agent.GRPCClient.SetSilence(&silence); err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		c.Writer.WriteString(status.Convert(err).Message())
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"silence": silence.ID,
+		"author":  silence.Author,
+		"reason":  silence.Reason,
+	}).Info("api: Silence created")
+	renderJSON(c, http.StatusCreated, &silence)
+}
+
+func (h *HTTPTransport) silenceDeleteHandler(c *gin.Context) {
+	// Call gRPC DeleteSilence
+	silence, err := h.agent.GRPCClient.DeleteSilence(c.Param("silence"))
+	if err != nil {
+		s := status.Convert(err)
+		if s.Message() == buntdb.ErrNotFound.Error() {
+			c.AbortWithStatus(http.StatusNotFound)
+		} else {
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+		c.Writer.WriteString(s.Message())
+		return
+	}
+
+	renderJSON(c, http.StatusOK, silence)
+}
@@ -0,0 +1,118 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSilence_matches(t *testing.T) {
+	now := time.Now()
+	s := &Silence{
+		ID:       newULID(now),
+		Job:      "billing-*",
+		Metadata: map[string]string{"env": "prod"},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+		Reason:   "database migration",
+	}
+	require.NoError(t, s.Validate())
+
+	assert.True(t, s.matches(&Job{Name: "billing-report", Metadata: map[string]string{"env": "prod"}}))
+	assert.False(t, s.matches(&Job{Name: "billing-report", Metadata: map[string]string{"env": "staging"}}))
+	assert.False(t, s.matches(&Job{Name: "cleanup", Metadata: map[string]string{"env": "prod"}}))
+
+	assert.True(t, s.active(now))
+	assert.False(t, s.active(now.Add(time.Hour)))
+
+	s.Reason = ""
+	assert.Equal(t, ErrSilenceReason, s.Validate())
+	s.Reason = "migration"
+	s.EndsAt = now
+	assert.Equal(t, ErrSilenceSpec, s.Validate())
+}
+
+func TestStore_Silences(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	start := time.Date(2020, 5, 15, 8, 0, 0, 0, time.UTC)
+	first := &Silence{ID: newULID(start), StartsAt: start, EndsAt: start.Add(time.Hour), Reason: "upgrade", Author: "ops"}
+	second := &Silence{ID: newULID(start.Add(time.Second)), Job: "report", StartsAt: start, EndsAt: start.Add(time.Hour), Reason: "migration"}
+	require.NoError(t, s.SetSilence(second))
+	require.NoError(t, s.SetSilence(first))
+	assert.Error(t, s.SetSilence(&Silence{ID: "invalid"}))
+
+	silences, err := s.GetSilences()
+	require.NoError(t, err)
+	require.Len(t, silences, 2)
+	assert.Equal(t, first.ID, silences[0].ID)
+	assert.Equal(t, "ops", silences[0].Author)
+	assert.Equal(t, start, silences[0].StartsAt.UTC())
+	assert.Equal(t, "report", silences[1].Job)
+
+	deleted, err := s.DeleteSilence(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade", deleted.Reason)
+
+	_, err = s.DeleteSilence(first.ID)
+	assert.Error(t, err)
+}
+
+func TestAPISilences(t *testing.T) {
+	port := "8134"
+	baseURL := fmt.Sprintf("http://localhost:%s/v1", port)
+	dir, a := setupAPITest(t, port)
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	body := `{"job": "billing-*", "duration": "2h", "reason": "database migration"}`
+	req, _ := http.NewRequest(http.MethodPost, baseURL+"/silences", bytes.NewBufferString(body))
+	req.Header.Set(userHeader, "alice")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	var silence Silence
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&silence))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.NotEmpty(t, silence.ID)
+	assert.Equal(t, "alice", silence.Author)
+	assert.Equal(t, 2*time.Hour, silence.EndsAt.Sub(silence.StartsAt))
+
+	resp, err = http.Post(baseURL+"/silences", "application/json", bytes.NewBufferString(`{"duration": "2h"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "/silences")
+	require.NoError(t, err)
+	var silences []*Silence
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&silences))
+	resp.Body.Close()
+	require.Len(t, silences, 1)
+
+	// Only the selected jobs are silenced
+	now := time.Now()
+	assert.NotNil(t, a.silenced(&Job{Name: "billing-report"}, now))
+	assert.Nil(t, a.silenced(&Job{Name: "cleanup"}, now))
+	assert.Nil(t, a.silenced(&Job{Name: "billing-report"}, now.Add(3*time.Hour)))
+
+	req, _ = http.NewRequest(http.MethodDelete, baseURL+"/silences/"+silence.ID, nil)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
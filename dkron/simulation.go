@@ -0,0 +1,355 @@
+package dkron
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/distribworks/dkron/v3/extcron"
+	"github.com/hashicorp/serf/serf"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// SimEventRun is logged when an execution starts.
+	SimEventRun = "run"
+	// SimEventDone is logged when an execution finishes.
+	SimEventDone = "done"
+	// SimEventSkip is logged when a run is skipped because the previous
+	// one, or one of its concurrency group, is still running and the job
+	// forbids concurrency.
+	SimEventSkip = "skip"
+	// SimEventTrip is logged when the circuit breaker of a job trips.
+	SimEventTrip = "trip"
+	// SimEventNoNodes is logged when no node matches the tags of a job.
+	SimEventNoNodes = "no_nodes"
+)
+
+// SimNode is a simulated agent.
+type SimNode struct {
+	Name string
+	Tags map[string]string
+}
+
+// SimResult is the outcome of a simulated execution.
+type SimResult struct {
+	Success  bool
+	Output   string
+	Duration time.Duration
+}
+
+// SimExecutor decides the outcome of running a job in a node.
+type SimExecutor func(job *Job, node string, attempt uint) SimResult
+
+// SimEvent is an entry of the timeline of a simulation.
+type SimEvent struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Job     string    `json:"job"`
+	Node    string    `json:"node,omitempty"`
+	Attempt uint      `json:"attempt,omitempty"`
+	Success bool      `json:"success,omitempty"`
+}
+
+func (e *SimEvent) String() string {
+	s := fmt.Sprintf("%s %-8s %s", e.Time.Format(time.RFC3339), e.Kind, e.Job)
+	if e.Node != "" {
+		s += fmt.Sprintf(" on %s (attempt %d)", e.Node, e.Attempt)
+	}
+	if e.Kind == SimEventDone {
+		if e.Success {
+			s += " succeeded"
+		} else {
+			s += " failed"
+		}
+	}
+	return s
+}
+
+// Simulation runs jobs in a single process against simulated agents and a
+// mock clock. Runs, retries, concurrency, circuit breakers and dependent
+// jobs follow the same rules as a cluster, but time only advances from
+// one event to the next, so days of schedules run in milliseconds and the
+// same input always gives the same timeline.
+type Simulation struct {
+	Clock  *MockClock
+	Store  *Store
+	Region string
+
+	// Events is the timeline of the simulation.
+	Events []*SimEvent
+
+	members  []serf.Member
+	executor SimExecutor
+	rand     *rand.Rand
+	queue    simQueue
+	seq      int
+	running  map[string]int
+	// Running executions of every concurrency group lock, and the lock
+	// of every execution group holding one.
+	locked     map[string]int
+	groupLocks map[int64]string
+}
+
+// NewSimulation returns a simulation of the nodes starting at the given
+// time. Nodes are in the default region unless tagged otherwise. A nil
+// executor makes every execution succeed after a second.
+func NewSimulation(start time.Time, nodes []SimNode, executor SimExecutor) (*Simulation, error) {
+	store, err := NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	if executor == nil {
+		executor = func(*Job, string, uint) SimResult {
+			return SimResult{Success: true, Duration: time.Second}
+		}
+	}
+
+	s := &Simulation{
+		Clock:    NewMockClock(start),
+		Store:    store,
+		Region:   DefaultConfig().Region,
+		executor: executor,
+		rand:     rand.New(rand.NewSource(1)),
+		running:  map[string]int{},
+
+		locked:     map[string]int{},
+		groupLocks: map[int64]string{},
+	}
+
+	for _, n := range nodes {
+		tags := map[string]string{
+			"rpc_addr": n.Name,
+			"region":   s.Region,
+		}
+		for k, v := range n.Tags {
+			tags[k] = v
+		}
+		s.members = append(s.members, serf.Member{
+			Name:   n.Name,
+			Tags:   tags,
+			Status: serf.StatusAlive,
+		})
+	}
+
+	return s, nil
+}
+
+// Seed sets the seed used to pick nodes of tags with cardinality.
+func (s *Simulation) Seed(seed int64) {
+	s.rand = rand.New(rand.NewSource(seed))
+}
+
+// AddJob saves the job and schedules it if it is enabled and has no parent.
+func (s *Simulation) AddJob(job *Job) error {
+	if err := s.Store.SetJob(job, false); err != nil {
+		return err
+	}
+	if job.ParentJob == "" && !job.Disabled {
+		return s.scheduleNext(job)
+	}
+	return nil
+}
+
+// RunFor runs the simulation for the given duration.
+func (s *Simulation) RunFor(d time.Duration) error {
+	return s.RunUntil(s.Clock.Now().Add(d))
+}
+
+// RunUntil processes the events up to the given time, leaving the clock at it.
+func (s *Simulation) RunUntil(t time.Time) error {
+	for s.queue.Len() > 0 && !s.queue[0].at.After(t) {
+		e := heap.Pop(&s.queue).(*simQueueItem)
+		s.Clock.Set(e.at)
+		if err := e.fn(); err != nil {
+			return err
+		}
+	}
+	s.Clock.Set(t)
+	return nil
+}
+
+// Shutdown releases the store of the simulation.
+func (s *Simulation) Shutdown() error {
+	return s.Store.Shutdown()
+}
+
+func (s *Simulation) push(at time.Time, fn func() error) {
+	s.seq++
+	heap.Push(&s.queue, &simQueueItem{at: at, seq: s.seq, fn: fn})
+}
+
+func (s *Simulation) log(e *SimEvent) {
+	e.Time = s.Clock.Now()
+	s.Events = append(s.Events, e)
+}
+
+// scheduleNext queues the next scheduled run of the job.
+func (s *Simulation) scheduleNext(job *Job) error {
+	sched, err := extcron.Parse(job.cronSchedule())
+	if err != nil {
+		return err
+	}
+	next := sched.Next(s.Clock.Now())
+	if next.IsZero() {
+		return nil
+	}
+	name := job.Name
+	s.push(next, func() error { return s.fire(name, true) })
+	return nil
+}
+
+// fire runs the job in every node matching its tags.
+func (s *Simulation) fire(name string, scheduled bool) error {
+	job, err := s.Store.GetJob(name, nil)
+	if err == buntdb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// Disabled jobs, tripped ones included, leave the schedule
+	if job.Disabled {
+		return nil
+	}
+	if scheduled {
+		if err := s.scheduleNext(job); err != nil {
+			return err
+		}
+	}
+
+	if job.Concurrency == ConcurrencyForbid && s.running[name] > 0 {
+		s.log(&SimEvent{Kind: SimEventSkip, Job: name})
+		return nil
+	}
+	if lock := job.concurrencyLockName(); lock != "" && s.locked[lock] > 0 {
+		s.log(&SimEvent{Kind: SimEventSkip, Job: name})
+		return nil
+	}
+
+	nodes, _, err := filterNodes(s.members, job.Tags, s.Region, s.rand.Shuffle)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		s.log(&SimEvent{Kind: SimEventNoNodes, Job: name})
+		return nil
+	}
+
+	names := make([]string, 0, len(nodes))
+	for n := range nodes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	group := s.Clock.Now().UnixNano()
+	for _, node := range names {
+		if err := s.start(job, node, group, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start begins an execution and queues its end.
+func (s *Simulation) start(job *Job, node string, group int64, attempt uint) error {
+	ex := &Execution{
+		JobName:   job.Name,
+		StartedAt: s.Clock.Now(),
+		NodeName:  node,
+		Group:     group,
+		Attempt:   attempt,
+	}
+	if _, err := s.Store.SetExecution(ex); err != nil {
+		return err
+	}
+	s.running[job.Name]++
+	if lock := job.concurrencyLockName(); lock != "" {
+		s.locked[lock]++
+		s.groupLocks[group] = lock
+	}
+	s.log(&SimEvent{Kind: SimEventRun, Job: job.Name, Node: node, Attempt: attempt})
+
+	result := s.executor(job, node, attempt)
+	s.push(ex.StartedAt.Add(result.Duration), func() error {
+		return s.done(ex, result)
+	})
+	return nil
+}
+
+// done finishes an execution, retrying it or running the dependent jobs
+// as the leader does when an execution is done.
+func (s *Simulation) done(ex *Execution, result SimResult) error {
+	ex.FinishedAt = s.Clock.Now()
+	ex.Success = result.Success
+	ex.Output = result.Output
+	s.running[ex.JobName]--
+	if lock, ok := s.groupLocks[ex.Group]; ok {
+		s.locked[lock]--
+	}
+
+	if _, err := s.Store.SetExecutionDone(ex); err != nil {
+		if err == ErrExecutionDoneForDeletedJob {
+			return nil
+		}
+		return err
+	}
+	s.log(&SimEvent{Kind: SimEventDone, Job: ex.JobName, Node: ex.NodeName, Attempt: ex.Attempt, Success: ex.Success})
+
+	job, err := s.Store.GetJob(ex.JobName, nil)
+	if err != nil {
+		return err
+	}
+
+	if job.Status == StatusTripped {
+		s.log(&SimEvent{Kind: SimEventTrip, Job: job.Name})
+		return nil
+	}
+
+	if !ex.Success && ex.Attempt < job.Retries+1 {
+		return s.start(job, ex.NodeName, ex.Group, ex.Attempt+1)
+	}
+
+	if job.Status == StatusSuccess {
+		for _, dep := range job.DependentJobs {
+			if err := s.fire(dep, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type simQueueItem struct {
+	at  time.Time
+	seq int
+	fn  func() error
+}
+
+// simQueue is a queue of events ordered by time, and by insertion for
+// events at the same time.
+type simQueue []*simQueueItem
+
+func (q simQueue) Len() int { return len(q) }
+
+func (q simQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q simQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *simQueue) Push(x interface{}) { *q = append(*q, x.(*simQueueItem)) }
+
+func (q *simQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
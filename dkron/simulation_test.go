@@ -0,0 +1,172 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var simStart = time.Date(2020, 5, 15, 0, 0, 0, 0, time.UTC)
+
+func countSimEvents(s *Simulation, kind, job string) int {
+	n := 0
+	for _, e := range s.Events {
+		if e.Kind == kind && e.Job == job {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSimulation_schedule(t *testing.T) {
+	s, err := NewSimulation(simStart, []SimNode{{Name: "node1"}, {Name: "node2"}}, nil)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.AddJob(&Job{Name: "hourly", Schedule: "0 0 * * * *", Executor: "shell"}))
+	require.NoError(t, s.AddJob(&Job{Name: "madrid", Schedule: "0 0 9 * * *", Timezone: "Europe/Madrid", Executor: "shell"}))
+	require.NoError(t, s.RunFor(24*time.Hour))
+
+	// Both nodes run every hour, the last runs are still in progress
+	assert.Equal(t, 48, countSimEvents(s, SimEventRun, "hourly"))
+	assert.Equal(t, 46, countSimEvents(s, SimEventDone, "hourly"))
+	assert.Equal(t, simStart.Add(time.Hour), s.Events[0].Time)
+	assert.Equal(t, simStart.Add(24*time.Hour), s.Clock.Now())
+
+	var madrid []time.Time
+	for _, e := range s.Events {
+		if e.Kind == SimEventRun && e.Job == "madrid" {
+			madrid = append(madrid, e.Time)
+		}
+	}
+	require.Len(t, madrid, 2)
+	assert.Equal(t, simStart.Add(7*time.Hour), madrid[0])
+
+	job, err := s.Store.GetJob("hourly", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 46, job.SuccessCount)
+}
+
+func TestSimulation_concurrencyForbid(t *testing.T) {
+	s, err := NewSimulation(simStart, []SimNode{{Name: "node1"}}, func(*Job, string, uint) SimResult {
+		return SimResult{Success: true, Duration: 90 * time.Second}
+	})
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.AddJob(&Job{Name: "slow", Schedule: "@every 1m", Executor: "shell", Concurrency: ConcurrencyForbid}))
+	require.NoError(t, s.RunFor(10*time.Minute))
+
+	assert.Equal(t, 5, countSimEvents(s, SimEventRun, "slow"))
+	assert.Equal(t, 5, countSimEvents(s, SimEventSkip, "slow"))
+}
+
+func TestSimulation_concurrencyGroup(t *testing.T) {
+	s, err := NewSimulation(simStart, []SimNode{{Name: "node1"}}, func(*Job, string, uint) SimResult {
+		return SimResult{Success: true, Duration: 90 * time.Second}
+	})
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	warehouse := map[string]string{"lock": "warehouse"}
+	require.NoError(t, s.AddJob(&Job{Name: "load", Schedule: "0 */2 * * * *", Executor: "shell", Concurrency: ConcurrencyForbid, ConcurrencyKey: "lock", Metadata: warehouse}))
+	require.NoError(t, s.AddJob(&Job{Name: "vacuum", Schedule: "30 */2 * * * *", Executor: "shell", Concurrency: ConcurrencyForbid, ConcurrencyKey: "lock", Metadata: warehouse}))
+	require.NoError(t, s.RunFor(10*time.Minute))
+
+	// The group runs one job at a time, load always finds vacuum running
+	assert.Equal(t, 5, countSimEvents(s, SimEventRun, "vacuum"))
+	assert.Equal(t, 0, countSimEvents(s, SimEventRun, "load"))
+	assert.Equal(t, 5, countSimEvents(s, SimEventSkip, "load"))
+}
+
+func TestSimulation_dependentJobs(t *testing.T) {
+	s, err := NewSimulation(simStart, []SimNode{{Name: "node1"}}, func(*Job, string, uint) SimResult {
+		return SimResult{Success: true, Duration: time.Minute}
+	})
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.AddJob(&Job{Name: "parent", Schedule: "0 0 3 * * *", Executor: "shell"}))
+	require.NoError(t, s.AddJob(&Job{Name: "child", ParentJob: "parent", Executor: "shell"}))
+	require.NoError(t, s.AddJob(&Job{Name: "grandchild", ParentJob: "child", Executor: "shell"}))
+	require.NoError(t, s.RunFor(24*time.Hour))
+
+	timeline := []string{}
+	for _, e := range s.Events {
+		timeline = append(timeline, e.String())
+	}
+	assert.Equal(t, []string{
+		"2020-05-15T03:00:00Z run      parent on node1 (attempt 1)",
+		"2020-05-15T03:01:00Z done     parent on node1 (attempt 1) succeeded",
+		"2020-05-15T03:01:00Z run      child on node1 (attempt 1)",
+		"2020-05-15T03:02:00Z done     child on node1 (attempt 1) succeeded",
+		"2020-05-15T03:02:00Z run      grandchild on node1 (attempt 1)",
+		"2020-05-15T03:03:00Z done     grandchild on node1 (attempt 1) succeeded",
+	}, timeline)
+}
+
+func TestSimulation_retries(t *testing.T) {
+	s, err := NewSimulation(simStart, []SimNode{{Name: "node1"}}, func(job *Job, node string, attempt uint) SimResult {
+		// The parent fails the first attempt of every run
+		return SimResult{Success: attempt > 1, Duration: time.Minute}
+	})
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.AddJob(&Job{Name: "parent", Schedule: "0 0 3 * * *", Executor: "shell", Retries: 2}))
+	require.NoError(t, s.AddJob(&Job{Name: "child", ParentJob: "parent", Executor: "shell"}))
+	require.NoError(t, s.RunFor(24*time.Hour))
+
+	assert.Equal(t, 2, countSimEvents(s, SimEventRun, "parent"))
+
+	// The failed attempt belongs to the same execution group, so the run
+	// is partially failed and the dependent job doesn't run
+	job, err := s.Store.GetJob("parent", nil)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPartialyFailed, job.Status)
+	assert.Equal(t, 0, countSimEvents(s, SimEventRun, "child"))
+}
+
+func TestSimulation_circuitBreaker(t *testing.T) {
+	s, err := NewSimulation(simStart, []SimNode{{Name: "node1"}}, func(*Job, string, uint) SimResult {
+		return SimResult{Success: false, Duration: time.Second}
+	})
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.AddJob(&Job{Name: "broken", Schedule: "@every 1m", Executor: "shell", MaxConsecutiveFailures: 3}))
+	require.NoError(t, s.RunFor(time.Hour))
+
+	assert.Equal(t, 3, countSimEvents(s, SimEventRun, "broken"))
+	assert.Equal(t, 1, countSimEvents(s, SimEventTrip, "broken"))
+
+	job, err := s.Store.GetJob("broken", nil)
+	require.NoError(t, err)
+	assert.True(t, job.Disabled)
+	assert.Equal(t, StatusTripped, job.Status)
+}
+
+func TestSimulation_tags(t *testing.T) {
+	nodes := []SimNode{
+		{Name: "web1", Tags: map[string]string{"role": "web"}},
+		{Name: "web2", Tags: map[string]string{"role": "web"}},
+		{Name: "db1", Tags: map[string]string{"role": "db"}},
+	}
+	s, err := NewSimulation(simStart, nodes, nil)
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.AddJob(&Job{Name: "one-web", Schedule: "@every 1h", Executor: "shell", Tags: map[string]string{"role": "web:1"}}))
+	require.NoError(t, s.AddJob(&Job{Name: "cache", Schedule: "@every 1h", Executor: "shell", Tags: map[string]string{"role": "cache"}}))
+	require.NoError(t, s.RunFor(10*time.Hour))
+
+	assert.Equal(t, 10, countSimEvents(s, SimEventRun, "one-web"))
+	assert.Equal(t, 10, countSimEvents(s, SimEventNoNodes, "cache"))
+	for _, e := range s.Events {
+		if e.Job == "one-web" {
+			assert.Contains(t, []string{"web1", "web2"}, e.Node)
+		}
+	}
+}
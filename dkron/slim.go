@@ -0,0 +1,7 @@
+// +build slim
+
+package dkron
+
+// slimBuild makes every agent run slim, servers aren't available in builds
+// with the slim tag.
+const slimBuild = true
@@ -0,0 +1,6 @@
+// +build !slim
+
+package dkron
+
+// slimBuild is only set in builds with the slim tag.
+const slimBuild = false
@@ -0,0 +1,88 @@
+package dkron
+
+import (
+	"errors"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/distribworks/dkron/v3/extcron"
+	"github.com/sirupsen/logrus"
+	"github.com/tidwall/buntdb"
+)
+
+var (
+	// ErrStandbySelf is returned when a job is set as the standby of itself.
+	ErrStandbySelf = errors.New("a job can't be the standby of itself")
+	// ErrStandbyDependent is returned when a dependent job is set as a
+	// standby, it isn't scheduled.
+	ErrStandbyDependent = errors.New("dependent jobs can't be standby jobs")
+)
+
+// Reasons a standby job runs.
+const (
+	standbyPrimaryMissing = "primary job not found"
+	standbyPrimaryMissed  = "primary job missed its run"
+	standbyPrimaryFailed  = "primary job failed"
+)
+
+func (j *Job) validateStandby() error {
+	if j.StandbyFor == "" {
+		return nil
+	}
+	if j.StandbyFor == j.Name {
+		return ErrStandbySelf
+	}
+	if j.ParentJob != "" {
+		return ErrStandbyDependent
+	}
+	return nil
+}
+
+// standbyReason returns why the standby of the primary job runs, given the
+// last execution group of the primary and the start of the period it had
+// to run in, or empty if the primary succeeded or is still running.
+func standbyReason(primary *Job, exg []*Execution, since time.Time) string {
+	if len(exg) == 0 || groupStart(exg[0]).Before(since) {
+		return standbyPrimaryMissed
+	}
+	last := groupFinished(exg, primary.Retries)
+	if last != nil && !last.Success && !last.Skipped {
+		return standbyPrimaryFailed
+	}
+	return ""
+}
+
+// standbyRuns returns whether the scheduled run of the standby job at
+// scheduledAt goes on. The primary job must have run since the previous
+// scheduled time of the standby and not failed for the standby to skip it.
+func (a *Agent) standbyRuns(job *Job, scheduledAt time.Time) bool {
+	reason := standbyPrimaryMissing
+	primary, err := a.Store.GetJob(job.StandbyFor, nil)
+	if err == nil {
+		exg, err := a.Store.GetLastExecutionGroup(primary.Name)
+		if err != nil && err != buntdb.ErrNotFound {
+			log.WithError(err).WithField("job", job.Name).Error("job: Error getting the last run of the primary job")
+		}
+
+		var since time.Time
+		if s, err := extcron.Parse(job.cronSchedule()); err == nil && !scheduledAt.IsZero() {
+			since = prevScheduleTime(s, scheduledAt)
+		}
+		reason = standbyReason(primary, exg, since)
+	}
+
+	fields := logrus.Fields{
+		"job":     job.Name,
+		"primary": job.StandbyFor,
+	}
+	if reason == "" {
+		log.WithFields(fields).Info("job: Skipping standby job, primary job succeeded")
+		metrics.IncrCounterWithLabels([]string{"job", "standby_skipped"}, 1, []metrics.Label{
+			{Name: "job", Value: job.Name},
+			{Name: "namespace", Value: job.Namespace()},
+		})
+		return false
+	}
+	log.WithFields(fields).WithField("reason", reason).Info("job: Running standby job")
+	return true
+}
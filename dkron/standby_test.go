@@ -0,0 +1,57 @@
+package dkron
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandbyReason(t *testing.T) {
+	primary := &Job{Name: "feed-primary", Retries: 1}
+	since := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	ex := &Execution{JobName: primary.Name, Group: 1, Attempt: 1, ScheduledAt: since.Add(23 * time.Hour)}
+	exg := []*Execution{ex}
+
+	assert.Equal(t, standbyPrimaryMissed, standbyReason(primary, nil, since))
+
+	// Running, or failed and retried
+	assert.Empty(t, standbyReason(primary, exg, since))
+	ex.FinishedAt = ex.ScheduledAt.Add(time.Minute)
+	assert.Empty(t, standbyReason(primary, exg, since))
+
+	ex.Attempt = 2
+	assert.Equal(t, standbyPrimaryFailed, standbyReason(primary, exg, since))
+	ex.Success = true
+	assert.Empty(t, standbyReason(primary, exg, since))
+
+	// The last run is from a previous period
+	ex.ScheduledAt = since.Add(-time.Hour)
+	assert.Equal(t, standbyPrimaryMissed, standbyReason(primary, exg, since))
+}
+
+func TestStandbyRuns(t *testing.T) {
+	dir, a := setupAPITest(t, "8138")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	primary := &Job{Name: "feed-primary", Schedule: "@every 1h", Executor: "shell"}
+	standby := &Job{Name: "feed-standby", Schedule: "@every 1h", Executor: "shell", StandbyFor: primary.Name}
+	require.NoError(t, a.Store.SetJob(standby, false))
+
+	now := time.Now()
+	assert.True(t, a.standbyRuns(standby, now))
+
+	require.NoError(t, a.Store.SetJob(primary, false))
+	assert.True(t, a.standbyRuns(standby, now))
+
+	ex := &Execution{JobName: primary.Name, Group: now.UnixNano(), Attempt: 1, NodeName: "test", StartedAt: now.Add(-time.Minute), ScheduledAt: now.Add(-time.Minute), FinishedAt: now, Success: true}
+	_, err := a.Store.SetExecution(ex)
+	require.NoError(t, err)
+	assert.False(t, a.standbyRuns(standby, now))
+
+	standby.StandbyFor = standby.Name
+	assert.Equal(t, ErrStandbySelf, standby.Validate())
+}
@@ -1,6 +1,9 @@
 package dkron
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // Storage is the interface that should be used by any
 // storage engine implemented for dkron. It contains the
@@ -9,14 +12,74 @@ import "io"
 type Storage interface {
 	SetJob(job *Job, copyDependentJobs bool) error
 	DeleteJob(name string) (*Job, error)
+	DeleteJobs(name string, options *DeleteOptions) ([]*Job, error)
+	GetTrash() ([]*TrashedJob, error)
+	RestoreJob(name string) (*Job, error)
+	ResetJob(name string) (*Job, error)
 	SetExecution(execution *Execution) (string, error)
 	SetExecutionDone(execution *Execution) (bool, error)
+	AnnotateExecution(jobName, key string, annotations []string) (*Execution, error)
 	GetJobs(options *JobOptions) ([]*Job, error)
 	GetJob(name string, options *JobOptions) (*Job, error)
+	GetJobVersions(name string) ([]*Job, error)
+	SearchJobs(q string, regex bool) ([]*Job, error)
 	GetExecutions(jobName string) ([]*Execution, error)
+	GetShadowExecutions(jobName, label string) ([]*Execution, error)
+	GetShadowExecution(jobName, label, key string) (*Execution, error)
+	GetExecution(jobName, key string) (*Execution, error)
+	GetExecutionByID(id string) (*Execution, error)
 	GetLastExecutionGroup(jobName string) ([]*Execution, error)
 	GetExecutionGroup(execution *Execution) ([]*Execution, error)
 	GetGroupedExecutions(jobName string) (map[int64][]*Execution, []int64, error)
+	GetStatuses() (map[string]string, error)
+	GetExecutionSummary(jobName, groupBy string, loc *time.Location) ([]*ExecutionSummary, error)
+	GetCostReport(groupBy, period string, since, until time.Time) ([]*CostReport, error)
+	Check() (*CheckReport, error)
+	Repair() (int, error)
+	SetReadOnly(readOnly bool) error
+	ReadOnly() (bool, error)
+	SetMaintenanceWindow(w *MaintenanceWindow) error
+	DeleteMaintenanceWindow(name string) (*MaintenanceWindow, error)
+	GetMaintenanceWindows() ([]*MaintenanceWindow, error)
+	SetDispatchIntent(di *DispatchIntent) error
+	DeleteDispatchIntent(jobName string, group int64, matrixItem string) error
+	MarkDispatched(jobName string, group int64, matrixItem, nodeName string) error
+	GetDispatchIntents() ([]*DispatchIntent, error)
+	SetBackfill(b *Backfill) error
+	GetBackfill(jobName, id string) (*Backfill, error)
+	GetBackfills(jobName string) ([]*Backfill, error)
+	AcquireLock(l *Lock, now time.Time) (*Lock, error)
+	RenewLock(l *Lock, now time.Time) (*Lock, error)
+	ReleaseLock(name, holder string) error
+	GetLock(name string) (*Lock, error)
+	GetLocks() ([]*Lock, error)
+	SetKV(kv *KV) error
+	GetKV(namespace, key string) (*KV, error)
+	GetKVs(namespace string) ([]*KV, error)
+	DeleteKV(namespace, key string) error
+	SetScheduleMacros(macros map[string]string) error
+	GetScheduleMacros() (map[string]string, error)
+	SetExecutionRetention(retention time.Duration) error
+	GetExecutionRetention() (time.Duration, error)
+	SetSilence(silence *Silence) error
+	DeleteSilence(id string) (*Silence, error)
+	GetSilences() ([]*Silence, error)
+	SetCredential(cred *Credential) error
+	DeleteCredential(name string) (*Credential, error)
+	GetCredential(name string) (*Credential, error)
+	GetCredentials() ([]*Credential, error)
+	ApplyComplianceEvent(event *ComplianceEvent) (*ComplianceEvent, error)
+	GetLegalHold(jobName string) (*ComplianceEvent, error)
+	GetLegalHolds() ([]*ComplianceEvent, error)
+	GetComplianceEvents(jobName string) ([]*ComplianceEvent, error)
+	ApplySchedulerEvent(event *SchedulerEvent) (*SchedulerEvent, error)
+	SchedulerPause() (*SchedulerEvent, error)
+	GetSchedulerEvents() ([]*SchedulerEvent, error)
+	RecordChanges(maxPending int)
+	SetApplyIndex(index uint64)
+	GetChanges(limit int) ([]*Change, error)
+	CommitChanges(offset uint64, seq int) error
+	GetChangeOffset() (uint64, int, error)
 	Shutdown() error
 	Snapshot(w io.WriteCloser) error
 	Restore(r io.ReadCloser) error
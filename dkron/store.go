@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
 	"github.com/golang/protobuf/proto"
@@ -32,8 +35,27 @@ var (
 // It gives dkron the ability to manipulate its embedded storage
 // BuntDB.
 type Store struct {
-	db   *buntdb.DB
-	lock *sync.Mutex // for
+	// applyIndex is the raft index of the log being applied, the offset of
+	// the changes recorded. First to be aligned for atomic access.
+	applyIndex uint64
+
+	db    *buntdb.DB
+	lock  *sync.Mutex // for
+	index *jobIndex
+	cache *jobCache
+
+	// compression of the executions written, see encodeExecution.
+	compression string
+	// executionRetention is how long finished executions are kept, zero
+	// keeps them until maxExecutions is reached. It's the one of the
+	// cluster, set by the leader, see SetExecutionRetention.
+	executionRetention time.Duration
+	// maxExecutions is the number of executions kept per job.
+	maxExecutions int
+
+	// cdcMaxPending is the number of undelivered changes recorded for the
+	// change data capture sink, zero doesn't record them.
+	cdcMaxPending int
 }
 
 // JobOptions additional options to apply when loading a Job.
@@ -41,6 +63,20 @@ type JobOptions struct {
 	Metadata map[string]string `json:"tags"`
 }
 
+// DeleteOptions additional options to apply when deleting a Job.
+type DeleteOptions struct {
+	// Cascade deletes the dependent jobs of the job, recursively.
+	Cascade bool
+	// Now is the time of the deletion, decided by the leader so all the
+	// servers apply the same.
+	Now time.Time
+	// TrashExpiresAt keeps the deleted jobs in the trash until this time,
+	// zero doesn't keep them.
+	TrashExpiresAt time.Time
+	// TrashExecutions keeps the executions in the trash with the jobs.
+	TrashExecutions bool
+}
+
 type kv struct {
 	Key   string
 	Value []byte
@@ -54,13 +90,39 @@ func NewStore() (*Store, error) {
 	}
 
 	store := &Store{
-		db:   db,
-		lock: &sync.Mutex{},
+		db:    db,
+		lock:  &sync.Mutex{},
+		index: newJobIndex(),
+		cache: newJobCache(),
+
+		maxExecutions: MaxExecutions,
+	}
+
+	// Expired executions are deleted by the store
+	var config buntdb.Config
+	if err := db.ReadConfig(&config); err != nil {
+		return nil, err
+	}
+	config.OnExpiredSync = store.onExpired
+	if err := db.SetConfig(config); err != nil {
+		return nil, err
+	}
+
+	// A new store is always empty and up to date
+	err = db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(schemaVersionKey, strconv.Itoa(SchemaVersion), nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return store, nil
 }
 
+// setJobTxFunc stores the job and records the change. The search index is
+// updated by the callers once the transaction is committed, a rolled back
+// job isn't indexed.
 func (s *Store) setJobTxFunc(pbj *dkronpb.Job) func(tx *buntdb.Tx) error {
 	return func(tx *buntdb.Tx) error {
 		jobKey := fmt.Sprintf("%s:%s", jobsPrefix, pbj.Name)
@@ -74,8 +136,9 @@ func (s *Store) setJobTxFunc(pbj *dkronpb.Job) func(tx *buntdb.Tx) error {
 		if _, _, err := tx.Set(jobKey, string(jb), nil); err != nil {
 			return err
 		}
+		s.cache.invalidateJob(pbj.Name)
 
-		return nil
+		return s.recordJobTx(tx, pbj)
 	}
 }
 
@@ -88,6 +151,7 @@ func (s *Store) DB() *buntdb.DB {
 func (s *Store) SetJob(job *Job, copyDependentJobs bool) error {
 	var pbej dkronpb.Job
 	var ej *Job
+	var pbj *dkronpb.Job
 
 	if err := job.Validate(); err != nil {
 		return err
@@ -124,12 +188,44 @@ func (s *Store) SetJob(job *Job, copyDependentJobs bool) error {
 			if ej.ErrorCount > job.ErrorCount {
 				job.ErrorCount = ej.ErrorCount
 			}
+			if ej.SkipCount > job.SkipCount {
+				job.SkipCount = ej.SkipCount
+			}
 			if len(ej.DependentJobs) != 0 && copyDependentJobs {
 				job.DependentJobs = ej.DependentJobs
 			}
 			if ej.Status != "" {
 				job.Status = ej.Status
 			}
+			job.ConsecutiveFailures = ej.ConsecutiveFailures
+			job.EscalationLevel = ej.EscalationLevel
+			if ej.BreachedGroup > job.BreachedGroup {
+				job.BreachedGroup = ej.BreachedGroup
+			}
+			// The usage of the run budget is kept unless a later period
+			// or more runs are set
+			if job.Budget != nil && ej.Budget != nil {
+				job.Budget.mergeUsage(ej.Budget)
+			}
+			// The runs of a canary are counted when they finish
+			if job.Canary != nil && ej.Canary != nil && job.Canary.StartedAt.Equal(ej.Canary.StartedAt) {
+				job.Canary.Stable = ej.Canary.Stable
+				job.Canary.Canary = ej.Canary.Canary
+			}
+			// A tripped job stays disabled until its breaker is reset
+			if ej.Status == StatusTripped {
+				job.Disabled = true
+			}
+			// Unknown for jobs created before they were recorded
+			job.CreatedAt = ej.CreatedAt
+			job.CreatedBy = ej.CreatedBy
+			if !job.UpdatedAt.HasValue() {
+				job.UpdatedAt = ej.UpdatedAt
+				job.UpdatedBy = ej.UpdatedBy
+			}
+		} else if !job.CreatedAt.HasValue() {
+			job.CreatedAt = job.UpdatedAt
+			job.CreatedBy = job.UpdatedBy
 		}
 
 		if job.Schedule != ej.Schedule {
@@ -144,13 +240,17 @@ func (s *Store) SetJob(job *Job, copyDependentJobs bool) error {
 			}
 		}
 
-		pbj := job.ToProto()
-		s.setJobTxFunc(pbj)(tx)
-		return nil
+		if err := s.versionJobTxFunc(job, ej)(tx); err != nil {
+			return err
+		}
+
+		pbj = job.ToProto()
+		return s.setJobTxFunc(pbj)(tx)
 	})
 	if err != nil {
 		return err
 	}
+	s.index.update(pbj)
 
 	// If the parent job changed update the parents of the old (if any) and new jobs
 	if job.ParentJob != ej.ParentJob {
@@ -217,9 +317,9 @@ func (s *Store) addToParent(child *Job) error {
 // SetExecutionDone saves the execution and updates the job with the corresponding
 // results
 func (s *Store) SetExecutionDone(execution *Execution) (bool, error) {
+	var pbj dkronpb.Job
 	err := s.db.Update(func(tx *buntdb.Tx) error {
 		// Load the job from the store
-		var pbj dkronpb.Job
 		if err := s.getJobTxFunc(execution.JobName, &pbj)(tx); err != nil {
 			if err == buntdb.ErrNotFound {
 				log.Warning(ErrExecutionDoneForDeletedJob)
@@ -237,21 +337,63 @@ func (s *Store) SetExecutionDone(execution *Execution) (bool, error) {
 			return err
 		}
 
-		if pbe.Success {
+		group, err := s.groupExecutionsTx(pbj.Name, pbe.Group, tx)
+		if err != nil {
+			return err
+		}
+
+		// Failed runs are counted once, when the first execution of the
+		// group fails its last attempt
+		failedRun := !pbe.Skipped && !pbe.Success && pbe.Attempt >= pbj.Retries+1
+		for _, ex := range group {
+			if ex.Key() != execution.Key() && !ex.Skipped && !ex.Success && !ex.FinishedAt.IsZero() && ex.Attempt >= uint(pbj.Retries)+1 {
+				failedRun = false
+			}
+		}
+
+		// Skipped runs are neither successful nor failed
+		if pbe.Skipped {
+			pbj.SkipCount++
+		} else if pbe.Success {
 			pbj.LastSuccess.HasValue = true
 			pbj.LastSuccess.Time = pbe.FinishedAt
 			pbj.SuccessCount++
+			pbj.ConsecutiveFailures = 0
 		} else {
 			pbj.LastError.HasValue = true
 			pbj.LastError.Time = pbe.FinishedAt
 			pbj.ErrorCount++
+			if failedRun {
+				pbj.ConsecutiveFailures++
+			}
 		}
 
-		status, err := s.computeStatus(pbj.Name, pbe.Group, tx)
-		if err != nil {
-			return err
+		if pbj.Canary != nil && !pbe.Skipped {
+			recordCanaryRun(pbj.Canary, pbe)
+		}
+
+		pbj.Status = groupStatus(group)
+
+		// Trip the circuit breaker disabling the job
+		if pbj.MaxConsecutiveFailures > 0 && pbj.ConsecutiveFailures >= pbj.MaxConsecutiveFailures {
+			pbj.Disabled = true
+			pbj.Status = StatusTripped
+		}
+
+		// Escalate the failed runs, the escalation restarts on success
+		if pbe.Success {
+			pbj.EscalationLevel = 0
+		} else if failedRun {
+			steps := escalationFromProto(pbj.Escalation)
+			level := escalationLevel(steps, int(pbj.ConsecutiveFailures))
+			for i := int(pbj.EscalationLevel); i < level; i++ {
+				if steps[i].Disable {
+					pbj.Disabled = true
+					pbj.Status = StatusTripped
+				}
+			}
+			pbj.EscalationLevel = int32(level)
 		}
-		pbj.Status = status
 
 		if err := s.setJobTxFunc(&pbj)(tx); err != nil {
 			return err
@@ -263,10 +405,37 @@ func (s *Store) SetExecutionDone(execution *Execution) (bool, error) {
 		log.WithError(err).Error("store: Error in SetExecutionDone")
 		return false, err
 	}
+	s.index.update(&pbj)
 
 	return true, nil
 }
 
+// ResetJob resets the circuit breaker of a job, enabling it again if it
+// was tripped.
+func (s *Store) ResetJob(name string) (*Job, error) {
+	var pbj dkronpb.Job
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		if err := s.getJobTxFunc(name, &pbj)(tx); err != nil {
+			return err
+		}
+
+		pbj.ConsecutiveFailures = 0
+		pbj.EscalationLevel = 0
+		if pbj.Status == StatusTripped {
+			pbj.Disabled = false
+			pbj.Status = StatusNotSet
+		}
+
+		return s.setJobTxFunc(&pbj)(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.index.update(&pbj)
+
+	return NewJobFromProto(&pbj), nil
+}
+
 func (s *Store) jobHasMetadata(job *Job, metadata map[string]string) bool {
 	if job == nil || job.Metadata == nil || len(job.Metadata) == 0 {
 		return false
@@ -285,33 +454,87 @@ func (s *Store) jobHasMetadata(job *Job, metadata map[string]string) bool {
 func (s *Store) GetJobs(options *JobOptions) ([]*Job, error) {
 	jobs := make([]*Job, 0)
 
-	err := s.db.View(func(tx *buntdb.Tx) error {
-		err := tx.Ascend("", func(key, value string) bool {
-			if strings.HasPrefix(key, jobsPrefix+":") {
+	pbjs, err := s.getJobProtos()
+	if err != nil {
+		return jobs, err
+	}
+	for _, pbj := range pbjs {
+		job := NewJobFromProto(pbj)
+
+		if options == nil || (options.Metadata == nil || len(options.Metadata) == 0 || s.jobHasMetadata(job, options.Metadata)) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
+
+// getJobProtos returns all the jobs sorted by name from the cache, reading
+// the ones that changed since they were cached, or every job the first
+// time, from the store.
+func (s *Store) getJobProtos() ([]*dkronpb.Job, error) {
+	cached, stale, gen, ok := s.cache.allJobs()
+	if !ok {
+		var pbjs []*dkronpb.Job
+		err := s.db.View(func(tx *buntdb.Tx) error {
+			return tx.AscendKeys(jobsPrefix+":*", func(key, value string) bool {
 				var pbj dkronpb.Job
-				_ = proto.Unmarshal([]byte(value), &pbj)
-				job := NewJobFromProto(&pbj)
+				_ = decodeJob([]byte(value), &pbj)
+				pbjs = append(pbjs, &pbj)
+				return true
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.cache.setAllJobs(gen, pbjs)
+		return pbjs, nil
+	}
+	if len(stale) == 0 {
+		return cached, nil
+	}
 
-				if options == nil || (options.Metadata == nil || len(options.Metadata) == 0 || s.jobHasMetadata(job, options.Metadata)) {
-					jobs = append(jobs, job)
-				}
+	found := map[string]*dkronpb.Job{}
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		for _, name := range stale {
+			var pbj dkronpb.Job
+			err := s.getJobTxFunc(name, &pbj)(tx)
+			if err == buntdb.ErrNotFound {
+				// Deleted
+				continue
 			}
-			return true
-		})
-		return err
+			if err != nil {
+				return err
+			}
+			found[name] = &pbj
+		}
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache.refreshJobs(gen, stale, found)
 
-	return jobs, err
+	for _, pbj := range found {
+		cached = append(cached, pbj)
+	}
+	sortJobProtos(cached)
+	return cached, nil
 }
 
 // GetJob finds and return a Job from the store
 func (s *Store) GetJob(name string, options *JobOptions) (*Job, error) {
-	var pbj dkronpb.Job
+	if pbj, ok := s.cache.job(name); ok {
+		return NewJobFromProto(pbj), nil
+	}
 
+	var pbj dkronpb.Job
+	gen := s.cache.generation()
 	err := s.db.View(s.getJobTxFunc(name, &pbj))
 	if err != nil {
 		return nil, err
 	}
+	s.cache.setJob(gen, &pbj)
 
 	job := NewJobFromProto(&pbj)
 
@@ -326,7 +549,7 @@ func (s *Store) getJobTxFunc(name string, pbj *dkronpb.Job) func(tx *buntdb.Tx)
 			return err
 		}
 
-		if err := proto.Unmarshal([]byte(item), pbj); err != nil {
+		if err := decodeJob([]byte(item), pbj); err != nil {
 			return err
 		}
 
@@ -341,7 +564,22 @@ func (s *Store) getJobTxFunc(name string, pbj *dkronpb.Job) func(tx *buntdb.Tx)
 // DeleteJob deletes the given job from the store, along with
 // all its executions and references to it.
 func (s *Store) DeleteJob(name string) (*Job, error) {
-	var job *Job
+	jobs, err := s.DeleteJobs(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return jobs[0], nil
+}
+
+// DeleteJobs deletes the given job like DeleteJob, along with its
+// dependent jobs when cascading, in a single transaction. It returns
+// the deleted jobs, starting with the given one.
+func (s *Store) DeleteJobs(name string, options *DeleteOptions) ([]*Job, error) {
+	if options == nil {
+		options = &DeleteOptions{}
+	}
+
+	var jobs []*Job
 	err := s.db.Update(func(tx *buntdb.Tx) error {
 		// Get the job
 		var pbj dkronpb.Job
@@ -349,32 +587,77 @@ func (s *Store) DeleteJob(name string) (*Job, error) {
 			return err
 		}
 		// Check if the job has dependent jobs
-		// and return an error indicating to remove childs
-		// first.
-		if len(pbj.DependentJobs) > 0 {
-			return ErrDependentJobs
+		// and return an error listing them unless
+		// deleting them too.
+		if len(pbj.DependentJobs) > 0 && !options.Cascade {
+			return fmt.Errorf("%s: %s", ErrDependentJobs, strings.Join(pbj.DependentJobs, ", "))
 		}
-		job = NewJobFromProto(&pbj)
 
-		if err := s.deleteExecutionsTxFunc(name)(tx); err != nil {
-			return err
+		// Jobs listed twice or depending on each other are deleted once
+		tree := []*dkronpb.Job{&pbj}
+		visited := map[string]bool{pbj.Name: true}
+		for i := 0; i < len(tree); i++ {
+			for _, dj := range tree[i].DependentJobs {
+				if visited[dj] {
+					continue
+				}
+				visited[dj] = true
+				var child dkronpb.Job
+				if err := s.getJobTxFunc(dj, &child)(tx); err != nil {
+					if err == buntdb.ErrNotFound {
+						continue
+					}
+					return err
+				}
+				tree = append(tree, &child)
+			}
 		}
 
-		_, err := tx.Delete(fmt.Sprintf("%s:%s", jobsPrefix, name))
-		return err
+		for _, pbj := range tree {
+			if heldTx(tx, pbj.Name) {
+				return fmt.Errorf("%s: %s", ErrLegalHold, pbj.Name)
+			}
+		}
+
+		for _, pbj := range tree {
+			if !options.TrashExpiresAt.IsZero() {
+				if err := s.trashJobTxFunc(pbj, options.Now, options.TrashExpiresAt, options.TrashExecutions)(tx); err != nil {
+					return err
+				}
+			}
+
+			if err := s.deleteExecutionsTxFunc(pbj.Name)(tx); err != nil {
+				return err
+			}
+
+			key := fmt.Sprintf("%s:%s", jobsPrefix, pbj.Name)
+			value, err := tx.Delete(key)
+			if err != nil {
+				return err
+			}
+			if err := s.recordDeleteTx(tx, key, value, false); err != nil {
+				return err
+			}
+			s.cache.invalidateJob(pbj.Name)
+			jobs = append(jobs, NewJobFromProto(pbj))
+		}
+		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	for _, job := range jobs {
+		s.index.remove(job.Name)
+	}
 
 	// If the transaction succeded, remove from parent
-	if job.ParentJob != "" {
-		if err := s.removeFromParent(job); err != nil {
+	if jobs[0].ParentJob != "" {
+		if err := s.removeFromParent(jobs[0]); err != nil {
 			return nil, err
 		}
 	}
 
-	return job, nil
+	return jobs, nil
 }
 
 // GetExecutions returns the exections given a Job name.
@@ -389,6 +672,78 @@ func (s *Store) GetExecutions(jobName string) ([]*Execution, error) {
 	return s.unmarshalExecutions(kvs)
 }
 
+// GetExecutionByID returns the execution with the given ID. The executions
+// are found by the start time in their keys, only the ones started in the
+// millisecond of the ID are decoded.
+func (s *Store) GetExecutionByID(id string) (*Execution, error) {
+	ms, isULID := ulidTime(id)
+	prefix := executionsPrefix + ":"
+
+	var execution *Execution
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendGreaterOrEqual("", prefix, func(key, value string) bool {
+			if !strings.HasPrefix(key, prefix) {
+				return false
+			}
+			// Keys end with the start time and the node of the execution
+			rest := strings.TrimPrefix(key, prefix)
+			exKey := rest[strings.Index(rest, ":")+1:]
+			if exKey != id {
+				if !isULID {
+					return true
+				}
+				i := strings.Index(exKey, "-")
+				if i < 0 {
+					return true
+				}
+				nanos, perr := strconv.ParseInt(exKey[:i], 10, 64)
+				if perr != nil || nanos/int64(time.Millisecond) != ms {
+					return true
+				}
+			}
+
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				return false
+			}
+			ex := NewExecutionFromProto(&pbe)
+			ex.setLegacyID()
+			if ex.Id != id {
+				return true
+			}
+			execution = ex
+			return false
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if execution == nil {
+		return nil, buntdb.ErrNotFound
+	}
+	return execution, nil
+}
+
+// GetExecution returns the execution of the job with the given key.
+func (s *Store) GetExecution(jobName, key string) (*Execution, error) {
+	var pbe dkronpb.Execution
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(fmt.Sprintf("%s:%s:%s", executionsPrefix, jobName, key))
+		if err != nil {
+			return err
+		}
+		return decodeExecution([]byte(value), &pbe)
+	})
+	if err != nil {
+		return nil, err
+	}
+	execution := NewExecutionFromProto(&pbe)
+	execution.setLegacyID()
+	return execution, nil
+}
+
 func (s *Store) list(prefix string, checkRoot bool) ([]kv, error) {
 	var found bool
 	kvs := []kv{}
@@ -420,13 +775,30 @@ func (*Store) listTxFunc(prefix string, kvs *[]kv, found *bool) func(tx *buntdb.
 
 // GetLastExecutionGroup get last execution group given the Job name.
 func (s *Store) GetLastExecutionGroup(jobName string) ([]*Execution, error) {
+	if pbes, ok := s.cache.lastGroup(jobName); ok {
+		executions := make([]*Execution, 0, len(pbes))
+		for _, pbe := range pbes {
+			execution := NewExecutionFromProto(pbe)
+			execution.setLegacyID()
+			executions = append(executions, execution)
+		}
+		return executions, nil
+	}
+
+	gen := s.cache.generation()
 	executions, byGroup, err := s.GetGroupedExecutions(jobName)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(executions) > 0 && len(byGroup) > 0 {
-		return executions[byGroup[0]], nil
+		group := executions[byGroup[0]]
+		pbes := make([]*dkronpb.Execution, 0, len(group))
+		for _, ex := range group {
+			pbes = append(pbes, ex.ToProto())
+		}
+		s.cache.setLastGroup(gen, jobName, pbes)
+		return group, nil
 	}
 
 	return nil, nil
@@ -470,7 +842,7 @@ func (s *Store) GetGroupedExecutions(jobName string) (map[int64][]*Execution, []
 	return groups, byGroup, nil
 }
 
-func (*Store) setExecutionTxFunc(key string, pbe *dkronpb.Execution) func(tx *buntdb.Tx) error {
+func (s *Store) setExecutionTxFunc(key string, pbe *dkronpb.Execution) func(tx *buntdb.Tx) error {
 	return func(tx *buntdb.Tx) error {
 		// Get previous execution
 		i, err := tx.Get(key)
@@ -481,29 +853,95 @@ func (*Store) setExecutionTxFunc(key string, pbe *dkronpb.Execution) func(tx *bu
 		// more recent, avoiding non ordered execution set
 		if i != "" {
 			var p dkronpb.Execution
-			if err := proto.Unmarshal([]byte(i), &p); err != nil {
+			if err := decodeExecution([]byte(i), &p); err != nil {
 				return err
 			}
 			// Compare existing execution
 			if p.GetFinishedAt().Seconds > pbe.GetFinishedAt().Seconds {
 				return nil
 			}
+			// Annotations made while it ran are kept
+			pbe.Annotations = mergeAnnotations(p.Annotations, pbe.Annotations)
 		}
 
-		eb, err := proto.Marshal(pbe)
+		eb, err := encodeExecution(pbe, s.compression)
 		if err != nil {
 			return err
 		}
 
-		_, _, err = tx.Set(key, string(eb), nil)
-		return err
+		if _, _, err := tx.Set(key, string(eb), s.executionSetOptions(tx, pbe)); err != nil {
+			return err
+		}
+		s.cache.invalidateExecutions(pbe.JobName)
+		return s.recordExecutionTx(tx, pbe)
+	}
+}
+
+// mergeAnnotations returns the stored annotations followed by the new ones
+// not in them.
+func mergeAnnotations(stored, annotations []string) []string {
+	if len(stored) == 0 {
+		return annotations
+	}
+	merged := append([]string(nil), stored...)
+	for _, a := range annotations {
+		found := false
+		for _, s := range stored {
+			if s == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, a)
+		}
 	}
+	return merged
+}
+
+// AnnotateExecution appends the annotations to the execution of the job
+// with the key, returning the annotated execution.
+func (s *Store) AnnotateExecution(jobName, key string, annotations []string) (*Execution, error) {
+	var pbe dkronpb.Execution
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		k := fmt.Sprintf("%s:%s:%s", executionsPrefix, jobName, key)
+		value, err := tx.Get(k)
+		if err != nil {
+			return err
+		}
+		if err := decodeExecution([]byte(value), &pbe); err != nil {
+			return err
+		}
+		pbe.Annotations = append(pbe.Annotations, annotations...)
+
+		eb, err := encodeExecution(&pbe, s.compression)
+		if err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(k, string(eb), s.executionSetOptions(tx, &pbe)); err != nil {
+			return err
+		}
+		return s.recordExecutionTx(tx, &pbe)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cache.invalidateExecutions(jobName)
+
+	execution := NewExecutionFromProto(&pbe)
+	execution.setLegacyID()
+	return execution, nil
 }
 
 // SetExecution Save a new execution and returns the key of the new saved item or an error.
 func (s *Store) SetExecution(execution *Execution) (string, error) {
+	if execution.Shadow != "" {
+		return s.setShadowExecution(execution)
+	}
+
 	pbe := execution.ToProto()
 	key := fmt.Sprintf("%s:%s:%s", executionsPrefix, execution.JobName, execution.Key())
+	execution.setLegacyID()
 
 	log.WithFields(logrus.Fields{
 		"job":       execution.JobName,
@@ -521,6 +959,15 @@ func (s *Store) SetExecution(execution *Execution) (string, error) {
 		return "", err
 	}
 
+	// Decode the executions only when they're over the limit, most writes
+	// are under it, more so when they expire. Executions under legal hold
+	// are all kept.
+	if s.countExecutions(execution.JobName) <= s.maxExecutions {
+		return key, nil
+	}
+	if _, err := s.GetLegalHold(execution.JobName); err == nil {
+		return key, nil
+	}
 	execs, err := s.GetExecutions(execution.JobName)
 	if err != nil && err != buntdb.ErrNotFound {
 		log.WithError(err).
@@ -529,21 +976,25 @@ func (s *Store) SetExecution(execution *Execution) (string, error) {
 	}
 
 	// Delete all execution results over the limit, starting from olders
-	if len(execs) > MaxExecutions {
+	if len(execs) > s.maxExecutions {
 		//sort the array of all execution groups by StartedAt time
 		sort.Slice(execs, func(i, j int) bool {
 			return execs[i].StartedAt.Before(execs[j].StartedAt)
 		})
 
-		for i := 0; i < len(execs)-MaxExecutions; i++ {
+		for i := 0; i < len(execs)-s.maxExecutions; i++ {
 			log.WithFields(logrus.Fields{
 				"job":       execs[i].JobName,
 				"execution": execs[i].Key(),
 			}).Debug("store: to detele key")
 			err = s.db.Update(func(tx *buntdb.Tx) error {
 				k := fmt.Sprintf("%s:%s:%s", executionsPrefix, execs[i].JobName, execs[i].Key())
-				_, err := tx.Delete(k)
-				return err
+				s.cache.invalidateExecutions(execs[i].JobName)
+				value, err := tx.Delete(k)
+				if err != nil {
+					return err
+				}
+				return s.recordDeleteTx(tx, k, value, false)
 			})
 			if err != nil {
 				log.WithError(err).
@@ -556,21 +1007,42 @@ func (s *Store) SetExecution(execution *Execution) (string, error) {
 	return key, nil
 }
 
+// countExecutions returns the number of stored executions of the job.
+func (s *Store) countExecutions(jobName string) int {
+	n := 0
+	s.db.View(func(tx *buntdb.Tx) error {
+		return tx.AscendKeys(fmt.Sprintf("%s:%s:*", executionsPrefix, jobName), func(key, value string) bool {
+			n++
+			return true
+		})
+	})
+	return n
+}
+
 // DeleteExecutions removes all executions of a job
 func (s *Store) deleteExecutionsTxFunc(jobName string) func(tx *buntdb.Tx) error {
 	return func(tx *buntdb.Tx) error {
 		var delkeys []string
-		prefix := fmt.Sprintf("%s:%s", executionsPrefix, jobName)
-		tx.Ascend("", func(key, value string) bool {
-			if strings.HasPrefix(key, prefix) {
-				delkeys = append(delkeys, key)
-			}
+		tx.AscendKeys(fmt.Sprintf("%s:%s:*", executionsPrefix, jobName), func(key, value string) bool {
+			delkeys = append(delkeys, key)
+			return true
+		})
+		// The executions of the shadow runs of the job go with them
+		tx.AscendKeys(fmt.Sprintf("%s:%s:*", shadowPrefix, jobName), func(key, value string) bool {
+			delkeys = append(delkeys, key)
 			return true
 		})
 
 		for _, k := range delkeys {
-			_, _ = tx.Delete(k)
+			value, err := tx.Delete(k)
+			if err != nil {
+				continue
+			}
+			if err := s.recordDeleteTx(tx, k, value, false); err != nil {
+				return err
+			}
 		}
+		s.cache.invalidateExecutions(jobName)
 
 		return nil
 	}
@@ -588,7 +1060,88 @@ func (s *Store) Snapshot(w io.WriteCloser) error {
 
 // Restore load data created with backup in to Bunt
 func (s *Store) Restore(r io.ReadCloser) error {
-	return s.db.Load(r)
+	// Snapshots of a newer schema are refused before loading any of it
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := checkSnapshotSchema(data); err != nil {
+		return err
+	}
+
+	// Data loaded without a version predates schema versioning
+	err = s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(schemaVersionKey)
+		if err == buntdb.ErrNotFound {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// The cache is emptied again once loaded, dropping fills racing with it
+	s.cache.reset()
+	if err := s.db.Load(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	if err := s.upgrade(); err != nil {
+		return err
+	}
+	if s.executionRetention, err = s.GetExecutionRetention(); err != nil {
+		return err
+	}
+	if n, err := s.expireExecutions(false); err != nil {
+		return err
+	} else if n > 0 {
+		log.WithField("executions", n).Info("store: Set the retention of executions stored without it")
+	}
+	s.cache.reset()
+	if err := s.reindex(); err != nil {
+		return err
+	}
+	return s.loadScheduleMacros()
+}
+
+// reindex rebuilds the job search index from the stored jobs.
+func (s *Store) reindex() error {
+	s.index.reset()
+	return s.db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			if strings.HasPrefix(key, jobsPrefix+":") {
+				var pbj dkronpb.Job
+				if err := decodeJob([]byte(value), &pbj); err == nil {
+					s.index.update(&pbj)
+				}
+			}
+			return true
+		})
+	})
+}
+
+// SearchJobs returns the jobs matching the query, see parseQuery
+// for the query syntax.
+func (s *Store) SearchJobs(q string, regex bool) ([]*Job, error) {
+	terms, err := parseQuery(q, regex)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0)
+	for _, name := range s.index.search(terms) {
+		job, err := s.GetJob(name, nil)
+		if err != nil {
+			// Deleted after searching
+			if err == buntdb.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
 }
 
 func (s *Store) unmarshalExecutions(items []kv) ([]*Execution, error) {
@@ -596,11 +1149,12 @@ func (s *Store) unmarshalExecutions(items []kv) ([]*Execution, error) {
 	for _, item := range items {
 		var pbe dkronpb.Execution
 
-		if err := proto.Unmarshal(item.Value, &pbe); err != nil {
+		if err := decodeExecution(item.Value, &pbe); err != nil {
 			log.WithError(err).WithField("key", item.Key).Debug("error unmarshaling")
 			return nil, err
 		}
 		execution := NewExecutionFromProto(&pbe)
+		execution.setLegacyID()
 		executions = append(executions, execution)
 	}
 	return executions, nil
@@ -608,17 +1162,26 @@ func (s *Store) unmarshalExecutions(items []kv) ([]*Execution, error) {
 
 func (s *Store) computeStatus(jobName string, exGroup int64, tx *buntdb.Tx) (string, error) {
 	// compute job status based on execution group
+	executions, err := s.groupExecutionsTx(jobName, exGroup, tx)
+	if err != nil {
+		return "", err
+	}
+	return groupStatus(executions), nil
+}
+
+// groupExecutionsTx returns the stored executions of the execution group.
+func (s *Store) groupExecutionsTx(jobName string, exGroup int64, tx *buntdb.Tx) ([]*Execution, error) {
 	kvs := []kv{}
 	found := false
 	prefix := fmt.Sprintf("%s:%s:", executionsPrefix, jobName)
 
 	if err := s.listTxFunc(prefix, &kvs, &found)(tx); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	execs, err := s.unmarshalExecutions(kvs)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var executions []*Execution
@@ -627,20 +1190,31 @@ func (s *Store) computeStatus(jobName string, exGroup int64, tx *buntdb.Tx) (str
 			executions = append(executions, ex)
 		}
 	}
+	return executions, nil
+}
 
+// groupStatus returns the status of a job given the executions of its
+// last execution group. Skipped executions don't count unless all of
+// them were skipped.
+func groupStatus(executions []*Execution) string {
 	success := 0
 	failed := 0
+	skipped := 0
 
 	var status string
 	for _, ex := range executions {
-		if ex.Success {
+		if ex.Skipped {
+			skipped = skipped + 1
+		} else if ex.Success {
 			success = success + 1
 		} else {
 			failed = failed + 1
 		}
 	}
 
-	if failed == 0 {
+	if skipped > 0 && skipped == len(executions) {
+		status = StatusSkipped
+	} else if failed == 0 {
 		status = StatusSuccess
 	} else if failed > 0 && success == 0 {
 		status = StatusFailed
@@ -648,7 +1222,66 @@ func (s *Store) computeStatus(jobName string, exGroup int64, tx *buntdb.Tx) (str
 		status = StatusPartialyFailed
 	}
 
-	return status, nil
+	return status
+}
+
+// GetStatuses returns the status of the last execution group of every job
+// with executions, running ones included. The executions are read in a
+// single pass, their keys sort them by job and start time, and the result
+// is cached until the next write.
+func (s *Store) GetStatuses() (map[string]string, error) {
+	if statuses, ok := s.cache.jobStatuses(); ok {
+		return statuses, nil
+	}
+
+	gen := s.cache.generation()
+	lastGroups := map[string][]*dkronpb.Execution{}
+	prefix := executionsPrefix + ":"
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendGreaterOrEqual("", prefix, func(key, value string) bool {
+			if !strings.HasPrefix(key, prefix) {
+				return false
+			}
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				err = fmt.Errorf("key %s: %s", key, err)
+				return false
+			}
+			group := lastGroups[pbe.JobName]
+			switch {
+			case len(group) == 0 || pbe.Group > group[0].Group:
+				lastGroups[pbe.JobName] = []*dkronpb.Execution{&pbe}
+			case pbe.Group == group[0].Group:
+				lastGroups[pbe.JobName] = append(group, &pbe)
+			}
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]string, len(lastGroups))
+	for jobName, pbes := range lastGroups {
+		executions := make([]*Execution, 0, len(pbes))
+		running := false
+		for _, pbe := range pbes {
+			ex := NewExecutionFromProto(pbe)
+			running = running || ex.FinishedAt.IsZero()
+			executions = append(executions, ex)
+		}
+		if running {
+			statuses[jobName] = StatusRunning
+		} else {
+			statuses[jobName] = groupStatus(executions)
+		}
+		s.cache.setLastGroup(gen, jobName, pbes)
+	}
+	s.cache.setStatuses(gen, statuses)
+
+	return statuses, nil
 }
 
 func trimDirectoryKey(key []byte) []byte {
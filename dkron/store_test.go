@@ -1,8 +1,10 @@
 package dkron
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -162,13 +164,55 @@ func TestStore_ChildIsUpdatedAfterDeletingParentJob(t *testing.T) {
 	storeChildJob(t, s, "child1", "parent1")
 
 	_, err := s.DeleteJob("parent1")
-	assert.EqualError(t, err, ErrDependentJobs.Error())
+	assert.EqualError(t, err, ErrDependentJobs.Error()+": child1")
 
 	deleteJob(t, s, "child1")
 	_, err = s.DeleteJob("parent1")
 	assert.NoError(t, err)
 }
 
+func TestStore_DeleteJobsCascade(t *testing.T) {
+	s := setupStore(t)
+
+	storeJob(t, s, "root")
+	storeChildJob(t, s, "parent1", "root")
+	storeChildJob(t, s, "child1", "parent1")
+	storeChildJob(t, s, "child2", "parent1")
+	storeChildJob(t, s, "grandchild1", "child1")
+
+	_, err := s.DeleteJobs("parent1", nil)
+	assert.EqualError(t, err, ErrDependentJobs.Error()+": child1, child2")
+
+	jobs, err := s.DeleteJobs("parent1", &DeleteOptions{Cascade: true})
+	require.NoError(t, err)
+
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.Name)
+	}
+	assert.Equal(t, []string{"parent1", "child1", "child2", "grandchild1"}, names)
+
+	remaining, err := s.GetJobs(nil)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "root", remaining[0].Name)
+	assert.Empty(t, remaining[0].DependentJobs)
+
+	// Duplicated dependents and cycles are deleted once
+	storeChildJob(t, s, "a", "root")
+	storeChildJob(t, s, "b", "a")
+	a := loadJob(t, s, "a")
+	a.DependentJobs = []string{"b", "b"}
+	require.NoError(t, s.SetJob(a, false))
+	b := loadJob(t, s, "b")
+	b.DependentJobs = []string{"a"}
+	require.NoError(t, s.SetJob(b, false))
+
+	jobs, err = s.DeleteJobs("a", &DeleteOptions{Cascade: true})
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+}
+
 func TestStore_GetJobsWithMetadata(t *testing.T) {
 	s := setupStore(t)
 
@@ -334,6 +378,241 @@ func TestStore_GetLastExecutionGroup(t *testing.T) {
 	}
 }
 
+func TestStore_CircuitBreaker(t *testing.T) {
+	s := setupStore(t)
+
+	job := scaffoldJob()
+	job.Disabled = false
+	job.MaxConsecutiveFailures = 2
+	job.Retries = 1
+	require.NoError(t, s.SetJob(job, false))
+
+	now := time.Now()
+	fail := func(group int64, node string, attempt uint) {
+		_, err := s.SetExecutionDone(&Execution{
+			JobName:    job.Name,
+			StartedAt:  now.Add(time.Duration(attempt) * time.Second),
+			FinishedAt: now.Add(time.Duration(attempt) * time.Second),
+			Success:    false,
+			NodeName:   node,
+			Group:      group,
+			Attempt:    attempt,
+		})
+		require.NoError(t, err)
+	}
+
+	// Failed runs count once, after their last attempt on any node
+	fail(1, "node1", 1)
+	assert.Equal(t, 0, loadJob(t, s, job.Name).ConsecutiveFailures)
+	fail(1, "node1", 2)
+	fail(1, "node2", 2)
+	assert.Equal(t, 1, loadJob(t, s, job.Name).ConsecutiveFailures)
+	fail(2, "node1", 2)
+
+	job = loadJob(t, s, job.Name)
+	assert.Equal(t, StatusTripped, job.Status)
+	assert.Equal(t, 2, job.ConsecutiveFailures)
+	assert.True(t, job.Disabled)
+
+	// Updating a tripped job doesn't enable it
+	job.Disabled = false
+	require.NoError(t, s.SetJob(job, false))
+	job = loadJob(t, s, job.Name)
+	assert.True(t, job.Disabled)
+
+	job, err := s.ResetJob(job.Name)
+	require.NoError(t, err)
+	assert.Equal(t, StatusNotSet, job.Status)
+	assert.Equal(t, 0, job.ConsecutiveFailures)
+	assert.False(t, job.Disabled)
+}
+
+func TestStore_SearchJobs(t *testing.T) {
+	s := setupStore(t)
+
+	job := scaffoldJob()
+	job.Name = "report"
+	job.ExecutorConfig = map[string]string{"command": "psql -c 'select * from orders'"}
+	job.Metadata = map[string]string{"env": "prod"}
+	require.NoError(t, s.SetJob(job, false))
+
+	job = scaffoldJob()
+	job.Name = "cleanup"
+	job.OwnerTeam = "Platform"
+	job.Metadata = map[string]string{"env": "staging"}
+	require.NoError(t, s.SetJob(job, false))
+
+	names := func(jobs []*Job) []string {
+		var n []string
+		for _, j := range jobs {
+			n = append(n, j.Name)
+		}
+		return n
+	}
+
+	jobs, err := s.SearchJobs("ORDERS", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report"}, names(jobs))
+
+	jobs, err = s.SearchJobs("owner:platform", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cleanup"}, names(jobs))
+
+	jobs, err = s.SearchJobs("metadata.env:prod command:psql", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"report"}, names(jobs))
+
+	jobs, err = s.SearchJobs("name:^(report|cleanup)$", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cleanup", "report"}, names(jobs))
+
+	_, err = s.SearchJobs("table:orders", false)
+	assert.Equal(t, ErrUnknownSearchField, err)
+
+	_, err = s.SearchJobs(" ", false)
+	assert.Equal(t, ErrEmptyQuery, err)
+
+	deleteJob(t, s, "report")
+	jobs, err = s.SearchJobs("orders", false)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	// Jobs of rolled back transactions aren't indexed
+	pbj := scaffoldJob().ToProto()
+	pbj.Name = "rollback"
+	err = s.db.Update(func(tx *buntdb.Tx) error {
+		if err := s.setJobTxFunc(pbj)(tx); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+	jobs, err = s.SearchJobs("name:rollback", false)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestStore_SchemaUpgrade(t *testing.T) {
+	s := setupStore(t)
+
+	v, err := s.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, v)
+
+	// Snapshot without version
+	old := setupStore(t)
+	storeJob(t, old, "job1")
+	require.NoError(t, old.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(schemaVersionKey)
+		return err
+	}))
+	f, err := ioutil.TempFile("", "dkron-snapshot")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	require.NoError(t, old.Snapshot(f))
+	f.Seek(0, 0)
+
+	require.NoError(t, s.Restore(f))
+	v, err = s.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, v)
+	loadJob(t, s, "job1")
+
+	// Snapshot from a newer version
+	storeJob(t, old, "job3")
+	require.NoError(t, old.db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(schemaVersionKey, strconv.Itoa(SchemaVersion+1), nil)
+		return err
+	}))
+	f.Truncate(0)
+	f.Seek(0, 0)
+	require.NoError(t, old.Snapshot(f))
+	f.Seek(0, 0)
+
+	err = s.Restore(f)
+	assert.Equal(t, ErrSchemaTooNew{Version: SchemaVersion + 1}, err)
+
+	// Nothing of it was loaded
+	v, err = s.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, v)
+	_, err = s.GetJob("job3", nil)
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
+
+func TestStore_Check(t *testing.T) {
+	s := setupStore(t)
+
+	storeJob(t, s, "parent1")
+	storeChildJob(t, s, "child1", "parent1")
+	_, err := s.SetExecution(&Execution{
+		JobName:    "child1",
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+		NodeName:   "testNode",
+	})
+	require.NoError(t, err)
+
+	report, err := s.Check()
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, report.SchemaVersion)
+	assert.Equal(t, 2, report.Jobs)
+	assert.Equal(t, 2, report.DisabledJobs)
+	assert.Equal(t, 1, report.Executions)
+	assert.Empty(t, report.Problems)
+
+	// Break the references bypassing the store checks
+	require.NoError(t, s.db.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete("jobs:child1")
+		return err
+	}))
+
+	report, err = s.Check()
+	require.NoError(t, err)
+	require.Len(t, report.Problems, 2)
+	assert.Equal(t, ProblemOrphanedExecution, report.Problems[0].Kind)
+	assert.Equal(t, ProblemDanglingDependent, report.Problems[1].Kind)
+}
+
+func TestStore_Repair(t *testing.T) {
+	s := setupStore(t)
+
+	storeJob(t, s, "parent1")
+	storeChildJob(t, s, "child1", "parent1")
+	storeChildJob(t, s, "child2", "parent1")
+	_, err := s.SetExecution(&Execution{
+		JobName:    "gone",
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+		NodeName:   "testNode",
+	})
+	require.NoError(t, err)
+
+	// Duplicate a child, lose another and add a dangling one as old versions did
+	parent := loadJob(t, s, "parent1")
+	require.NoError(t, s.db.Update(func(tx *buntdb.Tx) error {
+		pbj := parent.ToProto()
+		pbj.DependentJobs = []string{"child1", "child1", "missing"}
+		return s.setJobTxFunc(pbj)(tx)
+	}))
+
+	report, err := s.Check()
+	require.NoError(t, err)
+	assert.Len(t, report.Problems, 4)
+
+	repaired, err := s.Repair()
+	require.NoError(t, err)
+	// Duplicate, dangling and lost child, orphaned execution
+	assert.Equal(t, 4, repaired)
+
+	report, err = s.Check()
+	require.NoError(t, err)
+	assert.Empty(t, report.Problems)
+
+	parent = loadJob(t, s, "parent1")
+	assert.Equal(t, []string{"child1", "child2"}, parent.DependentJobs)
+}
+
 func Test_computeStatus(t *testing.T) {
 	s, err := NewStore()
 	require.NoError(t, err)
@@ -404,6 +683,11 @@ func Test_computeStatus(t *testing.T) {
 	}
 	s.SetExecution(ex6)
 
+	// Skipped executions count only when all of the group were skipped
+	s.SetExecution(&Execution{JobName: "test", StartedAt: n.Add(50 * time.Millisecond), FinishedAt: n, Skipped: true, NodeName: "testNode1", Group: 5})
+	s.SetExecution(&Execution{JobName: "test", StartedAt: n.Add(60 * time.Millisecond), FinishedAt: n, Skipped: true, NodeName: "testNode1", Group: 6})
+	s.SetExecution(&Execution{JobName: "test", StartedAt: n.Add(70 * time.Millisecond), FinishedAt: n, Success: true, NodeName: "testNode2", Group: 6})
+
 	// Tests status
 	err = s.db.View(func(tx *buntdb.Tx) error {
 		status, _ := s.computeStatus("test", 1, tx)
@@ -418,13 +702,177 @@ func Test_computeStatus(t *testing.T) {
 		status, _ = s.computeStatus("test", 4, tx)
 		assert.Equal(t, StatusFailed, status)
 
+		status, _ = s.computeStatus("test", 5, tx)
+		assert.Equal(t, StatusSkipped, status)
+
+		status, _ = s.computeStatus("test", 6, tx)
+		assert.Equal(t, StatusSuccess, status)
+
 		return nil
 	})
 	require.NoError(t, err)
 }
 
+func TestStore_GetStatuses(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	n := time.Now()
+	executions := []*Execution{
+		{JobName: "partial", StartedAt: n, FinishedAt: n, Success: true, NodeName: "node1", Group: 1},
+		{JobName: "partial", StartedAt: n.Add(time.Millisecond), FinishedAt: n, NodeName: "node2", Group: 1},
+		{JobName: "running", StartedAt: n, FinishedAt: n, NodeName: "node1", Group: 1},
+		{JobName: "running", StartedAt: n.Add(time.Second), NodeName: "node1", Group: 2},
+	}
+	for _, ex := range executions {
+		_, err := s.SetExecution(ex)
+		require.NoError(t, err)
+	}
+
+	statuses, err := s.GetStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"partial": StatusPartialyFailed,
+		"running": StatusRunning,
+	}, statuses)
+
+	// The cached statuses follow the writes
+	executions[3].FinishedAt = n.Add(2 * time.Second)
+	executions[3].Success = true
+	_, err = s.SetExecution(executions[3])
+	require.NoError(t, err)
+	statuses, err = s.GetStatuses()
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuccess, statuses["running"])
+}
+
+func TestStore_GetExecutionByID(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	n := time.Now()
+	ex1 := &Execution{JobName: "job1", StartedAt: n, NodeName: "node1", Id: newULID(n)}
+	ex2 := &Execution{JobName: "job2", StartedAt: n, NodeName: "node1", Id: newULID(n)}
+	legacy := &Execution{JobName: "job2", StartedAt: n.Add(time.Second), NodeName: "node1"}
+	for _, ex := range []*Execution{ex1, ex2, legacy} {
+		_, err := s.SetExecution(ex)
+		require.NoError(t, err)
+	}
+
+	ex, err := s.GetExecutionByID(ex2.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "job2", ex.JobName)
+	assert.Equal(t, ex2.Id, ex.Id)
+
+	// Executions without an ID are found by their key
+	ex, err = s.GetExecutionByID(legacy.Key())
+	require.NoError(t, err)
+	assert.Equal(t, legacy.Key(), ex.Id)
+
+	_, err = s.GetExecutionByID(newULID(n))
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
+
+func TestStore_AnnotateExecution(t *testing.T) {
+	s := setupStore(t)
+	defer s.Shutdown()
+	storeJob(t, s, "test")
+
+	n := time.Now()
+	ex := &Execution{JobName: "test", StartedAt: n, NodeName: "node1", Annotations: []string{"backfill 1"}}
+	_, err := s.SetExecution(ex)
+	require.NoError(t, err)
+
+	annotated, err := s.AnnotateExecution("test", ex.Key(), []string{"flaky"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backfill 1", "flaky"}, annotated.Annotations)
+	_, err = s.AnnotateExecution("test", ex.Key(), []string{"see INC-12"})
+	require.NoError(t, err)
+
+	// Finishing the execution keeps the annotations made while it ran
+	ex.FinishedAt = n.Add(time.Second)
+	ex.Success = true
+	_, err = s.SetExecutionDone(ex)
+	require.NoError(t, err)
+	stored, err := s.GetExecution("test", ex.Key())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backfill 1", "flaky", "see INC-12"}, stored.Annotations)
+
+	_, err = s.AnnotateExecution("test", "missing", []string{"flaky"})
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
+
 // Following are supporting functions for the tests
 
+func TestStore_Trash(t *testing.T) {
+	s := setupStore(t)
+
+	storeJob(t, s, "parent1")
+	storeChildJob(t, s, "child1", "parent1")
+	_, err := s.SetExecution(&Execution{
+		JobName:    "child1",
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+		NodeName:   "testNode",
+	})
+	require.NoError(t, err)
+
+	deletedAt := time.Now().Add(-time.Minute)
+	_, err = s.DeleteJobs("child1", &DeleteOptions{
+		Now:             deletedAt,
+		TrashExpiresAt:  deletedAt.Add(time.Hour),
+		TrashExecutions: true,
+	})
+	require.NoError(t, err)
+
+	_, err = s.GetJob("child1", nil)
+	assert.Equal(t, buntdb.ErrNotFound, err)
+	assert.Empty(t, loadJob(t, s, "parent1").DependentJobs)
+
+	trash, err := s.GetTrash()
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, "child1", trash[0].Job.Name)
+	assert.Equal(t, 1, trash[0].Executions)
+	// The times of the command are kept as given
+	assert.True(t, deletedAt.Equal(trash[0].DeletedAt))
+	assert.True(t, deletedAt.Add(time.Hour).Equal(trash[0].ExpiresAt))
+
+	// Can't restore over an existing job
+	storeJob(t, s, "child1")
+	_, err = s.RestoreJob("child1")
+	assert.Equal(t, ErrJobExists, err)
+	deleteJob(t, s, "child1")
+
+	job, err := s.RestoreJob("child1")
+	require.NoError(t, err)
+	assert.Equal(t, "parent1", job.ParentJob)
+	assert.Equal(t, []string{"child1"}, loadJob(t, s, "parent1").DependentJobs)
+
+	execs, err := s.GetExecutions("child1")
+	require.NoError(t, err)
+	assert.Len(t, execs, 1)
+
+	trash, err = s.GetTrash()
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+
+	_, err = s.RestoreJob("child1")
+	assert.Equal(t, buntdb.ErrNotFound, err)
+
+	// Commands replayed once expired don't keep the job
+	_, err = s.DeleteJobs("child1", &DeleteOptions{
+		Now:            deletedAt.Add(-2 * time.Hour),
+		TrashExpiresAt: deletedAt.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	trash, err = s.GetTrash()
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+}
+
 func storeJob(t *testing.T, s *Store, jobName string) {
 	job := scaffoldJob()
 	job.Name = jobName
@@ -0,0 +1,161 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/tidwall/buntdb"
+)
+
+// Groupings of the execution summaries.
+const (
+	SummaryByDay    = "day"
+	SummaryByNode   = "node"
+	SummaryByStatus = "status"
+)
+
+// ErrInvalidGroupBy is returned when the grouping of an execution summary
+// is unknown.
+var ErrInvalidGroupBy = errors.New("invalid group by, use \"day\", \"node\" or \"status\"")
+
+// ExecutionSummary aggregates the executions of a job sharing a day, a
+// node or a status.
+type ExecutionSummary struct {
+	// Key of the group, the day as YYYY-MM-DD in the timezone of the job,
+	// the node name, or success, failed, skipped or running.
+	Key string `json:"key"`
+
+	Executions int `json:"executions"`
+	Successes  int `json:"successes"`
+	Failures   int `json:"failures"`
+	Skipped    int `json:"skipped"`
+	Running    int `json:"running"`
+
+	// Average duration of the finished executions.
+	AvgDuration time.Duration `json:"avg_duration"`
+
+	// Cost of the finished executions.
+	Cost float64 `json:"cost"`
+
+	total time.Duration
+}
+
+// summaryKey returns the key of the group of the execution.
+func summaryKey(pbe *dkronpb.Execution, startedAt time.Time, running bool, groupBy string, loc *time.Location) string {
+	switch groupBy {
+	case SummaryByDay:
+		return startedAt.In(loc).Format("2006-01-02")
+	case SummaryByNode:
+		return pbe.NodeName
+	}
+	if running {
+		return "running"
+	}
+	if pbe.Skipped {
+		return StatusSkipped
+	}
+	if pbe.Success {
+		return "success"
+	}
+	return "failed"
+}
+
+// GetExecutionSummary aggregates the executions of the job grouped by day
+// in loc, node or status, sorted by key. The executions are counted as
+// they are read, without keeping them.
+func (s *Store) GetExecutionSummary(jobName, groupBy string, loc *time.Location) ([]*ExecutionSummary, error) {
+	switch groupBy {
+	case SummaryByDay, SummaryByNode, SummaryByStatus:
+	default:
+		return nil, ErrInvalidGroupBy
+	}
+
+	groups := map[string]*ExecutionSummary{}
+	prefix := fmt.Sprintf("%s:%s:", executionsPrefix, jobName)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendGreaterOrEqual("", prefix, func(key, value string) bool {
+			if !strings.HasPrefix(key, prefix) {
+				return false
+			}
+			var pbe dkronpb.Execution
+			if err = decodeExecution([]byte(value), &pbe); err != nil {
+				err = fmt.Errorf("key %s: %s", key, err)
+				return false
+			}
+
+			startedAt, _ := ptypes.Timestamp(pbe.StartedAt)
+			finishedAt, ferr := ptypes.Timestamp(pbe.FinishedAt)
+			running := pbe.FinishedAt == nil || ferr != nil || finishedAt.IsZero()
+
+			k := summaryKey(&pbe, startedAt, running, groupBy, loc)
+			sum, ok := groups[k]
+			if !ok {
+				sum = &ExecutionSummary{Key: k}
+				groups[k] = sum
+			}
+			sum.Executions++
+			switch {
+			case running:
+				sum.Running++
+			case pbe.Skipped:
+				sum.Skipped++
+			case pbe.Success:
+				sum.Successes++
+			default:
+				sum.Failures++
+			}
+			if !running {
+				sum.total += finishedAt.Sub(startedAt)
+				sum.Cost += pbe.Cost
+			}
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*ExecutionSummary, 0, len(groups))
+	for _, sum := range groups {
+		if finished := sum.Executions - sum.Running; finished > 0 {
+			sum.AvgDuration = sum.total / time.Duration(finished)
+		}
+		summaries = append(summaries, sum)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Key < summaries[j].Key
+	})
+	return summaries, nil
+}
+
+// executionSummaryHandler serves the summary of the executions of the job.
+func (h *HTTPTransport) executionSummaryHandler(c *gin.Context) {
+	job, err := h.agent.Store.GetJob(c.Param("job"), nil)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	groupBy := c.DefaultQuery("group_by", SummaryByDay)
+	summaries, err := h.agent.Store.GetExecutionSummary(job.Name, groupBy, job.location())
+	if err == ErrInvalidGroupBy {
+		c.AbortWithStatus(http.StatusBadRequest)
+		c.Writer.WriteString(err.Error())
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	renderJSON(c, http.StatusOK, summaries)
+}
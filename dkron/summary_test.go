@@ -0,0 +1,60 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_GetExecutionSummary(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	require.NoError(t, s.SetJob(&Job{Name: "report", Schedule: "@every 1h", Executor: "shell", Disabled: true}, false))
+
+	day := time.Date(2020, 3, 10, 22, 0, 0, 0, time.UTC)
+	for i, ex := range []*Execution{
+		{JobName: "report", NodeName: "a", StartedAt: day, FinishedAt: day.Add(time.Minute), Success: true},
+		{JobName: "report", NodeName: "b", StartedAt: day.Add(time.Hour), FinishedAt: day.Add(time.Hour + 3*time.Minute)},
+		{JobName: "report", NodeName: "a", StartedAt: day.Add(3 * time.Hour), FinishedAt: day.Add(3*time.Hour + 2*time.Minute), Success: true},
+		{JobName: "report", NodeName: "a", StartedAt: day.Add(4 * time.Hour)},
+	} {
+		ex.Group = int64(i)
+		ex.Attempt = 1
+		_, err := s.SetExecution(ex)
+		require.NoError(t, err)
+	}
+
+	byDay, err := s.GetExecutionSummary("report", SummaryByDay, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, byDay, 2)
+	assert.Equal(t, "2020-03-10", byDay[0].Key)
+	assert.Equal(t, 2, byDay[0].Executions)
+	assert.Equal(t, 2*time.Minute, byDay[0].AvgDuration)
+	assert.Equal(t, 1, byDay[1].Running)
+
+	// Days are counted in the given timezone
+	loc := time.FixedZone("UTC-5", -5*3600)
+	byDay, err = s.GetExecutionSummary("report", SummaryByDay, loc)
+	require.NoError(t, err)
+	require.Len(t, byDay, 1)
+	assert.Equal(t, 4, byDay[0].Executions)
+
+	byNode, err := s.GetExecutionSummary("report", SummaryByNode, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, byNode, 2)
+	assert.Equal(t, "a", byNode[0].Key)
+	assert.Equal(t, 3, byNode[0].Executions)
+	assert.Equal(t, 2, byNode[0].Successes)
+
+	byStatus, err := s.GetExecutionSummary("report", SummaryByStatus, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, byStatus, 3)
+	assert.Equal(t, []string{"failed", "running", "success"}, []string{byStatus[0].Key, byStatus[1].Key, byStatus[2].Key})
+
+	_, err = s.GetExecutionSummary("report", "week", time.UTC)
+	assert.Equal(t, ErrInvalidGroupBy, err)
+}
@@ -0,0 +1,236 @@
+package dkron
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// defaultTimelineWindow is the window of the timeline when no start is given.
+	defaultTimelineWindow = 24 * time.Hour
+	// maxTimelineWindow bounds the window of the timeline.
+	maxTimelineWindow = 31 * 24 * time.Hour
+)
+
+// Timeline lists the executions of all the jobs overlapping a time window,
+// shaped to be rendered as a Gantt chart with a lane per node.
+type Timeline struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Nodes that run executions in the window, one lane each.
+	Nodes []string `json:"nodes"`
+
+	// Executions overlapping the window, by start time.
+	Items []*TimelineItem `json:"items"`
+
+	// Execution groups of the items, linked to the run of the parent
+	// job that triggered them.
+	Groups []*TimelineGroup `json:"groups"`
+}
+
+// TimelineItem is an execution in a timeline.
+type TimelineItem struct {
+	Job         string    `json:"job"`
+	Group       int64     `json:"group"`
+	Attempt     uint      `json:"attempt"`
+	Node        string    `json:"node"`
+	Status      string    `json:"status"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end,omitempty"`
+	ScheduledAt time.Time `json:"scheduled_at,omitempty"`
+}
+
+// TimelineGroup is an execution group in a timeline.
+type TimelineGroup struct {
+	Job       string    `json:"job"`
+	Namespace string    `json:"namespace"`
+	Group     int64     `json:"group"`
+	Status    string    `json:"status"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end,omitempty"`
+
+	// Run of the parent job after which this one started, when both
+	// are in the timeline.
+	ParentJob   string `json:"parent_job,omitempty"`
+	ParentGroup int64  `json:"parent_group,omitempty"`
+}
+
+// BuildTimeline returns the executions overlapping the window, of the
+// jobs of the given namespace or all of them if empty.
+func BuildTimeline(store Storage, from, to time.Time, namespace string) (*Timeline, error) {
+	jobs, err := store.GetJobs(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Timeline{
+		From:   from,
+		To:     to,
+		Nodes:  []string{},
+		Items:  []*TimelineItem{},
+		Groups: []*TimelineGroup{},
+	}
+	nodes := map[string]bool{}
+	parents := map[string]string{}
+	groupsByJob := map[string][]*TimelineGroup{}
+
+	for _, job := range jobs {
+		if namespace != "" && job.Namespace() != namespace {
+			continue
+		}
+		parents[job.Name] = job.ParentJob
+
+		executions, err := store.GetExecutions(job.Name)
+		if err != nil && err != buntdb.ErrNotFound {
+			return nil, err
+		}
+
+		groups := map[int64][]*Execution{}
+		for _, ex := range executions {
+			// Running executions have no end yet
+			if !ex.StartedAt.Before(to) || (!ex.FinishedAt.IsZero() && !ex.FinishedAt.After(from)) {
+				continue
+			}
+			groups[ex.Group] = append(groups[ex.Group], ex)
+
+			item := &TimelineItem{
+				Job:         ex.JobName,
+				Group:       ex.Group,
+				Attempt:     ex.Attempt,
+				Node:        ex.NodeName,
+				Status:      executionStatus(ex),
+				Start:       ex.StartedAt,
+				End:         ex.FinishedAt,
+				ScheduledAt: ex.ScheduledAt,
+			}
+			t.Items = append(t.Items, item)
+			nodes[ex.NodeName] = true
+		}
+
+		for group, exs := range groups {
+			g := &TimelineGroup{
+				Job:       job.Name,
+				Namespace: job.Namespace(),
+				Group:     group,
+				Status:    StatusSuccess,
+			}
+			running := false
+			for _, ex := range exs {
+				if g.Start.IsZero() || ex.StartedAt.Before(g.Start) {
+					g.Start = ex.StartedAt
+				}
+				if ex.FinishedAt.IsZero() {
+					running = true
+				} else if ex.FinishedAt.After(g.End) {
+					g.End = ex.FinishedAt
+				}
+			}
+			skipped := 0
+			attempts := lastAttempts(exs)
+			for _, ex := range attempts {
+				if ex.Skipped {
+					skipped++
+				} else if !ex.FinishedAt.IsZero() && !ex.Success {
+					g.Status = StatusFailed
+				}
+			}
+			if skipped == len(attempts) {
+				g.Status = StatusSkipped
+			}
+			if running {
+				g.Status = StatusRunning
+				g.End = time.Time{}
+			}
+			groupsByJob[job.Name] = append(groupsByJob[job.Name], g)
+			t.Groups = append(t.Groups, g)
+		}
+	}
+
+	// Dependent jobs run right after a successful run of their parent,
+	// link each run to the last one of its parent finished before it.
+	for _, g := range t.Groups {
+		parent := parents[g.Job]
+		if parent == "" {
+			continue
+		}
+		var link *TimelineGroup
+		for _, pg := range groupsByJob[parent] {
+			if pg.Status != StatusSuccess || pg.End.After(g.Start) {
+				continue
+			}
+			if link == nil || pg.End.After(link.End) {
+				link = pg
+			}
+		}
+		if link != nil {
+			g.ParentJob = parent
+			g.ParentGroup = link.Group
+		}
+	}
+
+	for n := range nodes {
+		t.Nodes = append(t.Nodes, n)
+	}
+	sort.Strings(t.Nodes)
+	sort.SliceStable(t.Items, func(i, j int) bool {
+		return t.Items[i].Start.Before(t.Items[j].Start)
+	})
+	sort.SliceStable(t.Groups, func(i, j int) bool {
+		return t.Groups[i].Start.Before(t.Groups[j].Start)
+	})
+
+	return t, nil
+}
+
+// executionStatus returns whether the execution is running, succeeded,
+// failed or was skipped.
+func executionStatus(ex *Execution) string {
+	switch {
+	case ex.FinishedAt.IsZero():
+		return StatusRunning
+	case ex.Skipped:
+		return StatusSkipped
+	case ex.Success:
+		return StatusSuccess
+	default:
+		return StatusFailed
+	}
+}
+
+func (h *HTTPTransport) timelineHandler(c *gin.Context) {
+	to := time.Now()
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid to %q", v)})
+			return
+		}
+		to = t
+	}
+	from := to.Add(-defaultTimelineWindow)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid from %q", v)})
+			return
+		}
+		from = t
+	}
+	if !from.Before(to) || to.Sub(from) > maxTimelineWindow {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("from must be before to and the window at most %s", maxTimelineWindow)})
+		return
+	}
+
+	t, err := BuildTimeline(h.agent.Store, from, to, c.Query("namespace"))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, t)
+}
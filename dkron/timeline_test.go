@@ -0,0 +1,67 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	for _, j := range []*Job{
+		{Name: "extract", Schedule: "@every 1h", Executor: "shell"},
+		{Name: "load", ParentJob: "extract", Executor: "shell"},
+		{Name: "billing", Schedule: "@every 1h", Executor: "shell", Metadata: map[string]string{namespaceKey: "payments"}},
+	} {
+		require.NoError(t, s.SetJob(j, true))
+	}
+
+	from := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(6 * time.Hour)
+	executions := []*Execution{
+		// Finished before the window
+		{JobName: "extract", Group: 1, Attempt: 1, NodeName: "n1", StartedAt: from.Add(-2 * time.Hour), FinishedAt: from.Add(-time.Hour), Success: true},
+		// Overlaps the start of the window
+		{JobName: "extract", Group: 2, Attempt: 1, NodeName: "n1", StartedAt: from.Add(-time.Minute), FinishedAt: from.Add(time.Hour), Success: true},
+		{JobName: "load", Group: 3, Attempt: 1, NodeName: "n2", StartedAt: from.Add(time.Hour), FinishedAt: from.Add(2 * time.Hour)},
+		{JobName: "load", Group: 3, Attempt: 2, NodeName: "n2", StartedAt: from.Add(2 * time.Hour), FinishedAt: from.Add(3 * time.Hour), Success: true},
+		{JobName: "billing", Group: 4, Attempt: 1, NodeName: "n3", StartedAt: from.Add(5 * time.Hour)},
+		// Starts after the window
+		{JobName: "billing", Group: 5, Attempt: 1, NodeName: "n3", StartedAt: to.Add(time.Minute)},
+	}
+	for _, e := range executions {
+		_, err := s.SetExecution(e)
+		require.NoError(t, err)
+	}
+
+	tl, err := BuildTimeline(s, from, to, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"n1", "n2", "n3"}, tl.Nodes)
+	require.Len(t, tl.Items, 4)
+	assert.Equal(t, int64(2), tl.Items[0].Group)
+	assert.Equal(t, StatusFailed, tl.Items[1].Status)
+	assert.Equal(t, StatusSuccess, tl.Items[2].Status)
+	assert.Equal(t, StatusRunning, tl.Items[3].Status)
+
+	require.Len(t, tl.Groups, 3)
+	assert.Equal(t, "extract", tl.Groups[0].Job)
+	assert.Equal(t, "load", tl.Groups[1].Job)
+	assert.Equal(t, StatusSuccess, tl.Groups[1].Status)
+	assert.Equal(t, from.Add(3*time.Hour), tl.Groups[1].End)
+	assert.Equal(t, "extract", tl.Groups[1].ParentJob)
+	assert.Equal(t, int64(2), tl.Groups[1].ParentGroup)
+	assert.Equal(t, "payments", tl.Groups[2].Namespace)
+	assert.Equal(t, StatusRunning, tl.Groups[2].Status)
+	assert.True(t, tl.Groups[2].End.IsZero())
+
+	tl, err = BuildTimeline(s, from, to, "payments")
+	require.NoError(t, err)
+	assert.Len(t, tl.Items, 1)
+	assert.Equal(t, []string{"n3"}, tl.Nodes)
+}
@@ -0,0 +1,125 @@
+package dkron
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiTokenHeader is the header carrying the API token of a request.
+const apiTokenHeader = "X-Dkron-Token"
+
+var (
+	// ErrAPIToken is returned when a request changing or running jobs has
+	// no known API token.
+	ErrAPIToken = errors.New("missing or unknown API token")
+	// ErrExecutorNotAllowed is returned when the API token of a request
+	// can't use the executor of a job.
+	ErrExecutorNotAllowed = errors.New("executor not allowed for the API token")
+)
+
+// APIToken grants the requests carrying it the use of some executors.
+type APIToken struct {
+	// Name of the token, reported in the errors.
+	Name string `mapstructure:"name"`
+
+	// Token sent in the X-Dkron-Token header.
+	Token string `mapstructure:"token"`
+
+	// Executors of the jobs the token can change or run, all when empty.
+	Executors []string `mapstructure:"executors"`
+}
+
+// allows returns whether the token can use the executor.
+func (t *APIToken) allows(executor string) bool {
+	if len(t.Executors) == 0 {
+		return true
+	}
+	for _, e := range t.Executors {
+		if e == executor {
+			return true
+		}
+	}
+	return false
+}
+
+// executors returns the executors the job runs with, of the job, its
+// steps and its canary.
+func (j *Job) executors() []string {
+	var executors []string
+	if len(j.Steps) == 0 {
+		executors = append(executors, j.Executor)
+	}
+	for _, s := range j.Steps {
+		executors = append(executors, s.Executor)
+	}
+	if j.Canary != nil {
+		executors = append(executors, j.Canary.Executor)
+	}
+	if j.Matrix != nil && j.Matrix.Discovery != nil {
+		executors = append(executors, j.Matrix.Discovery.Executor)
+	}
+	if j.Precheck != nil {
+		executors = append(executors, j.Precheck.Executor)
+	}
+	return executors
+}
+
+// apiToken returns the configured API token the request carries.
+func (h *HTTPTransport) apiToken(c *gin.Context) *APIToken {
+	header := []byte(c.GetHeader(apiTokenHeader))
+	if len(header) == 0 {
+		// Webhook senders like Alertmanager only send bearer tokens
+		header = []byte(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	for _, t := range h.agent.config.APITokens {
+		if t != nil && t.Token != "" && subtle.ConstantTimeCompare(header, []byte(t.Token)) == 1 {
+			return t
+		}
+	}
+	return nil
+}
+
+// executorsAllowed returns an error if the API token of the request can't
+// use the executors of the job. When API tokens are configured, requests
+// changing or running jobs must carry one of them or the admin token.
+func (h *HTTPTransport) executorsAllowed(c *gin.Context, job *Job) error {
+	if len(h.agent.config.APITokens) == 0 || h.isAdmin(c) {
+		return nil
+	}
+
+	token := h.apiToken(c)
+	if token == nil {
+		return ErrAPIToken
+	}
+	for _, e := range job.executors() {
+		if !token.allows(e) {
+			return fmt.Errorf("%s %s: %s", ErrExecutorNotAllowed, token.Name, e)
+		}
+	}
+	return nil
+}
+
+// checkExecutors aborts the request if its API token can't use the
+// executors of the jobs.
+func (h *HTTPTransport) checkExecutors(c *gin.Context, jobs ...*Job) bool {
+	for _, job := range jobs {
+		if err := h.executorsAllowed(c, job); err != nil {
+			if err == ErrAPIToken {
+				c.AbortWithStatus(http.StatusUnauthorized)
+			} else {
+				c.AbortWithStatus(http.StatusForbidden)
+			}
+			c.Writer.WriteString(fmt.Sprintf("%s: %s", job.Name, err))
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package dkron
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckExecutors(t *testing.T) {
+	h := &HTTPTransport{agent: &Agent{config: &Config{AdminToken: "admin"}}}
+	request := func(header, token string, job *Job) (bool, int) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest(http.MethodPost, "/v1/jobs", nil)
+		if token != "" {
+			c.Request.Header.Set(header, token)
+		}
+		ok := h.checkExecutors(c, job)
+		return ok, w.Code
+	}
+
+	job := scaffoldJob()
+
+	// Without API tokens any request passes
+	ok, _ := request(apiTokenHeader, "", job)
+	assert.True(t, ok)
+
+	h.agent.config.APITokens = []*APIToken{
+		{Name: "ci", Token: "ci-secret", Executors: []string{"http"}},
+		{Name: "ops", Token: "ops-secret"},
+	}
+
+	ok, code := request(apiTokenHeader, "", job)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	ok, code = request(apiTokenHeader, "ci-secret", job)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	ok, _ = request(apiTokenHeader, "ops-secret", job)
+	assert.True(t, ok)
+	ok, _ = request(adminTokenHeader, "admin", job)
+	assert.True(t, ok)
+	ok, _ = request("Authorization", "Bearer ops-secret", job)
+	assert.True(t, ok)
+	ok, _ = request("Authorization", "Basic ops-secret", job)
+	assert.False(t, ok)
+
+	// Steps, canaries, matrix discoveries and pre-checks are checked instead of and along the job executor
+	job.Executor = "http"
+	ok, _ = request(apiTokenHeader, "ci-secret", job)
+	assert.True(t, ok)
+	job.Canary = &Canary{Executor: "shell"}
+	ok, _ = request(apiTokenHeader, "ci-secret", job)
+	assert.False(t, ok)
+	job.Canary = nil
+	job.Matrix = &Matrix{Discovery: &Step{Name: "discover", Executor: "shell"}}
+	ok, _ = request(apiTokenHeader, "ci-secret", job)
+	assert.False(t, ok)
+	job.Matrix = nil
+	job.Precheck = &Precheck{Executor: "shell"}
+	ok, _ = request(apiTokenHeader, "ci-secret", job)
+	assert.False(t, ok)
+	job.Precheck = nil
+	job.Steps = []*Step{{Name: "build", Executor: "shell"}}
+	ok, _ = request(apiTokenHeader, "ci-secret", job)
+	assert.False(t, ok)
+}
@@ -0,0 +1,197 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	trashPrefix = "trash"
+)
+
+var (
+	// ErrJobExists is returned when restoring or cloning a job over an existing job.
+	ErrJobExists = errors.New("store: a job with the same name already exists")
+)
+
+// TrashedJob is a deleted job kept in the trash until it expires.
+type TrashedJob struct {
+	// The deleted job.
+	Job *Job `json:"job"`
+
+	// Number of executions kept with the job.
+	Executions int `json:"executions"`
+
+	// When the job was deleted.
+	DeletedAt time.Time `json:"deleted_at"`
+
+	// When the job will be permanently deleted.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// trashJobTxFunc keeps the job deleted at now in the trash until expiresAt,
+// along with its executions if withExecutions is set. Both times come with
+// the command deleting the job so all the servers keep the same.
+func (s *Store) trashJobTxFunc(pbj *dkronpb.Job, now, expiresAt time.Time, withExecutions bool) func(tx *buntdb.Tx) error {
+	return func(tx *buntdb.Tx) error {
+		// Already expired when replaying an old command
+		ttl := time.Until(expiresAt)
+		if ttl <= 0 {
+			return nil
+		}
+
+		tj := &dkronpb.TrashedJob{
+			Job: proto.Clone(pbj).(*dkronpb.Job),
+		}
+		tj.DeletedAt, _ = ptypes.TimestampProto(now)
+		tj.ExpiresAt, _ = ptypes.TimestampProto(expiresAt)
+		// Dependent jobs are added back when they are restored
+		tj.Job.DependentJobs = nil
+
+		if withExecutions {
+			kvs := []kv{}
+			found := false
+			prefix := fmt.Sprintf("%s:%s:", executionsPrefix, pbj.Name)
+			if err := s.listTxFunc(prefix, &kvs, &found)(tx); err != nil {
+				return err
+			}
+			for _, item := range kvs {
+				var pbe dkronpb.Execution
+				if err := decodeExecution(item.Value, &pbe); err != nil {
+					return err
+				}
+				tj.Executions = append(tj.Executions, &pbe)
+			}
+		}
+
+		b, err := proto.Marshal(tj)
+		if err != nil {
+			return err
+		}
+		_, _, err = tx.Set(fmt.Sprintf("%s:%s", trashPrefix, pbj.Name), string(b), &buntdb.SetOptions{
+			Expires: true,
+			TTL:     ttl,
+		})
+		return err
+	}
+}
+
+// GetTrash returns the jobs in the trash.
+func (s *Store) GetTrash() ([]*TrashedJob, error) {
+	trash := []*TrashedJob{}
+
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(trashPrefix+":*", func(key, value string) bool {
+			var tj dkronpb.TrashedJob
+			if err = proto.Unmarshal([]byte(value), &tj); err != nil {
+				return false
+			}
+			ttl, terr := tx.TTL(key)
+			if terr != nil {
+				err = terr
+				return false
+			}
+			deletedAt, _ := ptypes.Timestamp(tj.DeletedAt)
+			expiresAt := time.Now().Add(ttl)
+			if tj.ExpiresAt != nil {
+				expiresAt, _ = ptypes.Timestamp(tj.ExpiresAt)
+			}
+
+			trash = append(trash, &TrashedJob{
+				Job:        NewJobFromProto(tj.Job),
+				Executions: len(tj.Executions),
+				DeletedAt:  deletedAt,
+				ExpiresAt:  expiresAt,
+			})
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trash, nil
+}
+
+// RestoreJob moves a job and its executions back from the trash, adding it
+// back to its parent, in a single transaction.
+func (s *Store) RestoreJob(name string) (*Job, error) {
+	key := fmt.Sprintf("%s:%s", trashPrefix, name)
+
+	var job *Job
+	var tj dkronpb.TrashedJob
+	err := s.db.Update(func(tx *buntdb.Tx) error {
+		value, err := tx.Get(key)
+		if err != nil {
+			return err
+		}
+		if err := proto.Unmarshal([]byte(value), &tj); err != nil {
+			return err
+		}
+
+		var pbej dkronpb.Job
+		err = s.getJobTxFunc(name, &pbej)(tx)
+		if err == nil {
+			return ErrJobExists
+		}
+		if err != buntdb.ErrNotFound {
+			return err
+		}
+
+		job = NewJobFromProto(tj.Job)
+		if err := job.Validate(); err != nil {
+			return err
+		}
+		var parent dkronpb.Job
+		if job.ParentJob != "" {
+			if err := s.getJobTxFunc(job.ParentJob, &parent)(tx); err != nil {
+				if err == buntdb.ErrNotFound {
+					return ErrParentJobNotFound
+				}
+				return err
+			}
+		}
+
+		if job.Next, err = job.GetNext(); err != nil {
+			return err
+		}
+		if err := s.versionJobTxFunc(job, &Job{})(tx); err != nil {
+			return err
+		}
+		if err := s.setJobTxFunc(job.ToProto())(tx); err != nil {
+			return err
+		}
+		if job.ParentJob != "" {
+			parent.DependentJobs = append(parent.DependentJobs, job.Name)
+			if err := s.setJobTxFunc(&parent)(tx); err != nil {
+				return err
+			}
+		}
+
+		for _, pbe := range tj.Executions {
+			ekey := fmt.Sprintf("%s:%s:%s", executionsPrefix, name, NewExecutionFromProto(pbe).Key())
+			if err := s.setExecutionTxFunc(ekey, pbe)(tx); err != nil {
+				return err
+			}
+		}
+		_, err = tx.Delete(key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.index.update(job.ToProto())
+	log.WithField("job", name).WithField("executions", len(tj.Executions)).
+		Info("store: Restored job from trash")
+
+	return job, nil
+}
@@ -0,0 +1,193 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/hashicorp/serf/serf"
+	"github.com/sirupsen/logrus"
+)
+
+// Events of the members triggering jobs.
+const (
+	TriggerMemberJoin   = "member-join"
+	TriggerMemberLeave  = "member-leave"
+	TriggerMemberFailed = "member-failed"
+)
+
+// Events of the Alertmanager alerts triggering jobs.
+const (
+	TriggerAlertFiring   = "alert-firing"
+	TriggerAlertResolved = "alert-resolved"
+)
+
+// ErrInvalidTrigger is returned when a member trigger is not valid.
+var ErrInvalidTrigger = errors.New("invalid trigger")
+
+// MemberTrigger runs a job when a member of the cluster having the tags
+// joins, leaves or fails, or when an alert having the labels fires or
+// resolves.
+type MemberTrigger struct {
+	// Event of the member, member-join, member-leave or member-failed, or
+	// of the alert, alert-firing or alert-resolved.
+	Event string `json:"event"`
+
+	// Tags the member must have, any member when empty.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Labels the alert must have, at least one.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func triggersFromProto(in []*proto.MemberTrigger) []*MemberTrigger {
+	var triggers []*MemberTrigger
+	for _, t := range in {
+		triggers = append(triggers, &MemberTrigger{
+			Event:  t.Event,
+			Tags:   t.Tags,
+			Labels: t.Labels,
+		})
+	}
+	return triggers
+}
+
+func triggersToProto(triggers []*MemberTrigger) []*proto.MemberTrigger {
+	var out []*proto.MemberTrigger
+	for _, t := range triggers {
+		out = append(out, &proto.MemberTrigger{
+			Event:  t.Event,
+			Tags:   t.Tags,
+			Labels: t.Labels,
+		})
+	}
+	return out
+}
+
+func validateTriggers(triggers []*MemberTrigger) error {
+	for i, t := range triggers {
+		if t == nil {
+			return fmt.Errorf("%s: trigger %d is empty", ErrInvalidTrigger, i+1)
+		}
+		switch t.Event {
+		case TriggerMemberJoin, TriggerMemberLeave, TriggerMemberFailed:
+			if len(t.Labels) > 0 {
+				return fmt.Errorf("%s: member events have no labels, use tags", ErrInvalidTrigger)
+			}
+		case TriggerAlertFiring, TriggerAlertResolved:
+			if len(t.Labels) == 0 {
+				return fmt.Errorf("%s: alert events need the labels of the alerts", ErrInvalidTrigger)
+			}
+			if len(t.Tags) > 0 {
+				return fmt.Errorf("%s: alert events have no tags, use labels", ErrInvalidTrigger)
+			}
+		default:
+			return fmt.Errorf("%s: unknown event %q", ErrInvalidTrigger, t.Event)
+		}
+	}
+	return nil
+}
+
+// memberEventTrigger returns the trigger event of the serf event, if any.
+func memberEventTrigger(t serf.EventType) string {
+	switch t {
+	case serf.EventMemberJoin:
+		return TriggerMemberJoin
+	case serf.EventMemberLeave:
+		return TriggerMemberLeave
+	case serf.EventMemberFailed:
+		return TriggerMemberFailed
+	}
+	return ""
+}
+
+// matches returns whether the event of the member fires the trigger.
+func (t *MemberTrigger) matches(event string, m serf.Member) bool {
+	if t.Event != event {
+		return false
+	}
+	for k, v := range t.Tags {
+		if m.Tags[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// memberParams returns the params of the executions triggered by the member.
+func memberParams(m serf.Member) map[string]string {
+	params := map[string]string{
+		"member_name":   m.Name,
+		"member_addr":   m.Addr.String(),
+		"member_status": m.Status.String(),
+	}
+	for k, v := range m.Tags {
+		params["member_tag_"+k] = v
+	}
+	return params
+}
+
+// triggerMemberJobs runs the jobs triggered by the event of the members,
+// only in the leader so every event runs them once.
+func (a *Agent) triggerMemberJobs(me serf.MemberEvent) {
+	if !a.config.Server || !a.IsLeader() {
+		return
+	}
+	event := memberEventTrigger(me.EventType())
+	if event == "" {
+		return
+	}
+
+	jobs, err := a.Store.GetJobs(nil)
+	if err != nil {
+		log.WithError(err).Error("agent: Error getting the jobs triggered by member events")
+		return
+	}
+
+	for _, job := range jobs {
+		if job.Disabled || len(job.Triggers) == 0 {
+			continue
+		}
+		for _, m := range me.Members {
+			if !job.triggeredBy(event, m) {
+				continue
+			}
+			if err := a.checkMinInterval(job, time.Now()); err != nil {
+				log.WithError(err).WithField("job", job.Name).Warn("agent: Skipping job triggered by member event")
+				continue
+			}
+			now := time.Now()
+			if err := a.useBudget(job, now); err != nil {
+				log.WithError(err).WithField("job", job.Name).Warn("agent: Skipping job triggered by member event")
+				continue
+			}
+			log.WithFields(logrus.Fields{
+				"job":    job.Name,
+				"event":  event,
+				"member": m.Name,
+			}).Info("agent: Running job triggered by member event")
+
+			ex := NewExecution(job.Name)
+			ex.Params = memberParams(m)
+			ex.Annotations = []string{fmt.Sprintf("%s %s", event, m.Name)}
+			go func(job *Job) {
+				if _, err := a.Run(job.Name, ex); err != nil {
+					log.WithError(err).WithField("job", job.Name).Error("agent: Error running job triggered by member event")
+					a.refundBudget(job, now)
+				}
+			}(job)
+		}
+	}
+}
+
+// triggeredBy returns whether any trigger of the job fires on the event of
+// the member.
+func (j *Job) triggeredBy(event string, m serf.Member) bool {
+	for _, t := range j.Triggers {
+		if t.matches(event, m) {
+			return true
+		}
+	}
+	return false
+}
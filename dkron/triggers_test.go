@@ -0,0 +1,38 @@
+package dkron
+
+import (
+	"net"
+	"testing"
+
+	"github.com/hashicorp/serf/serf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberTriggers(t *testing.T) {
+	job := scaffoldJob()
+	job.Triggers = []*MemberTrigger{
+		{Event: TriggerMemberJoin, Tags: map[string]string{"role": "web"}},
+		{Event: TriggerMemberFailed},
+	}
+	assert.NoError(t, job.Validate())
+
+	web := serf.Member{Name: "web3", Addr: net.ParseIP("10.0.0.3"), Status: serf.StatusAlive, Tags: map[string]string{"role": "web"}}
+	db := serf.Member{Name: "db1", Addr: net.ParseIP("10.0.0.9"), Status: serf.StatusFailed, Tags: map[string]string{"role": "db"}}
+
+	assert.True(t, job.triggeredBy(memberEventTrigger(serf.EventMemberJoin), web))
+	assert.False(t, job.triggeredBy(memberEventTrigger(serf.EventMemberJoin), db))
+	assert.True(t, job.triggeredBy(memberEventTrigger(serf.EventMemberFailed), db))
+	assert.False(t, job.triggeredBy(memberEventTrigger(serf.EventMemberLeave), web))
+
+	params := memberParams(web)
+	assert.Equal(t, "web3", params["member_name"])
+	assert.Equal(t, "10.0.0.3", params["member_addr"])
+	assert.Equal(t, "alive", params["member_status"])
+	assert.Equal(t, "web", params["member_tag_role"])
+
+	// Triggers survive the round trip through the store
+	assert.Equal(t, job.Triggers, NewJobFromProto(job.ToProto()).Triggers)
+
+	job.Triggers = []*MemberTrigger{{Event: "member-update"}}
+	assert.Error(t, job.Validate())
+}
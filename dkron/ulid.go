@@ -0,0 +1,58 @@
+package dkron
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+// ulidAlphabet is the Crockford base32 alphabet used by ULIDs.
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidLen is the length of an encoded ULID.
+const ulidLen = 26
+
+// newULID returns a ULID for the given time: 48 bits with the time in
+// milliseconds followed by 80 random bits, encoded in 26 characters that
+// sort like the time.
+func newULID(t time.Time) string {
+	var b [16]byte
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> uint(40-8*i))
+	}
+	rand.Read(b[6:])
+
+	// 128 bits are encoded in 130, padded with two leading zero bits
+	out := make([]byte, ulidLen)
+	for i := range out {
+		var v byte
+		for bit := i*5 - 2; bit < i*5+3; bit++ {
+			v <<= 1
+			if bit >= 0 {
+				v |= (b[bit/8] >> uint(7-bit%8)) & 1
+			}
+		}
+		out[i] = ulidAlphabet[v]
+	}
+	return string(out)
+}
+
+// ulidTime returns the time of the ULID in milliseconds, ok is false if
+// the id isn't a ULID.
+func ulidTime(id string) (ms int64, ok bool) {
+	if len(id) != ulidLen {
+		return 0, false
+	}
+	for i := 0; i < ulidLen; i++ {
+		v := strings.IndexByte(ulidAlphabet, id[i])
+		if v < 0 || (i == 0 && v > 7) {
+			return 0, false
+		}
+		// The first 10 characters hold the time
+		if i < 10 {
+			ms = ms<<5 | int64(v)
+		}
+	}
+	return ms, true
+}
@@ -0,0 +1,27 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestULID(t *testing.T) {
+	now := time.Now()
+	id := newULID(now)
+	assert.Len(t, id, ulidLen)
+	assert.NotEqual(t, id, newULID(now))
+
+	ms, ok := ulidTime(id)
+	assert.True(t, ok)
+	assert.Equal(t, now.UnixNano()/int64(time.Millisecond), ms)
+
+	// IDs sort like their time
+	assert.Less(t, newULID(now.Add(-time.Second)), id)
+
+	for _, invalid := range []string{"", "1591890443004513000-dkron1", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "01E9ZB3K8WQ4T5V6X7Y8Z9A0BU"} {
+		_, ok := ulidTime(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
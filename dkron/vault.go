@@ -0,0 +1,294 @@
+package dkron
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	proto "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/sirupsen/logrus"
+)
+
+// vaultTimeout is the timeout of the requests to the Vault API.
+const vaultTimeout = 10 * time.Second
+
+var (
+	// ErrInvalidSecret is returned when a secret of a job is not valid.
+	ErrInvalidSecret = errors.New("invalid secret")
+	// ErrNoVault is returned when running a job with secrets without the
+	// address of Vault configured.
+	ErrNoVault = errors.New("vault-addr is not configured")
+
+	// secretEnvName matches the valid environment variable names.
+	secretEnvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Secret is a value read from Vault by the agent running the job just
+// before the run, like a key of a KV secret or dynamic database
+// credentials. Executors get it by its env name, the shell executor as an
+// environment variable.
+type Secret struct {
+	// Env is the name the executors get the secret by.
+	Env string `json:"env"`
+
+	// Path of the secret in Vault, like secret/data/billing or
+	// database/creds/reporting. Secrets of the same path are read once
+	// per run, sharing their lease.
+	Path string `json:"path"`
+
+	// Key of the value in the data of the secret.
+	Key string `json:"key"`
+}
+
+func secretsFromProto(in []*proto.JobSecret) []*Secret {
+	var secrets []*Secret
+	for _, s := range in {
+		secrets = append(secrets, &Secret{Env: s.Env, Path: s.Path, Key: s.Key})
+	}
+	return secrets
+}
+
+func secretsToProto(secrets []*Secret) []*proto.JobSecret {
+	var out []*proto.JobSecret
+	for _, s := range secrets {
+		out = append(out, &proto.JobSecret{Env: s.Env, Path: s.Path, Key: s.Key})
+	}
+	return out
+}
+
+// validateSecrets checks that every secret has a path, a key and a unique
+// valid env name.
+func (j *Job) validateSecrets() error {
+	names := map[string]bool{}
+	for i, s := range j.Secrets {
+		if s == nil || !secretEnvName.MatchString(s.Env) {
+			return fmt.Errorf("%s: secret %d has no valid env name", ErrInvalidSecret, i+1)
+		}
+		if names[s.Env] {
+			return fmt.Errorf("%s: duplicated secret %s", ErrInvalidSecret, s.Env)
+		}
+		names[s.Env] = true
+		if s.Path == "" || s.Key == "" {
+			return fmt.Errorf("%s: secret %s needs a path and a key", ErrInvalidSecret, s.Env)
+		}
+	}
+	return nil
+}
+
+// vaultClient reads secrets from Vault with the token of the agent, or
+// with the token of its AppRole login.
+type vaultClient struct {
+	addr     string
+	token    string
+	roleID   string
+	secretID string
+	client   *http.Client
+
+	mu sync.Mutex
+	// loginToken is the token of the last AppRole login, valid until
+	// loginExpires.
+	loginToken   string
+	loginExpires time.Time
+}
+
+// newVaultClient returns the Vault client of the configuration, nil when
+// the address of Vault is not configured.
+func newVaultClient(c *Config) *vaultClient {
+	if c.VaultAddr == "" {
+		return nil
+	}
+	return &vaultClient{
+		addr:     strings.TrimSuffix(c.VaultAddr, "/"),
+		token:    c.VaultToken,
+		roleID:   c.VaultRoleID,
+		secretID: c.VaultSecretID,
+		client:   &http.Client{Timeout: vaultTimeout},
+	}
+}
+
+// vaultResponse is the part of the responses of the Vault API in use.
+type vaultResponse struct {
+	LeaseID string                 `json:"lease_id"`
+	Data    map[string]interface{} `json:"data"`
+	Auth    *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// do sends a request to the Vault API with the token, decoding its response.
+func (v *vaultClient) do(method, path, token string, body interface{}) (*vaultResponse, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, v.addr+"/v1/"+strings.TrimPrefix(path, "/"), r)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var vr vaultResponse
+	if resp.StatusCode == http.StatusNoContent {
+		return &vr, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&vr); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault: %s %s returned %s %s", method, path, resp.Status, strings.Join(vr.Errors, ", "))
+	}
+	return &vr, nil
+}
+
+// authToken returns the token of the requests, logging in with the
+// AppRole when there's no static token and the last login is expiring.
+func (v *vaultClient) authToken() (string, error) {
+	if v.token != "" {
+		return v.token, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.loginToken != "" && time.Now().Before(v.loginExpires) {
+		return v.loginToken, nil
+	}
+
+	vr, err := v.do("POST", "auth/approle/login", "", map[string]string{
+		"role_id":   v.roleID,
+		"secret_id": v.secretID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if vr.Auth == nil || vr.Auth.ClientToken == "" {
+		return "", errors.New("vault: AppRole login returned no token")
+	}
+	// Log in again before the token expires
+	ttl := time.Duration(vr.Auth.LeaseDuration) * time.Second
+	v.loginToken = vr.Auth.ClientToken
+	v.loginExpires = time.Now().Add(ttl * 9 / 10)
+	return v.loginToken, nil
+}
+
+// read returns the data of the secret at the path and its lease, if it
+// has one. The data of KV version 2 secrets is unwrapped.
+func (v *vaultClient) read(path string) (map[string]interface{}, string, error) {
+	token, err := v.authToken()
+	if err != nil {
+		return nil, "", err
+	}
+	vr, err := v.do("GET", path, token, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data := vr.Data
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	return data, vr.LeaseID, nil
+}
+
+// revoke revokes the leases, logging the ones that fail as they expire
+// on their own anyway.
+func (v *vaultClient) revoke(leases []string) {
+	if len(leases) == 0 {
+		return
+	}
+	token, err := v.authToken()
+	if err != nil {
+		log.WithError(err).Error("vault: Error revoking leases")
+		return
+	}
+	for _, id := range leases {
+		if _, err := v.do("PUT", "sys/leases/revoke", token, map[string]string{"lease_id": id}); err != nil {
+			log.WithError(err).WithField("lease", id).Error("vault: Error revoking lease")
+		}
+	}
+}
+
+// resolveSecrets reads the secrets of the job, returning their values by
+// env name and the leases to revoke once the run is done.
+func (v *vaultClient) resolveSecrets(secrets []*proto.JobSecret) (map[string]string, []string, error) {
+	if len(secrets) == 0 {
+		return nil, nil, nil
+	}
+	if v == nil {
+		return nil, nil, ErrNoVault
+	}
+
+	values := map[string]string{}
+	var leases []string
+	read := map[string]map[string]interface{}{}
+	for _, s := range secrets {
+		data, ok := read[s.Path]
+		if !ok {
+			var lease string
+			var err error
+			data, lease, err = v.read(s.Path)
+			if err != nil {
+				return nil, leases, err
+			}
+			if lease != "" {
+				leases = append(leases, lease)
+			}
+			read[s.Path] = data
+		}
+
+		value, ok := data[s.Key]
+		if !ok {
+			return nil, leases, fmt.Errorf("vault: Secret %s has no key %s", s.Path, s.Key)
+		}
+		switch val := value.(type) {
+		case string:
+			values[s.Env] = val
+		default:
+			b, _ := json.Marshal(val)
+			values[s.Env] = string(b)
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"secrets": len(secrets),
+		"leases":  len(leases),
+	}).Debug("vault: Read secrets")
+	return values, leases, nil
+}
+
+// redactSecrets replaces the values of the secrets in the output.
+func redactSecrets(b []byte, values map[string]string) []byte {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		b = bytes.ReplaceAll(b, []byte(v), []byte(redactedText))
+	}
+	return b
+}
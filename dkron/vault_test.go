@@ -0,0 +1,117 @@
+package dkron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultSecrets(t *testing.T) {
+	var logins, reads int
+	var revoked []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/approle/login" {
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["role_id"] != "role" || body["secret_id"] != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"errors": ["invalid role or secret ID"]}`)
+				return
+			}
+			logins++
+			fmt.Fprint(w, `{"auth": {"client_token": "s.approle", "lease_duration": 3600}}`)
+			return
+		}
+		if r.Header.Get("X-Vault-Token") != "s.approle" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors": ["permission denied"]}`)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/database/creds/reporting":
+			reads++
+			fmt.Fprint(w, `{"lease_id": "database/creds/reporting/h1", "data": {"username": "v-reporting", "password": "A1a-xyz"}}`)
+		case "/v1/secret/data/smtp":
+			fmt.Fprint(w, `{"data": {"data": {"password": "hunter2", "port": 587}, "metadata": {"version": 3}}}`)
+		case "/v1/sys/leases/revoke":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			revoked = append(revoked, body["lease_id"])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors": []}`)
+		}
+	}))
+	defer ts.Close()
+
+	job := scaffoldJob()
+	job.Secrets = []*Secret{
+		{Env: "PGUSER", Path: "database/creds/reporting", Key: "username"},
+		{Env: "PGPASSWORD", Path: "database/creds/reporting", Key: "password"},
+		{Env: "SMTP_PASSWORD", Path: "secret/data/smtp", Key: "password"},
+		{Env: "SMTP_PORT", Path: "secret/data/smtp", Key: "port"},
+	}
+	require.NoError(t, job.Validate())
+	pbSecrets := job.ToProto().Secrets
+
+	var v *vaultClient
+	_, _, err := v.resolveSecrets(pbSecrets)
+	assert.Equal(t, ErrNoVault, err)
+
+	c := DefaultConfig()
+	c.VaultAddr = ts.URL + "/"
+	c.VaultRoleID = "role"
+	c.VaultSecretID = "secret"
+	v = newVaultClient(c)
+
+	values, leases, err := v.resolveSecrets(pbSecrets)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PGUSER":        "v-reporting",
+		"PGPASSWORD":    "A1a-xyz",
+		"SMTP_PASSWORD": "hunter2",
+		"SMTP_PORT":     "587",
+	}, values)
+	assert.Equal(t, []string{"database/creds/reporting/h1"}, leases)
+	assert.Equal(t, 1, reads)
+
+	v.revoke(leases)
+	assert.Equal(t, leases, revoked)
+	assert.Equal(t, 1, logins)
+
+	_, _, err = v.resolveSecrets(job.ToProto().Secrets[2:3])
+	require.NoError(t, err)
+	job.Secrets[2].Key = "user"
+	_, leases, err = v.resolveSecrets(job.ToProto().Secrets)
+	assert.Error(t, err)
+	assert.Len(t, leases, 1)
+
+	c.VaultSecretID = "wrong"
+	_, _, err = newVaultClient(c).resolveSecrets(pbSecrets)
+	assert.Error(t, err)
+
+	assert.Equal(t, []byte("user [REDACTED] logged in"), redactSecrets([]byte("user v-reporting logged in"), values))
+}
+
+func TestSecretValidate(t *testing.T) {
+	job := scaffoldJob()
+	job.Secrets = []*Secret{{Env: "API_TOKEN", Path: "secret/data/api", Key: "token"}}
+	assert.NoError(t, job.Validate())
+	assert.Equal(t, job.Secrets, NewJobFromProto(job.ToProto()).Secrets)
+
+	job.Secrets = []*Secret{{Env: "API-TOKEN", Path: "secret/data/api", Key: "token"}}
+	assert.Error(t, job.Validate())
+	job.Secrets = []*Secret{{Env: "API_TOKEN", Path: "secret/data/api"}}
+	assert.Error(t, job.Validate())
+	job.Secrets = []*Secret{
+		{Env: "API_TOKEN", Path: "secret/data/api", Key: "token"},
+		{Env: "API_TOKEN", Path: "secret/data/other", Key: "token"},
+	}
+	assert.Error(t, job.Validate())
+}
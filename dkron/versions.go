@@ -0,0 +1,180 @@
+package dkron
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	dkronpb "github.com/distribworks/dkron/v3/plugin/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/protobuf/proto"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	jobVersionsPrefix = "versions"
+
+	// jobVersionsKept is the number of versions kept per job, the oldest
+	// are deleted first.
+	jobVersionsKept = 100
+)
+
+// ErrNoJobVersion is returned when a job had no version at the requested
+// time or number.
+var ErrNoJobVersion = errors.New("store: job version not found")
+
+func jobVersionKey(name string, version int64) string {
+	return fmt.Sprintf("%s:%s:%020d", jobVersionsPrefix, name, version)
+}
+
+// changeTime returns the time the leader stamps a change of the job with,
+// now or just after the stored change if the clock is behind it, so every
+// change is a new version.
+func (a *Agent) changeTime(name string) time.Time {
+	now := time.Now()
+	if ej, err := a.Store.GetJob(name, nil); err == nil && ej.UpdatedAt.HasValue() && !now.After(ej.UpdatedAt.Get()) {
+		return ej.UpdatedAt.Get().Add(time.Millisecond)
+	}
+	return now
+}
+
+// versionJobTxFunc numbers the version of the job being stored. Changes
+// made through the API, that update the job, are a new version kept in the
+// store. The writes of the server, like run status, keep the version of
+// the stored job ej. Versions survive the job, a job created again with
+// the same name continues the numbering.
+func (s *Store) versionJobTxFunc(job, ej *Job) func(tx *buntdb.Tx) error {
+	return func(tx *buntdb.Tx) error {
+		if ej.Name != "" && !job.UpdatedAt.After(ej.UpdatedAt) {
+			job.Version = ej.Version
+			return nil
+		}
+
+		var keys []string
+		err := tx.AscendKeys(fmt.Sprintf("%s:%s:*", jobVersionsPrefix, job.Name), func(key, value string) bool {
+			keys = append(keys, key)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		last := ej.Version
+		if len(keys) > 0 {
+			var pbj dkronpb.Job
+			value, err := tx.Get(keys[len(keys)-1])
+			if err != nil {
+				return err
+			}
+			if err := proto.Unmarshal([]byte(value), &pbj); err != nil {
+				return err
+			}
+			if pbj.Version > last {
+				last = pbj.Version
+			}
+		}
+		job.Version = last + 1
+
+		jb, err := proto.Marshal(job.ToProto())
+		if err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(jobVersionKey(job.Name, job.Version), string(jb), nil); err != nil {
+			return err
+		}
+
+		for len(keys) >= jobVersionsKept {
+			if _, err := tx.Delete(keys[0]); err != nil {
+				return err
+			}
+			keys = keys[1:]
+		}
+		return nil
+	}
+}
+
+// GetJobVersions returns the kept versions of the job, oldest first.
+func (s *Store) GetJobVersions(name string) ([]*Job, error) {
+	versions := []*Job{}
+
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(fmt.Sprintf("%s:%s:*", jobVersionsPrefix, name), func(key, value string) bool {
+			var pbj dkronpb.Job
+			if err = proto.Unmarshal([]byte(value), &pbj); err != nil {
+				return false
+			}
+			versions = append(versions, NewJobFromProto(&pbj))
+			return true
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// jobVersionAsOf returns the version of the job in effect at the time,
+// the last one updated before it.
+func jobVersionAsOf(versions []*Job, t time.Time) (*Job, error) {
+	var found *Job
+	for _, v := range versions {
+		if v.UpdatedAt.HasValue() && v.UpdatedAt.Get().After(t) {
+			break
+		}
+		found = v
+	}
+	if found == nil {
+		return nil, ErrNoJobVersion
+	}
+	return found, nil
+}
+
+// jobVersion returns the version of the job with the number.
+func jobVersion(versions []*Job, n int64) (*Job, error) {
+	for _, v := range versions {
+		if v.Version == n {
+			return v, nil
+		}
+	}
+	return nil, ErrNoJobVersion
+}
+
+// jobVersionHandler returns the spec of the job as it was at the time
+// given with ?as_of=<RFC3339>, or the version given with ?version=<n>. The
+// job_version of the executions links them to the version they ran with,
+// the job may have been deleted since.
+func (h *HTTPTransport) jobVersionHandler(c *gin.Context) {
+	versions, err := h.agent.Store.GetJobVersions(c.Param("job"))
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	var job *Job
+	if v, ok := c.GetQuery("version"); ok {
+		n, perr := strconv.ParseInt(v, 10, 64)
+		if perr != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid version %q", v)})
+			return
+		}
+		job, err = jobVersion(versions, n)
+	} else {
+		v := c.Query("as_of")
+		t, perr := time.Parse(time.RFC3339, v)
+		if perr != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid as_of %q", v)})
+			return
+		}
+		job, err = jobVersionAsOf(versions, t)
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	renderRead(c, http.StatusOK, job)
+}
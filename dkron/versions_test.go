@@ -0,0 +1,123 @@
+package dkron
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_JobVersions(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	t0 := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	job := &Job{Name: "report", Schedule: "@every 1h", Executor: "shell"}
+	job.UpdatedAt.Set(t0)
+	require.NoError(t, s.SetJob(job, false))
+	assert.Equal(t, int64(1), job.Version)
+
+	// Writes of the server keep the version
+	stored, err := s.GetJob("report", nil)
+	require.NoError(t, err)
+	stored.SuccessCount = 3
+	require.NoError(t, s.SetJob(stored, false))
+	assert.Equal(t, int64(1), stored.Version)
+
+	changed := &Job{Name: "report", Schedule: "@every 2h", Executor: "shell"}
+	changed.UpdatedAt.Set(t0.Add(time.Hour))
+	require.NoError(t, s.SetJob(changed, false))
+	assert.Equal(t, int64(2), changed.Version)
+
+	versions, err := s.GetJobVersions("report")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "@every 1h", versions[0].Schedule)
+	assert.Equal(t, "@every 2h", versions[1].Schedule)
+
+	v, err := jobVersionAsOf(versions, t0.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), v.Version)
+	v, err = jobVersionAsOf(versions, t0.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), v.Version)
+	_, err = jobVersionAsOf(versions, t0.Add(-time.Minute))
+	assert.Equal(t, ErrNoJobVersion, err)
+	_, err = jobVersion(versions, 3)
+	assert.Equal(t, ErrNoJobVersion, err)
+
+	// A job created again continues the numbering
+	_, err = s.DeleteJob("report")
+	require.NoError(t, err)
+	recreated := &Job{Name: "report", Schedule: "@every 3h", Executor: "shell"}
+	require.NoError(t, s.SetJob(recreated, false))
+	assert.Equal(t, int64(3), recreated.Version)
+
+	// The oldest versions are deleted
+	for i := 0; i < jobVersionsKept; i++ {
+		j := &Job{Name: "report", Schedule: "@every 3h", Executor: "shell"}
+		j.UpdatedAt.Set(time.Now().Add(time.Duration(i+1) * time.Second))
+		require.NoError(t, s.SetJob(j, false))
+	}
+	versions, err = s.GetJobVersions("report")
+	require.NoError(t, err)
+	require.Len(t, versions, jobVersionsKept)
+	assert.Equal(t, int64(4), versions[0].Version)
+	assert.Equal(t, int64(jobVersionsKept+3), versions[len(versions)-1].Version)
+}
+
+func TestAPIJobAsOf(t *testing.T) {
+	dir, a := setupAPITest(t, "8142")
+	defer os.RemoveAll(dir)
+	defer a.Stop()
+
+	job := &Job{Name: "report", Schedule: "@every 1h", Executor: "shell", Disabled: true}
+	require.NoError(t, a.GRPCClient.SetJob(job, ""))
+	time.Sleep(10 * time.Millisecond)
+	between := time.Now()
+	time.Sleep(time.Second)
+	job.Schedule = "@every 2h"
+	job.UpdatedAt.Set(time.Now())
+	require.NoError(t, a.GRPCClient.SetJob(job, ""))
+
+	get := func(query string) (*http.Response, *Job) {
+		resp, err := http.Get("http://localhost:8142/v1/jobs/report" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var j Job
+		json.NewDecoder(resp.Body).Decode(&j)
+		return resp, &j
+	}
+
+	resp, j := get("")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(2), j.Version)
+
+	resp, j = get("?as_of=" + between.UTC().Format(time.RFC3339Nano))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(1), j.Version)
+	assert.Equal(t, "@every 1h", j.Schedule)
+
+	resp, j = get("?version=2")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "@every 2h", j.Schedule)
+
+	resp, _ = get("?as_of=2001-01-01T00:00:00Z")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = get("?as_of=yesterday")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Changes stamped by a node with a slow clock are still new versions
+	job.Schedule = "@every 3h"
+	job.UpdatedAt.Set(time.Now().Add(-time.Hour))
+	require.NoError(t, a.GRPCClient.SetJob(job, ""))
+	resp, j = get("")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(3), j.Version)
+	assert.True(t, j.UpdatedAt.Get().After(between))
+}
@@ -0,0 +1,174 @@
+package dkron
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/buntdb"
+)
+
+// Workflow is the dependency tree of a job with the latest run of every
+// job in it, shaped to be rendered as a DAG or a Gantt chart.
+type Workflow struct {
+	Root  string          `json:"root"`
+	Nodes []*WorkflowNode `json:"nodes"`
+	Edges []*WorkflowEdge `json:"edges"`
+
+	// Span of the latest runs of the jobs in the workflow.
+	StartedAt  time.Time `json:"started_at,omitempty"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+}
+
+// WorkflowNode is a job of a workflow.
+type WorkflowNode struct {
+	Job       string `json:"job"`
+	ParentJob string `json:"parent_job,omitempty"`
+	// Distance to the root of the workflow.
+	Depth    int    `json:"depth"`
+	Schedule string `json:"schedule,omitempty"`
+	Disabled bool   `json:"disabled"`
+	Status   string `json:"status"`
+
+	// Latest run of the job, nil if it never ran.
+	LastRun *WorkflowRun `json:"last_run"`
+}
+
+// WorkflowRun is an execution group of a job, summarized.
+type WorkflowRun struct {
+	Group      int64     `json:"group"`
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	Duration   string    `json:"duration,omitempty"`
+	Nodes      []string  `json:"nodes"`
+}
+
+// WorkflowEdge links a job to one of its dependent jobs.
+type WorkflowEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// buildWorkflow walks the dependent jobs of the root job breadth first,
+// so parents are always listed before their children.
+func buildWorkflow(s Storage, root string) (*Workflow, error) {
+	job, err := s.GetJob(root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workflow{
+		Root:  root,
+		Nodes: []*WorkflowNode{},
+		Edges: []*WorkflowEdge{},
+	}
+	seen := map[string]bool{root: true}
+	queue := []*WorkflowNode{{Job: root}}
+	jobs := map[string]*Job{root: job}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		j := jobs[n.Job]
+		n.Disabled = j.Disabled
+		n.Status = j.Status
+		if n.Depth == 0 {
+			n.ParentJob = j.ParentJob
+			n.Schedule = j.Schedule
+		}
+
+		if n.LastRun, err = lastWorkflowRun(s, n.Job); err != nil {
+			return nil, err
+		}
+		if r := n.LastRun; r != nil {
+			if w.StartedAt.IsZero() || r.StartedAt.Before(w.StartedAt) {
+				w.StartedAt = r.StartedAt
+			}
+			if r.FinishedAt.After(w.FinishedAt) {
+				w.FinishedAt = r.FinishedAt
+			}
+		}
+		w.Nodes = append(w.Nodes, n)
+
+		children := append([]string{}, j.DependentJobs...)
+		sort.Strings(children)
+		for _, c := range children {
+			if seen[c] {
+				continue
+			}
+			cj, err := s.GetJob(c, nil)
+			if err == buntdb.ErrNotFound {
+				// Dangling reference, fsck reports it
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			seen[c] = true
+			jobs[c] = cj
+			w.Edges = append(w.Edges, &WorkflowEdge{From: n.Job, To: c})
+			queue = append(queue, &WorkflowNode{Job: c, ParentJob: n.Job, Depth: n.Depth + 1})
+		}
+	}
+
+	return w, nil
+}
+
+// lastWorkflowRun summarizes the last execution group of a job.
+func lastWorkflowRun(s Storage, jobName string) (*WorkflowRun, error) {
+	executions, err := s.GetLastExecutionGroup(jobName)
+	if err == buntdb.ErrNotFound || len(executions) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r := &WorkflowRun{
+		Group:  executions[0].Group,
+		Status: StatusSuccess,
+		Nodes:  []string{},
+	}
+	running := false
+	for _, e := range executions {
+		if r.StartedAt.IsZero() || e.StartedAt.Before(r.StartedAt) {
+			r.StartedAt = e.StartedAt
+		}
+		if e.FinishedAt.IsZero() {
+			running = true
+		} else if e.FinishedAt.After(r.FinishedAt) {
+			r.FinishedAt = e.FinishedAt
+		}
+	}
+	for node, e := range lastAttempts(executions) {
+		r.Nodes = append(r.Nodes, node)
+		if !e.FinishedAt.IsZero() && !e.Success && !e.Skipped {
+			r.Status = StatusFailed
+		}
+	}
+	sort.Strings(r.Nodes)
+
+	if running {
+		r.Status = StatusRunning
+		r.FinishedAt = time.Time{}
+	} else {
+		r.Duration = r.FinishedAt.Sub(r.StartedAt).String()
+	}
+	return r, nil
+}
+
+func (h *HTTPTransport) workflowHandler(c *gin.Context) {
+	w, err := buildWorkflow(h.agent.Store, c.Param("root"))
+	if err == buntdb.ErrNotFound {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	renderJSON(c, http.StatusOK, w)
+}
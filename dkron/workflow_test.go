@@ -0,0 +1,79 @@
+package dkron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/buntdb"
+)
+
+func TestBuildWorkflow(t *testing.T) {
+	s, err := NewStore()
+	require.NoError(t, err)
+	defer s.Shutdown()
+
+	for _, j := range []*Job{
+		{Name: "extract", Schedule: "@every 1h", Executor: "shell"},
+		{Name: "transform", ParentJob: "extract", Executor: "shell"},
+		{Name: "report", ParentJob: "transform", Executor: "shell"},
+		{Name: "archive", ParentJob: "extract", Executor: "shell"},
+	} {
+		require.NoError(t, s.SetJob(j, true))
+	}
+
+	start := time.Date(2020, 5, 1, 2, 0, 0, 0, time.UTC)
+	executions := []*Execution{
+		{JobName: "extract", Group: 1, Attempt: 1, NodeName: "n1", StartedAt: start, FinishedAt: start.Add(time.Minute), Success: true},
+		// The failed first attempt is retried successfully
+		{JobName: "transform", Group: 2, Attempt: 1, NodeName: "n1", StartedAt: start.Add(time.Minute), FinishedAt: start.Add(2 * time.Minute)},
+		{JobName: "transform", Group: 2, Attempt: 2, NodeName: "n1", StartedAt: start.Add(2 * time.Minute), FinishedAt: start.Add(3 * time.Minute), Success: true},
+		{JobName: "archive", Group: 3, Attempt: 1, NodeName: "n2", StartedAt: start.Add(time.Minute), FinishedAt: start.Add(5 * time.Minute)},
+		{JobName: "report", Group: 4, Attempt: 1, NodeName: "n1", StartedAt: start.Add(3 * time.Minute)},
+	}
+	for _, e := range executions {
+		_, err := s.SetExecution(e)
+		require.NoError(t, err)
+	}
+
+	w, err := buildWorkflow(s, "extract")
+	require.NoError(t, err)
+
+	require.Len(t, w.Nodes, 4)
+	names := []string{}
+	for _, n := range w.Nodes {
+		names = append(names, n.Job)
+	}
+	assert.Equal(t, []string{"extract", "archive", "transform", "report"}, names)
+	assert.Equal(t, []*WorkflowEdge{
+		{From: "extract", To: "archive"},
+		{From: "extract", To: "transform"},
+		{From: "transform", To: "report"},
+	}, w.Edges)
+
+	assert.Equal(t, "@every 1h", w.Nodes[0].Schedule)
+	assert.Equal(t, 2, w.Nodes[3].Depth)
+	assert.Equal(t, "transform", w.Nodes[3].ParentJob)
+
+	assert.Equal(t, StatusSuccess, w.Nodes[0].LastRun.Status)
+	assert.Equal(t, "1m0s", w.Nodes[0].LastRun.Duration)
+	assert.Equal(t, StatusFailed, w.Nodes[1].LastRun.Status)
+	assert.Equal(t, []string{"n2"}, w.Nodes[1].LastRun.Nodes)
+	assert.Equal(t, StatusSuccess, w.Nodes[2].LastRun.Status)
+	assert.Equal(t, "2m0s", w.Nodes[2].LastRun.Duration)
+	assert.Equal(t, StatusRunning, w.Nodes[3].LastRun.Status)
+	assert.True(t, w.Nodes[3].LastRun.FinishedAt.IsZero())
+
+	assert.Equal(t, start, w.StartedAt)
+	assert.Equal(t, start.Add(5*time.Minute), w.FinishedAt)
+
+	// Subtrees can be requested too
+	w, err = buildWorkflow(s, "transform")
+	require.NoError(t, err)
+	assert.Len(t, w.Nodes, 2)
+	assert.Equal(t, "extract", w.Nodes[0].ParentJob)
+
+	_, err = buildWorkflow(s, "missing")
+	assert.Equal(t, buntdb.ErrNotFound, err)
+}
@@ -0,0 +1,162 @@
+package dkron
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// workspaceInterval is how often agents collect old workspaces.
+const workspaceInterval = 10 * time.Minute
+
+// workspaces are the directories the executions of an agent run in, one
+// each, kept after the execution finishes until they're collected by age
+// or by the size of all of them.
+type workspaces struct {
+	sync.Mutex
+	dir       string
+	retention time.Duration
+	maxSize   int64
+	active    map[string]bool
+}
+
+// newWorkspaces returns the workspaces in dir, maxSize is in MB.
+func newWorkspaces(dir string, retention time.Duration, maxSize int64) (*workspaces, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &workspaces{
+		dir:       dir,
+		retention: retention,
+		maxSize:   maxSize << 20,
+		active:    map[string]bool{},
+	}, nil
+}
+
+// Create creates the empty workspace of an execution.
+func (ws *workspaces) Create(jobName string, group int64) (string, error) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	dir, err := ioutil.TempDir(ws.dir, fmt.Sprintf("%s-%d-", jobName, group))
+	if err != nil {
+		return "", err
+	}
+	ws.active[dir] = true
+	return dir, nil
+}
+
+// Release marks the workspace as finished, it's removed right away
+// without retention.
+func (ws *workspaces) Release(dir string) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	delete(ws.active, dir)
+	if ws.retention <= 0 {
+		if err := os.RemoveAll(dir); err != nil {
+			log.WithError(err).WithField("workspace", dir).Error("agent: Error removing workspace")
+		}
+		return
+	}
+	// The modification time tells when the execution finished
+	now := time.Now()
+	os.Chtimes(dir, now, now)
+}
+
+// workspace is a finished workspace found by Collect.
+type workspace struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// Collect removes the finished workspaces older than the retention, then
+// the oldest ones until all of them fit in the max size. It returns the
+// number of workspaces removed.
+func (ws *workspaces) Collect(now time.Time) (int, error) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	infos, err := ioutil.ReadDir(ws.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var finished []*workspace
+	var total int64
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		path := filepath.Join(ws.dir, info.Name())
+		w := &workspace{path: path, modTime: info.ModTime(), size: dirSize(path)}
+		total += w.size
+		// Running executions keep their workspace, counted in the size
+		if !ws.active[path] {
+			finished = append(finished, w)
+		}
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].modTime.Before(finished[j].modTime)
+	})
+
+	removed := 0
+	for _, w := range finished {
+		expired := ws.retention > 0 && now.Sub(w.modTime) > ws.retention
+		oversize := ws.maxSize > 0 && total > ws.maxSize
+		if !expired && !oversize {
+			break
+		}
+		if err := os.RemoveAll(w.path); err != nil {
+			return removed, err
+		}
+		total -= w.size
+		removed++
+	}
+	return removed, nil
+}
+
+// dirSize returns the size of the files in the directory, ignoring the
+// ones it can't read.
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// collectWorkspaces collects the old workspaces until the agent is shut
+// down, starting with the ones left by a previous run of the agent.
+func (a *Agent) collectWorkspaces() {
+	ticker := time.NewTicker(workspaceInterval)
+	defer ticker.Stop()
+
+	for {
+		removed, err := a.workspaces.Collect(time.Now())
+		if err != nil {
+			log.WithError(err).Error("agent: Error collecting workspaces")
+		} else if removed > 0 {
+			log.WithFields(logrus.Fields{
+				"removed": removed,
+				"dir":     a.workspaces.dir,
+			}).Info("agent: Collected old workspaces")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.shutdownCh:
+			return
+		}
+	}
+}
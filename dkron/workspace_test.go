@@ -0,0 +1,66 @@
+package dkron
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacesCollect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-workspaces-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ws, err := newWorkspaces(dir, time.Hour, 1)
+	require.NoError(t, err)
+
+	now := time.Now()
+	old, err := ws.Create("sync", 1)
+	require.NoError(t, err)
+	ws.Release(old)
+	require.NoError(t, os.Chtimes(old, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+
+	big, err := ws.Create("export", 2)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(big, "dump"), make([]byte, 1<<20), 0644))
+	ws.Release(big)
+	require.NoError(t, os.Chtimes(big, now.Add(-time.Minute), now.Add(-time.Minute)))
+
+	running, err := ws.Create("export", 3)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(running, "dump"), make([]byte, 1<<20), 0644))
+
+	recent, err := ws.Create("sync", 4)
+	require.NoError(t, err)
+	ws.Release(recent)
+
+	// The expired one goes first, then the oldest until they fit, running
+	// executions keep their workspace
+	removed, err := ws.Collect(now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.NoDirExists(t, old)
+	assert.NoDirExists(t, big)
+	assert.DirExists(t, running)
+	assert.DirExists(t, recent)
+}
+
+func TestWorkspacesRelease(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dkron-workspaces-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Without retention workspaces are removed when the execution finishes
+	ws, err := newWorkspaces(dir, 0, 0)
+	require.NoError(t, err)
+	w, err := ws.Create("sync", 1)
+	require.NoError(t, err)
+	assert.DirExists(t, w)
+	ws.Release(w)
+	assert.NoDirExists(t, w)
+}
@@ -20,9 +20,13 @@ func NewParser() cron.ScheduleParser {
 }
 
 // Parse parses a cron schedule specification. It accepts the cron spec with
-// mandatory seconds parameter, descriptors and the custom descriptors
-// "@at <date>" and "@manually".
+// mandatory seconds parameter, descriptors, the custom descriptors
+// "@at <date>" and "@manually" and the macros set with SetMacros.
 func (p ExtParser) Parse(spec string) (cron.Schedule, error) {
+	return p.parse(Expand(spec))
+}
+
+func (p ExtParser) parse(spec string) (cron.Schedule, error) {
 	if spec == "@manually" {
 		return At(time.Time{}), nil
 	}
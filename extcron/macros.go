@@ -0,0 +1,87 @@
+package extcron
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	macrosMu sync.RWMutex
+	macros   = map[string]string{}
+
+	// macroName matches the names of the macros, like @nightly-batch.
+	macroName = regexp.MustCompile(`^@[a-z0-9][a-z0-9_-]*$`)
+
+	// reserved are the descriptors that can't be redefined as macros.
+	reserved = map[string]bool{
+		"@yearly":   true,
+		"@annually": true,
+		"@monthly":  true,
+		"@weekly":   true,
+		"@daily":    true,
+		"@midnight": true,
+		"@hourly":   true,
+		"@minutely": true,
+		"@every":    true,
+		"@at":       true,
+		"@manually": true,
+	}
+)
+
+// ValidateMacros returns an error if any of the macros has an invalid or
+// reserved name or a schedule that doesn't parse. Macros can't use other
+// macros.
+func ValidateMacros(m map[string]string) error {
+	for name, spec := range m {
+		if !macroName.MatchString(name) || reserved[name] {
+			return fmt.Errorf("invalid macro name %s, it must be lowercase, start with @ and not be a predefined descriptor", name)
+		}
+		if _, err := standaloneParser.(ExtParser).parse(spec); err != nil {
+			return fmt.Errorf("invalid schedule for macro %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// SetMacros replaces the macros expanded by Parse and Expand.
+func SetMacros(m map[string]string) error {
+	if err := ValidateMacros(m); err != nil {
+		return err
+	}
+
+	macrosMu.Lock()
+	defer macrosMu.Unlock()
+	macros = make(map[string]string, len(m))
+	for name, spec := range m {
+		macros[name] = spec
+	}
+	return nil
+}
+
+// Macros returns a copy of the macros.
+func Macros() map[string]string {
+	macrosMu.RLock()
+	defer macrosMu.RUnlock()
+
+	m := make(map[string]string, len(macros))
+	for name, spec := range macros {
+		m[name] = spec
+	}
+	return m
+}
+
+// Expand returns the schedule of the macro, or spec if it isn't a macro.
+func Expand(spec string) string {
+	if !strings.HasPrefix(spec, "@") {
+		return spec
+	}
+
+	macrosMu.RLock()
+	defer macrosMu.RUnlock()
+	if expanded, ok := macros[spec]; ok {
+		return expanded
+	}
+	return spec
+}
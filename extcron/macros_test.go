@@ -0,0 +1,39 @@
+package extcron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMacros(t *testing.T) {
+	require.NoError(t, SetMacros(map[string]string{
+		"@nightly-batch": "0 30 2 * * *",
+	}))
+	defer SetMacros(nil)
+
+	assert.Equal(t, "0 30 2 * * *", Expand("@nightly-batch"))
+	assert.Equal(t, "@daily", Expand("@daily"))
+
+	s, err := Parse("@nightly-batch")
+	require.NoError(t, err)
+	ref := time.Date(2020, time.May, 15, 12, 0, 0, 0, time.Local)
+	assert.Equal(t, time.Date(2020, time.May, 16, 2, 30, 0, 0, time.Local), s.Next(ref))
+
+	_, err = Parse("@unknown")
+	assert.Error(t, err)
+
+	for _, invalid := range []map[string]string{
+		{"@daily": "0 0 1 * * *"},
+		{"nightly": "0 30 2 * * *"},
+		{"@Nightly": "0 30 2 * * *"},
+		{"@nightly": "0 30 2 * *"},
+		{"@nightly": "@nightly-batch"},
+	} {
+		assert.Error(t, SetMacros(invalid), "%v", invalid)
+	}
+	// Invalid macros don't replace the current ones
+	assert.Equal(t, map[string]string{"@nightly-batch": "0 30 2 * * *"}, Macros())
+}
@@ -11,6 +11,7 @@ require (
 	github.com/gin-gonic/gin v1.6.3
 	github.com/gogo/protobuf v1.3.1 // indirect
 	github.com/golang/protobuf v1.4.2
+	github.com/golang/snappy v0.0.4
 	github.com/hashicorp/go-discover v0.0.0-20200108194735-7698de1390a1
 	github.com/hashicorp/go-hclog v0.14.1
 	github.com/hashicorp/go-immutable-radix v1.2.0 // indirect
@@ -44,7 +45,9 @@ require (
 	github.com/tinylib/msgp v1.1.2 // indirect
 	golang.org/x/crypto v0.0.0-20200429183012-4b2356b1ed79 // indirect
 	golang.org/x/net v0.0.0-20200506145744-7e3656a0809f
+	golang.org/x/sys v0.0.0-20200615200032-f1bc736245b1
 	google.golang.org/grpc v1.29.1
+	gopkg.in/yaml.v2 v2.2.8
 )
 
 go 1.14
@@ -27,31 +27,68 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Job struct {
-	Name                 string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Timezone             string                   `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
-	Schedule             string                   `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
-	Owner                string                   `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
-	OwnerEmail           string                   `protobuf:"bytes,8,opt,name=owner_email,json=ownerEmail,proto3" json:"owner_email,omitempty"`
-	SuccessCount         int32                    `protobuf:"varint,9,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
-	ErrorCount           int32                    `protobuf:"varint,10,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
-	Disabled             bool                     `protobuf:"varint,11,opt,name=disabled,proto3" json:"disabled,omitempty"`
-	Tags                 map[string]string        `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Retries              uint32                   `protobuf:"varint,13,opt,name=retries,proto3" json:"retries,omitempty"`
-	DependentJobs        []string                 `protobuf:"bytes,14,rep,name=dependent_jobs,json=dependentJobs,proto3" json:"dependent_jobs,omitempty"`
-	ParentJob            string                   `protobuf:"bytes,15,opt,name=parent_job,json=parentJob,proto3" json:"parent_job,omitempty"`
-	Concurrency          string                   `protobuf:"bytes,16,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
-	Executor             string                   `protobuf:"bytes,17,opt,name=executor,proto3" json:"executor,omitempty"`
-	ExecutorConfig       map[string]string        `protobuf:"bytes,18,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Status               string                   `protobuf:"bytes,19,opt,name=status,proto3" json:"status,omitempty"`
-	Metadata             map[string]string        `protobuf:"bytes,20,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	LastSuccess          *Job_NullableTime        `protobuf:"bytes,25,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
-	LastError            *Job_NullableTime        `protobuf:"bytes,26,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
-	Next                 *timestamp.Timestamp     `protobuf:"bytes,23,opt,name=next,proto3" json:"next,omitempty"`
-	Displayname          string                   `protobuf:"bytes,24,opt,name=displayname,proto3" json:"displayname,omitempty"`
-	Processors           map[string]*PluginConfig `protobuf:"bytes,27,rep,name=processors,proto3" json:"processors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	Name                   string                   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Timezone               string                   `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Schedule               string                   `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Owner                  string                   `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`
+	OwnerEmail             string                   `protobuf:"bytes,8,opt,name=owner_email,json=ownerEmail,proto3" json:"owner_email,omitempty"`
+	SuccessCount           int32                    `protobuf:"varint,9,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	ErrorCount             int32                    `protobuf:"varint,10,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	Disabled               bool                     `protobuf:"varint,11,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Tags                   map[string]string        `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Retries                uint32                   `protobuf:"varint,13,opt,name=retries,proto3" json:"retries,omitempty"`
+	DependentJobs          []string                 `protobuf:"bytes,14,rep,name=dependent_jobs,json=dependentJobs,proto3" json:"dependent_jobs,omitempty"`
+	ParentJob              string                   `protobuf:"bytes,15,opt,name=parent_job,json=parentJob,proto3" json:"parent_job,omitempty"`
+	Concurrency            string                   `protobuf:"bytes,16,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Executor               string                   `protobuf:"bytes,17,opt,name=executor,proto3" json:"executor,omitempty"`
+	ExecutorConfig         map[string]string        `protobuf:"bytes,18,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Status                 string                   `protobuf:"bytes,19,opt,name=status,proto3" json:"status,omitempty"`
+	Metadata               map[string]string        `protobuf:"bytes,20,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	LastSuccess            *Job_NullableTime        `protobuf:"bytes,25,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	LastError              *Job_NullableTime        `protobuf:"bytes,26,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Next                   *timestamp.Timestamp     `protobuf:"bytes,23,opt,name=next,proto3" json:"next,omitempty"`
+	Displayname            string                   `protobuf:"bytes,24,opt,name=displayname,proto3" json:"displayname,omitempty"`
+	Processors             map[string]*PluginConfig `protobuf:"bytes,27,rep,name=processors,proto3" json:"processors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MaxConsecutiveFailures int32                    `protobuf:"varint,28,opt,name=max_consecutive_failures,json=maxConsecutiveFailures,proto3" json:"max_consecutive_failures,omitempty"`
+	ConsecutiveFailures    int32                    `protobuf:"varint,29,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	OwnerTeam              string                   `protobuf:"bytes,30,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	OwnerEscalationChannel string                   `protobuf:"bytes,31,opt,name=owner_escalation_channel,json=ownerEscalationChannel,proto3" json:"owner_escalation_channel,omitempty"`
+	Locked                 bool                     `protobuf:"varint,32,opt,name=locked,proto3" json:"locked,omitempty"`
+	Steps                  []*JobStep               `protobuf:"bytes,33,rep,name=steps,proto3" json:"steps,omitempty"`
+	Resources              *JobResources            `protobuf:"bytes,34,opt,name=resources,proto3" json:"resources,omitempty"`
+	ConcurrencyKey         string                   `protobuf:"bytes,35,opt,name=concurrency_key,json=concurrencyKey,proto3" json:"concurrency_key,omitempty"`
+	CreatedAt              *Job_NullableTime        `protobuf:"bytes,36,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt              *Job_NullableTime        `protobuf:"bytes,37,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedBy              string                   `protobuf:"bytes,38,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy              string                   `protobuf:"bytes,39,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	Priority               string                   `protobuf:"bytes,40,opt,name=priority,proto3" json:"priority,omitempty"`
+	NotifyOn               string                   `protobuf:"bytes,41,opt,name=notify_on,json=notifyOn,proto3" json:"notify_on,omitempty"`
+	NotifyEvery            int32                    `protobuf:"varint,42,opt,name=notify_every,json=notifyEvery,proto3" json:"notify_every,omitempty"`
+	Escalation             []*EscalationStep        `protobuf:"bytes,43,rep,name=escalation,proto3" json:"escalation,omitempty"`
+	EscalationLevel        int32                    `protobuf:"varint,44,opt,name=escalation_level,json=escalationLevel,proto3" json:"escalation_level,omitempty"`
+	Canary                 *Canary                  `protobuf:"bytes,45,opt,name=canary,proto3" json:"canary,omitempty"`
+	Signature              string                   `protobuf:"bytes,46,opt,name=signature,proto3" json:"signature,omitempty"`
+	SignedBy               string                   `protobuf:"bytes,47,opt,name=signed_by,json=signedBy,proto3" json:"signed_by,omitempty"`
+	Triggers               []*MemberTrigger         `protobuf:"bytes,48,rep,name=triggers,proto3" json:"triggers,omitempty"`
+	Deadline               string                   `protobuf:"bytes,49,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	DeadlineAction         string                   `protobuf:"bytes,50,opt,name=deadline_action,json=deadlineAction,proto3" json:"deadline_action,omitempty"`
+	BreachedGroup          int64                    `protobuf:"varint,51,opt,name=breached_group,json=breachedGroup,proto3" json:"breached_group,omitempty"`
+	StandbyFor             string                   `protobuf:"bytes,52,opt,name=standby_for,json=standbyFor,proto3" json:"standby_for,omitempty"`
+	MinInterval            string                   `protobuf:"bytes,53,opt,name=min_interval,json=minInterval,proto3" json:"min_interval,omitempty"`
+	Budget                 *Budget                  `protobuf:"bytes,54,opt,name=budget,proto3" json:"budget,omitempty"`
+	Version                int64                    `protobuf:"varint,55,opt,name=version,proto3" json:"version,omitempty"`
+	RedactPatterns         []string                 `protobuf:"bytes,56,rep,name=redact_patterns,json=redactPatterns,proto3" json:"redact_patterns,omitempty"`
+	ConsulService          *ConsulService           `protobuf:"bytes,57,opt,name=consul_service,json=consulService,proto3" json:"consul_service,omitempty"`
+	Secrets                []*JobSecret             `protobuf:"bytes,58,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	Credentials            []string                 `protobuf:"bytes,59,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	CostPerRun             float64                  `protobuf:"fixed64,60,opt,name=cost_per_run,json=costPerRun,proto3" json:"cost_per_run,omitempty"`
+	Matrix                 *Matrix                  `protobuf:"bytes,61,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	Precheck               *JobPrecheck             `protobuf:"bytes,62,opt,name=precheck,proto3" json:"precheck,omitempty"`
+	OnParentSkip           string                   `protobuf:"bytes,63,opt,name=on_parent_skip,json=onParentSkip,proto3" json:"on_parent_skip,omitempty"`
+	SkipCount              int32                    `protobuf:"varint,64,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
+	XXX_unrecognized       []byte                   `json:"-"`
+	XXX_sizecache          int32                    `json:"-"`
 }
 
 func (m *Job) Reset()         { *m = Job{} }
@@ -233,1506 +270,6698 @@ func (m *Job) GetProcessors() map[string]*PluginConfig {
 	return nil
 }
 
-type Job_NullableTime struct {
-	HasValue             bool                 `protobuf:"varint,1,opt,name=has_value,json=hasValue,proto3" json:"has_value,omitempty"`
-	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *Job) GetMaxConsecutiveFailures() int32 {
+	if m != nil {
+		return m.MaxConsecutiveFailures
+	}
+	return 0
 }
 
-func (m *Job_NullableTime) Reset()         { *m = Job_NullableTime{} }
-func (m *Job_NullableTime) String() string { return proto.CompactTextString(m) }
-func (*Job_NullableTime) ProtoMessage()    {}
-func (*Job_NullableTime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{0, 3}
+func (m *Job) GetConsecutiveFailures() int32 {
+	if m != nil {
+		return m.ConsecutiveFailures
+	}
+	return 0
 }
 
-func (m *Job_NullableTime) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Job_NullableTime.Unmarshal(m, b)
-}
-func (m *Job_NullableTime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Job_NullableTime.Marshal(b, m, deterministic)
-}
-func (m *Job_NullableTime) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Job_NullableTime.Merge(m, src)
-}
-func (m *Job_NullableTime) XXX_Size() int {
-	return xxx_messageInfo_Job_NullableTime.Size(m)
-}
-func (m *Job_NullableTime) XXX_DiscardUnknown() {
-	xxx_messageInfo_Job_NullableTime.DiscardUnknown(m)
+func (m *Job) GetOwnerTeam() string {
+	if m != nil {
+		return m.OwnerTeam
+	}
+	return ""
 }
 
-var xxx_messageInfo_Job_NullableTime proto.InternalMessageInfo
+func (m *Job) GetOwnerEscalationChannel() string {
+	if m != nil {
+		return m.OwnerEscalationChannel
+	}
+	return ""
+}
 
-func (m *Job_NullableTime) GetHasValue() bool {
+func (m *Job) GetLocked() bool {
 	if m != nil {
-		return m.HasValue
+		return m.Locked
 	}
 	return false
 }
 
-func (m *Job_NullableTime) GetTime() *timestamp.Timestamp {
+func (m *Job) GetSteps() []*JobStep {
 	if m != nil {
-		return m.Time
+		return m.Steps
 	}
 	return nil
 }
 
-type PluginConfig struct {
-	Config               map[string]string `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *Job) GetResources() *JobResources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
 }
 
-func (m *PluginConfig) Reset()         { *m = PluginConfig{} }
-func (m *PluginConfig) String() string { return proto.CompactTextString(m) }
-func (*PluginConfig) ProtoMessage()    {}
-func (*PluginConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{1}
+func (m *Job) GetConcurrencyKey() string {
+	if m != nil {
+		return m.ConcurrencyKey
+	}
+	return ""
 }
 
-func (m *PluginConfig) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PluginConfig.Unmarshal(m, b)
-}
-func (m *PluginConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PluginConfig.Marshal(b, m, deterministic)
-}
-func (m *PluginConfig) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PluginConfig.Merge(m, src)
-}
-func (m *PluginConfig) XXX_Size() int {
-	return xxx_messageInfo_PluginConfig.Size(m)
-}
-func (m *PluginConfig) XXX_DiscardUnknown() {
-	xxx_messageInfo_PluginConfig.DiscardUnknown(m)
+func (m *Job) GetCreatedAt() *Job_NullableTime {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
 }
 
-var xxx_messageInfo_PluginConfig proto.InternalMessageInfo
-
-func (m *PluginConfig) GetConfig() map[string]string {
+func (m *Job) GetUpdatedAt() *Job_NullableTime {
 	if m != nil {
-		return m.Config
+		return m.UpdatedAt
 	}
 	return nil
 }
 
-type SetJobRequest struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetCreatedBy() string {
+	if m != nil {
+		return m.CreatedBy
+	}
+	return ""
 }
 
-func (m *SetJobRequest) Reset()         { *m = SetJobRequest{} }
-func (m *SetJobRequest) String() string { return proto.CompactTextString(m) }
-func (*SetJobRequest) ProtoMessage()    {}
-func (*SetJobRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{2}
+func (m *Job) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
 }
 
-func (m *SetJobRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetJobRequest.Unmarshal(m, b)
-}
-func (m *SetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetJobRequest.Marshal(b, m, deterministic)
-}
-func (m *SetJobRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetJobRequest.Merge(m, src)
-}
-func (m *SetJobRequest) XXX_Size() int {
-	return xxx_messageInfo_SetJobRequest.Size(m)
+func (m *Job) GetPriority() string {
+	if m != nil {
+		return m.Priority
+	}
+	return ""
 }
-func (m *SetJobRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetJobRequest.DiscardUnknown(m)
+
+func (m *Job) GetNotifyOn() string {
+	if m != nil {
+		return m.NotifyOn
+	}
+	return ""
 }
 
-var xxx_messageInfo_SetJobRequest proto.InternalMessageInfo
+func (m *Job) GetNotifyEvery() int32 {
+	if m != nil {
+		return m.NotifyEvery
+	}
+	return 0
+}
 
-func (m *SetJobRequest) GetJob() *Job {
+func (m *Job) GetEscalation() []*EscalationStep {
 	if m != nil {
-		return m.Job
+		return m.Escalation
 	}
 	return nil
 }
 
-type SetJobResponse struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetEscalationLevel() int32 {
+	if m != nil {
+		return m.EscalationLevel
+	}
+	return 0
 }
 
-func (m *SetJobResponse) Reset()         { *m = SetJobResponse{} }
-func (m *SetJobResponse) String() string { return proto.CompactTextString(m) }
-func (*SetJobResponse) ProtoMessage()    {}
-func (*SetJobResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{3}
+func (m *Job) GetCanary() *Canary {
+	if m != nil {
+		return m.Canary
+	}
+	return nil
 }
 
-func (m *SetJobResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SetJobResponse.Unmarshal(m, b)
-}
-func (m *SetJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SetJobResponse.Marshal(b, m, deterministic)
-}
-func (m *SetJobResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SetJobResponse.Merge(m, src)
-}
-func (m *SetJobResponse) XXX_Size() int {
-	return xxx_messageInfo_SetJobResponse.Size(m)
-}
-func (m *SetJobResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_SetJobResponse.DiscardUnknown(m)
+func (m *Job) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
 }
 
-var xxx_messageInfo_SetJobResponse proto.InternalMessageInfo
+func (m *Job) GetSignedBy() string {
+	if m != nil {
+		return m.SignedBy
+	}
+	return ""
+}
 
-func (m *SetJobResponse) GetJob() *Job {
+func (m *Job) GetTriggers() []*MemberTrigger {
 	if m != nil {
-		return m.Job
+		return m.Triggers
 	}
 	return nil
 }
 
-type DeleteJobRequest struct {
-	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetDeadline() string {
+	if m != nil {
+		return m.Deadline
+	}
+	return ""
 }
 
-func (m *DeleteJobRequest) Reset()         { *m = DeleteJobRequest{} }
-func (m *DeleteJobRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteJobRequest) ProtoMessage()    {}
-func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{4}
+func (m *Job) GetDeadlineAction() string {
+	if m != nil {
+		return m.DeadlineAction
+	}
+	return ""
 }
 
-func (m *DeleteJobRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteJobRequest.Unmarshal(m, b)
-}
-func (m *DeleteJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteJobRequest.Marshal(b, m, deterministic)
-}
-func (m *DeleteJobRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteJobRequest.Merge(m, src)
-}
-func (m *DeleteJobRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteJobRequest.Size(m)
-}
-func (m *DeleteJobRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteJobRequest.DiscardUnknown(m)
+func (m *Job) GetBreachedGroup() int64 {
+	if m != nil {
+		return m.BreachedGroup
+	}
+	return 0
 }
 
-var xxx_messageInfo_DeleteJobRequest proto.InternalMessageInfo
-
-func (m *DeleteJobRequest) GetJobName() string {
+func (m *Job) GetStandbyFor() string {
 	if m != nil {
-		return m.JobName
+		return m.StandbyFor
 	}
 	return ""
 }
 
-type DeleteJobResponse struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *Job) GetMinInterval() string {
+	if m != nil {
+		return m.MinInterval
+	}
+	return ""
 }
 
-func (m *DeleteJobResponse) Reset()         { *m = DeleteJobResponse{} }
-func (m *DeleteJobResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteJobResponse) ProtoMessage()    {}
-func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{5}
+func (m *Job) GetBudget() *Budget {
+	if m != nil {
+		return m.Budget
+	}
+	return nil
 }
 
-func (m *DeleteJobResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteJobResponse.Unmarshal(m, b)
+func (m *Job) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
 }
-func (m *DeleteJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteJobResponse.Marshal(b, m, deterministic)
+
+func (m *Job) GetRedactPatterns() []string {
+	if m != nil {
+		return m.RedactPatterns
+	}
+	return nil
 }
-func (m *DeleteJobResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteJobResponse.Merge(m, src)
+
+func (m *Job) GetConsulService() *ConsulService {
+	if m != nil {
+		return m.ConsulService
+	}
+	return nil
 }
-func (m *DeleteJobResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteJobResponse.Size(m)
+
+func (m *Job) GetSecrets() []*JobSecret {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
 }
-func (m *DeleteJobResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteJobResponse.DiscardUnknown(m)
+
+func (m *Job) GetCredentials() []string {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
 }
 
-var xxx_messageInfo_DeleteJobResponse proto.InternalMessageInfo
+func (m *Job) GetCostPerRun() float64 {
+	if m != nil {
+		return m.CostPerRun
+	}
+	return 0
+}
 
-func (m *DeleteJobResponse) GetJob() *Job {
+func (m *Job) GetMatrix() *Matrix {
 	if m != nil {
-		return m.Job
+		return m.Matrix
 	}
 	return nil
 }
 
-type GetJobRequest struct {
-	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *GetJobRequest) Reset()         { *m = GetJobRequest{} }
-func (m *GetJobRequest) String() string { return proto.CompactTextString(m) }
-func (*GetJobRequest) ProtoMessage()    {}
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{6}
+func (m *Job) GetPrecheck() *JobPrecheck {
+	if m != nil {
+		return m.Precheck
+	}
+	return nil
 }
 
-func (m *GetJobRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetJobRequest.Unmarshal(m, b)
-}
-func (m *GetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetJobRequest.Marshal(b, m, deterministic)
-}
-func (m *GetJobRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetJobRequest.Merge(m, src)
-}
-func (m *GetJobRequest) XXX_Size() int {
-	return xxx_messageInfo_GetJobRequest.Size(m)
-}
-func (m *GetJobRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetJobRequest.DiscardUnknown(m)
+func (m *Job) GetOnParentSkip() string {
+	if m != nil {
+		return m.OnParentSkip
+	}
+	return ""
 }
 
-var xxx_messageInfo_GetJobRequest proto.InternalMessageInfo
-
-func (m *GetJobRequest) GetJobName() string {
+func (m *Job) GetSkipCount() int32 {
 	if m != nil {
-		return m.JobName
+		return m.SkipCount
 	}
-	return ""
+	return 0
 }
 
-type GetJobResponse struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Job_NullableTime struct {
+	HasValue             bool                 `protobuf:"varint,1,opt,name=has_value,json=hasValue,proto3" json:"has_value,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetJobResponse) Reset()         { *m = GetJobResponse{} }
-func (m *GetJobResponse) String() string { return proto.CompactTextString(m) }
-func (*GetJobResponse) ProtoMessage()    {}
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{7}
+func (m *Job_NullableTime) Reset()         { *m = Job_NullableTime{} }
+func (m *Job_NullableTime) String() string { return proto.CompactTextString(m) }
+func (*Job_NullableTime) ProtoMessage()    {}
+func (*Job_NullableTime) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{0, 3}
 }
 
-func (m *GetJobResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetJobResponse.Unmarshal(m, b)
+func (m *Job_NullableTime) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Job_NullableTime.Unmarshal(m, b)
 }
-func (m *GetJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetJobResponse.Marshal(b, m, deterministic)
+func (m *Job_NullableTime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Job_NullableTime.Marshal(b, m, deterministic)
 }
-func (m *GetJobResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetJobResponse.Merge(m, src)
+func (m *Job_NullableTime) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Job_NullableTime.Merge(m, src)
 }
-func (m *GetJobResponse) XXX_Size() int {
-	return xxx_messageInfo_GetJobResponse.Size(m)
+func (m *Job_NullableTime) XXX_Size() int {
+	return xxx_messageInfo_Job_NullableTime.Size(m)
 }
-func (m *GetJobResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetJobResponse.DiscardUnknown(m)
+func (m *Job_NullableTime) XXX_DiscardUnknown() {
+	xxx_messageInfo_Job_NullableTime.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetJobResponse proto.InternalMessageInfo
+var xxx_messageInfo_Job_NullableTime proto.InternalMessageInfo
 
-func (m *GetJobResponse) GetJob() *Job {
+func (m *Job_NullableTime) GetHasValue() bool {
 	if m != nil {
-		return m.Job
+		return m.HasValue
+	}
+	return false
+}
+
+func (m *Job_NullableTime) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
 	}
 	return nil
 }
 
-type Execution struct {
-	JobName              string               `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	Success              bool                 `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Output               []byte               `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
-	NodeName             string               `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
-	Group                int64                `protobuf:"varint,5,opt,name=group,proto3" json:"group,omitempty"`
-	Attempt              uint32               `protobuf:"varint,6,opt,name=attempt,proto3" json:"attempt,omitempty"`
-	StartedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	FinishedAt           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+type Budget struct {
+	MaxRuns              int32                `protobuf:"varint,1,opt,name=max_runs,json=maxRuns,proto3" json:"max_runs,omitempty"`
+	Period               string               `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	CarryOver            int32                `protobuf:"varint,3,opt,name=carry_over,json=carryOver,proto3" json:"carry_over,omitempty"`
+	PeriodStart          *timestamp.Timestamp `protobuf:"bytes,4,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	Used                 int32                `protobuf:"varint,5,opt,name=used,proto3" json:"used,omitempty"`
+	Carried              int32                `protobuf:"varint,6,opt,name=carried,proto3" json:"carried,omitempty"`
+	ExceededAt           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=exceeded_at,json=exceededAt,proto3" json:"exceeded_at,omitempty"`
+	Refunded             int32                `protobuf:"varint,8,opt,name=refunded,proto3" json:"refunded,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *Execution) Reset()         { *m = Execution{} }
-func (m *Execution) String() string { return proto.CompactTextString(m) }
-func (*Execution) ProtoMessage()    {}
-func (*Execution) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{8}
+func (m *Budget) Reset()         { *m = Budget{} }
+func (m *Budget) String() string { return proto.CompactTextString(m) }
+func (*Budget) ProtoMessage()    {}
+func (*Budget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{1}
 }
 
-func (m *Execution) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Execution.Unmarshal(m, b)
+func (m *Budget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Budget.Unmarshal(m, b)
 }
-func (m *Execution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Execution.Marshal(b, m, deterministic)
+func (m *Budget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Budget.Marshal(b, m, deterministic)
 }
-func (m *Execution) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Execution.Merge(m, src)
+func (m *Budget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Budget.Merge(m, src)
 }
-func (m *Execution) XXX_Size() int {
-	return xxx_messageInfo_Execution.Size(m)
+func (m *Budget) XXX_Size() int {
+	return xxx_messageInfo_Budget.Size(m)
 }
-func (m *Execution) XXX_DiscardUnknown() {
-	xxx_messageInfo_Execution.DiscardUnknown(m)
+func (m *Budget) XXX_DiscardUnknown() {
+	xxx_messageInfo_Budget.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Execution proto.InternalMessageInfo
+var xxx_messageInfo_Budget proto.InternalMessageInfo
 
-func (m *Execution) GetJobName() string {
+func (m *Budget) GetMaxRuns() int32 {
 	if m != nil {
-		return m.JobName
+		return m.MaxRuns
 	}
-	return ""
+	return 0
 }
 
-func (m *Execution) GetSuccess() bool {
+func (m *Budget) GetPeriod() string {
 	if m != nil {
-		return m.Success
+		return m.Period
 	}
-	return false
+	return ""
 }
 
-func (m *Execution) GetOutput() []byte {
+func (m *Budget) GetCarryOver() int32 {
 	if m != nil {
-		return m.Output
+		return m.CarryOver
 	}
-	return nil
+	return 0
 }
 
-func (m *Execution) GetNodeName() string {
+func (m *Budget) GetPeriodStart() *timestamp.Timestamp {
 	if m != nil {
-		return m.NodeName
+		return m.PeriodStart
 	}
-	return ""
+	return nil
 }
 
-func (m *Execution) GetGroup() int64 {
+func (m *Budget) GetUsed() int32 {
 	if m != nil {
-		return m.Group
+		return m.Used
 	}
 	return 0
 }
 
-func (m *Execution) GetAttempt() uint32 {
+func (m *Budget) GetCarried() int32 {
 	if m != nil {
-		return m.Attempt
+		return m.Carried
 	}
 	return 0
 }
 
-func (m *Execution) GetStartedAt() *timestamp.Timestamp {
+func (m *Budget) GetExceededAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.StartedAt
+		return m.ExceededAt
 	}
 	return nil
 }
 
-func (m *Execution) GetFinishedAt() *timestamp.Timestamp {
+func (m *Budget) GetRefunded() int32 {
 	if m != nil {
-		return m.FinishedAt
+		return m.Refunded
 	}
-	return nil
+	return 0
 }
 
-type ExecutionDoneRequest struct {
-	Execution            *Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type JobPrecheck struct {
+	Executor             string            `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	ExecutorConfig       map[string]string `protobuf:"bytes,2,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NoWorkPattern        string            `protobuf:"bytes,3,opt,name=no_work_pattern,json=noWorkPattern,proto3" json:"no_work_pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *ExecutionDoneRequest) Reset()         { *m = ExecutionDoneRequest{} }
-func (m *ExecutionDoneRequest) String() string { return proto.CompactTextString(m) }
-func (*ExecutionDoneRequest) ProtoMessage()    {}
-func (*ExecutionDoneRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{9}
+func (m *JobPrecheck) Reset()         { *m = JobPrecheck{} }
+func (m *JobPrecheck) String() string { return proto.CompactTextString(m) }
+func (*JobPrecheck) ProtoMessage()    {}
+func (*JobPrecheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{2}
 }
 
-func (m *ExecutionDoneRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExecutionDoneRequest.Unmarshal(m, b)
+func (m *JobPrecheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobPrecheck.Unmarshal(m, b)
 }
-func (m *ExecutionDoneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExecutionDoneRequest.Marshal(b, m, deterministic)
+func (m *JobPrecheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobPrecheck.Marshal(b, m, deterministic)
 }
-func (m *ExecutionDoneRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExecutionDoneRequest.Merge(m, src)
+func (m *JobPrecheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobPrecheck.Merge(m, src)
 }
-func (m *ExecutionDoneRequest) XXX_Size() int {
-	return xxx_messageInfo_ExecutionDoneRequest.Size(m)
+func (m *JobPrecheck) XXX_Size() int {
+	return xxx_messageInfo_JobPrecheck.Size(m)
 }
-func (m *ExecutionDoneRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExecutionDoneRequest.DiscardUnknown(m)
+func (m *JobPrecheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobPrecheck.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExecutionDoneRequest proto.InternalMessageInfo
+var xxx_messageInfo_JobPrecheck proto.InternalMessageInfo
 
-func (m *ExecutionDoneRequest) GetExecution() *Execution {
+func (m *JobPrecheck) GetExecutor() string {
 	if m != nil {
-		return m.Execution
+		return m.Executor
+	}
+	return ""
+}
+
+func (m *JobPrecheck) GetExecutorConfig() map[string]string {
+	if m != nil {
+		return m.ExecutorConfig
 	}
 	return nil
 }
 
-type ExecutionDoneResponse struct {
-	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *JobPrecheck) GetNoWorkPattern() string {
+	if m != nil {
+		return m.NoWorkPattern
+	}
+	return ""
 }
 
-func (m *ExecutionDoneResponse) Reset()         { *m = ExecutionDoneResponse{} }
-func (m *ExecutionDoneResponse) String() string { return proto.CompactTextString(m) }
-func (*ExecutionDoneResponse) ProtoMessage()    {}
-func (*ExecutionDoneResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{10}
+type Matrix struct {
+	Params               map[string]*MatrixValues `protobuf:"bytes,1,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	FromParent           string                   `protobuf:"bytes,2,opt,name=from_parent,json=fromParent,proto3" json:"from_parent,omitempty"`
+	Parallelism          int32                    `protobuf:"varint,3,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	Discovery            *JobStep                 `protobuf:"bytes,4,opt,name=discovery,proto3" json:"discovery,omitempty"`
+	Spread               bool                     `protobuf:"varint,5,opt,name=spread,proto3" json:"spread,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
 
-func (m *ExecutionDoneResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ExecutionDoneResponse.Unmarshal(m, b)
+func (m *Matrix) Reset()         { *m = Matrix{} }
+func (m *Matrix) String() string { return proto.CompactTextString(m) }
+func (*Matrix) ProtoMessage()    {}
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{3}
 }
-func (m *ExecutionDoneResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ExecutionDoneResponse.Marshal(b, m, deterministic)
+
+func (m *Matrix) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Matrix.Unmarshal(m, b)
 }
-func (m *ExecutionDoneResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExecutionDoneResponse.Merge(m, src)
+func (m *Matrix) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Matrix.Marshal(b, m, deterministic)
 }
-func (m *ExecutionDoneResponse) XXX_Size() int {
-	return xxx_messageInfo_ExecutionDoneResponse.Size(m)
+func (m *Matrix) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Matrix.Merge(m, src)
 }
-func (m *ExecutionDoneResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExecutionDoneResponse.DiscardUnknown(m)
+func (m *Matrix) XXX_Size() int {
+	return xxx_messageInfo_Matrix.Size(m)
+}
+func (m *Matrix) XXX_DiscardUnknown() {
+	xxx_messageInfo_Matrix.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExecutionDoneResponse proto.InternalMessageInfo
+var xxx_messageInfo_Matrix proto.InternalMessageInfo
 
-func (m *ExecutionDoneResponse) GetFrom() string {
+func (m *Matrix) GetParams() map[string]*MatrixValues {
 	if m != nil {
-		return m.From
+		return m.Params
+	}
+	return nil
+}
+
+func (m *Matrix) GetFromParent() string {
+	if m != nil {
+		return m.FromParent
 	}
 	return ""
 }
 
-func (m *ExecutionDoneResponse) GetPayload() []byte {
+func (m *Matrix) GetParallelism() int32 {
 	if m != nil {
-		return m.Payload
+		return m.Parallelism
+	}
+	return 0
+}
+
+func (m *Matrix) GetDiscovery() *JobStep {
+	if m != nil {
+		return m.Discovery
 	}
 	return nil
 }
 
-type RunJobRequest struct {
-	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+func (m *Matrix) GetSpread() bool {
+	if m != nil {
+		return m.Spread
+	}
+	return false
+}
+
+type MatrixValues struct {
+	Values               []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RunJobRequest) Reset()         { *m = RunJobRequest{} }
-func (m *RunJobRequest) String() string { return proto.CompactTextString(m) }
-func (*RunJobRequest) ProtoMessage()    {}
-func (*RunJobRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{11}
+func (m *MatrixValues) Reset()         { *m = MatrixValues{} }
+func (m *MatrixValues) String() string { return proto.CompactTextString(m) }
+func (*MatrixValues) ProtoMessage()    {}
+func (*MatrixValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{4}
 }
 
-func (m *RunJobRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RunJobRequest.Unmarshal(m, b)
+func (m *MatrixValues) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatrixValues.Unmarshal(m, b)
 }
-func (m *RunJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RunJobRequest.Marshal(b, m, deterministic)
+func (m *MatrixValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatrixValues.Marshal(b, m, deterministic)
 }
-func (m *RunJobRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RunJobRequest.Merge(m, src)
+func (m *MatrixValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatrixValues.Merge(m, src)
 }
-func (m *RunJobRequest) XXX_Size() int {
-	return xxx_messageInfo_RunJobRequest.Size(m)
+func (m *MatrixValues) XXX_Size() int {
+	return xxx_messageInfo_MatrixValues.Size(m)
 }
-func (m *RunJobRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RunJobRequest.DiscardUnknown(m)
+func (m *MatrixValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatrixValues.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RunJobRequest proto.InternalMessageInfo
+var xxx_messageInfo_MatrixValues proto.InternalMessageInfo
 
-func (m *RunJobRequest) GetJobName() string {
+func (m *MatrixValues) GetValues() []string {
 	if m != nil {
-		return m.JobName
+		return m.Values
 	}
-	return ""
+	return nil
 }
 
-type RunJobResponse struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+type ConsulService struct {
+	Service              string   `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Tag                  string   `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Count                int32    `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Datacenter           string   `protobuf:"bytes,4,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RunJobResponse) Reset()         { *m = RunJobResponse{} }
-func (m *RunJobResponse) String() string { return proto.CompactTextString(m) }
-func (*RunJobResponse) ProtoMessage()    {}
-func (*RunJobResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{12}
+func (m *ConsulService) Reset()         { *m = ConsulService{} }
+func (m *ConsulService) String() string { return proto.CompactTextString(m) }
+func (*ConsulService) ProtoMessage()    {}
+func (*ConsulService) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{5}
 }
 
-func (m *RunJobResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RunJobResponse.Unmarshal(m, b)
+func (m *ConsulService) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsulService.Unmarshal(m, b)
 }
-func (m *RunJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RunJobResponse.Marshal(b, m, deterministic)
+func (m *ConsulService) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsulService.Marshal(b, m, deterministic)
 }
-func (m *RunJobResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RunJobResponse.Merge(m, src)
+func (m *ConsulService) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsulService.Merge(m, src)
 }
-func (m *RunJobResponse) XXX_Size() int {
-	return xxx_messageInfo_RunJobResponse.Size(m)
+func (m *ConsulService) XXX_Size() int {
+	return xxx_messageInfo_ConsulService.Size(m)
 }
-func (m *RunJobResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RunJobResponse.DiscardUnknown(m)
+func (m *ConsulService) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsulService.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RunJobResponse proto.InternalMessageInfo
+var xxx_messageInfo_ConsulService proto.InternalMessageInfo
 
-func (m *RunJobResponse) GetJob() *Job {
+func (m *ConsulService) GetService() string {
 	if m != nil {
-		return m.Job
+		return m.Service
 	}
-	return nil
+	return ""
 }
 
-type ToggleJobRequest struct {
-	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+func (m *ConsulService) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *ConsulService) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ConsulService) GetDatacenter() string {
+	if m != nil {
+		return m.Datacenter
+	}
+	return ""
+}
+
+type JobSecret struct {
+	Env                  string   `protobuf:"bytes,1,opt,name=env,proto3" json:"env,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ToggleJobRequest) Reset()         { *m = ToggleJobRequest{} }
-func (m *ToggleJobRequest) String() string { return proto.CompactTextString(m) }
-func (*ToggleJobRequest) ProtoMessage()    {}
-func (*ToggleJobRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{13}
+func (m *JobSecret) Reset()         { *m = JobSecret{} }
+func (m *JobSecret) String() string { return proto.CompactTextString(m) }
+func (*JobSecret) ProtoMessage()    {}
+func (*JobSecret) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{6}
 }
 
-func (m *ToggleJobRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ToggleJobRequest.Unmarshal(m, b)
+func (m *JobSecret) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobSecret.Unmarshal(m, b)
 }
-func (m *ToggleJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ToggleJobRequest.Marshal(b, m, deterministic)
+func (m *JobSecret) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobSecret.Marshal(b, m, deterministic)
 }
-func (m *ToggleJobRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ToggleJobRequest.Merge(m, src)
+func (m *JobSecret) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobSecret.Merge(m, src)
 }
-func (m *ToggleJobRequest) XXX_Size() int {
-	return xxx_messageInfo_ToggleJobRequest.Size(m)
+func (m *JobSecret) XXX_Size() int {
+	return xxx_messageInfo_JobSecret.Size(m)
 }
-func (m *ToggleJobRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ToggleJobRequest.DiscardUnknown(m)
+func (m *JobSecret) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobSecret.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ToggleJobRequest proto.InternalMessageInfo
+var xxx_messageInfo_JobSecret proto.InternalMessageInfo
 
-func (m *ToggleJobRequest) GetJobName() string {
+func (m *JobSecret) GetEnv() string {
 	if m != nil {
-		return m.JobName
+		return m.Env
 	}
 	return ""
 }
 
-type ToggleJobResponse struct {
-	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *JobSecret) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
 }
 
-func (m *ToggleJobResponse) Reset()         { *m = ToggleJobResponse{} }
-func (m *ToggleJobResponse) String() string { return proto.CompactTextString(m) }
-func (*ToggleJobResponse) ProtoMessage()    {}
-func (*ToggleJobResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{14}
+func (m *JobSecret) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
 }
 
-func (m *ToggleJobResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ToggleJobResponse.Unmarshal(m, b)
+type MemberTrigger struct {
+	Event                string            `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Labels               map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
-func (m *ToggleJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ToggleJobResponse.Marshal(b, m, deterministic)
+
+func (m *MemberTrigger) Reset()         { *m = MemberTrigger{} }
+func (m *MemberTrigger) String() string { return proto.CompactTextString(m) }
+func (*MemberTrigger) ProtoMessage()    {}
+func (*MemberTrigger) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{7}
 }
-func (m *ToggleJobResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ToggleJobResponse.Merge(m, src)
+
+func (m *MemberTrigger) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemberTrigger.Unmarshal(m, b)
 }
-func (m *ToggleJobResponse) XXX_Size() int {
-	return xxx_messageInfo_ToggleJobResponse.Size(m)
+func (m *MemberTrigger) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemberTrigger.Marshal(b, m, deterministic)
 }
-func (m *ToggleJobResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ToggleJobResponse.DiscardUnknown(m)
+func (m *MemberTrigger) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberTrigger.Merge(m, src)
+}
+func (m *MemberTrigger) XXX_Size() int {
+	return xxx_messageInfo_MemberTrigger.Size(m)
+}
+func (m *MemberTrigger) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberTrigger.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ToggleJobResponse proto.InternalMessageInfo
+var xxx_messageInfo_MemberTrigger proto.InternalMessageInfo
 
-func (m *ToggleJobResponse) GetJob() *Job {
+func (m *MemberTrigger) GetEvent() string {
 	if m != nil {
-		return m.Job
+		return m.Event
+	}
+	return ""
+}
+
+func (m *MemberTrigger) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
 	}
 	return nil
 }
 
-type RaftServer struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
-	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Leader               bool     `protobuf:"varint,4,opt,name=leader,proto3" json:"leader,omitempty"`
-	Voter                bool     `protobuf:"varint,5,opt,name=voter,proto3" json:"voter,omitempty"`
-	RaftProtocol         string   `protobuf:"bytes,6,opt,name=raft_protocol,json=raftProtocol,proto3" json:"raft_protocol,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *MemberTrigger) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
 }
 
-func (m *RaftServer) Reset()         { *m = RaftServer{} }
-func (m *RaftServer) String() string { return proto.CompactTextString(m) }
-func (*RaftServer) ProtoMessage()    {}
-func (*RaftServer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{15}
+type Canary struct {
+	Executor             string               `protobuf:"bytes,1,opt,name=executor,proto3" json:"executor,omitempty"`
+	ExecutorConfig       map[string]string    `protobuf:"bytes,2,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Percent              int32                `protobuf:"varint,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	Nodes                []string             `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	StartedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndsAt               *timestamp.Timestamp `protobuf:"bytes,6,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Stable               *CanaryStats         `protobuf:"bytes,7,opt,name=stable,proto3" json:"stable,omitempty"`
+	Canary               *CanaryStats         `protobuf:"bytes,8,opt,name=canary,proto3" json:"canary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *RaftServer) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RaftServer.Unmarshal(m, b)
+func (m *Canary) Reset()         { *m = Canary{} }
+func (m *Canary) String() string { return proto.CompactTextString(m) }
+func (*Canary) ProtoMessage()    {}
+func (*Canary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{8}
 }
-func (m *RaftServer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RaftServer.Marshal(b, m, deterministic)
+
+func (m *Canary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Canary.Unmarshal(m, b)
 }
-func (m *RaftServer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RaftServer.Merge(m, src)
+func (m *Canary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Canary.Marshal(b, m, deterministic)
 }
-func (m *RaftServer) XXX_Size() int {
-	return xxx_messageInfo_RaftServer.Size(m)
+func (m *Canary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Canary.Merge(m, src)
 }
-func (m *RaftServer) XXX_DiscardUnknown() {
-	xxx_messageInfo_RaftServer.DiscardUnknown(m)
+func (m *Canary) XXX_Size() int {
+	return xxx_messageInfo_Canary.Size(m)
+}
+func (m *Canary) XXX_DiscardUnknown() {
+	xxx_messageInfo_Canary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RaftServer proto.InternalMessageInfo
+var xxx_messageInfo_Canary proto.InternalMessageInfo
 
-func (m *RaftServer) GetId() string {
+func (m *Canary) GetExecutor() string {
 	if m != nil {
-		return m.Id
+		return m.Executor
 	}
 	return ""
 }
 
-func (m *RaftServer) GetNode() string {
+func (m *Canary) GetExecutorConfig() map[string]string {
 	if m != nil {
-		return m.Node
+		return m.ExecutorConfig
 	}
-	return ""
+	return nil
 }
 
-func (m *RaftServer) GetAddress() string {
+func (m *Canary) GetPercent() int32 {
 	if m != nil {
-		return m.Address
+		return m.Percent
 	}
-	return ""
+	return 0
 }
 
-func (m *RaftServer) GetLeader() bool {
+func (m *Canary) GetNodes() []string {
 	if m != nil {
-		return m.Leader
+		return m.Nodes
 	}
-	return false
+	return nil
 }
 
-func (m *RaftServer) GetVoter() bool {
+func (m *Canary) GetStartedAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.Voter
+		return m.StartedAt
 	}
-	return false
+	return nil
 }
 
-func (m *RaftServer) GetRaftProtocol() string {
+func (m *Canary) GetEndsAt() *timestamp.Timestamp {
 	if m != nil {
-		return m.RaftProtocol
+		return m.EndsAt
 	}
-	return ""
+	return nil
 }
 
-type RaftGetConfigurationResponse struct {
-	Servers              []*RaftServer `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
-	Index                uint64        `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *Canary) GetStable() *CanaryStats {
+	if m != nil {
+		return m.Stable
+	}
+	return nil
 }
 
-func (m *RaftGetConfigurationResponse) Reset()         { *m = RaftGetConfigurationResponse{} }
-func (m *RaftGetConfigurationResponse) String() string { return proto.CompactTextString(m) }
-func (*RaftGetConfigurationResponse) ProtoMessage()    {}
-func (*RaftGetConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{16}
+func (m *Canary) GetCanary() *CanaryStats {
+	if m != nil {
+		return m.Canary
+	}
+	return nil
 }
 
-func (m *RaftGetConfigurationResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RaftGetConfigurationResponse.Unmarshal(m, b)
+type CanaryStats struct {
+	SuccessCount         int32    `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	ErrorCount           int32    `protobuf:"varint,2,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	TotalDuration        int64    `protobuf:"varint,3,opt,name=total_duration,json=totalDuration,proto3" json:"total_duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *RaftGetConfigurationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RaftGetConfigurationResponse.Marshal(b, m, deterministic)
+
+func (m *CanaryStats) Reset()         { *m = CanaryStats{} }
+func (m *CanaryStats) String() string { return proto.CompactTextString(m) }
+func (*CanaryStats) ProtoMessage()    {}
+func (*CanaryStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{9}
 }
-func (m *RaftGetConfigurationResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RaftGetConfigurationResponse.Merge(m, src)
+
+func (m *CanaryStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CanaryStats.Unmarshal(m, b)
 }
-func (m *RaftGetConfigurationResponse) XXX_Size() int {
-	return xxx_messageInfo_RaftGetConfigurationResponse.Size(m)
+func (m *CanaryStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CanaryStats.Marshal(b, m, deterministic)
 }
-func (m *RaftGetConfigurationResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RaftGetConfigurationResponse.DiscardUnknown(m)
+func (m *CanaryStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CanaryStats.Merge(m, src)
+}
+func (m *CanaryStats) XXX_Size() int {
+	return xxx_messageInfo_CanaryStats.Size(m)
+}
+func (m *CanaryStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CanaryStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RaftGetConfigurationResponse proto.InternalMessageInfo
+var xxx_messageInfo_CanaryStats proto.InternalMessageInfo
 
-func (m *RaftGetConfigurationResponse) GetServers() []*RaftServer {
+func (m *CanaryStats) GetSuccessCount() int32 {
 	if m != nil {
-		return m.Servers
+		return m.SuccessCount
 	}
-	return nil
+	return 0
 }
 
-func (m *RaftGetConfigurationResponse) GetIndex() uint64 {
+func (m *CanaryStats) GetErrorCount() int32 {
 	if m != nil {
-		return m.Index
+		return m.ErrorCount
 	}
 	return 0
 }
 
-type RaftRemovePeerByIDRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *CanaryStats) GetTotalDuration() int64 {
+	if m != nil {
+		return m.TotalDuration
+	}
+	return 0
+}
+
+type EscalationStep struct {
+	After                int32    `protobuf:"varint,1,opt,name=after,proto3" json:"after,omitempty"`
+	Webhook              string   `protobuf:"bytes,2,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Payload              string   `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Email                string   `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Disable              bool     `protobuf:"varint,5,opt,name=disable,proto3" json:"disable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RaftRemovePeerByIDRequest) Reset()         { *m = RaftRemovePeerByIDRequest{} }
-func (m *RaftRemovePeerByIDRequest) String() string { return proto.CompactTextString(m) }
-func (*RaftRemovePeerByIDRequest) ProtoMessage()    {}
-func (*RaftRemovePeerByIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{17}
+func (m *EscalationStep) Reset()         { *m = EscalationStep{} }
+func (m *EscalationStep) String() string { return proto.CompactTextString(m) }
+func (*EscalationStep) ProtoMessage()    {}
+func (*EscalationStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{10}
 }
 
-func (m *RaftRemovePeerByIDRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RaftRemovePeerByIDRequest.Unmarshal(m, b)
+func (m *EscalationStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EscalationStep.Unmarshal(m, b)
 }
-func (m *RaftRemovePeerByIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RaftRemovePeerByIDRequest.Marshal(b, m, deterministic)
+func (m *EscalationStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EscalationStep.Marshal(b, m, deterministic)
 }
-func (m *RaftRemovePeerByIDRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RaftRemovePeerByIDRequest.Merge(m, src)
+func (m *EscalationStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EscalationStep.Merge(m, src)
 }
-func (m *RaftRemovePeerByIDRequest) XXX_Size() int {
-	return xxx_messageInfo_RaftRemovePeerByIDRequest.Size(m)
+func (m *EscalationStep) XXX_Size() int {
+	return xxx_messageInfo_EscalationStep.Size(m)
 }
-func (m *RaftRemovePeerByIDRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RaftRemovePeerByIDRequest.DiscardUnknown(m)
+func (m *EscalationStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_EscalationStep.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RaftRemovePeerByIDRequest proto.InternalMessageInfo
+var xxx_messageInfo_EscalationStep proto.InternalMessageInfo
 
-func (m *RaftRemovePeerByIDRequest) GetId() string {
+func (m *EscalationStep) GetAfter() int32 {
 	if m != nil {
-		return m.Id
+		return m.After
 	}
-	return ""
+	return 0
 }
 
-type AgentRunStream struct {
-	Execution            *Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *EscalationStep) GetWebhook() string {
+	if m != nil {
+		return m.Webhook
+	}
+	return ""
 }
 
-func (m *AgentRunStream) Reset()         { *m = AgentRunStream{} }
-func (m *AgentRunStream) String() string { return proto.CompactTextString(m) }
-func (*AgentRunStream) ProtoMessage()    {}
-func (*AgentRunStream) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{18}
+func (m *EscalationStep) GetPayload() string {
+	if m != nil {
+		return m.Payload
+	}
+	return ""
 }
 
-func (m *AgentRunStream) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AgentRunStream.Unmarshal(m, b)
+func (m *EscalationStep) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
 }
-func (m *AgentRunStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AgentRunStream.Marshal(b, m, deterministic)
+
+func (m *EscalationStep) GetDisable() bool {
+	if m != nil {
+		return m.Disable
+	}
+	return false
 }
-func (m *AgentRunStream) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AgentRunStream.Merge(m, src)
+
+type JobStep struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Executor             string            `protobuf:"bytes,2,opt,name=executor,proto3" json:"executor,omitempty"`
+	ExecutorConfig       map[string]string `protobuf:"bytes,3,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
-func (m *AgentRunStream) XXX_Size() int {
-	return xxx_messageInfo_AgentRunStream.Size(m)
+
+func (m *JobStep) Reset()         { *m = JobStep{} }
+func (m *JobStep) String() string { return proto.CompactTextString(m) }
+func (*JobStep) ProtoMessage()    {}
+func (*JobStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{11}
 }
-func (m *AgentRunStream) XXX_DiscardUnknown() {
-	xxx_messageInfo_AgentRunStream.DiscardUnknown(m)
+
+func (m *JobStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobStep.Unmarshal(m, b)
+}
+func (m *JobStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobStep.Marshal(b, m, deterministic)
+}
+func (m *JobStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobStep.Merge(m, src)
+}
+func (m *JobStep) XXX_Size() int {
+	return xxx_messageInfo_JobStep.Size(m)
+}
+func (m *JobStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobStep.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AgentRunStream proto.InternalMessageInfo
+var xxx_messageInfo_JobStep proto.InternalMessageInfo
 
-func (m *AgentRunStream) GetExecution() *Execution {
+func (m *JobStep) GetName() string {
 	if m != nil {
-		return m.Execution
+		return m.Name
+	}
+	return ""
+}
+
+func (m *JobStep) GetExecutor() string {
+	if m != nil {
+		return m.Executor
+	}
+	return ""
+}
+
+func (m *JobStep) GetExecutorConfig() map[string]string {
+	if m != nil {
+		return m.ExecutorConfig
 	}
 	return nil
 }
 
-type AgentRunResponse struct {
-	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+type JobResources struct {
+	Cpu                  float64  `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Memory               int64    `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	IoClass              string   `protobuf:"bytes,3,opt,name=io_class,json=ioClass,proto3" json:"io_class,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AgentRunResponse) Reset()         { *m = AgentRunResponse{} }
-func (m *AgentRunResponse) String() string { return proto.CompactTextString(m) }
-func (*AgentRunResponse) ProtoMessage()    {}
-func (*AgentRunResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{19}
+func (m *JobResources) Reset()         { *m = JobResources{} }
+func (m *JobResources) String() string { return proto.CompactTextString(m) }
+func (*JobResources) ProtoMessage()    {}
+func (*JobResources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{12}
 }
 
-func (m *AgentRunResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AgentRunResponse.Unmarshal(m, b)
+func (m *JobResources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobResources.Unmarshal(m, b)
 }
-func (m *AgentRunResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AgentRunResponse.Marshal(b, m, deterministic)
+func (m *JobResources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobResources.Marshal(b, m, deterministic)
 }
-func (m *AgentRunResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AgentRunResponse.Merge(m, src)
+func (m *JobResources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobResources.Merge(m, src)
 }
-func (m *AgentRunResponse) XXX_Size() int {
-	return xxx_messageInfo_AgentRunResponse.Size(m)
+func (m *JobResources) XXX_Size() int {
+	return xxx_messageInfo_JobResources.Size(m)
 }
-func (m *AgentRunResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_AgentRunResponse.DiscardUnknown(m)
+func (m *JobResources) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobResources.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AgentRunResponse proto.InternalMessageInfo
+var xxx_messageInfo_JobResources proto.InternalMessageInfo
 
-func (m *AgentRunResponse) GetFrom() string {
+func (m *JobResources) GetCpu() float64 {
 	if m != nil {
-		return m.From
+		return m.Cpu
 	}
-	return ""
+	return 0
 }
 
-func (m *AgentRunResponse) GetPayload() []byte {
+func (m *JobResources) GetMemory() int64 {
 	if m != nil {
-		return m.Payload
+		return m.Memory
 	}
-	return nil
+	return 0
 }
 
-type GetActiveExecutionsResponse struct {
-	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+func (m *JobResources) GetIoClass() string {
+	if m != nil {
+		return m.IoClass
+	}
+	return ""
 }
 
-func (m *GetActiveExecutionsResponse) Reset()         { *m = GetActiveExecutionsResponse{} }
-func (m *GetActiveExecutionsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetActiveExecutionsResponse) ProtoMessage()    {}
-func (*GetActiveExecutionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{20}
+type PluginConfig struct {
+	Config               map[string]string `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *GetActiveExecutionsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetActiveExecutionsResponse.Unmarshal(m, b)
+func (m *PluginConfig) Reset()         { *m = PluginConfig{} }
+func (m *PluginConfig) String() string { return proto.CompactTextString(m) }
+func (*PluginConfig) ProtoMessage()    {}
+func (*PluginConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{13}
 }
-func (m *GetActiveExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetActiveExecutionsResponse.Marshal(b, m, deterministic)
+
+func (m *PluginConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PluginConfig.Unmarshal(m, b)
 }
-func (m *GetActiveExecutionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetActiveExecutionsResponse.Merge(m, src)
+func (m *PluginConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PluginConfig.Marshal(b, m, deterministic)
 }
-func (m *GetActiveExecutionsResponse) XXX_Size() int {
-	return xxx_messageInfo_GetActiveExecutionsResponse.Size(m)
+func (m *PluginConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PluginConfig.Merge(m, src)
 }
-func (m *GetActiveExecutionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetActiveExecutionsResponse.DiscardUnknown(m)
+func (m *PluginConfig) XXX_Size() int {
+	return xxx_messageInfo_PluginConfig.Size(m)
+}
+func (m *PluginConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_PluginConfig.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetActiveExecutionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_PluginConfig proto.InternalMessageInfo
 
-func (m *GetActiveExecutionsResponse) GetExecutions() []*Execution {
+func (m *PluginConfig) GetConfig() map[string]string {
 	if m != nil {
-		return m.Executions
+		return m.Config
 	}
 	return nil
 }
 
-type AgentRunRequest struct {
-	Job                  *Job       `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	Execution            *Execution `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type SetJobRequest struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	AdminToken           string   `protobuf:"bytes,2,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AgentRunRequest) Reset()         { *m = AgentRunRequest{} }
-func (m *AgentRunRequest) String() string { return proto.CompactTextString(m) }
-func (*AgentRunRequest) ProtoMessage()    {}
-func (*AgentRunRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1f0292872e9433f8, []int{21}
+func (m *SetJobRequest) Reset()         { *m = SetJobRequest{} }
+func (m *SetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*SetJobRequest) ProtoMessage()    {}
+func (*SetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{14}
 }
 
-func (m *AgentRunRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AgentRunRequest.Unmarshal(m, b)
+func (m *SetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetJobRequest.Unmarshal(m, b)
 }
-func (m *AgentRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AgentRunRequest.Marshal(b, m, deterministic)
+func (m *SetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetJobRequest.Marshal(b, m, deterministic)
 }
-func (m *AgentRunRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AgentRunRequest.Merge(m, src)
+func (m *SetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetJobRequest.Merge(m, src)
 }
-func (m *AgentRunRequest) XXX_Size() int {
-	return xxx_messageInfo_AgentRunRequest.Size(m)
+func (m *SetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_SetJobRequest.Size(m)
 }
-func (m *AgentRunRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AgentRunRequest.DiscardUnknown(m)
+func (m *SetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetJobRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AgentRunRequest proto.InternalMessageInfo
+var xxx_messageInfo_SetJobRequest proto.InternalMessageInfo
 
-func (m *AgentRunRequest) GetJob() *Job {
+func (m *SetJobRequest) GetJob() *Job {
 	if m != nil {
 		return m.Job
 	}
 	return nil
 }
 
-func (m *AgentRunRequest) GetExecution() *Execution {
+func (m *SetJobRequest) GetAdminToken() string {
 	if m != nil {
-		return m.Execution
+		return m.AdminToken
 	}
-	return nil
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*Job)(nil), "types.Job")
-	proto.RegisterMapType((map[string]string)(nil), "types.Job.ExecutorConfigEntry")
-	proto.RegisterMapType((map[string]string)(nil), "types.Job.MetadataEntry")
-	proto.RegisterMapType((map[string]*PluginConfig)(nil), "types.Job.ProcessorsEntry")
-	proto.RegisterMapType((map[string]string)(nil), "types.Job.TagsEntry")
-	proto.RegisterType((*Job_NullableTime)(nil), "types.Job.NullableTime")
-	proto.RegisterType((*PluginConfig)(nil), "types.PluginConfig")
-	proto.RegisterMapType((map[string]string)(nil), "types.PluginConfig.ConfigEntry")
-	proto.RegisterType((*SetJobRequest)(nil), "types.SetJobRequest")
-	proto.RegisterType((*SetJobResponse)(nil), "types.SetJobResponse")
-	proto.RegisterType((*DeleteJobRequest)(nil), "types.DeleteJobRequest")
-	proto.RegisterType((*DeleteJobResponse)(nil), "types.DeleteJobResponse")
-	proto.RegisterType((*GetJobRequest)(nil), "types.GetJobRequest")
-	proto.RegisterType((*GetJobResponse)(nil), "types.GetJobResponse")
-	proto.RegisterType((*Execution)(nil), "types.Execution")
-	proto.RegisterType((*ExecutionDoneRequest)(nil), "types.ExecutionDoneRequest")
-	proto.RegisterType((*ExecutionDoneResponse)(nil), "types.ExecutionDoneResponse")
-	proto.RegisterType((*RunJobRequest)(nil), "types.RunJobRequest")
-	proto.RegisterType((*RunJobResponse)(nil), "types.RunJobResponse")
-	proto.RegisterType((*ToggleJobRequest)(nil), "types.ToggleJobRequest")
-	proto.RegisterType((*ToggleJobResponse)(nil), "types.ToggleJobResponse")
-	proto.RegisterType((*RaftServer)(nil), "types.RaftServer")
-	proto.RegisterType((*RaftGetConfigurationResponse)(nil), "types.RaftGetConfigurationResponse")
-	proto.RegisterType((*RaftRemovePeerByIDRequest)(nil), "types.RaftRemovePeerByIDRequest")
-	proto.RegisterType((*AgentRunStream)(nil), "types.AgentRunStream")
-	proto.RegisterType((*AgentRunResponse)(nil), "types.AgentRunResponse")
-	proto.RegisterType((*GetActiveExecutionsResponse)(nil), "types.GetActiveExecutionsResponse")
-	proto.RegisterType((*AgentRunRequest)(nil), "types.AgentRunRequest")
+type SetJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("dkron.proto", fileDescriptor_1f0292872e9433f8)
+func (m *SetJobResponse) Reset()         { *m = SetJobResponse{} }
+func (m *SetJobResponse) String() string { return proto.CompactTextString(m) }
+func (*SetJobResponse) ProtoMessage()    {}
+func (*SetJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{15}
 }
 
-var fileDescriptor_1f0292872e9433f8 = []byte{
-	// 1330 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xdb, 0x6e, 0x1b, 0x37,
-	0x13, 0xc6, 0x4a, 0x96, 0xad, 0x1d, 0x1d, 0xec, 0xd0, 0x4e, 0xb2, 0x59, 0xe7, 0xff, 0x23, 0x28,
-	0xf8, 0x01, 0xfd, 0x0d, 0xa2, 0xa4, 0x6e, 0xd3, 0x9c, 0x80, 0x20, 0x6e, 0xec, 0x1a, 0x35, 0xda,
-	0xd4, 0x5d, 0x19, 0xbd, 0xe9, 0x85, 0x40, 0x69, 0xc7, 0xf2, 0x26, 0xab, 0xa5, 0x4a, 0x72, 0xdd,
-	0xa8, 0x97, 0x7d, 0x8f, 0x3e, 0x46, 0x9f, 0xa8, 0x2f, 0x52, 0x90, 0x5c, 0xae, 0x57, 0xb2, 0xe4,
-	0x43, 0xee, 0x38, 0xc3, 0x8f, 0xc3, 0x39, 0x7c, 0x33, 0x24, 0xd4, 0xc2, 0x8f, 0x9c, 0x25, 0xdd,
-	0x09, 0x67, 0x92, 0x91, 0x8a, 0x9c, 0x4e, 0x50, 0xf8, 0x0f, 0x46, 0x8c, 0x8d, 0x62, 0x7c, 0xa2,
-	0x95, 0x83, 0xf4, 0xe4, 0x89, 0x8c, 0xc6, 0x28, 0x24, 0x1d, 0x4f, 0x0c, 0xce, 0xdf, 0x9e, 0x07,
-	0xe0, 0x78, 0x22, 0xa7, 0x66, 0xb3, 0xfd, 0xb7, 0x0b, 0xe5, 0x43, 0x36, 0x20, 0x04, 0x56, 0x12,
-	0x3a, 0x46, 0xcf, 0x69, 0x39, 0x1d, 0x37, 0xd0, 0x6b, 0xe2, 0x43, 0x55, 0xd9, 0xfa, 0x83, 0x25,
-	0xe8, 0x95, 0xb4, 0x3e, 0x97, 0xd5, 0x9e, 0x18, 0x9e, 0x62, 0x98, 0xc6, 0xe8, 0x95, 0xcd, 0x9e,
-	0x95, 0xc9, 0x16, 0x54, 0xd8, 0xef, 0x09, 0x72, 0x6f, 0x4d, 0x6f, 0x18, 0x81, 0x3c, 0x80, 0x9a,
-	0x5e, 0xf4, 0x71, 0x4c, 0xa3, 0xd8, 0xab, 0xea, 0x3d, 0xd0, 0xaa, 0x7d, 0xa5, 0x21, 0x0f, 0xa1,
-	0x21, 0xd2, 0xe1, 0x10, 0x85, 0xe8, 0x0f, 0x59, 0x9a, 0x48, 0xcf, 0x6d, 0x39, 0x9d, 0x4a, 0x50,
-	0xcf, 0x94, 0xef, 0x94, 0x4e, 0x59, 0x41, 0xce, 0x19, 0xcf, 0x20, 0xa0, 0x21, 0xa0, 0x55, 0x06,
-	0xe0, 0x43, 0x35, 0x8c, 0x04, 0x1d, 0xc4, 0x18, 0x7a, 0xb5, 0x96, 0xd3, 0xa9, 0x06, 0xb9, 0x4c,
-	0x3a, 0xb0, 0x22, 0xe9, 0x48, 0x78, 0xf5, 0x56, 0xb9, 0x53, 0xdb, 0xd9, 0xea, 0xea, 0x04, 0x76,
-	0x0f, 0xd9, 0xa0, 0x7b, 0x4c, 0x47, 0x62, 0x3f, 0x91, 0x7c, 0x1a, 0x68, 0x04, 0xf1, 0x60, 0x8d,
-	0xa3, 0xe4, 0x11, 0x0a, 0xaf, 0xd1, 0x72, 0x3a, 0x8d, 0xc0, 0x8a, 0xe4, 0x7f, 0xd0, 0x0c, 0x71,
-	0x82, 0x49, 0x88, 0x89, 0xec, 0x7f, 0x60, 0x03, 0xe1, 0x35, 0x5b, 0xe5, 0x8e, 0x1b, 0x34, 0x72,
-	0xed, 0x21, 0x1b, 0x08, 0xf2, 0x1f, 0x80, 0x09, 0xe5, 0x19, 0xc6, 0x5b, 0xd7, 0xc1, 0xba, 0x46,
-	0xa3, 0xd2, 0xdd, 0x82, 0xda, 0x90, 0x25, 0xc3, 0x94, 0x73, 0x4c, 0x86, 0x53, 0x6f, 0x43, 0xef,
-	0x17, 0x55, 0x2a, 0x0e, 0xfc, 0x84, 0xc3, 0x54, 0x32, 0xee, 0xdd, 0x32, 0x09, 0xb6, 0x32, 0x39,
-	0x80, 0x75, 0xbb, 0xee, 0x0f, 0x59, 0x72, 0x12, 0x8d, 0x3c, 0xa2, 0x43, 0xfa, 0x6f, 0x21, 0xa4,
-	0xfd, 0x0c, 0xf1, 0x4e, 0x03, 0x4c, 0x70, 0x4d, 0x9c, 0x51, 0x92, 0x3b, 0xb0, 0x2a, 0x24, 0x95,
-	0xa9, 0xf0, 0x36, 0xf5, 0x15, 0x99, 0x44, 0xbe, 0x86, 0xea, 0x18, 0x25, 0x0d, 0xa9, 0xa4, 0xde,
-	0x96, 0xb6, 0xec, 0x15, 0x2c, 0xff, 0x98, 0x6d, 0x19, 0x9b, 0x39, 0x92, 0xbc, 0x82, 0x7a, 0x4c,
-	0x85, 0xec, 0x67, 0x05, 0xf3, 0xee, 0xb5, 0x9c, 0x4e, 0x6d, 0xe7, 0x6e, 0xe1, 0xe4, 0xfb, 0x34,
-	0x8e, 0x55, 0x29, 0x8e, 0xa3, 0x31, 0x06, 0x35, 0x05, 0xee, 0x19, 0x2c, 0xf9, 0x06, 0x40, 0x9f,
-	0xd5, 0x95, 0xf4, 0xfc, 0xcb, 0x4f, 0xba, 0x0a, 0xba, 0xaf, 0x90, 0xa4, 0x0b, 0x2b, 0x09, 0x7e,
-	0x92, 0xde, 0x5d, 0x7d, 0xc2, 0xef, 0x1a, 0xae, 0x77, 0x2d, 0xd7, 0xbb, 0xc7, 0xb6, 0x19, 0x02,
-	0x8d, 0x53, 0x89, 0x0f, 0x23, 0x31, 0x89, 0xe9, 0x54, 0xd3, 0xdd, 0x33, 0x89, 0x2f, 0xa8, 0xc8,
-	0x2b, 0x80, 0x09, 0x67, 0xca, 0x29, 0xc6, 0x85, 0xb7, 0xad, 0xa3, 0xf7, 0x0b, 0x9e, 0x1c, 0xe5,
-	0x9b, 0x26, 0xfe, 0x02, 0xda, 0x7f, 0x0e, 0x6e, 0xce, 0x24, 0xb2, 0x01, 0xe5, 0x8f, 0x38, 0xcd,
-	0x3a, 0x4a, 0x2d, 0x55, 0x63, 0x9c, 0xd1, 0x38, 0xb5, 0xdd, 0x64, 0x84, 0x57, 0xa5, 0x17, 0x8e,
-	0xbf, 0x0b, 0x9b, 0x0b, 0xea, 0x75, 0x23, 0x13, 0xaf, 0xa1, 0x31, 0x53, 0x98, 0x1b, 0x1d, 0xfe,
-	0x15, 0xea, 0xc5, 0x0c, 0x93, 0x6d, 0x70, 0x4f, 0xa9, 0xe8, 0x1b, 0xb4, 0x63, 0xda, 0xe8, 0x94,
-	0x8a, 0x5f, 0x94, 0xac, 0x72, 0xae, 0xe6, 0x80, 0xb6, 0x72, 0x45, 0xce, 0x15, 0xce, 0x0f, 0x60,
-	0x7d, 0x2e, 0x69, 0x0b, 0x7c, 0xfb, 0x7f, 0xd1, 0xb7, 0xda, 0xce, 0x66, 0x96, 0xf1, 0xa3, 0x38,
-	0x1d, 0x45, 0x89, 0xc9, 0x49, 0xc1, 0xe1, 0xf6, 0x9f, 0x0e, 0xd4, 0x8b, 0x7b, 0xe4, 0x39, 0xac,
-	0x66, 0xad, 0xe0, 0xe8, 0x92, 0x3d, 0x58, 0x60, 0xa0, 0x5b, 0xec, 0x85, 0x0c, 0xee, 0xbf, 0x84,
-	0xda, 0x67, 0xa6, 0xbc, 0xfd, 0x18, 0x1a, 0x3d, 0x54, 0xfd, 0x1c, 0xe0, 0x6f, 0x29, 0x0a, 0x49,
-	0xee, 0x43, 0x59, 0xb5, 0xbb, 0xa3, 0x43, 0x80, 0x73, 0xd2, 0x04, 0x4a, 0xdd, 0xee, 0x42, 0xd3,
-	0xc2, 0xc5, 0x84, 0x25, 0x02, 0xaf, 0xc0, 0x3f, 0x86, 0x8d, 0x3d, 0x8c, 0x51, 0x62, 0xe1, 0x86,
-	0x7b, 0x50, 0xfd, 0xc0, 0x06, 0xfd, 0xc2, 0xac, 0x5e, 0xfb, 0xc0, 0x06, 0xef, 0xe9, 0x18, 0xdb,
-	0x5f, 0xc2, 0xad, 0x02, 0xfc, 0x5a, 0x37, 0x7c, 0x01, 0x8d, 0x83, 0x99, 0x00, 0x2e, 0x31, 0xdf,
-	0x85, 0xe6, 0xc1, 0x4d, 0xbc, 0xff, 0xab, 0x04, 0xae, 0xe1, 0x74, 0xc4, 0x92, 0x4b, 0x0c, 0xab,
-	0x59, 0x6b, 0x27, 0x46, 0x49, 0x33, 0xcd, 0x8a, 0x6a, 0x3c, 0xb1, 0x54, 0x4e, 0x52, 0xa9, 0x9f,
-	0x98, 0x7a, 0x90, 0x49, 0x8a, 0x9d, 0x09, 0x0b, 0xd1, 0x58, 0x5b, 0x31, 0xc3, 0x51, 0x29, 0xb4,
-	0xb9, 0x2d, 0xa8, 0x8c, 0x38, 0x4b, 0x27, 0x5e, 0xa5, 0xe5, 0x74, 0xca, 0x81, 0x11, 0xd4, 0x25,
-	0x54, 0x4a, 0xf5, 0xf2, 0x79, 0xab, 0x66, 0xa0, 0x67, 0x22, 0x79, 0x09, 0x20, 0x24, 0xe5, 0x12,
-	0xc3, 0x3e, 0x95, 0xfa, 0xc9, 0xba, 0x9c, 0xd3, 0x6e, 0x86, 0xde, 0x95, 0xe4, 0x35, 0xd4, 0x4e,
-	0xa2, 0x24, 0x12, 0xa7, 0xe6, 0x6c, 0xf5, 0xca, 0xb3, 0x60, 0xe1, 0xbb, 0xb2, 0xfd, 0x1d, 0x6c,
-	0xe5, 0xe9, 0xd9, 0x63, 0x09, 0xda, 0x12, 0x74, 0xc1, 0x45, 0xab, 0xcf, 0x72, 0xbb, 0x91, 0xe5,
-	0x36, 0xc7, 0x07, 0xe7, 0x90, 0xf6, 0x3e, 0xdc, 0x9e, 0xb3, 0x93, 0x95, 0x87, 0xc0, 0xca, 0x09,
-	0x67, 0x63, 0xfb, 0xa4, 0xab, 0xb5, 0x4a, 0xc3, 0x84, 0x4e, 0x63, 0x46, 0x43, 0x9d, 0xeb, 0x7a,
-	0x60, 0x45, 0x45, 0x85, 0x20, 0x4d, 0xae, 0x4d, 0x05, 0x8b, 0xbd, 0x2e, 0x91, 0x8f, 0xd9, 0x68,
-	0x14, 0x5f, 0x9f, 0xc8, 0x05, 0xf8, 0xf5, 0xc8, 0xe6, 0x00, 0x04, 0xf4, 0x44, 0xf6, 0x90, 0x9f,
-	0x21, 0x27, 0x4d, 0x28, 0x45, 0x61, 0x66, 0xb6, 0x14, 0x85, 0xfa, 0x77, 0xc3, 0x42, 0xdb, 0xc1,
-	0x7a, 0xad, 0x19, 0x11, 0x86, 0x5c, 0xd1, 0xce, 0x7c, 0x60, 0xac, 0xa8, 0x68, 0x17, 0x23, 0x0d,
-	0x91, 0x6b, 0x6e, 0x55, 0x83, 0x4c, 0xd2, 0x83, 0x80, 0x49, 0xe4, 0x9a, 0x59, 0xd5, 0xc0, 0x08,
-	0xea, 0xdb, 0xc2, 0xe9, 0x89, 0xec, 0xeb, 0x72, 0x0f, 0x59, 0xac, 0xf9, 0xe5, 0x06, 0x75, 0xa5,
-	0x3c, 0xca, 0x74, 0x6d, 0x0a, 0xf7, 0x95, 0x7b, 0x07, 0x28, 0xcd, 0xac, 0x49, 0x39, 0xd5, 0x75,
-	0xb4, 0xd1, 0x3d, 0x82, 0x35, 0xa1, 0x5d, 0x17, 0xd9, 0xf8, 0xba, 0x95, 0x45, 0x78, 0x1e, 0x54,
-	0x60, 0x11, 0xca, 0x8f, 0x28, 0x09, 0xf1, 0x93, 0x0e, 0x67, 0x25, 0x30, 0x42, 0xfb, 0x11, 0xdc,
-	0x53, 0xe0, 0x00, 0xc7, 0xec, 0x0c, 0x8f, 0x10, 0xf9, 0xb7, 0xd3, 0xef, 0xf7, 0x6c, 0xb6, 0xe7,
-	0x12, 0xd2, 0x7e, 0x0b, 0xcd, 0xdd, 0x11, 0x26, 0x32, 0x48, 0x93, 0x9e, 0xe4, 0x48, 0xc7, 0x37,
-	0xa6, 0xdd, 0x5b, 0xd8, 0xb0, 0x16, 0x3e, 0x93, 0x71, 0x3f, 0xc1, 0xf6, 0x01, 0xca, 0xdd, 0xa1,
-	0x8c, 0xce, 0x30, 0xbf, 0x42, 0xe4, 0xc6, 0x9e, 0x02, 0xe4, 0xb7, 0xd9, 0xac, 0x5c, 0xf4, 0xa8,
-	0x80, 0x69, 0xf7, 0x61, 0xfd, 0xdc, 0xa5, 0x6b, 0x0c, 0xe4, 0xd9, 0x98, 0x4b, 0x57, 0xc6, 0xbc,
-	0xf3, 0x4f, 0x05, 0x2a, 0x7b, 0xea, 0x07, 0x4e, 0x9e, 0xc1, 0xaa, 0x19, 0x86, 0xc4, 0xfe, 0x22,
-	0x67, 0xe6, 0xa8, 0x7f, 0x7b, 0x4e, 0x9b, 0xc5, 0x74, 0x08, 0x8d, 0x99, 0x5e, 0x25, 0xdb, 0xf3,
-	0xd7, 0x15, 0x26, 0x81, 0x7f, 0x7f, 0xf1, 0x66, 0x66, 0xeb, 0x39, 0x54, 0x7e, 0x40, 0x7a, 0x86,
-	0xe4, 0xce, 0x85, 0x81, 0xb3, 0xaf, 0x3e, 0xf8, 0xfe, 0x12, 0xbd, 0xf2, 0xbd, 0x37, 0xeb, 0x7b,
-	0x6f, 0xa1, 0xef, 0x73, 0x6f, 0xd5, 0x1b, 0x70, 0xf3, 0xe7, 0x85, 0xd8, 0xaf, 0xd9, 0xfc, 0xfb,
-	0xe4, 0x7b, 0x17, 0x37, 0xb2, 0xf3, 0xcf, 0x60, 0xd5, 0x0c, 0x8d, 0xfc, 0xda, 0x99, 0x79, 0x93,
-	0x5f, 0x3b, 0x37, 0x59, 0xde, 0x80, 0x9b, 0x0f, 0x83, 0xfc, 0xda, 0xf9, 0x69, 0x92, 0x5f, 0x7b,
-	0x71, 0x6e, 0xf4, 0x60, 0x6b, 0x51, 0xe7, 0x2d, 0xcd, 0xda, 0xc3, 0x42, 0xe3, 0x2d, 0x6d, 0xd7,
-	0xf7, 0x40, 0x2e, 0xf6, 0x1a, 0x69, 0x15, 0x8e, 0x2e, 0x6c, 0xc3, 0xa5, 0x25, 0xf9, 0x19, 0x36,
-	0x17, 0xb4, 0xc2, 0x52, 0x1f, 0xdb, 0xe7, 0xec, 0x5a, 0xda, 0x3e, 0x2f, 0xa0, 0xde, 0x43, 0x79,
-	0xfe, 0x00, 0x5f, 0x20, 0xf6, 0x32, 0x67, 0x76, 0xf6, 0xa0, 0xa2, 0xdb, 0x88, 0xbc, 0x86, 0xaa,
-	0xed, 0x27, 0x72, 0x27, 0x3b, 0x3e, 0xd7, 0x60, 0x79, 0xd5, 0x66, 0xa7, 0xc9, 0x53, 0x67, 0xb0,
-	0xaa, 0xad, 0x7e, 0xf5, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc5, 0xb4, 0x29, 0x03, 0xb3, 0x0e,
-	0x00, 0x00,
+func (m *SetJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetJobResponse.Unmarshal(m, b)
+}
+func (m *SetJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetJobResponse.Marshal(b, m, deterministic)
+}
+func (m *SetJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetJobResponse.Merge(m, src)
+}
+func (m *SetJobResponse) XXX_Size() int {
+	return xxx_messageInfo_SetJobResponse.Size(m)
+}
+func (m *SetJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetJobResponse.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+var xxx_messageInfo_SetJobResponse proto.InternalMessageInfo
 
-// DkronClient is the client API for Dkron service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DkronClient interface {
-	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
-	ExecutionDone(ctx context.Context, in *ExecutionDoneRequest, opts ...grpc.CallOption) (*ExecutionDoneResponse, error)
-	Leave(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	SetJob(ctx context.Context, in *SetJobRequest, opts ...grpc.CallOption) (*SetJobResponse, error)
-	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
-	RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error)
-	ToggleJob(ctx context.Context, in *ToggleJobRequest, opts ...grpc.CallOption) (*ToggleJobResponse, error)
-	RaftGetConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RaftGetConfigurationResponse, error)
-	RaftRemovePeerByID(ctx context.Context, in *RaftRemovePeerByIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetActiveExecutions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetActiveExecutionsResponse, error)
-	SetExecution(ctx context.Context, in *Execution, opts ...grpc.CallOption) (*empty.Empty, error)
+func (m *SetJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
 }
 
-type dkronClient struct {
-	cc grpc.ClientConnInterface
+type DeleteJobRequest struct {
+	JobName               string               `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	TrashRetentionSeconds int64                `protobuf:"varint,2,opt,name=trash_retention_seconds,json=trashRetentionSeconds,proto3" json:"trash_retention_seconds,omitempty"`
+	TrashExecutions       bool                 `protobuf:"varint,3,opt,name=trash_executions,json=trashExecutions,proto3" json:"trash_executions,omitempty"`
+	Cascade               bool                 `protobuf:"varint,4,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	Now                   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=now,proto3" json:"now,omitempty"`
+	TrashExpiresAt        *timestamp.Timestamp `protobuf:"bytes,6,opt,name=trash_expires_at,json=trashExpiresAt,proto3" json:"trash_expires_at,omitempty"`
+	AdminToken            string               `protobuf:"bytes,7,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
+	XXX_unrecognized      []byte               `json:"-"`
+	XXX_sizecache         int32                `json:"-"`
 }
 
-func NewDkronClient(cc grpc.ClientConnInterface) DkronClient {
-	return &dkronClient{cc}
+func (m *DeleteJobRequest) Reset()         { *m = DeleteJobRequest{} }
+func (m *DeleteJobRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobRequest) ProtoMessage()    {}
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{16}
 }
 
-func (c *dkronClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
-	out := new(GetJobResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/GetJob", in, out, opts...)
+func (m *DeleteJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobRequest.Unmarshal(m, b)
+}
+func (m *DeleteJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobRequest.Merge(m, src)
+}
+func (m *DeleteJobRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobRequest.Size(m)
+}
+func (m *DeleteJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobRequest proto.InternalMessageInfo
+
+func (m *DeleteJobRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *DeleteJobRequest) GetTrashRetentionSeconds() int64 {
+	if m != nil {
+		return m.TrashRetentionSeconds
+	}
+	return 0
+}
+
+func (m *DeleteJobRequest) GetTrashExecutions() bool {
+	if m != nil {
+		return m.TrashExecutions
+	}
+	return false
+}
+
+func (m *DeleteJobRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
+	}
+	return false
+}
+
+func (m *DeleteJobRequest) GetNow() *timestamp.Timestamp {
+	if m != nil {
+		return m.Now
+	}
+	return nil
+}
+
+func (m *DeleteJobRequest) GetTrashExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.TrashExpiresAt
+	}
+	return nil
+}
+
+func (m *DeleteJobRequest) GetAdminToken() string {
+	if m != nil {
+		return m.AdminToken
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJobResponse) Reset()         { *m = DeleteJobResponse{} }
+func (m *DeleteJobResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteJobResponse) ProtoMessage()    {}
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{17}
+}
+
+func (m *DeleteJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJobResponse.Unmarshal(m, b)
+}
+func (m *DeleteJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJobResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJobResponse.Merge(m, src)
+}
+func (m *DeleteJobResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteJobResponse.Size(m)
+}
+func (m *DeleteJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJobResponse proto.InternalMessageInfo
+
+func (m *DeleteJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRequest) Reset()         { *m = GetJobRequest{} }
+func (m *GetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*GetJobRequest) ProtoMessage()    {}
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{18}
+}
+
+func (m *GetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRequest.Unmarshal(m, b)
+}
+func (m *GetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRequest.Marshal(b, m, deterministic)
+}
+func (m *GetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRequest.Merge(m, src)
+}
+func (m *GetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_GetJobRequest.Size(m)
+}
+func (m *GetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRequest proto.InternalMessageInfo
+
+func (m *GetJobRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobResponse) Reset()         { *m = GetJobResponse{} }
+func (m *GetJobResponse) String() string { return proto.CompactTextString(m) }
+func (*GetJobResponse) ProtoMessage()    {}
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{19}
+}
+
+func (m *GetJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobResponse.Unmarshal(m, b)
+}
+func (m *GetJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobResponse.Marshal(b, m, deterministic)
+}
+func (m *GetJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobResponse.Merge(m, src)
+}
+func (m *GetJobResponse) XXX_Size() int {
+	return xxx_messageInfo_GetJobResponse.Size(m)
+}
+func (m *GetJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobResponse proto.InternalMessageInfo
+
+func (m *GetJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type Execution struct {
+	JobName              string               `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Success              bool                 `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Output               []byte               `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	NodeName             string               `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Group                int64                `protobuf:"varint,5,opt,name=group,proto3" json:"group,omitempty"`
+	Attempt              uint32               `protobuf:"varint,6,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	StartedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt           *timestamp.Timestamp `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Annotations          []string             `protobuf:"bytes,9,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	Artifacts            []*Artifact          `protobuf:"bytes,10,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	RequestId            string               `protobuf:"bytes,11,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ScheduledAt          *timestamp.Timestamp `protobuf:"bytes,12,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	Id                   string               `protobuf:"bytes,13,opt,name=id,proto3" json:"id,omitempty"`
+	Shadow               string               `protobuf:"bytes,14,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	Canary               bool                 `protobuf:"varint,15,opt,name=canary,proto3" json:"canary,omitempty"`
+	Params               map[string]string    `protobuf:"bytes,16,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	JobVersion           int64                `protobuf:"varint,17,opt,name=job_version,json=jobVersion,proto3" json:"job_version,omitempty"`
+	Cost                 float64              `protobuf:"fixed64,18,opt,name=cost,proto3" json:"cost,omitempty"`
+	Metrics              map[string]float64   `protobuf:"bytes,19,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	MatrixItem           string               `protobuf:"bytes,20,opt,name=matrix_item,json=matrixItem,proto3" json:"matrix_item,omitempty"`
+	MatrixSize           int32                `protobuf:"varint,21,opt,name=matrix_size,json=matrixSize,proto3" json:"matrix_size,omitempty"`
+	Discovery            bool                 `protobuf:"varint,22,opt,name=discovery,proto3" json:"discovery,omitempty"`
+	Skipped              bool                 `protobuf:"varint,23,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Execution) Reset()         { *m = Execution{} }
+func (m *Execution) String() string { return proto.CompactTextString(m) }
+func (*Execution) ProtoMessage()    {}
+func (*Execution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{20}
+}
+
+func (m *Execution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Execution.Unmarshal(m, b)
+}
+func (m *Execution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Execution.Marshal(b, m, deterministic)
+}
+func (m *Execution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Execution.Merge(m, src)
+}
+func (m *Execution) XXX_Size() int {
+	return xxx_messageInfo_Execution.Size(m)
+}
+func (m *Execution) XXX_DiscardUnknown() {
+	xxx_messageInfo_Execution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Execution proto.InternalMessageInfo
+
+func (m *Execution) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *Execution) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *Execution) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *Execution) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *Execution) GetGroup() int64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *Execution) GetAttempt() uint32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *Execution) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *Execution) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *Execution) GetAnnotations() []string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *Execution) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *Execution) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *Execution) GetScheduledAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *Execution) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Execution) GetShadow() string {
+	if m != nil {
+		return m.Shadow
+	}
+	return ""
+}
+
+func (m *Execution) GetCanary() bool {
+	if m != nil {
+		return m.Canary
+	}
+	return false
+}
+
+func (m *Execution) GetParams() map[string]string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *Execution) GetJobVersion() int64 {
+	if m != nil {
+		return m.JobVersion
+	}
+	return 0
+}
+
+func (m *Execution) GetCost() float64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+func (m *Execution) GetMetrics() map[string]float64 {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *Execution) GetMatrixItem() string {
+	if m != nil {
+		return m.MatrixItem
+	}
+	return ""
+}
+
+func (m *Execution) GetMatrixSize() int32 {
+	if m != nil {
+		return m.MatrixSize
+	}
+	return 0
+}
+
+func (m *Execution) GetDiscovery() bool {
+	if m != nil {
+		return m.Discovery
+	}
+	return false
+}
+
+func (m *Execution) GetSkipped() bool {
+	if m != nil {
+		return m.Skipped
+	}
+	return false
+}
+
+type Artifact struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256               string   `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Artifact) Reset()         { *m = Artifact{} }
+func (m *Artifact) String() string { return proto.CompactTextString(m) }
+func (*Artifact) ProtoMessage()    {}
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{21}
+}
+
+func (m *Artifact) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Artifact.Unmarshal(m, b)
+}
+func (m *Artifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Artifact.Marshal(b, m, deterministic)
+}
+func (m *Artifact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Artifact.Merge(m, src)
+}
+func (m *Artifact) XXX_Size() int {
+	return xxx_messageInfo_Artifact.Size(m)
+}
+func (m *Artifact) XXX_DiscardUnknown() {
+	xxx_messageInfo_Artifact.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Artifact proto.InternalMessageInfo
+
+func (m *Artifact) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Artifact) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Artifact) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Artifact) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+type ExecutionDoneRequest struct {
+	Execution            *Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ExecutionDoneRequest) Reset()         { *m = ExecutionDoneRequest{} }
+func (m *ExecutionDoneRequest) String() string { return proto.CompactTextString(m) }
+func (*ExecutionDoneRequest) ProtoMessage()    {}
+func (*ExecutionDoneRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{22}
+}
+
+func (m *ExecutionDoneRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionDoneRequest.Unmarshal(m, b)
+}
+func (m *ExecutionDoneRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionDoneRequest.Marshal(b, m, deterministic)
+}
+func (m *ExecutionDoneRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionDoneRequest.Merge(m, src)
+}
+func (m *ExecutionDoneRequest) XXX_Size() int {
+	return xxx_messageInfo_ExecutionDoneRequest.Size(m)
+}
+func (m *ExecutionDoneRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionDoneRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionDoneRequest proto.InternalMessageInfo
+
+func (m *ExecutionDoneRequest) GetExecution() *Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+type ExecutionDoneResponse struct {
+	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecutionDoneResponse) Reset()         { *m = ExecutionDoneResponse{} }
+func (m *ExecutionDoneResponse) String() string { return proto.CompactTextString(m) }
+func (*ExecutionDoneResponse) ProtoMessage()    {}
+func (*ExecutionDoneResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{23}
+}
+
+func (m *ExecutionDoneResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionDoneResponse.Unmarshal(m, b)
+}
+func (m *ExecutionDoneResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionDoneResponse.Marshal(b, m, deterministic)
+}
+func (m *ExecutionDoneResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionDoneResponse.Merge(m, src)
+}
+func (m *ExecutionDoneResponse) XXX_Size() int {
+	return xxx_messageInfo_ExecutionDoneResponse.Size(m)
+}
+func (m *ExecutionDoneResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionDoneResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionDoneResponse proto.InternalMessageInfo
+
+func (m *ExecutionDoneResponse) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ExecutionDoneResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type RunJobRequest struct {
+	JobName              string            `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Annotations          []string          `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	RequestId            string            `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Shadow               *ShadowRun        `protobuf:"bytes,4,opt,name=shadow,proto3" json:"shadow,omitempty"`
+	Params               map[string]string `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *RunJobRequest) Reset()         { *m = RunJobRequest{} }
+func (m *RunJobRequest) String() string { return proto.CompactTextString(m) }
+func (*RunJobRequest) ProtoMessage()    {}
+func (*RunJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{24}
+}
+
+func (m *RunJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunJobRequest.Unmarshal(m, b)
+}
+func (m *RunJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunJobRequest.Marshal(b, m, deterministic)
+}
+func (m *RunJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunJobRequest.Merge(m, src)
+}
+func (m *RunJobRequest) XXX_Size() int {
+	return xxx_messageInfo_RunJobRequest.Size(m)
+}
+func (m *RunJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunJobRequest proto.InternalMessageInfo
+
+func (m *RunJobRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *RunJobRequest) GetAnnotations() []string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *RunJobRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *RunJobRequest) GetShadow() *ShadowRun {
+	if m != nil {
+		return m.Shadow
+	}
+	return nil
+}
+
+func (m *RunJobRequest) GetParams() map[string]string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+type ShadowRun struct {
+	Label                string            `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExecutorConfig       map[string]string `protobuf:"bytes,3,rep,name=executor_config,json=executorConfig,proto3" json:"executor_config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Signature            string            `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ShadowRun) Reset()         { *m = ShadowRun{} }
+func (m *ShadowRun) String() string { return proto.CompactTextString(m) }
+func (*ShadowRun) ProtoMessage()    {}
+func (*ShadowRun) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{25}
+}
+
+func (m *ShadowRun) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShadowRun.Unmarshal(m, b)
+}
+func (m *ShadowRun) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShadowRun.Marshal(b, m, deterministic)
+}
+func (m *ShadowRun) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShadowRun.Merge(m, src)
+}
+func (m *ShadowRun) XXX_Size() int {
+	return xxx_messageInfo_ShadowRun.Size(m)
+}
+func (m *ShadowRun) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShadowRun.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShadowRun proto.InternalMessageInfo
+
+func (m *ShadowRun) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *ShadowRun) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *ShadowRun) GetExecutorConfig() map[string]string {
+	if m != nil {
+		return m.ExecutorConfig
+	}
+	return nil
+}
+
+func (m *ShadowRun) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type RunJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunJobResponse) Reset()         { *m = RunJobResponse{} }
+func (m *RunJobResponse) String() string { return proto.CompactTextString(m) }
+func (*RunJobResponse) ProtoMessage()    {}
+func (*RunJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{26}
+}
+
+func (m *RunJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunJobResponse.Unmarshal(m, b)
+}
+func (m *RunJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunJobResponse.Marshal(b, m, deterministic)
+}
+func (m *RunJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunJobResponse.Merge(m, src)
+}
+func (m *RunJobResponse) XXX_Size() int {
+	return xxx_messageInfo_RunJobResponse.Size(m)
+}
+func (m *RunJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunJobResponse proto.InternalMessageInfo
+
+func (m *RunJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type ToggleJobRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ToggleJobRequest) Reset()         { *m = ToggleJobRequest{} }
+func (m *ToggleJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ToggleJobRequest) ProtoMessage()    {}
+func (*ToggleJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{27}
+}
+
+func (m *ToggleJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ToggleJobRequest.Unmarshal(m, b)
+}
+func (m *ToggleJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ToggleJobRequest.Marshal(b, m, deterministic)
+}
+func (m *ToggleJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ToggleJobRequest.Merge(m, src)
+}
+func (m *ToggleJobRequest) XXX_Size() int {
+	return xxx_messageInfo_ToggleJobRequest.Size(m)
+}
+func (m *ToggleJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ToggleJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ToggleJobRequest proto.InternalMessageInfo
+
+func (m *ToggleJobRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+type ToggleJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ToggleJobResponse) Reset()         { *m = ToggleJobResponse{} }
+func (m *ToggleJobResponse) String() string { return proto.CompactTextString(m) }
+func (*ToggleJobResponse) ProtoMessage()    {}
+func (*ToggleJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{28}
+}
+
+func (m *ToggleJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ToggleJobResponse.Unmarshal(m, b)
+}
+func (m *ToggleJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ToggleJobResponse.Marshal(b, m, deterministic)
+}
+func (m *ToggleJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ToggleJobResponse.Merge(m, src)
+}
+func (m *ToggleJobResponse) XXX_Size() int {
+	return xxx_messageInfo_ToggleJobResponse.Size(m)
+}
+func (m *ToggleJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ToggleJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ToggleJobResponse proto.InternalMessageInfo
+
+func (m *ToggleJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type ResetJobRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	AdminToken           string   `protobuf:"bytes,2,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetJobRequest) Reset()         { *m = ResetJobRequest{} }
+func (m *ResetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ResetJobRequest) ProtoMessage()    {}
+func (*ResetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{29}
+}
+
+func (m *ResetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetJobRequest.Unmarshal(m, b)
+}
+func (m *ResetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetJobRequest.Marshal(b, m, deterministic)
+}
+func (m *ResetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetJobRequest.Merge(m, src)
+}
+func (m *ResetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_ResetJobRequest.Size(m)
+}
+func (m *ResetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetJobRequest proto.InternalMessageInfo
+
+func (m *ResetJobRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *ResetJobRequest) GetAdminToken() string {
+	if m != nil {
+		return m.AdminToken
+	}
+	return ""
+}
+
+type ResetJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResetJobResponse) Reset()         { *m = ResetJobResponse{} }
+func (m *ResetJobResponse) String() string { return proto.CompactTextString(m) }
+func (*ResetJobResponse) ProtoMessage()    {}
+func (*ResetJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{30}
+}
+
+func (m *ResetJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResetJobResponse.Unmarshal(m, b)
+}
+func (m *ResetJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResetJobResponse.Marshal(b, m, deterministic)
+}
+func (m *ResetJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResetJobResponse.Merge(m, src)
+}
+func (m *ResetJobResponse) XXX_Size() int {
+	return xxx_messageInfo_ResetJobResponse.Size(m)
+}
+func (m *ResetJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResetJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResetJobResponse proto.InternalMessageInfo
+
+func (m *ResetJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type AnnotateExecutionRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Annotations          []string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AnnotateExecutionRequest) Reset()         { *m = AnnotateExecutionRequest{} }
+func (m *AnnotateExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*AnnotateExecutionRequest) ProtoMessage()    {}
+func (*AnnotateExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{31}
+}
+
+func (m *AnnotateExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnotateExecutionRequest.Unmarshal(m, b)
+}
+func (m *AnnotateExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnotateExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *AnnotateExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnotateExecutionRequest.Merge(m, src)
+}
+func (m *AnnotateExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_AnnotateExecutionRequest.Size(m)
+}
+func (m *AnnotateExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnotateExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnotateExecutionRequest proto.InternalMessageInfo
+
+func (m *AnnotateExecutionRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *AnnotateExecutionRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *AnnotateExecutionRequest) GetAnnotations() []string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+type AnnotateExecutionResponse struct {
+	Execution            *Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AnnotateExecutionResponse) Reset()         { *m = AnnotateExecutionResponse{} }
+func (m *AnnotateExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*AnnotateExecutionResponse) ProtoMessage()    {}
+func (*AnnotateExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{32}
+}
+
+func (m *AnnotateExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnotateExecutionResponse.Unmarshal(m, b)
+}
+func (m *AnnotateExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnotateExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *AnnotateExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnotateExecutionResponse.Merge(m, src)
+}
+func (m *AnnotateExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_AnnotateExecutionResponse.Size(m)
+}
+func (m *AnnotateExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnotateExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AnnotateExecutionResponse proto.InternalMessageInfo
+
+func (m *AnnotateExecutionResponse) GetExecution() *Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+type TrashedJob struct {
+	Job                  *Job                 `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Executions           []*Execution         `protobuf:"bytes,2,rep,name=executions,proto3" json:"executions,omitempty"`
+	DeletedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TrashedJob) Reset()         { *m = TrashedJob{} }
+func (m *TrashedJob) String() string { return proto.CompactTextString(m) }
+func (*TrashedJob) ProtoMessage()    {}
+func (*TrashedJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{33}
+}
+
+func (m *TrashedJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrashedJob.Unmarshal(m, b)
+}
+func (m *TrashedJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrashedJob.Marshal(b, m, deterministic)
+}
+func (m *TrashedJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrashedJob.Merge(m, src)
+}
+func (m *TrashedJob) XXX_Size() int {
+	return xxx_messageInfo_TrashedJob.Size(m)
+}
+func (m *TrashedJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrashedJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrashedJob proto.InternalMessageInfo
+
+func (m *TrashedJob) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *TrashedJob) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+func (m *TrashedJob) GetDeletedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
+func (m *TrashedJob) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+// JobList is the protobuf response of the job listings of the HTTP API.
+type JobList struct {
+	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobList) Reset()         { *m = JobList{} }
+func (m *JobList) String() string { return proto.CompactTextString(m) }
+func (*JobList) ProtoMessage()    {}
+func (*JobList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{34}
+}
+
+func (m *JobList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobList.Unmarshal(m, b)
+}
+func (m *JobList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobList.Marshal(b, m, deterministic)
+}
+func (m *JobList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobList.Merge(m, src)
+}
+func (m *JobList) XXX_Size() int {
+	return xxx_messageInfo_JobList.Size(m)
+}
+func (m *JobList) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobList proto.InternalMessageInfo
+
+func (m *JobList) GetJobs() []*Job {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+// ExecutionList is the protobuf response of the execution listings of the
+// HTTP API.
+type ExecutionList struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ExecutionList) Reset()         { *m = ExecutionList{} }
+func (m *ExecutionList) String() string { return proto.CompactTextString(m) }
+func (*ExecutionList) ProtoMessage()    {}
+func (*ExecutionList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{35}
+}
+
+func (m *ExecutionList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionList.Unmarshal(m, b)
+}
+func (m *ExecutionList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionList.Marshal(b, m, deterministic)
+}
+func (m *ExecutionList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionList.Merge(m, src)
+}
+func (m *ExecutionList) XXX_Size() int {
+	return xxx_messageInfo_ExecutionList.Size(m)
+}
+func (m *ExecutionList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionList proto.InternalMessageInfo
+
+func (m *ExecutionList) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type RestoreJobRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	AdminToken           string   `protobuf:"bytes,2,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreJobRequest) Reset()         { *m = RestoreJobRequest{} }
+func (m *RestoreJobRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreJobRequest) ProtoMessage()    {}
+func (*RestoreJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{36}
+}
+
+func (m *RestoreJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreJobRequest.Unmarshal(m, b)
+}
+func (m *RestoreJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreJobRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreJobRequest.Merge(m, src)
+}
+func (m *RestoreJobRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreJobRequest.Size(m)
+}
+func (m *RestoreJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreJobRequest proto.InternalMessageInfo
+
+func (m *RestoreJobRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *RestoreJobRequest) GetAdminToken() string {
+	if m != nil {
+		return m.AdminToken
+	}
+	return ""
+}
+
+type RestoreJobResponse struct {
+	Job                  *Job     `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreJobResponse) Reset()         { *m = RestoreJobResponse{} }
+func (m *RestoreJobResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreJobResponse) ProtoMessage()    {}
+func (*RestoreJobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{37}
+}
+
+func (m *RestoreJobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreJobResponse.Unmarshal(m, b)
+}
+func (m *RestoreJobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreJobResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreJobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreJobResponse.Merge(m, src)
+}
+func (m *RestoreJobResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreJobResponse.Size(m)
+}
+func (m *RestoreJobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreJobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreJobResponse proto.InternalMessageInfo
+
+func (m *RestoreJobResponse) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+type SetReadOnlyRequest struct {
+	ReadOnly             bool     `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetReadOnlyRequest) Reset()         { *m = SetReadOnlyRequest{} }
+func (m *SetReadOnlyRequest) String() string { return proto.CompactTextString(m) }
+func (*SetReadOnlyRequest) ProtoMessage()    {}
+func (*SetReadOnlyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{38}
+}
+
+func (m *SetReadOnlyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReadOnlyRequest.Unmarshal(m, b)
+}
+func (m *SetReadOnlyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReadOnlyRequest.Marshal(b, m, deterministic)
+}
+func (m *SetReadOnlyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReadOnlyRequest.Merge(m, src)
+}
+func (m *SetReadOnlyRequest) XXX_Size() int {
+	return xxx_messageInfo_SetReadOnlyRequest.Size(m)
+}
+func (m *SetReadOnlyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReadOnlyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReadOnlyRequest proto.InternalMessageInfo
+
+func (m *SetReadOnlyRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type SetReadOnlyResponse struct {
+	ReadOnly             bool     `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetReadOnlyResponse) Reset()         { *m = SetReadOnlyResponse{} }
+func (m *SetReadOnlyResponse) String() string { return proto.CompactTextString(m) }
+func (*SetReadOnlyResponse) ProtoMessage()    {}
+func (*SetReadOnlyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{39}
+}
+
+func (m *SetReadOnlyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReadOnlyResponse.Unmarshal(m, b)
+}
+func (m *SetReadOnlyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReadOnlyResponse.Marshal(b, m, deterministic)
+}
+func (m *SetReadOnlyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReadOnlyResponse.Merge(m, src)
+}
+func (m *SetReadOnlyResponse) XXX_Size() int {
+	return xxx_messageInfo_SetReadOnlyResponse.Size(m)
+}
+func (m *SetReadOnlyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReadOnlyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReadOnlyResponse proto.InternalMessageInfo
+
+func (m *SetReadOnlyResponse) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+type ScheduleMacros struct {
+	Macros               map[string]string `protobuf:"bytes,1,rep,name=macros,proto3" json:"macros,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ScheduleMacros) Reset()         { *m = ScheduleMacros{} }
+func (m *ScheduleMacros) String() string { return proto.CompactTextString(m) }
+func (*ScheduleMacros) ProtoMessage()    {}
+func (*ScheduleMacros) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{40}
+}
+
+func (m *ScheduleMacros) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleMacros.Unmarshal(m, b)
+}
+func (m *ScheduleMacros) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleMacros.Marshal(b, m, deterministic)
+}
+func (m *ScheduleMacros) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleMacros.Merge(m, src)
+}
+func (m *ScheduleMacros) XXX_Size() int {
+	return xxx_messageInfo_ScheduleMacros.Size(m)
+}
+func (m *ScheduleMacros) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleMacros.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduleMacros proto.InternalMessageInfo
+
+func (m *ScheduleMacros) GetMacros() map[string]string {
+	if m != nil {
+		return m.Macros
+	}
+	return nil
+}
+
+type ExecutionRetention struct {
+	RetentionSeconds     int64    `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecutionRetention) Reset()         { *m = ExecutionRetention{} }
+func (m *ExecutionRetention) String() string { return proto.CompactTextString(m) }
+func (*ExecutionRetention) ProtoMessage()    {}
+func (*ExecutionRetention) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{41}
+}
+
+func (m *ExecutionRetention) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionRetention.Unmarshal(m, b)
+}
+func (m *ExecutionRetention) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionRetention.Marshal(b, m, deterministic)
+}
+func (m *ExecutionRetention) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionRetention.Merge(m, src)
+}
+func (m *ExecutionRetention) XXX_Size() int {
+	return xxx_messageInfo_ExecutionRetention.Size(m)
+}
+func (m *ExecutionRetention) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionRetention.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionRetention proto.InternalMessageInfo
+
+func (m *ExecutionRetention) GetRetentionSeconds() int64 {
+	if m != nil {
+		return m.RetentionSeconds
+	}
+	return 0
+}
+
+type MaintenanceWindow struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tags                 map[string]string    `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Schedule             string               `protobuf:"bytes,3,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Timezone             string               `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Duration             string               `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	Start                *timestamp.Timestamp `protobuf:"bytes,6,opt,name=start,proto3" json:"start,omitempty"`
+	End                  *timestamp.Timestamp `protobuf:"bytes,7,opt,name=end,proto3" json:"end,omitempty"`
+	Policy               string               `protobuf:"bytes,8,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *MaintenanceWindow) Reset()         { *m = MaintenanceWindow{} }
+func (m *MaintenanceWindow) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceWindow) ProtoMessage()    {}
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{42}
+}
+
+func (m *MaintenanceWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaintenanceWindow.Unmarshal(m, b)
+}
+func (m *MaintenanceWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaintenanceWindow.Marshal(b, m, deterministic)
+}
+func (m *MaintenanceWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceWindow.Merge(m, src)
+}
+func (m *MaintenanceWindow) XXX_Size() int {
+	return xxx_messageInfo_MaintenanceWindow.Size(m)
+}
+func (m *MaintenanceWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenanceWindow proto.InternalMessageInfo
+
+func (m *MaintenanceWindow) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MaintenanceWindow) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *MaintenanceWindow) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *MaintenanceWindow) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
+}
+
+func (m *MaintenanceWindow) GetDuration() string {
+	if m != nil {
+		return m.Duration
+	}
+	return ""
+}
+
+func (m *MaintenanceWindow) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *MaintenanceWindow) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *MaintenanceWindow) GetPolicy() string {
+	if m != nil {
+		return m.Policy
+	}
+	return ""
+}
+
+type SetMaintenanceWindowRequest struct {
+	Window               *MaintenanceWindow `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetMaintenanceWindowRequest) Reset()         { *m = SetMaintenanceWindowRequest{} }
+func (m *SetMaintenanceWindowRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMaintenanceWindowRequest) ProtoMessage()    {}
+func (*SetMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{43}
+}
+
+func (m *SetMaintenanceWindowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMaintenanceWindowRequest.Unmarshal(m, b)
+}
+func (m *SetMaintenanceWindowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMaintenanceWindowRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMaintenanceWindowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMaintenanceWindowRequest.Merge(m, src)
+}
+func (m *SetMaintenanceWindowRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMaintenanceWindowRequest.Size(m)
+}
+func (m *SetMaintenanceWindowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMaintenanceWindowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMaintenanceWindowRequest proto.InternalMessageInfo
+
+func (m *SetMaintenanceWindowRequest) GetWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+type SetMaintenanceWindowResponse struct {
+	Window               *MaintenanceWindow `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetMaintenanceWindowResponse) Reset()         { *m = SetMaintenanceWindowResponse{} }
+func (m *SetMaintenanceWindowResponse) String() string { return proto.CompactTextString(m) }
+func (*SetMaintenanceWindowResponse) ProtoMessage()    {}
+func (*SetMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{44}
+}
+
+func (m *SetMaintenanceWindowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMaintenanceWindowResponse.Unmarshal(m, b)
+}
+func (m *SetMaintenanceWindowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMaintenanceWindowResponse.Marshal(b, m, deterministic)
+}
+func (m *SetMaintenanceWindowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMaintenanceWindowResponse.Merge(m, src)
+}
+func (m *SetMaintenanceWindowResponse) XXX_Size() int {
+	return xxx_messageInfo_SetMaintenanceWindowResponse.Size(m)
+}
+func (m *SetMaintenanceWindowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMaintenanceWindowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMaintenanceWindowResponse proto.InternalMessageInfo
+
+func (m *SetMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+type DeleteMaintenanceWindowRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteMaintenanceWindowRequest) Reset()         { *m = DeleteMaintenanceWindowRequest{} }
+func (m *DeleteMaintenanceWindowRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteMaintenanceWindowRequest) ProtoMessage()    {}
+func (*DeleteMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{45}
+}
+
+func (m *DeleteMaintenanceWindowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMaintenanceWindowRequest.Unmarshal(m, b)
+}
+func (m *DeleteMaintenanceWindowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMaintenanceWindowRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteMaintenanceWindowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMaintenanceWindowRequest.Merge(m, src)
+}
+func (m *DeleteMaintenanceWindowRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteMaintenanceWindowRequest.Size(m)
+}
+func (m *DeleteMaintenanceWindowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMaintenanceWindowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMaintenanceWindowRequest proto.InternalMessageInfo
+
+func (m *DeleteMaintenanceWindowRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteMaintenanceWindowResponse struct {
+	Window               *MaintenanceWindow `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeleteMaintenanceWindowResponse) Reset()         { *m = DeleteMaintenanceWindowResponse{} }
+func (m *DeleteMaintenanceWindowResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteMaintenanceWindowResponse) ProtoMessage()    {}
+func (*DeleteMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{46}
+}
+
+func (m *DeleteMaintenanceWindowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteMaintenanceWindowResponse.Unmarshal(m, b)
+}
+func (m *DeleteMaintenanceWindowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteMaintenanceWindowResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteMaintenanceWindowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteMaintenanceWindowResponse.Merge(m, src)
+}
+func (m *DeleteMaintenanceWindowResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteMaintenanceWindowResponse.Size(m)
+}
+func (m *DeleteMaintenanceWindowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteMaintenanceWindowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteMaintenanceWindowResponse proto.InternalMessageInfo
+
+func (m *DeleteMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+type Silence struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Job                  string               `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	Metadata             map[string]string    `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	StartsAt             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt               *timestamp.Timestamp `protobuf:"bytes,5,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Reason               string               `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Author               string               `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Silence) Reset()         { *m = Silence{} }
+func (m *Silence) String() string { return proto.CompactTextString(m) }
+func (*Silence) ProtoMessage()    {}
+func (*Silence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{47}
+}
+
+func (m *Silence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Silence.Unmarshal(m, b)
+}
+func (m *Silence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Silence.Marshal(b, m, deterministic)
+}
+func (m *Silence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Silence.Merge(m, src)
+}
+func (m *Silence) XXX_Size() int {
+	return xxx_messageInfo_Silence.Size(m)
+}
+func (m *Silence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Silence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Silence proto.InternalMessageInfo
+
+func (m *Silence) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Silence) GetJob() string {
+	if m != nil {
+		return m.Job
+	}
+	return ""
+}
+
+func (m *Silence) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Silence) GetStartsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartsAt
+	}
+	return nil
+}
+
+func (m *Silence) GetEndsAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndsAt
+	}
+	return nil
+}
+
+func (m *Silence) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Silence) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+type SetSilenceRequest struct {
+	Silence              *Silence `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetSilenceRequest) Reset()         { *m = SetSilenceRequest{} }
+func (m *SetSilenceRequest) String() string { return proto.CompactTextString(m) }
+func (*SetSilenceRequest) ProtoMessage()    {}
+func (*SetSilenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{48}
+}
+
+func (m *SetSilenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSilenceRequest.Unmarshal(m, b)
+}
+func (m *SetSilenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSilenceRequest.Marshal(b, m, deterministic)
+}
+func (m *SetSilenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSilenceRequest.Merge(m, src)
+}
+func (m *SetSilenceRequest) XXX_Size() int {
+	return xxx_messageInfo_SetSilenceRequest.Size(m)
+}
+func (m *SetSilenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSilenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSilenceRequest proto.InternalMessageInfo
+
+func (m *SetSilenceRequest) GetSilence() *Silence {
+	if m != nil {
+		return m.Silence
+	}
+	return nil
+}
+
+type SetSilenceResponse struct {
+	Silence              *Silence `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetSilenceResponse) Reset()         { *m = SetSilenceResponse{} }
+func (m *SetSilenceResponse) String() string { return proto.CompactTextString(m) }
+func (*SetSilenceResponse) ProtoMessage()    {}
+func (*SetSilenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{49}
+}
+
+func (m *SetSilenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSilenceResponse.Unmarshal(m, b)
+}
+func (m *SetSilenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSilenceResponse.Marshal(b, m, deterministic)
+}
+func (m *SetSilenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSilenceResponse.Merge(m, src)
+}
+func (m *SetSilenceResponse) XXX_Size() int {
+	return xxx_messageInfo_SetSilenceResponse.Size(m)
+}
+func (m *SetSilenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSilenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSilenceResponse proto.InternalMessageInfo
+
+func (m *SetSilenceResponse) GetSilence() *Silence {
+	if m != nil {
+		return m.Silence
+	}
+	return nil
+}
+
+type DeleteSilenceRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSilenceRequest) Reset()         { *m = DeleteSilenceRequest{} }
+func (m *DeleteSilenceRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSilenceRequest) ProtoMessage()    {}
+func (*DeleteSilenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{50}
+}
+
+func (m *DeleteSilenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSilenceRequest.Unmarshal(m, b)
+}
+func (m *DeleteSilenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSilenceRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteSilenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSilenceRequest.Merge(m, src)
+}
+func (m *DeleteSilenceRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteSilenceRequest.Size(m)
+}
+func (m *DeleteSilenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSilenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSilenceRequest proto.InternalMessageInfo
+
+func (m *DeleteSilenceRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteSilenceResponse struct {
+	Silence              *Silence `protobuf:"bytes,1,opt,name=silence,proto3" json:"silence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSilenceResponse) Reset()         { *m = DeleteSilenceResponse{} }
+func (m *DeleteSilenceResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteSilenceResponse) ProtoMessage()    {}
+func (*DeleteSilenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{51}
+}
+
+func (m *DeleteSilenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSilenceResponse.Unmarshal(m, b)
+}
+func (m *DeleteSilenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSilenceResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteSilenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSilenceResponse.Merge(m, src)
+}
+func (m *DeleteSilenceResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteSilenceResponse.Size(m)
+}
+func (m *DeleteSilenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSilenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSilenceResponse proto.InternalMessageInfo
+
+func (m *DeleteSilenceResponse) GetSilence() *Silence {
+	if m != nil {
+		return m.Silence
+	}
+	return nil
+}
+
+type Credential struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string               `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Data                 []byte               `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Jobs                 []string             `protobuf:"bytes,4,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Author               string               `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Credential) Reset()         { *m = Credential{} }
+func (m *Credential) String() string { return proto.CompactTextString(m) }
+func (*Credential) ProtoMessage()    {}
+func (*Credential) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{52}
+}
+
+func (m *Credential) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Credential.Unmarshal(m, b)
+}
+func (m *Credential) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Credential.Marshal(b, m, deterministic)
+}
+func (m *Credential) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Credential.Merge(m, src)
+}
+func (m *Credential) XXX_Size() int {
+	return xxx_messageInfo_Credential.Size(m)
+}
+func (m *Credential) XXX_DiscardUnknown() {
+	xxx_messageInfo_Credential.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Credential proto.InternalMessageInfo
+
+func (m *Credential) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Credential) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Credential) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Credential) GetJobs() []string {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+func (m *Credential) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *Credential) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type SetCredentialRequest struct {
+	Credential           *Credential `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SetCredentialRequest) Reset()         { *m = SetCredentialRequest{} }
+func (m *SetCredentialRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCredentialRequest) ProtoMessage()    {}
+func (*SetCredentialRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{53}
+}
+
+func (m *SetCredentialRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCredentialRequest.Unmarshal(m, b)
+}
+func (m *SetCredentialRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCredentialRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCredentialRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCredentialRequest.Merge(m, src)
+}
+func (m *SetCredentialRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCredentialRequest.Size(m)
+}
+func (m *SetCredentialRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCredentialRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCredentialRequest proto.InternalMessageInfo
+
+func (m *SetCredentialRequest) GetCredential() *Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+type SetCredentialResponse struct {
+	Credential           *Credential `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SetCredentialResponse) Reset()         { *m = SetCredentialResponse{} }
+func (m *SetCredentialResponse) String() string { return proto.CompactTextString(m) }
+func (*SetCredentialResponse) ProtoMessage()    {}
+func (*SetCredentialResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{54}
+}
+
+func (m *SetCredentialResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCredentialResponse.Unmarshal(m, b)
+}
+func (m *SetCredentialResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCredentialResponse.Marshal(b, m, deterministic)
+}
+func (m *SetCredentialResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCredentialResponse.Merge(m, src)
+}
+func (m *SetCredentialResponse) XXX_Size() int {
+	return xxx_messageInfo_SetCredentialResponse.Size(m)
+}
+func (m *SetCredentialResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCredentialResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCredentialResponse proto.InternalMessageInfo
+
+func (m *SetCredentialResponse) GetCredential() *Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+type DeleteCredentialRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteCredentialRequest) Reset()         { *m = DeleteCredentialRequest{} }
+func (m *DeleteCredentialRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteCredentialRequest) ProtoMessage()    {}
+func (*DeleteCredentialRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{55}
+}
+
+func (m *DeleteCredentialRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCredentialRequest.Unmarshal(m, b)
+}
+func (m *DeleteCredentialRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCredentialRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteCredentialRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCredentialRequest.Merge(m, src)
+}
+func (m *DeleteCredentialRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteCredentialRequest.Size(m)
+}
+func (m *DeleteCredentialRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCredentialRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCredentialRequest proto.InternalMessageInfo
+
+func (m *DeleteCredentialRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type DeleteCredentialResponse struct {
+	Credential           *Credential `protobuf:"bytes,1,opt,name=credential,proto3" json:"credential,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *DeleteCredentialResponse) Reset()         { *m = DeleteCredentialResponse{} }
+func (m *DeleteCredentialResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteCredentialResponse) ProtoMessage()    {}
+func (*DeleteCredentialResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{56}
+}
+
+func (m *DeleteCredentialResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteCredentialResponse.Unmarshal(m, b)
+}
+func (m *DeleteCredentialResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteCredentialResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteCredentialResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteCredentialResponse.Merge(m, src)
+}
+func (m *DeleteCredentialResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteCredentialResponse.Size(m)
+}
+func (m *DeleteCredentialResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteCredentialResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteCredentialResponse proto.InternalMessageInfo
+
+func (m *DeleteCredentialResponse) GetCredential() *Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+type ComplianceEvent struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action               string               `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	JobName              string               `protobuf:"bytes,3,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	User                 string               `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	Reason               string               `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	At                   *timestamp.Timestamp `protobuf:"bytes,6,opt,name=at,proto3" json:"at,omitempty"`
+	Executions           int32                `protobuf:"varint,7,opt,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ComplianceEvent) Reset()         { *m = ComplianceEvent{} }
+func (m *ComplianceEvent) String() string { return proto.CompactTextString(m) }
+func (*ComplianceEvent) ProtoMessage()    {}
+func (*ComplianceEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{57}
+}
+
+func (m *ComplianceEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComplianceEvent.Unmarshal(m, b)
+}
+func (m *ComplianceEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComplianceEvent.Marshal(b, m, deterministic)
+}
+func (m *ComplianceEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComplianceEvent.Merge(m, src)
+}
+func (m *ComplianceEvent) XXX_Size() int {
+	return xxx_messageInfo_ComplianceEvent.Size(m)
+}
+func (m *ComplianceEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComplianceEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComplianceEvent proto.InternalMessageInfo
+
+func (m *ComplianceEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ComplianceEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ComplianceEvent) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *ComplianceEvent) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ComplianceEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ComplianceEvent) GetAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.At
+	}
+	return nil
+}
+
+func (m *ComplianceEvent) GetExecutions() int32 {
+	if m != nil {
+		return m.Executions
+	}
+	return 0
+}
+
+type ComplianceRequest struct {
+	Event                *ComplianceEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ComplianceRequest) Reset()         { *m = ComplianceRequest{} }
+func (m *ComplianceRequest) String() string { return proto.CompactTextString(m) }
+func (*ComplianceRequest) ProtoMessage()    {}
+func (*ComplianceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{58}
+}
+
+func (m *ComplianceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComplianceRequest.Unmarshal(m, b)
+}
+func (m *ComplianceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComplianceRequest.Marshal(b, m, deterministic)
+}
+func (m *ComplianceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComplianceRequest.Merge(m, src)
+}
+func (m *ComplianceRequest) XXX_Size() int {
+	return xxx_messageInfo_ComplianceRequest.Size(m)
+}
+func (m *ComplianceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComplianceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComplianceRequest proto.InternalMessageInfo
+
+func (m *ComplianceRequest) GetEvent() *ComplianceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type ComplianceResponse struct {
+	Event                *ComplianceEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ComplianceResponse) Reset()         { *m = ComplianceResponse{} }
+func (m *ComplianceResponse) String() string { return proto.CompactTextString(m) }
+func (*ComplianceResponse) ProtoMessage()    {}
+func (*ComplianceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{59}
+}
+
+func (m *ComplianceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComplianceResponse.Unmarshal(m, b)
+}
+func (m *ComplianceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComplianceResponse.Marshal(b, m, deterministic)
+}
+func (m *ComplianceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComplianceResponse.Merge(m, src)
+}
+func (m *ComplianceResponse) XXX_Size() int {
+	return xxx_messageInfo_ComplianceResponse.Size(m)
+}
+func (m *ComplianceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComplianceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComplianceResponse proto.InternalMessageInfo
+
+func (m *ComplianceResponse) GetEvent() *ComplianceEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type SchedulerEvent struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action               string               `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	User                 string               `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Reason               string               `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	At                   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=at,proto3" json:"at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SchedulerEvent) Reset()         { *m = SchedulerEvent{} }
+func (m *SchedulerEvent) String() string { return proto.CompactTextString(m) }
+func (*SchedulerEvent) ProtoMessage()    {}
+func (*SchedulerEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{60}
+}
+
+func (m *SchedulerEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SchedulerEvent.Unmarshal(m, b)
+}
+func (m *SchedulerEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SchedulerEvent.Marshal(b, m, deterministic)
+}
+func (m *SchedulerEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulerEvent.Merge(m, src)
+}
+func (m *SchedulerEvent) XXX_Size() int {
+	return xxx_messageInfo_SchedulerEvent.Size(m)
+}
+func (m *SchedulerEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulerEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulerEvent proto.InternalMessageInfo
+
+func (m *SchedulerEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SchedulerEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *SchedulerEvent) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *SchedulerEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *SchedulerEvent) GetAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.At
+	}
+	return nil
+}
+
+type SetSchedulerPauseRequest struct {
+	Event                *SchedulerEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SetSchedulerPauseRequest) Reset()         { *m = SetSchedulerPauseRequest{} }
+func (m *SetSchedulerPauseRequest) String() string { return proto.CompactTextString(m) }
+func (*SetSchedulerPauseRequest) ProtoMessage()    {}
+func (*SetSchedulerPauseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{61}
+}
+
+func (m *SetSchedulerPauseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSchedulerPauseRequest.Unmarshal(m, b)
+}
+func (m *SetSchedulerPauseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSchedulerPauseRequest.Marshal(b, m, deterministic)
+}
+func (m *SetSchedulerPauseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSchedulerPauseRequest.Merge(m, src)
+}
+func (m *SetSchedulerPauseRequest) XXX_Size() int {
+	return xxx_messageInfo_SetSchedulerPauseRequest.Size(m)
+}
+func (m *SetSchedulerPauseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSchedulerPauseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSchedulerPauseRequest proto.InternalMessageInfo
+
+func (m *SetSchedulerPauseRequest) GetEvent() *SchedulerEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type SetSchedulerPauseResponse struct {
+	Event                *SchedulerEvent `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SetSchedulerPauseResponse) Reset()         { *m = SetSchedulerPauseResponse{} }
+func (m *SetSchedulerPauseResponse) String() string { return proto.CompactTextString(m) }
+func (*SetSchedulerPauseResponse) ProtoMessage()    {}
+func (*SetSchedulerPauseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{62}
+}
+
+func (m *SetSchedulerPauseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetSchedulerPauseResponse.Unmarshal(m, b)
+}
+func (m *SetSchedulerPauseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetSchedulerPauseResponse.Marshal(b, m, deterministic)
+}
+func (m *SetSchedulerPauseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetSchedulerPauseResponse.Merge(m, src)
+}
+func (m *SetSchedulerPauseResponse) XXX_Size() int {
+	return xxx_messageInfo_SetSchedulerPauseResponse.Size(m)
+}
+func (m *SetSchedulerPauseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetSchedulerPauseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetSchedulerPauseResponse proto.InternalMessageInfo
+
+func (m *SetSchedulerPauseResponse) GetEvent() *SchedulerEvent {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type CommitChangesRequest struct {
+	Offset               uint64   `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Seq                  uint32   `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitChangesRequest) Reset()         { *m = CommitChangesRequest{} }
+func (m *CommitChangesRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitChangesRequest) ProtoMessage()    {}
+func (*CommitChangesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{63}
+}
+
+func (m *CommitChangesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitChangesRequest.Unmarshal(m, b)
+}
+func (m *CommitChangesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitChangesRequest.Marshal(b, m, deterministic)
+}
+func (m *CommitChangesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitChangesRequest.Merge(m, src)
+}
+func (m *CommitChangesRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitChangesRequest.Size(m)
+}
+func (m *CommitChangesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitChangesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitChangesRequest proto.InternalMessageInfo
+
+func (m *CommitChangesRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *CommitChangesRequest) GetSeq() uint32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+type DispatchIntent struct {
+	JobName              string               `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Group                int64                `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
+	RequestId            string               `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Nodes                map[string]string    `protobuf:"bytes,4,rep,name=nodes,proto3" json:"nodes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Params               map[string]string    `protobuf:"bytes,6,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	MatrixItem           string               `protobuf:"bytes,7,opt,name=matrix_item,json=matrixItem,proto3" json:"matrix_item,omitempty"`
+	MatrixSize           int32                `protobuf:"varint,8,opt,name=matrix_size,json=matrixSize,proto3" json:"matrix_size,omitempty"`
+	Discovery            bool                 `protobuf:"varint,9,opt,name=discovery,proto3" json:"discovery,omitempty"`
+	Dispatched           []string             `protobuf:"bytes,10,rep,name=dispatched,proto3" json:"dispatched,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DispatchIntent) Reset()         { *m = DispatchIntent{} }
+func (m *DispatchIntent) String() string { return proto.CompactTextString(m) }
+func (*DispatchIntent) ProtoMessage()    {}
+func (*DispatchIntent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{64}
+}
+
+func (m *DispatchIntent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DispatchIntent.Unmarshal(m, b)
+}
+func (m *DispatchIntent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DispatchIntent.Marshal(b, m, deterministic)
+}
+func (m *DispatchIntent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DispatchIntent.Merge(m, src)
+}
+func (m *DispatchIntent) XXX_Size() int {
+	return xxx_messageInfo_DispatchIntent.Size(m)
+}
+func (m *DispatchIntent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DispatchIntent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DispatchIntent proto.InternalMessageInfo
+
+func (m *DispatchIntent) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *DispatchIntent) GetGroup() int64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *DispatchIntent) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *DispatchIntent) GetNodes() map[string]string {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *DispatchIntent) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *DispatchIntent) GetParams() map[string]string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *DispatchIntent) GetMatrixItem() string {
+	if m != nil {
+		return m.MatrixItem
+	}
+	return ""
+}
+
+func (m *DispatchIntent) GetMatrixSize() int32 {
+	if m != nil {
+		return m.MatrixSize
+	}
+	return 0
+}
+
+func (m *DispatchIntent) GetDiscovery() bool {
+	if m != nil {
+		return m.Discovery
+	}
+	return false
+}
+
+func (m *DispatchIntent) GetDispatched() []string {
+	if m != nil {
+		return m.Dispatched
+	}
+	return nil
+}
+
+type DeleteDispatchIntentRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Group                int64    `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
+	MatrixItem           string   `protobuf:"bytes,3,opt,name=matrix_item,json=matrixItem,proto3" json:"matrix_item,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDispatchIntentRequest) Reset()         { *m = DeleteDispatchIntentRequest{} }
+func (m *DeleteDispatchIntentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDispatchIntentRequest) ProtoMessage()    {}
+func (*DeleteDispatchIntentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{65}
+}
+
+func (m *DeleteDispatchIntentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDispatchIntentRequest.Unmarshal(m, b)
+}
+func (m *DeleteDispatchIntentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDispatchIntentRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteDispatchIntentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDispatchIntentRequest.Merge(m, src)
+}
+func (m *DeleteDispatchIntentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDispatchIntentRequest.Size(m)
+}
+func (m *DeleteDispatchIntentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDispatchIntentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDispatchIntentRequest proto.InternalMessageInfo
+
+func (m *DeleteDispatchIntentRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *DeleteDispatchIntentRequest) GetGroup() int64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *DeleteDispatchIntentRequest) GetMatrixItem() string {
+	if m != nil {
+		return m.MatrixItem
+	}
+	return ""
+}
+
+type MarkDispatchedRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Group                int64    `protobuf:"varint,2,opt,name=group,proto3" json:"group,omitempty"`
+	MatrixItem           string   `protobuf:"bytes,3,opt,name=matrix_item,json=matrixItem,proto3" json:"matrix_item,omitempty"`
+	NodeName             string   `protobuf:"bytes,4,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MarkDispatchedRequest) Reset()         { *m = MarkDispatchedRequest{} }
+func (m *MarkDispatchedRequest) String() string { return proto.CompactTextString(m) }
+func (*MarkDispatchedRequest) ProtoMessage()    {}
+func (*MarkDispatchedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{66}
+}
+
+func (m *MarkDispatchedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MarkDispatchedRequest.Unmarshal(m, b)
+}
+func (m *MarkDispatchedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MarkDispatchedRequest.Marshal(b, m, deterministic)
+}
+func (m *MarkDispatchedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MarkDispatchedRequest.Merge(m, src)
+}
+func (m *MarkDispatchedRequest) XXX_Size() int {
+	return xxx_messageInfo_MarkDispatchedRequest.Size(m)
+}
+func (m *MarkDispatchedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MarkDispatchedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MarkDispatchedRequest proto.InternalMessageInfo
+
+func (m *MarkDispatchedRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *MarkDispatchedRequest) GetGroup() int64 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *MarkDispatchedRequest) GetMatrixItem() string {
+	if m != nil {
+		return m.MatrixItem
+	}
+	return ""
+}
+
+func (m *MarkDispatchedRequest) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+type Backfill struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobName              string               `protobuf:"bytes,2,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	From                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Parallelism          int32                `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	Status               string               `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Total                int32                `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	Dispatched           int32                `protobuf:"varint,8,opt,name=dispatched,proto3" json:"dispatched,omitempty"`
+	Succeeded            int32                `protobuf:"varint,9,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed               int32                `protobuf:"varint,10,opt,name=failed,proto3" json:"failed,omitempty"`
+	Position             *timestamp.Timestamp `protobuf:"bytes,11,opt,name=position,proto3" json:"position,omitempty"`
+	RequestId            string               `protobuf:"bytes,12,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt           *timestamp.Timestamp `protobuf:"bytes,14,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Backfill) Reset()         { *m = Backfill{} }
+func (m *Backfill) String() string { return proto.CompactTextString(m) }
+func (*Backfill) ProtoMessage()    {}
+func (*Backfill) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{67}
+}
+
+func (m *Backfill) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backfill.Unmarshal(m, b)
+}
+func (m *Backfill) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backfill.Marshal(b, m, deterministic)
+}
+func (m *Backfill) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backfill.Merge(m, src)
+}
+func (m *Backfill) XXX_Size() int {
+	return xxx_messageInfo_Backfill.Size(m)
+}
+func (m *Backfill) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backfill.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backfill proto.InternalMessageInfo
+
+func (m *Backfill) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Backfill) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *Backfill) GetFrom() *timestamp.Timestamp {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *Backfill) GetTo() *timestamp.Timestamp {
+	if m != nil {
+		return m.To
+	}
+	return nil
+}
+
+func (m *Backfill) GetParallelism() int32 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+func (m *Backfill) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Backfill) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Backfill) GetDispatched() int32 {
+	if m != nil {
+		return m.Dispatched
+	}
+	return 0
+}
+
+func (m *Backfill) GetSucceeded() int32 {
+	if m != nil {
+		return m.Succeeded
+	}
+	return 0
+}
+
+func (m *Backfill) GetFailed() int32 {
+	if m != nil {
+		return m.Failed
+	}
+	return 0
+}
+
+func (m *Backfill) GetPosition() *timestamp.Timestamp {
+	if m != nil {
+		return m.Position
+	}
+	return nil
+}
+
+func (m *Backfill) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *Backfill) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Backfill) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+type BackfillRequest struct {
+	Backfill             *Backfill `protobuf:"bytes,1,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *BackfillRequest) Reset()         { *m = BackfillRequest{} }
+func (m *BackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*BackfillRequest) ProtoMessage()    {}
+func (*BackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{68}
+}
+
+func (m *BackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackfillRequest.Unmarshal(m, b)
+}
+func (m *BackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *BackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackfillRequest.Merge(m, src)
+}
+func (m *BackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_BackfillRequest.Size(m)
+}
+func (m *BackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackfillRequest proto.InternalMessageInfo
+
+func (m *BackfillRequest) GetBackfill() *Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
+type BackfillResponse struct {
+	Backfill             *Backfill `protobuf:"bytes,1,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *BackfillResponse) Reset()         { *m = BackfillResponse{} }
+func (m *BackfillResponse) String() string { return proto.CompactTextString(m) }
+func (*BackfillResponse) ProtoMessage()    {}
+func (*BackfillResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{69}
+}
+
+func (m *BackfillResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackfillResponse.Unmarshal(m, b)
+}
+func (m *BackfillResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackfillResponse.Marshal(b, m, deterministic)
+}
+func (m *BackfillResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackfillResponse.Merge(m, src)
+}
+func (m *BackfillResponse) XXX_Size() int {
+	return xxx_messageInfo_BackfillResponse.Size(m)
+}
+func (m *BackfillResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackfillResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackfillResponse proto.InternalMessageInfo
+
+func (m *BackfillResponse) GetBackfill() *Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
+type CancelBackfillRequest struct {
+	JobName              string   `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelBackfillRequest) Reset()         { *m = CancelBackfillRequest{} }
+func (m *CancelBackfillRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelBackfillRequest) ProtoMessage()    {}
+func (*CancelBackfillRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{70}
+}
+
+func (m *CancelBackfillRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelBackfillRequest.Unmarshal(m, b)
+}
+func (m *CancelBackfillRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelBackfillRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelBackfillRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelBackfillRequest.Merge(m, src)
+}
+func (m *CancelBackfillRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelBackfillRequest.Size(m)
+}
+func (m *CancelBackfillRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelBackfillRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelBackfillRequest proto.InternalMessageInfo
+
+func (m *CancelBackfillRequest) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+func (m *CancelBackfillRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelBackfillResponse struct {
+	Backfill             *Backfill `protobuf:"bytes,1,opt,name=backfill,proto3" json:"backfill,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CancelBackfillResponse) Reset()         { *m = CancelBackfillResponse{} }
+func (m *CancelBackfillResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelBackfillResponse) ProtoMessage()    {}
+func (*CancelBackfillResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{71}
+}
+
+func (m *CancelBackfillResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelBackfillResponse.Unmarshal(m, b)
+}
+func (m *CancelBackfillResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelBackfillResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelBackfillResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelBackfillResponse.Merge(m, src)
+}
+func (m *CancelBackfillResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelBackfillResponse.Size(m)
+}
+func (m *CancelBackfillResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelBackfillResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelBackfillResponse proto.InternalMessageInfo
+
+func (m *CancelBackfillResponse) GetBackfill() *Backfill {
+	if m != nil {
+		return m.Backfill
+	}
+	return nil
+}
+
+type Lock struct {
+	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Holder               string               `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
+	AcquiredAt           *timestamp.Timestamp `protobuf:"bytes,3,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Lock) Reset()         { *m = Lock{} }
+func (m *Lock) String() string { return proto.CompactTextString(m) }
+func (*Lock) ProtoMessage()    {}
+func (*Lock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{72}
+}
+
+func (m *Lock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lock.Unmarshal(m, b)
+}
+func (m *Lock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lock.Marshal(b, m, deterministic)
+}
+func (m *Lock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lock.Merge(m, src)
+}
+func (m *Lock) XXX_Size() int {
+	return xxx_messageInfo_Lock.Size(m)
+}
+func (m *Lock) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lock proto.InternalMessageInfo
+
+func (m *Lock) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Lock) GetHolder() string {
+	if m != nil {
+		return m.Holder
+	}
+	return ""
+}
+
+func (m *Lock) GetAcquiredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.AcquiredAt
+	}
+	return nil
+}
+
+func (m *Lock) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type AcquireLockRequest struct {
+	Lock                 *Lock                `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	Now                  *timestamp.Timestamp `protobuf:"bytes,2,opt,name=now,proto3" json:"now,omitempty"`
+	Renew                bool                 `protobuf:"varint,3,opt,name=renew,proto3" json:"renew,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AcquireLockRequest) Reset()         { *m = AcquireLockRequest{} }
+func (m *AcquireLockRequest) String() string { return proto.CompactTextString(m) }
+func (*AcquireLockRequest) ProtoMessage()    {}
+func (*AcquireLockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{73}
+}
+
+func (m *AcquireLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcquireLockRequest.Unmarshal(m, b)
+}
+func (m *AcquireLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcquireLockRequest.Marshal(b, m, deterministic)
+}
+func (m *AcquireLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLockRequest.Merge(m, src)
+}
+func (m *AcquireLockRequest) XXX_Size() int {
+	return xxx_messageInfo_AcquireLockRequest.Size(m)
+}
+func (m *AcquireLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcquireLockRequest proto.InternalMessageInfo
+
+func (m *AcquireLockRequest) GetLock() *Lock {
+	if m != nil {
+		return m.Lock
+	}
+	return nil
+}
+
+func (m *AcquireLockRequest) GetNow() *timestamp.Timestamp {
+	if m != nil {
+		return m.Now
+	}
+	return nil
+}
+
+func (m *AcquireLockRequest) GetRenew() bool {
+	if m != nil {
+		return m.Renew
+	}
+	return false
+}
+
+type AcquireLockResponse struct {
+	Lock                 *Lock    `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcquireLockResponse) Reset()         { *m = AcquireLockResponse{} }
+func (m *AcquireLockResponse) String() string { return proto.CompactTextString(m) }
+func (*AcquireLockResponse) ProtoMessage()    {}
+func (*AcquireLockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{74}
+}
+
+func (m *AcquireLockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcquireLockResponse.Unmarshal(m, b)
+}
+func (m *AcquireLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcquireLockResponse.Marshal(b, m, deterministic)
+}
+func (m *AcquireLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLockResponse.Merge(m, src)
+}
+func (m *AcquireLockResponse) XXX_Size() int {
+	return xxx_messageInfo_AcquireLockResponse.Size(m)
+}
+func (m *AcquireLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcquireLockResponse proto.InternalMessageInfo
+
+func (m *AcquireLockResponse) GetLock() *Lock {
+	if m != nil {
+		return m.Lock
+	}
+	return nil
+}
+
+type ReleaseLockRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Holder               string   `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseLockRequest) Reset()         { *m = ReleaseLockRequest{} }
+func (m *ReleaseLockRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseLockRequest) ProtoMessage()    {}
+func (*ReleaseLockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{75}
+}
+
+func (m *ReleaseLockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseLockRequest.Unmarshal(m, b)
+}
+func (m *ReleaseLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseLockRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseLockRequest.Merge(m, src)
+}
+func (m *ReleaseLockRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseLockRequest.Size(m)
+}
+func (m *ReleaseLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseLockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseLockRequest proto.InternalMessageInfo
+
+func (m *ReleaseLockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReleaseLockRequest) GetHolder() string {
+	if m != nil {
+		return m.Holder
+	}
+	return ""
+}
+
+type KV struct {
+	Namespace            string               `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key                  string               `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte               `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *KV) Reset()         { *m = KV{} }
+func (m *KV) String() string { return proto.CompactTextString(m) }
+func (*KV) ProtoMessage()    {}
+func (*KV) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{76}
+}
+
+func (m *KV) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KV.Unmarshal(m, b)
+}
+func (m *KV) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KV.Marshal(b, m, deterministic)
+}
+func (m *KV) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KV.Merge(m, src)
+}
+func (m *KV) XXX_Size() int {
+	return xxx_messageInfo_KV.Size(m)
+}
+func (m *KV) XXX_DiscardUnknown() {
+	xxx_messageInfo_KV.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KV proto.InternalMessageInfo
+
+func (m *KV) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *KV) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KV) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *KV) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type SetKVRequest struct {
+	Kv                   *KV      `protobuf:"bytes,1,opt,name=kv,proto3" json:"kv,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetKVRequest) Reset()         { *m = SetKVRequest{} }
+func (m *SetKVRequest) String() string { return proto.CompactTextString(m) }
+func (*SetKVRequest) ProtoMessage()    {}
+func (*SetKVRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{77}
+}
+
+func (m *SetKVRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetKVRequest.Unmarshal(m, b)
+}
+func (m *SetKVRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetKVRequest.Marshal(b, m, deterministic)
+}
+func (m *SetKVRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetKVRequest.Merge(m, src)
+}
+func (m *SetKVRequest) XXX_Size() int {
+	return xxx_messageInfo_SetKVRequest.Size(m)
+}
+func (m *SetKVRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetKVRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetKVRequest proto.InternalMessageInfo
+
+func (m *SetKVRequest) GetKv() *KV {
+	if m != nil {
+		return m.Kv
+	}
+	return nil
+}
+
+type DeleteKVRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteKVRequest) Reset()         { *m = DeleteKVRequest{} }
+func (m *DeleteKVRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteKVRequest) ProtoMessage()    {}
+func (*DeleteKVRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{78}
+}
+
+func (m *DeleteKVRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteKVRequest.Unmarshal(m, b)
+}
+func (m *DeleteKVRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteKVRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteKVRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteKVRequest.Merge(m, src)
+}
+func (m *DeleteKVRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteKVRequest.Size(m)
+}
+func (m *DeleteKVRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteKVRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteKVRequest proto.InternalMessageInfo
+
+func (m *DeleteKVRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeleteKVRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type ReadIndexResponse struct {
+	Index                uint64   `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadIndexResponse) Reset()         { *m = ReadIndexResponse{} }
+func (m *ReadIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadIndexResponse) ProtoMessage()    {}
+func (*ReadIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{79}
+}
+
+func (m *ReadIndexResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadIndexResponse.Unmarshal(m, b)
+}
+func (m *ReadIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadIndexResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadIndexResponse.Merge(m, src)
+}
+func (m *ReadIndexResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadIndexResponse.Size(m)
+}
+func (m *ReadIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadIndexResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadIndexResponse proto.InternalMessageInfo
+
+func (m *ReadIndexResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type StoreProblem struct {
+	Kind                 string   `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Detail               string   `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StoreProblem) Reset()         { *m = StoreProblem{} }
+func (m *StoreProblem) String() string { return proto.CompactTextString(m) }
+func (*StoreProblem) ProtoMessage()    {}
+func (*StoreProblem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{80}
+}
+
+func (m *StoreProblem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StoreProblem.Unmarshal(m, b)
+}
+func (m *StoreProblem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StoreProblem.Marshal(b, m, deterministic)
+}
+func (m *StoreProblem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreProblem.Merge(m, src)
+}
+func (m *StoreProblem) XXX_Size() int {
+	return xxx_messageInfo_StoreProblem.Size(m)
+}
+func (m *StoreProblem) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreProblem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreProblem proto.InternalMessageInfo
+
+func (m *StoreProblem) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *StoreProblem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StoreProblem) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type CheckStoreRequest struct {
+	Repair               bool     `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+	AdminToken           string   `protobuf:"bytes,2,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckStoreRequest) Reset()         { *m = CheckStoreRequest{} }
+func (m *CheckStoreRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckStoreRequest) ProtoMessage()    {}
+func (*CheckStoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{81}
+}
+
+func (m *CheckStoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckStoreRequest.Unmarshal(m, b)
+}
+func (m *CheckStoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckStoreRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckStoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckStoreRequest.Merge(m, src)
+}
+func (m *CheckStoreRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckStoreRequest.Size(m)
+}
+func (m *CheckStoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckStoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckStoreRequest proto.InternalMessageInfo
+
+func (m *CheckStoreRequest) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+func (m *CheckStoreRequest) GetAdminToken() string {
+	if m != nil {
+		return m.AdminToken
+	}
+	return ""
+}
+
+type CheckStoreResponse struct {
+	SchemaVersion        int32           `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Jobs                 int32           `protobuf:"varint,2,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	DisabledJobs         int32           `protobuf:"varint,3,opt,name=disabled_jobs,json=disabledJobs,proto3" json:"disabled_jobs,omitempty"`
+	Executions           int32           `protobuf:"varint,4,opt,name=executions,proto3" json:"executions,omitempty"`
+	Problems             []*StoreProblem `protobuf:"bytes,5,rep,name=problems,proto3" json:"problems,omitempty"`
+	Repaired             int32           `protobuf:"varint,6,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CheckStoreResponse) Reset()         { *m = CheckStoreResponse{} }
+func (m *CheckStoreResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckStoreResponse) ProtoMessage()    {}
+func (*CheckStoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{82}
+}
+
+func (m *CheckStoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckStoreResponse.Unmarshal(m, b)
+}
+func (m *CheckStoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckStoreResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckStoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckStoreResponse.Merge(m, src)
+}
+func (m *CheckStoreResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckStoreResponse.Size(m)
+}
+func (m *CheckStoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckStoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckStoreResponse proto.InternalMessageInfo
+
+func (m *CheckStoreResponse) GetSchemaVersion() int32 {
+	if m != nil {
+		return m.SchemaVersion
+	}
+	return 0
+}
+
+func (m *CheckStoreResponse) GetJobs() int32 {
+	if m != nil {
+		return m.Jobs
+	}
+	return 0
+}
+
+func (m *CheckStoreResponse) GetDisabledJobs() int32 {
+	if m != nil {
+		return m.DisabledJobs
+	}
+	return 0
+}
+
+func (m *CheckStoreResponse) GetExecutions() int32 {
+	if m != nil {
+		return m.Executions
+	}
+	return 0
+}
+
+func (m *CheckStoreResponse) GetProblems() []*StoreProblem {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+func (m *CheckStoreResponse) GetRepaired() int32 {
+	if m != nil {
+		return m.Repaired
+	}
+	return 0
+}
+
+type RaftServer struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Address              string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Leader               bool     `protobuf:"varint,4,opt,name=leader,proto3" json:"leader,omitempty"`
+	Voter                bool     `protobuf:"varint,5,opt,name=voter,proto3" json:"voter,omitempty"`
+	RaftProtocol         string   `protobuf:"bytes,6,opt,name=raft_protocol,json=raftProtocol,proto3" json:"raft_protocol,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftServer) Reset()         { *m = RaftServer{} }
+func (m *RaftServer) String() string { return proto.CompactTextString(m) }
+func (*RaftServer) ProtoMessage()    {}
+func (*RaftServer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{83}
+}
+
+func (m *RaftServer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftServer.Unmarshal(m, b)
+}
+func (m *RaftServer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftServer.Marshal(b, m, deterministic)
+}
+func (m *RaftServer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftServer.Merge(m, src)
+}
+func (m *RaftServer) XXX_Size() int {
+	return xxx_messageInfo_RaftServer.Size(m)
+}
+func (m *RaftServer) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftServer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftServer proto.InternalMessageInfo
+
+func (m *RaftServer) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RaftServer) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *RaftServer) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RaftServer) GetLeader() bool {
+	if m != nil {
+		return m.Leader
+	}
+	return false
+}
+
+func (m *RaftServer) GetVoter() bool {
+	if m != nil {
+		return m.Voter
+	}
+	return false
+}
+
+func (m *RaftServer) GetRaftProtocol() string {
+	if m != nil {
+		return m.RaftProtocol
+	}
+	return ""
+}
+
+type RaftGetConfigurationResponse struct {
+	Servers              []*RaftServer `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	Index                uint64        `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RaftGetConfigurationResponse) Reset()         { *m = RaftGetConfigurationResponse{} }
+func (m *RaftGetConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*RaftGetConfigurationResponse) ProtoMessage()    {}
+func (*RaftGetConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{84}
+}
+
+func (m *RaftGetConfigurationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftGetConfigurationResponse.Unmarshal(m, b)
+}
+func (m *RaftGetConfigurationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftGetConfigurationResponse.Marshal(b, m, deterministic)
+}
+func (m *RaftGetConfigurationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftGetConfigurationResponse.Merge(m, src)
+}
+func (m *RaftGetConfigurationResponse) XXX_Size() int {
+	return xxx_messageInfo_RaftGetConfigurationResponse.Size(m)
+}
+func (m *RaftGetConfigurationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftGetConfigurationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftGetConfigurationResponse proto.InternalMessageInfo
+
+func (m *RaftGetConfigurationResponse) GetServers() []*RaftServer {
+	if m != nil {
+		return m.Servers
+	}
+	return nil
+}
+
+func (m *RaftGetConfigurationResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type RaftRemovePeerByIDRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RaftRemovePeerByIDRequest) Reset()         { *m = RaftRemovePeerByIDRequest{} }
+func (m *RaftRemovePeerByIDRequest) String() string { return proto.CompactTextString(m) }
+func (*RaftRemovePeerByIDRequest) ProtoMessage()    {}
+func (*RaftRemovePeerByIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{85}
+}
+
+func (m *RaftRemovePeerByIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RaftRemovePeerByIDRequest.Unmarshal(m, b)
+}
+func (m *RaftRemovePeerByIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RaftRemovePeerByIDRequest.Marshal(b, m, deterministic)
+}
+func (m *RaftRemovePeerByIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftRemovePeerByIDRequest.Merge(m, src)
+}
+func (m *RaftRemovePeerByIDRequest) XXX_Size() int {
+	return xxx_messageInfo_RaftRemovePeerByIDRequest.Size(m)
+}
+func (m *RaftRemovePeerByIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftRemovePeerByIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftRemovePeerByIDRequest proto.InternalMessageInfo
+
+func (m *RaftRemovePeerByIDRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type AgentRunStream struct {
+	Execution            *Execution `protobuf:"bytes,1,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *AgentRunStream) Reset()         { *m = AgentRunStream{} }
+func (m *AgentRunStream) String() string { return proto.CompactTextString(m) }
+func (*AgentRunStream) ProtoMessage()    {}
+func (*AgentRunStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{86}
+}
+
+func (m *AgentRunStream) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentRunStream.Unmarshal(m, b)
+}
+func (m *AgentRunStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentRunStream.Marshal(b, m, deterministic)
+}
+func (m *AgentRunStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentRunStream.Merge(m, src)
+}
+func (m *AgentRunStream) XXX_Size() int {
+	return xxx_messageInfo_AgentRunStream.Size(m)
+}
+func (m *AgentRunStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentRunStream.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentRunStream proto.InternalMessageInfo
+
+func (m *AgentRunStream) GetExecution() *Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+type AgentRunResponse struct {
+	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Payload              []byte   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AgentRunResponse) Reset()         { *m = AgentRunResponse{} }
+func (m *AgentRunResponse) String() string { return proto.CompactTextString(m) }
+func (*AgentRunResponse) ProtoMessage()    {}
+func (*AgentRunResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{87}
+}
+
+func (m *AgentRunResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentRunResponse.Unmarshal(m, b)
+}
+func (m *AgentRunResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentRunResponse.Marshal(b, m, deterministic)
+}
+func (m *AgentRunResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentRunResponse.Merge(m, src)
+}
+func (m *AgentRunResponse) XXX_Size() int {
+	return xxx_messageInfo_AgentRunResponse.Size(m)
+}
+func (m *AgentRunResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentRunResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentRunResponse proto.InternalMessageInfo
+
+func (m *AgentRunResponse) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *AgentRunResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+type GetActiveExecutionsResponse struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetActiveExecutionsResponse) Reset()         { *m = GetActiveExecutionsResponse{} }
+func (m *GetActiveExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetActiveExecutionsResponse) ProtoMessage()    {}
+func (*GetActiveExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{88}
+}
+
+func (m *GetActiveExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetActiveExecutionsResponse.Unmarshal(m, b)
+}
+func (m *GetActiveExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetActiveExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetActiveExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetActiveExecutionsResponse.Merge(m, src)
+}
+func (m *GetActiveExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetActiveExecutionsResponse.Size(m)
+}
+func (m *GetActiveExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetActiveExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetActiveExecutionsResponse proto.InternalMessageInfo
+
+func (m *GetActiveExecutionsResponse) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type AgentRunRequest struct {
+	Job                  *Job          `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Execution            *Execution    `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	KvToken              string        `protobuf:"bytes,3,opt,name=kv_token,json=kvToken,proto3" json:"kv_token,omitempty"`
+	Credentials          []*Credential `protobuf:"bytes,4,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AgentRunRequest) Reset()         { *m = AgentRunRequest{} }
+func (m *AgentRunRequest) String() string { return proto.CompactTextString(m) }
+func (*AgentRunRequest) ProtoMessage()    {}
+func (*AgentRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{89}
+}
+
+func (m *AgentRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentRunRequest.Unmarshal(m, b)
+}
+func (m *AgentRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentRunRequest.Marshal(b, m, deterministic)
+}
+func (m *AgentRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentRunRequest.Merge(m, src)
+}
+func (m *AgentRunRequest) XXX_Size() int {
+	return xxx_messageInfo_AgentRunRequest.Size(m)
+}
+func (m *AgentRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentRunRequest proto.InternalMessageInfo
+
+func (m *AgentRunRequest) GetJob() *Job {
+	if m != nil {
+		return m.Job
+	}
+	return nil
+}
+
+func (m *AgentRunRequest) GetExecution() *Execution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+func (m *AgentRunRequest) GetKvToken() string {
+	if m != nil {
+		return m.KvToken
+	}
+	return ""
+}
+
+func (m *AgentRunRequest) GetCredentials() []*Credential {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+type ReloadPluginRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadPluginRequest) Reset()         { *m = ReloadPluginRequest{} }
+func (m *ReloadPluginRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadPluginRequest) ProtoMessage()    {}
+func (*ReloadPluginRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{90}
+}
+
+func (m *ReloadPluginRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadPluginRequest.Unmarshal(m, b)
+}
+func (m *ReloadPluginRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadPluginRequest.Marshal(b, m, deterministic)
+}
+func (m *ReloadPluginRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadPluginRequest.Merge(m, src)
+}
+func (m *ReloadPluginRequest) XXX_Size() int {
+	return xxx_messageInfo_ReloadPluginRequest.Size(m)
+}
+func (m *ReloadPluginRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadPluginRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadPluginRequest proto.InternalMessageInfo
+
+func (m *ReloadPluginRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type ReloadPluginResponse struct {
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type   string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// replaced is set when the plugin replaced a loaded one.
+	Replaced             bool     `protobuf:"varint,4,opt,name=replaced,proto3" json:"replaced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadPluginResponse) Reset()         { *m = ReloadPluginResponse{} }
+func (m *ReloadPluginResponse) String() string { return proto.CompactTextString(m) }
+func (*ReloadPluginResponse) ProtoMessage()    {}
+func (*ReloadPluginResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f0292872e9433f8, []int{91}
+}
+
+func (m *ReloadPluginResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadPluginResponse.Unmarshal(m, b)
+}
+func (m *ReloadPluginResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadPluginResponse.Marshal(b, m, deterministic)
+}
+func (m *ReloadPluginResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadPluginResponse.Merge(m, src)
+}
+func (m *ReloadPluginResponse) XXX_Size() int {
+	return xxx_messageInfo_ReloadPluginResponse.Size(m)
+}
+func (m *ReloadPluginResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadPluginResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadPluginResponse proto.InternalMessageInfo
+
+func (m *ReloadPluginResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReloadPluginResponse) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ReloadPluginResponse) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+func (m *ReloadPluginResponse) GetReplaced() bool {
+	if m != nil {
+		return m.Replaced
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Job)(nil), "types.Job")
+	proto.RegisterMapType((map[string]string)(nil), "types.Job.ExecutorConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.Job.MetadataEntry")
+	proto.RegisterMapType((map[string]*PluginConfig)(nil), "types.Job.ProcessorsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.Job.TagsEntry")
+	proto.RegisterType((*Job_NullableTime)(nil), "types.Job.NullableTime")
+	proto.RegisterType((*Budget)(nil), "types.Budget")
+	proto.RegisterType((*JobPrecheck)(nil), "types.JobPrecheck")
+	proto.RegisterMapType((map[string]string)(nil), "types.JobPrecheck.ExecutorConfigEntry")
+	proto.RegisterType((*Matrix)(nil), "types.Matrix")
+	proto.RegisterMapType((map[string]*MatrixValues)(nil), "types.Matrix.ParamsEntry")
+	proto.RegisterType((*MatrixValues)(nil), "types.MatrixValues")
+	proto.RegisterType((*ConsulService)(nil), "types.ConsulService")
+	proto.RegisterType((*JobSecret)(nil), "types.JobSecret")
+	proto.RegisterType((*MemberTrigger)(nil), "types.MemberTrigger")
+	proto.RegisterMapType((map[string]string)(nil), "types.MemberTrigger.LabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.MemberTrigger.TagsEntry")
+	proto.RegisterType((*Canary)(nil), "types.Canary")
+	proto.RegisterMapType((map[string]string)(nil), "types.Canary.ExecutorConfigEntry")
+	proto.RegisterType((*CanaryStats)(nil), "types.CanaryStats")
+	proto.RegisterType((*EscalationStep)(nil), "types.EscalationStep")
+	proto.RegisterType((*JobStep)(nil), "types.JobStep")
+	proto.RegisterMapType((map[string]string)(nil), "types.JobStep.ExecutorConfigEntry")
+	proto.RegisterType((*JobResources)(nil), "types.JobResources")
+	proto.RegisterType((*PluginConfig)(nil), "types.PluginConfig")
+	proto.RegisterMapType((map[string]string)(nil), "types.PluginConfig.ConfigEntry")
+	proto.RegisterType((*SetJobRequest)(nil), "types.SetJobRequest")
+	proto.RegisterType((*SetJobResponse)(nil), "types.SetJobResponse")
+	proto.RegisterType((*DeleteJobRequest)(nil), "types.DeleteJobRequest")
+	proto.RegisterType((*DeleteJobResponse)(nil), "types.DeleteJobResponse")
+	proto.RegisterType((*GetJobRequest)(nil), "types.GetJobRequest")
+	proto.RegisterType((*GetJobResponse)(nil), "types.GetJobResponse")
+	proto.RegisterType((*Execution)(nil), "types.Execution")
+	proto.RegisterMapType((map[string]float64)(nil), "types.Execution.MetricsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.Execution.ParamsEntry")
+	proto.RegisterType((*Artifact)(nil), "types.Artifact")
+	proto.RegisterType((*ExecutionDoneRequest)(nil), "types.ExecutionDoneRequest")
+	proto.RegisterType((*ExecutionDoneResponse)(nil), "types.ExecutionDoneResponse")
+	proto.RegisterType((*RunJobRequest)(nil), "types.RunJobRequest")
+	proto.RegisterMapType((map[string]string)(nil), "types.RunJobRequest.ParamsEntry")
+	proto.RegisterType((*ShadowRun)(nil), "types.ShadowRun")
+	proto.RegisterMapType((map[string]string)(nil), "types.ShadowRun.ExecutorConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.ShadowRun.TagsEntry")
+	proto.RegisterType((*RunJobResponse)(nil), "types.RunJobResponse")
+	proto.RegisterType((*ToggleJobRequest)(nil), "types.ToggleJobRequest")
+	proto.RegisterType((*ToggleJobResponse)(nil), "types.ToggleJobResponse")
+	proto.RegisterType((*ResetJobRequest)(nil), "types.ResetJobRequest")
+	proto.RegisterType((*ResetJobResponse)(nil), "types.ResetJobResponse")
+	proto.RegisterType((*AnnotateExecutionRequest)(nil), "types.AnnotateExecutionRequest")
+	proto.RegisterType((*AnnotateExecutionResponse)(nil), "types.AnnotateExecutionResponse")
+	proto.RegisterType((*TrashedJob)(nil), "types.TrashedJob")
+	proto.RegisterType((*JobList)(nil), "types.JobList")
+	proto.RegisterType((*ExecutionList)(nil), "types.ExecutionList")
+	proto.RegisterType((*RestoreJobRequest)(nil), "types.RestoreJobRequest")
+	proto.RegisterType((*RestoreJobResponse)(nil), "types.RestoreJobResponse")
+	proto.RegisterType((*SetReadOnlyRequest)(nil), "types.SetReadOnlyRequest")
+	proto.RegisterType((*SetReadOnlyResponse)(nil), "types.SetReadOnlyResponse")
+	proto.RegisterType((*ScheduleMacros)(nil), "types.ScheduleMacros")
+	proto.RegisterMapType((map[string]string)(nil), "types.ScheduleMacros.MacrosEntry")
+	proto.RegisterType((*ExecutionRetention)(nil), "types.ExecutionRetention")
+	proto.RegisterType((*MaintenanceWindow)(nil), "types.MaintenanceWindow")
+	proto.RegisterMapType((map[string]string)(nil), "types.MaintenanceWindow.TagsEntry")
+	proto.RegisterType((*SetMaintenanceWindowRequest)(nil), "types.SetMaintenanceWindowRequest")
+	proto.RegisterType((*SetMaintenanceWindowResponse)(nil), "types.SetMaintenanceWindowResponse")
+	proto.RegisterType((*DeleteMaintenanceWindowRequest)(nil), "types.DeleteMaintenanceWindowRequest")
+	proto.RegisterType((*DeleteMaintenanceWindowResponse)(nil), "types.DeleteMaintenanceWindowResponse")
+	proto.RegisterType((*Silence)(nil), "types.Silence")
+	proto.RegisterMapType((map[string]string)(nil), "types.Silence.MetadataEntry")
+	proto.RegisterType((*SetSilenceRequest)(nil), "types.SetSilenceRequest")
+	proto.RegisterType((*SetSilenceResponse)(nil), "types.SetSilenceResponse")
+	proto.RegisterType((*DeleteSilenceRequest)(nil), "types.DeleteSilenceRequest")
+	proto.RegisterType((*DeleteSilenceResponse)(nil), "types.DeleteSilenceResponse")
+	proto.RegisterType((*Credential)(nil), "types.Credential")
+	proto.RegisterType((*SetCredentialRequest)(nil), "types.SetCredentialRequest")
+	proto.RegisterType((*SetCredentialResponse)(nil), "types.SetCredentialResponse")
+	proto.RegisterType((*DeleteCredentialRequest)(nil), "types.DeleteCredentialRequest")
+	proto.RegisterType((*DeleteCredentialResponse)(nil), "types.DeleteCredentialResponse")
+	proto.RegisterType((*ComplianceEvent)(nil), "types.ComplianceEvent")
+	proto.RegisterType((*ComplianceRequest)(nil), "types.ComplianceRequest")
+	proto.RegisterType((*ComplianceResponse)(nil), "types.ComplianceResponse")
+	proto.RegisterType((*SchedulerEvent)(nil), "types.SchedulerEvent")
+	proto.RegisterType((*SetSchedulerPauseRequest)(nil), "types.SetSchedulerPauseRequest")
+	proto.RegisterType((*SetSchedulerPauseResponse)(nil), "types.SetSchedulerPauseResponse")
+	proto.RegisterType((*CommitChangesRequest)(nil), "types.CommitChangesRequest")
+	proto.RegisterType((*DispatchIntent)(nil), "types.DispatchIntent")
+	proto.RegisterMapType((map[string]string)(nil), "types.DispatchIntent.NodesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.DispatchIntent.ParamsEntry")
+	proto.RegisterType((*DeleteDispatchIntentRequest)(nil), "types.DeleteDispatchIntentRequest")
+	proto.RegisterType((*MarkDispatchedRequest)(nil), "types.MarkDispatchedRequest")
+	proto.RegisterType((*Backfill)(nil), "types.Backfill")
+	proto.RegisterType((*BackfillRequest)(nil), "types.BackfillRequest")
+	proto.RegisterType((*BackfillResponse)(nil), "types.BackfillResponse")
+	proto.RegisterType((*CancelBackfillRequest)(nil), "types.CancelBackfillRequest")
+	proto.RegisterType((*CancelBackfillResponse)(nil), "types.CancelBackfillResponse")
+	proto.RegisterType((*Lock)(nil), "types.Lock")
+	proto.RegisterType((*AcquireLockRequest)(nil), "types.AcquireLockRequest")
+	proto.RegisterType((*AcquireLockResponse)(nil), "types.AcquireLockResponse")
+	proto.RegisterType((*ReleaseLockRequest)(nil), "types.ReleaseLockRequest")
+	proto.RegisterType((*KV)(nil), "types.KV")
+	proto.RegisterType((*SetKVRequest)(nil), "types.SetKVRequest")
+	proto.RegisterType((*DeleteKVRequest)(nil), "types.DeleteKVRequest")
+	proto.RegisterType((*ReadIndexResponse)(nil), "types.ReadIndexResponse")
+	proto.RegisterType((*StoreProblem)(nil), "types.StoreProblem")
+	proto.RegisterType((*CheckStoreRequest)(nil), "types.CheckStoreRequest")
+	proto.RegisterType((*CheckStoreResponse)(nil), "types.CheckStoreResponse")
+	proto.RegisterType((*RaftServer)(nil), "types.RaftServer")
+	proto.RegisterType((*RaftGetConfigurationResponse)(nil), "types.RaftGetConfigurationResponse")
+	proto.RegisterType((*RaftRemovePeerByIDRequest)(nil), "types.RaftRemovePeerByIDRequest")
+	proto.RegisterType((*AgentRunStream)(nil), "types.AgentRunStream")
+	proto.RegisterType((*AgentRunResponse)(nil), "types.AgentRunResponse")
+	proto.RegisterType((*GetActiveExecutionsResponse)(nil), "types.GetActiveExecutionsResponse")
+	proto.RegisterType((*AgentRunRequest)(nil), "types.AgentRunRequest")
+	proto.RegisterType((*ReloadPluginRequest)(nil), "types.ReloadPluginRequest")
+	proto.RegisterType((*ReloadPluginResponse)(nil), "types.ReloadPluginResponse")
+}
+
+func init() {
+	proto.RegisterFile("dkron.proto", fileDescriptor_1f0292872e9433f8)
+}
+
+var fileDescriptor_1f0292872e9433f8 = []byte{
+	// 4936 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x7b, 0x4b, 0x6f, 0x1c, 0xc7,
+	0x76, 0x30, 0xe6, 0xc9, 0x99, 0xc3, 0x77, 0x89, 0xa4, 0x9a, 0x23, 0xd9, 0xe2, 0x6d, 0x5b, 0xba,
+	0x94, 0x1f, 0xb4, 0x24, 0xdb, 0x92, 0x2c, 0x7d, 0xf6, 0xd5, 0x88, 0x92, 0xf5, 0xe9, 0x41, 0x89,
+	0xe9, 0x11, 0x74, 0x17, 0x09, 0x30, 0x28, 0x76, 0x17, 0xc9, 0x36, 0x7b, 0xba, 0xc7, 0xd5, 0x35,
+	0x94, 0xc6, 0xcb, 0x04, 0xb9, 0x01, 0x2e, 0x10, 0x20, 0x8b, 0x6c, 0x83, 0x24, 0xdb, 0x64, 0x71,
+	0xf7, 0xd9, 0x24, 0xdb, 0x00, 0x59, 0xe5, 0x1f, 0x04, 0x48, 0xfe, 0x43, 0x96, 0xc1, 0xa9, 0x47,
+	0xbf, 0x66, 0x86, 0x33, 0x94, 0x1d, 0x64, 0x35, 0x7d, 0x4e, 0x9d, 0x7a, 0x9d, 0x3a, 0xaf, 0x3a,
+	0xa7, 0x06, 0xe6, 0xbd, 0x13, 0x1e, 0x85, 0x3b, 0x7d, 0x1e, 0x89, 0x88, 0xd4, 0xc4, 0xb0, 0xcf,
+	0xe2, 0xd6, 0x95, 0xa3, 0x28, 0x3a, 0x0a, 0xd8, 0x17, 0x12, 0x79, 0x30, 0x38, 0xfc, 0x42, 0xf8,
+	0x3d, 0x16, 0x0b, 0xda, 0xeb, 0x2b, 0xba, 0xd6, 0xa5, 0x22, 0x01, 0xeb, 0xf5, 0xc5, 0x50, 0x35,
+	0xda, 0xff, 0xbd, 0x06, 0x95, 0x67, 0xd1, 0x01, 0x21, 0x50, 0x0d, 0x69, 0x8f, 0x59, 0xa5, 0xad,
+	0xd2, 0x76, 0xd3, 0x91, 0xdf, 0xa4, 0x05, 0x0d, 0x1c, 0xeb, 0xa7, 0x28, 0x64, 0x56, 0x59, 0xe2,
+	0x13, 0x18, 0xdb, 0x62, 0xf7, 0x98, 0x79, 0x83, 0x80, 0x59, 0x15, 0xd5, 0x66, 0x60, 0xb2, 0x06,
+	0xb5, 0xe8, 0x6d, 0xc8, 0xb8, 0x35, 0x27, 0x1b, 0x14, 0x40, 0xae, 0xc0, 0xbc, 0xfc, 0xe8, 0xb2,
+	0x1e, 0xf5, 0x03, 0xab, 0x21, 0xdb, 0x40, 0xa2, 0x1e, 0x23, 0x86, 0x7c, 0x04, 0x8b, 0xf1, 0xc0,
+	0x75, 0x59, 0x1c, 0x77, 0xdd, 0x68, 0x10, 0x0a, 0xab, 0xb9, 0x55, 0xda, 0xae, 0x39, 0x0b, 0x1a,
+	0xb9, 0x8b, 0x38, 0x1c, 0x85, 0x71, 0x1e, 0x71, 0x4d, 0x02, 0x92, 0x04, 0x24, 0x4a, 0x11, 0xb4,
+	0xa0, 0xe1, 0xf9, 0x31, 0x3d, 0x08, 0x98, 0x67, 0xcd, 0x6f, 0x95, 0xb6, 0x1b, 0x4e, 0x02, 0x93,
+	0x6d, 0xa8, 0x0a, 0x7a, 0x14, 0x5b, 0x0b, 0x5b, 0x95, 0xed, 0xf9, 0x5b, 0x6b, 0x3b, 0x92, 0x81,
+	0x3b, 0xcf, 0xa2, 0x83, 0x9d, 0xd7, 0xf4, 0x28, 0x7e, 0x1c, 0x0a, 0x3e, 0x74, 0x24, 0x05, 0xb1,
+	0x60, 0x8e, 0x33, 0xc1, 0x7d, 0x16, 0x5b, 0x8b, 0x5b, 0xa5, 0xed, 0x45, 0xc7, 0x80, 0xe4, 0x2a,
+	0x2c, 0x79, 0xac, 0xcf, 0x42, 0x8f, 0x85, 0xa2, 0xfb, 0x43, 0x74, 0x10, 0x5b, 0x4b, 0x5b, 0x95,
+	0xed, 0xa6, 0xb3, 0x98, 0x60, 0x9f, 0x45, 0x07, 0x31, 0xf9, 0x00, 0xa0, 0x4f, 0xb9, 0xa6, 0xb1,
+	0x96, 0xe5, 0x66, 0x9b, 0x0a, 0x83, 0xec, 0xde, 0x82, 0x79, 0x37, 0x0a, 0xdd, 0x01, 0xe7, 0x2c,
+	0x74, 0x87, 0xd6, 0x8a, 0x6c, 0xcf, 0xa2, 0x70, 0x1f, 0xec, 0x1d, 0x73, 0x07, 0x22, 0xe2, 0xd6,
+	0xaa, 0x62, 0xb0, 0x81, 0xc9, 0x13, 0x58, 0x36, 0xdf, 0x5d, 0x37, 0x0a, 0x0f, 0xfd, 0x23, 0x8b,
+	0xc8, 0x2d, 0x7d, 0x98, 0xd9, 0xd2, 0x63, 0x4d, 0xb1, 0x2b, 0x09, 0xd4, 0xe6, 0x96, 0x58, 0x0e,
+	0x49, 0x36, 0xa0, 0x1e, 0x0b, 0x2a, 0x06, 0xb1, 0x75, 0x41, 0x4e, 0xa1, 0x21, 0xf2, 0x15, 0x34,
+	0x7a, 0x4c, 0x50, 0x8f, 0x0a, 0x6a, 0xad, 0xc9, 0x91, 0xad, 0xcc, 0xc8, 0x7b, 0xba, 0x49, 0x8d,
+	0x99, 0x50, 0x92, 0x7b, 0xb0, 0x10, 0xd0, 0x58, 0x74, 0xf5, 0x81, 0x59, 0x9b, 0x5b, 0xa5, 0xed,
+	0xf9, 0x5b, 0x17, 0x33, 0x3d, 0x5f, 0x0e, 0x82, 0x00, 0x8f, 0xe2, 0xb5, 0xdf, 0x63, 0xce, 0x3c,
+	0x12, 0x77, 0x14, 0x2d, 0xb9, 0x0d, 0x20, 0xfb, 0xca, 0x93, 0xb4, 0x5a, 0x67, 0xf7, 0x6c, 0x22,
+	0xe9, 0x63, 0xa4, 0x24, 0x3b, 0x50, 0x0d, 0xd9, 0x3b, 0x61, 0x5d, 0x94, 0x3d, 0x5a, 0x3b, 0x4a,
+	0xd6, 0x77, 0x8c, 0xac, 0xef, 0xbc, 0x36, 0xca, 0xe0, 0x48, 0x3a, 0x64, 0xbc, 0xe7, 0xc7, 0xfd,
+	0x80, 0x0e, 0xa5, 0xb8, 0x5b, 0x8a, 0xf1, 0x19, 0x14, 0xb9, 0x07, 0xd0, 0xe7, 0x11, 0x2e, 0x2a,
+	0xe2, 0xb1, 0x75, 0x49, 0xee, 0xbe, 0x95, 0x59, 0xc9, 0x7e, 0xd2, 0xa8, 0xf6, 0x9f, 0xa1, 0x26,
+	0x77, 0xc1, 0xea, 0xd1, 0x77, 0x78, 0x26, 0x31, 0xf2, 0xd9, 0x3f, 0x65, 0xdd, 0x43, 0xea, 0x07,
+	0x03, 0xce, 0x62, 0xeb, 0xb2, 0x14, 0xd5, 0x8d, 0x1e, 0x7d, 0xb7, 0x9b, 0x36, 0x7f, 0xaf, 0x5b,
+	0xc9, 0x4d, 0x58, 0x1b, 0xdb, 0xeb, 0x03, 0xd9, 0xeb, 0x82, 0x3b, 0xa6, 0xcb, 0x07, 0xa0, 0xb4,
+	0xa7, 0x2b, 0x18, 0xed, 0x59, 0x1f, 0x2a, 0x11, 0x93, 0x98, 0xd7, 0x8c, 0xf6, 0x70, 0x2d, 0xaa,
+	0x99, 0xc5, 0x2e, 0x0d, 0xa8, 0xf0, 0xa3, 0xb0, 0xeb, 0x1e, 0xd3, 0x30, 0x64, 0x81, 0x75, 0x45,
+	0x12, 0x6f, 0x28, 0xe5, 0x4b, 0x9a, 0x77, 0x55, 0x2b, 0x4a, 0x45, 0x10, 0xb9, 0x27, 0xcc, 0xb3,
+	0xb6, 0xa4, 0x02, 0x69, 0x88, 0x7c, 0x0c, 0xb5, 0x58, 0xb0, 0x7e, 0x6c, 0xfd, 0x4a, 0x32, 0x65,
+	0x29, 0x65, 0x4a, 0x47, 0xb0, 0xbe, 0xa3, 0x1a, 0xc9, 0x4d, 0x68, 0x72, 0x16, 0x47, 0x03, 0xee,
+	0xb2, 0xd8, 0xb2, 0xe5, 0xb1, 0x5c, 0x48, 0x29, 0x1d, 0xd3, 0xe4, 0xa4, 0x54, 0xe4, 0xd7, 0xb0,
+	0x9c, 0x11, 0xfd, 0xee, 0x09, 0x1b, 0x5a, 0x1f, 0xc9, 0x15, 0x2e, 0x65, 0xd0, 0xcf, 0xd9, 0x10,
+	0xa5, 0xc4, 0xe5, 0x8c, 0x0a, 0xe6, 0x75, 0xa9, 0xb0, 0x3e, 0x9e, 0x22, 0x25, 0x9a, 0xb4, 0x2d,
+	0xb0, 0xdf, 0xa0, 0xef, 0x99, 0x7e, 0x57, 0xa7, 0xf4, 0xd3, 0xa4, 0x6d, 0x81, 0x2c, 0x36, 0xf3,
+	0x1d, 0x0c, 0xad, 0x6b, 0x8a, 0xc5, 0x1a, 0xf3, 0x70, 0x88, 0xcd, 0x66, 0xd8, 0x83, 0xa1, 0xf5,
+	0x6b, 0xd5, 0xac, 0x31, 0x0f, 0xa5, 0x0a, 0xf7, 0xb9, 0x1f, 0x71, 0x5f, 0x0c, 0xad, 0x6d, 0xa5,
+	0xc2, 0x06, 0x26, 0x97, 0xa0, 0x19, 0x46, 0xc2, 0x3f, 0x1c, 0x76, 0xa3, 0xd0, 0xba, 0xae, 0x1a,
+	0x15, 0xe2, 0x55, 0x48, 0x7e, 0x05, 0x0b, 0xba, 0x91, 0x9d, 0x32, 0x3e, 0xb4, 0x3e, 0x91, 0x42,
+	0x30, 0xaf, 0x70, 0x8f, 0x11, 0x45, 0xbe, 0x06, 0x48, 0xcf, 0xd5, 0xfa, 0x54, 0x1e, 0xc8, 0xba,
+	0xde, 0x51, 0x7a, 0xa2, 0xf2, 0x5c, 0x32, 0x84, 0xe4, 0x3a, 0xac, 0xa4, 0x50, 0x37, 0x60, 0xa7,
+	0x2c, 0xb0, 0x3e, 0x93, 0xa3, 0x2f, 0xa7, 0xf8, 0x17, 0x88, 0x26, 0x57, 0xa1, 0xee, 0xd2, 0x90,
+	0xf2, 0xa1, 0xf5, 0xb9, 0xe4, 0xd7, 0xa2, 0x1e, 0x7d, 0x57, 0x22, 0x1d, 0xdd, 0x48, 0x2e, 0x43,
+	0x33, 0xf6, 0x8f, 0x42, 0x2a, 0x06, 0x9c, 0x59, 0x3b, 0x8a, 0x05, 0x09, 0x02, 0xb7, 0x89, 0x80,
+	0x62, 0xd0, 0x17, 0xda, 0x4f, 0x48, 0xc4, 0xc3, 0x21, 0xb9, 0x01, 0x0d, 0xc1, 0xfd, 0xa3, 0x23,
+	0xc6, 0x63, 0xeb, 0x46, 0xce, 0x24, 0xef, 0xb1, 0xde, 0x01, 0xe3, 0xaf, 0x55, 0xa3, 0x93, 0x50,
+	0x49, 0xe3, 0xce, 0xa8, 0x17, 0xf8, 0x21, 0xb3, 0x6e, 0xaa, 0xd1, 0x0c, 0x8c, 0x42, 0x64, 0xbe,
+	0xbb, 0xd4, 0x95, 0x6c, 0xb9, 0xa5, 0x84, 0xc8, 0xa0, 0xdb, 0x12, 0x8b, 0x16, 0xfc, 0x80, 0x33,
+	0x8a, 0xde, 0xaa, 0x7b, 0xc4, 0xa3, 0x41, 0xdf, 0xfa, 0x72, 0xab, 0xb4, 0x5d, 0x71, 0x16, 0x0d,
+	0xf6, 0x09, 0x22, 0xd1, 0xd3, 0xc4, 0x82, 0x86, 0xde, 0xc1, 0xb0, 0x7b, 0x18, 0x71, 0xeb, 0x2b,
+	0xe5, 0xaf, 0x34, 0xea, 0xfb, 0x88, 0xe3, 0x29, 0xf5, 0xfc, 0xb0, 0xeb, 0x87, 0x82, 0xf1, 0x53,
+	0x1a, 0x58, 0x5f, 0x2b, 0x5b, 0xd2, 0xf3, 0xc3, 0xa7, 0x1a, 0x85, 0x3c, 0x3c, 0x18, 0x78, 0x47,
+	0x4c, 0x58, 0xb7, 0x73, 0x3c, 0x7c, 0x28, 0x91, 0x8e, 0x6e, 0x44, 0x6f, 0x73, 0xca, 0x78, 0x8c,
+	0x4b, 0xbe, 0x23, 0x97, 0x62, 0x40, 0xdc, 0x14, 0x67, 0x1e, 0x75, 0x45, 0xb7, 0x4f, 0x85, 0x60,
+	0x3c, 0x8c, 0xad, 0xbb, 0xd2, 0xdd, 0x2c, 0x29, 0xf4, 0xbe, 0xc6, 0x92, 0xfb, 0x80, 0xba, 0x12,
+	0x0f, 0x82, 0x6e, 0xcc, 0xf8, 0xa9, 0xef, 0x32, 0xeb, 0x9b, 0xad, 0x52, 0x86, 0xa3, 0xbb, 0xb2,
+	0xb1, 0xa3, 0xda, 0x9c, 0x45, 0x37, 0x0b, 0x92, 0x4f, 0x60, 0x2e, 0x66, 0x2e, 0x67, 0x22, 0xb6,
+	0xee, 0xc9, 0x73, 0x58, 0xc9, 0xa8, 0xb6, 0x6c, 0x70, 0x0c, 0x81, 0xf4, 0x5c, 0x9c, 0xa1, 0x9f,
+	0xf3, 0x69, 0x10, 0x5b, 0xf7, 0xe5, 0x6a, 0xb2, 0x28, 0xb2, 0x05, 0x0b, 0x6e, 0x14, 0x8b, 0x6e,
+	0x9f, 0xf1, 0x2e, 0x1f, 0x84, 0xd6, 0xff, 0xdb, 0x2a, 0x6d, 0x97, 0x1c, 0x40, 0xdc, 0x3e, 0xe3,
+	0xce, 0x00, 0x4f, 0xa0, 0xde, 0xa3, 0x82, 0xfb, 0xef, 0xac, 0x6f, 0x73, 0x6c, 0xd9, 0x93, 0x48,
+	0x47, 0x37, 0x92, 0x1d, 0xd4, 0x1f, 0xe6, 0x1e, 0x33, 0xf7, 0xc4, 0xfa, 0x4e, 0x12, 0x92, 0x74,
+	0x5d, 0xfb, 0xba, 0xc5, 0x49, 0x68, 0xc8, 0xc7, 0xb0, 0x14, 0x85, 0x5d, 0xed, 0x76, 0xe3, 0x13,
+	0xbf, 0x6f, 0xfd, 0x46, 0x1e, 0xc9, 0x42, 0x14, 0xee, 0x4b, 0x64, 0xe7, 0xc4, 0xef, 0xa3, 0xd2,
+	0x62, 0x9b, 0x0e, 0x20, 0x1e, 0x48, 0xe1, 0x6f, 0x22, 0x46, 0xc6, 0x0f, 0xad, 0x3b, 0xd0, 0x4c,
+	0x82, 0x01, 0xb2, 0x02, 0x15, 0x34, 0x46, 0x2a, 0x28, 0xc2, 0x4f, 0x8c, 0x6d, 0x4e, 0x69, 0x30,
+	0x30, 0x01, 0x91, 0x02, 0xee, 0x95, 0xef, 0x96, 0x5a, 0x6d, 0xb8, 0x30, 0xc6, 0xe5, 0x9e, 0x6b,
+	0x88, 0xfb, 0xb0, 0x98, 0xf3, 0xad, 0xe7, 0xea, 0xfc, 0xc7, 0xb0, 0x90, 0x35, 0x63, 0xa8, 0x7a,
+	0xc7, 0x34, 0xee, 0x2a, 0xea, 0x92, 0x8a, 0x84, 0x8e, 0x69, 0xfc, 0x06, 0x61, 0x74, 0x9b, 0x18,
+	0xca, 0xc9, 0x51, 0xa6, 0xb8, 0x4d, 0xa4, 0x6b, 0x39, 0xb0, 0x5c, 0xf0, 0x7b, 0x63, 0xd6, 0x76,
+	0x3d, 0xbb, 0xb6, 0xd4, 0xea, 0xef, 0x07, 0x83, 0x23, 0x3f, 0x54, 0x3c, 0xc9, 0x2c, 0xd8, 0xfe,
+	0xbb, 0x32, 0xd4, 0x95, 0x22, 0x90, 0x4d, 0x68, 0xa0, 0xdf, 0xe4, 0x83, 0x30, 0x96, 0x03, 0xd6,
+	0x9c, 0xb9, 0x1e, 0x7d, 0xe7, 0x0c, 0xc2, 0x18, 0x9d, 0x51, 0x9f, 0x71, 0x3f, 0xf2, 0xf4, 0x8e,
+	0x35, 0x24, 0x4d, 0x33, 0xe5, 0x7c, 0xd8, 0x8d, 0x4e, 0x19, 0x97, 0x21, 0x68, 0xcd, 0x69, 0x4a,
+	0xcc, 0xab, 0x53, 0xc6, 0xc9, 0xb7, 0xb0, 0xa0, 0x08, 0xbb, 0xb1, 0xa0, 0x5c, 0x58, 0xd5, 0xa9,
+	0x1b, 0x9d, 0x57, 0xf4, 0x1d, 0x24, 0xc7, 0x70, 0x78, 0x10, 0x33, 0xcf, 0xaa, 0xc9, 0x71, 0xe5,
+	0x37, 0x6a, 0x29, 0x8e, 0xef, 0x33, 0xcf, 0xaa, 0xab, 0x35, 0x6a, 0x90, 0xdc, 0x87, 0x79, 0xf6,
+	0xce, 0x65, 0xcc, 0x53, 0xfe, 0x65, 0x6e, 0xea, 0x5c, 0x60, 0xc8, 0xdb, 0x32, 0x60, 0xe5, 0xec,
+	0x70, 0x10, 0x7a, 0xcc, 0x93, 0x41, 0x71, 0xcd, 0x49, 0x60, 0xfb, 0xbf, 0x4a, 0x30, 0x9f, 0x91,
+	0xf5, 0x5c, 0x50, 0x58, 0x2a, 0x04, 0x85, 0xaf, 0x46, 0x83, 0xc2, 0xb2, 0x54, 0xe6, 0x6b, 0xa3,
+	0x4a, 0x33, 0x53, 0x70, 0x78, 0x0d, 0x96, 0xc3, 0xa8, 0xfb, 0x36, 0xe2, 0x27, 0xc6, 0xf8, 0xe8,
+	0x48, 0x7f, 0x31, 0x8c, 0x7e, 0x1b, 0xf1, 0x13, 0x6d, 0x7b, 0x7e, 0x01, 0xc1, 0xb7, 0xff, 0xba,
+	0x0c, 0x75, 0xa5, 0xfc, 0xe4, 0x26, 0xd4, 0xfb, 0x94, 0xd3, 0x1e, 0x0a, 0x02, 0xae, 0x7e, 0x33,
+	0x67, 0x1b, 0x76, 0xf6, 0x65, 0x9b, 0x5a, 0xb0, 0x26, 0x44, 0x4b, 0x7d, 0xc8, 0xa3, 0x9e, 0xd6,
+	0x7c, 0x3d, 0x3a, 0x20, 0x4a, 0xa9, 0x3d, 0xda, 0x2c, 0x24, 0x0d, 0x02, 0x16, 0xf8, 0x71, 0x4f,
+	0x0b, 0x4b, 0x16, 0x45, 0x3e, 0x83, 0xa6, 0xe7, 0xc7, 0x6e, 0x24, 0xdd, 0xad, 0x92, 0x95, 0x62,
+	0x78, 0x93, 0x12, 0xc8, 0xb0, 0xb9, 0xcf, 0x19, 0x55, 0xf2, 0xd1, 0x70, 0x34, 0xd4, 0x7a, 0x09,
+	0xf3, 0x99, 0xf5, 0xcd, 0xae, 0x21, 0x6a, 0x6f, 0x52, 0x33, 0xe3, 0x2c, 0x5b, 0xae, 0xc1, 0x42,
+	0xb6, 0x09, 0xe7, 0x95, 0x8d, 0x8a, 0x37, 0x4d, 0x47, 0x43, 0xf6, 0x8f, 0xb0, 0x98, 0xb3, 0xef,
+	0x28, 0xaa, 0xc6, 0x0d, 0xa8, 0xd9, 0x0d, 0x88, 0x6b, 0x12, 0xf4, 0x48, 0xf3, 0x08, 0x3f, 0xf1,
+	0x54, 0x94, 0x29, 0x54, 0x6c, 0x51, 0x00, 0xf9, 0x10, 0x00, 0xcd, 0x90, 0xcb, 0xd0, 0x95, 0x49,
+	0x8e, 0x34, 0x9d, 0x0c, 0xc6, 0xde, 0x85, 0x66, 0xe2, 0x1c, 0x70, 0x50, 0x16, 0x9e, 0x9a, 0x8d,
+	0xb2, 0xf0, 0x14, 0xf5, 0xa7, 0x4f, 0xc5, 0xb1, 0x9e, 0x47, 0x7e, 0x1b, 0x76, 0x54, 0x12, 0x76,
+	0xd8, 0x7f, 0x51, 0x86, 0xc5, 0x9c, 0xab, 0xc7, 0xc5, 0xb0, 0x53, 0x3c, 0x44, 0x35, 0x96, 0x02,
+	0xc8, 0x2d, 0x7d, 0x6f, 0x2b, 0xe7, 0x2e, 0x39, 0xb9, 0x9e, 0x23, 0x37, 0xb8, 0xbb, 0x50, 0x0f,
+	0xe8, 0x01, 0x0b, 0x62, 0xab, 0x22, 0x7b, 0x6d, 0x8d, 0xed, 0xf5, 0x42, 0x92, 0x68, 0x71, 0x52,
+	0xf4, 0xef, 0xef, 0x01, 0xbe, 0x81, 0xf9, 0xcc, 0x78, 0xe7, 0x52, 0x80, 0x7f, 0xac, 0x40, 0x5d,
+	0x05, 0x56, 0x67, 0xea, 0xf8, 0xb3, 0x49, 0x3a, 0xfe, 0xab, 0x5c, 0x70, 0x36, 0x93, 0x7a, 0x5b,
+	0x30, 0xd7, 0x67, 0x1c, 0x8f, 0x53, 0x9f, 0xbc, 0x01, 0x71, 0x99, 0x61, 0xe4, 0xb1, 0xd8, 0xaa,
+	0x4a, 0x29, 0x53, 0x00, 0xf9, 0x06, 0x40, 0x9a, 0x52, 0x65, 0xe3, 0x6a, 0x53, 0x6d, 0x5c, 0x53,
+	0x53, 0xb7, 0x05, 0xf9, 0x12, 0xe6, 0x58, 0xe8, 0xc5, 0xd8, 0xaf, 0x3e, 0xb5, 0x5f, 0x1d, 0x49,
+	0xdb, 0x82, 0x7c, 0x22, 0xef, 0xa6, 0x07, 0x01, 0xb3, 0xe6, 0x72, 0xbe, 0x5f, 0x6d, 0xb1, 0x23,
+	0xa8, 0x88, 0x1d, 0x4d, 0x81, 0xb4, 0x3a, 0x56, 0x6d, 0x4c, 0xa6, 0x55, 0x14, 0xbf, 0x84, 0xb9,
+	0xfa, 0x09, 0xe6, 0x33, 0x23, 0x8f, 0x26, 0x2e, 0x4a, 0xd3, 0x13, 0x17, 0xe5, 0x91, 0xc4, 0xc5,
+	0x55, 0x58, 0x12, 0x91, 0xa0, 0x41, 0xd7, 0x1b, 0x70, 0x15, 0xd5, 0x57, 0x54, 0x58, 0x2a, 0xb1,
+	0x8f, 0x34, 0xd2, 0xfe, 0x7d, 0x09, 0x96, 0xf2, 0x01, 0x3e, 0x2e, 0x94, 0x1e, 0xa2, 0x9a, 0xaa,
+	0x79, 0x15, 0x80, 0xe7, 0xfb, 0x96, 0x1d, 0x1c, 0x47, 0xd1, 0x89, 0xde, 0x80, 0x01, 0xe5, 0xc9,
+	0xd3, 0x61, 0x10, 0x51, 0x4f, 0x2b, 0xa3, 0x01, 0x71, 0x24, 0x95, 0x9d, 0xa9, 0x6a, 0xf5, 0x43,
+	0x00, 0xe9, 0x75, 0x0a, 0x45, 0xdb, 0x3b, 0x03, 0xda, 0xff, 0x5a, 0x82, 0x39, 0x6d, 0x1f, 0x27,
+	0x65, 0x90, 0x12, 0x59, 0x2e, 0x17, 0x64, 0xf9, 0xf9, 0xa8, 0x2c, 0x2b, 0x4d, 0xb5, 0xf3, 0x86,
+	0x77, 0x16, 0x61, 0xfe, 0x25, 0x0e, 0xb5, 0x03, 0x0b, 0xd9, 0xfb, 0x29, 0xf6, 0x75, 0xfb, 0x03,
+	0xd9, 0xb7, 0xe4, 0xe0, 0x27, 0x9a, 0xdf, 0x1e, 0xeb, 0x45, 0x7c, 0x28, 0x3b, 0x57, 0x1c, 0x0d,
+	0x61, 0xf4, 0xe2, 0x47, 0x5d, 0x37, 0xa0, 0x71, 0x6c, 0x18, 0xea, 0x47, 0xbb, 0x08, 0xda, 0x7f,
+	0x5a, 0x82, 0x85, 0x6c, 0xfc, 0x43, 0xee, 0x40, 0x5d, 0x6f, 0x56, 0xb9, 0xb7, 0x2b, 0x63, 0x82,
+	0xa4, 0x9d, 0xec, 0x4e, 0x35, 0x39, 0x1a, 0x97, 0xf7, 0xdd, 0xd9, 0x4b, 0x58, 0xec, 0x30, 0x21,
+	0x37, 0xf7, 0xe3, 0x80, 0xc5, 0x82, 0x5c, 0x86, 0x0a, 0x66, 0xa5, 0x4a, 0x52, 0x57, 0x20, 0x73,
+	0x39, 0x47, 0x34, 0x4a, 0x2a, 0xf5, 0xf0, 0x66, 0x23, 0xa2, 0x13, 0x16, 0x1a, 0x77, 0x2a, 0x51,
+	0xaf, 0x11, 0x63, 0xef, 0xc0, 0x92, 0x19, 0x2f, 0xee, 0x47, 0x61, 0xcc, 0xce, 0x1e, 0xd0, 0xfe,
+	0xe7, 0x32, 0xac, 0x3c, 0x62, 0x01, 0x13, 0x2c, 0xb3, 0x86, 0x4d, 0x68, 0xfc, 0x10, 0x1d, 0x74,
+	0x33, 0x22, 0x33, 0xf7, 0x43, 0x74, 0xf0, 0x12, 0xa5, 0xe6, 0x36, 0x5c, 0x14, 0x9c, 0xc6, 0xc7,
+	0x5d, 0xce, 0x04, 0x0b, 0xe5, 0x4d, 0x35, 0x66, 0x6e, 0x14, 0x7a, 0xb1, 0x66, 0xfc, 0xba, 0x6c,
+	0x76, 0x4c, 0x6b, 0x47, 0x35, 0xe2, 0xe5, 0x56, 0xf5, 0x53, 0xc2, 0xe1, 0x47, 0xa1, 0x3a, 0x8f,
+	0x86, 0xb3, 0x2c, 0xf1, 0x8f, 0x13, 0xb4, 0x8a, 0xe5, 0x62, 0x97, 0x7a, 0x4c, 0x8a, 0x7a, 0xc3,
+	0x31, 0x20, 0xf9, 0x0c, 0x2a, 0x61, 0xf4, 0x76, 0x06, 0xfb, 0x86, 0x64, 0xe4, 0x51, 0x3a, 0x65,
+	0xdf, 0xe7, 0x6c, 0x46, 0x13, 0xb7, 0xa4, 0x97, 0x23, 0xbb, 0xb4, 0x45, 0x91, 0xe3, 0x73, 0x23,
+	0x1c, 0xbf, 0x09, 0xab, 0x19, 0x06, 0xce, 0xc4, 0xf4, 0x4f, 0x60, 0xf1, 0x09, 0x13, 0x33, 0x31,
+	0x1c, 0x0f, 0xf4, 0xc9, 0x79, 0x0e, 0xf4, 0xef, 0xe7, 0xa0, 0x99, 0x30, 0xf3, 0xac, 0x93, 0xc4,
+	0x38, 0x44, 0x27, 0x03, 0xcb, 0x8a, 0xcd, 0x1a, 0x44, 0x5d, 0x8a, 0x06, 0xa2, 0x3f, 0x50, 0xce,
+	0x67, 0xc1, 0xd1, 0x90, 0xca, 0x8b, 0x78, 0x4c, 0x8d, 0x56, 0x35, 0x79, 0x11, 0x8f, 0xc9, 0xe1,
+	0xd6, 0xa0, 0xa6, 0x2e, 0xec, 0x35, 0x29, 0x06, 0x0a, 0xc0, 0x49, 0xa8, 0x10, 0xac, 0xd7, 0x57,
+	0xac, 0x5f, 0x74, 0x0c, 0x58, 0x70, 0x59, 0x73, 0xe7, 0x71, 0x59, 0xf7, 0x61, 0xfe, 0xd0, 0x0f,
+	0xfd, 0xf8, 0x58, 0xf5, 0x6d, 0x4c, 0xed, 0x0b, 0x86, 0xbc, 0x2d, 0xe3, 0x4d, 0x1a, 0x86, 0x91,
+	0xa0, 0x4a, 0x06, 0x9b, 0xea, 0x8e, 0x9c, 0x41, 0x91, 0xcf, 0xa1, 0x49, 0xb9, 0xf0, 0x0f, 0xa9,
+	0x2b, 0x62, 0x0b, 0xa4, 0x25, 0x58, 0xd6, 0x5c, 0x6e, 0x6b, 0xbc, 0x93, 0x52, 0xe0, 0x65, 0x87,
+	0xab, 0x63, 0xec, 0xfa, 0x2a, 0xad, 0xdd, 0x74, 0x9a, 0x1a, 0xf3, 0xd4, 0xc3, 0xcb, 0x8e, 0x49,
+	0xbe, 0xcb, 0xd5, 0x2e, 0x4c, 0xbf, 0xec, 0x24, 0xf4, 0x6d, 0x41, 0x96, 0xa0, 0xec, 0x7b, 0x32,
+	0xcf, 0xdd, 0x74, 0xca, 0xbe, 0x27, 0xc3, 0xdb, 0x63, 0xea, 0x45, 0x6f, 0xad, 0x25, 0x9d, 0x15,
+	0x96, 0x10, 0xe2, 0xb5, 0x97, 0x5d, 0x56, 0x61, 0xaf, 0x82, 0xc8, 0x57, 0x49, 0xc8, 0xbe, 0x22,
+	0x77, 0x72, 0xd9, 0xe4, 0xa1, 0x8c, 0x88, 0x4c, 0x8a, 0xda, 0x51, 0x6c, 0x4c, 0xe2, 0x63, 0x55,
+	0x1e, 0x29, 0xfc, 0x10, 0x1d, 0xbc, 0x51, 0x18, 0x74, 0x28, 0x98, 0x33, 0xb0, 0x88, 0xb4, 0xc0,
+	0xf2, 0x9b, 0xdc, 0x81, 0xb9, 0x1e, 0x13, 0xdc, 0x77, 0x31, 0x63, 0x8d, 0x73, 0x7d, 0x30, 0x32,
+	0xd7, 0x9e, 0x6a, 0x57, 0x93, 0x19, 0x6a, 0x9c, 0x4d, 0x65, 0x15, 0xba, 0xbe, 0x60, 0x3d, 0x6b,
+	0x4d, 0xa9, 0x98, 0x42, 0x3d, 0x15, 0xac, 0x97, 0x21, 0x88, 0xfd, 0x9f, 0x98, 0xb5, 0xae, 0xfc,
+	0xb3, 0x42, 0x75, 0xfc, 0x9f, 0x50, 0x25, 0x32, 0x57, 0x84, 0x0d, 0xc9, 0x80, 0x14, 0x21, 0x25,
+	0xfd, 0xc4, 0xef, 0xf7, 0x99, 0x67, 0x5d, 0xd4, 0x92, 0xae, 0x40, 0x34, 0xdc, 0x67, 0x5f, 0x0a,
+	0x26, 0x07, 0x94, 0xf7, 0x60, 0x21, 0xbb, 0x9b, 0x69, 0x7d, 0x4b, 0x59, 0xa3, 0xff, 0x27, 0xd0,
+	0x30, 0x92, 0x34, 0xd6, 0x35, 0xaf, 0x40, 0x65, 0xc0, 0x03, 0x73, 0x11, 0x18, 0xf0, 0x00, 0xa9,
+	0xe4, 0xd6, 0x55, 0xd8, 0x21, 0xbf, 0xb5, 0x28, 0xdc, 0xfa, 0xfa, 0xb6, 0xd6, 0x45, 0x0d, 0xd9,
+	0xdf, 0xc3, 0x5a, 0xc2, 0xf1, 0x47, 0x51, 0xc8, 0x8c, 0x91, 0xd9, 0x81, 0x66, 0x62, 0x7c, 0xb5,
+	0xf5, 0x58, 0x29, 0x9e, 0x90, 0x93, 0x92, 0xd8, 0x8f, 0x61, 0xbd, 0x30, 0x8e, 0x36, 0x40, 0x04,
+	0xaa, 0x78, 0x81, 0x33, 0x4b, 0xc6, 0xef, 0x6c, 0xdc, 0x52, 0x96, 0x46, 0xc3, 0x80, 0xf6, 0xef,
+	0xcb, 0xb0, 0xe8, 0x0c, 0xc2, 0xd9, 0xdc, 0x4b, 0x41, 0x3b, 0xcb, 0xa3, 0xda, 0x99, 0x57, 0xb7,
+	0x4a, 0x51, 0xdd, 0xb6, 0x13, 0xfd, 0xa8, 0xe6, 0x76, 0xd8, 0x91, 0x48, 0x67, 0x10, 0x26, 0x1a,
+	0x73, 0x37, 0xd1, 0x8c, 0x5a, 0xee, 0x12, 0x92, 0x5b, 0xeb, 0x38, 0xed, 0xf8, 0x19, 0x52, 0x63,
+	0xff, 0x53, 0x19, 0x9a, 0xc9, 0x52, 0x90, 0x4e, 0xde, 0x6b, 0xcc, 0x8d, 0x4a, 0x02, 0x64, 0x27,
+	0x77, 0xa3, 0x6a, 0x15, 0x37, 0x30, 0x72, 0x9b, 0xda, 0x9b, 0x14, 0xac, 0x7d, 0x3c, 0xd2, 0x75,
+	0x96, 0xbb, 0x47, 0x2e, 0x69, 0x5c, 0x2d, 0x24, 0x8d, 0xff, 0x2f, 0x53, 0x70, 0xe8, 0x0a, 0xcd,
+	0xe1, 0xcc, 0xe4, 0x0a, 0x3f, 0x87, 0x95, 0xd7, 0xd1, 0xd1, 0x51, 0x30, 0x5b, 0x68, 0x83, 0x8e,
+	0x3c, 0x43, 0x3e, 0xd3, 0x0c, 0x7b, 0xb0, 0xec, 0xb0, 0x78, 0x46, 0x57, 0x3e, 0x3d, 0x78, 0xbb,
+	0x01, 0x2b, 0xe9, 0x70, 0x33, 0x2d, 0xc0, 0x07, 0xab, 0xad, 0x94, 0x83, 0xa5, 0x3a, 0x3c, 0x7d,
+	0x25, 0x9a, 0xeb, 0xe5, 0x94, 0xeb, 0x05, 0xc5, 0xab, 0x8c, 0x28, 0x9e, 0xfd, 0x1c, 0x36, 0xc7,
+	0x4c, 0xa5, 0x57, 0x79, 0x5e, 0xdb, 0xf2, 0xef, 0x25, 0x80, 0xd7, 0x18, 0x68, 0x31, 0x0f, 0x4b,
+	0xae, 0x67, 0x07, 0xbd, 0x37, 0x00, 0x32, 0x51, 0x63, 0x79, 0xab, 0x32, 0x76, 0xf4, 0x0c, 0x0d,
+	0x06, 0x17, 0x9e, 0x8c, 0xc9, 0xa4, 0xcb, 0xad, 0x4c, 0x0f, 0x2e, 0x34, 0x75, 0x5b, 0xc6, 0x25,
+	0x99, 0x78, 0x71, 0x7a, 0x6a, 0xb2, 0xc9, 0x4c, 0xa8, 0x68, 0x5f, 0x97, 0x17, 0xae, 0x17, 0x7e,
+	0x8c, 0x29, 0x9a, 0xaa, 0xac, 0x3f, 0xab, 0x8b, 0x44, 0x76, 0x47, 0x12, 0x6f, 0xb7, 0x61, 0x31,
+	0x59, 0xb9, 0xec, 0x90, 0xdf, 0x63, 0x69, 0xfa, 0x1e, 0xed, 0x57, 0xb0, 0xea, 0xb0, 0x58, 0x44,
+	0x9c, 0xfd, 0x42, 0xd2, 0x77, 0x0b, 0x48, 0x76, 0xc0, 0x99, 0xe4, 0xef, 0x26, 0x90, 0x0e, 0x13,
+	0x0e, 0xa3, 0xde, 0xab, 0x30, 0x18, 0x9a, 0x55, 0x5c, 0xc2, 0x32, 0x23, 0xf5, 0xba, 0x51, 0x18,
+	0x0c, 0x4d, 0x7a, 0x9b, 0x6b, 0x1a, 0xfb, 0x16, 0x5c, 0xc8, 0x75, 0xd1, 0xf3, 0x9c, 0xd9, 0xe7,
+	0x77, 0x25, 0x58, 0xea, 0xe8, 0xa8, 0x68, 0x8f, 0xba, 0x3c, 0xc2, 0x23, 0xae, 0xf7, 0xe4, 0x97,
+	0x55, 0xca, 0x65, 0x59, 0xf2, 0x64, 0x3b, 0xea, 0x47, 0xdb, 0x6f, 0xd5, 0x01, 0xed, 0x77, 0x06,
+	0x7d, 0x2e, 0x13, 0xd4, 0x06, 0x92, 0x11, 0x7e, 0x7d, 0xc7, 0x21, 0x9f, 0xc2, 0xea, 0xe8, 0x75,
+	0xa8, 0x24, 0x5d, 0xf5, 0x0a, 0x2f, 0xdc, 0x84, 0xec, 0xff, 0x2c, 0xc3, 0xea, 0x1e, 0xf5, 0x43,
+	0xc1, 0x42, 0x1a, 0xba, 0xec, 0xb7, 0x7e, 0x88, 0xde, 0x68, 0x5c, 0x18, 0x70, 0x3b, 0xe7, 0x08,
+	0xec, 0x24, 0x21, 0x59, 0xe8, 0x3b, 0xe2, 0x10, 0xce, 0x7a, 0xff, 0x91, 0x7d, 0x37, 0x52, 0x1d,
+	0x7d, 0x37, 0x92, 0xe4, 0x37, 0x6a, 0xaa, 0xcd, 0xc0, 0xe4, 0x06, 0xd4, 0x54, 0xb2, 0x7e, 0xfa,
+	0x0d, 0x4a, 0x11, 0xe2, 0x65, 0x8d, 0x85, 0xde, 0x0c, 0x91, 0x3d, 0x92, 0xc9, 0x52, 0x42, 0x14,
+	0xf8, 0xee, 0x50, 0x3f, 0x3e, 0xd1, 0xd0, 0x7b, 0xfb, 0x1b, 0xfb, 0x15, 0x5c, 0xea, 0x30, 0x31,
+	0xc2, 0x2c, 0x23, 0xa2, 0x37, 0xa0, 0xfe, 0x56, 0x22, 0xb4, 0x64, 0x5b, 0x93, 0xb8, 0xeb, 0x68,
+	0x3a, 0x7b, 0x1f, 0x2e, 0x8f, 0x1f, 0x50, 0x0b, 0xf0, 0xf9, 0x47, 0xfc, 0x0a, 0x3e, 0x54, 0x37,
+	0xc7, 0x89, 0xab, 0x1c, 0x23, 0x15, 0x76, 0x07, 0xae, 0x4c, 0xec, 0xf5, 0xde, 0x4b, 0xf9, 0x97,
+	0x32, 0xcc, 0x75, 0xfc, 0x80, 0x85, 0x2e, 0xd3, 0x57, 0x8e, 0x52, 0x72, 0xe5, 0x58, 0x51, 0x16,
+	0x40, 0x3b, 0x0b, 0x34, 0xc8, 0x77, 0x33, 0x4f, 0x50, 0x2a, 0xb9, 0x6b, 0x85, 0x1e, 0x63, 0xe2,
+	0x33, 0x94, 0x3b, 0xa0, 0xee, 0x71, 0x33, 0x1a, 0xd7, 0x86, 0x22, 0xce, 0xa7, 0x29, 0x6b, 0x33,
+	0xa7, 0x29, 0x37, 0xa0, 0xce, 0x19, 0x8d, 0xa3, 0x50, 0x4a, 0x6d, 0xd3, 0xd1, 0x10, 0xe2, 0xe9,
+	0x40, 0x1c, 0x47, 0xe6, 0x15, 0x94, 0x86, 0x7e, 0x56, 0x8d, 0xcf, 0xfe, 0x16, 0x56, 0x3b, 0x4c,
+	0x68, 0x06, 0x98, 0x03, 0xdc, 0x86, 0xb9, 0x58, 0x61, 0xac, 0x52, 0xae, 0x72, 0x61, 0xe8, 0x4c,
+	0xb3, 0xfd, 0x9d, 0xb4, 0xa4, 0x49, 0x77, 0x7d, 0x92, 0xb3, 0xf7, 0xbf, 0x06, 0x6b, 0x4a, 0x2c,
+	0x0a, 0x2b, 0x28, 0x9c, 0xa6, 0xdd, 0x86, 0xf5, 0x02, 0xdd, 0xb9, 0xa7, 0xfa, 0x43, 0x09, 0x60,
+	0x37, 0x29, 0x2a, 0x8f, 0x35, 0x5d, 0x04, 0xaa, 0xd8, 0xd9, 0xd4, 0x18, 0xf0, 0x1b, 0x71, 0x5a,
+	0x62, 0xf0, 0x7e, 0x20, 0xbf, 0x11, 0x27, 0xfd, 0xa4, 0xca, 0x66, 0xcb, 0xef, 0xcc, 0xe9, 0xd4,
+	0xb2, 0xa7, 0x83, 0x9e, 0x39, 0xf3, 0x50, 0x64, 0xba, 0x1d, 0x4a, 0xdf, 0x8a, 0xd8, 0x4f, 0x61,
+	0xad, 0xc3, 0x44, 0xba, 0x66, 0xc3, 0x9c, 0x9b, 0xf2, 0x0d, 0x89, 0x46, 0xea, 0x6d, 0xaf, 0x9a,
+	0xfc, 0x74, 0x4a, 0x9d, 0x21, 0xb2, 0x9f, 0xc1, 0x7a, 0x61, 0x28, 0xcd, 0xbf, 0xf7, 0x18, 0xeb,
+	0x73, 0xb8, 0xa8, 0xce, 0x62, 0x74, 0x65, 0xe3, 0x34, 0x7f, 0x0f, 0xac, 0x51, 0xf2, 0xf7, 0x9f,
+	0xfd, 0xdf, 0x4a, 0xb0, 0xbc, 0x1b, 0xf5, 0xfa, 0x81, 0x8f, 0x06, 0xe1, 0xb1, 0xac, 0xe6, 0x14,
+	0x75, 0x1f, 0xcf, 0x42, 0xbd, 0xd7, 0xd0, 0x15, 0x5e, 0x05, 0xe5, 0xe2, 0x8c, 0x4a, 0x3e, 0xce,
+	0x50, 0xe5, 0x59, 0x53, 0x97, 0x92, 0xdf, 0x19, 0x45, 0xac, 0xe5, 0x14, 0xf1, 0x13, 0x28, 0xcf,
+	0x74, 0x94, 0x65, 0x2a, 0xab, 0x5e, 0x99, 0x08, 0x69, 0x4e, 0xe7, 0xe8, 0xd3, 0x78, 0xa8, 0x0d,
+	0xab, 0xe9, 0x6e, 0x0c, 0x1b, 0x3f, 0xcb, 0xd6, 0xac, 0xe6, 0x6f, 0x6d, 0x18, 0x8e, 0xe4, 0xb7,
+	0xad, 0x6b, 0x59, 0xf6, 0x43, 0x20, 0xd9, 0x21, 0x34, 0x6b, 0xcf, 0x37, 0xc6, 0x5f, 0x65, 0x42,
+	0x15, 0x7e, 0x3e, 0xa6, 0x1a, 0xce, 0x55, 0xc6, 0x72, 0xae, 0x3a, 0x86, 0x73, 0xb5, 0x59, 0x38,
+	0x67, 0x3f, 0x01, 0x0b, 0x4d, 0x8b, 0x59, 0xd4, 0x3e, 0x1d, 0xc4, 0x09, 0x83, 0x3e, 0xcd, 0x6f,
+	0x6e, 0xbd, 0x10, 0x45, 0xf1, 0xdc, 0xde, 0xfe, 0x3f, 0x6c, 0x8e, 0x19, 0x48, 0xb3, 0xe9, 0x5c,
+	0x23, 0x3d, 0x80, 0xb5, 0xdd, 0xa8, 0xd7, 0xf3, 0x05, 0xbe, 0x6b, 0x3b, 0x62, 0xb1, 0x59, 0x0e,
+	0xa6, 0x1e, 0x0f, 0x0f, 0x63, 0xa6, 0x46, 0xa9, 0x3a, 0x1a, 0x42, 0x43, 0x1c, 0xb3, 0x1f, 0x25,
+	0xbf, 0x16, 0x1d, 0xfc, 0xb4, 0xff, 0xac, 0x0a, 0x4b, 0x8f, 0xfc, 0xb8, 0x4f, 0x85, 0x7b, 0x8c,
+	0x6f, 0x7a, 0xc2, 0x33, 0x83, 0xdf, 0x24, 0x3b, 0x59, 0xce, 0x66, 0x27, 0xa7, 0xe4, 0x12, 0x6e,
+	0x67, 0x6b, 0x6d, 0x69, 0x82, 0x20, 0x3f, 0xeb, 0xce, 0x4b, 0x24, 0x51, 0x7e, 0x2e, 0xad, 0xc6,
+	0x65, 0x5e, 0xc2, 0xcd, 0x50, 0x8d, 0x4b, 0x1f, 0xc3, 0x7d, 0x93, 0x24, 0x25, 0xea, 0xb9, 0xa8,
+	0xb6, 0x30, 0xe7, 0x84, 0x9c, 0x5d, 0x36, 0x8b, 0x36, 0x37, 0x2d, 0x8b, 0xd6, 0x38, 0x3b, 0x8b,
+	0xd6, 0x2c, 0x66, 0xd1, 0xb0, 0xea, 0xac, 0x57, 0xc1, 0x3c, 0x99, 0x17, 0x6d, 0x3a, 0x19, 0x4c,
+	0xeb, 0x2e, 0x40, 0xca, 0x8a, 0xf3, 0xd6, 0x66, 0xdf, 0x37, 0x9f, 0x12, 0xc1, 0x25, 0x65, 0x12,
+	0xf3, 0x0c, 0x9a, 0xe1, 0x3a, 0x34, 0x5e, 0x22, 0x0a, 0x4c, 0xac, 0x14, 0x99, 0x88, 0x37, 0x91,
+	0xf5, 0x3d, 0xca, 0x4f, 0x1e, 0x25, 0x1b, 0xff, 0x5f, 0x9b, 0xeb, 0xcc, 0x7c, 0xbb, 0xfd, 0xbb,
+	0x2a, 0x34, 0x1e, 0x52, 0xf7, 0xe4, 0xd0, 0x0f, 0x82, 0x11, 0x0b, 0x93, 0x5d, 0x4b, 0x39, 0xbf,
+	0x96, 0x1d, 0x9d, 0xbc, 0x9b, 0x7e, 0x29, 0x96, 0x74, 0x68, 0x68, 0x44, 0x34, 0x43, 0xa8, 0x56,
+	0x16, 0x51, 0xf1, 0x2d, 0x47, 0x6d, 0xf4, 0x2d, 0x47, 0xfa, 0xa8, 0xb9, 0x9e, 0x7b, 0xd4, 0xbc,
+	0x06, 0x35, 0x59, 0x4a, 0xd5, 0x76, 0x5d, 0x01, 0x05, 0x91, 0xd3, 0x02, 0x9b, 0x62, 0x64, 0xaa,
+	0x6a, 0xe0, 0xaa, 0xd7, 0x3a, 0xfa, 0x45, 0x7a, 0x8a, 0xc0, 0xb9, 0xf0, 0xa9, 0xae, 0x14, 0x56,
+	0x6c, 0xd2, 0x10, 0xb9, 0x0d, 0x8d, 0x7e, 0x14, 0xfb, 0xd2, 0x00, 0xcf, 0x4f, 0x0f, 0x41, 0x0d,
+	0x6d, 0xc1, 0x5a, 0x2c, 0x14, 0xad, 0x45, 0x5e, 0xeb, 0x17, 0xcf, 0xa3, 0xf5, 0x85, 0x82, 0xc6,
+	0xd2, 0x79, 0x0a, 0x1a, 0xf6, 0x77, 0xb0, 0x6c, 0xe4, 0x20, 0x35, 0xea, 0x8d, 0x03, 0x8d, 0xd2,
+	0xd6, 0xd8, 0x14, 0x30, 0x12, 0xca, 0x84, 0xc0, 0xfe, 0x0d, 0xac, 0xa4, 0xfd, 0x13, 0x5b, 0x7e,
+	0x8e, 0x01, 0x1e, 0xc2, 0xfa, 0x2e, 0xba, 0xc1, 0xa0, 0xb8, 0x8c, 0x33, 0x34, 0x42, 0x09, 0x6c,
+	0x39, 0x89, 0x4a, 0x1f, 0xc3, 0x46, 0x71, 0x8c, 0xf7, 0x59, 0xca, 0x3f, 0x94, 0xa0, 0xfa, 0x22,
+	0x72, 0x4f, 0xc6, 0xc6, 0xa4, 0x1b, 0x50, 0x3f, 0x8e, 0x02, 0x8f, 0x99, 0x72, 0xb7, 0x86, 0x90,
+	0xfb, 0xd4, 0xfd, 0x71, 0xe0, 0xf3, 0x59, 0xb3, 0x45, 0x60, 0xc8, 0x7f, 0x5e, 0xba, 0x68, 0x08,
+	0xa4, 0xad, 0x06, 0xc2, 0x25, 0x1b, 0xa6, 0x5d, 0x81, 0x2a, 0x3e, 0xe9, 0xd6, 0x7b, 0x9d, 0xd7,
+	0x7b, 0x95, 0x14, 0xb2, 0xc1, 0x14, 0x41, 0xcb, 0xb3, 0x15, 0x41, 0xd7, 0xa0, 0xc6, 0x59, 0xc8,
+	0xde, 0xea, 0x62, 0xab, 0x02, 0xec, 0xdb, 0x70, 0x21, 0x37, 0xb5, 0xe6, 0xf5, 0xb4, 0xb9, 0xed,
+	0x07, 0x40, 0x1c, 0x16, 0x30, 0x1a, 0xe7, 0x96, 0x7c, 0x0e, 0x66, 0xdb, 0x7f, 0x5e, 0x82, 0xf2,
+	0xf3, 0x37, 0xa8, 0xb9, 0x48, 0x16, 0xf7, 0x69, 0xf2, 0x0c, 0x2a, 0x45, 0x8c, 0x49, 0x4f, 0x26,
+	0x1e, 0x40, 0x5d, 0x1e, 0x14, 0x50, 0xb8, 0x11, 0x54, 0xcf, 0x73, 0x23, 0xb8, 0x0e, 0x0b, 0x1d,
+	0x26, 0x9e, 0xbf, 0x49, 0x65, 0xb5, 0x7c, 0x72, 0xaa, 0x37, 0xde, 0xd4, 0x1b, 0x7f, 0xfe, 0xc6,
+	0x29, 0x9f, 0x9c, 0xda, 0x6d, 0x58, 0x56, 0x3e, 0x26, 0xa5, 0x3e, 0xe7, 0xf2, 0xed, 0xeb, 0x98,
+	0xab, 0xa3, 0xde, 0xd3, 0xd0, 0x63, 0xef, 0x12, 0x6e, 0xaf, 0x41, 0xcd, 0x47, 0x84, 0x0e, 0x75,
+	0x14, 0x60, 0xbf, 0x80, 0x85, 0x8e, 0x88, 0x38, 0xdb, 0xe7, 0xd1, 0x41, 0xc0, 0x7a, 0xc8, 0xdc,
+	0x13, 0x3f, 0x34, 0xc6, 0x5d, 0x7e, 0x8f, 0xe1, 0xcf, 0x06, 0xd4, 0x3d, 0x26, 0xf0, 0x75, 0x88,
+	0x72, 0x23, 0x1a, 0xb2, 0x5f, 0xc0, 0xea, 0x2e, 0x3e, 0x2a, 0x94, 0x43, 0x66, 0x82, 0x2c, 0xce,
+	0xfa, 0xd4, 0xe7, 0x3a, 0xcf, 0xa6, 0xa1, 0xe9, 0x19, 0xc2, 0xff, 0x28, 0x01, 0xc9, 0x0e, 0xa7,
+	0x37, 0x72, 0x15, 0x96, 0x30, 0xbf, 0xd4, 0xa3, 0x49, 0xc1, 0x50, 0x3d, 0x76, 0x59, 0x54, 0xd8,
+	0x4c, 0xcd, 0x50, 0xde, 0xf5, 0xd4, 0xf3, 0x1a, 0xf9, 0x8d, 0xcf, 0x73, 0xcc, 0x3f, 0x80, 0xd4,
+	0x1f, 0x76, 0xd4, 0x73, 0xa7, 0x05, 0x83, 0x94, 0xff, 0xd7, 0xc9, 0x47, 0xfe, 0xd5, 0x62, 0xe4,
+	0x4f, 0xbe, 0xc0, 0xb7, 0xc8, 0x92, 0x5b, 0xa6, 0x96, 0x63, 0x1e, 0xef, 0x65, 0x39, 0xe9, 0x24,
+	0x44, 0xea, 0x59, 0x27, 0x6e, 0x39, 0x79, 0x2e, 0x9a, 0xc0, 0xf6, 0xdf, 0x94, 0x00, 0x1c, 0x7a,
+	0x28, 0xf0, 0xb9, 0x1e, 0xe3, 0x23, 0x9e, 0x15, 0x65, 0x3d, 0xf2, 0x92, 0x8b, 0x2d, 0x7e, 0xcb,
+	0x22, 0xb7, 0xe7, 0x71, 0x96, 0x3e, 0x31, 0xd1, 0xa0, 0xfc, 0xb7, 0x06, 0xa3, 0x9e, 0xbe, 0x0d,
+	0x35, 0x1c, 0x0d, 0x49, 0x71, 0x8e, 0x04, 0xe3, 0xfa, 0xcd, 0x8e, 0x02, 0x90, 0x19, 0x9c, 0x1e,
+	0x8a, 0xae, 0x94, 0x5c, 0x37, 0x0a, 0xb4, 0x8f, 0x5c, 0x40, 0xe4, 0xbe, 0xc6, 0xd9, 0x14, 0x2e,
+	0xe3, 0xf2, 0x9e, 0x30, 0xa1, 0xca, 0x28, 0x3a, 0x41, 0x97, 0xb1, 0x97, 0xf2, 0x3d, 0x21, 0xe3,
+	0x26, 0x31, 0x6a, 0x6e, 0x81, 0xe9, 0xa6, 0x1c, 0x43, 0x91, 0x8a, 0x60, 0x39, 0x2b, 0x82, 0x9f,
+	0xc2, 0x26, 0x12, 0x3b, 0xac, 0x17, 0x9d, 0xb2, 0x7d, 0xc6, 0xf8, 0xc3, 0xe1, 0xd3, 0x47, 0x93,
+	0xf2, 0x09, 0x0f, 0x60, 0xa9, 0x7d, 0xc4, 0x42, 0xe1, 0x0c, 0xc2, 0x8e, 0xe0, 0x8c, 0xf6, 0xce,
+	0x5d, 0x0b, 0x78, 0x00, 0x2b, 0x66, 0x84, 0xf7, 0x2c, 0x31, 0xbe, 0x82, 0x4b, 0x4f, 0x98, 0xc0,
+	0xbf, 0x10, 0x9c, 0xa6, 0xb5, 0x89, 0x38, 0x93, 0x0e, 0x3b, 0x6f, 0x6e, 0xfd, 0x0f, 0x25, 0x58,
+	0x4e, 0xd7, 0x34, 0xcb, 0xc3, 0x9c, 0xdc, 0xa6, 0xcb, 0x53, 0x37, 0x8d, 0xbe, 0xf1, 0xe4, 0x54,
+	0x2b, 0x9a, 0x16, 0x9a, 0x93, 0x53, 0xa9, 0x65, 0xe4, 0xcb, 0xfc, 0x2b, 0xfe, 0xea, 0x56, 0x65,
+	0xfc, 0x5d, 0x3e, 0x4b, 0x65, 0x5f, 0x87, 0x0b, 0x0e, 0x43, 0x66, 0xa8, 0xc7, 0x4a, 0x19, 0xd3,
+	0x2c, 0xdf, 0x7a, 0x96, 0xd2, 0xb7, 0x9e, 0x36, 0x87, 0xb5, 0x3c, 0x69, 0xca, 0xf3, 0x99, 0xf2,
+	0x38, 0x69, 0xdd, 0xb9, 0x92, 0xad, 0x3b, 0x6b, 0xad, 0x0a, 0xa8, 0xcb, 0x3c, 0x2d, 0xee, 0x09,
+	0x7c, 0xeb, 0x6f, 0x57, 0xa1, 0xf6, 0x08, 0xff, 0x1f, 0x49, 0xbe, 0x86, 0xba, 0x7a, 0xcf, 0x42,
+	0xcc, 0xdf, 0x1f, 0x72, 0x4f, 0x61, 0x5a, 0xeb, 0x05, 0xac, 0x5e, 0xdc, 0x33, 0x58, 0xcc, 0x15,
+	0xa3, 0xc9, 0xa5, 0x22, 0x77, 0x33, 0xa5, 0xee, 0xd6, 0xe5, 0xf1, 0x8d, 0x7a, 0xac, 0x3b, 0x50,
+	0x7b, 0xc1, 0xe8, 0x29, 0x23, 0x1b, 0x23, 0xbe, 0xe2, 0x31, 0xfe, 0xfd, 0xb2, 0x35, 0x01, 0x8f,
+	0x6b, 0xef, 0xe4, 0xd7, 0xde, 0x19, 0xbb, 0xf6, 0xc2, 0x0b, 0xac, 0xef, 0xa0, 0x99, 0xbc, 0x10,
+	0x22, 0xe6, 0xaf, 0x4d, 0xc5, 0x47, 0x57, 0x2d, 0x6b, 0xb4, 0x41, 0xf7, 0xff, 0x1a, 0xea, 0xaa,
+	0xee, 0x99, 0x4c, 0x9b, 0xab, 0x51, 0xb7, 0xd6, 0x0b, 0xd8, 0x74, 0xda, 0xa4, 0x9e, 0x99, 0x4c,
+	0x5b, 0x2c, 0x88, 0xb6, 0xac, 0xd1, 0x06, 0xdd, 0xbf, 0x03, 0x6b, 0xe3, 0x2c, 0xcd, 0x44, 0xae,
+	0x7d, 0x94, 0x31, 0x34, 0x13, 0xcd, 0xd3, 0x4b, 0x20, 0xa3, 0xb6, 0x85, 0x6c, 0x65, 0xba, 0x8e,
+	0x35, 0x3b, 0x13, 0x8f, 0xe4, 0x8f, 0xe0, 0xc2, 0x18, 0xd5, 0x9f, 0xb8, 0x46, 0x3b, 0x95, 0xae,
+	0x89, 0xe6, 0xe2, 0xae, 0x0c, 0x0d, 0x92, 0x06, 0x32, 0xa2, 0xc7, 0x13, 0x17, 0x73, 0x1f, 0x1a,
+	0xa6, 0x7e, 0x4b, 0x4c, 0x9a, 0xa8, 0x50, 0x1f, 0x6e, 0x5d, 0x1c, 0xc1, 0xeb, 0x69, 0xdb, 0x00,
+	0xa9, 0x6f, 0x25, 0xe6, 0x58, 0x46, 0xbc, 0x77, 0x6b, 0x73, 0x4c, 0x8b, 0x1e, 0xe2, 0x11, 0xcc,
+	0x67, 0x4a, 0x6b, 0x64, 0x33, 0x15, 0xc7, 0x42, 0x85, 0xae, 0xd5, 0x1a, 0xd7, 0x94, 0x2e, 0x24,
+	0xad, 0x03, 0x26, 0x0b, 0x19, 0xa9, 0x35, 0xb6, 0x36, 0xc7, 0xb4, 0xe8, 0x21, 0xba, 0x32, 0xdf,
+	0x3a, 0x5a, 0xe5, 0xb2, 0xd3, 0x69, 0x27, 0xd5, 0x3c, 0x5a, 0x1f, 0x9d, 0x49, 0xa3, 0x27, 0x38,
+	0x36, 0x99, 0xd3, 0xd1, 0x39, 0xae, 0xe6, 0xf4, 0x68, 0xe2, 0x34, 0xd7, 0xa6, 0x91, 0xe9, 0x99,
+	0xee, 0x67, 0xae, 0xd9, 0x1b, 0xc5, 0x9b, 0x47, 0xe1, 0x4c, 0x47, 0x2e, 0x2f, 0x7b, 0xb0, 0x94,
+	0xbf, 0xd6, 0x90, 0xcb, 0xe9, 0xeb, 0xe7, 0xd1, 0x1b, 0x53, 0xeb, 0x83, 0x09, 0xad, 0xe9, 0xf9,
+	0x66, 0xc2, 0xf6, 0xe4, 0x7c, 0x47, 0x6f, 0x11, 0xad, 0xd6, 0xb8, 0x26, 0x3d, 0xca, 0x03, 0x98,
+	0xcf, 0x04, 0xf1, 0x24, 0x3d, 0xc6, 0x62, 0x60, 0x3f, 0x51, 0xce, 0xbf, 0x82, 0x9a, 0x0c, 0x9e,
+	0xc9, 0x85, 0xf4, 0xac, 0x9e, 0xbf, 0x99, 0xd6, 0xeb, 0x1e, 0x34, 0x4c, 0x1c, 0x9d, 0x70, 0xb2,
+	0x10, 0x58, 0x4f, 0xec, 0xfb, 0x2d, 0x34, 0x93, 0x00, 0x7a, 0xa2, 0x72, 0xa7, 0xa2, 0x5a, 0x0c,
+	0xb5, 0xdb, 0x00, 0x69, 0x71, 0x25, 0x11, 0xe9, 0x91, 0x72, 0x4d, 0x6b, 0x73, 0x4c, 0x4b, 0xea,
+	0x80, 0x72, 0x75, 0x93, 0xc4, 0x01, 0x8d, 0xab, 0xba, 0xb4, 0x2e, 0x8f, 0x6f, 0xcc, 0xa8, 0x7a,
+	0x92, 0x3d, 0x4e, 0x55, 0xbd, 0x98, 0xbd, 0x6e, 0x6d, 0x8e, 0x69, 0x49, 0x97, 0x93, 0x2b, 0x43,
+	0x24, 0xcb, 0x19, 0x57, 0xe7, 0x68, 0x5d, 0x1e, 0xdf, 0x98, 0x18, 0xfa, 0x95, 0x62, 0x5d, 0x81,
+	0x7c, 0x98, 0xdb, 0xc0, 0xe8, 0x88, 0x57, 0x26, 0xb6, 0xeb, 0x41, 0xdf, 0xa8, 0x72, 0x58, 0x2e,
+	0x57, 0x4c, 0xae, 0x64, 0xf8, 0x3b, 0x2e, 0x1d, 0xdd, 0xda, 0x9a, 0x4c, 0x90, 0x8e, 0x3b, 0xf2,
+	0x0c, 0x25, 0x19, 0x77, 0xd2, 0x5b, 0x98, 0xd6, 0xd6, 0x64, 0x02, 0x35, 0xee, 0xad, 0xbf, 0x2c,
+	0x41, 0x4d, 0x86, 0x7c, 0xa8, 0xf1, 0x26, 0xf6, 0x4b, 0xe4, 0xb4, 0x10, 0x0c, 0xb6, 0xd6, 0x0b,
+	0x78, 0x15, 0xfa, 0xde, 0x28, 0x91, 0x27, 0xb0, 0x90, 0x0d, 0xae, 0x48, 0x2b, 0xd5, 0xae, 0x62,
+	0x70, 0xd6, 0xba, 0x34, 0xb6, 0x4d, 0xad, 0xe7, 0xa0, 0x2e, 0x85, 0xfb, 0xcb, 0xff, 0x19, 0x00,
+	0xfb, 0x4f, 0x48, 0xaa, 0x57, 0x42, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DkronClient is the client API for Dkron service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DkronClient interface {
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
+	ExecutionDone(ctx context.Context, in *ExecutionDoneRequest, opts ...grpc.CallOption) (*ExecutionDoneResponse, error)
+	Leave(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetJob(ctx context.Context, in *SetJobRequest, opts ...grpc.CallOption) (*SetJobResponse, error)
+	DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error)
+	RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error)
+	ToggleJob(ctx context.Context, in *ToggleJobRequest, opts ...grpc.CallOption) (*ToggleJobResponse, error)
+	RaftGetConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RaftGetConfigurationResponse, error)
+	RaftRemovePeerByID(ctx context.Context, in *RaftRemovePeerByIDRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetActiveExecutions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetActiveExecutionsResponse, error)
+	SetExecution(ctx context.Context, in *Execution, opts ...grpc.CallOption) (*empty.Empty, error)
+	ResetJob(ctx context.Context, in *ResetJobRequest, opts ...grpc.CallOption) (*ResetJobResponse, error)
+	CheckStore(ctx context.Context, in *CheckStoreRequest, opts ...grpc.CallOption) (*CheckStoreResponse, error)
+	SetReadOnly(ctx context.Context, in *SetReadOnlyRequest, opts ...grpc.CallOption) (*SetReadOnlyResponse, error)
+	RestoreJob(ctx context.Context, in *RestoreJobRequest, opts ...grpc.CallOption) (*RestoreJobResponse, error)
+	SetMaintenanceWindow(ctx context.Context, in *SetMaintenanceWindowRequest, opts ...grpc.CallOption) (*SetMaintenanceWindowResponse, error)
+	DeleteMaintenanceWindow(ctx context.Context, in *DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*DeleteMaintenanceWindowResponse, error)
+	Backfill(ctx context.Context, in *BackfillRequest, opts ...grpc.CallOption) (*BackfillResponse, error)
+	CancelBackfill(ctx context.Context, in *CancelBackfillRequest, opts ...grpc.CallOption) (*CancelBackfillResponse, error)
+	AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error)
+	ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetKV(ctx context.Context, in *SetKVRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeleteKV(ctx context.Context, in *DeleteKVRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ReadIndex(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReadIndexResponse, error)
+	SetSilence(ctx context.Context, in *SetSilenceRequest, opts ...grpc.CallOption) (*SetSilenceResponse, error)
+	DeleteSilence(ctx context.Context, in *DeleteSilenceRequest, opts ...grpc.CallOption) (*DeleteSilenceResponse, error)
+	Compliance(ctx context.Context, in *ComplianceRequest, opts ...grpc.CallOption) (*ComplianceResponse, error)
+	SetCredential(ctx context.Context, in *SetCredentialRequest, opts ...grpc.CallOption) (*SetCredentialResponse, error)
+	DeleteCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*DeleteCredentialResponse, error)
+	SetSchedulerPause(ctx context.Context, in *SetSchedulerPauseRequest, opts ...grpc.CallOption) (*SetSchedulerPauseResponse, error)
+	AnnotateExecution(ctx context.Context, in *AnnotateExecutionRequest, opts ...grpc.CallOption) (*AnnotateExecutionResponse, error)
+}
+
+type dkronClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDkronClient(cc grpc.ClientConnInterface) DkronClient {
+	return &dkronClient{cc}
+}
+
+func (c *dkronClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
+	out := new(GetJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) ExecutionDone(ctx context.Context, in *ExecutionDoneRequest, opts ...grpc.CallOption) (*ExecutionDoneResponse, error) {
+	out := new(ExecutionDoneResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/ExecutionDone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) Leave(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/types.Dkron/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetJob(ctx context.Context, in *SetJobRequest, opts ...grpc.CallOption) (*SetJobResponse, error) {
+	out := new(SetJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error) {
+	out := new(DeleteJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/DeleteJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error) {
+	out := new(RunJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/RunJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) ToggleJob(ctx context.Context, in *ToggleJobRequest, opts ...grpc.CallOption) (*ToggleJobResponse, error) {
+	out := new(ToggleJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/ToggleJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) RaftGetConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RaftGetConfigurationResponse, error) {
+	out := new(RaftGetConfigurationResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/RaftGetConfiguration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) RaftRemovePeerByID(ctx context.Context, in *RaftRemovePeerByIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/types.Dkron/RaftRemovePeerByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) GetActiveExecutions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetActiveExecutionsResponse, error) {
+	out := new(GetActiveExecutionsResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/GetActiveExecutions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetExecution(ctx context.Context, in *Execution, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetExecution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) ResetJob(ctx context.Context, in *ResetJobRequest, opts ...grpc.CallOption) (*ResetJobResponse, error) {
+	out := new(ResetJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/ResetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) CheckStore(ctx context.Context, in *CheckStoreRequest, opts ...grpc.CallOption) (*CheckStoreResponse, error) {
+	out := new(CheckStoreResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/CheckStore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetReadOnly(ctx context.Context, in *SetReadOnlyRequest, opts ...grpc.CallOption) (*SetReadOnlyResponse, error) {
+	out := new(SetReadOnlyResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetReadOnly", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) RestoreJob(ctx context.Context, in *RestoreJobRequest, opts ...grpc.CallOption) (*RestoreJobResponse, error) {
+	out := new(RestoreJobResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/RestoreJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetMaintenanceWindow(ctx context.Context, in *SetMaintenanceWindowRequest, opts ...grpc.CallOption) (*SetMaintenanceWindowResponse, error) {
+	out := new(SetMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetMaintenanceWindow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteMaintenanceWindow(ctx context.Context, in *DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*DeleteMaintenanceWindowResponse, error) {
+	out := new(DeleteMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/DeleteMaintenanceWindow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) Backfill(ctx context.Context, in *BackfillRequest, opts ...grpc.CallOption) (*BackfillResponse, error) {
+	out := new(BackfillResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/Backfill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) CancelBackfill(ctx context.Context, in *CancelBackfillRequest, opts ...grpc.CallOption) (*CancelBackfillResponse, error) {
+	out := new(CancelBackfillResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/CancelBackfill", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error) {
+	out := new(AcquireLockResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/AcquireLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/types.Dkron/ReleaseLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetKV(ctx context.Context, in *SetKVRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetKV", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteKV(ctx context.Context, in *DeleteKVRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/types.Dkron/DeleteKV", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) ReadIndex(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ReadIndexResponse, error) {
+	out := new(ReadIndexResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/ReadIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetSilence(ctx context.Context, in *SetSilenceRequest, opts ...grpc.CallOption) (*SetSilenceResponse, error) {
+	out := new(SetSilenceResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetSilence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteSilence(ctx context.Context, in *DeleteSilenceRequest, opts ...grpc.CallOption) (*DeleteSilenceResponse, error) {
+	out := new(DeleteSilenceResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/DeleteSilence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) Compliance(ctx context.Context, in *ComplianceRequest, opts ...grpc.CallOption) (*ComplianceResponse, error) {
+	out := new(ComplianceResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/Compliance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetCredential(ctx context.Context, in *SetCredentialRequest, opts ...grpc.CallOption) (*SetCredentialResponse, error) {
+	out := new(SetCredentialResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) DeleteCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*DeleteCredentialResponse, error) {
+	out := new(DeleteCredentialResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/DeleteCredential", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) SetSchedulerPause(ctx context.Context, in *SetSchedulerPauseRequest, opts ...grpc.CallOption) (*SetSchedulerPauseResponse, error) {
+	out := new(SetSchedulerPauseResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/SetSchedulerPause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dkronClient) AnnotateExecution(ctx context.Context, in *AnnotateExecutionRequest, opts ...grpc.CallOption) (*AnnotateExecutionResponse, error) {
+	out := new(AnnotateExecutionResponse)
+	err := c.cc.Invoke(ctx, "/types.Dkron/AnnotateExecution", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+// DkronServer is the server API for Dkron service.
+type DkronServer interface {
+	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
+	ExecutionDone(context.Context, *ExecutionDoneRequest) (*ExecutionDoneResponse, error)
+	Leave(context.Context, *empty.Empty) (*empty.Empty, error)
+	SetJob(context.Context, *SetJobRequest) (*SetJobResponse, error)
+	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
+	RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error)
+	ToggleJob(context.Context, *ToggleJobRequest) (*ToggleJobResponse, error)
+	RaftGetConfiguration(context.Context, *empty.Empty) (*RaftGetConfigurationResponse, error)
+	RaftRemovePeerByID(context.Context, *RaftRemovePeerByIDRequest) (*empty.Empty, error)
+	GetActiveExecutions(context.Context, *empty.Empty) (*GetActiveExecutionsResponse, error)
+	SetExecution(context.Context, *Execution) (*empty.Empty, error)
+	ResetJob(context.Context, *ResetJobRequest) (*ResetJobResponse, error)
+	CheckStore(context.Context, *CheckStoreRequest) (*CheckStoreResponse, error)
+	SetReadOnly(context.Context, *SetReadOnlyRequest) (*SetReadOnlyResponse, error)
+	RestoreJob(context.Context, *RestoreJobRequest) (*RestoreJobResponse, error)
+	SetMaintenanceWindow(context.Context, *SetMaintenanceWindowRequest) (*SetMaintenanceWindowResponse, error)
+	DeleteMaintenanceWindow(context.Context, *DeleteMaintenanceWindowRequest) (*DeleteMaintenanceWindowResponse, error)
+	Backfill(context.Context, *BackfillRequest) (*BackfillResponse, error)
+	CancelBackfill(context.Context, *CancelBackfillRequest) (*CancelBackfillResponse, error)
+	AcquireLock(context.Context, *AcquireLockRequest) (*AcquireLockResponse, error)
+	ReleaseLock(context.Context, *ReleaseLockRequest) (*empty.Empty, error)
+	SetKV(context.Context, *SetKVRequest) (*empty.Empty, error)
+	DeleteKV(context.Context, *DeleteKVRequest) (*empty.Empty, error)
+	ReadIndex(context.Context, *empty.Empty) (*ReadIndexResponse, error)
+	SetSilence(context.Context, *SetSilenceRequest) (*SetSilenceResponse, error)
+	DeleteSilence(context.Context, *DeleteSilenceRequest) (*DeleteSilenceResponse, error)
+	Compliance(context.Context, *ComplianceRequest) (*ComplianceResponse, error)
+	SetCredential(context.Context, *SetCredentialRequest) (*SetCredentialResponse, error)
+	DeleteCredential(context.Context, *DeleteCredentialRequest) (*DeleteCredentialResponse, error)
+	SetSchedulerPause(context.Context, *SetSchedulerPauseRequest) (*SetSchedulerPauseResponse, error)
+	AnnotateExecution(context.Context, *AnnotateExecutionRequest) (*AnnotateExecutionResponse, error)
+}
+
+// UnimplementedDkronServer can be embedded to have forward compatible implementations.
+type UnimplementedDkronServer struct {
+}
+
+func (*UnimplementedDkronServer) GetJob(ctx context.Context, req *GetJobRequest) (*GetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (*UnimplementedDkronServer) ExecutionDone(ctx context.Context, req *ExecutionDoneRequest) (*ExecutionDoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecutionDone not implemented")
+}
+func (*UnimplementedDkronServer) Leave(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedDkronServer) SetJob(ctx context.Context, req *SetJobRequest) (*SetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetJob not implemented")
+}
+func (*UnimplementedDkronServer) DeleteJob(ctx context.Context, req *DeleteJobRequest) (*DeleteJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJob not implemented")
+}
+func (*UnimplementedDkronServer) RunJob(ctx context.Context, req *RunJobRequest) (*RunJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJob not implemented")
+}
+func (*UnimplementedDkronServer) ToggleJob(ctx context.Context, req *ToggleJobRequest) (*ToggleJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleJob not implemented")
+}
+func (*UnimplementedDkronServer) RaftGetConfiguration(ctx context.Context, req *empty.Empty) (*RaftGetConfigurationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RaftGetConfiguration not implemented")
+}
+func (*UnimplementedDkronServer) RaftRemovePeerByID(ctx context.Context, req *RaftRemovePeerByIDRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RaftRemovePeerByID not implemented")
+}
+func (*UnimplementedDkronServer) GetActiveExecutions(ctx context.Context, req *empty.Empty) (*GetActiveExecutionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveExecutions not implemented")
+}
+func (*UnimplementedDkronServer) SetExecution(ctx context.Context, req *Execution) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExecution not implemented")
+}
+func (*UnimplementedDkronServer) ResetJob(ctx context.Context, req *ResetJobRequest) (*ResetJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetJob not implemented")
+}
+func (*UnimplementedDkronServer) CheckStore(ctx context.Context, req *CheckStoreRequest) (*CheckStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckStore not implemented")
+}
+func (*UnimplementedDkronServer) SetReadOnly(ctx context.Context, req *SetReadOnlyRequest) (*SetReadOnlyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReadOnly not implemented")
+}
+func (*UnimplementedDkronServer) RestoreJob(ctx context.Context, req *RestoreJobRequest) (*RestoreJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreJob not implemented")
+}
+func (*UnimplementedDkronServer) SetMaintenanceWindow(ctx context.Context, req *SetMaintenanceWindowRequest) (*SetMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMaintenanceWindow not implemented")
+}
+func (*UnimplementedDkronServer) DeleteMaintenanceWindow(ctx context.Context, req *DeleteMaintenanceWindowRequest) (*DeleteMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMaintenanceWindow not implemented")
+}
+func (*UnimplementedDkronServer) Backfill(ctx context.Context, req *BackfillRequest) (*BackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backfill not implemented")
+}
+func (*UnimplementedDkronServer) CancelBackfill(ctx context.Context, req *CancelBackfillRequest) (*CancelBackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBackfill not implemented")
+}
+func (*UnimplementedDkronServer) AcquireLock(ctx context.Context, req *AcquireLockRequest) (*AcquireLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireLock not implemented")
+}
+func (*UnimplementedDkronServer) ReleaseLock(ctx context.Context, req *ReleaseLockRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLock not implemented")
+}
+func (*UnimplementedDkronServer) SetKV(ctx context.Context, req *SetKVRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKV not implemented")
+}
+func (*UnimplementedDkronServer) DeleteKV(ctx context.Context, req *DeleteKVRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteKV not implemented")
+}
+func (*UnimplementedDkronServer) ReadIndex(ctx context.Context, req *empty.Empty) (*ReadIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadIndex not implemented")
+}
+func (*UnimplementedDkronServer) SetSilence(ctx context.Context, req *SetSilenceRequest) (*SetSilenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSilence not implemented")
+}
+func (*UnimplementedDkronServer) DeleteSilence(ctx context.Context, req *DeleteSilenceRequest) (*DeleteSilenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSilence not implemented")
+}
+func (*UnimplementedDkronServer) Compliance(ctx context.Context, req *ComplianceRequest) (*ComplianceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compliance not implemented")
+}
+func (*UnimplementedDkronServer) SetCredential(ctx context.Context, req *SetCredentialRequest) (*SetCredentialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCredential not implemented")
+}
+func (*UnimplementedDkronServer) DeleteCredential(ctx context.Context, req *DeleteCredentialRequest) (*DeleteCredentialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCredential not implemented")
+}
+func (*UnimplementedDkronServer) SetSchedulerPause(ctx context.Context, req *SetSchedulerPauseRequest) (*SetSchedulerPauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSchedulerPause not implemented")
+}
+func (*UnimplementedDkronServer) AnnotateExecution(ctx context.Context, req *AnnotateExecutionRequest) (*AnnotateExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnotateExecution not implemented")
+}
+
+func RegisterDkronServer(s *grpc.Server, srv DkronServer) {
+	s.RegisterService(&_Dkron_serviceDesc, srv)
+}
+
+func _Dkron_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) ExecutionDone(ctx context.Context, in *ExecutionDoneRequest, opts ...grpc.CallOption) (*ExecutionDoneResponse, error) {
-	out := new(ExecutionDoneResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/ExecutionDone", in, out, opts...)
-	if err != nil {
+func _Dkron_ExecutionDone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecutionDoneRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).ExecutionDone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/ExecutionDone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).ExecutionDone(ctx, req.(*ExecutionDoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) Leave(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/types.Dkron/Leave", in, out, opts...)
-	if err != nil {
+func _Dkron_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).Leave(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) SetJob(ctx context.Context, in *SetJobRequest, opts ...grpc.CallOption) (*SetJobResponse, error) {
-	out := new(SetJobResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/SetJob", in, out, opts...)
-	if err != nil {
+func _Dkron_SetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetJobRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).SetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/SetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetJob(ctx, req.(*SetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) DeleteJob(ctx context.Context, in *DeleteJobRequest, opts ...grpc.CallOption) (*DeleteJobResponse, error) {
-	out := new(DeleteJobResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/DeleteJob", in, out, opts...)
-	if err != nil {
+func _Dkron_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/DeleteJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).DeleteJob(ctx, req.(*DeleteJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error) {
-	out := new(RunJobResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/RunJob", in, out, opts...)
-	if err != nil {
+func _Dkron_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).RunJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/RunJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).RunJob(ctx, req.(*RunJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_ToggleJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ToggleJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).ToggleJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/ToggleJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).ToggleJob(ctx, req.(*ToggleJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_RaftGetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).RaftGetConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/RaftGetConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).RaftGetConfiguration(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_RaftRemovePeerByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RaftRemovePeerByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).RaftRemovePeerByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/RaftRemovePeerByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).RaftRemovePeerByID(ctx, req.(*RaftRemovePeerByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_GetActiveExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).GetActiveExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/GetActiveExecutions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).GetActiveExecutions(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_SetExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Execution)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).SetExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/SetExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetExecution(ctx, req.(*Execution))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_ResetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).ResetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/ResetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).ResetJob(ctx, req.(*ResetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_CheckStore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).CheckStore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/CheckStore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).CheckStore(ctx, req.(*CheckStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dkron_SetReadOnly_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReadOnlyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).SetReadOnly(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/SetReadOnly",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetReadOnly(ctx, req.(*SetReadOnlyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) ToggleJob(ctx context.Context, in *ToggleJobRequest, opts ...grpc.CallOption) (*ToggleJobResponse, error) {
-	out := new(ToggleJobResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/ToggleJob", in, out, opts...)
-	if err != nil {
+func _Dkron_RestoreJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreJobRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).RestoreJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/RestoreJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).RestoreJob(ctx, req.(*RestoreJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) RaftGetConfiguration(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RaftGetConfigurationResponse, error) {
-	out := new(RaftGetConfigurationResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/RaftGetConfiguration", in, out, opts...)
-	if err != nil {
+func _Dkron_SetMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).SetMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/SetMaintenanceWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).SetMaintenanceWindow(ctx, req.(*SetMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) RaftRemovePeerByID(ctx context.Context, in *RaftRemovePeerByIDRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/types.Dkron/RaftRemovePeerByID", in, out, opts...)
-	if err != nil {
+func _Dkron_DeleteMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).DeleteMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/DeleteMaintenanceWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).DeleteMaintenanceWindow(ctx, req.(*DeleteMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) GetActiveExecutions(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*GetActiveExecutionsResponse, error) {
-	out := new(GetActiveExecutionsResponse)
-	err := c.cc.Invoke(ctx, "/types.Dkron/GetActiveExecutions", in, out, opts...)
-	if err != nil {
+func _Dkron_Backfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(DkronServer).Backfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/Backfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).Backfill(ctx, req.(*BackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *dkronClient) SetExecution(ctx context.Context, in *Execution, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/types.Dkron/SetExecution", in, out, opts...)
-	if err != nil {
+func _Dkron_CancelBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBackfillRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// DkronServer is the server API for Dkron service.
-type DkronServer interface {
-	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
-	ExecutionDone(context.Context, *ExecutionDoneRequest) (*ExecutionDoneResponse, error)
-	Leave(context.Context, *empty.Empty) (*empty.Empty, error)
-	SetJob(context.Context, *SetJobRequest) (*SetJobResponse, error)
-	DeleteJob(context.Context, *DeleteJobRequest) (*DeleteJobResponse, error)
-	RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error)
-	ToggleJob(context.Context, *ToggleJobRequest) (*ToggleJobResponse, error)
-	RaftGetConfiguration(context.Context, *empty.Empty) (*RaftGetConfigurationResponse, error)
-	RaftRemovePeerByID(context.Context, *RaftRemovePeerByIDRequest) (*empty.Empty, error)
-	GetActiveExecutions(context.Context, *empty.Empty) (*GetActiveExecutionsResponse, error)
-	SetExecution(context.Context, *Execution) (*empty.Empty, error)
-}
-
-// UnimplementedDkronServer can be embedded to have forward compatible implementations.
-type UnimplementedDkronServer struct {
-}
-
-func (*UnimplementedDkronServer) GetJob(ctx context.Context, req *GetJobRequest) (*GetJobResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
-}
-func (*UnimplementedDkronServer) ExecutionDone(ctx context.Context, req *ExecutionDoneRequest) (*ExecutionDoneResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecutionDone not implemented")
-}
-func (*UnimplementedDkronServer) Leave(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
-}
-func (*UnimplementedDkronServer) SetJob(ctx context.Context, req *SetJobRequest) (*SetJobResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetJob not implemented")
-}
-func (*UnimplementedDkronServer) DeleteJob(ctx context.Context, req *DeleteJobRequest) (*DeleteJobResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteJob not implemented")
-}
-func (*UnimplementedDkronServer) RunJob(ctx context.Context, req *RunJobRequest) (*RunJobResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RunJob not implemented")
-}
-func (*UnimplementedDkronServer) ToggleJob(ctx context.Context, req *ToggleJobRequest) (*ToggleJobResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ToggleJob not implemented")
-}
-func (*UnimplementedDkronServer) RaftGetConfiguration(ctx context.Context, req *empty.Empty) (*RaftGetConfigurationResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RaftGetConfiguration not implemented")
-}
-func (*UnimplementedDkronServer) RaftRemovePeerByID(ctx context.Context, req *RaftRemovePeerByIDRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RaftRemovePeerByID not implemented")
-}
-func (*UnimplementedDkronServer) GetActiveExecutions(ctx context.Context, req *empty.Empty) (*GetActiveExecutionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetActiveExecutions not implemented")
-}
-func (*UnimplementedDkronServer) SetExecution(ctx context.Context, req *Execution) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetExecution not implemented")
+	if interceptor == nil {
+		return srv.(DkronServer).CancelBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/CancelBackfill",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).CancelBackfill(ctx, req.(*CancelBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDkronServer(s *grpc.Server, srv DkronServer) {
-	s.RegisterService(&_Dkron_serviceDesc, srv)
+func _Dkron_AcquireLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DkronServer).AcquireLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Dkron/AcquireLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DkronServer).AcquireLock(ctx, req.(*AcquireLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetJobRequest)
+func _Dkron_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).GetJob(ctx, in)
+		return srv.(DkronServer).ReleaseLock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/GetJob",
+		FullMethod: "/types.Dkron/ReleaseLock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).GetJob(ctx, req.(*GetJobRequest))
+		return srv.(DkronServer).ReleaseLock(ctx, req.(*ReleaseLockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_ExecutionDone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExecutionDoneRequest)
+func _Dkron_SetKV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKVRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).ExecutionDone(ctx, in)
+		return srv.(DkronServer).SetKV(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/ExecutionDone",
+		FullMethod: "/types.Dkron/SetKV",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).ExecutionDone(ctx, req.(*ExecutionDoneRequest))
+		return srv.(DkronServer).SetKV(ctx, req.(*SetKVRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _Dkron_DeleteKV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteKVRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).Leave(ctx, in)
+		return srv.(DkronServer).DeleteKV(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/Leave",
+		FullMethod: "/types.Dkron/DeleteKV",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).Leave(ctx, req.(*empty.Empty))
+		return srv.(DkronServer).DeleteKV(ctx, req.(*DeleteKVRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_SetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetJobRequest)
+func _Dkron_ReadIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).SetJob(ctx, in)
+		return srv.(DkronServer).ReadIndex(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/SetJob",
+		FullMethod: "/types.Dkron/ReadIndex",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).SetJob(ctx, req.(*SetJobRequest))
+		return srv.(DkronServer).ReadIndex(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteJobRequest)
+func _Dkron_SetSilence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSilenceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).DeleteJob(ctx, in)
+		return srv.(DkronServer).SetSilence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/DeleteJob",
+		FullMethod: "/types.Dkron/SetSilence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).DeleteJob(ctx, req.(*DeleteJobRequest))
+		return srv.(DkronServer).SetSilence(ctx, req.(*SetSilenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RunJobRequest)
+func _Dkron_DeleteSilence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSilenceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).RunJob(ctx, in)
+		return srv.(DkronServer).DeleteSilence(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/RunJob",
+		FullMethod: "/types.Dkron/DeleteSilence",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).RunJob(ctx, req.(*RunJobRequest))
+		return srv.(DkronServer).DeleteSilence(ctx, req.(*DeleteSilenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_ToggleJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ToggleJobRequest)
+func _Dkron_Compliance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComplianceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).ToggleJob(ctx, in)
+		return srv.(DkronServer).Compliance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/ToggleJob",
+		FullMethod: "/types.Dkron/Compliance",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).ToggleJob(ctx, req.(*ToggleJobRequest))
+		return srv.(DkronServer).Compliance(ctx, req.(*ComplianceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_RaftGetConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _Dkron_SetCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCredentialRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).RaftGetConfiguration(ctx, in)
+		return srv.(DkronServer).SetCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/RaftGetConfiguration",
+		FullMethod: "/types.Dkron/SetCredential",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).RaftGetConfiguration(ctx, req.(*empty.Empty))
+		return srv.(DkronServer).SetCredential(ctx, req.(*SetCredentialRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_RaftRemovePeerByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RaftRemovePeerByIDRequest)
+func _Dkron_DeleteCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCredentialRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).RaftRemovePeerByID(ctx, in)
+		return srv.(DkronServer).DeleteCredential(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/RaftRemovePeerByID",
+		FullMethod: "/types.Dkron/DeleteCredential",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).RaftRemovePeerByID(ctx, req.(*RaftRemovePeerByIDRequest))
+		return srv.(DkronServer).DeleteCredential(ctx, req.(*DeleteCredentialRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_GetActiveExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _Dkron_SetSchedulerPause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSchedulerPauseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).GetActiveExecutions(ctx, in)
+		return srv.(DkronServer).SetSchedulerPause(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/GetActiveExecutions",
+		FullMethod: "/types.Dkron/SetSchedulerPause",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).GetActiveExecutions(ctx, req.(*empty.Empty))
+		return srv.(DkronServer).SetSchedulerPause(ctx, req.(*SetSchedulerPauseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dkron_SetExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Execution)
+func _Dkron_AnnotateExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateExecutionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DkronServer).SetExecution(ctx, in)
+		return srv.(DkronServer).AnnotateExecution(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/types.Dkron/SetExecution",
+		FullMethod: "/types.Dkron/AnnotateExecution",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DkronServer).SetExecution(ctx, req.(*Execution))
+		return srv.(DkronServer).AnnotateExecution(ctx, req.(*AnnotateExecutionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1785,6 +7014,86 @@ var _Dkron_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetExecution",
 			Handler:    _Dkron_SetExecution_Handler,
 		},
+		{
+			MethodName: "ResetJob",
+			Handler:    _Dkron_ResetJob_Handler,
+		},
+		{
+			MethodName: "CheckStore",
+			Handler:    _Dkron_CheckStore_Handler,
+		},
+		{
+			MethodName: "SetReadOnly",
+			Handler:    _Dkron_SetReadOnly_Handler,
+		},
+		{
+			MethodName: "RestoreJob",
+			Handler:    _Dkron_RestoreJob_Handler,
+		},
+		{
+			MethodName: "SetMaintenanceWindow",
+			Handler:    _Dkron_SetMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "DeleteMaintenanceWindow",
+			Handler:    _Dkron_DeleteMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "Backfill",
+			Handler:    _Dkron_Backfill_Handler,
+		},
+		{
+			MethodName: "CancelBackfill",
+			Handler:    _Dkron_CancelBackfill_Handler,
+		},
+		{
+			MethodName: "AcquireLock",
+			Handler:    _Dkron_AcquireLock_Handler,
+		},
+		{
+			MethodName: "ReleaseLock",
+			Handler:    _Dkron_ReleaseLock_Handler,
+		},
+		{
+			MethodName: "SetKV",
+			Handler:    _Dkron_SetKV_Handler,
+		},
+		{
+			MethodName: "DeleteKV",
+			Handler:    _Dkron_DeleteKV_Handler,
+		},
+		{
+			MethodName: "ReadIndex",
+			Handler:    _Dkron_ReadIndex_Handler,
+		},
+		{
+			MethodName: "SetSilence",
+			Handler:    _Dkron_SetSilence_Handler,
+		},
+		{
+			MethodName: "DeleteSilence",
+			Handler:    _Dkron_DeleteSilence_Handler,
+		},
+		{
+			MethodName: "Compliance",
+			Handler:    _Dkron_Compliance_Handler,
+		},
+		{
+			MethodName: "SetCredential",
+			Handler:    _Dkron_SetCredential_Handler,
+		},
+		{
+			MethodName: "DeleteCredential",
+			Handler:    _Dkron_DeleteCredential_Handler,
+		},
+		{
+			MethodName: "SetSchedulerPause",
+			Handler:    _Dkron_SetSchedulerPause_Handler,
+		},
+		{
+			MethodName: "AnnotateExecution",
+			Handler:    _Dkron_AnnotateExecution_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dkron.proto",
@@ -1795,6 +7104,7 @@ var _Dkron_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type AgentClient interface {
 	AgentRun(ctx context.Context, in *AgentRunRequest, opts ...grpc.CallOption) (Agent_AgentRunClient, error)
+	ReloadPlugin(ctx context.Context, in *ReloadPluginRequest, opts ...grpc.CallOption) (*ReloadPluginResponse, error)
 }
 
 type agentClient struct {
@@ -1837,9 +7147,19 @@ func (x *agentAgentRunClient) Recv() (*AgentRunStream, error) {
 	return m, nil
 }
 
+func (c *agentClient) ReloadPlugin(ctx context.Context, in *ReloadPluginRequest, opts ...grpc.CallOption) (*ReloadPluginResponse, error) {
+	out := new(ReloadPluginResponse)
+	err := c.cc.Invoke(ctx, "/types.Agent/ReloadPlugin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 type AgentServer interface {
 	AgentRun(*AgentRunRequest, Agent_AgentRunServer) error
+	ReloadPlugin(context.Context, *ReloadPluginRequest) (*ReloadPluginResponse, error)
 }
 
 // UnimplementedAgentServer can be embedded to have forward compatible implementations.
@@ -1849,6 +7169,9 @@ type UnimplementedAgentServer struct {
 func (*UnimplementedAgentServer) AgentRun(req *AgentRunRequest, srv Agent_AgentRunServer) error {
 	return status.Errorf(codes.Unimplemented, "method AgentRun not implemented")
 }
+func (*UnimplementedAgentServer) ReloadPlugin(ctx context.Context, req *ReloadPluginRequest) (*ReloadPluginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadPlugin not implemented")
+}
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
 	s.RegisterService(&_Agent_serviceDesc, srv)
@@ -1875,10 +7198,33 @@ func (x *agentAgentRunServer) Send(m *AgentRunStream) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Agent_ReloadPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ReloadPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.Agent/ReloadPlugin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ReloadPlugin(ctx, req.(*ReloadPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.Agent",
 	HandlerType: (*AgentServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReloadPlugin",
+			Handler:    _Agent_ReloadPlugin_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "AgentRun",
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -25,12 +26,20 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type ExecuteRequest struct {
-	JobName              string            `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
-	Config               map[string]string `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	StatusServer         uint32            `protobuf:"varint,3,opt,name=status_server,json=statusServer,proto3" json:"status_server,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	JobName               string               `protobuf:"bytes,1,opt,name=job_name,json=jobName,proto3" json:"job_name,omitempty"`
+	Config                map[string]string    `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	StatusServer          uint32               `protobuf:"varint,3,opt,name=status_server,json=statusServer,proto3" json:"status_server,omitempty"`
+	ArtifactsDir          string               `protobuf:"bytes,4,opt,name=artifacts_dir,json=artifactsDir,proto3" json:"artifacts_dir,omitempty"`
+	ScheduledTime         *timestamp.Timestamp `protobuf:"bytes,5,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
+	PreviousScheduledTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=previous_scheduled_time,json=previousScheduledTime,proto3" json:"previous_scheduled_time,omitempty"`
+	WorkspaceDir          string               `protobuf:"bytes,7,opt,name=workspace_dir,json=workspaceDir,proto3" json:"workspace_dir,omitempty"`
+	Namespace             string               `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	KvToken               string               `protobuf:"bytes,9,opt,name=kv_token,json=kvToken,proto3" json:"kv_token,omitempty"`
+	Params                map[string]string    `protobuf:"bytes,10,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Secrets               map[string]string    `protobuf:"bytes,11,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
+	XXX_unrecognized      []byte               `json:"-"`
+	XXX_sizecache         int32                `json:"-"`
 }
 
 func (m *ExecuteRequest) Reset()         { *m = ExecuteRequest{} }
@@ -79,12 +88,70 @@ func (m *ExecuteRequest) GetStatusServer() uint32 {
 	return 0
 }
 
+func (m *ExecuteRequest) GetArtifactsDir() string {
+	if m != nil {
+		return m.ArtifactsDir
+	}
+	return ""
+}
+
+func (m *ExecuteRequest) GetScheduledTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScheduledTime
+	}
+	return nil
+}
+
+func (m *ExecuteRequest) GetPreviousScheduledTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.PreviousScheduledTime
+	}
+	return nil
+}
+
+func (m *ExecuteRequest) GetWorkspaceDir() string {
+	if m != nil {
+		return m.WorkspaceDir
+	}
+	return ""
+}
+
+func (m *ExecuteRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ExecuteRequest) GetKvToken() string {
+	if m != nil {
+		return m.KvToken
+	}
+	return ""
+}
+
+func (m *ExecuteRequest) GetParams() map[string]string {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+func (m *ExecuteRequest) GetSecrets() map[string]string {
+	if m != nil {
+		return m.Secrets
+	}
+	return nil
+}
+
 type ExecuteResponse struct {
-	Output               []byte   `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
-	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Output               []byte             `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	Error                string             `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Artifacts            []string           `protobuf:"bytes,3,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Metrics              map[string]float64 `protobuf:"bytes,4,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *ExecuteResponse) Reset()         { *m = ExecuteResponse{} }
@@ -126,6 +193,20 @@ func (m *ExecuteResponse) GetError() string {
 	return ""
 }
 
+func (m *ExecuteResponse) GetArtifacts() []string {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *ExecuteResponse) GetMetrics() map[string]float64 {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
 type StatusUpdateRequest struct {
 	Output               []byte   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
 	Error                bool     `protobuf:"varint,3,opt,name=error,proto3" json:"error,omitempty"`
@@ -215,7 +296,10 @@ func (m *StatusUpdateResponse) GetR() int64 {
 func init() {
 	proto.RegisterType((*ExecuteRequest)(nil), "types.ExecuteRequest")
 	proto.RegisterMapType((map[string]string)(nil), "types.ExecuteRequest.ConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.ExecuteRequest.ParamsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.ExecuteRequest.SecretsEntry")
 	proto.RegisterType((*ExecuteResponse)(nil), "types.ExecuteResponse")
+	proto.RegisterMapType((map[string]float64)(nil), "types.ExecuteResponse.MetricsEntry")
 	proto.RegisterType((*StatusUpdateRequest)(nil), "types.StatusUpdateRequest")
 	proto.RegisterType((*StatusUpdateResponse)(nil), "types.StatusUpdateResponse")
 }
@@ -225,27 +309,42 @@ func init() {
 }
 
 var fileDescriptor_12d1cdcda51e000f = []byte{
-	// 318 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4f, 0x4f, 0x32, 0x31,
-	0x10, 0xc6, 0xd3, 0xdd, 0xb0, 0xf0, 0x0e, 0x0b, 0xaf, 0xa9, 0x48, 0xd6, 0xf5, 0x82, 0xe8, 0x81,
-	0xd3, 0x1e, 0xf0, 0x02, 0x5e, 0x8c, 0x41, 0x12, 0x4f, 0x26, 0x96, 0x78, 0x26, 0x0b, 0x8e, 0x46,
-	0x84, 0x6d, 0xed, 0x1f, 0x22, 0x5f, 0xd0, 0xcf, 0x65, 0x68, 0x8b, 0x88, 0xd9, 0x5b, 0xe7, 0x99,
-	0xce, 0x6f, 0x9e, 0x99, 0x16, 0x9a, 0xf8, 0x89, 0x73, 0xa3, 0xb9, 0xcc, 0x84, 0xe4, 0x9a, 0xd3,
-	0x8a, 0xde, 0x08, 0x54, 0xdd, 0x2f, 0x02, 0xcd, 0xb1, 0xcd, 0x20, 0xc3, 0x0f, 0x83, 0x4a, 0xd3,
-	0x53, 0xa8, 0x2d, 0xf8, 0x6c, 0x5a, 0xe4, 0x2b, 0x4c, 0x48, 0x87, 0xf4, 0xfe, 0xb1, 0xea, 0x82,
-	0xcf, 0x1e, 0xf2, 0x15, 0xd2, 0x21, 0x44, 0x73, 0x5e, 0xbc, 0xbc, 0xbd, 0x26, 0x41, 0x27, 0xec,
-	0xd5, 0xfb, 0xe7, 0x99, 0xa5, 0x64, 0x87, 0x84, 0x6c, 0x64, 0xef, 0x8c, 0x0b, 0x2d, 0x37, 0xcc,
-	0x17, 0xd0, 0x0b, 0x68, 0x28, 0x9d, 0x6b, 0xa3, 0xa6, 0x0a, 0xe5, 0x1a, 0x65, 0x12, 0x76, 0x48,
-	0xaf, 0xc1, 0x62, 0x27, 0x4e, 0xac, 0x96, 0x0e, 0xa1, 0xfe, 0xab, 0x96, 0x1e, 0x41, 0xf8, 0x8e,
-	0x1b, 0x6f, 0x62, 0x7b, 0xa4, 0x2d, 0xa8, 0xac, 0xf3, 0xa5, 0xc1, 0x24, 0xb0, 0x9a, 0x0b, 0xae,
-	0x83, 0x01, 0xe9, 0xde, 0xc0, 0xff, 0x1f, 0x17, 0x4a, 0xf0, 0x42, 0x21, 0x6d, 0x43, 0xc4, 0x8d,
-	0x16, 0x46, 0x5b, 0x42, 0xcc, 0x7c, 0xb4, 0x85, 0xa0, 0x94, 0x5c, 0xee, 0x20, 0x36, 0xe8, 0x8e,
-	0xe0, 0x78, 0x62, 0xbd, 0x3c, 0x89, 0xe7, 0x7c, 0xbf, 0x8d, 0x3d, 0x24, 0x28, 0x87, 0x6c, 0xe7,
-	0xa8, 0xed, 0x20, 0x97, 0xd0, 0x3a, 0x84, 0x78, 0x2b, 0x31, 0x10, 0x69, 0x5d, 0x84, 0x8c, 0xc8,
-	0xfe, 0x1d, 0xd4, 0xc6, 0xfe, 0x35, 0xe8, 0x00, 0xaa, 0xde, 0x37, 0x3d, 0x29, 0xdd, 0x66, 0xda,
-	0xfe, 0x2b, 0x3b, 0x66, 0xff, 0x11, 0x62, 0xd7, 0xeb, 0x1e, 0x97, 0x02, 0x25, 0xbd, 0x85, 0xc8,
-	0x75, 0xa5, 0xa9, 0xaf, 0x28, 0x99, 0x27, 0x3d, 0x2b, 0xcd, 0x39, 0xe4, 0x2c, 0xb2, 0x7f, 0xe3,
-	0xea, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x5a, 0x84, 0x11, 0xe7, 0x2d, 0x02, 0x00, 0x00,
+	// 559 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4f, 0x6f, 0xd3, 0x4e,
+	0x10, 0x95, 0xe3, 0xe6, 0xdf, 0xc4, 0xc9, 0xef, 0xa7, 0xa5, 0x2d, 0x8b, 0x41, 0x22, 0x24, 0x1c,
+	0x72, 0x72, 0xa5, 0x70, 0x69, 0x2b, 0x38, 0xa0, 0x36, 0x12, 0x17, 0x10, 0x38, 0xe5, 0x1c, 0x6d,
+	0x9c, 0x49, 0x70, 0x13, 0x67, 0xcd, 0xee, 0x3a, 0x90, 0x6f, 0xc9, 0x91, 0x8f, 0x83, 0x76, 0xd7,
+	0x71, 0x12, 0x64, 0x51, 0xf5, 0xe6, 0x99, 0x9d, 0xf7, 0x66, 0xde, 0xdb, 0x1d, 0x43, 0x07, 0x7f,
+	0x62, 0x94, 0x29, 0x2e, 0x82, 0x54, 0x70, 0xc5, 0x49, 0x55, 0x6d, 0x53, 0x94, 0xfe, 0xcb, 0x05,
+	0xe7, 0x8b, 0x15, 0x5e, 0x98, 0xe4, 0x34, 0x9b, 0x5f, 0xa8, 0x38, 0x41, 0xa9, 0x58, 0x92, 0xda,
+	0xba, 0xde, 0xaf, 0x2a, 0x74, 0x46, 0x06, 0x8a, 0x21, 0x7e, 0xcf, 0x50, 0x2a, 0xf2, 0x0c, 0x1a,
+	0xf7, 0x7c, 0x3a, 0x59, 0xb3, 0x04, 0xa9, 0xd3, 0x75, 0x06, 0xcd, 0xb0, 0x7e, 0xcf, 0xa7, 0x9f,
+	0x58, 0x82, 0xe4, 0x0a, 0x6a, 0x11, 0x5f, 0xcf, 0xe3, 0x05, 0xad, 0x74, 0xdd, 0x41, 0x6b, 0xf8,
+	0x2a, 0x30, 0x6d, 0x82, 0x63, 0x86, 0xe0, 0xc6, 0xd4, 0x8c, 0xd6, 0x4a, 0x6c, 0xc3, 0x1c, 0x40,
+	0xfa, 0xd0, 0x96, 0x8a, 0xa9, 0x4c, 0x4e, 0x24, 0x8a, 0x0d, 0x0a, 0xea, 0x76, 0x9d, 0x41, 0x3b,
+	0xf4, 0x6c, 0x72, 0x6c, 0x72, 0xba, 0x88, 0x09, 0x15, 0xcf, 0x59, 0xa4, 0xe4, 0x64, 0x16, 0x0b,
+	0x7a, 0x62, 0xfa, 0x7b, 0x45, 0xf2, 0x36, 0x16, 0xe4, 0x3d, 0x74, 0x64, 0xf4, 0x0d, 0x67, 0xd9,
+	0x0a, 0x67, 0x13, 0xad, 0x87, 0x56, 0xbb, 0xce, 0xa0, 0x35, 0xf4, 0x03, 0x2b, 0x36, 0xd8, 0x89,
+	0x0d, 0xee, 0x76, 0x62, 0xc3, 0x76, 0x81, 0xd0, 0x39, 0x12, 0xc2, 0xd3, 0x54, 0xe0, 0x26, 0xe6,
+	0x7a, 0x9c, 0x63, 0xae, 0xda, 0x83, 0x5c, 0x67, 0x3b, 0xe8, 0xf8, 0x88, 0xb3, 0x0f, 0xed, 0x1f,
+	0x5c, 0x2c, 0x65, 0xca, 0x22, 0x34, 0xb3, 0xd7, 0xed, 0xec, 0x45, 0x52, 0xcf, 0xfe, 0x02, 0x9a,
+	0xda, 0x57, 0x13, 0xd3, 0x86, 0x29, 0xd8, 0x27, 0xb4, 0xf3, 0xcb, 0xcd, 0x44, 0xf1, 0x25, 0xae,
+	0x69, 0xd3, 0x3a, 0xbf, 0xdc, 0xdc, 0xe9, 0x50, 0x3b, 0x9f, 0x32, 0xc1, 0x12, 0x49, 0xe1, 0x5f,
+	0xce, 0x7f, 0x36, 0x35, 0xb9, 0xf3, 0x16, 0x40, 0xde, 0x42, 0x5d, 0x62, 0x24, 0x50, 0x49, 0xda,
+	0x32, 0xd8, 0x5e, 0x39, 0x76, 0x6c, 0x8b, 0x2c, 0x78, 0x07, 0xf1, 0xaf, 0xa0, 0x75, 0x70, 0x9d,
+	0xe4, 0x7f, 0x70, 0x97, 0xb8, 0xcd, 0xdf, 0x85, 0xfe, 0x24, 0xa7, 0x50, 0xdd, 0xb0, 0x55, 0x86,
+	0xb4, 0x62, 0x72, 0x36, 0xb8, 0xae, 0x5c, 0x3a, 0x1a, 0x7a, 0x30, 0xcf, 0xa3, 0xa0, 0xd7, 0xe0,
+	0x1d, 0x8e, 0xf3, 0x18, 0x6c, 0xef, 0xb7, 0x03, 0xff, 0x15, 0xd2, 0x64, 0xca, 0xd7, 0x12, 0xc9,
+	0x39, 0xd4, 0x78, 0xa6, 0xd2, 0x4c, 0x19, 0x0a, 0x2f, 0xcc, 0x23, 0xcd, 0x82, 0x42, 0x70, 0xb1,
+	0x63, 0x31, 0x81, 0xbe, 0xa5, 0xe2, 0xc5, 0x51, 0xb7, 0xeb, 0xea, 0x5b, 0x2a, 0x12, 0xe4, 0x1d,
+	0xd4, 0x13, 0x54, 0x22, 0x8e, 0x24, 0x3d, 0x31, 0x7e, 0xf6, 0xff, 0xf6, 0xd3, 0x36, 0x0d, 0x3e,
+	0xda, 0xaa, 0xdc, 0xd0, 0x1c, 0xa3, 0xa5, 0x1d, 0x1e, 0x3c, 0x24, 0xcd, 0x39, 0x94, 0x76, 0x03,
+	0x4f, 0xc6, 0x66, 0x5f, 0xbe, 0xa6, 0x33, 0xb6, 0xdf, 0xd8, 0xbd, 0xba, 0x4a, 0xb9, 0x3a, 0xbd,
+	0x6b, 0x8d, 0x5c, 0x5d, 0xef, 0x35, 0x9c, 0x1e, 0x93, 0xe4, 0x1e, 0x79, 0xe0, 0x08, 0x33, 0x86,
+	0x1b, 0x3a, 0x62, 0x78, 0x0b, 0x8d, 0x51, 0xfe, 0x4b, 0x21, 0x97, 0x50, 0xb7, 0xdf, 0x48, 0xce,
+	0x4a, 0xdf, 0x8e, 0x7f, 0x5e, 0x6e, 0xc1, 0xf0, 0x0b, 0x78, 0xb6, 0xd7, 0x07, 0x5c, 0xa5, 0xa8,
+	0x77, 0xb7, 0x66, 0xbb, 0x12, 0x3f, 0x47, 0x94, 0xe8, 0xf1, 0x9f, 0x97, 0x9e, 0x59, 0xca, 0x69,
+	0xcd, 0xac, 0xe4, 0x9b, 0x3f, 0x03, 0x00, 0x68, 0x85, 0xc8, 0xef, 0xf2, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.